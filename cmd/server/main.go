@@ -2,15 +2,20 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log/slog"
 	"os"
 
 	"github.com/agentregistry-dev/agentregistry/pkg/registry"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
 )
 
 func main() {
+	storage := flag.String("storage", "", `storage backend: "postgres", or "sqlite:///path/to/registry.db" for an embedded database (default: $AGENT_REGISTRY_STORAGE, else "postgres")`)
+	flag.Parse()
+
 	ctx := context.Background()
-	if err := registry.App(ctx); err != nil {
+	if err := registry.App(ctx, types.AppOptions{Storage: *storage}); err != nil {
 		slog.Error("failed to start registry", "error", err)
 		os.Exit(1)
 	}
@@ -13,12 +13,15 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 )
 
 var (
-	// ErrUnsupportedHost is returned for a non-GitHub/non-GitLab host. It is a
-	// permanent condition: callers can errors.Is it to avoid retrying hosts that
-	// are not supported.
+	// ErrUnsupportedHost is returned for a remote whose host is not on the
+	// caller's allowlist (see WithAllowedHosts) or whose URL scheme git cannot
+	// be pointed at safely. It is a permanent condition: callers can errors.Is
+	// it to avoid retrying remotes that are not permitted.
 	ErrUnsupportedHost = errors.New("unsupported git host")
 	// ErrRefNotFound is returned when a ref resolves to no commit on the remote
 	// (deleted branch/tag, typo, or a short/non-existent SHA). Terminal:
@@ -26,23 +29,181 @@ var (
 	ErrRefNotFound = errors.New("git ref not found")
 )
 
-// ParseGitURL parses a Git web URL into its clone URL, branch, and subdirectory
-// path. It supports GitHub URLs and GitLab-style URLs, including self-hosted
-// GitLab instances that use /-/tree/ and /-/blob/ routes.
+// Option configures which remotes ResolveRefContext and CloneAndCopyContext
+// will contact.
+type Option func(*options)
+
+type options struct {
+	allowedHosts []string
+	allowFile    bool
+}
+
+// DefaultAllowedHosts are the hosts remotes may use when no allowlist is
+// given.
+var DefaultAllowedHosts = []string{"github.com"}
+
+// WithAllowedHosts restricts remotes to the given hosts. Entries match the
+// remote's hostname case-insensitively (ports are ignored); an entry of the
+// form "*.example.com" matches any subdomain of example.com, and "*" matches
+// any host. Entries allow https and ssh remotes; plaintext http:// and
+// git:// remotes are only allowed by an entry naming the scheme, such as
+// "http://gitea.lan". An empty list means DefaultAllowedHosts.
+func WithAllowedHosts(hosts []string) Option {
+	return func(o *options) { o.allowedHosts = hosts }
+}
+
+// WithFileRemotes permits file:// remotes (local repositories). They are off by
+// default because a server resolving user-supplied URLs must never read its own
+// filesystem; tests opt in.
+func WithFileRemotes() Option {
+	return func(o *options) { o.allowFile = true }
+}
+
+// IsSCPLikeURL reports whether rawURL uses git's scp-style remote syntax
+// ([user@]host:path, e.g. git@gitlab.example.com:group/repo.git).
+func IsSCPLikeURL(rawURL string) bool {
+	_, _, ok := v1alpha1.SplitSCPLikeRemote(rawURL)
+	return ok
+}
+
+// CheckRemote verifies that git may contact rawURL under opts: the scheme must
+// be https, ssh, http or git (or file with WithFileRemotes) and the host must
+// be on the allowlist, by an entry naming the scheme for http and git.
+// Violations wrap ErrUnsupportedHost.
+func CheckRemote(rawURL string, opts ...Option) error {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	scheme, host, err := remoteHost(rawURL, o.allowFile)
+	if err != nil {
+		return err
+	}
+	if host == "" {
+		return nil
+	}
+	allowed := o.allowedHosts
+	if len(allowed) == 0 {
+		allowed = DefaultAllowedHosts
+	}
+	if hostAllowed(scheme, host, allowed) {
+		return nil
+	}
+	if plaintextScheme(scheme) && hostAllowed("https", host, allowed) {
+		return fmt.Errorf("%w: plaintext %s:// remotes to %q must be allowed explicitly with a %q entry",
+			ErrUnsupportedHost, scheme, host, scheme+"://"+host)
+	}
+	return fmt.Errorf("%w: %q is not in the allowed git hosts", ErrUnsupportedHost, host)
+}
+
+// remoteHost returns the scheme and hostname (without port) of a git remote,
+// or "" for a permitted file:// remote. scp-style remotes report "ssh".
+func remoteHost(rawURL string, allowFile bool) (string, string, error) {
+	if host, _, ok := v1alpha1.SplitSCPLikeRemote(rawURL); ok {
+		// A host beginning with "-" would reach ssh as an option.
+		if strings.HasPrefix(host, "-") {
+			return "", "", fmt.Errorf("%w: invalid host %q", ErrUnsupportedHost, host)
+		}
+		return "ssh", host, nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: invalid URL: %v", ErrUnsupportedHost, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "ssh", "git", "git+ssh":
+		if u.Hostname() == "" {
+			return "", "", fmt.Errorf("%w: %q has no host", ErrUnsupportedHost, rawURL)
+		}
+		return u.Scheme, u.Hostname(), nil
+	case "file":
+		if !allowFile {
+			return "", "", fmt.Errorf("%w: file:// remotes are not permitted", ErrUnsupportedHost)
+		}
+		return "", "", nil
+	default:
+		return "", "", fmt.Errorf("%w: unsupported scheme %q", ErrUnsupportedHost, u.Scheme)
+	}
+}
+
+// plaintextScheme reports whether git talks to remotes of scheme without
+// transport security.
+func plaintextScheme(scheme string) bool {
+	return scheme == "http" || scheme == "git"
+}
+
+// hostAllowed reports whether an entry of allowed admits host over scheme.
+// Entries without a scheme admit only the secure schemes.
+func hostAllowed(scheme, host string, allowed []string) bool {
+	host = strings.ToLower(host)
+	for _, entry := range allowed {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		entryScheme, entryHost, ok := strings.Cut(entry, "://")
+		if ok {
+			if entryScheme != scheme {
+				continue
+			}
+		} else {
+			entryHost = entry
+			if plaintextScheme(scheme) {
+				continue
+			}
+		}
+		if entryHost == "*" {
+			return true
+		}
+		if suffix, ok := strings.CutPrefix(entryHost, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+			continue
+		}
+		if host == entryHost {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseGitURL parses a Git URL into its clone URL, branch, and subdirectory
+// path. Web URLs (http/https) are understood for GitHub (and GitHub
+// Enterprise-style /tree/ and /blob/ routes), GitLab (including self-hosted
+// instances that use /-/tree/ and /-/blob/ routes), and Gitea/Forgejo
+// (/src/branch/, /src/tag/, /src/commit/); any other http(s) path is treated
+// as the repository path itself. ssh://, git://, file:// and scp-style
+// (git@host:path) remotes carry no browse route and are returned unchanged as
+// the clone URL.
 // Branch names containing slashes (e.g. feature/my-branch) are supported when
 // encoded as %2F in the URL. The raw (escaped) path is used for splitting so
 // the encoded branch segment is preserved, then unescaped for the return value.
 func ParseGitURL(rawURL string) (cloneURL, branch, subPath string, err error) {
+	if IsSCPLikeURL(rawURL) {
+		return rawURL, "", "", nil
+	}
 	u, err := url.Parse(rawURL)
 	if err != nil {
 		return "", "", "", fmt.Errorf("invalid URL: %w", err)
 	}
 
-	if u.Host == "" {
+	switch u.Scheme {
+	case "http", "https":
+	case "ssh", "git", "git+ssh":
+		if u.Host == "" {
+			return "", "", "", fmt.Errorf("invalid Git URL: expected absolute URL")
+		}
+		return rawURL, "", "", nil
+	case "file":
+		if u.Path == "" {
+			return "", "", "", fmt.Errorf("invalid Git URL: file URL has no path")
+		}
+		return rawURL, "", "", nil
+	case "":
 		return "", "", "", fmt.Errorf("invalid Git URL: expected absolute URL")
+	default:
+		return "", "", "", fmt.Errorf("%w: unsupported scheme %q", ErrUnsupportedHost, u.Scheme)
 	}
-	if u.Scheme != "http" && u.Scheme != "https" {
-		return "", "", "", fmt.Errorf("invalid Git URL: unsupported scheme %q", u.Scheme)
+	if u.Host == "" {
+		return "", "", "", fmt.Errorf("invalid Git URL: expected absolute URL")
 	}
 
 	// Use EscapedPath so that percent-encoded segments (e.g. %2F in branch
@@ -62,11 +223,19 @@ func ParseGitURL(rawURL string) (cloneURL, branch, subPath string, err error) {
 	if strings.Contains(strings.ToLower(u.Host), "gitlab") {
 		return parseGitLabRootURL(u, parts)
 	}
-	if u.Host != "github.com" {
-		return "", "", "", fmt.Errorf("%w: %q", ErrUnsupportedHost, u.Host)
+	if u.Host == "github.com" {
+		return parseGitHubStyleURL(u, parts)
 	}
-
-	return parseGitHubStyleURL(u, parts)
+	// Generic host: honor a GitHub-style browse route (GitHub Enterprise and
+	// many mirrors) or a Gitea/Forgejo one, and otherwise treat the whole path
+	// as the repository (nested groups, Bitbucket Server /scm/ paths, ...).
+	if len(parts) >= 4 && (parts[2] == "tree" || parts[2] == "blob") {
+		return parseGitHubStyleURL(u, parts)
+	}
+	if len(parts) >= 5 && parts[2] == "src" && (parts[3] == "branch" || parts[3] == "tag" || parts[3] == "commit") {
+		return parseGiteaStyleURL(u, parts)
+	}
+	return parseGitLabRootURL(u, parts)
 }
 
 func parseGitHubStyleURL(u *url.URL, parts []string) (cloneURL, branch, subPath string, err error) {
@@ -88,6 +257,16 @@ func parseGitHubStyleURL(u *url.URL, parts []string) (cloneURL, branch, subPath
 	return cloneURL, branch, subPath, nil
 }
 
+func parseGiteaStyleURL(u *url.URL, parts []string) (cloneURL, branch, subPath string, err error) {
+	repo := strings.TrimSuffix(parts[1], ".git")
+	cloneURL = fmt.Sprintf("%s://%s/%s/%s.git", u.Scheme, u.Host, parts[0], repo)
+	branch, _ = url.PathUnescape(parts[4])
+	if len(parts) > 5 {
+		subPath, _ = url.PathUnescape(strings.Join(parts[5:], "/"))
+	}
+	return cloneURL, branch, subPath, nil
+}
+
 func parseGitLabRootURL(u *url.URL, parts []string) (cloneURL, branch, subPath string, err error) {
 	repoParts := append([]string(nil), parts...)
 	repoParts[len(repoParts)-1] = strings.TrimSuffix(repoParts[len(repoParts)-1], ".git")
@@ -124,12 +303,17 @@ func parseGitLabStyleURL(u *url.URL, parts []string, marker int) (cloneURL, bran
 //
 // Every git invocation runs under ctx, so a caller can bound
 // clone/fetch/checkout time (and disk/CPU runaway) by passing a
-// context.WithTimeout. ctx cancellation kills the git child process.
-func CloneAndCopyContext(ctx context.Context, repoURL, branch, commit, subPath, targetDir string, verbose bool) error {
+// context.WithTimeout. ctx cancellation kills the git child process. opts
+// restrict which remotes may be contacted (see CheckRemote); a disallowed
+// remote returns ErrUnsupportedHost before git runs.
+func CloneAndCopyContext(ctx context.Context, repoURL, branch, commit, subPath, targetDir string, verbose bool, opts ...Option) error {
 	cloneURL, urlBranch, urlSubPath, err := ParseGitURL(repoURL)
 	if err != nil {
 		return fmt.Errorf("parse Git URL: %w", err)
 	}
+	if err := CheckRemote(cloneURL, opts...); err != nil {
+		return err
+	}
 	if branch == "" {
 		branch = urlBranch
 	}
@@ -155,7 +339,7 @@ func CloneAndCopyContext(ctx context.Context, repoURL, branch, commit, subPath,
 	if branch != "" {
 		cloneArgs = append(cloneArgs, "--branch", branch)
 	}
-	cloneArgs = append(cloneArgs, cloneURL, tempDir)
+	cloneArgs = append(cloneArgs, "--", cloneURL, tempDir)
 
 	gitCmd := exec.CommandContext(ctx, "git", cloneArgs...)
 	if verbose {
@@ -218,15 +402,19 @@ func isFullCommitSHA(s string) bool {
 // full 40-char commit SHA is returned unchanged (lowercased). An empty ref
 // (after the URL-embedded branch is considered) resolves the remote's default
 // branch (HEAD). ctx bounds the ls-remote call. A ref that resolves to no
-// commit returns ErrRefNotFound (terminal).
-func ResolveRefContext(ctx context.Context, repoURL, ref string) (string, error) {
-	if isFullCommitSHA(ref) {
-		return strings.ToLower(ref), nil
-	}
+// commit returns ErrRefNotFound (terminal); a remote disallowed by opts returns
+// ErrUnsupportedHost (terminal).
+func ResolveRefContext(ctx context.Context, repoURL, ref string, opts ...Option) (string, error) {
 	cloneURL, urlBranch, _, err := ParseGitURL(repoURL)
 	if err != nil {
 		return "", fmt.Errorf("parse Git URL: %w", err)
 	}
+	if err := CheckRemote(cloneURL, opts...); err != nil {
+		return "", err
+	}
+	if isFullCommitSHA(ref) {
+		return strings.ToLower(ref), nil
+	}
 	if ref == "" {
 		ref = urlBranch
 	}
@@ -237,7 +425,9 @@ func ResolveRefContext(ctx context.Context, repoURL, ref string) (string, error)
 	if err := safeGitRef(lsRef); err != nil {
 		return "", err
 	}
-	out, err := exec.CommandContext(ctx, "git", "ls-remote", cloneURL, lsRef).Output()
+	// Query the peeled "<ref>^{}" too: ls-remote patterns match the ref name's
+	// tail, so without it an annotated tag yields only the tag object's SHA.
+	out, err := exec.CommandContext(ctx, "git", "ls-remote", "--", cloneURL, lsRef, lsRef+"^{}").Output()
 	if err != nil {
 		return "", fmt.Errorf("git ls-remote %s %q: %w", cloneURL, lsRef, err)
 	}
@@ -1,7 +1,10 @@
 package gitutil
 
 import (
+	"context"
+	"errors"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -134,6 +137,40 @@ func TestParseGitURL(t *testing.T) {
 			wantRef:  "feature/gitlab-support",
 			wantPath: "cursor/skill",
 		},
+		{
+			name:     "gitea tree URL",
+			rawURL:   "https://gitea.example.com/org/skills/src/branch/main/cursor/skill",
+			wantURL:  "https://gitea.example.com/org/skills.git",
+			wantRef:  "main",
+			wantPath: "cursor/skill",
+		},
+		{
+			name:     "github enterprise tree URL",
+			rawURL:   "https://git.example.com/org/repo/tree/release/sub",
+			wantURL:  "https://git.example.com/org/repo.git",
+			wantRef:  "release",
+			wantPath: "sub",
+		},
+		{
+			name:    "generic host nested repo path",
+			rawURL:  "https://git.example.com/scm/platform/skills.git",
+			wantURL: "https://git.example.com/scm/platform/skills.git",
+		},
+		{
+			name:    "ssh URL returned unchanged",
+			rawURL:  "ssh://git@gitlab.internal:2222/group/repo.git",
+			wantURL: "ssh://git@gitlab.internal:2222/group/repo.git",
+		},
+		{
+			name:    "scp-style remote returned unchanged",
+			rawURL:  "git@gitlab.internal:group/sub/repo.git",
+			wantURL: "git@gitlab.internal:group/sub/repo.git",
+		},
+		{
+			name:    "file URL returned unchanged",
+			rawURL:  "file:///srv/git/repo.git",
+			wantURL: "file:///srv/git/repo.git",
+		},
 		{
 			name:    "missing repo in path",
 			rawURL:  "https://github.com/owner",
@@ -151,7 +188,7 @@ func TestParseGitURL(t *testing.T) {
 		},
 		{
 			name:    "unsupported scheme",
-			rawURL:  "ssh://github.com/owner/repo",
+			rawURL:  "ftp://github.com/owner/repo",
 			wantErr: true,
 		},
 	}
@@ -604,6 +641,150 @@ func TestCopyFile(t *testing.T) {
 	})
 }
 
+func TestCheckRemote(t *testing.T) {
+	internal := WithAllowedHosts([]string{"gitlab.internal", "*.corp.example.com", "http://gitea.lan"})
+	anyHost := WithAllowedHosts([]string{"*"})
+	tests := []struct {
+		name    string
+		url     string
+		opts    []Option
+		wantErr bool
+	}{
+		{name: "github.com when allowlist empty", url: "https://github.com/o/r.git"},
+		{name: "other hosts refused when allowlist empty", url: "https://gitea.example.org/o/r.git", wantErr: true},
+		{name: "star allows any host", url: "git@gitea.example.org:o/r.git", opts: []Option{anyHost}},
+		{name: "plaintext http needs a scheme entry", url: "http://gitlab.internal/g/r.git", opts: []Option{internal}, wantErr: true},
+		{name: "plaintext git needs a scheme entry", url: "git://gitea.example.org/o/r.git", opts: []Option{anyHost}, wantErr: true},
+		{name: "plaintext http allowed by scheme entry", url: "http://gitea.lan/g/r.git", opts: []Option{internal}},
+		{name: "scheme entry does not allow other schemes", url: "git://gitea.lan/g/r.git", opts: []Option{internal}, wantErr: true},
+		{name: "allowlisted https host", url: "https://gitlab.internal/g/r.git", opts: []Option{internal}},
+		{name: "allowlisted host with port and case", url: "ssh://git@GitLab.Internal:2222/g/r.git", opts: []Option{internal}},
+		{name: "allowlisted scp-style host", url: "git@gitlab.internal:g/r.git", opts: []Option{internal}},
+		{name: "wildcard subdomain", url: "https://git.corp.example.com/g/r.git", opts: []Option{internal}},
+		{name: "wildcard does not match apex", url: "https://corp.example.com/g/r.git", opts: []Option{internal}, wantErr: true},
+		{name: "host not allowlisted", url: "https://github.com/o/r.git", opts: []Option{internal}, wantErr: true},
+		{name: "file remote off by default", url: "file:///srv/git/r.git", wantErr: true},
+		{name: "file remote opted in", url: "file:///srv/git/r.git", opts: []Option{WithFileRemotes()}},
+		{name: "unsupported scheme", url: "ext::sh -c touch% /tmp/pwned", wantErr: true},
+		{name: "option-like scp host", url: "-oProxyCommand=evil:r.git", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckRemote(tt.url, tt.opts...)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnsupportedHost) {
+					t.Fatalf("CheckRemote(%q) = %v, want ErrUnsupportedHost", tt.url, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CheckRemote(%q) unexpected error: %v", tt.url, err)
+			}
+		})
+	}
+}
+
+// newBareRepo creates a bare repository with two commits on main (the second
+// tagged v1.0.0, with a skills/demo/SKILL.md) and returns its file:// URL plus
+// the first and second commit SHAs.
+func newBareRepo(t *testing.T) (repoURL, first, second string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	root := t.TempDir()
+	bare := filepath.Join(root, "remote.git")
+	work := filepath.Join(root, "work")
+	git := func(dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1",
+		)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	git(root, "init", "--bare", "-b", "main", bare)
+	git(root, "init", "-b", "main", work)
+	if err := os.WriteFile(filepath.Join(work, "README.md"), []byte("v0"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git(work, "add", ".")
+	git(work, "commit", "-m", "first")
+	first = git(work, "rev-parse", "HEAD")
+	if err := os.MkdirAll(filepath.Join(work, "skills", "demo"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(work, "skills", "demo", "SKILL.md"), []byte("demo"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git(work, "add", ".")
+	git(work, "commit", "-m", "second")
+	second = git(work, "rev-parse", "HEAD")
+	git(work, "tag", "-a", "v1.0.0", "-m", "release")
+	git(work, "push", bare, "main", "--tags")
+	return "file://" + filepath.ToSlash(bare), first, second
+}
+
+func TestResolveRefContextFileRemote(t *testing.T) {
+	repoURL, first, second := newBareRepo(t)
+	ctx := context.Background()
+
+	for _, ref := range []string{"", "main", "v1.0.0"} {
+		got, err := ResolveRefContext(ctx, repoURL, ref, WithFileRemotes())
+		if err != nil {
+			t.Fatalf("ResolveRefContext(%q): %v", ref, err)
+		}
+		if got != second {
+			t.Fatalf("ResolveRefContext(%q) = %s, want %s", ref, got, second)
+		}
+	}
+	if got, err := ResolveRefContext(ctx, repoURL, strings.ToUpper(first), WithFileRemotes()); err != nil || got != first {
+		t.Fatalf("full SHA should pass through lowercased, got %q, %v", got, err)
+	}
+	if _, err := ResolveRefContext(ctx, repoURL, "no-such-branch", WithFileRemotes()); !errors.Is(err, ErrRefNotFound) {
+		t.Fatalf("missing ref: expected ErrRefNotFound, got %v", err)
+	}
+	if _, err := ResolveRefContext(ctx, repoURL, "main"); !errors.Is(err, ErrUnsupportedHost) {
+		t.Fatalf("file remote without opt-in: expected ErrUnsupportedHost, got %v", err)
+	}
+	if _, err := ResolveRefContext(ctx, repoURL, first, WithFileRemotes(), WithAllowedHosts([]string{"github.com"})); err != nil {
+		t.Fatalf("file remote has no host and must not be rejected by the allowlist: %v", err)
+	}
+}
+
+func TestCloneAndCopyContextFileRemote(t *testing.T) {
+	repoURL, first, _ := newBareRepo(t)
+	ctx := context.Background()
+
+	out := filepath.Join(t.TempDir(), "skill")
+	if err := CloneAndCopyContext(ctx, repoURL, "v1.0.0", "", "skills/demo", out, false, WithFileRemotes()); err != nil {
+		t.Fatalf("CloneAndCopyContext(tag): %v", err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(out, "SKILL.md")); string(got) != "demo" {
+		t.Fatalf("SKILL.md = %q, want %q", got, "demo")
+	}
+
+	// Pinning the first commit must check out the tree as of that commit.
+	pinned := filepath.Join(t.TempDir(), "pinned")
+	if err := CloneAndCopyContext(ctx, repoURL, "", first, "", pinned, false, WithFileRemotes()); err != nil {
+		t.Fatalf("CloneAndCopyContext(commit): %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(pinned, "skills")); !os.IsNotExist(err) {
+		t.Fatalf("expected no skills/ at the first commit, stat err = %v", err)
+	}
+
+	if err := CloneAndCopyContext(ctx, repoURL, "", "", "", t.TempDir(), false); !errors.Is(err, ErrUnsupportedHost) {
+		t.Fatalf("file remote without opt-in: expected ErrUnsupportedHost, got %v", err)
+	}
+}
+
 func TestRepoNameFromCloneURL(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -22,7 +22,7 @@ import (
 // independent command with its own flag state, which is required for testing
 // since cobra flags accumulate across Execute() calls on the same command instance.
 func NewApplyCmd(deps cliruntime.Deps) *cobra.Command {
-	var dryRun bool
+	var dryRun, overwriteImmutableTags bool
 	cmd := &cobra.Command{
 		Use:   cliruntime.CommandApply + " -f FILE",
 		Short: "Apply one or more resources from a YAML file",
@@ -32,13 +32,31 @@ documents and applies them via POST /v0/apply.
 Each resource is applied atomically; the server reports per-resource status.
 Best-effort: per-resource errors are reported without aborting the batch.
 
+Documents without metadata.namespace land in the default namespace, or in the
+namespace given by -n/--namespace.
+
+A document that carries metadata.resourceVersion (as "arctl get -o yaml"
+prints it) only applies if the object has not changed since it was read.
+Otherwise the apply fails with a conflict and prints a diff from the
+server's current object to the document.
+
+Semver tags such as 1.0.0 are immutable once published: re-applying one with
+different content fails with a TagImmutable conflict, so publish a new tag
+instead. Floating tags such as latest stay mutable. Registry admins can
+replace a published tag with --overwrite-immutable-tags; the server audits
+every such overwrite.
+
 Examples:
   arctl apply -f agent.yaml
+  arctl apply -f agent.yaml -n team-a
   arctl apply -f stack.yaml --dry-run
   cat stack.yaml | arctl apply -f -`,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runApply(cmd, deps, dryRun)
+			return runApply(cmd, deps, client.ApplyOpts{
+				DryRun:                 dryRun,
+				OverwriteImmutableTags: overwriteImmutableTags,
+			})
 		},
 	}
 	cmd.Flags().StringArrayP("filename", "f", nil,
@@ -46,38 +64,32 @@ Examples:
 	_ = cmd.MarkFlagRequired("filename")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false,
 		"Validate and simulate without mutating state")
+	cmd.Flags().BoolVar(&overwriteImmutableTags, "overwrite-immutable-tags", false,
+		"Replace the content of immutable tags (registry admins only; audited)")
+	addNamespaceFlag(cmd)
 	return cmd
 }
 
-func runApply(cmd *cobra.Command, deps cliruntime.Deps, dryRun bool) error {
+func runApply(cmd *cobra.Command, deps cliruntime.Deps, opts client.ApplyOpts) error {
 	filePaths, err := cmd.Flags().GetStringArray("filename")
 	if err != nil {
 		return fmt.Errorf("getting filename flag: %w", err)
 	}
+	namespace, _ := cmd.Flags().GetString(namespaceFlag)
 
 	// 1. Read and validate all input files before sending anything.
-	var allData [][]byte
+	var (
+		allData    [][]byte
+		allObjects [][]v1alpha1.Object
+	)
 	for _, path := range filePaths {
-		var data []byte
-		if path == "-" {
-			data, err = io.ReadAll(cmd.InOrStdin())
-			if err != nil {
-				return fmt.Errorf("reading stdin: %w", err)
-			}
-		} else {
-			data, err = InjectArctlLabels(path)
-			if err != nil {
-				return err
-			}
-		}
-
-		// Validate locally via registry decode — catches unknown kinds before sending.
-		objects, err := scheme.DecodeBytes(data)
+		data, objects, err := readManifest(cmd, path, namespace)
 		if err != nil {
-			return fmt.Errorf("parsing %s: %w", path, err)
+			return err
 		}
 		warnLegacyAgentModelConfiguration(cmd.ErrOrStderr(), objects)
 		allData = append(allData, data)
+		allObjects = append(allObjects, objects)
 	}
 
 	if deps.Runtime == nil {
@@ -91,16 +103,15 @@ func runApply(cmd *cobra.Command, deps cliruntime.Deps, dryRun bool) error {
 	// 3. Send each file as a separate batch call (preserves document separation).
 	var anyFailure bool
 	for i, data := range allData {
-		results, err := c.Apply(cmd.Context(), data, client.ApplyOpts{
-			DryRun: dryRun,
-		})
+		results, err := c.Apply(cmd.Context(), data, opts)
 		if err != nil {
 			// Request-level error (network, 4xx) — report and continue if multiple files.
 			fmt.Fprintf(cmd.ErrOrStderr(), "Error applying %s: %v\n", filePaths[i], err)
 			anyFailure = true
 			continue
 		}
-		printResults(cmd.OutOrStdout(), results, dryRun)
+		printResults(cmd.OutOrStdout(), results, opts.DryRun)
+		printConflictDiffs(cmd.Context(), cmd.OutOrStdout(), c, filePaths[i], allObjects[i], results)
 		for _, r := range results {
 			if r.Status == arv0.ApplyStatusFailed {
 				anyFailure = true
@@ -114,6 +125,39 @@ func runApply(cmd *cobra.Command, deps cliruntime.Deps, dryRun bool) error {
 	return nil
 }
 
+// readManifest reads the YAML at path (stdin for "-"), moves documents
+// without metadata.namespace into namespace when it is set, and decodes
+// them locally so unknown kinds fail before anything is sent.
+func readManifest(cmd *cobra.Command, path, namespace string) ([]byte, []v1alpha1.Object, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading stdin: %w", err)
+		}
+	} else {
+		data, err = InjectArctlLabels(path)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if namespace != "" {
+		if data, err = setDocumentNamespace(data, namespace); err != nil {
+			return nil, nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	}
+
+	objects, err := scheme.DecodeBytes(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return data, objects, nil
+}
+
 func warnLegacyAgentModelConfiguration(out io.Writer, objects []v1alpha1.Object) {
 	for _, obj := range objects {
 		agent, ok := obj.(*v1alpha1.Agent)
@@ -178,8 +222,8 @@ func InjectArctlLabels(yamlPath string) ([]byte, error) {
 		}
 		meta := findOrCreateMappingChild(root, "metadata")
 		labels := findOrCreateMappingChild(meta, "labels")
-		upsertLabel(labels, "arctl.dev/framework", cfg.Framework)
-		upsertLabel(labels, "arctl.dev/language", cfg.Language)
+		upsertScalar(labels, "arctl.dev/framework", cfg.Framework)
+		upsertScalar(labels, "arctl.dev/language", cfg.Language)
 		injected = true
 	}
 
@@ -256,16 +300,16 @@ func findOrCreateMappingChild(parent *yaml.Node, key string) *yaml.Node {
 	return valN
 }
 
-// upsertLabel sets labels[key] = value, creating the entry if missing.
-func upsertLabel(labels *yaml.Node, key, value string) {
-	for i := 0; i < len(labels.Content)-1; i += 2 {
-		if labels.Content[i].Value == key {
-			labels.Content[i+1].Value = value
-			labels.Content[i+1].Tag = ""
+// upsertScalar sets mapping[key] = value, creating the entry if missing.
+func upsertScalar(mapping *yaml.Node, key, value string) {
+	for i := 0; i < len(mapping.Content)-1; i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1].Value = value
+			mapping.Content[i+1].Tag = ""
 			return
 		}
 	}
-	labels.Content = append(labels.Content,
+	mapping.Content = append(mapping.Content,
 		&yaml.Node{Kind: yaml.ScalarNode, Value: key},
 		&yaml.Node{Kind: yaml.ScalarNode, Value: value})
 }
@@ -284,9 +328,18 @@ func printResults(out io.Writer, results []arv0.ApplyResult, dryRun bool) {
 		if dryRun {
 			fmt.Fprint(out, " (dry run)")
 		}
+		switch r.Reason {
+		case arv0.ApplyReasonApprovalPending:
+			fmt.Fprint(out, ", pending approval")
+		case arv0.ApplyReasonApprovalRejected:
+			fmt.Fprint(out, ", rejected in review")
+		}
 		if r.Error != "" {
 			fmt.Fprintf(out, ": %s", r.Error)
 		}
 		fmt.Fprintln(out)
+		for _, warning := range r.Warnings {
+			fmt.Fprintf(out, "  warning: %s\n", warning)
+		}
 	}
 }
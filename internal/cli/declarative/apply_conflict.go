@@ -0,0 +1,119 @@
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"sigs.k8s.io/yaml"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// printConflictDiffs explains each apply rejected for a stale
+// metadata.resourceVersion with a diff from the object's current state to
+// the submitted document, so the user can see what changed underneath
+// them before re-applying.
+func printConflictDiffs(ctx context.Context, out io.Writer, c *client.Client, source string, objects []v1alpha1.Object, results []arv0.ApplyResult) {
+	for _, r := range results {
+		if r.Reason != arv0.ApplyReasonConflict {
+			continue
+		}
+		submitted := findSubmitted(objects, r)
+		if submitted == nil {
+			continue
+		}
+		kind, namespace := submitted.GetKind(), submitted.GetMetadata().NamespaceOrDefault()
+		tag := r.Tag
+		if tag == "" {
+			tag = submitted.GetMetadata().Tag
+		}
+		var (
+			live *v1alpha1.RawObject
+			err  error
+		)
+		if v1alpha1.IsTaggedArtifactKind(kind) && tag != "" {
+			live, err = c.Get(ctx, kind, namespace, r.Name, tag)
+		} else {
+			live, err = c.GetLatest(ctx, kind, namespace, r.Name)
+		}
+		if err != nil {
+			fmt.Fprintf(out, "  could not read current %s/%s: %v\n", r.Kind, r.Name, err)
+			continue
+		}
+		diff, err := conflictDiff(live, submitted, source)
+		if err != nil {
+			fmt.Fprintf(out, "  could not diff %s/%s: %v\n", r.Kind, r.Name, err)
+			continue
+		}
+		if diff == "" {
+			fmt.Fprintf(out, "  %s/%s already matches the server; drop metadata.resourceVersion or set it to %s and re-apply\n",
+				r.Kind, r.Name, live.Metadata.ResourceVersion)
+			continue
+		}
+		fmt.Fprint(out, diff)
+	}
+}
+
+// findSubmitted returns the decoded document an apply result reports on.
+func findSubmitted(objects []v1alpha1.Object, r arv0.ApplyResult) v1alpha1.Object {
+	namespace := v1alpha1.ObjectMeta{Namespace: r.Namespace}.NamespaceOrDefault()
+	for _, obj := range objects {
+		meta := obj.GetMetadata()
+		if !strings.EqualFold(obj.GetKind(), r.Kind) || meta.Name != r.Name || meta.NamespaceOrDefault() != namespace {
+			continue
+		}
+		if r.Tag != "" && meta.Tag != "" && meta.Tag != r.Tag {
+			continue
+		}
+		return obj
+	}
+	return nil
+}
+
+// conflictDiff renders a unified diff of the user-editable fields
+// (labels, annotations, spec) from the live object to the submitted one.
+func conflictDiff(live *v1alpha1.RawObject, submitted v1alpha1.Object, source string) (string, error) {
+	from, err := editableYAML(live)
+	if err != nil {
+		return "", err
+	}
+	to, err := editableYAML(submitted)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from),
+		B:        difflib.SplitLines(to),
+		FromFile: fmt.Sprintf("%s/%s (server, resourceVersion %s)", live.Kind, live.Metadata.Name, live.Metadata.ResourceVersion),
+		ToFile:   source,
+		Context:  3,
+	})
+}
+
+func editableYAML(obj any) (string, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	var doc struct {
+		Metadata struct {
+			Labels      map[string]string `json:"labels,omitempty"`
+			Annotations map[string]string `json:"annotations,omitempty"`
+		} `json:"metadata"`
+		Spec json.RawMessage `json:"spec,omitempty"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return "", err
+	}
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -189,6 +189,27 @@ func TestApplyDryRunFlag(t *testing.T) {
 	assert.Equal(t, "true", parsedQuery.Get("dryRun"), "expected ?dryRun=true in request URL")
 }
 
+// TestApplyOverwriteImmutableTagsFlag verifies --overwrite-immutable-tags
+// sets ?overwriteImmutableTags=true on the request.
+func TestApplyOverwriteImmutableTagsFlag(t *testing.T) {
+	results := []arv0.ApplyResult{
+		{Kind: "agent", Name: "acme-bot", Tag: "1.0.0", Status: arv0.ApplyStatusConfigured},
+	}
+	srv, captured := newApplyTestServer(t, results)
+
+	var buf bytes.Buffer
+	cmd := declarative.NewApplyCmd(applyDeps(t, srv))
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"-f", writeTempYAML(t, agentYAML), "--overwrite-immutable-tags"})
+	require.NoError(t, cmd.Execute())
+
+	parsedQuery, err := url.ParseQuery(captured.URL.RawQuery)
+	require.NoError(t, err)
+	assert.Equal(t, "true", parsedQuery.Get("overwriteImmutableTags"))
+	assert.Empty(t, parsedQuery.Get("dryRun"))
+}
+
 // TestApplyNoQueryNoise verifies that omitting dry-run keeps the batch URL clean.
 func TestApplyNoQueryNoise(t *testing.T) {
 	results := []arv0.ApplyResult{
@@ -336,3 +357,52 @@ spec:
 	assert.Contains(t, string(got), "arctl.dev/framework: fastmcp")
 	assert.Contains(t, string(got), "arctl.dev/language: python")
 }
+
+// TestApplyPrintsDiffOnConflict verifies a stale resourceVersion result is
+// explained with a diff against the object the server currently holds.
+func TestApplyPrintsDiffOnConflict(t *testing.T) {
+	const staleAgent = `apiVersion: ar.dev/v1alpha1
+kind: Agent
+metadata:
+  name: acme-bot
+  tag: "1.0.0"
+  resourceVersion: "41"
+spec:
+  description: "A bot, v2"
+`
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			_, _ = w.Write(batchApplyResponse([]arv0.ApplyResult{{
+				Kind:      "Agent",
+				Namespace: "default",
+				Name:      "acme-bot",
+				Tag:       "1.0.0",
+				Status:    arv0.ApplyStatusFailed,
+				Error:     "conflict: resourceVersion 41 is stale; the object is now at 57",
+				Reason:    arv0.ApplyReasonConflict,
+			}}))
+			return
+		}
+		gotPath = r.URL.Path
+		_, _ = w.Write([]byte(`{"apiVersion":"ar.dev/v1alpha1","kind":"Agent",
+			"metadata":{"namespace":"default","name":"acme-bot","tag":"1.0.0","resourceVersion":"57"},
+			"spec":{"description":"A bot, v3"}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	var out bytes.Buffer
+	cmd := declarative.NewApplyCmd(applyDeps(t, srv))
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{"-f", writeTempYAML(t, staleAgent)})
+	require.Error(t, cmd.Execute())
+
+	assert.Equal(t, "/v0/agents/acme-bot/1.0.0", gotPath)
+	output := out.String()
+	assert.Contains(t, output, "✗ Agent/acme-bot")
+	assert.Contains(t, output, "Agent/acme-bot (server, resourceVersion 57)")
+	assert.Contains(t, output, "-  description: A bot, v3")
+	assert.Contains(t, output, "+  description: A bot, v2")
+}
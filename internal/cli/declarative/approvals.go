@@ -0,0 +1,161 @@
+package declarative
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+)
+
+// NewApprovalsCmd returns the "approvals" cobra command.
+func NewApprovalsCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "approvals",
+		Short: "Review content published into namespaces that require approval",
+		Long: `A Namespace whose spec.approvalPolicy is required holds every publish of a
+covered kind for review. Held content is stored, but refs and deployments
+ignore its tag until a reviewer approves it. Approvals attach to the content
+digest, so approved content stays approved under every tag that holds it.
+
+Reviewers need the "approve" action on the artifact, and cannot review
+content they published themselves.`,
+	}
+	cmd.AddCommand(newApprovalsListCmd(deps))
+	cmd.AddCommand(newApprovalsReviewCmd(deps, arv0.ApprovalDecisionApprove))
+	cmd.AddCommand(newApprovalsReviewCmd(deps, arv0.ApprovalDecisionReject))
+	return cmd
+}
+
+func newApprovalsListCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List content awaiting review",
+		Long: `List the review queue of a namespace, oldest request first. --state lists
+approved, rejected or all entries instead of pending ones.
+
+Examples:
+  arctl approvals list -n catalog
+  arctl approvals list -A --state all -o yaml`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runApprovalsList(cmd, deps)
+		},
+	}
+	cmd.Flags().StringP("output", "o", "table", "Output format: table, yaml, json")
+	cmd.Flags().String("state", "", "Only entries in this state: pending (default), approved, rejected, all")
+	cmd.Flags().BoolP("all-namespaces", "A", false, "List across every namespace")
+	addNamespaceFlag(cmd)
+	return cmd
+}
+
+func runApprovalsList(cmd *cobra.Command, deps cliruntime.Deps) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	state, _ := cmd.Flags().GetString("state")
+	namespace, _ := cmd.Flags().GetString(namespaceFlag)
+	if all, _ := cmd.Flags().GetBool("all-namespaces"); all {
+		if namespace != "" {
+			return fmt.Errorf("--namespace and --all-namespaces are mutually exclusive")
+		}
+		namespace = allNamespaces
+	}
+
+	c, err := registryClient(cmd, deps)
+	if err != nil {
+		return err
+	}
+	items, err := c.ListApprovals(cmd.Context(), namespace, state)
+	if err != nil {
+		return fmt.Errorf("listing approvals: %w", err)
+	}
+
+	switch outputFormat {
+	case "yaml":
+		return marshalYAML(cmd, items)
+	case "json":
+		return marshalJSON(cmd, items)
+	}
+	if len(items) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No approvals found.")
+		return nil
+	}
+	t := printer.NewTablePrinter(cmd.OutOrStdout())
+	t.SetHeaders("KIND", "NAMESPACE", "NAME", "DIGEST", "STATE", "REQUESTED BY", "REQUESTED", "REVIEWED BY", "COMMENT")
+	for _, a := range items {
+		name := a.Name
+		if a.Tag != "" {
+			name += ":" + a.Tag
+		}
+		t.AddRow(a.Kind, a.Namespace, printer.TruncateString(name, 40), shortDigest(a.Digest), a.State,
+			printer.TruncateString(a.RequestedBy, 30), a.RequestedAt.Local().Format(time.DateTime),
+			printer.TruncateString(a.ReviewedBy, 30), printer.TruncateString(a.Comment, 40))
+	}
+	return t.Render()
+}
+
+func newApprovalsReviewCmd(deps cliruntime.Deps, decision string) *cobra.Command {
+	short := "Approve content so refs and deployments use it"
+	if decision == arv0.ApprovalDecisionReject {
+		short = "Reject content so refs and deployments keep ignoring it"
+	}
+	cmd := &cobra.Command{
+		Use:   decision + " TYPE NAME",
+		Short: short,
+		Long: fmt.Sprintf(`%s the content a tag holds now (--tag, default latest), or the
+content with --digest. -m records a comment with the decision and in the
+audit log.
+
+Examples:
+  arctl approvals %s agent summarizer -n catalog --tag 1.2.0 -m "reviewed"
+  arctl approvals %s mcp acme-fetch -n catalog --digest sha256:3a7b...`,
+			strings.ToUpper(decision[:1])+decision[1:], decision, decision),
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runApprovalsReview(cmd, deps, args, decision)
+		},
+	}
+	cmd.Flags().String("tag", "", "Tag whose content to review (defaults to latest)")
+	cmd.Flags().String("digest", "", "Content digest (sha256:<hex>) to review instead of a tag's")
+	cmd.Flags().StringP("comment", "m", "", "Comment recorded with the decision")
+	addNamespaceFlag(cmd)
+	return cmd
+}
+
+func runApprovalsReview(cmd *cobra.Command, deps cliruntime.Deps, args []string, decision string) error {
+	tag, _ := cmd.Flags().GetString("tag")
+	digest, _ := cmd.Flags().GetString("digest")
+	comment, _ := cmd.Flags().GetString("comment")
+	if tag != "" && digest != "" {
+		return fmt.Errorf("--tag and --digest are mutually exclusive")
+	}
+
+	kind, ref, err := taggedArtifactRef(cmd, deps, args, "reviewed; only tagged kinds need approval")
+	if err != nil {
+		return err
+	}
+	c, err := registryClient(cmd, deps)
+	if err != nil {
+		return err
+	}
+	reviewed, err := c.ReviewApproval(cmd.Context(), arv0.ApprovalReview{
+		Kind:      kind,
+		Namespace: ref.Namespace,
+		Name:      ref.Name,
+		Tag:       tag,
+		Digest:    digest,
+		Decision:  decision,
+		Comment:   comment,
+	})
+	if err != nil {
+		return fmt.Errorf("reviewing %s %q: %w", kind, args[1], err)
+	}
+	target := signTarget{Kind: kind, Namespace: reviewed.Namespace, Name: reviewed.Name, Tag: reviewed.Tag}
+	fmt.Fprintf(cmd.OutOrStdout(), "%s/%s %s (%s)\n", strings.ToLower(kind), signTargetName(target), reviewed.State, reviewed.Digest)
+	return nil
+}
@@ -0,0 +1,99 @@
+package declarative_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+)
+
+// approvalServer serves GET and POST /v0/approvals over one pending
+// entry for agent catalog/summarizer:1.2.0, recording the reviews it
+// receives.
+func approvalServer(t *testing.T) (*httptest.Server, *[]arv0.ApprovalReview) {
+	t.Helper()
+	var reviews []arv0.ApprovalReview
+	entry := arv0.ApprovalEntry{
+		Kind: "Agent", Namespace: "catalog", Name: "summarizer", Tag: "1.2.0", Digest: testDigest,
+		State: arv0.ApprovalPending, RequestedBy: "alice@example.com", RequestedAt: time.Now(),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v0/approvals", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			require.Equal(t, "catalog", r.URL.Query().Get("namespace"))
+			_ = json.NewEncoder(w).Encode(arv0.ApprovalListResponse{Items: []arv0.ApprovalEntry{entry}})
+		case http.MethodPost:
+			var review arv0.ApprovalReview
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&review))
+			reviews = append(reviews, review)
+			reviewed := entry
+			reviewed.State = arv0.ApprovalApproved
+			if review.Decision == arv0.ApprovalDecisionReject {
+				reviewed.State = arv0.ApprovalRejected
+			}
+			reviewed.Comment = review.Comment
+			_ = json.NewEncoder(w).Encode(reviewed)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &reviews
+}
+
+func TestApprovals_ListAndApprove(t *testing.T) {
+	srv, reviews := approvalServer(t)
+	setupClientForServer(t, srv)
+
+	var out bytes.Buffer
+	cmd := declarative.NewApprovalsCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"list", "-n", "catalog"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "summarizer:1.2.0")
+	assert.Contains(t, out.String(), "sha256:3a7bd3e2360a")
+	assert.Contains(t, out.String(), "pending")
+
+	out.Reset()
+	cmd = declarative.NewApprovalsCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"approve", "agent", "summarizer", "-n", "catalog", "--tag", "1.2.0", "-m", "reviewed"})
+	require.NoError(t, cmd.Execute())
+	require.Len(t, *reviews, 1)
+	assert.Equal(t, arv0.ApprovalReview{
+		Kind: "Agent", Namespace: "catalog", Name: "summarizer", Tag: "1.2.0",
+		Decision: arv0.ApprovalDecisionApprove, Comment: "reviewed",
+	}, (*reviews)[0])
+	assert.Contains(t, out.String(), "agent/catalog/summarizer:1.2.0 approved")
+}
+
+func TestApprovals_RejectByDigest(t *testing.T) {
+	srv, reviews := approvalServer(t)
+	setupClientForServer(t, srv)
+
+	var out bytes.Buffer
+	cmd := declarative.NewApprovalsCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"reject", "agent", "summarizer", "-n", "catalog", "--digest", testDigest})
+	require.NoError(t, cmd.Execute())
+	require.Len(t, *reviews, 1)
+	assert.Equal(t, testDigest, (*reviews)[0].Digest)
+	assert.Equal(t, arv0.ApprovalDecisionReject, (*reviews)[0].Decision)
+	assert.Contains(t, out.String(), "rejected")
+}
+
+func TestApprovals_RejectsUntaggedKinds(t *testing.T) {
+	cmd := declarative.NewApprovalsCmd(declarativeTestDeps(nil))
+	cmd.SetArgs([]string{"approve", "runtime", "kubernetes-default"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not reviewed")
+}
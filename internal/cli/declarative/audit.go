@@ -0,0 +1,168 @@
+package declarative
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+)
+
+// NewAuditCmd returns a new "audit" cobra command.
+func NewAuditCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit [TYPE [NAME]]",
+		Short: "Show the registry audit log",
+		Long: `Show who created, updated, deleted or changed the status of registry
+resources, and which requests were denied, newest first. Reading the audit
+log requires registry admin.
+
+Narrow the log to one type, or one resource, with TYPE and NAME. --since and
+--until take a duration back from now (24h) or an RFC 3339 time.
+
+Examples:
+  arctl audit
+  arctl audit agent summarizer -n team-a
+  arctl audit --principal alice@example.com --since 168h
+  arctl audit --verb deny --since 24h
+  arctl audit --request-id 3f1c2a9e-6a0b-4d6f-9e1b-2f7d0c4b8a11 -o json`,
+		Args:         cobra.MaximumNArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAudit(cmd, deps, args)
+		},
+	}
+	cmd.Flags().StringP("output", "o", "table", "Output format: table, yaml, json")
+	cmd.Flags().StringP(namespaceFlag, "n", "", "Only entries in this namespace (default: every namespace)")
+	cmd.Flags().String("tag", "", "Only entries for this tag")
+	cmd.Flags().String("principal", "", "Only entries made by this principal")
+	cmd.Flags().String("verb", "", "Only entries with this verb: create, update, delete, deny, sign, approve, reject, deprecate, undeprecate, yank, unyank")
+	cmd.Flags().String("request-id", "", "Only entries recorded by this request")
+	cmd.Flags().String("since", "", "Only entries at or after this time (duration ago, e.g. 24h, or RFC 3339)")
+	cmd.Flags().String("until", "", "Only entries before this time (duration ago, e.g. 1h, or RFC 3339)")
+	cmd.Flags().Int("limit", 50, "Maximum number of entries (1-500)")
+	cmd.Flags().String("cursor", "", "Continue from the cursor printed below a previous page")
+	return cmd
+}
+
+func runAudit(cmd *cobra.Command, deps cliruntime.Deps, args []string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	opts := client.AuditOpts{}
+	opts.Namespace, _ = cmd.Flags().GetString(namespaceFlag)
+	opts.Tag, _ = cmd.Flags().GetString("tag")
+	opts.Principal, _ = cmd.Flags().GetString("principal")
+	opts.Verb, _ = cmd.Flags().GetString("verb")
+	opts.RequestID, _ = cmd.Flags().GetString("request-id")
+	opts.Limit, _ = cmd.Flags().GetInt("limit")
+	opts.Cursor, _ = cmd.Flags().GetString("cursor")
+
+	now := time.Now()
+	var err error
+	since, _ := cmd.Flags().GetString("since")
+	if opts.Since, err = parseAuditTime(since, now); err != nil {
+		return fmt.Errorf("--since: %w", err)
+	}
+	until, _ := cmd.Flags().GetString("until")
+	if opts.Until, err = parseAuditTime(until, now); err != nil {
+		return fmt.Errorf("--until: %w", err)
+	}
+
+	if len(args) > 0 {
+		k, err := kindRegistry(deps).Lookup(args[0])
+		if err != nil {
+			return err
+		}
+		opts.Kind = canonicalKindName(k)
+		if opts.Kind == "" {
+			return fmt.Errorf("type %q is not audited", args[0])
+		}
+	}
+	if len(args) > 1 {
+		opts.Name = args[1]
+	}
+
+	c, err := registryClient(cmd, deps)
+	if err != nil {
+		return err
+	}
+	resp, err := c.ListAudit(cmd.Context(), opts)
+	if err != nil {
+		return fmt.Errorf("reading audit log: %w", err)
+	}
+
+	switch outputFormat {
+	case "yaml":
+		return marshalYAML(cmd, resp)
+	case "json":
+		return marshalJSON(cmd, resp)
+	}
+	if len(resp.Items) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No audit entries found.")
+		return nil
+	}
+	t := printer.NewTablePrinter(cmd.OutOrStdout())
+	t.SetHeaders("TIME", "PRINCIPAL", "VERB", "KIND", "NAMESPACE", "NAME", "DETAIL")
+	for _, e := range resp.Items {
+		name := e.Name
+		if e.Tag != "" {
+			name += "@" + e.Tag
+		}
+		t.AddRow(e.Time.Local().Format(time.DateTime), printer.TruncateString(e.Principal, 30), e.Verb,
+			e.Kind, e.Namespace, printer.TruncateString(name, 40), printer.TruncateString(auditDetail(e), 60))
+	}
+	if err := t.Render(); err != nil {
+		return err
+	}
+	if resp.NextCursor != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "\nMore entries: --cursor %s\n", resp.NextCursor)
+	}
+	return nil
+}
+
+// parseAuditTime reads s as a duration back from now or an RFC 3339 time.
+// Empty is the zero time.
+func parseAuditTime(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither a duration nor an RFC 3339 time", s)
+	}
+	return t, nil
+}
+
+// auditDetail summarizes an entry for the table: the reason when set,
+// otherwise the fields its diff touches, e.g. "spec.image, metadata.labels".
+func auditDetail(e arv0.AuditEntry) string {
+	if e.Reason != "" {
+		return e.Reason
+	}
+	var diff map[string]json.RawMessage
+	if len(e.Diff) == 0 || json.Unmarshal(e.Diff, &diff) != nil {
+		return ""
+	}
+	var fields []string
+	for _, key := range slices.Sorted(maps.Keys(diff)) {
+		var nested map[string]json.RawMessage
+		if json.Unmarshal(diff[key], &nested) == nil && len(nested) > 0 {
+			for _, sub := range slices.Sorted(maps.Keys(nested)) {
+				fields = append(fields, key+"."+sub)
+			}
+			continue
+		}
+		fields = append(fields, key)
+	}
+	return strings.Join(fields, ", ")
+}
@@ -0,0 +1,62 @@
+package declarative_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+)
+
+func TestAudit_RendersEntries(t *testing.T) {
+	var gotURI string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURI = r.URL.RequestURI()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(arv0.AuditListResponse{
+			Items: []arv0.AuditEntry{
+				{
+					ID: 7, Time: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC), Principal: "alice@example.com",
+					Verb: "update", Kind: "Agent", Namespace: "team-a", Name: "summarizer", Tag: "latest",
+					Diff: json.RawMessage(`{"spec":{"image":"ghcr.io/acme/summarizer:2"},"metadata":{"labels":{"tier":"gold"}}}`),
+				},
+				{
+					ID: 6, Time: time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC), Principal: "bob@example.com",
+					Verb: "deny", Kind: "Agent", Namespace: "team-a", Name: "summarizer",
+					Reason: "apply: forbidden",
+				},
+			},
+			NextCursor: "6",
+		})
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	var out bytes.Buffer
+	cmd := declarative.NewAuditCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"agent", "summarizer", "-n", "team-a", "--verb", "update", "--since", "2026-09-01T00:00:00Z", "--limit", "2"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "/v0/audit?kind=Agent&limit=2&name=summarizer&namespace=team-a&since=2026-09-01T00%3A00%3A00Z&verb=update", gotURI)
+	assert.Contains(t, out.String(), "alice@example.com")
+	assert.Contains(t, out.String(), "summarizer@latest")
+	assert.Contains(t, out.String(), "metadata.labels, spec.image")
+	assert.Contains(t, out.String(), "apply: forbidden")
+	assert.Contains(t, out.String(), "More entries: --cursor 6")
+}
+
+func TestAudit_RejectsMalformedSince(t *testing.T) {
+	cmd := declarative.NewAuditCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--since", "last tuesday"})
+	require.ErrorContains(t, cmd.Execute(), "--since")
+}
@@ -137,6 +137,19 @@ func init() {
 				return deploymentRow(cliCommon.DeploymentRecordFromObject(deployment))
 			},
 			withMutableListFunc(listDeploymentResources),
+			withMutableWatchFunc(watchDeploymentResources),
+		),
+	)
+
+	// Namespace is a mutable object whose rows all live in the default
+	// namespace, so NAME always resolves to default/NAME.
+	scheme.Register(
+		mutableTypedKind(
+			"namespace", "namespaces", []string{"Namespace", "ns"},
+			[]scheme.Column{{Header: "NAME"}, {Header: "DESCRIPTION"}},
+			v1alpha1.KindNamespace,
+			func() *v1alpha1.Namespace { return &v1alpha1.Namespace{} },
+			namespaceRow,
 		),
 	)
 }
@@ -179,6 +192,9 @@ func typedKind[T v1alpha1.Object](
 		ListFunc: func(ctx context.Context, c *client.Client, opts scheme.ListOpts) ([]any, error) {
 			return listAny(ctx, c, canonicalKind, opts, newObj)
 		},
+		Watch: func(ctx context.Context, c *client.Client, opts scheme.ListOpts, fn func(string, any) error) error {
+			return watchAny(ctx, c, canonicalKind, opts, newObj, fn)
+		},
 		Delete: func(ctx context.Context, c *client.Client, name, tag string) error {
 			return deleteAny(ctx, c, canonicalKind, name, tag, newObj)
 		},
@@ -204,6 +220,13 @@ func withMutableListFunc(fn scheme.ListFunc) mutableTypedKindOption {
 	}
 }
 
+// withMutableWatchFunc is the Watch counterpart of withMutableListFunc.
+func withMutableWatchFunc(fn scheme.WatchFunc) mutableTypedKindOption {
+	return func(k *scheme.Kind) {
+		k.Watch = fn
+	}
+}
+
 // mutableTypedKind builds a scheme.Kind for mutable namespace/name resources which
 // do not support tagging.
 func mutableTypedKind[T v1alpha1.Object](
@@ -238,6 +261,9 @@ func mutableTypedKind[T v1alpha1.Object](
 		ListFunc: func(ctx context.Context, c *client.Client, opts scheme.ListOpts) ([]any, error) {
 			return listAny(ctx, c, canonicalKind, opts, newObj)
 		},
+		Watch: func(ctx context.Context, c *client.Client, opts scheme.ListOpts, fn func(string, any) error) error {
+			return watchAny(ctx, c, canonicalKind, opts, newObj, fn)
+		},
 		Delete: func(ctx context.Context, c *client.Client, name, tag string) error {
 			return deleteAny(ctx, c, canonicalKind, name, tag, newObj)
 		},
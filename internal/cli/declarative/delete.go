@@ -27,14 +27,20 @@ Explicit mode: specify type and name. For taggable artifacts, --tag selects an
 exact tag and defaults to latest.
   arctl delete TYPE NAME [--tag TAG]
 
-TYPE must be one of: agent, mcp, skill, prompt, deployment
-(plural and uppercase forms also accepted)`,
+TYPE must be one of: agent, mcp, skill, prompt, deployment, runtime, namespace
+(plural and uppercase forms also accepted)
+
+NAME may be NAMESPACE/NAME; -n/--namespace sets the namespace for NAME and for
+file documents that do not name one. A namespace is only deleted once it is
+empty, and the default namespace cannot be deleted.`,
 		Example: `  arctl delete -f my-agent/agent.yaml
   arctl delete -f my-server/mcp.yaml
   arctl delete agent acme-summarizer --tag stable
   arctl delete agent acme-summarizer --all-tags
   arctl delete mcp acme-fetch --tag stable
-  arctl delete deployment team-a/my-agent`,
+  arctl delete deployment team-a/my-agent
+  arctl delete agent acme-summarizer -n team-a
+  arctl delete namespace team-a`,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runDeclarativeDelete(cmd, deps, args)
@@ -43,6 +49,7 @@ TYPE must be one of: agent, mcp, skill, prompt, deployment
 	cmd.Flags().StringP("filename", "f", "", "YAML file to read resources from")
 	cmd.Flags().String("tag", "", "Specific tag to delete (taggable artifact kinds only; defaults to latest)")
 	cmd.Flags().Bool("all-tags", false, "Delete every tag of NAME (taggable artifact kinds only)")
+	addNamespaceFlag(cmd)
 	return cmd
 }
 
@@ -51,6 +58,7 @@ func runDeclarativeDelete(cmd *cobra.Command, deps cliruntime.Deps, args []strin
 	filename, _ := cmd.Flags().GetString("filename")
 	allTags, _ := cmd.Flags().GetBool("all-tags")
 	tag, _ := cmd.Flags().GetString("tag")
+	namespace, _ := cmd.Flags().GetString(namespaceFlag)
 	allTagsFlag := "--all-tags"
 	tagFlag := "--tag"
 
@@ -66,21 +74,32 @@ func runDeclarativeDelete(cmd *cobra.Command, deps cliruntime.Deps, args []strin
 		if allTags {
 			return fmt.Errorf("%s cannot be used with -f", allTagsFlag)
 		}
-		return deleteFromFile(cmd, c, filename)
+		return deleteFromFile(cmd, c, filename, namespace)
 	}
 
 	// Explicit mode: TYPE NAME [--tag TAG | --all-tags]
 	if len(args) != 2 {
 		return fmt.Errorf("explicit mode requires TYPE and NAME arguments (or use -f FILE)")
 	}
+	k, err := kinds.Lookup(args[0])
+	if err != nil {
+		return err
+	}
+	if err := checkNamespaceFlag(k, namespace); err != nil {
+		return err
+	}
+	name, err := qualifyName(namespace, args[1])
+	if err != nil {
+		return err
+	}
 	if allTags {
 		if tag != "" {
 			return fmt.Errorf("%s and %s are mutually exclusive", tagFlag, allTagsFlag)
 		}
-		return deleteAllTagsResource(cmd, kinds, c, args[0], args[1])
+		return deleteAllTagsResource(cmd, kinds, c, args[0], name)
 	}
 
-	return deleteResource(cmd, kinds, c, args[0], args[1], tag)
+	return deleteResource(cmd, kinds, c, args[0], name, tag)
 }
 
 // deleteAllTagsResource removes every live tag of (kind, name).
@@ -101,7 +120,7 @@ func deleteAllTagsResource(cmd *cobra.Command, kinds *scheme.Registry, c *client
 
 // deleteFromFile reads a YAML file and sends a single DELETE /v0/apply request.
 // Per-resource results are printed; non-zero exit if any failed.
-func deleteFromFile(cmd *cobra.Command, c *client.Client, filename string) error {
+func deleteFromFile(cmd *cobra.Command, c *client.Client, filename, namespace string) error {
 	var data []byte
 	var err error
 	if filename == "-" {
@@ -116,6 +135,12 @@ func deleteFromFile(cmd *cobra.Command, c *client.Client, filename string) error
 		}
 	}
 
+	if namespace != "" {
+		if data, err = setDocumentNamespace(data, namespace); err != nil {
+			return err
+		}
+	}
+
 	// Validate locally so unknown kinds fail before hitting the network.
 	if _, err := scheme.DecodeBytes(data); err != nil {
 		return fmt.Errorf("parsing %s: %w", filename, err)
@@ -143,10 +168,11 @@ func deleteResource(cmd *cobra.Command, kinds *scheme.Registry, c *client.Client
 		return err
 	}
 
-	// Deployments and runtimes have no tag of their own; rejecting --tag here
-	// keeps users from confusing a deployment's target tag (or a runtime's
-	// non-existent tag) with the metadata identity used for delete.
-	if tag != "" && (k.Kind == "deployment" || k.Kind == "runtime") {
+	// Deployments, runtimes, and namespaces have no tag of their own;
+	// rejecting --tag here keeps users from confusing a deployment's target
+	// tag (or a runtime's non-existent tag) with the metadata identity used
+	// for delete.
+	if tag != "" && (k.Kind == "deployment" || k.Kind == "runtime" || k.Kind == "namespace") {
 		return fmt.Errorf("--tag is not supported for %s", k.Kind)
 	}
 
@@ -71,6 +71,9 @@ func NewExtensionKind(k ExtensionKind) *scheme.Kind {
 		ListFunc: func(ctx context.Context, c *client.Client, opts scheme.ListOpts) ([]any, error) {
 			return listAny(ctx, c, k.CanonicalKind, opts, k.NewObject)
 		},
+		Watch: func(ctx context.Context, c *client.Client, opts scheme.ListOpts, fn func(string, any) error) error {
+			return watchAny(ctx, c, k.CanonicalKind, opts, k.NewObject, fn)
+		},
 		Delete: func(ctx context.Context, c *client.Client, name, tag string) error {
 			return deleteAny(ctx, c, k.CanonicalKind, name, tag, k.NewObject)
 		},
@@ -23,8 +23,12 @@ func NewGetCmd(deps cliruntime.Deps) *cobra.Command {
 		Short: "List or retrieve registry resources",
 		Long: `List or retrieve registry resources by type.
 
-Supported types: agents, mcps, skills, prompts, runtimes, deployments
-(singular and uppercase forms also accepted, e.g. Agent, agent, agents)
+Supported types: agents, mcps, skills, prompts, runtimes, deployments,
+namespaces (singular and uppercase forms also accepted, e.g. Agent, agent,
+agents)
+
+Lists and lookups target the default namespace unless -n/--namespace is set;
+-A/--all-namespaces lists across every namespace.
 
 Examples:
   arctl get all
@@ -37,9 +41,17 @@ Examples:
   arctl get agent acme-summarizer --tag stable
   arctl get agent acme-summarizer --all-tags
   arctl get deployment team-a/acme-summarizer
+  arctl get agents -n team-a
+  arctl get agents --all-namespaces
+  arctl get namespaces
   arctl get deployments --origin discovered  # list discovered (unmanaged) deployments
   arctl get deployments --origin all         # list managed and discovered
-  arctl get skills -o json`,
+  arctl get skills -o json
+  arctl get agents -w                    # list, then stream changes until interrupted
+  arctl get mcps -l 'env in (prod,staging),!deprecated'
+  arctl get mcps --field-selector spec.source.package.origin.type=npm
+  arctl get deployments --field-selector 'status.conditions[Ready]=True'
+  arctl get referrers mcp acme-fetch     # list the resources that reference acme-fetch`,
 		Args:         cobra.RangeArgs(1, 2),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -51,6 +63,12 @@ Examples:
 	cmd.Flags().Bool("latest", false, "List mode only: restrict to rows pinned to the literal 'latest' tag (equivalent to --tag latest).")
 	cmd.Flags().Bool("all-tags", false, "List every tag of NAME (tagged content kinds only)")
 	cmd.Flags().String("origin", "", "Deployments only: filter by provenance — managed, discovered, or all (defaults to managed when unset).")
+	addNamespaceFlag(cmd)
+	cmd.Flags().BoolP("all-namespaces", "A", false, "List mode only: list across every namespace")
+	cmd.Flags().BoolP("watch", "w", false, "List mode only: after listing, stream changes until interrupted")
+	cmd.Flags().StringP("selector", "l", "", "List mode only: label selector, e.g. env in (prod,staging),tier!=experimental,!deprecated")
+	cmd.Flags().String("field-selector", "", "List mode only: field selector over spec, status and metadata.name/namespace/tag, e.g. status.conditions[Ready]=True")
+	cmd.AddCommand(newGetReferrersCmd(deps))
 	return cmd
 }
 
@@ -61,6 +79,11 @@ func runGet(cmd *cobra.Command, deps cliruntime.Deps, args []string) error {
 	latest, _ := cmd.Flags().GetBool("latest")
 	tag, _ := cmd.Flags().GetString("tag")
 	origin, _ := cmd.Flags().GetString("origin")
+	namespace, _ := cmd.Flags().GetString(namespaceFlag)
+	allNamespacesSet, _ := cmd.Flags().GetBool("all-namespaces")
+	watch, _ := cmd.Flags().GetBool("watch")
+	selector, _ := cmd.Flags().GetString("selector")
+	fieldSelector, _ := cmd.Flags().GetString("field-selector")
 	allTagsFlag := "--all-tags"
 	tagFlag := "--tag"
 	latestFlag := "--latest"
@@ -75,19 +98,45 @@ func runGet(cmd *cobra.Command, deps cliruntime.Deps, args []string) error {
 		return fmt.Errorf("%s and %s are mutually exclusive", tagFlag, latestFlag)
 	}
 
+	if allNamespacesSet {
+		if namespace != "" {
+			return fmt.Errorf("--namespace and --all-namespaces are mutually exclusive")
+		}
+		if len(args) == 2 {
+			return fmt.Errorf("--all-namespaces is a list flag and cannot be combined with a resource NAME")
+		}
+		namespace = allNamespaces
+	}
+
 	originOpt, err := resolveOrigin(origin)
 	if err != nil {
 		return err
 	}
 
+	if watch && allTags {
+		return fmt.Errorf("--watch and %s are mutually exclusive", allTagsFlag)
+	}
+	if watch && len(args) == 2 {
+		return fmt.Errorf("--watch is a list flag and cannot be combined with a resource NAME")
+	}
+	if (selector != "" || fieldSelector != "") && (allTags || len(args) == 2) {
+		return fmt.Errorf("--selector and --field-selector are list flags and cannot be combined with a resource NAME")
+	}
+
 	if args[0] == "all" {
+		if watch {
+			return fmt.Errorf("--watch cannot be used with `get all`")
+		}
 		if origin != "" {
 			return fmt.Errorf("--origin cannot be used with `get all`")
 		}
 		return runGetAllArg(cmd, deps, kinds, outputFormat, getFlags{
-			allTags: allTags,
-			latest:  latest,
-			tag:     tag,
+			allTags:       allTags,
+			latest:        latest,
+			tag:           tag,
+			namespace:     namespace,
+			selector:      selector,
+			fieldSelector: fieldSelector,
 		})
 	}
 
@@ -98,6 +147,15 @@ func runGet(cmd *cobra.Command, deps cliruntime.Deps, args []string) error {
 		return err
 	}
 
+	if err := checkNamespaceFlag(k, namespace); err != nil {
+		return err
+	}
+	if len(args) == 2 {
+		if args[1], err = qualifyName(namespace, args[1]); err != nil {
+			return err
+		}
+	}
+
 	if allTags {
 		return runGetAllTags(cmd, deps, k, args, outputFormat)
 	}
@@ -141,7 +199,17 @@ func runGet(cmd *cobra.Command, deps cliruntime.Deps, args []string) error {
 		return printItem(cmd, k, item, outputFormat)
 	}
 
-	listOpts := scheme.ListOpts{Tag: tag, LatestOnly: latest, Origin: originOpt}
+	listOpts := scheme.ListOpts{
+		Namespace:     namespace,
+		Tag:           tag,
+		LatestOnly:    latest,
+		Origin:        originOpt,
+		Labels:        selector,
+		FieldSelector: fieldSelector,
+	}
+	if watch {
+		return runGetWatch(cmd, c, k, listOpts, outputFormat)
+	}
 	items, err := listItems(cmd.Context(), c, k, listOpts)
 	if err != nil {
 		return fmt.Errorf("listing %s: %w", kindPlural(k), err)
@@ -154,9 +222,12 @@ func runGet(cmd *cobra.Command, deps cliruntime.Deps, args []string) error {
 }
 
 type getFlags struct {
-	allTags bool
-	latest  bool
-	tag     string
+	allTags       bool
+	latest        bool
+	tag           string
+	namespace     string
+	selector      string
+	fieldSelector string
 }
 
 // resolveOrigin validates the CLI --origin selector and normalizes it into
@@ -192,7 +263,11 @@ func runGetAllArg(cmd *cobra.Command, deps cliruntime.Deps, kinds *scheme.Regist
 	if err != nil {
 		return err
 	}
-	return runGetAll(cmd, kinds, c, outputFormat)
+	return runGetAll(cmd, kinds, c, outputFormat, scheme.ListOpts{
+		Namespace:     flags.namespace,
+		Labels:        flags.selector,
+		FieldSelector: flags.fieldSelector,
+	})
 }
 
 func runGetAllTags(cmd *cobra.Command, deps cliruntime.Deps, k *scheme.Kind, args []string, outputFormat string) error {
@@ -229,11 +304,11 @@ func registryClient(cmd *cobra.Command, deps cliruntime.Deps) (*client.Client, e
 	return c, nil
 }
 
-func runGetAll(cmd *cobra.Command, kinds *scheme.Registry, c *client.Client, outputFormat string) error {
+func runGetAll(cmd *cobra.Command, kinds *scheme.Registry, c *client.Client, outputFormat string, base scheme.ListOpts) error {
 	allKinds := kinds.All()
 	first := true
 	for _, k := range allKinds {
-		opts := scheme.ListOpts{}
+		opts := base
 		if strings.EqualFold(k.Kind, v1alpha1.KindDeployment) {
 			opts.Origin = v1alpha1.DeploymentOriginManaged
 		}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"sync"
 	"testing"
@@ -397,3 +398,41 @@ func TestGet_Latest_RejectsGetAll(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "--latest cannot be used with `get all`")
 }
+
+// TestGet_Selectors_ForwardedToList verifies -l and --field-selector reach
+// the list query unparsed; the server owns the selector grammar.
+func TestGet_Selectors_ForwardedToList(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		captured []url.Values
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		captured = append(captured, r.URL.Query())
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[]}`))
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	cmd := declarative.NewGetCmd(declarativeTestDeps(nil))
+	cmd.SetArgs([]string{"mcps", "-l", "env in (prod,staging),!deprecated", "--field-selector", "status.conditions[Ready]=True"})
+	require.NoError(t, cmd.Execute())
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, captured, "expected at least one server call")
+	assert.Equal(t, "env in (prod,staging),!deprecated", captured[0].Get("labels"))
+	assert.Equal(t, "status.conditions[Ready]=True", captured[0].Get("fieldSelector"))
+}
+
+func TestGet_Selectors_RejectNamedGet(t *testing.T) {
+	cmd := declarative.NewGetCmd(declarativeTestDeps(nil))
+	cmd.SetArgs([]string{"agent", "summarizer", "-l", "env=prod"})
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "list flags")
+}
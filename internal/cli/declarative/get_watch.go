@@ -0,0 +1,112 @@
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/scheme"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+)
+
+// runGetWatch implements `arctl get TYPE -w`: the current list followed by
+// one line per change until interrupted. When the server reports the watch
+// fell behind event retention the list is fetched again, as kubectl does.
+func runGetWatch(cmd *cobra.Command, c *client.Client, k *scheme.Kind, opts scheme.ListOpts, outputFormat string) error {
+	if k.Watch == nil {
+		return fmt.Errorf("watch not supported for kind %q", k.Kind)
+	}
+	for {
+		w := &watchPrinter{cmd: cmd, k: k, outputFormat: outputFormat}
+		err := k.Watch(cmd.Context(), c, opts, w.handle)
+		switch {
+		case errors.Is(err, client.ErrResourceVersionGone):
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: watch of %s expired; listing again\n", kindPlural(k))
+			continue
+		case errors.Is(err, context.Canceled):
+			return nil
+		case err != nil:
+			return fmt.Errorf("watching %s: %w", kindPlural(k), err)
+		}
+		return nil
+	}
+}
+
+// watchPrinter renders watch events. Tables buffer the initial ADDED items
+// until the first bookmark so they print as one aligned table, then print
+// each later change as a headerless row.
+type watchPrinter struct {
+	cmd          *cobra.Command
+	k            *scheme.Kind
+	outputFormat string
+
+	synced  bool
+	initial []watchRow
+}
+
+func (w *watchPrinter) handle(eventType string, item any) error {
+	if eventType == arv0.WatchEventBookmark {
+		if w.synced {
+			return nil
+		}
+		w.synced = true
+		if w.outputFormat != "json" && w.outputFormat != "yaml" {
+			return w.renderTable(true, w.initial...)
+		}
+		return nil
+	}
+	switch w.outputFormat {
+	case "json":
+		return w.printJSON(eventType, item)
+	case "yaml":
+		return w.printYAML(eventType, item)
+	}
+	if !w.synced {
+		w.initial = append(w.initial, watchRow{eventType, item})
+		return nil
+	}
+	return w.renderTable(false, watchRow{eventType, item})
+}
+
+type watchRow struct {
+	eventType string
+	item      any
+}
+
+func (w *watchPrinter) renderTable(headers bool, rows ...watchRow) error {
+	var opts []printer.Option
+	if !headers {
+		opts = append(opts, printer.WithNoHeaders())
+	}
+	t := printer.NewTablePrinter(w.cmd.OutOrStdout(), opts...)
+	t.SetHeaders(append([]string{"EVENT"}, tableColumns(w.k)...)...)
+	for _, row := range rows {
+		t.AddRow(stringsToAny(append([]string{row.eventType}, tableRow(w.k, row.item)...))...)
+	}
+	return t.Render()
+}
+
+// printJSON writes one compact event per line so the output can be piped
+// into line-oriented tools such as jq.
+func (w *watchPrinter) printJSON(eventType string, item any) error {
+	b, err := json.Marshal(map[string]any{"type": eventType, "object": item})
+	if err != nil {
+		return fmt.Errorf("encoding JSON: %w", err)
+	}
+	_, err = fmt.Fprintln(w.cmd.OutOrStdout(), string(b))
+	return err
+}
+
+func (w *watchPrinter) printYAML(eventType string, item any) error {
+	out := w.cmd.OutOrStdout()
+	if _, err := io.WriteString(out, "---\n"); err != nil {
+		return err
+	}
+	return marshalYAML(w.cmd, map[string]any{"type": eventType, "object": toYAMLValue(w.k, item)})
+}
@@ -0,0 +1,116 @@
+package declarative_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+func watchAgentEvent(t *testing.T, eventType, rv, name, title string) arv0.WatchEvent {
+	t.Helper()
+	obj, err := json.Marshal(&v1alpha1.Agent{
+		TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindAgent},
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: name, Tag: "latest"},
+		Spec:     v1alpha1.AgentSpec{Title: title},
+	})
+	require.NoError(t, err)
+	return arv0.WatchEvent{Type: eventType, ResourceVersion: rv, Object: obj}
+}
+
+func TestGetWatch_StreamsEventsAfterInitialTable(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		first := len(requests) == 1
+		if first {
+			// The first stream falls behind retention; the CLI lists again.
+			_ = enc.Encode(arv0.WatchEvent{Type: arv0.WatchEventError, Error: &arv0.WatchError{Status: http.StatusGone, Message: "too old"}})
+			return
+		}
+		for _, event := range []arv0.WatchEvent{
+			watchAgentEvent(t, arv0.WatchEventAdded, "7", "alice", "Alice"),
+			watchAgentEvent(t, arv0.WatchEventAdded, "7", "bob", "Bob"),
+			{Type: arv0.WatchEventBookmark, ResourceVersion: "7"},
+			watchAgentEvent(t, arv0.WatchEventModified, "8", "alice", "Alice v2"),
+			watchAgentEvent(t, arv0.WatchEventDeleted, "9", "bob", ""),
+		} {
+			require.NoError(t, enc.Encode(event))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	var out, errOut bytes.Buffer
+	cmd := declarative.NewGetCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	cmd.SetArgs([]string{"agents", "-w"})
+	require.NoError(t, cmd.Execute())
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, "/v0/agents?namespace=default&watch=true", requests[1])
+	assert.Contains(t, errOut.String(), "watch of agents expired")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 5, out.String())
+	assert.True(t, strings.HasPrefix(lines[0], "EVENT"), lines[0])
+	assert.Contains(t, lines[1], "ADDED")
+	assert.Contains(t, lines[1], "alice")
+	assert.Contains(t, lines[2], "bob")
+	assert.Contains(t, lines[3], "MODIFIED")
+	assert.Contains(t, lines[3], "alice")
+	assert.Contains(t, lines[4], "DELETED")
+	assert.Equal(t, 1, strings.Count(out.String(), "EVENT"), "header printed once")
+}
+
+func TestGetWatch_JSONPrintsOneEventPerLine(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		enc := json.NewEncoder(w)
+		_ = enc.Encode(watchAgentEvent(t, arv0.WatchEventAdded, "3", "alice", "Alice"))
+		_ = enc.Encode(arv0.WatchEvent{Type: arv0.WatchEventBookmark, ResourceVersion: "3"})
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	var out bytes.Buffer
+	cmd := declarative.NewGetCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"agents", "-w", "-o", "json"})
+	require.NoError(t, cmd.Execute())
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 1, "bookmarks are not printed")
+	var event struct {
+		Type   string         `json:"type"`
+		Object v1alpha1.Agent `json:"object"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &event))
+	assert.Equal(t, arv0.WatchEventAdded, event.Type)
+	assert.Equal(t, "alice", event.Object.Metadata.Name)
+}
+
+func TestGetWatch_RejectsNameAndAll(t *testing.T) {
+	for _, args := range [][]string{
+		{"agent", "alice", "-w"},
+		{"all", "-w"},
+		{"agent", "alice", "--all-tags", "-w"},
+	} {
+		cmd := declarative.NewGetCmd(declarativeTestDeps(nil))
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(args)
+		require.Error(t, cmd.Execute(), args)
+	}
+}
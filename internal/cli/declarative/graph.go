@@ -0,0 +1,224 @@
+package declarative
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+)
+
+// NewGraphCmd returns a new "graph" cobra command.
+func NewGraphCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "graph TYPE NAME",
+		Short: "Show the dependency graph of a resource",
+		Long: `Show every resource TYPE NAME depends on: an Agent's MCP servers, plugins,
+skills and instructions Prompt, and a Deployment's target, Model and Runtime,
+followed transitively. Refs are resolved the way the reconciler resolves
+them, so a ref with no tag shows the latest tag and a semver range shows the
+tag it selects today. Missing, terminating and unreadable dependencies are
+marked, and ref cycles are reported.
+
+Output formats: tree (default), dot (Graphviz), mermaid, json, yaml.
+
+Examples:
+  arctl graph agent planner
+  arctl graph agent planner --tag 1.2.0 -n team-a
+  arctl graph deployment planner -o dot | dot -Tsvg > planner.svg
+  arctl graph agent planner -o mermaid`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGraph(cmd, deps, args)
+		},
+	}
+	cmd.Flags().StringP("output", "o", "tree", "Output format: tree, dot, mermaid, yaml, json")
+	cmd.Flags().String("tag", "", "Root tag or semver range (tagged kinds only; default latest)")
+	addNamespaceFlag(cmd)
+	return cmd
+}
+
+func runGraph(cmd *cobra.Command, deps cliruntime.Deps, args []string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	tag, _ := cmd.Flags().GetString("tag")
+	namespace, _ := cmd.Flags().GetString(namespaceFlag)
+
+	k, err := kindRegistry(deps).Lookup(args[0])
+	if err != nil {
+		return err
+	}
+	kind := canonicalKindName(k)
+	if kind == "" {
+		return fmt.Errorf("type %q has no dependency graph", args[0])
+	}
+	if err := checkNamespaceFlag(k, namespace); err != nil {
+		return err
+	}
+	qualified, err := qualifyName(namespace, args[1])
+	if err != nil {
+		return err
+	}
+	ref, err := parseResourceLookupRef(qualified)
+	if err != nil {
+		return err
+	}
+
+	c, err := registryClient(cmd, deps)
+	if err != nil {
+		return err
+	}
+	graph, err := c.Graph(cmd.Context(), v1alpha1.ResourceRef{Kind: kind, Namespace: ref.Namespace, Name: ref.Name, Tag: tag})
+	if err != nil {
+		return fmt.Errorf("resolving graph of %s %q: %w", k.Kind, args[1], err)
+	}
+
+	out := cmd.OutOrStdout()
+	switch outputFormat {
+	case "yaml":
+		return marshalYAML(cmd, graph)
+	case "json":
+		return marshalJSON(cmd, graph)
+	case "dot":
+		writeGraphDOT(out, graph)
+	case "mermaid":
+		writeGraphMermaid(out, graph)
+	case "tree", "":
+		writeGraphTree(out, graph)
+	default:
+		return fmt.Errorf("unknown output format %q (want tree, dot, mermaid, yaml or json)", outputFormat)
+	}
+	return nil
+}
+
+// graphNodeLabel renders a node as "Kind namespace/name@tag".
+func graphNodeLabel(n arv0.GraphNode) string {
+	s := n.Kind + " " + n.Namespace + "/" + n.Name
+	if n.Tag != "" {
+		s += "@" + n.Tag
+	}
+	return s
+}
+
+// writeGraphTree prints the graph depth-first from the root. A node
+// reached a second time is printed once more without its children, and an
+// edge that closes a cycle is marked.
+func writeGraphTree(w io.Writer, g *arv0.GraphResponse) {
+	nodes := make(map[string]arv0.GraphNode, len(g.Nodes))
+	for _, n := range g.Nodes {
+		nodes[n.ID] = n
+	}
+	children := map[string][]arv0.GraphEdge{}
+	for _, e := range g.Edges {
+		children[e.From] = append(children[e.From], e)
+	}
+
+	printed := map[string]bool{}
+	onPath := map[string]bool{}
+	var visit func(id, prefix string)
+	visit = func(id, prefix string) {
+		printed[id] = true
+		onPath[id] = true
+		edges := children[id]
+		for i, e := range edges {
+			branch, indent := "├── ", "│   "
+			if i == len(edges)-1 {
+				branch, indent = "└── ", "    "
+			}
+			line := graphNodeLabel(nodes[e.To])
+			if e.Selector != "" && e.Selector != nodes[e.To].Tag {
+				line += " (" + e.Selector + ")"
+			}
+			line += graphNodeStatus(nodes[e.To])
+			switch {
+			case onPath[e.To]:
+				fmt.Fprintf(w, "%s%s%s [cycle]\n", prefix, branch, line)
+			case printed[e.To] && len(children[e.To]) > 0:
+				fmt.Fprintf(w, "%s%s%s (see above)\n", prefix, branch, line)
+			default:
+				fmt.Fprintf(w, "%s%s%s\n", prefix, branch, line)
+				visit(e.To, prefix+indent)
+			}
+		}
+		onPath[id] = false
+	}
+	fmt.Fprintln(w, graphNodeLabel(nodes[g.Root])+graphNodeStatus(nodes[g.Root]))
+	visit(g.Root, "")
+	if g.Truncated {
+		fmt.Fprintln(w, "\nThe graph was truncated at the server's node limit.")
+	}
+}
+
+// graphNodeStatus renders a non-Resolved status as a " [Status: message]"
+// suffix.
+func graphNodeStatus(n arv0.GraphNode) string {
+	switch {
+	case n.Status == arv0.GraphNodeResolved:
+		return ""
+	case n.Message != "":
+		return " [" + n.Status + ": " + n.Message + "]"
+	default:
+		return " [" + n.Status + "]"
+	}
+}
+
+// writeGraphDOT prints the graph in Graphviz DOT. Missing nodes are red,
+// terminating nodes dashed and forbidden nodes grey.
+func writeGraphDOT(w io.Writer, g *arv0.GraphResponse) {
+	fmt.Fprintln(w, "digraph dependencies {")
+	fmt.Fprintln(w, "  node [shape=box];")
+	for _, n := range g.Nodes {
+		attrs := fmt.Sprintf("label=%q", n.Kind+"\n"+strings.TrimPrefix(graphNodeLabel(n), n.Kind+" "))
+		switch n.Status {
+		case arv0.GraphNodeMissing:
+			attrs += ", color=red, fontcolor=red"
+		case arv0.GraphNodeTerminating:
+			attrs += ", style=dashed"
+		case arv0.GraphNodeForbidden:
+			attrs += ", color=grey, fontcolor=grey"
+		}
+		fmt.Fprintf(w, "  %q [%s];\n", n.ID, attrs)
+	}
+	for _, e := range g.Edges {
+		if e.Selector != "" {
+			fmt.Fprintf(w, "  %q -> %q [label=%q];\n", e.From, e.To, e.Selector)
+		} else {
+			fmt.Fprintf(w, "  %q -> %q;\n", e.From, e.To)
+		}
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// mermaidEscaper keeps labels inside Mermaid's quoted strings.
+var mermaidEscaper = strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;")
+
+// writeGraphMermaid prints the graph as a Mermaid flowchart. Node IDs are
+// positional (n0, n1, …) because registry IDs contain characters Mermaid
+// does not accept in identifiers.
+func writeGraphMermaid(w io.Writer, g *arv0.GraphResponse) {
+	ids := make(map[string]string, len(g.Nodes))
+	fmt.Fprintln(w, "flowchart TD")
+	for i, n := range g.Nodes {
+		ids[n.ID] = fmt.Sprintf("n%d", i)
+		label := n.Kind + "<br/>" + mermaidEscaper.Replace(strings.TrimPrefix(graphNodeLabel(n), n.Kind+" "))
+		fmt.Fprintf(w, "  %s[\"%s\"]", ids[n.ID], label)
+		if n.Status != arv0.GraphNodeResolved {
+			fmt.Fprintf(w, ":::%s", strings.ToLower(n.Status))
+		}
+		fmt.Fprintln(w)
+	}
+	for _, e := range g.Edges {
+		if e.Selector != "" {
+			fmt.Fprintf(w, "  %s -->|\"%s\"| %s\n", ids[e.From], mermaidEscaper.Replace(e.Selector), ids[e.To])
+		} else {
+			fmt.Fprintf(w, "  %s --> %s\n", ids[e.From], ids[e.To])
+		}
+	}
+	fmt.Fprintln(w, "  classDef missing stroke:#d00,color:#d00")
+	fmt.Fprintln(w, "  classDef terminating stroke-dasharray:4")
+	fmt.Fprintln(w, "  classDef forbidden stroke:#999,color:#999")
+}
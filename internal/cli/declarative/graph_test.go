@@ -0,0 +1,89 @@
+package declarative_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+)
+
+func graphServer(t *testing.T, gotURI *string) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*gotURI = r.URL.RequestURI()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(arv0.GraphResponse{
+			Root: "Deployment/team-a/web",
+			Nodes: []arv0.GraphNode{
+				{ID: "Deployment/team-a/web", Kind: "Deployment", Namespace: "team-a", Name: "web", Status: arv0.GraphNodeResolved},
+				{ID: "Agent/team-a/planner@latest", Kind: "Agent", Namespace: "team-a", Name: "planner", Tag: "latest", Status: arv0.GraphNodeResolved},
+				{ID: "MCPServer/team-a/acme-fetch@1.3.0", Kind: "MCPServer", Namespace: "team-a", Name: "acme-fetch", Tag: "1.3.0", Status: arv0.GraphNodeResolved},
+				{ID: "Skill/team-a/summarize@latest", Kind: "Skill", Namespace: "team-a", Name: "summarize", Tag: "latest", Status: arv0.GraphNodeMissing, Message: "no live object matches the ref"},
+				{ID: "Deployment/team-a/worker", Kind: "Deployment", Namespace: "team-a", Name: "worker", Status: arv0.GraphNodeTerminating},
+			},
+			Edges: []arv0.GraphEdge{
+				{From: "Deployment/team-a/web", To: "Agent/team-a/planner@latest"},
+				{From: "Agent/team-a/planner@latest", To: "MCPServer/team-a/acme-fetch@1.3.0", Selector: "^1.2"},
+				{From: "Agent/team-a/planner@latest", To: "Skill/team-a/summarize@latest"},
+				{From: "Deployment/team-a/web", To: "Deployment/team-a/worker"},
+				{From: "Deployment/team-a/worker", To: "Agent/team-a/planner@latest"},
+				{From: "Deployment/team-a/worker", To: "Deployment/team-a/web"},
+			},
+			Cycles: [][]string{{"Deployment/team-a/web", "Deployment/team-a/worker", "Deployment/team-a/web"}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+}
+
+func TestGraph_RendersTree(t *testing.T) {
+	var gotURI string
+	graphServer(t, &gotURI)
+
+	var out bytes.Buffer
+	cmd := declarative.NewGraphCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"deployment", "web", "-n", "team-a"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "/v0/graph?root=Deployment%2Fteam-a%2Fweb", gotURI)
+	assert.Equal(t, `Deployment team-a/web
+├── Agent team-a/planner@latest
+│   ├── MCPServer team-a/acme-fetch@1.3.0 (^1.2)
+│   └── Skill team-a/summarize@latest [Missing: no live object matches the ref]
+└── Deployment team-a/worker [Terminating]
+    ├── Agent team-a/planner@latest (see above)
+    └── Deployment team-a/web [cycle]
+`, out.String())
+}
+
+func TestGraph_RendersDOTAndMermaid(t *testing.T) {
+	var gotURI string
+	graphServer(t, &gotURI)
+
+	var out bytes.Buffer
+	cmd := declarative.NewGraphCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"agent", "planner", "--tag", "^1.0", "-o", "dot"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "/v0/graph?root=Agent%2Fplanner%3A%5E1.0", gotURI)
+	assert.Contains(t, out.String(), "digraph dependencies {")
+	assert.Contains(t, out.String(), `"Agent/team-a/planner@latest" -> "MCPServer/team-a/acme-fetch@1.3.0" [label="^1.2"];`)
+	assert.Contains(t, out.String(), `"Skill/team-a/summarize@latest" [label="Skill\nteam-a/summarize@latest", color=red, fontcolor=red];`)
+
+	out.Reset()
+	cmd = declarative.NewGraphCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"agent", "planner", "-o", "mermaid"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "flowchart TD")
+	assert.Contains(t, out.String(), `n3["Skill<br/>team-a/summarize@latest"]:::missing`)
+	assert.Contains(t, out.String(), `n1 -->|"^1.2"| n2`)
+}
@@ -362,7 +362,7 @@ init and add an MCP_SERVERS_CONFIG entry, e.g.:
 	cmd.Flags().StringVar(&initGit, "git", "", "Git repository URL")
 	cmd.Flags().StringVar(&initGitBranch, "git-branch", "", "Git branch to record on the agent's source repository")
 	cmd.Flags().StringVar(&initGitCommit, "git-commit", "", "Git commit SHA to pin the agent's source repository to")
-	cmd.Flags().StringSliceVar(&initMCPs, "mcp", nil, "Registry MCP server ref (name@version). Repeatable.")
+	cmd.Flags().StringArrayVar(&initMCPs, "mcp", nil, "Registry MCP server ref (name@tag or name@<semver range>, e.g. name@^1.2). Repeatable; commas are part of the ref.")
 	cmd.Flags().StringSliceVar(&initLocalMCPs, "local-mcp", nil, "Path to a sibling MCP project; wires it into .env so the local agent can reach it. Repeatable.")
 	return cmd
 }
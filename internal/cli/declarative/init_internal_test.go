@@ -2,7 +2,10 @@ package declarative
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -163,3 +166,59 @@ func TestInitAgent_MCP_RegistryFailure_NoPartialWrites(t *testing.T) {
 	_, err = os.Stat(filepath.Join(pd, ".env"))
 	assert.True(t, os.IsNotExist(err), ".env must not be written on registry failure")
 }
+
+func TestInitAgent_MCP_SemverRangeResolvesHighestTag(t *testing.T) {
+	for _, ref := range []string{"^1.2", ">=1.2, <2"} {
+		t.Run(ref, func(t *testing.T) {
+			testInitAgentMCPSemverRange(t, ref)
+		})
+	}
+}
+
+// testInitAgentMCPSemverRange runs init with --mcp acme-fetch@<ref>, a
+// range matching 1.2.0 and 1.3.1 but not 2.0.0.
+func testInitAgentMCPSemverRange(t *testing.T, ref string) {
+	dir := t.TempDir()
+	remote := func(tag string) map[string]any {
+		return map[string]any{
+			"apiVersion": v1alpha1.GroupVersion,
+			"kind":       v1alpha1.KindMCPServer,
+			"metadata":   map[string]any{"namespace": "default", "name": "acme-fetch", "tag": tag},
+			"spec": map[string]any{
+				"remote": map[string]any{"type": "streamable-http", "url": "https://mcp.acme.com/" + tag},
+			},
+		}
+	}
+	var fetched []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v0/mcpservers/acme-fetch/tags":
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": []any{remote("latest"), remote("1.2.0"), remote("1.3.1"), remote("2.0.0")}})
+		case "/v0/mcpservers/acme-fetch/1.3.1":
+			fetched = append(fetched, "1.3.1")
+			_ = json.NewEncoder(w).Encode(remote("1.3.1"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	prev := mcpFetcherForTest
+	mcpFetcherForTest = nil
+	t.Cleanup(func() { mcpFetcherForTest = prev })
+
+	cmd := NewInitCmd(internalDeclarativeTestDeps(client.NewClient(srv.URL, "")))
+	cmd.SetArgs([]string{"agent", "myagent", "--framework", "adk", "--language", "python", "--mcp", "acme-fetch@" + ref, "--output-dir", dir})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, []string{"1.3.1"}, fetched)
+
+	pd := filepath.Join(dir, "myagent")
+	env, err := os.ReadFile(filepath.Join(pd, ".env"))
+	require.NoError(t, err)
+	assert.Contains(t, string(env), `"url":"https://mcp.acme.com/1.3.1"`)
+
+	// agent.yaml keeps the range so deployments float within it.
+	agentYAML, err := os.ReadFile(filepath.Join(pd, "agent.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(agentYAML), ref)
+}
@@ -92,19 +92,26 @@ func deleteAllTags(ctx context.Context, c *client.Client, k *scheme.Kind, name s
 }
 
 // tableRow returns a []string row for the given item, matching the TableColumns
-// registered in the kinds registry.
+// registered in the kinds registry behind a leading NAMESPACE cell.
 func tableRow(k *scheme.Kind, item any) []string {
+	row := []string{"<unknown kind>"}
 	if k.RowFunc != nil {
-		return k.RowFunc(item)
+		row = k.RowFunc(item)
 	}
-	return []string{"<unknown kind>"}
+	if !isNamespaced(k) {
+		return row
+	}
+	return append([]string{itemNamespace(item)}, row...)
 }
 
 // tableColumns returns the column header strings for the given kind.
 func tableColumns(k *scheme.Kind) []string {
-	headers := make([]string, len(k.TableColumns))
-	for i, col := range k.TableColumns {
-		headers[i] = col.Header
+	headers := make([]string, 0, len(k.TableColumns)+1)
+	if isNamespaced(k) {
+		headers = append(headers, "NAMESPACE")
+	}
+	for _, col := range k.TableColumns {
+		headers = append(headers, col.Header)
 	}
 	return headers
 }
@@ -0,0 +1,105 @@
+package declarative
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/scheme"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// namespaceFlag is the -n/--namespace flag shared by get, delete, apply, and
+// wait. Unset means the default namespace.
+const namespaceFlag = "namespace"
+
+// allNamespaces is the list-endpoint sentinel for "every namespace"; see
+// client.ListOpts.Namespace.
+const allNamespaces = "all"
+
+func addNamespaceFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP(namespaceFlag, "n", "", `Namespace to operate in (defaults to "default")`)
+}
+
+// qualifyName folds the -n value into a NAME or NAMESPACE/NAME argument so
+// the per-kind dispatch closures keep a single reference grammar. An explicit
+// NAMESPACE/NAME must agree with -n when both are given.
+func qualifyName(namespace, name string) (string, error) {
+	if namespace == "" {
+		return name, nil
+	}
+	ref, err := parseResourceLookupRef(name)
+	if err != nil {
+		return "", err
+	}
+	if ref.Name != name && ref.Namespace != namespace {
+		return "", fmt.Errorf("namespace %q in %q conflicts with --namespace %q", ref.Namespace, name, namespace)
+	}
+	return namespace + "/" + ref.Name, nil
+}
+
+// listNamespace returns the client-side list scope for opts: the requested
+// namespace, or the default namespace when unset.
+func listNamespace(opts scheme.ListOpts) string {
+	if opts.Namespace == "" {
+		return v1alpha1.DefaultNamespace
+	}
+	return opts.Namespace
+}
+
+// isNamespaced reports whether resources of k live in user-chosen
+// namespaces. Namespace objects always live in the default namespace, so
+// -n does not apply to them and a NAMESPACE column would carry no
+// information.
+func isNamespaced(k *scheme.Kind) bool {
+	return k.Kind != "namespace"
+}
+
+// checkNamespaceFlag rejects -n for kinds that are not namespaced.
+func checkNamespaceFlag(k *scheme.Kind, namespace string) error {
+	if namespace != "" && namespace != allNamespaces && !isNamespaced(k) {
+		return fmt.Errorf("--namespace is not supported for %s", kindPlural(k))
+	}
+	return nil
+}
+
+// itemNamespace returns the effective namespace of a listed item, or "" when
+// the item is not a v1alpha1 envelope.
+func itemNamespace(item any) string {
+	obj, ok := item.(v1alpha1.Object)
+	if !ok {
+		return ""
+	}
+	return obj.GetMetadata().NamespaceOrDefault()
+}
+
+// setDocumentNamespace stamps namespace onto every document in data that does
+// not name one, and rejects documents that name a different namespace.
+// Namespace documents are left alone: they always live in the default
+// namespace.
+func setDocumentNamespace(data []byte, namespace string) ([]byte, error) {
+	docs, err := splitYAMLDocs(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range docs {
+		if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+			continue
+		}
+		root := doc.Content[0]
+		if scalarValue(root, "kind") == v1alpha1.KindNamespace {
+			continue
+		}
+		meta := findOrCreateMappingChild(root, "metadata")
+		switch current := scalarValue(meta, "namespace"); current {
+		case "":
+			upsertScalar(meta, "namespace", namespace)
+		case namespace:
+		default:
+			return nil, fmt.Errorf("%s %q sets metadata.namespace %q, which conflicts with --namespace %q",
+				scalarValue(root, "kind"), scalarValue(meta, "name"), current, namespace)
+		}
+	}
+	return marshalYAMLDocs(docs)
+}
@@ -0,0 +1,151 @@
+package declarative_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// newNamespaceTestServer records every request URI and answers list calls
+// with one Agent in namespace team-a.
+func newNamespaceTestServer(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+	var (
+		mu       sync.Mutex
+		captured []string
+	)
+	agent := agentTagFixture("planner", "latest")
+	agent.Metadata.Namespace = "team-a"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		captured = append(captured, r.URL.RequestURI())
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v0/agents" {
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": []v1alpha1.Agent{agent}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(agent)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), captured...)
+	}
+}
+
+func TestGet_NamespaceFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantURI string
+	}{
+		{name: "default namespace", args: []string{"agents"}, wantURI: "/v0/agents?limit=200&namespace=default"},
+		{name: "-n scopes the list", args: []string{"agents", "-n", "team-a"}, wantURI: "/v0/agents?limit=200&namespace=team-a"},
+		{name: "-A lists every namespace", args: []string{"agents", "-A"}, wantURI: "/v0/agents?limit=200&namespace=all"},
+		{name: "-n qualifies NAME", args: []string{"agent", "planner", "-n", "team-a"}, wantURI: "/v0/agents/planner?namespace=team-a"},
+		{name: "NAMESPACE/NAME agrees with -n", args: []string{"agent", "team-a/planner", "-n", "team-a"}, wantURI: "/v0/agents/planner?namespace=team-a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := newNamespaceTestServer(t)
+			setupClientForServer(t, srv)
+
+			var out bytes.Buffer
+			cmd := declarative.NewGetCmd(declarativeTestDeps(nil))
+			cmd.SetOut(&out)
+			cmd.SetArgs(tt.args)
+			require.NoError(t, cmd.Execute())
+
+			require.NotEmpty(t, requests())
+			assert.Equal(t, tt.wantURI, requests()[0])
+			assert.Contains(t, out.String(), "NAMESPACE")
+			assert.Contains(t, out.String(), "team-a")
+		})
+	}
+}
+
+func TestGet_NamespaceFlagErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "-n with -A", args: []string{"agents", "-n", "team-a", "-A"}, wantErr: "mutually exclusive"},
+		{name: "-A with NAME", args: []string{"agent", "planner", "-A"}, wantErr: "cannot be combined with a resource NAME"},
+		{name: "conflicting NAMESPACE/NAME", args: []string{"agent", "team-b/planner", "-n", "team-a"}, wantErr: "conflicts with --namespace"},
+		{name: "-n on namespaces", args: []string{"namespaces", "-n", "team-a"}, wantErr: "not supported for namespaces"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := declarative.NewGetCmd(declarativeTestDeps(nil))
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestDelete_NamespaceFlagQualifiesName(t *testing.T) {
+	srv, requests := newNamespaceTestServer(t)
+	setupClientForServer(t, srv)
+
+	cmd := declarative.NewDeleteCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"agent", "planner", "--tag", "latest", "-n", "team-a"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, []string{"/v0/agents/planner/latest?namespace=team-a"}, requests())
+}
+
+func TestApply_NamespaceFlagStampsDocuments(t *testing.T) {
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(batchApplyResponse([]arv0.ApplyResult{
+			{Kind: "agent", Name: "acme-bot", Status: arv0.ApplyStatusConfigured},
+			{Kind: "namespace", Name: "team-a", Status: arv0.ApplyStatusConfigured},
+		}))
+	}))
+	t.Cleanup(srv.Close)
+
+	path := writeTempYAML(t, agentYAML+`---
+apiVersion: ar.dev/v1alpha1
+kind: Namespace
+metadata:
+  name: team-a
+spec:
+  description: Team A
+`)
+	cmd := declarative.NewApplyCmd(applyDeps(t, srv))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"-f", path, "-n", "team-a"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, string(body), "namespace: team-a")
+	assert.Equal(t, 1, bytes.Count(body, []byte("namespace: team-a")), "Namespace documents stay in the default namespace")
+
+	conflicting := writeTempYAML(t, `apiVersion: ar.dev/v1alpha1
+kind: Agent
+metadata:
+  name: acme-bot
+  namespace: team-b
+spec:
+  image: ghcr.io/acme/bot:latest
+`)
+	cmd = declarative.NewApplyCmd(applyDeps(t, srv))
+	cmd.SetArgs([]string{"-f", conflicting, "-n", "team-a"})
+	assert.ErrorContains(t, cmd.Execute(), "conflicts with --namespace")
+}
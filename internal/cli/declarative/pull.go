@@ -106,7 +106,10 @@ func pullResource(ctx context.Context, deps cliruntime.Deps, typ, name, tag, out
 	default:
 		fmt.Printf("Cloning %s into %s\n", repo.URL, outDir)
 	}
-	if err := gitutil.CloneAndCopyContext(ctx, repo.URL, repo.Branch, repo.Commit, repo.Subfolder, outDir, false); err != nil {
+	// The caller clones with their own credentials into their own checkout,
+	// so any https or ssh host will do; plaintext remotes stay refused.
+	anyHost := gitutil.WithAllowedHosts([]string{"*"})
+	if err := gitutil.CloneAndCopyContext(ctx, repo.URL, repo.Branch, repo.Commit, repo.Subfolder, outDir, false, anyHost); err != nil {
 		return err
 	}
 	if repo.Subfolder != "" {
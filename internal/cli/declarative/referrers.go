@@ -0,0 +1,99 @@
+package declarative
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+)
+
+// newGetReferrersCmd returns the "get referrers" subcommand.
+func newGetReferrersCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "referrers TYPE NAME",
+		Short: "List the resources that reference a resource",
+		Long: `List the live resources whose references select TYPE NAME, such as the
+Agents that use an MCP server or the Deployments that target an Agent. The
+registry refuses to delete a resource while it has referrers, unless the
+delete is forced.
+
+--tag narrows the list to references that select one tag; a reference with
+no tag selects latest, and a semver range selects the highest tag it matches.
+
+Examples:
+  arctl get referrers mcp acme-fetch
+  arctl get referrers skill summarize --tag 1.2.0 -n team-a
+  arctl get referrers model default -o json`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGetReferrers(cmd, deps, args)
+		},
+	}
+	cmd.Flags().StringP("output", "o", "table", "Output format: table, yaml, json")
+	cmd.Flags().String("tag", "", "Only references that select this tag (tagged kinds only)")
+	addNamespaceFlag(cmd)
+	return cmd
+}
+
+func runGetReferrers(cmd *cobra.Command, deps cliruntime.Deps, args []string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	tag, _ := cmd.Flags().GetString("tag")
+	namespace, _ := cmd.Flags().GetString(namespaceFlag)
+
+	k, err := kindRegistry(deps).Lookup(args[0])
+	if err != nil {
+		return err
+	}
+	kind := canonicalKindName(k)
+	if kind == "" {
+		return fmt.Errorf("type %q cannot be referenced", args[0])
+	}
+	if err := checkNamespaceFlag(k, namespace); err != nil {
+		return err
+	}
+	qualified, err := qualifyName(namespace, args[1])
+	if err != nil {
+		return err
+	}
+	ref, err := parseResourceLookupRef(qualified)
+	if err != nil {
+		return err
+	}
+
+	c, err := registryClient(cmd, deps)
+	if err != nil {
+		return err
+	}
+	referrers, hidden, err := c.ListReferrers(cmd.Context(), kind, ref.Namespace, ref.Name, tag)
+	if err != nil {
+		return fmt.Errorf("listing referrers of %s %q: %w", k.Kind, args[1], err)
+	}
+
+	switch outputFormat {
+	case "yaml":
+		return marshalYAML(cmd, referrers)
+	case "json":
+		return marshalJSON(cmd, referrers)
+	}
+	if len(referrers) == 0 && hidden == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "No resources reference %s %q.\n", k.Kind, args[1])
+		return nil
+	}
+	if len(referrers) > 0 {
+		t := printer.NewTablePrinter(cmd.OutOrStdout())
+		t.SetHeaders("KIND", "NAMESPACE", "NAME", "TAG")
+		for _, r := range referrers {
+			t.AddRow(r.Kind, r.Namespace, r.Name, r.Tag)
+		}
+		if err := t.Render(); err != nil {
+			return err
+		}
+	}
+	if hidden > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "%d more referrer(s) you are not authorized to read.\n", hidden)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package declarative_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+func TestGetReferrers_RendersReferrers(t *testing.T) {
+	var gotURI string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURI = r.URL.RequestURI()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"items": []v1alpha1.ResourceRef{
+			{Kind: v1alpha1.KindAgent, Namespace: "team-a", Name: "planner", Tag: "latest"},
+			{Kind: v1alpha1.KindDeployment, Namespace: "team-a", Name: "planner"},
+		}})
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	var out bytes.Buffer
+	cmd := declarative.NewGetCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"referrers", "mcp", "acme-fetch", "-n", "team-a", "--tag", "1.2.0"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "/v0/mcpservers/acme-fetch/referrers?namespace=team-a&tag=1.2.0", gotURI)
+	assert.Contains(t, out.String(), "KIND")
+	assert.Contains(t, out.String(), "planner")
+	assert.Contains(t, out.String(), "Deployment")
+}
+
+func TestGetReferrers_NoReferrers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[]}`))
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	var out bytes.Buffer
+	cmd := declarative.NewGetCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"referrers", "skill", "summarize"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "No resources reference")
+}
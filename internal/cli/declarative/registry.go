@@ -0,0 +1,321 @@
+package declarative
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+)
+
+// exportFormatVersion is the version of the export layout written by
+// "arctl registry export". Import refuses exports from a newer version.
+const exportFormatVersion = 1
+
+const (
+	// exportHeaderPrefix starts the comment line that versions a YAML
+	// export, e.g. "# agentregistry-export-format: 1".
+	exportHeaderPrefix = "# agentregistry-export-format:"
+	// Entries of a tarball export.
+	exportManifestFile  = "manifest.json"
+	exportResourcesFile = "resources.yaml"
+)
+
+// exportManifest describes a tarball export.
+type exportManifest struct {
+	FormatVersion int       `json:"formatVersion"`
+	ExportedAt    time.Time `json:"exportedAt"`
+	// Source is the registry URL the export was read from.
+	Source        string         `json:"source,omitempty"`
+	IncludeStatus bool           `json:"includeStatus"`
+	Kinds         map[string]int `json:"kinds"`
+}
+
+// NewRegistryCmd returns a new "registry" cobra command.
+func NewRegistryCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "registry",
+		Short: "Export and import the whole registry",
+	}
+	cmd.AddCommand(newRegistryExportCmd(deps))
+	cmd.AddCommand(newRegistryImportCmd(deps))
+	return cmd
+}
+
+// writeExportYAML writes objects to w as multi-doc YAML under the
+// versioned export header and returns the number of bytes written.
+func writeExportYAML(w io.Writer, objects []v1alpha1.Object, exportedAt time.Time) (int64, error) {
+	cw := &countingWriter{w: w}
+	if _, err := fmt.Fprintf(cw, "%s %d\n# exportedAt: %s\n", exportHeaderPrefix, exportFormatVersion, exportedAt.UTC().Format(time.RFC3339)); err != nil {
+		return cw.n, err
+	}
+	err := writeYAMLDocs(cw, objects)
+	return cw.n, err
+}
+
+// encodeYAMLDocs renders objects as "---"-separated YAML documents.
+func encodeYAMLDocs(objects []v1alpha1.Object) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeYAMLDocs(&buf, objects); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeYAMLDocs writes objects to w as "---"-separated YAML documents, one
+// object at a time.
+func writeYAMLDocs(w io.Writer, objects []v1alpha1.Object) error {
+	for i, obj := range objects {
+		b, err := yaml.Marshal(obj)
+		if err != nil {
+			meta := obj.GetMetadata()
+			return fmt.Errorf("encoding %s/%s: %w", obj.GetKind(), meta.Name, err)
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// writeExportArchive writes a gzipped tarball holding manifest and the
+// YAML export of objects. A tar entry declares its size before its
+// content, so resources.yaml is encoded once to size it and again straight
+// into the archive, rather than held in memory.
+func writeExportArchive(w io.Writer, manifest exportManifest, objects []v1alpha1.Object) error {
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding manifest: %w", err)
+	}
+	manifestJSON = append(manifestJSON, '\n')
+	resourcesSize, err := writeExportYAML(io.Discard, objects, manifest.ExportedAt)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, f := range []struct {
+		name  string
+		size  int64
+		write func(io.Writer) error
+	}{
+		{exportManifestFile, int64(len(manifestJSON)), func(w io.Writer) error {
+			_, err := w.Write(manifestJSON)
+			return err
+		}},
+		{exportResourcesFile, resourcesSize, func(w io.Writer) error {
+			_, err := writeExportYAML(w, objects, manifest.ExportedAt)
+			return err
+		}},
+	} {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    f.name,
+			Mode:    0o644,
+			Size:    f.size,
+			ModTime: manifest.ExportedAt,
+		}); err != nil {
+			return fmt.Errorf("writing %s: %w", f.name, err)
+		}
+		if err := f.write(tw); err != nil {
+			return fmt.Errorf("writing %s: %w", f.name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// readExport returns the YAML documents of an export, which is either a
+// tarball written by writeExportArchive or multi-doc YAML. Plain YAML
+// without the export header, such as a hand-written manifest, is accepted
+// as is.
+func readExport(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		if err := checkYAMLExportHeader(data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("reading export archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	var (
+		manifest  *exportManifest
+		resources []byte
+	)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading export archive: %w", err)
+		}
+		switch hdr.Name {
+		case exportManifestFile:
+			manifest = &exportManifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, fmt.Errorf("reading %s: %w", exportManifestFile, err)
+			}
+		case exportResourcesFile:
+			if resources, err = io.ReadAll(tr); err != nil {
+				return nil, fmt.Errorf("reading %s: %w", exportResourcesFile, err)
+			}
+		}
+	}
+	if manifest == nil || resources == nil {
+		return nil, fmt.Errorf("export archive must contain %s and %s", exportManifestFile, exportResourcesFile)
+	}
+	if manifest.FormatVersion > exportFormatVersion {
+		return nil, fmt.Errorf("export format %d is newer than this arctl supports (%d)", manifest.FormatVersion, exportFormatVersion)
+	}
+	return resources, nil
+}
+
+// checkYAMLExportHeader rejects a YAML export whose header names a format
+// newer than exportFormatVersion.
+func checkYAMLExportHeader(data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "#") {
+			return nil
+		}
+		value, ok := strings.CutPrefix(line, exportHeaderPrefix)
+		if !ok {
+			continue
+		}
+		version, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("malformed export header %q", line)
+		}
+		if version > exportFormatVersion {
+			return fmt.Errorf("export format %d is newer than this arctl supports (%d)", version, exportFormatVersion)
+		}
+		return nil
+	}
+	return nil
+}
+
+// objectIdentity keys an object by kind, namespace and name, ignoring the
+// tag: references may name a tag by semver range, so a dependency on a
+// name is a dependency on every tag of it.
+func objectIdentity(kind, namespace, name string) string {
+	if namespace == "" {
+		namespace = v1alpha1.DefaultNamespace
+	}
+	return strings.ToLower(kind) + "/" + namespace + "/" + name
+}
+
+// orderByDependencies stably reorders objects so every object follows the
+// objects it references, as reported by its ResolveRefs, and the Namespace
+// object of its namespace. References to objects outside the set are
+// ignored; so are references that close a cycle.
+func orderByDependencies(ctx context.Context, objects []v1alpha1.Object) []v1alpha1.Object {
+	byIdentity := make(map[string][]int, len(objects))
+	for i, obj := range objects {
+		meta := obj.GetMetadata()
+		key := objectIdentity(obj.GetKind(), meta.Namespace, meta.Name)
+		byIdentity[key] = append(byIdentity[key], i)
+	}
+
+	deps := make([][]int, len(objects))
+	for i, obj := range objects {
+		meta := obj.GetMetadata()
+		refs := []v1alpha1.ResourceRef{{Kind: v1alpha1.KindNamespace, Name: meta.NamespaceOrDefault()}}
+		_ = v1alpha1.ResolveObjectRefs(ctx, obj, func(_ context.Context, ref v1alpha1.ResourceRef) error {
+			if ref.Namespace == "" {
+				ref.Namespace = meta.NamespaceOrDefault()
+			}
+			refs = append(refs, ref)
+			return nil
+		})
+		for _, ref := range refs {
+			namespace := ref.Namespace
+			if strings.EqualFold(ref.Kind, v1alpha1.KindNamespace) {
+				namespace = v1alpha1.DefaultNamespace
+			}
+			for _, j := range byIdentity[objectIdentity(ref.Kind, namespace, ref.Name)] {
+				if j != i {
+					deps[i] = append(deps[i], j)
+				}
+			}
+		}
+	}
+
+	// depth is the length of the longest dependency chain below each
+	// object; sorting by it keeps every object after its dependencies.
+	const (
+		unvisited = -2
+		visiting  = -1
+	)
+	depth := make([]int, len(objects))
+	for i := range depth {
+		depth[i] = unvisited
+	}
+	var visit func(i int) int
+	visit = func(i int) int {
+		switch depth[i] {
+		case visiting:
+			return -1
+		case unvisited:
+		default:
+			return depth[i]
+		}
+		depth[i] = visiting
+		d := 0
+		for _, j := range deps[i] {
+			d = max(d, visit(j)+1)
+		}
+		depth[i] = d
+		return d
+	}
+	order := make([]int, len(objects))
+	for i := range objects {
+		visit(i)
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return depth[a] - depth[b] })
+
+	out := make([]v1alpha1.Object, len(objects))
+	for i, idx := range order {
+		out[i] = objects[idx]
+	}
+	return out
+}
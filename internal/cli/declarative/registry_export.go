@@ -0,0 +1,171 @@
+package declarative
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+)
+
+func newRegistryExportCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export every resource in the registry",
+		Long: `Export every object of every registered kind, in every namespace and with
+every tag, for backup or for moving a catalog to another registry. Kinds
+registered by extensions are included. Server-managed metadata (uid,
+resourceVersion, timestamps) is dropped, except that deprecation and yank
+markers are kept for import to restore; status is dropped unless
+--include-status is set. Import cannot restore status, so it refuses an
+export carrying status unless given --ignore-status.
+
+Objects are written in dependency order, so an export can be replayed with
+"arctl registry import" or "arctl apply -f". Discovered deployments are not
+exported.
+
+--format yaml writes multi-doc YAML. --format tar writes a gzipped tarball
+holding manifest.json and resources.yaml. The format defaults to tar when
+--file ends in .tar.gz or .tgz, and to yaml otherwise.
+
+Examples:
+  arctl registry export > catalog.yaml
+  arctl registry export -f backup.tar.gz --include-status`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runRegistryExport(cmd, deps)
+		},
+	}
+	cmd.Flags().StringP("file", "f", "-", "File to write the export to (- for stdout)")
+	cmd.Flags().String("format", "", "Export format: yaml or tar (default from --file)")
+	cmd.Flags().Bool("include-status", false, "Include each object's status")
+	return cmd
+}
+
+func runRegistryExport(cmd *cobra.Command, deps cliruntime.Deps) error {
+	path, _ := cmd.Flags().GetString("file")
+	format, _ := cmd.Flags().GetString("format")
+	includeStatus, _ := cmd.Flags().GetBool("include-status")
+	if format == "" {
+		format = "yaml"
+		if strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz") {
+			format = "tar"
+		}
+	}
+	if format != "yaml" && format != "tar" {
+		return fmt.Errorf("--format must be yaml or tar, got %q", format)
+	}
+
+	c, err := registryClient(cmd, deps)
+	if err != nil {
+		return err
+	}
+	objects, err := listRegistryObjects(cmd.Context(), c, v1alpha1.KindDescriptors(), cmd.ErrOrStderr())
+	if err != nil {
+		return err
+	}
+	manifest := exportManifest{
+		FormatVersion: exportFormatVersion,
+		ExportedAt:    time.Now().UTC().Truncate(time.Second),
+		Source:        c.BaseURL,
+		IncludeStatus: includeStatus,
+		Kinds:         map[string]int{},
+	}
+	for _, obj := range objects {
+		manifest.Kinds[obj.GetKind()]++
+		if !includeStatus {
+			if err := obj.UnmarshalStatus(nil); err != nil {
+				return fmt.Errorf("dropping status: %w", err)
+			}
+		}
+	}
+	// Dependency order needs every object, so the decoded objects are held;
+	// their encoding streams straight to the output.
+	objects = orderByDependencies(cmd.Context(), objects)
+
+	write := func(w io.Writer) error {
+		bw := bufio.NewWriter(w)
+		var err error
+		if format == "tar" {
+			err = writeExportArchive(bw, manifest, objects)
+		} else {
+			_, err = writeExportYAML(bw, objects, manifest.ExportedAt)
+		}
+		if err != nil {
+			return err
+		}
+		return bw.Flush()
+	}
+	if path == "-" {
+		return write(cmd.OutOrStdout())
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("writing export: %w", err)
+	}
+	if err := errors.Join(write(f), f.Close()); err != nil {
+		_ = os.Remove(path)
+		return fmt.Errorf("writing export: %w", err)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d resources to %s\n", len(objects), path)
+	return nil
+}
+
+// listRegistryObjects lists every object of each kind in descriptors across
+// all namespaces and tags, without server-managed metadata other than the
+// deprecation and yank markers, whose timestamps are dropped. Kinds the
+// server does not serve are reported to warn and skipped.
+func listRegistryObjects(ctx context.Context, c *client.Client, descriptors []v1alpha1.KindDescriptor, warn io.Writer) ([]v1alpha1.Object, error) {
+	var out []v1alpha1.Object
+	for _, d := range descriptors {
+		opts := client.ListOpts{Namespace: "all", Limit: 200}
+		if d.Kind == v1alpha1.KindDeployment {
+			opts.Origin = v1alpha1.DeploymentOriginManaged
+		}
+		newObj := func() v1alpha1.Object { return d.NewObject().(v1alpha1.Object) }
+		for {
+			rows, next, err := c.List(ctx, d.Kind, opts)
+			if errors.Is(err, client.ErrNotFound) {
+				fmt.Fprintf(warn, "Skipping %s: not served by this registry\n", d.Kind)
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("listing %s: %w", d.Kind, err)
+			}
+			for i := range rows {
+				obj, err := v1alpha1.EnvelopeFromRaw(newObj, &rows[i], d.Kind)
+				if err != nil {
+					return nil, fmt.Errorf("decoding %s %s: %w", d.Kind, rows[i].Metadata.Name, err)
+				}
+				meta := obj.GetMetadata()
+				meta.UID = ""
+				meta.ResourceVersion = ""
+				meta.CreatedAt = time.Time{}
+				meta.UpdatedAt = time.Time{}
+				meta.DeletionTimestamp = nil
+				if meta.Deprecated != nil {
+					meta.Deprecated.DeprecatedAt = time.Time{}
+				}
+				if meta.Yanked != nil {
+					meta.Yanked.YankedAt = time.Time{}
+				}
+				out = append(out, obj)
+			}
+			if next == "" {
+				break
+			}
+			opts.Cursor = next
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,475 @@
+package declarative
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+)
+
+// importBatchSize caps the documents sent per /v0/apply request. The
+// server applies a batch in document order, so dependency order holds
+// across batches too.
+const importBatchSize = 50
+
+// Conflict strategies for objects that exist with different content.
+const (
+	conflictSkip      = "skip"
+	conflictOverwrite = "overwrite"
+	conflictFail      = "fail"
+)
+
+// Import plan actions.
+const (
+	importCreate    = "create"
+	importUpdate    = "update"
+	importUnchanged = "unchanged"
+	importSkip      = "skip"
+	importConflict  = "conflict"
+)
+
+type importStep struct {
+	obj    v1alpha1.Object
+	action string
+	// current is the registry's copy of obj, if it has one.
+	current v1alpha1.Object
+}
+
+func newRegistryImportCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import an export into the registry",
+		Long: `Replay an export written by "arctl registry export" (YAML or tarball) into
+the registry. Objects are applied in dependency order, so everything an
+object references is applied before it. Objects identical to the registry's
+are left alone.
+
+--on-conflict decides what happens to objects that already exist with
+different labels, annotations or spec:
+  fail       apply nothing and list the conflicts (default)
+  skip       keep the registry's object
+  overwrite  replace it with the exported one
+
+Apply does not write the deprecation and yank markers an export carries, so
+import sets them afterwards on the tags it created, updated or left
+unchanged. Markers the registry's tags already carry are kept.
+
+Status is not imported; controllers recompute it. An export made with
+--include-status is refused unless --ignore-status acknowledges that its
+status is left behind. Applying a Deployment deploys it, as with
+"arctl apply".
+
+Examples:
+  arctl registry import -f backup.tar.gz --dry-run
+  arctl registry import -f catalog.yaml --on-conflict skip`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runRegistryImport(cmd, deps)
+		},
+	}
+	cmd.Flags().StringP("file", "f", "", "Export to import (- for stdin)")
+	_ = cmd.MarkFlagRequired("file")
+	cmd.Flags().String("on-conflict", conflictFail, "What to do with objects that exist with different content: fail, skip, overwrite")
+	cmd.Flags().Bool("dry-run", false, "Print what the import would do without changing the registry")
+	cmd.Flags().Bool("ignore-status", false, "Import an export that carries status, leaving the status for controllers to recompute")
+	cmd.Flags().Bool("overwrite-immutable-tags", false,
+		"With --on-conflict overwrite, also replace immutable tags (registry admins only; audited)")
+	return cmd
+}
+
+func runRegistryImport(cmd *cobra.Command, deps cliruntime.Deps) error {
+	path, _ := cmd.Flags().GetString("file")
+	strategy, _ := cmd.Flags().GetString("on-conflict")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	ignoreStatus, _ := cmd.Flags().GetBool("ignore-status")
+	overwriteImmutableTags, _ := cmd.Flags().GetBool("overwrite-immutable-tags")
+	switch strategy {
+	case conflictSkip, conflictOverwrite, conflictFail:
+	default:
+		return fmt.Errorf("--on-conflict must be fail, skip or overwrite, got %q", strategy)
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	docs, err := readExport(data)
+	if err != nil {
+		return err
+	}
+	objects, withStatus, err := decodeExport(docs)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if withStatus > 0 && !ignoreStatus {
+		return fmt.Errorf("%d resources carry status, which import cannot restore; re-run with --ignore-status to import them without it", withStatus)
+	}
+	objects = orderByDependencies(cmd.Context(), objects)
+
+	c, err := registryClient(cmd, deps)
+	if err != nil {
+		return err
+	}
+	steps, err := planImport(cmd, c, objects, strategy)
+	if err != nil {
+		return err
+	}
+
+	var conflicts int
+	for _, s := range steps {
+		if s.action == importConflict {
+			conflicts++
+		}
+	}
+	if dryRun || conflicts > 0 {
+		if err := printImportPlan(cmd.OutOrStdout(), steps); err != nil {
+			return err
+		}
+	}
+	if conflicts > 0 {
+		return fmt.Errorf("%d resources exist with different content; re-run with --on-conflict skip or overwrite", conflicts)
+	}
+	markers := pendingTagMarkers(steps)
+	if dryRun {
+		for _, m := range markers {
+			fmt.Fprintf(cmd.OutOrStdout(), "- %s would be %s\n", importStepName(m.obj), m.state())
+		}
+		return nil
+	}
+
+	var toApply []v1alpha1.Object
+	for _, s := range steps {
+		switch s.action {
+		case importCreate, importUpdate:
+			toApply = append(toApply, s.obj)
+		default:
+			fmt.Fprintf(cmd.OutOrStdout(), "- %s %s\n", importStepName(s.obj), importStepStatus(s.action))
+		}
+	}
+	var failed int
+	failedKeys := map[string]bool{}
+	for start := 0; start < len(toApply); start += importBatchSize {
+		batch := toApply[start:min(start+importBatchSize, len(toApply))]
+		body, err := encodeYAMLDocs(batch)
+		if err != nil {
+			return err
+		}
+		results, err := c.Apply(cmd.Context(), body, client.ApplyOpts{
+			OverwriteImmutableTags: overwriteImmutableTags && strategy == conflictOverwrite,
+		})
+		if err != nil {
+			return fmt.Errorf("applying: %w", err)
+		}
+		printResults(cmd.OutOrStdout(), results, false)
+		for _, r := range results {
+			if r.Status == arv0.ApplyStatusFailed {
+				failed++
+				failedKeys[resultKey(r)] = true
+			}
+		}
+	}
+	for _, m := range markers {
+		if failedKeys[importKey(m.obj)] {
+			continue
+		}
+		if err := m.restore(cmd.Context(), c); err != nil {
+			fmt.Fprintf(cmd.OutOrStdout(), "- %s failed: %v\n", importStepName(m.obj), err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "- %s %s\n", importStepName(m.obj), m.state())
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d resources failed to import", failed)
+	}
+	return nil
+}
+
+// tagMarkers are the deprecation and yank markers import sets on one tag.
+type tagMarkers struct {
+	obj        v1alpha1.Object
+	deprecated *v1alpha1.Deprecation
+	yanked     *v1alpha1.Yank
+}
+
+// pendingTagMarkers returns the markers of exported tags that the registry's
+// tags lack once the import is applied. Apply leaves a created tag
+// unmarked and clears the markers of a tag it republishes, so only an
+// unchanged tag keeps the markers the registry holds. Skipped tags keep
+// the registry's markers.
+func pendingTagMarkers(steps []importStep) []tagMarkers {
+	var out []tagMarkers
+	for _, s := range steps {
+		if s.action != importCreate && s.action != importUpdate && s.action != importUnchanged {
+			continue
+		}
+		meta := s.obj.GetMetadata()
+		var have *v1alpha1.ObjectMeta
+		if s.action == importUnchanged {
+			have = s.current.GetMetadata()
+		}
+		m := tagMarkers{obj: s.obj}
+		if meta.Deprecated != nil && (have == nil || !sameDeprecation(have.Deprecated, meta.Deprecated)) {
+			m.deprecated = meta.Deprecated
+		}
+		if meta.Yanked != nil && (have == nil || have.Yanked == nil || have.Yanked.Reason != meta.Yanked.Reason) {
+			m.yanked = meta.Yanked
+		}
+		if m.deprecated != nil || m.yanked != nil {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// sameDeprecation reports whether a and b mark a tag alike, ignoring when.
+func sameDeprecation(a, b *v1alpha1.Deprecation) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Message == b.Message && reflect.DeepEqual(a.Replacement, b.Replacement)
+}
+
+// restore sets m's markers through the deprecation and yank endpoints.
+func (m tagMarkers) restore(ctx context.Context, c *client.Client) error {
+	meta := m.obj.GetMetadata()
+	tag := meta.Tag
+	if tag == "" {
+		tag = "latest"
+	}
+	if m.deprecated != nil {
+		if _, err := c.Deprecate(ctx, m.obj.GetKind(), meta.Namespace, meta.Name, tag, m.deprecated); err != nil {
+			return fmt.Errorf("deprecating: %w", err)
+		}
+	}
+	if m.yanked != nil {
+		if _, err := c.Yank(ctx, m.obj.GetKind(), meta.Namespace, meta.Name, tag, m.yanked); err != nil {
+			return fmt.Errorf("yanking: %w", err)
+		}
+	}
+	return nil
+}
+
+func (m tagMarkers) state() string {
+	switch {
+	case m.deprecated != nil && m.yanked != nil:
+		return "deprecated and yanked"
+	case m.yanked != nil:
+		return "yanked"
+	}
+	return "deprecated"
+}
+
+// planImport decides, for each object in order, whether importing it
+// creates, updates or leaves alone the registry's copy.
+func planImport(cmd *cobra.Command, c *client.Client, objects []v1alpha1.Object, strategy string) ([]importStep, error) {
+	var descriptors []v1alpha1.KindDescriptor
+	seen := map[string]bool{}
+	for _, obj := range objects {
+		d, ok := v1alpha1.KindDescriptorFor(obj.GetKind())
+		if !ok {
+			return nil, fmt.Errorf("unknown kind %q", obj.GetKind())
+		}
+		if !seen[d.Kind] {
+			seen[d.Kind] = true
+			descriptors = append(descriptors, d)
+		}
+	}
+	existing, err := listRegistryObjects(cmd.Context(), c, descriptors, cmd.ErrOrStderr())
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[string]v1alpha1.Object, len(existing))
+	for _, obj := range existing {
+		current[importKey(obj)] = obj
+	}
+
+	steps := make([]importStep, 0, len(objects))
+	for _, obj := range objects {
+		step := importStep{obj: obj, action: importCreate}
+		if have, ok := current[importKey(obj)]; ok {
+			same, err := sameContent(have, obj)
+			if err != nil {
+				return nil, err
+			}
+			step.current = have
+			switch {
+			case same:
+				step.action = importUnchanged
+			case strategy == conflictOverwrite:
+				step.action = importUpdate
+			case strategy == conflictSkip:
+				step.action = importSkip
+			default:
+				step.action = importConflict
+			}
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// decodeExport decodes the YAML documents of an export like
+// scheme.DecodeBytes, which resets status, and also returns how many of
+// them carried a status other than the empty status of their kind.
+func decodeExport(docs []byte) ([]v1alpha1.Object, int, error) {
+	decoded, err := v1alpha1.Default.DecodeMulti(docs)
+	if err != nil {
+		return nil, 0, err
+	}
+	objects := make([]v1alpha1.Object, 0, len(decoded))
+	for _, item := range decoded {
+		obj, ok := item.(v1alpha1.Object)
+		if !ok {
+			return nil, 0, fmt.Errorf("decoded value does not implement v1alpha1.Object: %T", item)
+		}
+		objects = append(objects, obj)
+	}
+	withStatus, err := countWithStatus(objects)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, obj := range objects {
+		if err := obj.UnmarshalStatus(nil); err != nil {
+			return nil, 0, fmt.Errorf("reset status: %w", err)
+		}
+	}
+	return objects, withStatus, nil
+}
+
+// countWithStatus returns how many of objects carry a status other than
+// the empty status of their kind.
+func countWithStatus(objects []v1alpha1.Object) (int, error) {
+	empty := map[string][]byte{}
+	var n int
+	for _, obj := range objects {
+		kind := obj.GetKind()
+		if _, ok := empty[kind]; !ok {
+			d, ok := v1alpha1.KindDescriptorFor(kind)
+			if !ok {
+				return 0, fmt.Errorf("unknown kind %q", kind)
+			}
+			raw, err := d.NewObject().(v1alpha1.Object).MarshalStatus()
+			if err != nil {
+				return 0, fmt.Errorf("encoding %s status: %w", kind, err)
+			}
+			empty[kind] = raw
+		}
+		raw, err := obj.MarshalStatus()
+		if err != nil {
+			return 0, fmt.Errorf("encoding %s status: %w", kind, err)
+		}
+		if !bytes.Equal(raw, empty[kind]) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// importKey identifies the row obj is applied to: its tag, defaulting to
+// latest, for tagged kinds, and its name alone for mutable objects.
+func importKey(obj v1alpha1.Object) string {
+	meta := obj.GetMetadata()
+	key := objectIdentity(obj.GetKind(), meta.Namespace, meta.Name)
+	if v1alpha1.IsTaggedArtifactKind(obj.GetKind()) {
+		tag := meta.Tag
+		if tag == "" {
+			tag = "latest"
+		}
+		key += "@" + tag
+	}
+	return key
+}
+
+// resultKey is importKey for the object an apply result reports on.
+func resultKey(r arv0.ApplyResult) string {
+	key := objectIdentity(r.Kind, r.Namespace, r.Name)
+	if v1alpha1.IsTaggedArtifactKind(r.Kind) {
+		tag := r.Tag
+		if tag == "" {
+			tag = "latest"
+		}
+		key += "@" + tag
+	}
+	return key
+}
+
+// sameContent reports whether a and b have equal labels, annotations and
+// spec, the content apply writes.
+func sameContent(a, b v1alpha1.Object) (bool, error) {
+	am, bm := a.GetMetadata(), b.GetMetadata()
+	if !maps.Equal(am.Labels, bm.Labels) || !maps.Equal(am.Annotations, bm.Annotations) {
+		return false, nil
+	}
+	specs := make([]any, 2)
+	for i, obj := range []v1alpha1.Object{a, b} {
+		raw, err := obj.MarshalSpec()
+		if err != nil {
+			return false, fmt.Errorf("encoding %s spec: %w", obj.GetKind(), err)
+		}
+		if err := json.Unmarshal(raw, &specs[i]); err != nil {
+			return false, fmt.Errorf("decoding %s spec: %w", obj.GetKind(), err)
+		}
+	}
+	return reflect.DeepEqual(specs[0], specs[1]), nil
+}
+
+func printImportPlan(out io.Writer, steps []importStep) error {
+	counts := map[string]int{}
+	t := printer.NewTablePrinter(out)
+	t.SetHeaders("ACTION", "KIND", "NAMESPACE", "NAME", "TAG")
+	for _, s := range steps {
+		meta := s.obj.GetMetadata()
+		counts[s.action]++
+		t.AddRow(s.action, s.obj.GetKind(), meta.NamespaceOrDefault(), meta.Name, meta.Tag)
+	}
+	if err := t.Render(); err != nil {
+		return err
+	}
+	var summary []string
+	for _, action := range []string{importCreate, importUpdate, importUnchanged, importSkip, importConflict} {
+		if counts[action] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[action], action))
+		}
+	}
+	fmt.Fprintf(out, "\n%d resources: %s\n", len(steps), strings.Join(summary, ", "))
+	return nil
+}
+
+func importStepName(obj v1alpha1.Object) string {
+	meta := obj.GetMetadata()
+	name := obj.GetKind() + "/" + meta.Name
+	if meta.Tag != "" {
+		name += " (" + meta.Tag + ")"
+	}
+	return name
+}
+
+func importStepStatus(action string) string {
+	if action == importSkip {
+		return "skipped: exists with different content"
+	}
+	return action
+}
@@ -0,0 +1,289 @@
+package declarative_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+)
+
+const (
+	exportAgentJSON = `{"apiVersion":"ar.dev/v1alpha1","kind":"Agent",
+		"metadata":{"namespace":"team-a","name":"summarizer","tag":"1.0.0","resourceVersion":"12","uid":"u-1","createdAt":"2026-10-01T09:00:00Z"},
+		"spec":{"title":"Summarizer","mcpServers":[{"kind":"MCPServer","name":"weather","tag":"^1.2"}]},
+		"status":{"conditions":[{"type":"Ready","status":"True"}]}}`
+	exportMCPJSON = `{"apiVersion":"ar.dev/v1alpha1","kind":"MCPServer",
+		"metadata":{"namespace":"team-a","name":"weather","tag":"1.2.0","resourceVersion":"9"},
+		"spec":{"title":"Weather"}}`
+	exportNamespaceJSON = `{"apiVersion":"ar.dev/v1alpha1","kind":"Namespace",
+		"metadata":{"namespace":"default","name":"team-a"},
+		"spec":{"description":"Team A"}}`
+)
+
+// fakeRegistry serves list pages from items (plural -> JSON objects) and
+// records every /v0/apply body and tag marker write.
+type fakeRegistry struct {
+	mu       sync.Mutex
+	items    map[string][]string
+	listURIs []string
+	applied  []string
+	marked   []string
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == http.MethodPost && r.URL.Path == "/v0/apply" {
+		body, _ := io.ReadAll(r.Body)
+		f.applied = append(f.applied, string(body))
+		_ = json.NewEncoder(w).Encode(arv0.ApplyResultsResponse{Results: []arv0.ApplyResult{
+			{Kind: "Agent", Name: "summarizer", Tag: "1.0.0", Status: arv0.ApplyStatusCreated},
+		}})
+		return
+	}
+	if r.Method == http.MethodPut {
+		body, _ := io.ReadAll(r.Body)
+		f.marked = append(f.marked, r.URL.Path+" "+string(body))
+		_, _ = io.WriteString(w, `{}`)
+		return
+	}
+	f.listURIs = append(f.listURIs, r.URL.RequestURI())
+	plural := strings.TrimPrefix(r.URL.Path, "/v0/")
+	_, _ = io.WriteString(w, `{"items":[`+strings.Join(f.items[plural], ",")+`]}`)
+}
+
+func TestRegistryExport_DependencyOrderedYAML(t *testing.T) {
+	fake := &fakeRegistry{items: map[string][]string{
+		"agents":     {exportAgentJSON},
+		"mcpservers": {exportMCPJSON},
+		"namespaces": {exportNamespaceJSON},
+	}}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	var out bytes.Buffer
+	cmd := declarative.NewRegistryCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"export"})
+	require.NoError(t, cmd.Execute())
+
+	got := out.String()
+	require.True(t, strings.HasPrefix(got, "# agentregistry-export-format: 1\n"), got)
+	ns, mcp, agent := strings.Index(got, "\nkind: Namespace\n"), strings.Index(got, "\nkind: MCPServer\n"), strings.Index(got, "\nkind: Agent\n")
+	require.True(t, ns >= 0 && mcp >= 0 && agent >= 0, got)
+	assert.Less(t, ns, mcp, "namespace must precede the objects in it")
+	assert.Less(t, mcp, agent, "referenced MCP server must precede the agent")
+	assert.NotContains(t, got, "resourceVersion")
+	assert.NotContains(t, got, "uid:")
+	assert.NotContains(t, got, "createdAt")
+	assert.NotContains(t, got, "conditions", "status is dropped without --include-status")
+	assert.Contains(t, fake.listURIs, "/v0/deployments?limit=200&namespace=all&origin=managed")
+	assert.Contains(t, fake.listURIs, "/v0/agents?limit=200&namespace=all")
+}
+
+func TestRegistryExport_TarballImportsIntoEmptyRegistry(t *testing.T) {
+	source := &fakeRegistry{items: map[string][]string{
+		"agents":     {exportAgentJSON},
+		"mcpservers": {exportMCPJSON},
+	}}
+	srv := httptest.NewServer(source)
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	cmd := declarative.NewRegistryCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"export", "-f", archive, "--include-status"})
+	require.NoError(t, cmd.Execute())
+
+	target := &fakeRegistry{}
+	targetSrv := httptest.NewServer(target)
+	t.Cleanup(targetSrv.Close)
+	setupClientForServer(t, targetSrv)
+
+	cmd = declarative.NewRegistryCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"import", "-f", archive, "--dry-run"})
+	require.ErrorContains(t, cmd.Execute(), "1 resources carry status, which import cannot restore")
+
+	var out bytes.Buffer
+	cmd = declarative.NewRegistryCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"import", "-f", archive, "--dry-run", "--ignore-status"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Contains(t, out.String(), "2 resources: 2 create")
+	assert.Empty(t, target.applied, "dry run must not apply")
+
+	cmd = declarative.NewRegistryCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"import", "-f", archive, "--ignore-status"})
+	require.NoError(t, cmd.Execute())
+
+	require.Len(t, target.applied, 1)
+	body := target.applied[0]
+	assert.Less(t, strings.Index(body, "\nkind: MCPServer\n"), strings.Index(body, "\nkind: Agent\n"))
+	assert.NotContains(t, body, "conditions", "status is never imported")
+}
+
+func TestRegistryImport_ConflictStrategies(t *testing.T) {
+	exported := filepath.Join(t.TempDir(), "catalog.yaml")
+	source := &fakeRegistry{items: map[string][]string{
+		"agents":     {exportAgentJSON},
+		"mcpservers": {exportMCPJSON},
+	}}
+	srv := httptest.NewServer(source)
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+	cmd := declarative.NewRegistryCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"export", "-f", exported})
+	require.NoError(t, cmd.Execute())
+
+	// The target already holds the MCP server unchanged and the agent with
+	// a different title.
+	changedAgent := strings.Replace(exportAgentJSON, `"Summarizer"`, `"Summarizer v2"`, 1)
+	for _, tc := range []struct {
+		strategy    string
+		wantErr     string
+		wantApplied bool
+		wantOut     string
+	}{
+		{strategy: "fail", wantErr: "1 resources exist with different content", wantOut: "conflict"},
+		{strategy: "skip", wantOut: "Agent/summarizer (1.0.0) skipped"},
+		{strategy: "overwrite", wantApplied: true, wantOut: "MCPServer/weather (1.2.0) unchanged"},
+	} {
+		t.Run(tc.strategy, func(t *testing.T) {
+			target := &fakeRegistry{items: map[string][]string{
+				"agents":     {changedAgent},
+				"mcpservers": {exportMCPJSON},
+			}}
+			targetSrv := httptest.NewServer(target)
+			t.Cleanup(targetSrv.Close)
+			setupClientForServer(t, targetSrv)
+
+			var out bytes.Buffer
+			cmd := declarative.NewRegistryCmd(declarativeTestDeps(nil))
+			cmd.SetOut(&out)
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs([]string{"import", "-f", exported, "--on-conflict", tc.strategy})
+			err := cmd.Execute()
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Contains(t, out.String(), tc.wantOut)
+			if tc.wantApplied {
+				require.Len(t, target.applied, 1)
+				assert.Equal(t, 1, strings.Count(target.applied[0], "apiVersion:"), "only the changed agent is applied")
+				assert.Contains(t, target.applied[0], "title: Summarizer\n")
+			} else {
+				assert.Empty(t, target.applied)
+			}
+		})
+	}
+}
+
+func TestRegistryImport_RejectsNewerFormat(t *testing.T) {
+	path := writeTempYAML(t, `# agentregistry-export-format: 99
+apiVersion: ar.dev/v1alpha1
+kind: Namespace
+metadata:
+  name: team-a
+`)
+
+	cmd := declarative.NewRegistryCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"import", "-f", path})
+	require.ErrorContains(t, cmd.Execute(), "export format 99 is newer")
+}
+
+func TestRegistryImport_RestoresTagMarkers(t *testing.T) {
+	markedAgent := strings.Replace(exportAgentJSON, `"tag":"1.0.0",`,
+		`"tag":"1.0.0","deprecated":{"message":"use 2.x","deprecatedAt":"2026-10-02T09:00:00Z"},"yanked":{"reason":"leaks keys"},`, 1)
+	source := &fakeRegistry{items: map[string][]string{"agents": {markedAgent}}}
+	srv := httptest.NewServer(source)
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	exported := filepath.Join(t.TempDir(), "catalog.yaml")
+	cmd := declarative.NewRegistryCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"export", "-f", exported})
+	require.NoError(t, cmd.Execute())
+	raw, err := os.ReadFile(exported)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), "message: use 2.x")
+	assert.Contains(t, string(raw), "reason: leaks keys")
+	assert.NotContains(t, string(raw), "deprecatedAt", "marker timestamps are server-managed")
+
+	for _, tc := range []struct {
+		name       string
+		items      map[string][]string
+		wantMarked []string
+	}{
+		{
+			name: "created",
+			wantMarked: []string{
+				`/v0/agents/summarizer/1.0.0/deprecation {"message":"use 2.x"}`,
+				`/v0/agents/summarizer/1.0.0/yank {"reason":"leaks keys"}`,
+			},
+		},
+		{
+			// The registry's unchanged tag is already deprecated alike.
+			name: "unchanged",
+			items: map[string][]string{"agents": {strings.Replace(exportAgentJSON, `"tag":"1.0.0",`,
+				`"tag":"1.0.0","deprecated":{"message":"use 2.x"},`, 1)}},
+			wantMarked: []string{`/v0/agents/summarizer/1.0.0/yank {"reason":"leaks keys"}`},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			target := &fakeRegistry{items: tc.items}
+			targetSrv := httptest.NewServer(target)
+			t.Cleanup(targetSrv.Close)
+			setupClientForServer(t, targetSrv)
+
+			var out bytes.Buffer
+			cmd := declarative.NewRegistryCmd(declarativeTestDeps(nil))
+			cmd.SetOut(&out)
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs([]string{"import", "-f", exported, "--dry-run"})
+			require.NoError(t, cmd.Execute())
+			assert.Contains(t, out.String(), "Agent/summarizer (1.0.0) would be")
+			assert.Empty(t, target.marked, "dry run must not mark")
+
+			cmd = declarative.NewRegistryCmd(declarativeTestDeps(nil))
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs([]string{"import", "-f", exported})
+			require.NoError(t, cmd.Execute())
+			for i := range target.marked {
+				target.marked[i] = strings.TrimSpace(target.marked[i])
+			}
+			assert.Equal(t, tc.wantMarked, target.marked)
+		})
+	}
+}
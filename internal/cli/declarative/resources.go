@@ -8,6 +8,7 @@ import (
 	cliCommon "github.com/agentregistry-dev/agentregistry/internal/cli/common"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/scheme"
 	"github.com/agentregistry-dev/agentregistry/internal/client"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	"github.com/agentregistry-dev/agentregistry/pkg/printer"
 )
@@ -28,10 +29,12 @@ func listAny[T v1alpha1.Object](ctx context.Context, c *client.Client, kind stri
 		c,
 		kind,
 		client.ListOpts{
-			Namespace:  v1alpha1.DefaultNamespace,
-			Tag:        opts.Tag,
-			LatestOnly: opts.LatestOnly,
-			Limit:      200,
+			Namespace:     listNamespace(opts),
+			Labels:        opts.Labels,
+			FieldSelector: opts.FieldSelector,
+			Tag:           opts.Tag,
+			LatestOnly:    opts.LatestOnly,
+			Limit:         200,
 		},
 		newObj,
 	)
@@ -115,7 +118,9 @@ func listDeploymentResources(ctx context.Context, c *client.Client, opts scheme.
 		c,
 		v1alpha1.KindDeployment,
 		client.ListOpts{
-			Namespace:          v1alpha1.DefaultNamespace,
+			Namespace:          listNamespace(opts),
+			Labels:             opts.Labels,
+			FieldSelector:      opts.FieldSelector,
 			Limit:              200,
 			Origin:             opts.Origin,
 			IncludeTerminating: true,
@@ -133,18 +138,62 @@ func listDeploymentResources(ctx context.Context, c *client.Client, opts scheme.
 	return out, nil
 }
 
+// watchAny streams watch events for the given kind, filtered like listAny,
+// and hands fn the typed envelope (nil for bookmarks).
+func watchAny[T v1alpha1.Object](ctx context.Context, c *client.Client, kind string, opts scheme.ListOpts, newObj func() T, fn func(string, any) error) error {
+	return watchTyped(ctx, c, kind, client.WatchOpts{
+		Namespace:     listNamespace(opts),
+		Labels:        opts.Labels,
+		FieldSelector: opts.FieldSelector,
+		Tag:           opts.Tag,
+		LatestOnly:    opts.LatestOnly,
+	}, newObj, fn)
+}
+
+// watchDeploymentResources is the watch counterpart of
+// listDeploymentResources: origin-filtered and including terminating rows.
+func watchDeploymentResources(ctx context.Context, c *client.Client, opts scheme.ListOpts, fn func(string, any) error) error {
+	return watchTyped(ctx, c, v1alpha1.KindDeployment, client.WatchOpts{
+		Namespace:          listNamespace(opts),
+		Labels:             opts.Labels,
+		FieldSelector:      opts.FieldSelector,
+		Origin:             opts.Origin,
+		IncludeTerminating: true,
+	}, func() *v1alpha1.Deployment { return &v1alpha1.Deployment{} }, fn)
+}
+
+func watchTyped[T v1alpha1.Object](ctx context.Context, c *client.Client, kind string, opts client.WatchOpts, newObj func() T, fn func(string, any) error) error {
+	return client.WatchTyped(ctx, c, kind, opts, newObj, func(eventType, _ string, obj T) error {
+		if eventType == arv0.WatchEventBookmark {
+			return fn(eventType, nil)
+		}
+		return fn(eventType, obj)
+	})
+}
+
 func agentRow(agent *v1alpha1.Agent) []string {
 	if agent == nil {
 		return []string{"<invalid>"}
 	}
 	return []string{
 		printer.TruncateString(agent.Metadata.Name, 40),
-		agent.Metadata.Tag,
+		displayTag(agent.Metadata),
 		agentDisplayMode(agent.Spec),
 		printer.TruncateString(printer.EmptyValueOrDefault(agent.Spec.Description, "<none>"), 60),
 	}
 }
 
+// displayTag returns meta's tag, noting a yank or deprecation.
+func displayTag(meta v1alpha1.ObjectMeta) string {
+	switch {
+	case meta.Yanked != nil:
+		return meta.Tag + " (yanked)"
+	case meta.Deprecated != nil:
+		return meta.Tag + " (deprecated)"
+	}
+	return meta.Tag
+}
+
 func agentDisplayMode(spec v1alpha1.AgentSpec) string {
 	hasSource := spec.Source != nil && (spec.Source.Image != "" || spec.Source.Repository != nil)
 	hasHarness := len(spec.CompatibleHarnesses) > 0
@@ -166,7 +215,7 @@ func mcpRow(server *v1alpha1.MCPServer) []string {
 	}
 	return []string{
 		printer.TruncateString(server.Metadata.Name, 40),
-		server.Metadata.Tag,
+		displayTag(server.Metadata),
 		printer.TruncateString(printer.EmptyValueOrDefault(server.Spec.Description, "<none>"), 60),
 	}
 }
@@ -177,7 +226,7 @@ func skillRow(skill *v1alpha1.Skill) []string {
 	}
 	return []string{
 		printer.TruncateString(skill.Metadata.Name, 40),
-		skill.Metadata.Tag,
+		displayTag(skill.Metadata),
 		printer.TruncateString(printer.EmptyValueOrDefault(skill.Spec.Description, "<none>"), 60),
 	}
 }
@@ -188,7 +237,7 @@ func promptRow(prompt *v1alpha1.Prompt) []string {
 	}
 	return []string{
 		printer.TruncateString(prompt.Metadata.Name, 40),
-		prompt.Metadata.Tag,
+		displayTag(prompt.Metadata),
 		printer.TruncateString(printer.EmptyValueOrDefault(prompt.Spec.Description, "<none>"), 60),
 	}
 }
@@ -199,7 +248,7 @@ func pluginRow(plugin *v1alpha1.Plugin) []string {
 	}
 	return []string{
 		printer.TruncateString(plugin.Metadata.Name, 40),
-		plugin.Metadata.Tag,
+		displayTag(plugin.Metadata),
 		printer.TruncateString(printer.EmptyValueOrDefault(plugin.Spec.Description, "<none>"), 60),
 	}
 }
@@ -211,6 +260,16 @@ func runtimeRow(runtime *v1alpha1.Runtime) []string {
 	return []string{runtime.Metadata.Name, runtime.Spec.Type}
 }
 
+func namespaceRow(namespace *v1alpha1.Namespace) []string {
+	if namespace == nil {
+		return []string{"<invalid>"}
+	}
+	return []string{
+		printer.TruncateString(namespace.Metadata.Name, 40),
+		printer.TruncateString(printer.EmptyValueOrDefault(namespace.Spec.Description, "<none>"), 60),
+	}
+}
+
 func modelRow(model *v1alpha1.Model) []string {
 	if model == nil {
 		return []string{"<invalid>"}
@@ -221,7 +280,7 @@ func modelRow(model *v1alpha1.Model) []string {
 	}
 	return []string{
 		printer.TruncateString(model.Metadata.Name, 40),
-		model.Metadata.Tag,
+		displayTag(model.Metadata),
 		model.Spec.Provider,
 		printer.TruncateString(model.Spec.Model, 50),
 		printer.EmptyValueOrDefault(auth, "<provider default>"),
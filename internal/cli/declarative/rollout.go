@@ -0,0 +1,206 @@
+package declarative
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+)
+
+// NewRolloutCmd returns a new "rollout" cobra command.
+func NewRolloutCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollout",
+		Short: "Inspect the revision history of tagged resources",
+	}
+	history := &cobra.Command{
+		Use:   "history TYPE NAME",
+		Short: "List every content a tag has held",
+		Long: `List the revisions of a tag, newest first. The registry records a new
+revision each time a tag is applied with content it has not held before;
+re-applying earlier content makes that revision current again.
+
+Pin a deployment to a revision with spec.targetDigest, or restore one with
+"arctl rollback".
+
+Examples:
+  arctl rollout history agent summarizer
+  arctl rollout history agent summarizer --tag stable -n team-a -o yaml`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRolloutHistory(cmd, deps, args[0], args[1])
+		},
+	}
+	history.Flags().StringP("output", "o", "table", "Output format: table, yaml, json")
+	history.Flags().String("tag", "", `Tag to inspect (default "latest")`)
+	addNamespaceFlag(history)
+	cmd.AddCommand(history)
+	return cmd
+}
+
+// NewRollbackCmd returns a new "rollback" cobra command.
+func NewRollbackCmd(deps cliruntime.Deps) *cobra.Command {
+	var opts client.ApplyOpts
+	cmd := &cobra.Command{
+		Use:   "rollback TYPE NAME --to-revision N",
+		Short: "Restore a tag to an earlier revision",
+		Long: `Re-apply the content a tag held at an earlier revision. The rollback goes
+through the normal apply path, so admission policy, tag immutability and
+the audit log all apply to it, and it fails with a conflict if the tag
+changes while it runs.
+
+Rolling back an immutable (semver) tag needs --overwrite-immutable-tags.
+
+Examples:
+  arctl rollback agent summarizer --to-revision 2
+  arctl rollback agent summarizer --tag stable -n team-a --to-revision 3 --dry-run`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRollback(cmd, deps, args[0], args[1], opts)
+		},
+	}
+	cmd.Flags().Int("to-revision", 0, "Revision to restore, as listed by arctl rollout history")
+	_ = cmd.MarkFlagRequired("to-revision")
+	cmd.Flags().String("tag", "", `Tag to roll back (default "latest")`)
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false,
+		"Validate and simulate without mutating state")
+	cmd.Flags().BoolVar(&opts.OverwriteImmutableTags, "overwrite-immutable-tags", false,
+		"Roll back an immutable tag (registry admins only; audited)")
+	addNamespaceFlag(cmd)
+	return cmd
+}
+
+func runRolloutHistory(cmd *cobra.Command, deps cliruntime.Deps, typeName, name string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	revisions, err := listRevisions(cmd, deps, typeName, name)
+	if err != nil {
+		return err
+	}
+
+	switch outputFormat {
+	case "yaml":
+		return marshalYAML(cmd, revisions)
+	case "json":
+		return marshalJSON(cmd, revisions)
+	}
+	t := printer.NewTablePrinter(cmd.OutOrStdout())
+	t.SetHeaders("REVISION", "DIGEST", "CREATED", "CURRENT")
+	for _, r := range revisions {
+		current := ""
+		if r.Current {
+			current = "*"
+		}
+		t.AddRow(strconv.Itoa(r.Revision), shortDigest(r.Digest),
+			r.CreatedAt.Local().Format(time.DateTime), current)
+	}
+	return t.Render()
+}
+
+func runRollback(cmd *cobra.Command, deps cliruntime.Deps, typeName, name string, opts client.ApplyOpts) error {
+	to, _ := cmd.Flags().GetInt("to-revision")
+	revisions, err := listRevisions(cmd, deps, typeName, name)
+	if err != nil {
+		return err
+	}
+
+	var target, current *client.TagRevision
+	for i := range revisions {
+		if revisions[i].Revision == to {
+			target = &revisions[i]
+		}
+		if revisions[i].Current {
+			current = &revisions[i]
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("%s %s has no revision %d", typeName, name, to)
+	}
+	if target.Current {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s/%s is already at revision %d\n", target.Object.Kind, name, to)
+		return nil
+	}
+
+	// Carry the current resourceVersion so a concurrent change to the tag
+	// fails the rollback instead of being silently overwritten.
+	meta := target.Object.Metadata
+	doc := map[string]any{
+		"apiVersion": target.Object.APIVersion,
+		"kind":       target.Object.Kind,
+		"metadata": map[string]any{
+			"namespace":   meta.Namespace,
+			"name":        meta.Name,
+			"tag":         meta.Tag,
+			"labels":      meta.Labels,
+			"annotations": meta.Annotations,
+		},
+		"spec": target.Object.Spec,
+	}
+	if current != nil && current.Object.Metadata.ResourceVersion != "" {
+		doc["metadata"].(map[string]any)["resourceVersion"] = current.Object.Metadata.ResourceVersion
+	}
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("encoding revision %d: %w", to, err)
+	}
+
+	c, err := registryClient(cmd, deps)
+	if err != nil {
+		return err
+	}
+	results, err := c.Apply(cmd.Context(), body, opts)
+	if err != nil {
+		return fmt.Errorf("rolling back: %w", err)
+	}
+	printResults(cmd.OutOrStdout(), results, opts.DryRun)
+	for _, r := range results {
+		if r.Status == arv0.ApplyStatusFailed {
+			return fmt.Errorf("rollback of %s/%s to revision %d failed", r.Kind, r.Name, to)
+		}
+	}
+	return nil
+}
+
+// shortDigest abbreviates a "sha256:<hex>" digest to its first 12 hex
+// characters for table output.
+func shortDigest(digest string) string {
+	const short = len("sha256:") + 12
+	if len(digest) > short {
+		return digest[:short]
+	}
+	return digest
+}
+
+// listRevisions resolves typeName to a tagged kind and lists the revisions
+// of name at the --tag and -n flags.
+func listRevisions(cmd *cobra.Command, deps cliruntime.Deps, typeName, name string) ([]client.TagRevision, error) {
+	k, err := kindRegistry(deps).Lookup(typeName)
+	if err != nil {
+		return nil, err
+	}
+	kind := canonicalKindName(k)
+	if !v1alpha1.IsTaggedArtifactKind(kind) {
+		return nil, fmt.Errorf("type %q has no revision history", typeName)
+	}
+	tag, _ := cmd.Flags().GetString("tag")
+	namespace, _ := cmd.Flags().GetString(namespaceFlag)
+
+	c, err := registryClient(cmd, deps)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := c.ListRevisions(cmd.Context(), kind, namespace, name, tag)
+	if err != nil {
+		return nil, fmt.Errorf("listing revisions: %w", err)
+	}
+	return revisions, nil
+}
@@ -0,0 +1,95 @@
+package declarative_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+)
+
+const revisionsJSON = `{"items":[
+  {"revision":2,"digest":"sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb","createdAt":"2026-10-02T09:00:00Z","current":true,
+   "object":{"apiVersion":"ar.dev/v1alpha1","kind":"Agent","metadata":{"namespace":"team-a","name":"summarizer","tag":"latest","resourceVersion":"7"},"spec":{"image":"ghcr.io/acme/summarizer:2"}}},
+  {"revision":1,"digest":"sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa","createdAt":"2026-10-01T09:00:00Z","current":false,
+   "object":{"apiVersion":"ar.dev/v1alpha1","kind":"Agent","metadata":{"namespace":"team-a","name":"summarizer","tag":"latest","labels":{"tier":"gold"}},"spec":{"image":"ghcr.io/acme/summarizer:1"}}}
+]}`
+
+func TestRolloutHistory_RendersRevisions(t *testing.T) {
+	var gotURI string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURI = r.URL.RequestURI()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, revisionsJSON)
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	var out bytes.Buffer
+	cmd := declarative.NewRolloutCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"history", "agent", "summarizer", "-n", "team-a"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "/v0/agents/summarizer/latest/revisions?namespace=team-a", gotURI)
+	assert.Contains(t, out.String(), "REVISION")
+	assert.Contains(t, out.String(), "sha256:aaaaaaaaaaaa")
+	assert.Contains(t, out.String(), time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC).Local().Format(time.DateTime))
+}
+
+func TestRollback_AppliesEarlierRevision(t *testing.T) {
+	var applied map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet:
+			_, _ = io.WriteString(w, revisionsJSON)
+		case r.Method == http.MethodPost && r.URL.Path == "/v0/apply":
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &applied))
+			_ = json.NewEncoder(w).Encode(arv0.ApplyResultsResponse{Results: []arv0.ApplyResult{
+				{Kind: "Agent", Name: "summarizer", Tag: "latest", Status: arv0.ApplyStatusConfigured},
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	var out bytes.Buffer
+	cmd := declarative.NewRollbackCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"agent", "summarizer", "-n", "team-a", "--to-revision", "1"})
+	require.NoError(t, cmd.Execute())
+
+	require.NotNil(t, applied)
+	meta := applied["metadata"].(map[string]any)
+	assert.Equal(t, "7", meta["resourceVersion"], "rollback must carry the current resourceVersion")
+	assert.Equal(t, map[string]any{"tier": "gold"}, meta["labels"])
+	assert.Equal(t, map[string]any{"image": "ghcr.io/acme/summarizer:1"}, applied["spec"])
+	assert.Contains(t, out.String(), "Agent/summarizer (latest) configured")
+}
+
+func TestRollback_UnknownRevision(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, revisionsJSON)
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	cmd := declarative.NewRollbackCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"agent", "summarizer", "--to-revision", "9"})
+	require.ErrorContains(t, cmd.Execute(), "no revision 9")
+}
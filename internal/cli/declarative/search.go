@@ -0,0 +1,157 @@
+package declarative
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/scheme"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+)
+
+// highlightMarkers are the delimiters the server wraps matched terms in.
+// Tables drop them; -o json/yaml keep them for callers that render HTML.
+var highlightMarkers = strings.NewReplacer("<mark>", "", "</mark>", "")
+
+// NewSearchCmd returns a new "search" cobra command.
+func NewSearchCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search QUERY",
+		Short: "Full-text search across registry resources",
+		Long: `Search every resource kind by name, title, description, labels and plugin
+contents. Results are ranked; title and name matches rank above description
+matches. Tagged resources match on their latest tag.
+
+Words are AND-ed. "Quoted phrases" match in order, 'or' separates
+alternatives and a leading '-' excludes a word.
+
+Searches every namespace unless -n/--namespace is set.
+
+Examples:
+  arctl search weather
+  arctl search "pull request" --kind mcp
+  arctl search 'github or gitlab -archived'
+  arctl search forecast --kind agent --kind skill -n team-a
+  arctl search weather -l team=climate
+  arctl search weather -o json`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSearch(cmd, deps, args[0])
+		},
+	}
+	cmd.Flags().StringP("output", "o", "table", "Output format: table, yaml, json")
+	cmd.Flags().StringSlice("kind", nil, "Restrict to these types (e.g. mcp, agent); repeat or comma-separate")
+	cmd.Flags().StringP(namespaceFlag, "n", "", "Restrict to one namespace (default: every namespace)")
+	cmd.Flags().StringP("labels", "l", "", "Label selector, e.g. team=climate or env in (prod,staging),!deprecated")
+	cmd.Flags().Int("limit", 20, "Maximum number of results (1-100)")
+	cmd.Flags().Int("offset", 0, "Number of ranked results to skip")
+	return cmd
+}
+
+func runSearch(cmd *cobra.Command, deps cliruntime.Deps, query string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	kindNames, _ := cmd.Flags().GetStringSlice("kind")
+	namespace, _ := cmd.Flags().GetString(namespaceFlag)
+	labels, _ := cmd.Flags().GetString("labels")
+	limit, _ := cmd.Flags().GetInt("limit")
+	offset, _ := cmd.Flags().GetInt("offset")
+
+	opts := client.SearchOpts{Namespace: namespace, Labels: labels, Limit: limit, Offset: offset}
+	kinds := kindRegistry(deps)
+	for _, name := range kindNames {
+		k, err := kinds.Lookup(name)
+		if err != nil {
+			return err
+		}
+		canonical := canonicalKindName(k)
+		if canonical == "" {
+			return fmt.Errorf("type %q is not searchable", name)
+		}
+		opts.Kinds = append(opts.Kinds, canonical)
+	}
+
+	c, err := registryClient(cmd, deps)
+	if err != nil {
+		return err
+	}
+	resp, err := c.Search(cmd.Context(), query, opts)
+	if err != nil {
+		return fmt.Errorf("searching: %w", err)
+	}
+
+	switch outputFormat {
+	case "yaml":
+		return marshalYAML(cmd, resp)
+	case "json":
+		return marshalJSON(cmd, resp)
+	}
+	if len(resp.Hits) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "No results for %q.\n", query)
+		return nil
+	}
+	t := printer.NewTablePrinter(cmd.OutOrStdout())
+	t.SetHeaders("KIND", "NAMESPACE", "NAME", "MATCH")
+	for _, hit := range resp.Hits {
+		t.AddRow(hit.Kind, hit.Namespace, printer.TruncateString(hit.Name, 40),
+			printer.TruncateString(highlightMarkers.Replace(hit.Highlight), 70))
+	}
+	if err := t.Render(); err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.OutOrStdout())
+	fmt.Fprintln(cmd.OutOrStdout(), searchSummary(resp, offset))
+	return nil
+}
+
+// canonicalKindName maps a CLI kind ("mcp") onto its v1alpha1 kind
+// ("MCPServer") through the kind's name and aliases, which always include
+// the canonical spelling.
+func canonicalKindName(k *scheme.Kind) string {
+	for _, name := range append([]string{k.Kind}, k.Aliases...) {
+		if d, ok := v1alpha1.KindDescriptorFor(name); ok {
+			return d.Kind
+		}
+	}
+	return ""
+}
+
+// searchSummary renders the paging position and facet counts below the
+// results table, e.g.
+//
+//	Showing 1-20 of 34: MCPServer 30, Agent 4
+//	Labels: team=climate 12, team=travel 3
+func searchSummary(resp *arv0.SearchResponse, offset int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Showing %d-%d of %d", offset+1, offset+len(resp.Hits), resp.Total)
+	kinds := slices.SortedFunc(maps.Keys(resp.Facets.Kinds), func(a, b string) int {
+		if n := resp.Facets.Kinds[b] - resp.Facets.Kinds[a]; n != 0 {
+			return n
+		}
+		return strings.Compare(a, b)
+	})
+	for i, kind := range kinds {
+		sep := ", "
+		if i == 0 {
+			sep = ": "
+		}
+		fmt.Fprintf(&b, "%s%s %d", sep, kind, resp.Facets.Kinds[kind])
+	}
+	var labels []string
+	for _, key := range slices.Sorted(maps.Keys(resp.Facets.Labels)) {
+		for _, value := range slices.Sorted(maps.Keys(resp.Facets.Labels[key])) {
+			labels = append(labels, fmt.Sprintf("%s=%s %d", key, value, resp.Facets.Labels[key][value]))
+		}
+	}
+	if len(labels) > 0 {
+		b.WriteString("\nLabels: " + strings.Join(labels, ", "))
+	}
+	return b.String()
+}
@@ -0,0 +1,58 @@
+package declarative_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+)
+
+func TestSearch_RendersHitsAndFacets(t *testing.T) {
+	var gotURI string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURI = r.URL.RequestURI()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(arv0.SearchResponse{
+			Query: "weather",
+			Total: 3,
+			Hits: []arv0.SearchHit{
+				{Kind: "MCPServer", Namespace: "team-a", Name: "io.example/weather", Highlight: "Current <mark>weather</mark> alerts", Score: 0.9},
+				{Kind: "Agent", Namespace: "default", Name: "forecaster", Highlight: "<mark>Weather</mark> forecaster", Score: 0.4},
+			},
+			Facets: arv0.SearchFacets{
+				Kinds:  map[string]int{"MCPServer": 2, "Agent": 1},
+				Labels: map[string]map[string]int{"team": {"climate": 2}},
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	var out bytes.Buffer
+	cmd := declarative.NewSearchCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"weather", "--kind", "mcp,agent", "-n", "team-a", "--limit", "2"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "/v0/search?kind=MCPServer&kind=Agent&limit=2&namespace=team-a&q=weather", gotURI)
+	assert.Contains(t, out.String(), "io.example/weather")
+	assert.Contains(t, out.String(), "Current weather alerts", "highlight markers are stripped in tables")
+	assert.NotContains(t, out.String(), "<mark>")
+	assert.Contains(t, out.String(), "Showing 1-2 of 3: MCPServer 2, Agent 1")
+	assert.Contains(t, out.String(), "Labels: team=climate 2")
+}
+
+func TestSearch_RejectsUnknownKind(t *testing.T) {
+	cmd := declarative.NewSearchCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"weather", "--kind", "widget"})
+	require.Error(t, cmd.Execute())
+}
@@ -0,0 +1,248 @@
+package declarative
+
+import (
+	"crypto"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
+)
+
+// signTarget is one piece of content to sign: the artifact it belongs to
+// and its content digest.
+type signTarget struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Tag       string
+	Digest    string
+}
+
+// NewSignCmd returns the "sign" cobra command.
+func NewSignCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign (TYPE NAME | -f FILE)",
+		Short: "Sign the content of a tagged artifact such as an Agent, MCP server or Skill",
+		Long: `Sign attaches a detached signature to the content of a tagged artifact. The
+signature covers the content digest (sha256:<hex>) the registry stores the
+content under, so it follows that content to every tag that holds it and
+lapses when the content changes.
+
+TYPE NAME signs the content a tag holds now (--tag, default latest). -f FILE
+signs the content of each document in FILE as the registry would store it,
+so content can be signed before it is first applied to a namespace whose
+signature policy requires it.
+
+--key signs locally with an unencrypted PEM private key (Ed25519 or ECDSA).
+To sign with a cosign key pair instead, print the digest with --print-digest,
+sign it with "cosign sign-blob", and attach the result with --signature and
+--public-key.
+
+Examples:
+  arctl sign agent summarizer --tag 1.2.0 --key release.key
+  arctl sign -f agent.yaml -n team-a --key release.key
+  arctl sign mcp acme-fetch --print-digest > digest.txt
+  arctl sign mcp acme-fetch --public-key cosign.pub \
+    --signature "$(cosign sign-blob --key cosign.key digest.txt)"`,
+		Args:         cobra.MaximumNArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSign(cmd, deps, args)
+		},
+	}
+	cmd.Flags().StringP("filename", "f", "", "YAML file whose documents to sign (use - for stdin)")
+	cmd.Flags().String("tag", "", "Tag whose content to sign (defaults to latest)")
+	cmd.Flags().String("key", "", "PEM private key to sign with")
+	cmd.Flags().String("public-key", "", "PEM public key of a signature made elsewhere (with --signature)")
+	cmd.Flags().String("signature", "", "Base64 signature of the digest made elsewhere (with --public-key)")
+	cmd.Flags().Bool("print-digest", false, "Print the content digest to sign and exit")
+	addNamespaceFlag(cmd)
+	return cmd
+}
+
+func runSign(cmd *cobra.Command, deps cliruntime.Deps, args []string) error {
+	file, _ := cmd.Flags().GetString("filename")
+	keyPath, _ := cmd.Flags().GetString("key")
+	publicKeyPath, _ := cmd.Flags().GetString("public-key")
+	signature, _ := cmd.Flags().GetString("signature")
+	printDigest, _ := cmd.Flags().GetBool("print-digest")
+
+	switch {
+	case file == "" && len(args) != 2:
+		return fmt.Errorf("specify TYPE NAME or -f FILE")
+	case file != "" && len(args) > 0:
+		return fmt.Errorf("TYPE NAME and -f are mutually exclusive")
+	}
+	if !printDigest {
+		switch {
+		case keyPath != "" && (publicKeyPath != "" || signature != ""):
+			return fmt.Errorf("--key and --public-key/--signature are mutually exclusive")
+		case keyPath == "" && (publicKeyPath == "" || signature == ""):
+			return fmt.Errorf("specify --key, or both --public-key and --signature")
+		}
+	}
+
+	c, err := registryClient(cmd, deps)
+	if err != nil {
+		return err
+	}
+	var targets []signTarget
+	if file != "" {
+		targets, err = signTargetsFromFile(cmd, c, file)
+	} else {
+		targets, err = signTargetFromTag(cmd, deps, c, args)
+	}
+	if err != nil {
+		return err
+	}
+	if printDigest {
+		for _, t := range targets {
+			fmt.Fprintln(cmd.OutOrStdout(), t.Digest)
+		}
+		return nil
+	}
+	if signature != "" && len(targets) != 1 {
+		return fmt.Errorf("--signature signs one digest, but %d documents were given", len(targets))
+	}
+
+	var (
+		signer    crypto.Signer
+		publicKey string
+	)
+	if keyPath != "" {
+		pem, err := os.ReadFile(keyPath)
+		if err != nil {
+			return fmt.Errorf("reading key: %w", err)
+		}
+		if signer, err = signing.ParsePrivateKey(pem); err != nil {
+			return fmt.Errorf("parsing %s: %w", keyPath, err)
+		}
+		if publicKey, err = signing.MarshalPublicKey(signer.Public()); err != nil {
+			return err
+		}
+	} else {
+		pem, err := os.ReadFile(publicKeyPath)
+		if err != nil {
+			return fmt.Errorf("reading public key: %w", err)
+		}
+		publicKey = string(pem)
+	}
+
+	for _, t := range targets {
+		sig := strings.TrimSpace(signature)
+		if signer != nil {
+			if sig, err = signing.Sign(signer, t.Digest); err != nil {
+				return fmt.Errorf("signing %s %q: %w", t.Kind, t.Name, err)
+			}
+		}
+		added, err := c.AddSignature(cmd.Context(), t.Kind, t.Namespace, t.Name, t.Digest, publicKey, sig)
+		if err != nil {
+			return fmt.Errorf("signing %s %q: %w", t.Kind, t.Name, err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s/%s signed (%s, key %s)\n", strings.ToLower(t.Kind), signTargetName(t), t.Digest, shortKeyID(added.KeyID))
+	}
+	return nil
+}
+
+// signTargetFromTag resolves TYPE NAME [--tag] to the digest of the content
+// the tag holds now.
+func signTargetFromTag(cmd *cobra.Command, deps cliruntime.Deps, c *client.Client, args []string) ([]signTarget, error) {
+	tag, _ := cmd.Flags().GetString("tag")
+	kind, ref, err := signedArtifactRef(cmd, deps, args)
+	if err != nil {
+		return nil, err
+	}
+	digest, _, err := c.ListSignatures(cmd.Context(), kind, ref.Namespace, ref.Name, tag, "")
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s %q: %w", kind, args[1], err)
+	}
+	return []signTarget{{Kind: kind, Namespace: ref.Namespace, Name: ref.Name, Tag: tag, Digest: digest}}, nil
+}
+
+// signTargetsFromFile dry-runs the apply of file and collects the digest
+// the registry reports for each document. A document refused only for
+// lack of a signature still reports the digest it needs signed.
+func signTargetsFromFile(cmd *cobra.Command, c *client.Client, file string) ([]signTarget, error) {
+	namespace, _ := cmd.Flags().GetString(namespaceFlag)
+	data, _, err := readManifest(cmd, file, namespace)
+	if err != nil {
+		return nil, err
+	}
+	results, err := c.Apply(cmd.Context(), data, client.ApplyOpts{DryRun: true})
+	if err != nil {
+		return nil, fmt.Errorf("resolving digests of %s: %w", file, err)
+	}
+	var targets []signTarget
+	for _, r := range results {
+		if !v1alpha1.IsTaggedArtifactKind(r.Kind) {
+			continue
+		}
+		if r.Digest == "" {
+			if r.Status == arv0.ApplyStatusFailed {
+				return nil, fmt.Errorf("%s %q: %s", r.Kind, r.Name, r.Error)
+			}
+			continue
+		}
+		targets = append(targets, signTarget{Kind: r.Kind, Namespace: r.Namespace, Name: r.Name, Tag: r.Tag, Digest: r.Digest})
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("%s holds no tagged artifact to sign", file)
+	}
+	return targets, nil
+}
+
+// signedArtifactRef resolves TYPE NAME to a tagged-artifact kind and a
+// namespaced name.
+func signedArtifactRef(cmd *cobra.Command, deps cliruntime.Deps, args []string) (string, resourceLookupRef, error) {
+	return taggedArtifactRef(cmd, deps, args, "signed; only tagged kinds carry signatures")
+}
+
+// taggedArtifactRef resolves TYPE NAME to a tagged-artifact kind and a
+// namespaced name. notTagged completes the error for other kinds: "type
+// %q is not <notTagged>".
+func taggedArtifactRef(cmd *cobra.Command, deps cliruntime.Deps, args []string, notTagged string) (string, resourceLookupRef, error) {
+	namespace, _ := cmd.Flags().GetString(namespaceFlag)
+	k, err := kindRegistry(deps).Lookup(args[0])
+	if err != nil {
+		return "", resourceLookupRef{}, err
+	}
+	kind := canonicalKindName(k)
+	if !v1alpha1.IsTaggedArtifactKind(kind) {
+		return "", resourceLookupRef{}, fmt.Errorf("type %q is not %s", args[0], notTagged)
+	}
+	qualified, err := qualifyName(namespace, args[1])
+	if err != nil {
+		return "", resourceLookupRef{}, err
+	}
+	ref, err := parseResourceLookupRef(qualified)
+	if err != nil {
+		return "", resourceLookupRef{}, err
+	}
+	return kind, ref, nil
+}
+
+func signTargetName(t signTarget) string {
+	name := t.Name
+	if t.Namespace != "" && t.Namespace != v1alpha1.DefaultNamespace {
+		name = t.Namespace + "/" + name
+	}
+	if t.Tag != "" {
+		name += ":" + t.Tag
+	}
+	return name
+}
+
+// shortKeyID trims a key ID to the 12 characters printed in CLI output.
+func shortKeyID(keyID string) string {
+	if len(keyID) > 12 {
+		return keyID[:12]
+	}
+	return keyID
+}
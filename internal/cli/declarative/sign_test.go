@@ -0,0 +1,142 @@
+package declarative_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
+)
+
+const testDigest = "sha256:3a7bd3e2360a3d29eea436fcfb7e44c735d117c42d1c1835420b6b9942dd4f1b"
+
+// writeTestKeyPair writes an ed25519 key pair to dir and returns the paths
+// of the private and public key files.
+func writeTestKeyPair(t *testing.T, dir string) (string, string) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	privPath := filepath.Join(dir, "release.key")
+	require.NoError(t, os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+	pubPEM, err := signing.MarshalPublicKey(pub)
+	require.NoError(t, err)
+	pubPath := filepath.Join(dir, "release.pub")
+	require.NoError(t, os.WriteFile(pubPath, []byte(pubPEM), 0o600))
+	return privPath, pubPath
+}
+
+// signatureServer serves GET and POST /v0/agents/summarizer/signatures
+// over testDigest, keeping POSTed signatures in memory.
+func signatureServer(t *testing.T) (*httptest.Server, *[]map[string]string) {
+	t.Helper()
+	var sigs []map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v0/agents/summarizer/signatures", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			require.Equal(t, "1.2.0", r.URL.Query().Get("tag"))
+			_ = json.NewEncoder(w).Encode(map[string]any{"digest": testDigest, "items": sigs})
+		case http.MethodPost:
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			pub, err := signing.ParsePublicKey([]byte(body["publicKey"]))
+			require.NoError(t, err)
+			keyID, err := signing.KeyID(pub)
+			require.NoError(t, err)
+			sig := map[string]string{"keyId": keyID, "publicKey": body["publicKey"], "signature": body["signature"], "digest": body["digest"]}
+			sigs = append(sigs, sig)
+			_ = json.NewEncoder(w).Encode(sig)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &sigs
+}
+
+func TestSignVerify_RoundTrip(t *testing.T) {
+	srv, sigs := signatureServer(t)
+	setupClientForServer(t, srv)
+	privPath, pubPath := writeTestKeyPair(t, t.TempDir())
+
+	var out bytes.Buffer
+	cmd := declarative.NewSignCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"agent", "summarizer", "--tag", "1.2.0", "--key", privPath})
+	require.NoError(t, cmd.Execute())
+	require.Len(t, *sigs, 1)
+	assert.Equal(t, testDigest, (*sigs)[0]["digest"])
+	assert.Contains(t, out.String(), "agent/summarizer:1.2.0 signed")
+
+	pub, err := signing.ParsePublicKey(mustReadFile(t, pubPath))
+	require.NoError(t, err)
+	require.NoError(t, signing.Verify(pub, testDigest, (*sigs)[0]["signature"]))
+
+	out.Reset()
+	cmd = declarative.NewVerifyCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"agent", "summarizer", "--tag", "1.2.0", "--key", pubPath})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "agent/summarizer:1.2.0 verified")
+}
+
+func TestVerify_UntrustedKey(t *testing.T) {
+	srv, _ := signatureServer(t)
+	setupClientForServer(t, srv)
+	privPath, _ := writeTestKeyPair(t, t.TempDir())
+	_, otherPub := writeTestKeyPair(t, t.TempDir())
+
+	cmd := declarative.NewSignCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"agent", "summarizer", "--tag", "1.2.0", "--key", privPath})
+	require.NoError(t, cmd.Execute())
+
+	cmd = declarative.NewVerifyCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"agent", "summarizer", "--tag", "1.2.0", "--key", otherPub})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no valid signature")
+}
+
+func TestSign_PrintDigest(t *testing.T) {
+	srv, sigs := signatureServer(t)
+	setupClientForServer(t, srv)
+
+	var out bytes.Buffer
+	cmd := declarative.NewSignCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"agent", "summarizer", "--tag", "1.2.0", "--print-digest"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, testDigest, strings.TrimSpace(out.String()))
+	assert.Empty(t, *sigs)
+}
+
+func TestSign_RejectsUntaggedKinds(t *testing.T) {
+	cmd := declarative.NewSignCmd(declarativeTestDeps(nil))
+	cmd.SetArgs([]string{"runtime", "kubernetes-default", "--print-digest"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not signed")
+}
+
+func mustReadFile(t *testing.T, path string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return data
+}
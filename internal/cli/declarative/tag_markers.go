@@ -0,0 +1,173 @@
+package declarative
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+)
+
+// NewDeprecateCmd returns the "deprecate" cobra command.
+func NewDeprecateCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deprecate TYPE NAME",
+		Short: "Mark a tag of an artifact such as an Agent, MCP server or Skill deprecated",
+		Long: `Deprecate marks a tag (--tag, default latest) as discouraged without
+deleting it. Refs to a deprecated tag keep resolving, but "arctl apply" warns
+about them and "arctl get" flags the tag. -m tells users why, and
+--replacement names the tag to move to as [NAMESPACE/]NAME[:TAG] of the same
+type.
+
+The marker stays until --undo clears it or the tag is republished with new
+content.
+
+Examples:
+  arctl deprecate agent summarizer --tag 1.2.0 -m "use 2.x" --replacement summarizer:2.0.0
+  arctl deprecate mcp acme-fetch -n team-a --undo`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDeprecate(cmd, deps, args)
+		},
+	}
+	cmd.Flags().String("tag", "", "Tag to deprecate (defaults to latest)")
+	cmd.Flags().StringP("message", "m", "", "Why the tag is deprecated")
+	cmd.Flags().String("replacement", "", "Tag to move to, as [NAMESPACE/]NAME[:TAG] of the same type")
+	cmd.Flags().Bool("undo", false, "Clear the deprecation instead")
+	addNamespaceFlag(cmd)
+	return cmd
+}
+
+func runDeprecate(cmd *cobra.Command, deps cliruntime.Deps, args []string) error {
+	message, _ := cmd.Flags().GetString("message")
+	replacement, _ := cmd.Flags().GetString("replacement")
+	undo, _ := cmd.Flags().GetBool("undo")
+	if undo && (message != "" || replacement != "") {
+		return fmt.Errorf("--undo cannot be combined with --message or --replacement")
+	}
+
+	kind, ref, err := taggedArtifactRef(cmd, deps, args, "deprecated; only tagged kinds can be deprecated")
+	if err != nil {
+		return err
+	}
+	var d *v1alpha1.Deprecation
+	if !undo {
+		d = &v1alpha1.Deprecation{Message: message}
+		if replacement != "" {
+			r, err := parseReplacementRef(kind, replacement)
+			if err != nil {
+				return err
+			}
+			d.Replacement = &r
+		}
+	}
+	c, err := registryClient(cmd, deps)
+	if err != nil {
+		return err
+	}
+	marked, err := c.Deprecate(cmd.Context(), kind, ref.Namespace, ref.Name, markerTag(cmd), d)
+	if err != nil {
+		return fmt.Errorf("deprecating %s %q: %w", kind, args[1], err)
+	}
+	state := "deprecated"
+	if undo {
+		state = "no longer deprecated"
+	}
+	printTagMarker(cmd, kind, marked, state)
+	return nil
+}
+
+// NewYankCmd returns the "yank" cobra command.
+func NewYankCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "yank TYPE NAME",
+		Short: "Yank a bad tag of an artifact such as an Agent, MCP server or Skill",
+		Long: `Yank marks a tag (--tag, default latest) as bad without deleting it, so
+nothing that names it breaks. A yanked tag no longer counts as latest,
+semver ranges skip it, and the Deployment controller refuses to roll it out.
+Refs pinned to it exactly still resolve, with a warning on apply.
+
+The yank stays until --undo clears it or the tag is republished with new
+content.
+
+Examples:
+  arctl yank skill summarize --tag 1.2.0 -m "leaks credentials"
+  arctl yank skill summarize --tag 1.2.0 --undo`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runYank(cmd, deps, args)
+		},
+	}
+	cmd.Flags().String("tag", "", "Tag to yank (defaults to latest)")
+	cmd.Flags().StringP("reason", "m", "", "Why the tag is yanked")
+	cmd.Flags().Bool("undo", false, "Un-yank the tag instead")
+	addNamespaceFlag(cmd)
+	return cmd
+}
+
+func runYank(cmd *cobra.Command, deps cliruntime.Deps, args []string) error {
+	reason, _ := cmd.Flags().GetString("reason")
+	undo, _ := cmd.Flags().GetBool("undo")
+	if undo && reason != "" {
+		return fmt.Errorf("--undo cannot be combined with --reason")
+	}
+
+	kind, ref, err := taggedArtifactRef(cmd, deps, args, "yanked; only tagged kinds can be yanked")
+	if err != nil {
+		return err
+	}
+	var y *v1alpha1.Yank
+	if !undo {
+		y = &v1alpha1.Yank{Reason: reason}
+	}
+	c, err := registryClient(cmd, deps)
+	if err != nil {
+		return err
+	}
+	marked, err := c.Yank(cmd.Context(), kind, ref.Namespace, ref.Name, markerTag(cmd), y)
+	if err != nil {
+		return fmt.Errorf("yanking %s %q: %w", kind, args[1], err)
+	}
+	state := "yanked"
+	if undo {
+		state = "no longer yanked"
+	}
+	printTagMarker(cmd, kind, marked, state)
+	return nil
+}
+
+// markerTag returns the --tag to mark, defaulting to latest.
+func markerTag(cmd *cobra.Command) string {
+	if tag, _ := cmd.Flags().GetString("tag"); tag != "" {
+		return tag
+	}
+	return "latest"
+}
+
+// parseReplacementRef parses a --replacement of [NAMESPACE/]NAME[:TAG] into
+// a ref to kind. A blank namespace is left for the registry to default to
+// the deprecated tag's.
+func parseReplacementRef(kind, arg string) (v1alpha1.ResourceRef, error) {
+	name, tag, _ := strings.Cut(arg, ":")
+	ref := v1alpha1.ResourceRef{Kind: kind, Name: name, Tag: tag}
+	if strings.Contains(name, "/") {
+		lookup, err := parseResourceLookupRef(name)
+		if err != nil {
+			return v1alpha1.ResourceRef{}, fmt.Errorf("--replacement must be [NAMESPACE/]NAME[:TAG]")
+		}
+		ref.Namespace, ref.Name = lookup.Namespace, lookup.Name
+	}
+	if ref.Name == "" {
+		return v1alpha1.ResourceRef{}, fmt.Errorf("--replacement must be [NAMESPACE/]NAME[:TAG]")
+	}
+	return ref, nil
+}
+
+func printTagMarker(cmd *cobra.Command, kind string, marked *v1alpha1.RawObject, state string) {
+	target := signTarget{Kind: kind, Namespace: marked.Metadata.Namespace, Name: marked.Metadata.Name, Tag: marked.Metadata.Tag}
+	fmt.Fprintf(cmd.OutOrStdout(), "%s/%s %s\n", strings.ToLower(kind), signTargetName(target), state)
+}
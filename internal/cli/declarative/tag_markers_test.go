@@ -0,0 +1,123 @@
+package declarative_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// markerRequest is one request a tagMarkerServer received.
+type markerRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Body   map[string]any
+}
+
+// tagMarkerServer answers every marker request with the tag it names,
+// recording the requests it receives.
+func tagMarkerServer(t *testing.T) (*httptest.Server, *[]markerRequest) {
+	t.Helper()
+	var requests []markerRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := markerRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery}
+		if r.Method == http.MethodPut {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req.Body))
+		}
+		requests = append(requests, req)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v1alpha1.RawObject{
+			TypeMeta: v1alpha1.TypeMeta{Kind: "Agent"},
+			Metadata: v1alpha1.ObjectMeta{Namespace: "catalog", Name: "summarizer", Tag: "1.2.0"},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestDeprecate(t *testing.T) {
+	srv, requests := tagMarkerServer(t)
+	setupClientForServer(t, srv)
+
+	var out bytes.Buffer
+	cmd := declarative.NewDeprecateCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"agent", "summarizer", "-n", "catalog", "--tag", "1.2.0", "-m", "use 2.x", "--replacement", "summarizer:2.0.0"})
+	require.NoError(t, cmd.Execute())
+	require.Len(t, *requests, 1)
+	assert.Equal(t, markerRequest{
+		Method: http.MethodPut,
+		Path:   "/v0/agents/summarizer/1.2.0/deprecation",
+		Query:  "namespace=catalog",
+		Body: map[string]any{
+			"message":     "use 2.x",
+			"replacement": map[string]any{"kind": "Agent", "name": "summarizer", "tag": "2.0.0"},
+		},
+	}, (*requests)[0])
+	assert.Contains(t, out.String(), "agent/catalog/summarizer:1.2.0 deprecated")
+
+	out.Reset()
+	cmd = declarative.NewDeprecateCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"agent", "summarizer", "-n", "catalog", "--tag", "1.2.0", "--undo"})
+	require.NoError(t, cmd.Execute())
+	require.Len(t, *requests, 2)
+	assert.Equal(t, http.MethodDelete, (*requests)[1].Method)
+	assert.Equal(t, "/v0/agents/summarizer/1.2.0/deprecation", (*requests)[1].Path)
+	assert.Contains(t, out.String(), "no longer deprecated")
+}
+
+func TestYank(t *testing.T) {
+	srv, requests := tagMarkerServer(t)
+	setupClientForServer(t, srv)
+
+	var out bytes.Buffer
+	cmd := declarative.NewYankCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"agent", "catalog/summarizer", "-m", "leaks credentials"})
+	require.NoError(t, cmd.Execute())
+	require.Len(t, *requests, 1)
+	assert.Equal(t, markerRequest{
+		Method: http.MethodPut,
+		Path:   "/v0/agents/summarizer/latest/yank",
+		Query:  "namespace=catalog",
+		Body:   map[string]any{"reason": "leaks credentials"},
+	}, (*requests)[0])
+	assert.Contains(t, out.String(), "agent/catalog/summarizer:1.2.0 yanked")
+}
+
+func TestTagMarkers_RejectInvalidFlags(t *testing.T) {
+	srv, requests := tagMarkerServer(t)
+	setupClientForServer(t, srv)
+
+	for _, tc := range []struct {
+		name string
+		cmd  *cobra.Command
+		args []string
+		want string
+	}{
+		{"undo with message", declarative.NewDeprecateCmd(declarativeTestDeps(nil)), []string{"agent", "summarizer", "--undo", "-m", "x"}, "--undo cannot be combined"},
+		{"bad replacement", declarative.NewDeprecateCmd(declarativeTestDeps(nil)), []string{"agent", "summarizer", "--replacement", "a/b/c"}, "--replacement must be"},
+		{"undo with reason", declarative.NewYankCmd(declarativeTestDeps(nil)), []string{"skill", "summarize", "--undo", "-m", "x"}, "--undo cannot be combined"},
+		{"untagged kind", declarative.NewYankCmd(declarativeTestDeps(nil)), []string{"deployment", "summarizer"}, "only tagged kinds can be yanked"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.cmd.SetOut(&bytes.Buffer{})
+			tc.cmd.SetErr(&bytes.Buffer{})
+			tc.cmd.SetArgs(tc.args)
+			err := tc.cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.want)
+		})
+	}
+	assert.Empty(t, *requests)
+}
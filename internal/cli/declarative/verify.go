@@ -0,0 +1,98 @@
+package declarative
+
+import (
+	"crypto"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
+)
+
+// NewVerifyCmd returns the "verify" cobra command.
+func NewVerifyCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify TYPE NAME --key PUBLIC_KEY",
+		Short: "Verify the signatures of a tagged artifact such as an Agent, MCP server or Skill",
+		Long: `Verify checks that the content a tag holds now (--tag, default latest) carries
+a valid signature from one of the given public keys. The signatures are
+fetched from the registry and checked locally, so the result does not depend
+on the registry's own signature policy.
+
+--key accepts PEM public keys (Ed25519 or ECDSA, including cosign public
+keys) and may be repeated; one valid signature from any of them is enough.
+
+Examples:
+  arctl verify agent summarizer --tag 1.2.0 --key release.pub
+  arctl verify mcp acme-fetch -n team-a --key release.pub --key cosign.pub`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVerify(cmd, deps, args)
+		},
+	}
+	cmd.Flags().String("tag", "", "Tag whose content to verify (defaults to latest)")
+	cmd.Flags().StringArray("key", nil, "PEM public key to trust (repeatable)")
+	_ = cmd.MarkFlagRequired("key")
+	addNamespaceFlag(cmd)
+	return cmd
+}
+
+func runVerify(cmd *cobra.Command, deps cliruntime.Deps, args []string) error {
+	tag, _ := cmd.Flags().GetString("tag")
+	keyPaths, _ := cmd.Flags().GetStringArray("key")
+
+	type trustedKey struct {
+		path  string
+		keyID string
+		pub   crypto.PublicKey
+	}
+	var keys []trustedKey
+	for _, path := range keyPaths {
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading key: %w", err)
+		}
+		pub, err := signing.ParsePublicKey(pem)
+		if err != nil {
+			return fmt.Errorf("parsing %s: %w", path, err)
+		}
+		keyID, err := signing.KeyID(pub)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, trustedKey{path: path, keyID: keyID, pub: pub})
+	}
+
+	kind, ref, err := signedArtifactRef(cmd, deps, args)
+	if err != nil {
+		return err
+	}
+	c, err := registryClient(cmd, deps)
+	if err != nil {
+		return err
+	}
+	digest, sigs, err := c.ListSignatures(cmd.Context(), kind, ref.Namespace, ref.Name, tag, "")
+	if err != nil {
+		return fmt.Errorf("listing signatures of %s %q: %w", kind, args[1], err)
+	}
+
+	target := signTarget{Kind: kind, Namespace: ref.Namespace, Name: ref.Name, Tag: tag}
+	for _, key := range keys {
+		for _, sig := range sigs {
+			if sig.KeyID != key.keyID {
+				continue
+			}
+			if signing.Verify(key.pub, digest, sig.Signature) == nil {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s/%s verified (%s, key %s from %s)\n",
+					strings.ToLower(kind), signTargetName(target), digest, shortKeyID(key.keyID), key.path)
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%s %q (%s) has no valid signature from the given keys (%d signature(s) recorded)",
+		kind, args[1], digest, len(sigs))
+}
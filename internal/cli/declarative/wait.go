@@ -37,6 +37,7 @@ Timeout regimes:
   --timeout=-1   wait forever`,
 		Example: `  arctl wait deployment aws-v1
   arctl wait deployment team-a/aws-v1
+  arctl wait deployment aws-v1 -n team-a
   arctl wait deployment aws-v1 --for=failed
   arctl wait deployment aws-v1 --for=delete --timeout=10m`,
 		Args:         cobra.ExactArgs(2),
@@ -48,11 +49,17 @@ Timeout regimes:
 	cmd.Flags().String("for", "deployed", "Target state to wait for: deployed, failed, undeployed, delete")
 	cmd.Flags().Duration("timeout", cliCommon.DefaultWaitTimeout,
 		"Maximum time to wait. 0 polls once and exits; negative waits forever.")
+	addNamespaceFlag(cmd)
 	return cmd
 }
 
 func runDeclarativeWait(cmd *cobra.Command, deps cliruntime.Deps, args []string) error {
-	typeName, name := args[0], args[1]
+	typeName := args[0]
+	namespace, _ := cmd.Flags().GetString(namespaceFlag)
+	name, err := qualifyName(namespace, args[1])
+	if err != nil {
+		return err
+	}
 	ref, err := parseResourceLookupRef(name)
 	if err != nil {
 		return err
@@ -27,6 +27,9 @@ type Column struct {
 // Empty fields mean "no filter" — the default `arctl get <plural>` lists
 // every row of the kind.
 type ListOpts struct {
+	// Namespace scopes the list: empty lists the default namespace, "all"
+	// lists every namespace, and any other value lists that namespace.
+	Namespace string
 	// Tag, when set, restricts the list to rows with this tag value
 	// (tagged content kinds only). Mutually exclusive with LatestOnly.
 	Tag string
@@ -39,6 +42,10 @@ type ListOpts struct {
 	// Deployment ListFunc translates these to the server filter; only the
 	// Deployment kind honors this — other kinds ignore it.
 	Origin string
+	// Labels and FieldSelector forward the --selector and --field-selector
+	// filters to the server unparsed.
+	Labels        string
+	FieldSelector string
 }
 
 type ListFunc func(context.Context, *client.Client, ListOpts) ([]any, error)
@@ -60,6 +67,12 @@ type ListTagsFunc func(ctx context.Context, c *client.Client, name string) ([]an
 // identity is not tagged.
 type DeleteAllTagsFunc func(ctx context.Context, c *client.Client, name string) error
 
+// WatchFunc streams changes to a kind's list, calling fn with the watch
+// event type and the decoded item until ctx is cancelled or the stream
+// ends. Bookmarks reach fn with a nil item; the first one marks the end of
+// the initial ADDED items.
+type WatchFunc func(ctx context.Context, c *client.Client, opts ListOpts, fn func(eventType string, item any) error) error
+
 type Kind struct {
 	Kind          string
 	Plural        string
@@ -71,6 +84,7 @@ type Kind struct {
 	Delete        DeleteFunc
 	ListTags      ListTagsFunc
 	DeleteAllTags DeleteAllTagsFunc
+	Watch         WatchFunc
 
 	TableColumns []Column
 }
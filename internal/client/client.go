@@ -19,8 +19,8 @@ import (
 // Client is a lightweight API client for the agentregistry HTTP surface.
 // Resource methods speak v1alpha1 at /v0/{plural}/{name} plus
 // /v0/{plural}/{name}/{tag} for taggable artifacts, with ?namespace=<ns> as an
-// optional query param (namespace is hidden from the user-facing API; empty /
-// "default" are elided).
+// optional query param (empty / "default" are elided since the server
+// defaults to "default").
 type Client struct {
 	BaseURL    string
 	httpClient *http.Client
@@ -33,6 +33,11 @@ const DefaultBaseURL = "http://localhost:12121/v0"
 
 type VersionBody = arv0.VersionBody
 
+// ErrResourceVersionGone is returned by Watch when the requested
+// resourceVersion is older than the server's retained event history. List
+// again and watch from the list's resourceVersion.
+var ErrResourceVersionGone = errors.New("resource version is too old")
+
 // ErrNotFound is returned by Get / GetLatest / Delete / PatchStatus when
 // the server responds with 404. Callers can errors.Is(err, ErrNotFound)
 // to branch cleanly.
@@ -111,11 +116,7 @@ func (c *Client) doJSON(req *http.Request, out any) error {
 		return ErrNotFound
 	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
-		if msg := extractAPIErrorMessage(errBody); msg != "" {
-			return fmt.Errorf("%s: %s", resp.Status, msg)
-		}
-		return fmt.Errorf("unexpected status: %s, %s", resp.Status, string(errBody))
+		return statusError(resp)
 	}
 	if out == nil {
 		return nil
@@ -123,6 +124,16 @@ func (c *Client) doJSON(req *http.Request, out any) error {
 	return json.NewDecoder(resp.Body).Decode(out)
 }
 
+// statusError renders a non-2xx response as an error, preferring the
+// Huma error message when the body carries one.
+func statusError(resp *http.Response) error {
+	errBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if msg := extractAPIErrorMessage(errBody); msg != "" {
+		return fmt.Errorf("%s: %s", resp.Status, msg)
+	}
+	return fmt.Errorf("unexpected status: %s, %s", resp.Status, string(errBody))
+}
+
 // extractAPIErrorMessage parses a Huma-style JSON error body and returns a
 // human-readable string with just the error messages. Returns "" if the body
 // cannot be parsed.
@@ -183,9 +194,12 @@ func (c *Client) GetVersion() (*VersionBody, error) {
 // Any other value scopes to that exact namespace.
 type ListOpts struct {
 	Namespace string
-	Labels    string
-	Limit     int
-	Cursor    string
+	// Labels and FieldSelector are the server's labels and fieldSelector
+	// selectors, passed through unparsed.
+	Labels        string
+	FieldSelector string
+	Limit         int
+	Cursor        string
 	// Origin, when set, forwards the Deployment origin filter
 	// ("managed" or "discovered"). Empty leaves the server default intact.
 	Origin string
@@ -270,6 +284,270 @@ func (c *Client) ListTags(ctx context.Context, kind, namespace, name string) ([]
 	return resp.Items, nil
 }
 
+// TagRevision is one entry of a tag's revision history, as returned by
+// GET /v0/{plural}/{name}/{tag}/revisions.
+type TagRevision struct {
+	Revision  int       `json:"revision"`
+	Digest    string    `json:"digest"`
+	CreatedAt time.Time `json:"createdAt"`
+	Current   bool      `json:"current"`
+	// Object is the tag as of this revision; only the current revision
+	// carries status.
+	Object v1alpha1.RawObject `json:"object"`
+}
+
+// ListRevisions returns every content (kind, namespace, name, tag) has
+// held, newest first. Empty tag means "latest". Mutable-object kinds have
+// no revision history.
+func (c *Client) ListRevisions(ctx context.Context, kind, namespace, name, tag string) ([]TagRevision, error) {
+	if tag == "" {
+		tag = "latest"
+	}
+	path := fmt.Sprintf("/%s/%s/%s/revisions%s",
+		v1alpha1.PluralFor(kind),
+		url.PathEscape(name),
+		url.PathEscape(tag),
+		namespaceQuery(namespace))
+	req, err := c.newRequest(http.MethodGet, path)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var resp struct {
+		Items []TagRevision `json:"items"`
+	}
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// ListReferrers returns the live objects whose references select (kind,
+// namespace, name), by GET'ing /v0/{plural}/{name}/referrers. A non-empty
+// tag narrows the result to references that select that tag. hidden
+// counts the referrers the caller may not read, which the server leaves
+// out. The server refuses to delete a resource while it has referrers.
+func (c *Client) ListReferrers(ctx context.Context, kind, namespace, name, tag string) ([]v1alpha1.ResourceRef, int, error) {
+	q := url.Values{}
+	if namespace != "" && namespace != v1alpha1.DefaultNamespace {
+		q.Set("namespace", namespace)
+	}
+	if tag != "" {
+		q.Set("tag", tag)
+	}
+	path := fmt.Sprintf("/%s/%s/referrers", v1alpha1.PluralFor(kind), url.PathEscape(name))
+	if enc := q.Encode(); enc != "" {
+		path += "?" + enc
+	}
+	req, err := c.newRequest(http.MethodGet, path)
+	if err != nil {
+		return nil, 0, err
+	}
+	req = req.WithContext(ctx)
+	var resp struct {
+		Items  []v1alpha1.ResourceRef `json:"items"`
+		Hidden int                    `json:"hidden"`
+	}
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, 0, err
+	}
+	return resp.Items, resp.Hidden, nil
+}
+
+// Signature is one detached signature over a content digest, as returned
+// by GET /v0/{plural}/{name}/signatures.
+type Signature struct {
+	KeyID     string    `json:"keyId"`
+	PublicKey string    `json:"publicKey"`
+	Signature string    `json:"signature"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ListSignatures returns the content digest of (kind, namespace, name) and
+// the signatures recorded over it. A non-empty digest selects that content;
+// otherwise tag does, with empty meaning "latest".
+func (c *Client) ListSignatures(ctx context.Context, kind, namespace, name, tag, digest string) (string, []Signature, error) {
+	q := url.Values{}
+	if namespace != "" && namespace != v1alpha1.DefaultNamespace {
+		q.Set("namespace", namespace)
+	}
+	if digest != "" {
+		q.Set("digest", digest)
+	} else if tag != "" {
+		q.Set("tag", tag)
+	}
+	path := fmt.Sprintf("/%s/%s/signatures", v1alpha1.PluralFor(kind), url.PathEscape(name))
+	if enc := q.Encode(); enc != "" {
+		path += "?" + enc
+	}
+	req, err := c.newRequest(http.MethodGet, path)
+	if err != nil {
+		return "", nil, err
+	}
+	req = req.WithContext(ctx)
+	var resp struct {
+		Digest string      `json:"digest"`
+		Items  []Signature `json:"items"`
+	}
+	if err := c.doJSON(req, &resp); err != nil {
+		return "", nil, err
+	}
+	return resp.Digest, resp.Items, nil
+}
+
+// AddSignature attaches signature, a base64 signature of digest made with
+// the private half of the PEM publicKey, to the content of (kind,
+// namespace, name) by POST'ing /v0/{plural}/{name}/signatures. The server
+// verifies it against publicKey before recording it.
+func (c *Client) AddSignature(ctx context.Context, kind, namespace, name, digest, publicKey, signature string) (*Signature, error) {
+	body, err := json.Marshal(map[string]string{
+		"digest":    digest,
+		"publicKey": publicKey,
+		"signature": signature,
+	})
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/%s/%s/signatures%s", v1alpha1.PluralFor(kind), url.PathEscape(name), namespaceQuery(namespace))
+	req, err := c.newRequestWithBody(http.MethodPost, path, bytes.NewReader(body), "application/json")
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var out Signature
+	if err := c.doJSON(req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListApprovals returns the review entries of namespace in state by
+// GET'ing /v0/approvals. namespace "all" lists every namespace and state
+// "all" every state; blank means the server defaults, "default" and
+// "pending".
+func (c *Client) ListApprovals(ctx context.Context, namespace, state string) ([]arv0.ApprovalEntry, error) {
+	q := url.Values{}
+	if namespace != "" {
+		q.Set("namespace", namespace)
+	}
+	if state != "" {
+		q.Set("state", state)
+	}
+	path := "/approvals"
+	if enc := q.Encode(); enc != "" {
+		path += "?" + enc
+	}
+	req, err := c.newRequest(http.MethodGet, path)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var out arv0.ApprovalListResponse
+	if err := c.doJSON(req, &out); err != nil {
+		return nil, err
+	}
+	return out.Items, nil
+}
+
+// ReviewApproval records an approve or reject decision by POST'ing
+// /v0/approvals and returns the reviewed entry.
+func (c *Client) ReviewApproval(ctx context.Context, review arv0.ApprovalReview) (*arv0.ApprovalEntry, error) {
+	body, err := json.Marshal(review)
+	if err != nil {
+		return nil, err
+	}
+	req, err := c.newRequestWithBody(http.MethodPost, "/approvals", bytes.NewReader(body), "application/json")
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var out arv0.ApprovalEntry
+	if err := c.doJSON(req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Deprecate marks tag of (kind, namespace, name) deprecated by PUT'ing
+// /v0/{plural}/{name}/{tag}/deprecation, or clears its deprecation when d
+// is nil. Returns the tag as marked.
+func (c *Client) Deprecate(ctx context.Context, kind, namespace, name, tag string, d *v1alpha1.Deprecation) (*v1alpha1.RawObject, error) {
+	var body any
+	if d != nil {
+		body = d
+	}
+	return c.setTagMarker(ctx, kind, namespace, name, tag, "deprecation", body)
+}
+
+// Yank yanks tag of (kind, namespace, name) by PUT'ing
+// /v0/{plural}/{name}/{tag}/yank, or un-yanks it when y is nil. Returns
+// the tag as marked.
+func (c *Client) Yank(ctx context.Context, kind, namespace, name, tag string, y *v1alpha1.Yank) (*v1alpha1.RawObject, error) {
+	var body any
+	if y != nil {
+		body = y
+	}
+	return c.setTagMarker(ctx, kind, namespace, name, tag, "yank", body)
+}
+
+// setTagMarker PUTs body to the marker endpoint of one tag, or DELETEs the
+// marker when body is nil.
+func (c *Client) setTagMarker(ctx context.Context, kind, namespace, name, tag, marker string, body any) (*v1alpha1.RawObject, error) {
+	path := fmt.Sprintf("/%s/%s/%s/%s%s",
+		v1alpha1.PluralFor(kind),
+		url.PathEscape(name),
+		url.PathEscape(tag),
+		marker,
+		namespaceQuery(namespace))
+	var (
+		req *http.Request
+		err error
+	)
+	if body == nil {
+		req, err = c.newRequest(http.MethodDelete, path)
+	} else {
+		raw, merr := json.Marshal(body)
+		if merr != nil {
+			return nil, merr
+		}
+		req, err = c.newRequestWithBody(http.MethodPut, path, bytes.NewReader(raw), "application/json")
+	}
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var out v1alpha1.RawObject
+	if err := c.doJSON(req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Graph returns the dependency graph rooted at root by GET'ing /v0/graph.
+// A blank root.Namespace means the server default; a blank root.Tag means
+// latest.
+func (c *Client) Graph(ctx context.Context, root v1alpha1.ResourceRef) (*arv0.GraphResponse, error) {
+	ref := root.Kind + "/" + root.Name
+	if root.Namespace != "" && root.Namespace != v1alpha1.DefaultNamespace {
+		ref = root.Kind + "/" + root.Namespace + "/" + root.Name
+	}
+	if root.Tag != "" {
+		ref += ":" + root.Tag
+	}
+	q := url.Values{}
+	q.Set("root", ref)
+	req, err := c.newRequest(http.MethodGet, "/graph?"+q.Encode())
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var out arv0.GraphResponse
+	if err := c.doJSON(req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // List returns rows of kind, paginated. opts.Namespace="" (empty) lists
 // the default namespace; opts.Namespace="all" widens to every
 // namespace. The returned string is the nextCursor; empty means no
@@ -289,6 +567,9 @@ func (c *Client) List(ctx context.Context, kind string, opts ListOpts) ([]v1alph
 	if opts.Labels != "" {
 		q.Set("labels", opts.Labels)
 	}
+	if opts.FieldSelector != "" {
+		q.Set("fieldSelector", opts.FieldSelector)
+	}
 	if opts.Origin != "" {
 		q.Set("origin", opts.Origin)
 	}
@@ -316,6 +597,103 @@ func (c *Client) List(ctx context.Context, kind string, opts ListOpts) ([]v1alph
 	return resp.Items, resp.NextCursor, nil
 }
 
+// WatchOpts controls Watch. The filters match ListOpts.
+type WatchOpts struct {
+	Namespace          string
+	Labels             string
+	FieldSelector      string
+	Origin             string
+	Tag                string
+	LatestOnly         bool
+	IncludeTerminating bool
+	// ResourceVersion resumes after a revision taken from a list
+	// response or a previous event. Empty starts the stream with an
+	// ADDED event per current item, followed by a BOOKMARK.
+	ResourceVersion string
+}
+
+// Watch streams changes to kind from GET /v0/{plural}?watch=true and calls
+// fn for every event, bookmarks included. It returns when ctx is
+// cancelled, fn returns an error or the server ends the stream. An ERROR
+// event is returned as an error; a 410 from either the response or an
+// ERROR event wraps ErrResourceVersionGone.
+func (c *Client) Watch(ctx context.Context, kind string, opts WatchOpts, fn func(arv0.WatchEvent) error) error {
+	q := url.Values{}
+	q.Set("watch", "true")
+	if opts.Namespace != "" {
+		q.Set("namespace", opts.Namespace)
+	}
+	if opts.Labels != "" {
+		q.Set("labels", opts.Labels)
+	}
+	if opts.FieldSelector != "" {
+		q.Set("fieldSelector", opts.FieldSelector)
+	}
+	if opts.Origin != "" {
+		q.Set("origin", opts.Origin)
+	}
+	if opts.Tag != "" {
+		q.Set("tag", opts.Tag)
+	}
+	if opts.LatestOnly {
+		q.Set("latestOnly", "true")
+	}
+	if opts.IncludeTerminating {
+		q.Set("includeTerminating", "true")
+	}
+	if opts.ResourceVersion != "" {
+		q.Set("resourceVersion", opts.ResourceVersion)
+	}
+	req, err := c.newRequest(http.MethodGet, "/"+v1alpha1.PluralFor(kind)+"?"+q.Encode())
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+
+	// The client-wide timeout would cut every stream off; the caller's
+	// ctx bounds a watch instead.
+	streamClient := *c.httpClient
+	streamClient.Timeout = 0
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusGone {
+		return fmt.Errorf("%w: %w", ErrResourceVersionGone, statusError(resp))
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return statusError(resp)
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var event arv0.WatchEvent
+		if err := dec.Decode(&event); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("reading watch stream: %w", err)
+		}
+		if event.Type == arv0.WatchEventError {
+			if event.Error == nil {
+				return errors.New("watch failed")
+			}
+			if event.Error.Status == http.StatusGone {
+				return fmt.Errorf("%w: %s", ErrResourceVersionGone, event.Error.Message)
+			}
+			return fmt.Errorf("watch failed (%d): %s", event.Error.Status, event.Error.Message)
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+}
+
 // Delete soft-deletes a row. When tag is empty it uses the name-only
 // mutable-object route; otherwise it deletes the exact tag route. Returns
 // ErrNotFound when the row doesn't exist. See Store.Delete for the
@@ -349,6 +727,10 @@ func (c *Client) Delete(ctx context.Context, kind, namespace, name, tag string)
 // ApplyOpts carries cross-cutting batch options for the POST /v0/apply endpoint.
 type ApplyOpts struct {
 	DryRun bool
+	// OverwriteImmutableTags asks the server to replace the content of
+	// tags its tag policy protects. Only registry admins may; the server
+	// audits each overwrite.
+	OverwriteImmutableTags bool
 }
 
 // Apply sends a multi-doc YAML body to POST /v0/apply and returns per-resource results.
@@ -370,6 +752,9 @@ func (c *Client) applyBatch(ctx context.Context, method string, body []byte, opt
 	if opts.DryRun {
 		q.Set("dryRun", "true")
 	}
+	if opts.OverwriteImmutableTags {
+		q.Set("overwriteImmutableTags", "true")
+	}
 	if enc := q.Encode(); enc != "" {
 		path += "?" + enc
 	}
@@ -388,3 +773,111 @@ func (c *Client) applyBatch(ctx context.Context, method string, body []byte, opt
 }
 
 // =============================================================================
+
+// =============================================================================
+// Search
+// =============================================================================
+
+// SearchOpts controls the query parameters on Search. Empty Kinds and
+// Namespace search every kind and every namespace.
+type SearchOpts struct {
+	Kinds     []string
+	Namespace string
+	Labels    string
+	Limit     int
+	Offset    int
+}
+
+// Search runs a ranked full-text query against GET /v0/search.
+func (c *Client) Search(ctx context.Context, query string, opts SearchOpts) (*arv0.SearchResponse, error) {
+	q := url.Values{}
+	q.Set("q", query)
+	for _, kind := range opts.Kinds {
+		q.Add("kind", kind)
+	}
+	if opts.Namespace != "" {
+		q.Set("namespace", opts.Namespace)
+	}
+	if opts.Labels != "" {
+		q.Set("labels", opts.Labels)
+	}
+	if opts.Limit > 0 {
+		q.Set("limit", fmt.Sprintf("%d", opts.Limit))
+	}
+	if opts.Offset > 0 {
+		q.Set("offset", fmt.Sprintf("%d", opts.Offset))
+	}
+	req, err := c.newRequest(http.MethodGet, "/search?"+q.Encode())
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var out arv0.SearchResponse
+	if err := c.doJSON(req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// =============================================================================
+// Audit
+// =============================================================================
+
+// AuditOpts filters ListAudit. Empty fields match every entry; zero Since
+// and Until leave the time range open.
+type AuditOpts struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Tag       string
+	Principal string
+	Verb      string
+	RequestID string
+	Since     time.Time
+	Until     time.Time
+	Limit     int
+	Cursor    string
+}
+
+// ListAudit reads one page of the audit log, newest first, from
+// GET /v0/audit.
+func (c *Client) ListAudit(ctx context.Context, opts AuditOpts) (*arv0.AuditListResponse, error) {
+	q := url.Values{}
+	for key, value := range map[string]string{
+		"kind":      opts.Kind,
+		"namespace": opts.Namespace,
+		"name":      opts.Name,
+		"tag":       opts.Tag,
+		"principal": opts.Principal,
+		"verb":      opts.Verb,
+		"requestId": opts.RequestID,
+		"cursor":    opts.Cursor,
+	} {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	if !opts.Since.IsZero() {
+		q.Set("since", opts.Since.UTC().Format(time.RFC3339))
+	}
+	if !opts.Until.IsZero() {
+		q.Set("until", opts.Until.UTC().Format(time.RFC3339))
+	}
+	if opts.Limit > 0 {
+		q.Set("limit", fmt.Sprintf("%d", opts.Limit))
+	}
+	path := "/audit"
+	if enc := q.Encode(); enc != "" {
+		path += "?" + enc
+	}
+	req, err := c.newRequest(http.MethodGet, path)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var out arv0.AuditListResponse
+	if err := c.doJSON(req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
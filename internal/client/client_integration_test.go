@@ -31,7 +31,7 @@ func TestClient_V1Alpha1RoundTrip(t *testing.T) {
 
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("test", "v1"))
-	crud.Register(api, "/v0", stores, nil, nil, crud.PerKindHooks{}, nil)
+	crud.Register(api, "/v0", stores, nil, nil, crud.PerKindHooks{}, nil, nil, nil)
 	resource.RegisterApply(api, resource.ApplyConfig{
 		BasePrefix: "/v0",
 		Stores:     stores,
@@ -143,7 +143,7 @@ func TestClient_V1Alpha1_NotFound(t *testing.T) {
 
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("test", "v1"))
-	crud.Register(api, "/v0", stores, nil, nil, crud.PerKindHooks{}, nil)
+	crud.Register(api, "/v0", stores, nil, nil, crud.PerKindHooks{}, nil, nil, nil)
 
 	ts := httptest.NewServer(mux)
 	defer ts.Close()
@@ -2,13 +2,16 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 )
 
 // GetTyped fetches one resource and materializes its typed v1alpha1 envelope.
-// Empty tag resolves the latest tag for taggable resources.
+// Empty tag resolves the latest tag for taggable resources; a semver range
+// such as "^1.2" resolves to the highest matching tag (see ResolveTag).
 func GetTyped[T v1alpha1.Object](
 	ctx context.Context,
 	c *Client,
@@ -27,6 +30,9 @@ func GetTyped[T v1alpha1.Object](
 	if tag == "" {
 		raw, err = c.GetLatest(ctx, kind, namespace, name)
 	} else {
+		if tag, err = c.ResolveTag(ctx, kind, namespace, name, tag); err != nil {
+			return zero, err
+		}
 		raw, err = c.Get(ctx, kind, namespace, name, tag)
 	}
 	if err != nil {
@@ -35,6 +41,31 @@ func GetTyped[T v1alpha1.Object](
 	return v1alpha1.EnvelopeFromRaw(newObj, raw, kind)
 }
 
+// ResolveTag maps a ref tag to the concrete tag it selects, mirroring the
+// server's reference resolution. Literal tags are returned unchanged; a
+// semver range resolves to the highest matching tag from ListTags that is
+// not yanked, or ErrNotFound when none matches.
+func (c *Client) ResolveTag(ctx context.Context, kind, namespace, name, tag string) (string, error) {
+	if !v1alpha1.IsTagRange(tag) {
+		return tag, nil
+	}
+	rows, err := c.ListTags(ctx, kind, namespace, name)
+	if err != nil {
+		return "", err
+	}
+	tags := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if row.Metadata.Yanked == nil {
+			tags = append(tags, row.Metadata.Tag)
+		}
+	}
+	resolved, ok := v1alpha1.HighestMatchingTag(tag, tags)
+	if !ok {
+		return "", fmt.Errorf("%w: no tag of %s %s matches %q", ErrNotFound, kind, name, tag)
+	}
+	return resolved, nil
+}
+
 // ListTyped lists resources of one kind and materializes each typed envelope.
 func ListTyped[T v1alpha1.Object](
 	ctx context.Context,
@@ -114,3 +145,33 @@ func ListTagsOfName[T v1alpha1.Object](
 	}
 	return out, nil
 }
+
+// WatchTyped is Watch with each event's object materialized into its typed
+// envelope. Bookmarks reach fn with the zero T.
+func WatchTyped[T v1alpha1.Object](
+	ctx context.Context,
+	c *Client,
+	kind string,
+	opts WatchOpts,
+	newObj func() T,
+	fn func(eventType, resourceVersion string, obj T) error,
+) error {
+	if c == nil {
+		return fmt.Errorf("client is nil")
+	}
+	return c.Watch(ctx, kind, opts, func(event arv0.WatchEvent) error {
+		var zero T
+		if len(event.Object) == 0 {
+			return fn(event.Type, event.ResourceVersion, zero)
+		}
+		var raw v1alpha1.RawObject
+		if err := json.Unmarshal(event.Object, &raw); err != nil {
+			return fmt.Errorf("decode %s watch event: %w", kind, err)
+		}
+		obj, err := v1alpha1.EnvelopeFromRaw(newObj, &raw, kind)
+		if err != nil {
+			return fmt.Errorf("decode %s watch event: %w", kind, err)
+		}
+		return fn(event.Type, event.ResourceVersion, obj)
+	})
+}
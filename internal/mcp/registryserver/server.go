@@ -11,12 +11,14 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/agentregistry-dev/agentregistry/internal/version"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
@@ -139,6 +141,11 @@ func NewServer(
 		Authorize:  authorizers[v1alpha1.KindRuntime],
 		ListFilter: listFilters[v1alpha1.KindRuntime],
 	})
+	addSearchTool(server, resource.SearchConfig{
+		Stores:      stores,
+		Authorizers: authorizers,
+		ListFilters: listFilters,
+	})
 	addMetaTools(server)
 	addServerPrompts(server)
 
@@ -221,17 +228,19 @@ func addKindTools[T v1alpha1.Object](server *mcp.Server, store *v1alpha1store.St
 // case-insensitive substring filter applied server-side against
 // metadata.name after Store.List returns a page.
 type listInput struct {
-	Namespace string `json:"namespace,omitempty" doc:"Filter by namespace (empty = all namespaces)"`
-	Cursor    string `json:"cursor,omitempty"    doc:"Pagination cursor returned by a previous call"`
-	Limit     int    `json:"limit,omitempty"     doc:"Max items (1-100, default 30)"`
-	Search    string `json:"search,omitempty"    doc:"Case-insensitive substring filter on metadata.name"`
-	Tag       string `json:"tag,omitempty"       doc:"'latest' to return only the literal latest tag per (namespace, name); empty returns every tag"`
+	Namespace     string `json:"namespace,omitempty" doc:"Filter by namespace (empty = all namespaces)"`
+	Cursor        string `json:"cursor,omitempty"    doc:"Pagination cursor returned by a previous call"`
+	Limit         int    `json:"limit,omitempty"     doc:"Max items (1-100, default 30)"`
+	Search        string `json:"search,omitempty"    doc:"Case-insensitive substring filter on metadata.name"`
+	Tag           string `json:"tag,omitempty"       doc:"'latest' to return only the literal latest tag per (namespace, name), skipping yanked ones; empty returns every tag"`
+	Labels        string `json:"labels,omitempty"    doc:"Label selector: key=value, key!=value, key in (a,b), key notin (a,b), key and !key, comma-separated"`
+	FieldSelector string `json:"fieldSelector,omitempty" doc:"Field selector: path=value or path!=value, comma-separated, e.g. spec.source.package.origin.type=npm or status.conditions[Ready]=True"`
 }
 
 type getByRefInput struct {
 	Namespace string `json:"namespace,omitempty" doc:"Namespace (empty defaults to 'default')"`
 	Name      string `json:"name"                doc:"Resource name"    required:"true"`
-	Tag       string `json:"tag,omitempty"       doc:"Exact tag; empty or 'latest' returns the literal latest tag"`
+	Tag       string `json:"tag,omitempty"       doc:"Exact tag; empty or 'latest' returns the literal latest tag. Deprecated and yanked tags carry metadata.deprecated or metadata.yanked"`
 }
 
 // listOutput is the generic envelope every list_* tool returns. Items
@@ -242,6 +251,49 @@ type listOutput[T v1alpha1.Object] struct {
 	Count      int    `json:"count"`
 }
 
+// searchInput is the input for the search tool; it mirrors the
+// GET /v0/search query parameters.
+type searchInput struct {
+	Query     string   `json:"query"               doc:"Search query. Words are AND-ed; \"quoted phrases\", 'or' and '-exclusions' are supported" required:"true"`
+	Kinds     []string `json:"kinds,omitempty"     doc:"Restrict to these kinds (e.g. MCPServer, Agent, Skill); empty searches every kind"`
+	Namespace string   `json:"namespace,omitempty" doc:"Restrict to one namespace (empty = all namespaces)"`
+	Labels    string   `json:"labels,omitempty"    doc:"Label selector: key=value, key!=value, key in (a,b), key notin (a,b), key and !key, comma-separated"`
+	Limit     int      `json:"limit,omitempty"     doc:"Max hits (1-100, default 20)"`
+	Offset    int      `json:"offset,omitempty"    doc:"Number of ranked hits to skip"`
+}
+
+// addSearchTool registers the cross-kind full-text `search` tool. It runs
+// the same resource.Search the REST endpoint does, so ranking, facets and
+// the per-kind authz hooks behave identically on both surfaces.
+func addSearchTool(server *mcp.Server, cfg resource.SearchConfig) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name: "search",
+		Description: "Full-text search across every registry kind (MCP servers, agents, skills, prompts, plugins, models, deployments, runtimes). " +
+			"Matches names, titles, descriptions, labels and plugin contents; returns ranked hits with highlighted excerpts plus counts per kind and label.",
+		OutputSchema: outputSchemaFor[arv0.SearchResponse](),
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, args searchInput) (*mcp.CallToolResult, arv0.SearchResponse, error) {
+		q := resource.SearchQuery{
+			Query:     args.Query,
+			Kinds:     args.Kinds,
+			Namespace: strings.TrimSpace(args.Namespace),
+			Limit:     args.Limit,
+			Offset:    args.Offset,
+		}
+		if args.Labels != "" {
+			labels, err := v1alpha1.ParseLabelSelector(args.Labels)
+			if err != nil {
+				return nil, arv0.SearchResponse{}, err
+			}
+			q.Labels = labels
+		}
+		resp, err := resource.Search(ctx, cfg, q)
+		if err != nil {
+			return nil, arv0.SearchResponse{}, err
+		}
+		return nil, *resp, nil
+	})
+}
+
 // Deployment note: only read tools (list + get) are exposed via MCP.
 // Create + delete equivalents live on the v1alpha1 apply surface at
 // /v0/deployments/{name}?namespace={ns} — MCP clients that need to
@@ -294,6 +346,20 @@ func runList(
 	if strings.EqualFold(tag, "latest") {
 		opts.LatestOnly = true
 	}
+	if args.Labels != "" {
+		selector, err := v1alpha1.ParseLabelSelector(args.Labels)
+		if err != nil {
+			return nil, "", err
+		}
+		opts.LabelSelector = selector
+	}
+	if args.FieldSelector != "" {
+		selector, err := v1alpha1.ParseFieldSelector(args.FieldSelector)
+		if err != nil {
+			return nil, "", err
+		}
+		opts.FieldSelector = selector
+	}
 	if listFilter != nil {
 		extraWhere, extraArgs, err := listFilter(ctx, resource.AuthorizeInput{Verb: "list", Kind: kind, Namespace: namespace})
 		if err != nil {
@@ -306,6 +372,12 @@ func runList(
 	if err != nil {
 		return nil, "", fmt.Errorf("list: %w", err)
 	}
+	if opts.LatestOnly {
+		// A yanked "latest" no longer counts as latest.
+		raws = slices.DeleteFunc(raws, func(raw *v1alpha1.RawObject) bool {
+			return raw.Metadata.Yanked != nil
+		})
+	}
 	return raws, next, nil
 }
 
@@ -378,6 +450,19 @@ func getEnvelope[T v1alpha1.Object](
 		var zero T
 		return nil, zero, fmt.Errorf("decode %s: %w", kind, err)
 	}
+	// A deprecated or yanked tag leads the text content with a warning, so
+	// clients that only read the text see it before the envelope.
+	if warning := resource.TagMarkerWarning(kind, &raw.Metadata); warning != "" {
+		body, err := json.Marshal(obj)
+		if err != nil {
+			var zero T
+			return nil, zero, fmt.Errorf("encode %s: %w", kind, err)
+		}
+		return &mcp.CallToolResult{Content: []mcp.Content{
+			&mcp.TextContent{Text: "warning: " + warning},
+			&mcp.TextContent{Text: string(body)},
+		}}, obj, nil
+	}
 	return nil, obj, nil
 }
 
@@ -410,7 +495,11 @@ func addServerPrompts(server *mcp.Server) {
 		if resourceType != "" {
 			instruction += " (filter to " + resourceType + " only)"
 		}
-		instruction += ". Use the appropriate list tool (list_servers, list_agents, list_skills, list_prompts, list_plugins, list_deployments, list_runtimes, list_models) with the search parameter. Summarize what you find including names, descriptions, and tags."
+		instruction += ". Use the search tool with this query"
+		if resourceType != "" {
+			instruction += " and the matching kind in its kinds filter"
+		}
+		instruction += "; its facet counts show where matches cluster if you need to narrow further. Use the matching get tool (get_server, get_agent, ...) for details on a hit. Summarize what you find including names, descriptions, and tags."
 
 		return &mcp.GetPromptResult{
 			Description: "Search the registry for resources matching a query",
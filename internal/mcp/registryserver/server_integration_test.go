@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
@@ -105,6 +106,23 @@ func TestMCPListServers_HappyPath(t *testing.T) {
 	require.NoError(t, json.Unmarshal(raw, &gotOne))
 	assert.Equal(t, serverName, gotOne.Metadata.Name)
 	assert.Equal(t, "Echo test server", gotOne.Spec.Description)
+
+	// search ranks across kinds and reports facets.
+	searchRes, err := clientSession.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "search",
+		Arguments: map[string]any{"query": "echo"},
+	})
+	require.NoError(t, err, "call search")
+	require.False(t, searchRes.IsError, "search tool error: %+v", searchRes.Content)
+	var found arv0.SearchResponse
+	raw, err = json.Marshal(searchRes.StructuredContent)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(raw, &found))
+	require.Equal(t, 1, found.Total)
+	require.Len(t, found.Hits, 1)
+	assert.Equal(t, v1alpha1.KindMCPServer, found.Hits[0].Kind)
+	assert.Equal(t, serverName, found.Hits[0].Name)
+	assert.Equal(t, map[string]int{v1alpha1.KindMCPServer: 1}, found.Facets.Kinds)
 }
 
 // seedMCPServer publishes one MCPServer so the authz-seam tests have a row to
@@ -220,6 +238,37 @@ func TestGetEnvelope_Authz(t *testing.T) {
 	})
 }
 
+// TestTagMarkers_Surface checks that a yanked latest tag leaves tag=latest
+// listings and that get leads with a warning for it.
+func TestTagMarkers_Surface(t *testing.T) {
+	ctx := context.Background()
+	pool := v1alpha1store.NewTestDB(t)
+	stores := v1alpha1store.NewStores(pool, v1alpha1store.TestSchemaRegistry())
+	ns, name := seedMCPServer(ctx, t, stores)
+	store := stores[v1alpha1.KindMCPServer]
+	newObj := func() *v1alpha1.MCPServer { return &v1alpha1.MCPServer{} }
+
+	_, err := store.SetYank(ctx, ns, name, v1alpha1store.DefaultTag(), &v1alpha1.Yank{Reason: "leaks credentials"})
+	require.NoError(t, err)
+
+	rows, _, err := runList(ctx, store, v1alpha1.KindMCPServer, nil, nil, listInput{Namespace: ns, Tag: "latest"})
+	require.NoError(t, err)
+	assert.Empty(t, rows, "yanked latest is not listed as latest")
+	rows, _, err = runList(ctx, store, v1alpha1.KindMCPServer, nil, nil, listInput{Namespace: ns})
+	require.NoError(t, err)
+	assert.Len(t, rows, 1, "every-tag listing still includes it")
+
+	res, obj, err := getEnvelope(ctx, store, v1alpha1.KindMCPServer, nil, getByRefInput{Namespace: ns, Name: name}, newObj)
+	require.NoError(t, err)
+	require.NotNil(t, obj.Metadata.Yanked)
+	assert.Equal(t, "leaks credentials", obj.Metadata.Yanked.Reason)
+	require.NotNil(t, res)
+	require.Len(t, res.Content, 2)
+	warning, ok := res.Content[0].(*mcp.TextContent)
+	require.True(t, ok)
+	assert.Contains(t, warning.Text, "is yanked: leaks credentials")
+}
+
 // envelopeMeta decodes just the identity of a v1alpha1 envelope, so one helper
 // can assert every kind's list_X/get_X output without a typed decode per kind.
 type envelopeMeta struct {
@@ -7,6 +7,7 @@ import (
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/stretchr/testify/require"
 
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 )
 
@@ -32,6 +33,18 @@ func TestOutputSchemaFor_AcceptsPopulatedRawMessage(t *testing.T) {
 			schema: outputSchemaFor[*v1alpha1.Deployment](),
 			value:  d,
 		},
+		"search": {
+			schema: outputSchemaFor[arv0.SearchResponse](),
+			value: arv0.SearchResponse{
+				Query: "weather",
+				Total: 1,
+				Hits:  []arv0.SearchHit{{Kind: v1alpha1.KindMCPServer, Namespace: "default", Name: "weather", Score: 0.5}},
+				Facets: arv0.SearchFacets{
+					Kinds:  map[string]int{v1alpha1.KindMCPServer: 1},
+					Labels: map[string]map[string]int{"team": {"climate": 1}},
+				},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
@@ -182,6 +183,13 @@ func listServers(cfg Config) func(context.Context, *listServersInput) (*serverLi
 			}
 			return nil, huma.Error500InternalServerError("list MCP servers", err)
 		}
+		if opts.LatestOnly {
+			// A yanked "latest" no longer counts as latest, so the default
+			// catalogue leaves the server out until it is republished.
+			rows = slices.DeleteFunc(rows, func(row *v1alpha1.RawObject) bool {
+				return row.Metadata.Yanked != nil
+			})
+		}
 		servers, err := translateRows(rows)
 		if err != nil {
 			return nil, err
@@ -128,6 +128,42 @@ func TestListServers_Envelope(t *testing.T) {
 	assert.Empty(t, store.lastOpts.ExtraWhere)
 }
 
+func TestListServers_SkipsYankedLatest(t *testing.T) {
+	yanked := rawMCPServer(t, "team-a", "weather", "latest", npmSpec("Weather"))
+	yanked.Metadata.Yanked = &v1alpha1.Yank{Reason: "leaks credentials"}
+	store := &fakeStore{
+		rows: []*v1alpha1.RawObject{
+			yanked,
+			rawMCPServer(t, "team-a", "clock", "latest", npmSpec("Clock")),
+		},
+	}
+	srv := newAPI(t, store)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0.1/servers", nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp mcpregistry.ServerListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Servers, 1)
+	assert.Equal(t, "team-a/clock", resp.Servers[0].Server.Name)
+
+	// The versions list still surfaces the yanked tag, marked.
+	req = httptest.NewRequest(http.MethodGet, "/v0.1/servers/team-a%2Fweather/versions", nil)
+	w = httptest.NewRecorder()
+	store.rows = []*v1alpha1.RawObject{yanked}
+	srv.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Servers, 1)
+	require.NotNil(t, resp.Servers[0].Meta)
+	assert.Equal(t, "deprecated", resp.Servers[0].Meta.Official.Status)
+	assert.False(t, resp.Servers[0].Meta.Official.IsLatest)
+	require.NotNil(t, resp.Servers[0].Meta.TagMarkers)
+	assert.Equal(t, "leaks credentials", resp.Servers[0].Meta.TagMarkers.Yanked.Reason)
+}
+
 func TestListServers_VersionAndFilters(t *testing.T) {
 	store := &fakeStore{}
 	srv := newAPI(t, store)
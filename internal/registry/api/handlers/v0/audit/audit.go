@@ -0,0 +1,112 @@
+// Package audit owns the audit log query endpoint: `GET /v0/audit`. Entries
+// are written by audit.Recorder as the v1alpha1 stores commit changes and as
+// authorizers deny requests; this package only reads them back.
+package audit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+const maxAuditLimit = 500
+
+// Config bundles the inputs for Register.
+type Config struct {
+	BasePrefix string
+	// Store reads the audit log. Nil (e.g. the noop database path used by
+	// gen-openapi) answers every request with 501.
+	Store *v1alpha1store.AuditStore
+	// Authorize gates every read. The log names every principal and carries
+	// spec diffs across all namespaces, so wire it to a registry-admin
+	// check. Nil means no gate.
+	Authorize func(ctx context.Context) error
+}
+
+type auditListInput struct {
+	Kind      string    `query:"kind" doc:"Only entries for this kind, e.g. Agent."`
+	Namespace string    `query:"namespace" doc:"Only entries in this namespace."`
+	Name      string    `query:"name" doc:"Only entries for this resource name."`
+	Tag       string    `query:"tag" doc:"Only entries for this tag."`
+	Principal string    `query:"principal" doc:"Only entries made by this principal."`
+	Verb      string    `query:"verb" doc:"Only entries with this verb: create, update, delete, deny, sign, approve, reject, deprecate, undeprecate, yank or unyank."`
+	RequestID string    `query:"requestId" doc:"Only entries recorded by this request."`
+	Since     time.Time `query:"since" doc:"Only entries at or after this RFC 3339 time."`
+	Until     time.Time `query:"until" doc:"Only entries before this RFC 3339 time."`
+	Limit     int       `query:"limit" minimum:"0" maximum:"500" doc:"Max entries to return (default 50)."`
+	Cursor    string    `query:"cursor" doc:"Pagination cursor from a previous response's nextCursor."`
+}
+
+type auditListOutput struct {
+	Body arv0.AuditListResponse
+}
+
+// Register wires GET {BasePrefix}/audit: the audit log, newest first,
+// filtered by resource, principal, verb, request ID and time range.
+func Register(api huma.API, cfg Config) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-audit-events",
+		Method:      http.MethodGet,
+		Path:        cfg.BasePrefix + "/audit",
+		Summary:     "List audit log entries",
+		Description: "Who created, updated, deleted or changed the status of which resource, and which requests were denied. Newest entries first.",
+	}, func(ctx context.Context, in *auditListInput) (*auditListOutput, error) {
+		if cfg.Authorize != nil {
+			if err := cfg.Authorize(ctx); err != nil {
+				return nil, err
+			}
+		}
+		if cfg.Store == nil {
+			return nil, huma.Error501NotImplemented("audit log is not configured")
+		}
+		limit := in.Limit
+		if limit > maxAuditLimit {
+			limit = maxAuditLimit
+		}
+		entries, next, err := cfg.Store.List(ctx, v1alpha1store.AuditQuery{
+			Kind:      in.Kind,
+			Namespace: in.Namespace,
+			Name:      in.Name,
+			Tag:       in.Tag,
+			Principal: in.Principal,
+			Verb:      in.Verb,
+			RequestID: in.RequestID,
+			Since:     in.Since,
+			Until:     in.Until,
+			Limit:     limit,
+			Cursor:    in.Cursor,
+		})
+		if err != nil {
+			if errors.Is(err, v1alpha1store.ErrInvalidCursor) {
+				return nil, huma.Error400BadRequest("invalid cursor")
+			}
+			return nil, huma.Error500InternalServerError("list audit events", err)
+		}
+		out := &auditListOutput{Body: arv0.AuditListResponse{
+			Items:      make([]arv0.AuditEntry, 0, len(entries)),
+			NextCursor: next,
+		}}
+		for _, e := range entries {
+			out.Body.Items = append(out.Body.Items, arv0.AuditEntry{
+				ID:        e.ID,
+				Time:      e.OccurredAt,
+				RequestID: e.RequestID,
+				Principal: e.Principal,
+				Verb:      e.Verb,
+				Kind:      e.Kind,
+				Namespace: e.Namespace,
+				Name:      e.Name,
+				Tag:       e.Tag,
+				Reason:    e.Reason,
+				Diff:      e.Diff,
+			})
+		}
+		return out, nil
+	})
+}
@@ -0,0 +1,46 @@
+package audit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+
+	v0audit "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/audit"
+)
+
+func TestAuditEndpointGates(t *testing.T) {
+	testCases := []struct {
+		name           string
+		authorize      func(context.Context) error
+		expectedStatus int
+	}{
+		{
+			name:           "denied callers get the authorizer's error",
+			authorize:      func(context.Context) error { return huma.Error403Forbidden("registry admin required") },
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "no store answers 501",
+			expectedStatus: http.StatusNotImplemented,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+			v0audit.Register(api, v0audit.Config{BasePrefix: "/v0", Authorize: tc.authorize})
+
+			req := httptest.NewRequest(http.MethodGet, "/v0/audit?kind=Agent", nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+		})
+	}
+}
@@ -59,14 +59,18 @@ type PerKindHooks struct {
 
 // Register wires the namespace-scoped + cross-namespace list endpoints for
 // registered v1alpha1 kinds against the supplied Stores map (as produced by
-// v1alpha1store.NewStores). Each kind shares the same BasePrefix and cross-kind
-// Resolver.
+// v1alpha1store.NewStores). Each kind shares the same BasePrefix, cross-kind
+// Resolver and referrer lookup, so deletes of referenced resources are refused
+// without ?force=true.
 //
 // Kinds with no Store entry or no registered typed binding are silently
 // skipped; callers that want strict behavior should validate the maps ahead of
 // the call. Go generics still require a concrete type at the route-registration
 // call site, so bindings.go remains the small typed companion to the generic
 // v1alpha1 kind registry.
+//
+// watch, when non-nil, enables ?watch=true on every kind's list route, and
+// namespaceExists, when non-nil, refuses PUTs into undeclared namespaces.
 func Register(
 	api huma.API,
 	basePrefix string,
@@ -75,26 +79,33 @@ func Register(
 	registryValidator v1alpha1.RegistryValidatorFunc,
 	perKind PerKindHooks,
 	deleteAdmission types.DeleteAdmission,
+	watch *resource.WatchConfig,
+	namespaceExists func(ctx context.Context, namespace string) (bool, error),
 ) {
+	referrers := resource.NewReferrerLookup(stores)
 	cfgFor := func(kind string) (resource.Config, bool) {
 		store, ok := stores[kind]
 		if !ok {
 			return resource.Config{}, false
 		}
 		return resource.Config{
-			Kind:               kind,
-			BasePrefix:         basePrefix,
-			Store:              store,
-			Resolver:           resolver,
-			RegistryValidator:  registryValidator,
-			Authorize:          perKind.Authorizers[kind],
-			ListFilter:         perKind.ListFilters[kind],
-			EnableOriginFilter: kind == v1alpha1.KindDeployment,
-			PostUpsert:         perKind.PostUpserts[kind],
-			PostDelete:         perKind.PostDeletes[kind],
-			Prepare:            perKind.Prepares[kind],
-			DeleteAdmission:    deleteAdmission,
-			InitialFinalizers:  perKind.InitialFinalizers[kind],
+			Kind:                kind,
+			BasePrefix:          basePrefix,
+			Store:               store,
+			Resolver:            resolver,
+			RegistryValidator:   registryValidator,
+			Authorize:           perKind.Authorizers[kind],
+			ListFilter:          perKind.ListFilters[kind],
+			EnableOriginFilter:  kind == v1alpha1.KindDeployment,
+			PostUpsert:          perKind.PostUpserts[kind],
+			PostDelete:          perKind.PostDeletes[kind],
+			Prepare:             perKind.Prepares[kind],
+			DeleteAdmission:     deleteAdmission,
+			Referrers:           referrers,
+			ReferrerAuthorizers: perKind.Authorizers,
+			InitialFinalizers:   perKind.InitialFinalizers[kind],
+			Watch:               watch,
+			NamespaceExists:     namespaceExists,
 		}, true
 	}
 
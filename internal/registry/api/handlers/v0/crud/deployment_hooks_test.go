@@ -71,7 +71,9 @@ func seedDeploymentFixtures(t *testing.T) (humatest.TestAPI, map[string]*v1alpha
 				},
 			},
 		},
-		nil,
+		nil, // deleteAdmission
+		nil, // watch
+		nil, // namespaceExists
 	)
 	deploymentlogs.Register(api, deploymentlogs.Config{
 		BasePrefix:  "/v0",
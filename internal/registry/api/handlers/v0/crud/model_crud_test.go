@@ -23,7 +23,7 @@ func TestModelCRUD(t *testing.T) {
 	pool := v1alpha1store.NewTestPool(t)
 	stores := v1alpha1store.NewStores(pool, v1alpha1store.TestSchemaRegistry())
 	_, api := humatest.New(t)
-	crud.Register(api, "/v0", stores, nil, nil, crud.PerKindHooks{}, nil)
+	crud.Register(api, "/v0", stores, nil, nil, crud.PerKindHooks{}, nil, nil, nil)
 	resource.RegisterApply(api, resource.ApplyConfig{BasePrefix: "/v0", Stores: stores})
 
 	applyModel := func(model v1alpha1.Model) arv0.ApplyResult {
@@ -48,7 +48,7 @@ type Config struct {
 // path segment for the deployment identity. Namespace rides on the
 // ?namespace= query to match the main resource handler shape.
 type deploymentLogsInput struct {
-	Namespace string `query:"namespace" doc:"Namespace (internal; defaults to 'default')."`
+	Namespace string `query:"namespace" doc:"Namespace (defaults to 'default')."`
 	Name      string `path:"name"`
 	Follow    bool   `query:"follow" doc:"Stream indefinitely until client disconnects."`
 	TailLines int    `query:"tailLines" doc:"Max backlog lines before live tail; 0 = unbounded."`
@@ -77,10 +77,10 @@ type deploymentLogsOutput struct {
 // follow=true keeps the channel open until the client disconnects (or until the
 // adapter's context is cancelled).
 //
-// Non-streaming for now — huma lacks first-class SSE output and the
-// kubernetes adapter still returns a closed channel. When real log
-// streaming lands upstream, swap this for an SSE/chunked handler at the
-// same path without touching the adapter resolver surface.
+// Non-streaming for now — huma lacks first-class SSE output, so only the
+// backlog is drained even though the kubernetes adapter can follow. Swap
+// this for an SSE/chunked handler at the same path without touching the
+// adapter resolver surface.
 func Register(api huma.API, cfg Config) {
 	path := cfg.BasePrefix + "/deployments/{name}/logs"
 
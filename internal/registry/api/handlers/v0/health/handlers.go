@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"go.opentelemetry.io/otel/attribute"
@@ -16,10 +17,22 @@ import (
 
 // HealthBody represents the health check response body.
 type HealthBody struct {
-	Status string `json:"status" example:"ok" doc:"Health status"`
+	Status     string            `json:"status" example:"ok" doc:"Health status"`
+	Controller *ControllerHealth `json:"controller,omitempty" doc:"Controller leader election; omitted when it is disabled"`
 }
 
-func RegisterHealthEndpoint(api huma.API, pathPrefix string, cfg *config.Config, metrics *telemetry.Metrics) {
+// ControllerHealth reports this replica's part in controller leader
+// election. Followers serve the API but leave reconciliation to the leader.
+type ControllerHealth struct {
+	Role     string    `json:"role" enum:"leader,follower" doc:"Whether this replica runs the controllers"`
+	Identity string    `json:"identity" doc:"This replica's name in the controller lease"`
+	Leader   string    `json:"leader,omitempty" doc:"Replica last seen holding the controller lease"`
+	Since    time.Time `json:"since" doc:"When this replica took its current role"`
+}
+
+// RegisterHealthEndpoint registers GET {pathPrefix}/health. controller may
+// be nil when leader election is disabled.
+func RegisterHealthEndpoint(api huma.API, pathPrefix string, cfg *config.Config, metrics *telemetry.Metrics, controller func() *ControllerHealth) {
 	huma.Register(api, huma.Operation{
 		OperationID: "get-health" + strings.ReplaceAll(pathPrefix, "/", "-"),
 		Method:      http.MethodGet,
@@ -30,11 +43,11 @@ func RegisterHealthEndpoint(api huma.API, pathPrefix string, cfg *config.Config,
 	}, func(ctx context.Context, _ *struct{}) (*types.Response[HealthBody], error) {
 		recordHealthMetrics(ctx, metrics, pathPrefix+"/health", cfg.Version)
 
-		return &types.Response[HealthBody]{
-			Body: HealthBody{
-				Status: "ok",
-			},
-		}, nil
+		body := HealthBody{Status: "ok"}
+		if controller != nil {
+			body.Controller = controller()
+		}
+		return &types.Response[HealthBody]{Body: body}, nil
 	})
 }
 
@@ -19,8 +19,10 @@ func TestHealthEndpoint(t *testing.T) {
 	testCases := []struct {
 		name           string
 		config         *config.Config
+		controller     func() *v0health.ControllerHealth
 		expectedStatus int
 		expectedBody   v0health.HealthBody
+		expectedFields []string
 	}{
 		{
 			name:           "returns health status",
@@ -30,6 +32,15 @@ func TestHealthEndpoint(t *testing.T) {
 				Status: "ok",
 			},
 		},
+		{
+			name:   "reports controller role",
+			config: &config.Config{},
+			controller: func() *v0health.ControllerHealth {
+				return &v0health.ControllerHealth{Role: "follower", Identity: "replica-b", Leader: "replica-a"}
+			},
+			expectedStatus: http.StatusOK,
+			expectedFields: []string{`"role":"follower"`, `"identity":"replica-b"`, `"leader":"replica-a"`},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -39,7 +50,7 @@ func TestHealthEndpoint(t *testing.T) {
 
 			shutdownTelemetry, metrics, _ := telemetry.InitMetrics("test")
 
-			v0health.RegisterHealthEndpoint(api, "/v0", tc.config, metrics)
+			v0health.RegisterHealthEndpoint(api, "/v0", tc.config, metrics, tc.controller)
 
 			req := httptest.NewRequest(http.MethodGet, "/v0/health", nil)
 			w := httptest.NewRecorder()
@@ -52,6 +63,12 @@ func TestHealthEndpoint(t *testing.T) {
 
 			body := w.Body.String()
 			assert.Contains(t, body, `"status":"ok"`)
+			for _, field := range tc.expectedFields {
+				assert.Contains(t, body, field)
+			}
+			if tc.controller == nil {
+				assert.NotContains(t, body, `"controller"`)
+			}
 		})
 	}
 }
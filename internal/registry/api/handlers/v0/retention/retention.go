@@ -0,0 +1,61 @@
+// Package retention owns the tag retention dry-run endpoint:
+// `GET /v0/retention/tags`. The tag retention controller prunes on its own
+// schedule; this package only reports what its next pass would delete.
+package retention
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+)
+
+// Planner computes a tag retention pass without applying it.
+// *retention.Pruner implements it.
+type Planner interface {
+	Plan(ctx context.Context) (*arv0.TagRetentionReport, error)
+}
+
+// Config bundles the inputs for Register.
+type Config struct {
+	BasePrefix string
+	// Planner computes the report. Nil (e.g. the noop database path used
+	// by gen-openapi) answers every request with 501.
+	Planner Planner
+	// Authorize gates every read. The report spans every namespace, so
+	// wire it to a registry-admin check. Nil means no gate.
+	Authorize func(ctx context.Context) error
+}
+
+type tagRetentionOutput struct {
+	Body arv0.TagRetentionReport
+}
+
+// Register wires GET {BasePrefix}/retention/tags: the tags the next tag
+// retention pass would prune, and those it would hold back because live
+// objects reference them.
+func Register(api huma.API, cfg Config) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-tag-retention-report",
+		Method:      http.MethodGet,
+		Path:        cfg.BasePrefix + "/retention/tags",
+		Summary:     "Preview tag retention",
+		Description: "Which artifact tags the next tag retention pass would delete, and which it would keep because live objects still reference them. Nothing is deleted.",
+	}, func(ctx context.Context, _ *struct{}) (*tagRetentionOutput, error) {
+		if cfg.Authorize != nil {
+			if err := cfg.Authorize(ctx); err != nil {
+				return nil, err
+			}
+		}
+		if cfg.Planner == nil {
+			return nil, huma.Error501NotImplemented("tag retention is not configured")
+		}
+		report, err := cfg.Planner.Plan(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("plan tag retention", err)
+		}
+		return &tagRetentionOutput{Body: *report}, nil
+	})
+}
@@ -0,0 +1,78 @@
+package retention_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0retention "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/retention"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+)
+
+type fakePlanner struct {
+	report *arv0.TagRetentionReport
+}
+
+func (f fakePlanner) Plan(context.Context) (*arv0.TagRetentionReport, error) {
+	return f.report, nil
+}
+
+func TestTagRetentionEndpoint(t *testing.T) {
+	report := &arv0.TagRetentionReport{
+		Prune: []arv0.TagRetentionItem{{Kind: "MCPServer", Namespace: "default", Name: "acme-fetch", Tag: "1.0.0", Reason: arv0.TagRetentionReasonKeepLast}},
+		Held: []arv0.TagRetentionItem{{
+			Kind: "MCPServer", Namespace: "default", Name: "acme-fetch", Tag: "1.1.0", Reason: arv0.TagRetentionReasonKeepLast,
+			ReferencedBy: []string{"Agent default/support@1.0.0"},
+		}},
+	}
+	testCases := []struct {
+		name           string
+		planner        v0retention.Planner
+		authorize      func(context.Context) error
+		expectedStatus int
+	}{
+		{
+			name:           "denied callers get the authorizer's error",
+			planner:        fakePlanner{report: report},
+			authorize:      func(context.Context) error { return huma.Error403Forbidden("registry admin required") },
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "no planner answers 501",
+			expectedStatus: http.StatusNotImplemented,
+		},
+		{
+			name:           "returns the planned report",
+			planner:        fakePlanner{report: report},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+			v0retention.Register(api, v0retention.Config{BasePrefix: "/v0", Planner: tc.planner, Authorize: tc.authorize})
+
+			req := httptest.NewRequest(http.MethodGet, "/v0/retention/tags", nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			if w.Code != http.StatusOK {
+				return
+			}
+			var got arv0.TagRetentionReport
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			assert.Equal(t, "1.0.0", got.Prune[0].Tag)
+			assert.Equal(t, []string{"Agent default/support@1.0.0"}, got.Held[0].ReferencedBy)
+		})
+	}
+}
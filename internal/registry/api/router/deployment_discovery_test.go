@@ -10,7 +10,6 @@ import (
 	"github.com/danielgtaylor/huma/v2/humatest"
 	"github.com/stretchr/testify/require"
 
-	"github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/crud"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
 )
@@ -35,18 +34,7 @@ func TestDeploymentListFiltersPersistedDiscoveredRows(t *testing.T) {
 	require.NoError(t, err)
 
 	_, api := humatest.New(t)
-	registerKindRoutes(
-		api,
-		"/v0",
-		stores,
-		nil,
-		crud.PerKindHooks{},
-		nil,
-		nil,
-		nil,
-		nil,
-		nil,
-	)
+	registerKindRoutes(api, kindRoutesConfig{BasePrefix: "/v0", Stores: stores})
 
 	all := listDeploymentsForDiscoveryTest(t, api, "/v0/deployments")
 	require.Len(t, all.Items, 3)
@@ -0,0 +1,73 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+// namespaceDeleteAdmission protects Namespace deletes and hands every other
+// delete to next (resource.ProductionDeleteAdmission when nil). The default
+// namespace can never be deleted, and any other namespace is only deleted
+// once no store holds a row in it, terminating rows included, so a delete
+// never orphans resources behind a vanished tenancy boundary.
+func namespaceDeleteAdmission(stores Stores, next types.DeleteAdmission) types.DeleteAdmission {
+	if next == nil {
+		next = resource.ProductionDeleteAdmission
+	}
+	return func(ctx context.Context, in types.DeleteAdmissionInput) (types.DeleteAdmissionResult, error) {
+		if in.Kind != v1alpha1.KindNamespace {
+			return next(ctx, in)
+		}
+		if in.Name == v1alpha1.DefaultNamespace {
+			return types.DeleteAdmissionResult{}, huma.Error409Conflict("the default namespace cannot be deleted")
+		}
+		kind, err := namespaceOccupant(ctx, stores, in.Name)
+		if err != nil {
+			return types.DeleteAdmissionResult{}, fmt.Errorf("check namespace %q contents: %w", in.Name, err)
+		}
+		if kind != "" {
+			return types.DeleteAdmissionResult{}, huma.Error409Conflict(fmt.Sprintf(
+				"namespace %q is not empty: it still contains %s resources; delete them first",
+				in.Name, kind))
+		}
+		return next(ctx, in)
+	}
+}
+
+// namespaceOccupant returns the first kind (in name order, for stable error
+// messages) with at least one row in namespace, or "" when it is empty.
+func namespaceOccupant(ctx context.Context, stores Stores, namespace string) (string, error) {
+	kinds := make([]string, 0, len(stores))
+	for kind := range stores {
+		if kind != v1alpha1.KindNamespace {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		store := stores[kind]
+		if store == nil {
+			continue
+		}
+		rows, _, err := store.List(ctx, v1alpha1store.ListOpts{
+			Namespace:          namespace,
+			Limit:              1,
+			IncludeTerminating: true,
+		})
+		if err != nil {
+			return "", fmt.Errorf("list %s: %w", kind, err)
+		}
+		if len(rows) > 0 {
+			return kind, nil
+		}
+	}
+	return "", nil
+}
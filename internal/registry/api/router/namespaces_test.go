@@ -0,0 +1,102 @@
+//go:build integration
+
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/require"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+func TestNamespaceDeleteRefusedWhileOccupied(t *testing.T) {
+	pool := v1alpha1store.NewTestPool(t)
+	stores := v1alpha1store.NewStores(pool, v1alpha1store.TestSchemaRegistry())
+	ctx := t.Context()
+
+	_, err := stores[v1alpha1.KindNamespace].Upsert(ctx, &v1alpha1.Namespace{
+		Metadata: v1alpha1.ObjectMeta{Namespace: v1alpha1.DefaultNamespace, Name: "team-a"},
+	})
+	require.NoError(t, err)
+	_, err = stores[v1alpha1.KindRuntime].Upsert(ctx, &v1alpha1.Runtime{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "team-a", Name: "kube"},
+		Spec:     v1alpha1.RuntimeSpec{Type: v1alpha1.TypeKubernetes},
+	})
+	require.NoError(t, err)
+
+	_, api := humatest.New(t)
+	registerKindRoutes(api, kindRoutesConfig{BasePrefix: "/v0", Stores: stores})
+
+	resp := api.Delete("/v0/namespaces/team-a")
+	require.Equal(t, http.StatusConflict, resp.Code, resp.Body.String())
+	require.Contains(t, resp.Body.String(), "Runtime")
+
+	resp = api.Delete("/v0/namespaces/default")
+	require.Equal(t, http.StatusConflict, resp.Code, resp.Body.String())
+
+	require.NoError(t, stores[v1alpha1.KindRuntime].Delete(ctx, "team-a", "kube", ""))
+
+	resp = api.Delete("/v0/namespaces/team-a")
+	require.Equal(t, http.StatusNoContent, resp.Code, resp.Body.String())
+}
+
+func TestNamespaceApplyRequiresDeclaredNamespace(t *testing.T) {
+	db := v1alpha1store.NewTestDB(t)
+	stores := v1alpha1store.NewStores(db, v1alpha1store.TestSchemaRegistry())
+
+	_, api := humatest.New(t)
+	registerKindRoutes(api, kindRoutesConfig{BasePrefix: "/v0", Stores: stores})
+
+	runtime := func(namespace string) map[string]any {
+		return map[string]any{
+			"apiVersion": v1alpha1.GroupVersion,
+			"kind":       v1alpha1.KindRuntime,
+			"metadata":   map[string]any{"namespace": namespace, "name": "kube"},
+			"spec":       map[string]any{"type": v1alpha1.TypeKubernetes},
+		}
+	}
+	resp := api.Put("/v0/runtimes/kube?namespace=team-b", runtime("team-b"))
+	require.Equal(t, http.StatusNotFound, resp.Code, resp.Body.String())
+	require.Contains(t, resp.Body.String(), `namespace \"team-b\" does not exist`)
+	resp = api.Put("/v0/runtimes/kube", runtime(v1alpha1.DefaultNamespace))
+	require.Equal(t, http.StatusOK, resp.Code, "the default namespace needs no declaration: %s", resp.Body.String())
+
+	apply := func(yaml string) []arv0.ApplyResult {
+		t.Helper()
+		resp := api.Post("/v0/apply", "Content-Type: application/yaml", strings.NewReader(yaml))
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+		var out arv0.ApplyResultsResponse
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &out))
+		return out.Results
+	}
+	runtimeDoc := `apiVersion: ar.dev/v1alpha1
+kind: Runtime
+metadata:
+  namespace: team-b
+  name: kube
+spec:
+  type: Kubernetes
+`
+	results := apply(runtimeDoc)
+	require.Len(t, results, 1)
+	require.Equal(t, arv0.ApplyStatusFailed, results[0].Status)
+	require.Equal(t, arv0.ApplyReasonNamespaceNotFound, results[0].Reason)
+
+	results = apply(`apiVersion: ar.dev/v1alpha1
+kind: Namespace
+metadata:
+  name: team-b
+---
+` + runtimeDoc)
+	require.Len(t, results, 2)
+	for _, r := range results {
+		require.Equal(t, arv0.ApplyStatusCreated, r.Status, r.Error)
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
 	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
 	"github.com/agentregistry-dev/agentregistry/pkg/logging"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/audit"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 )
 
@@ -89,6 +91,24 @@ func MetricTelemetryMiddleware(metrics *telemetry.Metrics, options ...Middleware
 	}
 }
 
+// RequestIDMiddleware carries the caller's X-Request-Id header, or a fresh
+// UUID when absent or oversized, into the request context for the audit log
+// and echoes it on the response.
+func RequestIDMiddleware() func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		id := strings.TrimSpace(ctx.Header(audit.RequestIDHeader))
+		if id == "" || len(id) > maxRequestIDLength {
+			id = uuid.NewString()
+		}
+		ctx.SetHeader(audit.RequestIDHeader, id)
+		next(huma.WithContext(ctx, audit.WithRequestID(ctx.Context(), id)))
+	}
+}
+
+// maxRequestIDLength bounds caller-supplied request IDs stored in the audit
+// log.
+const maxRequestIDLength = 128
+
 // WithSkipPaths allows skipping instrumentation for specific paths
 func WithSkipPaths(paths ...string) MiddlewareOption {
 	return func(c *middlewareConfig) {
@@ -159,6 +179,10 @@ func NewHumaAPI(
 	// Create a new API using humago adapter for standard library
 	api := humago.New(mux, humaConfig)
 
+	// Tag every request with an ID before authn so audit entries, including
+	// authorization denials, can be traced back to it.
+	api.UseMiddleware(RequestIDMiddleware())
+
 	// Add authn middleware if configured
 	if authnProvider != nil {
 		middlewareOpts := []auth.MiddlewareOption{
@@ -9,10 +9,12 @@ import (
 
 	mcpregistrycompat "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/mcpregistry"
 	pluginmarketplacecompat "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/pluginmarketplace"
+	v0audit "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/audit"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/crud"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/deploymentlogs"
 	v0health "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/health"
 	v0ping "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/ping"
+	v0retention "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/retention"
 	v0version "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/version"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
 	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
@@ -89,6 +91,46 @@ type RouteOptions struct {
 	// TODO(controller): temporary bridge for pending staged refs during HTTP apply.
 	ResolverWrapper func(v1alpha1.ResolverFunc) v1alpha1.ResolverFunc
 
+	// ControllerHealth reports controller leader election on /v0/health.
+	// Nil omits it, as when leader election is disabled.
+	ControllerHealth func() *v0health.ControllerHealth
+
+	// Watch enables ?watch=true change streams on every list route. Nil
+	// (e.g. the noop database path used by gen-openapi) answers watch
+	// requests with 501.
+	Watch *resource.WatchConfig
+
+	// AuthorizeTagOverwrite gates /v0/apply?overwriteImmutableTags=true,
+	// the override of the tag immutability policy (config.Config.TagPolicy
+	// or the Namespace's spec.tagPolicy). Nil refuses every override.
+	AuthorizeTagOverwrite func(ctx context.Context, in resource.AuthorizeInput) error
+
+	// VerifySignature enforces the artifact signature policy on
+	// /v0/apply. Nil requires no signatures.
+	VerifySignature resource.SignatureVerifier
+
+	// ApprovalPolicy holds publishes into namespaces with an approval
+	// policy for review on /v0/apply. Nil requires no approvals.
+	ApprovalPolicy resource.ApprovalPolicy
+
+	// AuthorizeApprovalReview gates POST /v0/approvals for kinds without a
+	// per-kind authorizer. Nil refuses those reviews.
+	AuthorizeApprovalReview func(ctx context.Context, in resource.AuthorizeInput) error
+
+	// AuditEvents serves GET /v0/audit. Nil answers it with 501.
+	AuditEvents *v1alpha1store.AuditStore
+
+	// AuthorizeAudit gates GET /v0/audit. Nil leaves it ungated.
+	AuthorizeAudit func(ctx context.Context) error
+
+	// TagRetention serves GET /v0/retention/tags, the tag retention dry
+	// run. Nil answers it with 501.
+	TagRetention v0retention.Planner
+
+	// AuthorizeTagRetention gates GET /v0/retention/tags. Nil leaves it
+	// ungated.
+	AuthorizeTagRetention func(ctx context.Context) error
+
 	// ExtraResourceRoutes registers adjacent routes with access to the same
 	// v1alpha1 stores and hooks used by /v0/apply.
 	// TODO(controller): temporary bridge for downstream synchronous approval routes.
@@ -115,25 +157,40 @@ func RegisterRoutes(
 
 	pathPrefix := "/v0"
 
-	v0health.RegisterHealthEndpoint(api, pathPrefix, cfg, metrics)
+	v0health.RegisterHealthEndpoint(api, pathPrefix, cfg, metrics, opts.ControllerHealth)
 	v0ping.RegisterPingEndpoint(api, pathPrefix)
 	v0version.RegisterVersionEndpoint(api, pathPrefix, versionInfo)
+	v0audit.Register(api, v0audit.Config{
+		BasePrefix: pathPrefix,
+		Store:      opts.AuditEvents,
+		Authorize:  opts.AuthorizeAudit,
+	})
+	v0retention.Register(api, v0retention.Config{
+		BasePrefix: pathPrefix,
+		Planner:    opts.TagRetention,
+		Authorize:  opts.AuthorizeTagRetention,
+	})
 
 	// v1alpha1 generic routes. Cross-kind dangling-ref detection uses
 	// a Store-backed resolver. Deployment side effects are handled by
 	// the always-on Deployment controller after the row is persisted.
-	registerKindRoutes(
-		api,
-		pathPrefix,
-		opts.Stores,
-		opts.DeploymentLogResolver,
-		opts.PerKindHooks,
-		opts.RegistryValidator,
-		opts.Admission,
-		opts.DeleteAdmission,
-		opts.ResolverWrapper,
-		opts.ExtraResourceRoutes,
-	)
+	registerKindRoutes(api, kindRoutesConfig{
+		BasePrefix:            pathPrefix,
+		Stores:                opts.Stores,
+		LogResolver:           opts.DeploymentLogResolver,
+		PerKind:               opts.PerKindHooks,
+		RegistryValidator:     opts.RegistryValidator,
+		Admission:             opts.Admission,
+		DeleteAdmission:       opts.DeleteAdmission,
+		ResolverWrapper:       opts.ResolverWrapper,
+		ExtraResourceRoutes:   opts.ExtraResourceRoutes,
+		Watch:                 opts.Watch,
+		TagPolicy:             resource.NewTagPolicyLookup(opts.Stores, cfg.TagPolicy()),
+		AuthorizeTagOverwrite: opts.AuthorizeTagOverwrite,
+		VerifySignature:       opts.VerifySignature,
+		ApprovalPolicy:        opts.ApprovalPolicy,
+		AuthorizeReview:       opts.AuthorizeApprovalReview,
+	})
 
 	if opts.ExtraRoutes != nil {
 		opts.ExtraRoutes(api, pathPrefix)
@@ -192,6 +249,29 @@ func RegisterRoutes(
 	return nil
 }
 
+// kindRoutesConfig is what registerKindRoutes wires the resource routes
+// with. The fields mirror the RouteOptions they are taken from; nil hooks
+// keep their RouteOptions meaning.
+type kindRoutesConfig struct {
+	BasePrefix          string
+	Stores              Stores
+	LogResolver         deploymentlogs.LogResolver
+	PerKind             crud.PerKindHooks
+	RegistryValidator   v1alpha1.RegistryValidatorFunc
+	Admission           types.Admission
+	DeleteAdmission     types.DeleteAdmission
+	ResolverWrapper     func(v1alpha1.ResolverFunc) v1alpha1.ResolverFunc
+	ExtraResourceRoutes func(api huma.API, pathPrefix string, ctx types.ResourceRouteContext)
+	Watch               *resource.WatchConfig
+	// TagPolicy reports a namespace's tag immutability policy. Nil
+	// protects no tags.
+	TagPolicy             func(ctx context.Context, kind, namespace string) (v1alpha1.TagPolicy, error)
+	AuthorizeTagOverwrite func(ctx context.Context, in resource.AuthorizeInput) error
+	VerifySignature       resource.SignatureVerifier
+	ApprovalPolicy        resource.ApprovalPolicy
+	AuthorizeReview       func(ctx context.Context, in resource.AuthorizeInput) error
+}
+
 // registerKindRoutes wires the generic resource handler for every
 // built-in kind. Tagged artifacts use
 // `{basePrefix}/{plural}/{name}/{tag}`; mutable objects use
@@ -200,36 +280,33 @@ func RegisterRoutes(
 // widens scope across every namespace. The multi-doc apply endpoint
 // lives at `{basePrefix}/apply`. Cross-kind ResourceRef existence
 // dispatches through the shared internaldb.NewResolver.
-func registerKindRoutes(
-	api huma.API,
-	basePrefix string,
-	stores Stores,
-	logResolver deploymentlogs.LogResolver,
-	perKind crud.PerKindHooks,
-	registryValidator v1alpha1.RegistryValidatorFunc,
-	admission types.Admission,
-	deleteAdmission types.DeleteAdmission,
-	resolverWrapper func(v1alpha1.ResolverFunc) v1alpha1.ResolverFunc,
-	extraResourceRoutes func(api huma.API, pathPrefix string, ctx types.ResourceRouteContext),
-) resource.ApplyConfig {
+func registerKindRoutes(api huma.API, cfg kindRoutesConfig) resource.ApplyConfig {
+	basePrefix, stores, perKind := cfg.BasePrefix, cfg.Stores, cfg.PerKind
 	resolver := internaldb.NewResolver(stores)
-	if resolverWrapper != nil {
-		resolver = resolverWrapper(resolver)
+	if cfg.ResolverWrapper != nil {
+		resolver = cfg.ResolverWrapper(resolver)
 	}
+	registryValidator := cfg.RegistryValidator
 	if registryValidator == nil {
 		registryValidator = registries.Dispatcher
 	}
+	// Namespace deletes are refused while the namespace still holds
+	// resources, whichever delete admission owns the final write.
+	deleteAdmission := namespaceDeleteAdmission(stores, cfg.DeleteAdmission)
+	// Applies are refused into namespaces no Namespace object declares,
+	// so nothing lands outside a namespace the delete protection covers.
+	nsExists := resource.NewNamespaceExists(stores)
 	// Per-kind CRUD endpoints — one call per built-in kind, hidden
 	// inside crud.Register.
-	crud.Register(api, basePrefix, stores, resolver, registryValidator, perKind, deleteAdmission)
+	crud.Register(api, basePrefix, stores, resolver, registryValidator, perKind, deleteAdmission, cfg.Watch, nsExists)
 
 	// Deployment-specific endpoints: logs stream (cancel is subsumed
 	// by DesiredState=undeployed + DELETE in the v1alpha1 lifecycle).
-	if logResolver != nil {
+	if cfg.LogResolver != nil {
 		deploymentlogs.Register(api, deploymentlogs.Config{
 			BasePrefix:  basePrefix,
 			Store:       stores[v1alpha1.KindDeployment],
-			LogResolver: logResolver,
+			LogResolver: cfg.LogResolver,
 			Authorize:   perKind.Authorizers[v1alpha1.KindDeployment],
 		})
 	}
@@ -260,22 +337,58 @@ func registerKindRoutes(
 		PostUpserts:       perKind.PostUpserts,
 		PostDeletes:       perKind.PostDeletes,
 		InitialFinalizers: perKind.InitialFinalizers,
-		Admission:         admission,
+		Admission:         cfg.Admission,
 		DeleteAdmission:   deleteAdmission,
 		Prepare:           applyPrepare,
+		NamespaceExists:   nsExists,
+
+		TagPolicy:             cfg.TagPolicy,
+		AuthorizeTagOverwrite: cfg.AuthorizeTagOverwrite,
+		VerifySignature:       cfg.VerifySignature,
+		ApprovalPolicy:        cfg.ApprovalPolicy,
+
+		Referrers: resource.NewReferrerLookup(stores),
 	}
 	productionApplyCfg := applyCfg
 	productionApplyCfg.Admission = resource.ProductionAdmission
 	productionDeleteCfg := applyCfg
-	productionDeleteCfg.DeleteAdmission = resource.ProductionDeleteAdmission
+	productionDeleteCfg.DeleteAdmission = namespaceDeleteAdmission(stores, nil)
 	resource.RegisterApply(api, applyCfg)
 
-	if extraResourceRoutes != nil {
+	// Cross-kind full-text search at GET {basePrefix}/search scopes each
+	// kind with the same list hooks the per-kind list endpoints use.
+	resource.RegisterSearch(api, resource.SearchConfig{
+		BasePrefix:  basePrefix,
+		Stores:      stores,
+		Authorizers: perKind.Authorizers,
+		ListFilters: perKind.ListFilters,
+	})
+
+	// Dependency graph at GET {basePrefix}/graph walks refs through the
+	// same Getter reconcilers use, so it shows the closure they resolve.
+	resource.RegisterGraph(api, resource.GraphConfig{
+		BasePrefix:  basePrefix,
+		Stores:      stores,
+		Getter:      internaldb.NewGetter(stores),
+		Authorizers: perKind.Authorizers,
+	})
+
+	// Review queue at {basePrefix}/approvals for content held by a
+	// namespace's approval policy. Reviewers need the "approve" action on
+	// the artifact's kind, or registry admin where the kind has no hook.
+	resource.RegisterApprovals(api, resource.ApprovalsConfig{
+		BasePrefix:      basePrefix,
+		Stores:          stores,
+		Authorizers:     perKind.Authorizers,
+		AuthorizeReview: cfg.AuthorizeReview,
+	})
+
+	if cfg.ExtraResourceRoutes != nil {
 		opaqueStores := make(map[string]any, len(stores))
 		for kind, store := range stores {
 			opaqueStores[kind] = store
 		}
-		extraResourceRoutes(api, basePrefix, types.ResourceRouteContext{
+		cfg.ExtraResourceRoutes(api, basePrefix, types.ResourceRouteContext{
 			Stores:            opaqueStores,
 			Resolver:          resolver,
 			RegistryValidator: registryValidator,
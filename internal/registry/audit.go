@@ -0,0 +1,59 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+// withAuditedAuthorizers wraps the per-kind Authorizers so every request
+// they deny is recorded in the audit log. Apply it after every other
+// Authorizer wrapper, so denials from the namespace gate and RBAC alike are
+// recorded.
+func withAuditedAuthorizers(options types.AppOptions, auditor types.AuditRecorder) types.AppOptions {
+	if len(options.Authorizers) == 0 {
+		return options
+	}
+	authorizers := maps.Clone(options.Authorizers)
+	for kind, next := range authorizers {
+		if next == nil {
+			continue
+		}
+		authorizers[kind] = func(ctx context.Context, in types.AuthorizeInput) error {
+			err := next(ctx, in)
+			if isDenial(err) {
+				recordDenial(ctx, auditor, in, err)
+			}
+			return err
+		}
+	}
+	options.Authorizers = authorizers
+	return options
+}
+
+// recordDenial records err, an authorization denial of in, in the audit log.
+func recordDenial(ctx context.Context, auditor types.AuditRecorder, in types.AuthorizeInput, err error) {
+	auditor.Record(ctx, types.AuditEvent{
+		Verb:      types.AuditVerbDeny,
+		Kind:      in.Kind,
+		Namespace: in.Namespace,
+		Name:      in.Name,
+		Tag:       in.Tag,
+		Reason:    in.Verb + ": " + err.Error(),
+	})
+}
+
+// isDenial reports whether err is an authorizer's 401 or 403, as opposed to
+// a failure to decide.
+func isDenial(err error) bool {
+	var status huma.StatusError
+	if !errors.As(err, &status) {
+		return false
+	}
+	return status.GetStatus() == http.StatusForbidden || status.GetStatus() == http.StatusUnauthorized
+}
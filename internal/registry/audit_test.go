@@ -0,0 +1,54 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+	"github.com/agentregistry-dev/agentregistry/pkg/types/typestest"
+)
+
+func TestWithAuditedAuthorizersRecordsDenials(t *testing.T) {
+	auditor := &typestest.RecordingAuditor{}
+	options := withAuditedAuthorizers(types.AppOptions{
+		Authorizers: map[string]types.Authorizer{
+			v1alpha1.KindAgent: func(_ context.Context, in types.AuthorizeInput) error {
+				switch in.Name {
+				case "secret":
+					return huma.Error403Forbidden("no access to secret")
+				case "broken":
+					return errors.New("policy store unavailable")
+				}
+				return nil
+			},
+		},
+	}, auditor)
+
+	ctx := context.Background()
+	authorize := options.Authorizers[v1alpha1.KindAgent]
+	require.NoError(t, authorize(ctx, types.AuthorizeInput{Verb: "get", Kind: v1alpha1.KindAgent, Name: "public"}))
+	require.Error(t, authorize(ctx, types.AuthorizeInput{Verb: "get", Kind: v1alpha1.KindAgent, Name: "broken"}))
+	err := authorize(ctx, types.AuthorizeInput{Verb: "apply", Kind: v1alpha1.KindAgent, Namespace: "team-a", Name: "secret", Tag: "1.0.0"})
+	require.Error(t, err)
+
+	records := auditor.Records()
+	require.Len(t, records, 1, "only denials are recorded, not allowed calls or authorizer failures")
+	assert.Equal(t, types.AuditEvent{
+		Verb:      types.AuditVerbDeny,
+		Kind:      v1alpha1.KindAgent,
+		Namespace: "team-a",
+		Name:      "secret",
+		Tag:       "1.0.0",
+		Reason:    "apply: " + err.Error(),
+	}, records[0])
+}
+
+func TestWithAuditedAuthorizersLeavesEmptyHooksUntouched(t *testing.T) {
+	require.Nil(t, withAuditedAuthorizers(types.AppOptions{}, &typestest.RecordingAuditor{}).Authorizers)
+}
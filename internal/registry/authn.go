@@ -0,0 +1,75 @@
+package registry
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/modelcontextprotocol/go-sdk/oauthex"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+// newJWTAuthnProvider builds the built-in JWT authn provider from the
+// AGENT_REGISTRY_AUTH_JWT_* settings.
+func newJWTAuthnProvider(cfg *config.Config) (*auth.JWTAuthnProvider, error) {
+	jwtCfg := auth.JWTConfig{
+		Issuer:      cfg.AuthJWTIssuer,
+		JWKSURL:     cfg.AuthJWTJWKSURL,
+		Audiences:   cfg.AuthJWTAudiences,
+		GroupsClaim: cfg.AuthJWTGroupsClaim,
+	}
+	if cfg.AuthJWTStaticKeysFile != "" {
+		data, err := os.ReadFile(cfg.AuthJWTStaticKeysFile)
+		if err != nil {
+			return nil, fmt.Errorf("read jwt static keys: %w", err)
+		}
+		if jwtCfg.StaticKeys, err = auth.ParseStaticKeys(data); err != nil {
+			return nil, fmt.Errorf("parse jwt static keys %s: %w", cfg.AuthJWTStaticKeysFile, err)
+		}
+	}
+	if len(cfg.AuthJWTGroupPermissions) > 0 {
+		jwtCfg.GroupPermissions = make(map[string][]auth.Permission, len(cfg.AuthJWTGroupPermissions))
+		for group, raw := range cfg.AuthJWTGroupPermissions {
+			perms, err := auth.ParsePermissions(raw)
+			if err != nil {
+				return nil, fmt.Errorf("jwt group permissions for %q: %w", group, err)
+			}
+			jwtCfg.GroupPermissions[group] = perms
+		}
+	}
+	return auth.NewJWTAuthnProvider(jwtCfg)
+}
+
+// mcpAuthn resolves the MCP bridge's authn provider and OAuth discovery
+// metadata. AppOptions win; otherwise, when the built-in JWT provider is in
+// use and AuthJWTMCPResource is set, the bridge binds tokens to that resource
+// and advertises the issuer. Anything still unset falls back to the server's
+// authn provider with discovery disabled.
+func mcpAuthn(
+	cfg *config.Config,
+	options types.AppOptions,
+	authnProvider auth.AuthnProvider,
+	jwtAuthn *auth.JWTAuthnProvider,
+) (auth.AuthnProvider, *oauthex.ProtectedResourceMetadata, string) {
+	provider := options.MCPAuthnProvider
+	metadata := options.MCPProtectedResourceMetadata
+	metadataURL := options.MCPResourceMetadataURL
+	if jwtAuthn != nil && cfg.AuthJWTMCPResource != "" {
+		if provider == nil {
+			provider = jwtAuthn.ForAudience(cfg.AuthJWTMCPResource)
+		}
+		if metadata == nil {
+			metadata = jwtAuthn.ProtectedResourceMetadata(cfg.AuthJWTMCPResource)
+			if metadataURL == "" {
+				// config.Validate has already checked the resource URL.
+				metadataURL, _ = auth.ProtectedResourceMetadataURL(cfg.AuthJWTMCPResource)
+			}
+		}
+	}
+	if provider == nil {
+		provider = authnProvider
+	}
+	return provider, metadata, metadataURL
+}
@@ -0,0 +1,94 @@
+package registry
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/modelcontextprotocol/go-sdk/oauthex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+func TestNewJWTAuthnProviderFromConfig(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	keysFile := filepath.Join(t.TempDir(), "keys.pem")
+	require.NoError(t, os.WriteFile(keysFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+
+	provider, err := newJWTAuthnProvider(&config.Config{
+		AuthJWTIssuer:           "https://issuer.example.com",
+		AuthJWTStaticKeysFile:   keysFile,
+		AuthJWTGroupsClaim:      "roles",
+		AuthJWTGroupPermissions: map[string]string{"devs": "read publish:team-a/*"},
+	})
+	require.NoError(t, err)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{
+		"iss": "https://issuer.example.com", "sub": "dev-1", "roles": []string{"devs"},
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString(priv)
+	require.NoError(t, err)
+	session, err := provider.Authenticate(context.Background(), func(name string) string {
+		if name == "Authorization" {
+			return "Bearer " + token
+		}
+		return ""
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []auth.Permission{
+		{Action: auth.PermissionActionRead, ResourcePattern: "*"},
+		{Action: auth.PermissionActionPublish, ResourcePattern: "team-a/*"},
+	}, session.Principal().User.Permissions)
+
+	_, err = newJWTAuthnProvider(&config.Config{AuthJWTStaticKeysFile: filepath.Join(t.TempDir(), "missing.pem")})
+	require.Error(t, err)
+}
+
+func TestMCPAuthn(t *testing.T) {
+	jwtAuthn, err := auth.NewJWTAuthnProvider(auth.JWTConfig{Issuer: "https://issuer.example.com"})
+	require.NoError(t, err)
+	cfg := &config.Config{AuthJWTIssuer: "https://issuer.example.com", AuthJWTMCPResource: "https://registry.example.com/mcp"}
+
+	t.Run("jwt resource binds audience and serves metadata", func(t *testing.T) {
+		provider, md, mdURL := mcpAuthn(cfg, types.AppOptions{}, jwtAuthn, jwtAuthn)
+		require.NotNil(t, provider)
+		assert.NotSame(t, jwtAuthn, provider, "bridge must use an audience-bound provider")
+		require.NotNil(t, md)
+		assert.Equal(t, "https://registry.example.com/mcp", md.Resource)
+		assert.Equal(t, []string{"https://issuer.example.com"}, md.AuthorizationServers)
+		assert.Equal(t, "https://registry.example.com/.well-known/oauth-protected-resource/mcp", mdURL)
+	})
+
+	t.Run("app options win", func(t *testing.T) {
+		override := &oauthex.ProtectedResourceMetadata{Resource: "https://custom.example"}
+		provider, md, mdURL := mcpAuthn(cfg, types.AppOptions{
+			MCPAuthnProvider:             jwtAuthn,
+			MCPProtectedResourceMetadata: override,
+			MCPResourceMetadataURL:       "https://custom.example/.well-known/oauth-protected-resource",
+		}, jwtAuthn, jwtAuthn)
+		assert.Same(t, jwtAuthn, provider)
+		assert.Same(t, override, md)
+		assert.Equal(t, "https://custom.example/.well-known/oauth-protected-resource", mdURL)
+	})
+
+	t.Run("no mcp resource falls back to server provider", func(t *testing.T) {
+		provider, md, mdURL := mcpAuthn(&config.Config{AuthJWTIssuer: "https://issuer.example.com"}, types.AppOptions{}, jwtAuthn, jwtAuthn)
+		assert.Same(t, jwtAuthn, provider)
+		assert.Nil(t, md)
+		assert.Empty(t, mdURL)
+	})
+}
@@ -1,14 +1,21 @@
 package config
 
 import (
+	"fmt"
 	"log/slog"
 	"os"
 	"strconv"
 	"time"
 
 	env "github.com/caarlos0/env/v11"
+	"sigs.k8s.io/yaml"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 )
 
+// StoragePostgres is the default Storage backend.
+const StoragePostgres = "postgres"
+
 // Config holds the application configuration
 // See .env.example for more documentation
 type Config struct {
@@ -18,6 +25,12 @@ type Config struct {
 	Version       string `env:"VERSION" envDefault:"dev"`
 	LogLevel      string `env:"LOG_LEVEL" envDefault:"info"`
 
+	// Storage selects the storage backend: "postgres" connects to
+	// DatabaseURL; "sqlite:///path/to/registry.db" keeps everything in an
+	// embedded SQLite file, for single-binary local and CI use.
+	// AppOptions.Storage (the server's --storage flag) wins when set.
+	Storage string `env:"STORAGE" envDefault:"postgres"`
+
 	// MCP Registry compatibility (read-only)
 	//
 	// MCPRegistryCompatEnabled toggles the read-only MCP Registry v0.1
@@ -63,6 +76,10 @@ type Config struct {
 	// ControllerRetentionPruneBatchLimit caps rows removed per retention pass so
 	// pruning cannot monopolize the database during startup or repair loops.
 	ControllerRetentionPruneBatchLimit int `env:"CONTROLLER_RETENTION_PRUNE_BATCH_LIMIT" envDefault:"500"`
+	// AuditRetention is how long audit log entries are kept. The controller
+	// retention pass prunes older entries, ControllerRetentionPruneBatchLimit
+	// rows at a time. Set to 0 to keep them forever.
+	AuditRetention time.Duration `env:"AUDIT_RETENTION" envDefault:"2160h"`
 	// ControllerDiscoveryInterval is how often provider discovery snapshots are
 	// materialized into discovered Deployment rows. Provider-specific cache
 	// refreshes may have separate intervals.
@@ -74,6 +91,133 @@ type Config struct {
 	// ControllerDiscoveryDeleteAfterMisses is how many consecutive successful
 	// discovery polls may omit a discovered Deployment before it is deleted.
 	ControllerDiscoveryDeleteAfterMisses int `env:"CONTROLLER_DISCOVERY_DELETE_AFTER_MISSES" envDefault:"5"`
+	// ControllerLeaderElection has server replicas that share a database
+	// elect one of them, through a Postgres lease, to run the controllers.
+	// The others serve the API only and take over when the leader stops
+	// renewing the lease.
+	ControllerLeaderElection bool `env:"CONTROLLER_LEADER_ELECTION" envDefault:"true"`
+	// ControllerLeaseDuration is how long the controller lease outlives its
+	// last renewal. It bounds failover after a leader dies without
+	// releasing the lease.
+	ControllerLeaseDuration time.Duration `env:"CONTROLLER_LEASE_DURATION" envDefault:"15s"`
+	// ControllerLeaseRenewInterval is how often the leader renews, and
+	// followers try to take, the controller lease.
+	ControllerLeaseRenewInterval time.Duration `env:"CONTROLLER_LEASE_RENEW_INTERVAL" envDefault:"5s"`
+	// ControllerLeaderIdentity names this replica in the controller lease.
+	// Empty uses the hostname (the pod name on Kubernetes) plus a random
+	// suffix.
+	ControllerLeaderIdentity string `env:"CONTROLLER_LEADER_IDENTITY"`
+
+	// Upstream MCP registry mirror
+	//
+	// MCPSyncURL is the root of an upstream MCP registry (the official
+	// registry or another AgentRegistry) whose /v0.1/servers list is
+	// mirrored into MCPServer objects. Empty disables the mirror.
+	MCPSyncURL string `env:"MCP_SYNC_URL"`
+	// MCPSyncName identifies the mirror. It keys the sync checkpoint and
+	// labels every mirrored object, so changing it orphans earlier mirrors.
+	MCPSyncName string `env:"MCP_SYNC_NAME" envDefault:"upstream"`
+	// MCPSyncNamespace receives the mirrored MCPServer objects.
+	MCPSyncNamespace string `env:"MCP_SYNC_NAMESPACE" envDefault:"default"`
+	// MCPSyncInterval is how often the upstream is polled for changes.
+	MCPSyncInterval time.Duration `env:"MCP_SYNC_INTERVAL" envDefault:"1h"`
+	// MCPSyncInclude and MCPSyncExclude are path.Match globs over upstream
+	// server names (e.g. "io.github.acme/*"). Empty Include mirrors every
+	// server not excluded.
+	MCPSyncInclude []string `env:"MCP_SYNC_INCLUDE" envSeparator:","`
+	MCPSyncExclude []string `env:"MCP_SYNC_EXCLUDE" envSeparator:","`
+
+	// Tag immutability
+	//
+	// ImmutableTagsEnabled turns on the server-wide tag policy for tagged
+	// artifacts (Agent, MCPServer, Skill, ...): once written, a tag matching
+	// ImmutableTags and not MutableTags can only be overwritten by a
+	// registry admin, and every such overwrite is audited. A Namespace's
+	// spec.tagPolicy replaces this policy for its namespace.
+	ImmutableTagsEnabled bool `env:"IMMUTABLE_TAGS_ENABLED" envDefault:"true"`
+	// ImmutableTags lists the protected tag patterns: "semver" for
+	// MAJOR.MINOR.PATCH tags or path.Match globs (e.g. "release-*").
+	ImmutableTags []string `env:"IMMUTABLE_TAGS" envSeparator:"," envDefault:"semver"`
+	// MutableTags lists patterns exempt from ImmutableTags.
+	MutableTags []string `env:"MUTABLE_TAGS" envSeparator:","`
+	// ImmutableTagKinds limits the policy to these kinds. Empty means every
+	// tagged kind.
+	ImmutableTagKinds []string `env:"IMMUTABLE_TAG_KINDS" envSeparator:","`
+
+	// Tag retention
+	//
+	// TagRetentionKeepLast keeps the N highest semver tags of every tagged
+	// artifact and prunes older semver tags. TagRetentionMaxAge (e.g. "90d")
+	// instead prunes semver tags, and tags matching TagRetentionPrune, not
+	// written for that long, still sparing the newest KeepLast. Tags live
+	// objects reference are never pruned, nor "latest", other floating tags
+	// such as "stable" and tags matching TagRetentionKeep. Both zero disables the
+	// server-wide policy; a Namespace's spec.tagRetention replaces it for
+	// its namespace.
+	TagRetentionKeepLast int    `env:"TAG_RETENTION_KEEP_LAST"`
+	TagRetentionMaxAge   string `env:"TAG_RETENTION_MAX_AGE"`
+	// TagRetentionKeep lists tag patterns never pruned: "semver" or
+	// path.Match globs (e.g. "stable", "release-*").
+	TagRetentionKeep []string `env:"TAG_RETENTION_KEEP" envSeparator:","`
+	// TagRetentionPrune lists the non-semver tag patterns TagRetentionMaxAge
+	// also prunes (e.g. "dev-*").
+	TagRetentionPrune []string `env:"TAG_RETENTION_PRUNE" envSeparator:","`
+	// TagRetentionKinds limits the policy to these kinds. Empty means every
+	// tagged kind.
+	TagRetentionKinds []string `env:"TAG_RETENTION_KINDS" envSeparator:","`
+	// TagRetentionInterval is how often the controller leader prunes.
+	TagRetentionInterval time.Duration `env:"TAG_RETENTION_INTERVAL" envDefault:"1h"`
+
+	// Artifact signatures
+	//
+	// SignaturePolicyFile is a YAML v1alpha1.SignaturePolicy: the trusted
+	// public keys and whether tagged artifacts must carry a signature from
+	// one of them. When the policy is required, applies of unsigned
+	// content are refused and the Deployment controller refuses unsigned
+	// targets. A Namespace's spec.signaturePolicy replaces it for its
+	// namespace. Empty requires no signatures.
+	SignaturePolicyFile string `env:"SIGNATURE_POLICY_FILE"`
+
+	// GitAllowedHosts restricts which git hosts the Skill and Plugin
+	// controllers will resolve and clone sources from (comma-separated, e.g.
+	// "github.com,gitlab.internal,*.corp.example.com"; "*" allows any
+	// host). Entries allow https and ssh; plaintext remotes need an entry
+	// naming the scheme, e.g. "http://gitea.lan". Defaults to github.com.
+	GitAllowedHosts []string `env:"GIT_ALLOWED_HOSTS" envSeparator:"," envDefault:"github.com"`
+
+	// Built-in OIDC/JWT authentication
+	//
+	// Setting any of AuthJWTIssuer, AuthJWTJWKSURL or AuthJWTStaticKeysFile
+	// enables bearer JWT authentication on the HTTP API (and the MCP bridge)
+	// unless AppOptions.AuthnProvider supplies a provider programmatically.
+	// AuthJWTIssuer is the required "iss" claim; when no JWKS URL or static
+	// keys are given, the key set is found through the issuer's OpenID
+	// discovery document.
+	AuthJWTIssuer string `env:"AUTH_JWT_ISSUER"`
+	// AuthJWTJWKSURL points directly at the issuer's JWKS, skipping discovery.
+	AuthJWTJWKSURL string `env:"AUTH_JWT_JWKS_URL"`
+	// AuthJWTStaticKeysFile is a JWKS document or PEM public key file used to
+	// verify tokens offline, without contacting the issuer.
+	AuthJWTStaticKeysFile string `env:"AUTH_JWT_STATIC_KEYS_FILE"`
+	// AuthJWTAudiences, when set, requires the "aud" claim to carry one of
+	// these values (comma-separated).
+	AuthJWTAudiences []string `env:"AUTH_JWT_AUDIENCES" envSeparator:","`
+	// AuthJWTGroupsClaim names the token claim holding group memberships.
+	AuthJWTGroupsClaim string `env:"AUTH_JWT_GROUPS_CLAIM" envDefault:"groups"`
+	// AuthJWTGroupPermissions grants permissions per group, as
+	// "group=perm perm,group2=perm" where each perm is "<action>" or
+	// "<action>:<resource-glob>" (e.g. "admins=*,devs=read publish:team-a/*").
+	AuthJWTGroupPermissions map[string]string `env:"AUTH_JWT_GROUP_PERMISSIONS" envSeparator:"," envKeyValSeparator:"="`
+	// AuthJWTMCPResource is the canonical external URL of the MCP bridge.
+	// When set, bridge tokens must name it as their audience and the bridge
+	// serves RFC 9728 protected-resource metadata pointing at the issuer.
+	AuthJWTMCPResource string `env:"AUTH_JWT_MCP_RESOURCE"`
+
+	// AuthRBACPolicyFile is a YAML role/role-binding policy (see
+	// auth.RBACPolicy). When set, and AppOptions.AuthzProvider is nil, the
+	// built-in RBAC provider gates every v1alpha1 kind and scopes list
+	// results. The file is reloaded when it changes.
+	AuthRBACPolicyFile string `env:"AUTH_RBAC_POLICY_FILE"`
 
 	// SkipMigrations gates the server's Postgres migrator at startup.
 	// Set true when migrations are applied out-of-band (e.g. by
@@ -86,6 +230,58 @@ type Config struct {
 	SkipMigrations bool `env:"-"`
 }
 
+// JWTAuthEnabled reports whether the built-in JWT authn provider is
+// configured.
+func (c *Config) JWTAuthEnabled() bool {
+	return c.AuthJWTIssuer != "" || c.AuthJWTJWKSURL != "" || c.AuthJWTStaticKeysFile != ""
+}
+
+// TagPolicy returns the server-wide tag immutability policy. A disabled
+// policy protects no tags.
+func (c *Config) TagPolicy() v1alpha1.TagPolicy {
+	if !c.ImmutableTagsEnabled {
+		return v1alpha1.TagPolicy{}
+	}
+	return v1alpha1.TagPolicy{
+		Immutable: c.ImmutableTags,
+		Mutable:   c.MutableTags,
+		Kinds:     c.ImmutableTagKinds,
+	}
+}
+
+// TagRetentionPolicy returns the server-wide tag retention policy: a single
+// rule built from the TAG_RETENTION_* settings, or no rules when neither a
+// count nor an age is set.
+func (c *Config) TagRetentionPolicy() v1alpha1.TagRetentionPolicy {
+	if c.TagRetentionKeepLast == 0 && c.TagRetentionMaxAge == "" {
+		return v1alpha1.TagRetentionPolicy{}
+	}
+	return v1alpha1.TagRetentionPolicy{Rules: []v1alpha1.TagRetentionRule{{
+		Kinds:    c.TagRetentionKinds,
+		KeepLast: c.TagRetentionKeepLast,
+		MaxAge:   c.TagRetentionMaxAge,
+		Keep:     c.TagRetentionKeep,
+		Prune:    c.TagRetentionPrune,
+	}}}
+}
+
+// SignaturePolicy loads the server-wide artifact signature policy from
+// SignaturePolicyFile. No file means a policy that requires nothing.
+func (c *Config) SignaturePolicy() (v1alpha1.SignaturePolicy, error) {
+	var policy v1alpha1.SignaturePolicy
+	if c.SignaturePolicyFile == "" {
+		return policy, nil
+	}
+	data, err := os.ReadFile(c.SignaturePolicyFile)
+	if err != nil {
+		return policy, fmt.Errorf("read signature policy: %w", err)
+	}
+	if err := yaml.UnmarshalStrict(data, &policy); err != nil {
+		return policy, fmt.Errorf("parse signature policy %s: %w", c.SignaturePolicyFile, err)
+	}
+	return policy, nil
+}
+
 // NewConfig creates a new configuration with default values.
 //
 // Server-only entry point: NewConfig is called from registry.App() at
@@ -2,8 +2,12 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
 )
 
 func TestNewConfig_ControllerEnv(t *testing.T) {
@@ -13,6 +17,7 @@ func TestNewConfig_ControllerEnv(t *testing.T) {
 	t.Setenv("AGENT_REGISTRY_CONTROLLER_DISCOVERY_INTERVAL", "15s")
 	t.Setenv("AGENT_REGISTRY_CONTROLLER_DISCOVERY_STALE_AFTER_MISSES", "2")
 	t.Setenv("AGENT_REGISTRY_CONTROLLER_DISCOVERY_DELETE_AFTER_MISSES", "4")
+	t.Setenv("AGENT_REGISTRY_AUDIT_RETENTION", "720h")
 
 	cfg := NewConfig()
 
@@ -34,6 +39,66 @@ func TestNewConfig_ControllerEnv(t *testing.T) {
 	if cfg.ControllerDiscoveryDeleteAfterMisses != 4 {
 		t.Fatalf("discovery delete misses = %d, want 4", cfg.ControllerDiscoveryDeleteAfterMisses)
 	}
+	if cfg.AuditRetention != 720*time.Hour {
+		t.Fatalf("audit retention = %s, want 720h", cfg.AuditRetention)
+	}
+}
+
+func TestNewConfig_GitAllowedHostsDefaultsToGitHub(t *testing.T) {
+	cfg := NewConfig()
+
+	if !slices.Equal(cfg.GitAllowedHosts, []string{"github.com"}) {
+		t.Fatalf("git allowed hosts = %v, want [github.com]", cfg.GitAllowedHosts)
+	}
+}
+
+func TestNewConfig_GitAllowedHostsEnv(t *testing.T) {
+	t.Setenv("AGENT_REGISTRY_GIT_ALLOWED_HOSTS", "github.com,gitlab.internal,*.corp.example.com")
+
+	cfg := NewConfig()
+
+	want := []string{"github.com", "gitlab.internal", "*.corp.example.com"}
+	if !slices.Equal(cfg.GitAllowedHosts, want) {
+		t.Fatalf("git allowed hosts = %v, want %v", cfg.GitAllowedHosts, want)
+	}
+}
+
+func TestNewConfig_AuthJWTEnv(t *testing.T) {
+	t.Setenv("AGENT_REGISTRY_AUTH_JWT_ISSUER", "https://issuer.example.com")
+	t.Setenv("AGENT_REGISTRY_AUTH_JWT_AUDIENCES", "agentregistry,api")
+	t.Setenv("AGENT_REGISTRY_AUTH_JWT_GROUP_PERMISSIONS", "admins=*,devs=read publish:team-a/*")
+
+	cfg := NewConfig()
+
+	if !cfg.JWTAuthEnabled() {
+		t.Fatalf("JWTAuthEnabled = false with an issuer set")
+	}
+	if want := []string{"agentregistry", "api"}; !slices.Equal(cfg.AuthJWTAudiences, want) {
+		t.Fatalf("audiences = %v, want %v", cfg.AuthJWTAudiences, want)
+	}
+	if cfg.AuthJWTGroupsClaim != "groups" {
+		t.Fatalf("groups claim = %q, want default groups", cfg.AuthJWTGroupsClaim)
+	}
+	if got := cfg.AuthJWTGroupPermissions["devs"]; got != "read publish:team-a/*" {
+		t.Fatalf("devs permissions = %q", got)
+	}
+	if err := Validate(cfg); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	cfg.AuthJWTGroupPermissions["ops"] = "launch"
+	if err := Validate(cfg); err == nil {
+		t.Fatalf("Validate accepted an unknown permission action")
+	}
+}
+
+func TestValidate_AuthJWTMCPResourceRequiresJWT(t *testing.T) {
+	if err := Validate(&Config{AuthJWTMCPResource: "https://mcp.example.com/mcp"}); err == nil {
+		t.Fatalf("Validate accepted an MCP resource without JWT auth")
+	}
+	if err := Validate(&Config{AuthJWTIssuer: "https://issuer.example.com", AuthJWTMCPResource: "mcp"}); err == nil {
+		t.Fatalf("Validate accepted a relative MCP resource")
+	}
 }
 
 func TestNewConfig_SkipMigrationsEnv(t *testing.T) {
@@ -62,3 +127,163 @@ func TestNewConfig_SkipMigrationsEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestNewConfig_ControllerLeaseEnv(t *testing.T) {
+	cfg := NewConfig()
+	if !cfg.ControllerLeaderElection {
+		t.Fatalf("leader election disabled by default")
+	}
+	if err := Validate(cfg); err != nil {
+		t.Fatalf("Validate defaults: %v", err)
+	}
+
+	t.Setenv("AGENT_REGISTRY_CONTROLLER_LEASE_DURATION", "4s")
+	t.Setenv("AGENT_REGISTRY_CONTROLLER_LEASE_RENEW_INTERVAL", "4s")
+	if err := Validate(NewConfig()); err == nil {
+		t.Fatalf("Validate accepted a renew interval as long as the lease")
+	}
+
+	t.Setenv("AGENT_REGISTRY_CONTROLLER_LEADER_ELECTION", "false")
+	if err := Validate(NewConfig()); err != nil {
+		t.Fatalf("Validate checked lease timing with leader election disabled: %v", err)
+	}
+}
+
+func TestNewConfig_TagPolicyEnv(t *testing.T) {
+	cfg := NewConfig()
+	if got := cfg.TagPolicy(); !slices.Equal(got.Immutable, []string{"semver"}) || len(got.Kinds) != 0 {
+		t.Fatalf("default tag policy = %+v, want semver tags immutable for every kind", got)
+	}
+
+	t.Setenv("AGENT_REGISTRY_IMMUTABLE_TAGS", "semver,release-*")
+	t.Setenv("AGENT_REGISTRY_MUTABLE_TAGS", "*-rc.*")
+	t.Setenv("AGENT_REGISTRY_IMMUTABLE_TAG_KINDS", "MCPServer,Agent")
+	policy := NewConfig().TagPolicy()
+	if !policy.IsImmutable("MCPServer", "release-1") || policy.IsImmutable("MCPServer", "1.0.0-rc.1") || policy.IsImmutable("Skill", "1.0.0") {
+		t.Fatalf("tag policy = %+v does not follow the env", policy)
+	}
+
+	t.Setenv("AGENT_REGISTRY_IMMUTABLE_TAGS_ENABLED", "false")
+	if NewConfig().TagPolicy().IsImmutable("MCPServer", "1.0.0") {
+		t.Fatalf("disabled tag policy still protects tags")
+	}
+}
+
+func TestValidate_TagPolicy(t *testing.T) {
+	t.Setenv("AGENT_REGISTRY_IMMUTABLE_TAG_KINDS", "Deployment")
+	if err := Validate(NewConfig()); err == nil {
+		t.Fatalf("Validate accepted an untagged kind in the tag policy")
+	}
+}
+
+func TestNewConfig_TagRetentionEnv(t *testing.T) {
+	if NewConfig().TagRetentionPolicy().Enabled() {
+		t.Fatalf("tag retention enabled by default")
+	}
+
+	t.Setenv("AGENT_REGISTRY_TAG_RETENTION_KEEP_LAST", "5")
+	t.Setenv("AGENT_REGISTRY_TAG_RETENTION_KEEP", "stable,release-*")
+	t.Setenv("AGENT_REGISTRY_TAG_RETENTION_KINDS", "MCPServer")
+	cfg := NewConfig()
+	rule, ok := cfg.TagRetentionPolicy().RuleFor("MCPServer")
+	if !ok || rule.KeepLast != 5 || !rule.Keeps("release-1") {
+		t.Fatalf("tag retention rule = %+v, %v does not follow the env", rule, ok)
+	}
+	if _, ok := cfg.TagRetentionPolicy().RuleFor("Skill"); ok {
+		t.Fatalf("tag retention applies outside TAG_RETENTION_KINDS")
+	}
+	if err := Validate(cfg); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	t.Setenv("AGENT_REGISTRY_TAG_RETENTION_MAX_AGE", "soon")
+	if err := Validate(NewConfig()); err == nil {
+		t.Fatalf("Validate accepted a malformed max age")
+	}
+}
+
+func TestConfig_SignaturePolicyFile(t *testing.T) {
+	policy, err := NewConfig().SignaturePolicy()
+	if err != nil || policy.Required {
+		t.Fatalf("default signature policy = %+v, %v; want one that requires nothing", policy, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "signature-policy.yaml")
+	if err := os.WriteFile(path, []byte(`required: true
+kinds: [Agent]
+trustedKeys:
+  - name: release
+    publicKey: |
+      -----BEGIN PUBLIC KEY-----
+      MCowBQYDK2VwAyEAGb9ECWmEzf6FQbrBZ9w7lshQhqowtrbLDFw4rXAxZuE=
+      -----END PUBLIC KEY-----
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AGENT_REGISTRY_SIGNATURE_POLICY_FILE", path)
+	cfg := NewConfig()
+	policy, err = cfg.SignaturePolicy()
+	if err != nil {
+		t.Fatalf("SignaturePolicy: %v", err)
+	}
+	if !policy.Requires("Agent") || policy.Requires("Skill") || len(policy.TrustedKeys) != 1 {
+		t.Fatalf("signature policy = %+v does not follow the file", policy)
+	}
+	if err := Validate(cfg); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("required: true\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := Validate(NewConfig()); err == nil {
+		t.Fatalf("Validate accepted a required policy without trusted keys")
+	}
+}
+
+func TestNewConfig_MCPSyncEnv(t *testing.T) {
+	t.Setenv("AGENT_REGISTRY_MCP_SYNC_URL", "https://registry.modelcontextprotocol.io")
+	t.Setenv("AGENT_REGISTRY_MCP_SYNC_NAMESPACE", "mirror")
+	t.Setenv("AGENT_REGISTRY_MCP_SYNC_INCLUDE", "io.github.acme/*,com.example/*")
+
+	cfg := NewConfig()
+	if cfg.MCPSyncName != "upstream" || cfg.MCPSyncInterval != time.Hour {
+		t.Fatalf("mcp sync defaults = %q, %s", cfg.MCPSyncName, cfg.MCPSyncInterval)
+	}
+	if want := []string{"io.github.acme/*", "com.example/*"}; !slices.Equal(cfg.MCPSyncInclude, want) {
+		t.Fatalf("include = %v, want %v", cfg.MCPSyncInclude, want)
+	}
+	if err := Validate(cfg); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	t.Setenv("AGENT_REGISTRY_MCP_SYNC_EXCLUDE", "io.github.[acme/*")
+	if err := Validate(NewConfig()); err == nil {
+		t.Fatalf("Validate accepted a malformed exclude pattern")
+	}
+	t.Setenv("AGENT_REGISTRY_MCP_SYNC_EXCLUDE", "")
+	t.Setenv("AGENT_REGISTRY_MCP_SYNC_URL", "registry.example.com")
+	if err := Validate(NewConfig()); err == nil {
+		t.Fatalf("Validate accepted a relative upstream URL")
+	}
+}
+
+func TestValidate_Storage(t *testing.T) {
+	for _, storage := range []string{"", "postgres"} {
+		if err := Validate(&Config{Storage: storage, TagRetentionInterval: time.Hour}); err != nil {
+			t.Errorf("Validate(Storage=%q) = %v; want nil", storage, err)
+		}
+	}
+	// SQLite storage is only valid in a binary built with cgo.
+	for _, storage := range []string{"sqlite:///var/lib/agentregistry/registry.db", "sqlite://registry.db"} {
+		err := Validate(&Config{Storage: storage, TagRetentionInterval: time.Hour})
+		if (err == nil) != v1alpha1store.SQLiteSupported {
+			t.Errorf("Validate(Storage=%q) = %v with SQLiteSupported=%t", storage, err, v1alpha1store.SQLiteSupported)
+		}
+	}
+	for _, storage := range []string{"mysql://localhost", "sqlite://", "SQLITE:///registry.db"} {
+		if err := Validate(&Config{Storage: storage, TagRetentionInterval: time.Hour}); err == nil {
+			t.Errorf("Validate(Storage=%q) accepted an invalid backend", storage)
+		}
+	}
+}
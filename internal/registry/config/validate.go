@@ -1,12 +1,24 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
 
 // Validate performs runtime validations on the loaded configuration.
 func Validate(cfg *Config) error {
 	if cfg == nil {
 		return fmt.Errorf("config is nil")
 	}
+	if err := validateStorage(cfg.Storage); err != nil {
+		return fmt.Errorf("storage: %w", err)
+	}
 	if cfg.ControllerEventRetention < 0 {
 		return fmt.Errorf("controller event retention must be non-negative")
 	}
@@ -16,5 +28,90 @@ func Validate(cfg *Config) error {
 	if cfg.ControllerRetentionPruneBatchLimit < 0 {
 		return fmt.Errorf("controller retention prune batch limit must be non-negative")
 	}
+	if cfg.AuditRetention < 0 {
+		return fmt.Errorf("audit retention must be non-negative")
+	}
+	if cfg.ControllerLeaderElection {
+		if cfg.ControllerLeaseRenewInterval <= 0 {
+			return fmt.Errorf("controller lease renew interval must be positive")
+		}
+		if cfg.ControllerLeaseDuration <= cfg.ControllerLeaseRenewInterval {
+			return fmt.Errorf("controller lease duration must be longer than the renew interval")
+		}
+	}
+	if cfg.MCPSyncURL != "" {
+		if err := validateMCPSync(cfg); err != nil {
+			return fmt.Errorf("mcp sync: %w", err)
+		}
+	}
+	if err := cfg.TagPolicy().Validate(); err != nil {
+		return fmt.Errorf("tag policy: %w", err)
+	}
+	if err := cfg.TagRetentionPolicy().Validate(); err != nil {
+		return fmt.Errorf("tag retention: %w", err)
+	}
+	if cfg.TagRetentionInterval <= 0 {
+		return fmt.Errorf("tag retention interval must be positive")
+	}
+	signaturePolicy, err := cfg.SignaturePolicy()
+	if err != nil {
+		return err
+	}
+	if err := signaturePolicy.Validate(); err != nil {
+		return fmt.Errorf("signature policy: %w", err)
+	}
+	for group, perms := range cfg.AuthJWTGroupPermissions {
+		if _, err := auth.ParsePermissions(perms); err != nil {
+			return fmt.Errorf("jwt group permissions for %q: %w", group, err)
+		}
+	}
+	if cfg.AuthJWTMCPResource != "" {
+		if !cfg.JWTAuthEnabled() {
+			return fmt.Errorf("jwt MCP resource requires a jwt issuer, JWKS URL or static keys file")
+		}
+		if _, err := auth.ProtectedResourceMetadataURL(cfg.AuthJWTMCPResource); err != nil {
+			return fmt.Errorf("jwt MCP resource: %w", err)
+		}
+	}
+	return nil
+}
+
+func validateStorage(storage string) error {
+	if storage == "" || storage == StoragePostgres {
+		return nil
+	}
+	_, ok, err := v1alpha1store.ParseSQLiteStorage(storage)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("unknown backend %q; want %q or \"sqlite:///path/to/registry.db\"", storage, StoragePostgres)
+	}
+	if !v1alpha1store.SQLiteSupported {
+		return fmt.Errorf("sqlite storage needs a server built with CGO_ENABLED=1")
+	}
+	return nil
+}
+
+func validateMCPSync(cfg *Config) error {
+	u, err := url.Parse(cfg.MCPSyncURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url must be an absolute http(s) URL, got %q", cfg.MCPSyncURL)
+	}
+	// The name is the value of the mirror label on every mirrored object.
+	if len(cfg.MCPSyncName) > 63 || !v1alpha1.DNSSubdomainRegex.MatchString(cfg.MCPSyncName) {
+		return fmt.Errorf("name must be a DNS label of at most 63 characters, got %q", cfg.MCPSyncName)
+	}
+	if !v1alpha1.DNSSubdomainRegex.MatchString(cfg.MCPSyncNamespace) {
+		return fmt.Errorf("invalid namespace %q", cfg.MCPSyncNamespace)
+	}
+	if cfg.MCPSyncInterval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+	for _, pattern := range slices.Concat(cfg.MCPSyncInclude, cfg.MCPSyncExclude) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("filter %q: %w", pattern, err)
+		}
+	}
 	return nil
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
 	"github.com/agentregistry-dev/agentregistry/pkg/types"
 )
@@ -47,6 +48,10 @@ type DeploymentController struct {
 	// DependencyKinds extends the built-in resource kinds whose durable events
 	// requeue Deployments. Fingerprint gating prevents unchanged adapter work.
 	DependencyKinds map[string]bool
+	// VerifySignature refuses targets the artifact signature policy finds
+	// unsigned: the Deployment is marked Ready=False with reason
+	// SignatureRequired instead of applied. Nil requires no signatures.
+	VerifySignature resource.SignatureVerifier
 
 	mu         sync.RWMutex
 	checkpoint int64
@@ -0,0 +1,202 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// ControllerLeaseName is the lease that elects the replica running the
+// controller set.
+const ControllerLeaseName = "controllers"
+
+// Roles reported by LeaderElector.Status.
+const (
+	RoleLeader   = "leader"
+	RoleFollower = "follower"
+)
+
+const (
+	defaultLeaseDuration      = 15 * time.Second
+	defaultLeaseRenewInterval = 5 * time.Second
+	// leaseReleaseTimeout bounds the best-effort release on shutdown; an
+	// unreleased lease simply expires.
+	leaseReleaseTimeout = 5 * time.Second
+)
+
+// leaseStore is the subset of *v1alpha1store.LeaseStore the elector uses.
+type leaseStore interface {
+	TryAcquire(ctx context.Context, name, holder string, ttl time.Duration) (v1alpha1store.Lease, bool, error)
+	Release(ctx context.Context, name, holder string) error
+}
+
+// LeaderStatus is a replica's view of the controller election.
+type LeaderStatus struct {
+	// Role is RoleLeader or RoleFollower.
+	Role string
+	// Identity names this replica.
+	Identity string
+	// Leader names the replica last seen holding the lease; empty when no
+	// replica holds it.
+	Leader string
+	// Since is when this replica took its current role.
+	Since time.Time
+}
+
+// LeaderElector campaigns for a Postgres lease so that, across server
+// replicas sharing a database, at most one runs the controllers at a time.
+//
+// The leader renews the lease every RenewInterval. It stops leading as soon
+// as another replica holds the lease, or when renewals keep failing and the
+// lease is within two renew intervals of expiring, so its term ends before
+// a follower can take over. Followers retry every RenewInterval and
+// take over once the lease is released or expires.
+type LeaderElector struct {
+	Leases   leaseStore
+	Name     string
+	Identity string
+	// LeaseDuration is how long a lease lasts without renewal, and so the
+	// longest failover takes after a leader dies without releasing it.
+	LeaseDuration time.Duration
+	// RenewInterval must be well below LeaseDuration so that a couple of
+	// failed renewals do not end the leader's term.
+	RenewInterval time.Duration
+
+	mu     sync.Mutex
+	status LeaderStatus
+}
+
+// Status reports this replica's current role.
+func (e *LeaderElector) Status() LeaderStatus {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	status := e.status
+	if status.Role == "" {
+		status.Role = RoleFollower
+	}
+	status.Identity = e.Identity
+	return status
+}
+
+// Run campaigns until ctx is cancelled. Each time this replica wins the
+// lease, lead runs with a context that is cancelled when the term ends;
+// Run waits for lead to return before campaigning again. A lead that
+// returns on its own gives the lease up. On shutdown the lease is
+// released so a follower takes over without waiting for expiry.
+func (e *LeaderElector) Run(ctx context.Context, lead func(context.Context)) error {
+	if e == nil || e.Leases == nil {
+		return errors.New("leader election: lease store is required")
+	}
+	if e.Name == "" || e.Identity == "" {
+		return errors.New("leader election: lease name and identity are required")
+	}
+	leaseDuration, renewInterval := e.LeaseDuration, e.RenewInterval
+	if leaseDuration <= 0 {
+		leaseDuration = defaultLeaseDuration
+	}
+	if renewInterval <= 0 {
+		renewInterval = defaultLeaseRenewInterval
+	}
+	if renewInterval >= leaseDuration {
+		return errors.New("leader election: renew interval must be shorter than the lease duration")
+	}
+	e.setStatus(RoleFollower, "")
+
+	for {
+		attempt := time.Now()
+		lease, held, err := e.Leases.TryAcquire(ctx, e.Name, e.Identity, leaseDuration)
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case err != nil:
+			logger.Error("controller lease acquire failed", "lease", e.Name, "error", err)
+		case !held:
+			e.setStatus(RoleFollower, lease.Holder)
+		default:
+			e.lead(ctx, lead, attempt.Add(leaseDuration), leaseDuration, renewInterval)
+			if ctx.Err() != nil {
+				e.release()
+				return ctx.Err()
+			}
+		}
+		if !waitForReconnect(ctx, renewInterval) {
+			return ctx.Err()
+		}
+	}
+}
+
+// lead runs one leadership term, renewing the lease until it is lost or
+// ctx is cancelled. expires is the local-clock bound on the lease just
+// acquired: it is measured from before the acquire was sent, so it never
+// outlasts the database's view.
+func (e *LeaderElector) lead(ctx context.Context, lead func(context.Context), expires time.Time, leaseDuration, renewInterval time.Duration) {
+	e.setStatus(RoleLeader, e.Identity)
+	logger.Info("controller lease acquired; starting controllers", "lease", e.Name, "identity", e.Identity)
+
+	termCtx, endTerm := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		lead(termCtx)
+	}()
+	defer func() {
+		endTerm()
+		<-done
+		e.setStatus(RoleFollower, "")
+	}()
+
+	ticker := time.NewTicker(renewInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+			logger.Warn("controllers stopped while leading; giving up the controller lease", "lease", e.Name)
+			e.release()
+			return
+		case <-ticker.C:
+		}
+		attempt := time.Now()
+		renewCtx, cancel := context.WithDeadline(ctx, expires)
+		lease, held, err := e.Leases.TryAcquire(renewCtx, e.Name, e.Identity, leaseDuration)
+		cancel()
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err == nil && held:
+			expires = attempt.Add(leaseDuration)
+		case err == nil:
+			logger.Warn("controller lease lost; stopping controllers", "lease", e.Name, "holder", lease.Holder)
+			return
+		case time.Until(expires) < 2*renewInterval:
+			// Stop with a renew interval to spare so the controllers have
+			// wound down before a follower can take the lease.
+			logger.Error("controller lease renewal failed and the lease is about to expire; stopping controllers", "lease", e.Name, "error", err)
+			return
+		default:
+			logger.Warn("controller lease renewal failed; retrying", "lease", e.Name, "error", err)
+		}
+	}
+}
+
+func (e *LeaderElector) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), leaseReleaseTimeout)
+	defer cancel()
+	if err := e.Leases.Release(ctx, e.Name, e.Identity); err != nil {
+		logger.Warn("controller lease release failed; it will expire", "lease", e.Name, "error", err)
+	}
+}
+
+func (e *LeaderElector) setStatus(role, leader string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.status.Role != role {
+		e.status.Since = time.Now()
+	}
+	e.status.Role = role
+	e.status.Leader = leader
+}
@@ -0,0 +1,92 @@
+//go:build integration
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/plugins/source"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+type testReplica struct {
+	elector *LeaderElector
+	adapter *recordingDeploymentAdapter
+	stop    context.CancelFunc
+	stopped chan struct{}
+}
+
+// startTestReplica runs the production controller set behind a leader
+// elector, as one server replica would, with its own recording adapter.
+func startTestReplica(t *testing.T, identity string, pool *pgxpool.Pool, stores map[string]*v1alpha1store.Store) *testReplica {
+	t.Helper()
+	r := &testReplica{
+		elector: &LeaderElector{
+			Leases:        v1alpha1store.NewLeaseStore(pool, pkgdb.MustNewSchema(pkgdb.OSSSchema)),
+			Name:          ControllerLeaseName,
+			Identity:      identity,
+			LeaseDuration: 2 * time.Second,
+			RenewInterval: 100 * time.Millisecond,
+		},
+		adapter: &recordingDeploymentAdapter{},
+		stopped: make(chan struct{}),
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	r.stop = stop
+	config := ControllerConfig{Plugins: PluginControllerDeps{Resolver: source.NewResolver(nil)}}
+	adapters := map[string]types.DeploymentAdapter{v1alpha1.TypeKubernetes: r.adapter}
+	go func() {
+		defer close(r.stopped)
+		_ = r.elector.Run(ctx, func(ctx context.Context) {
+			stopControllers, err := StartControllers(ctx, pool, stores, adapters, config)
+			if err != nil {
+				t.Errorf("%s: start controllers: %v", identity, err)
+				return
+			}
+			<-ctx.Done()
+			stopControllers()
+		})
+	}()
+	t.Cleanup(func() {
+		stop()
+		<-r.stopped
+	})
+	return r
+}
+
+func TestLeaderElectionOnlyLeaderAppliesDeployments(t *testing.T) {
+	pool := v1alpha1store.NewTestPool(t)
+	stores := v1alpha1store.NewStores(pool, v1alpha1store.TestSchemaRegistry())
+	seedMCPServer(t, stores, "weather")
+
+	a := startTestReplica(t, "replica-a", pool, stores)
+	require.Eventually(t, func() bool { return a.elector.Status().Role == RoleLeader }, 5*time.Second, 20*time.Millisecond)
+	b := startTestReplica(t, "replica-b", pool, stores)
+	require.Eventually(t, func() bool { return b.elector.Status().Leader == "replica-a" }, 5*time.Second, 20*time.Millisecond)
+
+	seedDeployment(t, stores, "api", v1alpha1.DesiredStateDeployed)
+	require.Eventually(t, func() bool { return a.adapter.applyCalls.Load() > 0 }, 10*time.Second, 20*time.Millisecond)
+	seedDeployment(t, stores, "worker", v1alpha1.DesiredStateDeployed)
+	require.Eventually(t, func() bool { return a.adapter.applyCalls.Load() > 1 }, 10*time.Second, 20*time.Millisecond)
+	require.Zero(t, b.adapter.applyCalls.Load(), "follower must not call DeploymentAdapter.Apply")
+	require.Equal(t, RoleFollower, b.elector.Status().Role)
+
+	// Stopping the leader releases the lease; the follower takes over and
+	// reconciles new work, and the old leader applies nothing more.
+	a.stop()
+	<-a.stopped
+	appliedByA := a.adapter.applyCalls.Load()
+	require.Eventually(t, func() bool { return b.elector.Status().Role == RoleLeader }, 5*time.Second, 20*time.Millisecond)
+
+	seedDeployment(t, stores, "batch", v1alpha1.DesiredStateDeployed)
+	require.Eventually(t, func() bool { return b.adapter.applyCalls.Load() > 0 }, 10*time.Second, 20*time.Millisecond)
+	require.Equal(t, appliedByA, a.adapter.applyCalls.Load())
+}
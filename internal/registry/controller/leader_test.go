@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// fakeLeases is an in-memory lease table. Expiry uses the local clock.
+type fakeLeases struct {
+	mu      sync.Mutex
+	holder  string
+	expires time.Time
+	fail    bool
+}
+
+func (f *fakeLeases) TryAcquire(_ context.Context, name, holder string, ttl time.Duration) (v1alpha1store.Lease, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fail {
+		return v1alpha1store.Lease{}, false, errors.New("database unavailable")
+	}
+	now := time.Now()
+	if f.holder != "" && f.holder != holder && now.Before(f.expires) {
+		return v1alpha1store.Lease{Name: name, Holder: f.holder, ExpiresAt: f.expires}, false, nil
+	}
+	f.holder, f.expires = holder, now.Add(ttl)
+	return v1alpha1store.Lease{Name: name, Holder: holder, ExpiresAt: f.expires}, true, nil
+}
+
+func (f *fakeLeases) Release(_ context.Context, _, holder string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.holder == holder {
+		f.holder = ""
+	}
+	return nil
+}
+
+func (f *fakeLeases) steal(holder string, ttl time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.holder, f.expires = holder, time.Now().Add(ttl)
+}
+
+func (f *fakeLeases) setFail(fail bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fail = fail
+}
+
+func (f *fakeLeases) currentHolder() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.holder
+}
+
+func (f *fakeLeases) expiry() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.expires
+}
+
+func newTestElector(leases leaseStore, identity string) *LeaderElector {
+	return &LeaderElector{
+		Leases:        leases,
+		Name:          ControllerLeaseName,
+		Identity:      identity,
+		LeaseDuration: 200 * time.Millisecond,
+		RenewInterval: 20 * time.Millisecond,
+	}
+}
+
+// runElector runs e in the background and returns a channel that receives
+// each term's context as it starts.
+func runElector(t *testing.T, ctx context.Context, e *LeaderElector) (<-chan context.Context, <-chan error) {
+	t.Helper()
+	terms := make(chan context.Context, 4)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- e.Run(ctx, func(term context.Context) {
+			terms <- term
+			<-term.Done()
+		})
+	}()
+	return terms, errs
+}
+
+func TestLeaderElectorOnlyOneReplicaLeads(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	leases := &fakeLeases{}
+	a, b := newTestElector(leases, "replica-a"), newTestElector(leases, "replica-b")
+
+	termsA, errsA := runElector(t, ctx, a)
+	<-termsA
+	termsB, _ := runElector(t, ctx, b)
+
+	require.Eventually(t, func() bool { return b.Status().Leader == "replica-a" }, time.Second, 5*time.Millisecond)
+	select {
+	case <-termsB:
+		t.Fatal("follower started leading while the lease was held")
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.Equal(t, RoleLeader, a.Status().Role)
+	require.Equal(t, RoleFollower, b.Status().Role)
+
+	cancel()
+	require.ErrorIs(t, <-errsA, context.Canceled)
+}
+
+func TestLeaderElectorFailsOverWhenLeaderStops(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	leases := &fakeLeases{}
+	ctxA, stopA := context.WithCancel(ctx)
+	a, b := newTestElector(leases, "replica-a"), newTestElector(leases, "replica-b")
+
+	termsA, errsA := runElector(t, ctxA, a)
+	termA := <-termsA
+	termsB, _ := runElector(t, ctx, b)
+
+	stopA()
+	require.ErrorIs(t, <-errsA, context.Canceled)
+	require.Error(t, termA.Err(), "leader's term must end before Run returns")
+
+	select {
+	case <-termsB:
+	case <-time.After(time.Second):
+		t.Fatal("follower did not take over after the leader stopped")
+	}
+	require.Equal(t, RoleLeader, b.Status().Role)
+	require.Equal(t, "replica-b", leases.currentHolder())
+}
+
+func TestLeaderElectorStopsLeadingWhenLeaseIsLost(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	leases := &fakeLeases{}
+	a := newTestElector(leases, "replica-a")
+
+	terms, _ := runElector(t, ctx, a)
+	term := <-terms
+
+	leases.steal("replica-b", time.Hour)
+	select {
+	case <-term.Done():
+	case <-time.After(time.Second):
+		t.Fatal("term did not end after the lease was taken")
+	}
+	require.Eventually(t, func() bool {
+		status := a.Status()
+		return status.Role == RoleFollower && status.Leader == "replica-b"
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestLeaderElectorStopsLeadingBeforeLeaseExpires(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	leases := &fakeLeases{}
+	a := newTestElector(leases, "replica-a")
+
+	terms, _ := runElector(t, ctx, a)
+	term := <-terms
+
+	leases.setFail(true)
+	select {
+	case <-term.Done():
+	case <-time.After(time.Second):
+		t.Fatal("term did not end while renewals were failing")
+	}
+	require.True(t, time.Now().Before(leases.expiry()), "term must end before the lease can expire")
+	require.Eventually(t, func() bool { return a.Status().Role == RoleFollower }, time.Second, 5*time.Millisecond)
+}
+
+func TestLeaderElectorReleasesLeaseWhenControllersStop(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	leases := &fakeLeases{}
+	a := newTestElector(leases, "replica-a")
+
+	started := make(chan struct{}, 4)
+	go func() {
+		_ = a.Run(ctx, func(context.Context) { started <- struct{}{} })
+	}()
+	<-started
+	// The failed term gives the lease up and campaigns again.
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("elector did not campaign again after its controllers stopped")
+	}
+}
+
+func TestLeaderElectorRejectsRenewIntervalNotShorterThanLease(t *testing.T) {
+	e := newTestElector(&fakeLeases{}, "replica-a")
+	e.RenewInterval = e.LeaseDuration
+	require.ErrorContains(t, e.Run(context.Background(), func(context.Context) {}), "renew interval")
+}
@@ -0,0 +1,413 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/mcpregistry"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+const (
+	defaultMCPSyncInterval = time.Hour
+	defaultMCPSyncPageSize = 100
+	// mcpSyncClockSkew widens each incremental pass's updated_since window
+	// so servers changed upstream while the previous pass ran are not
+	// missed when the two clocks disagree. Re-mirroring an unchanged
+	// server is a no-op upsert.
+	mcpSyncClockSkew = time.Minute
+)
+
+// MCPSyncConfig configures the mirror of one upstream MCP registry. An empty
+// URL disables it.
+type MCPSyncConfig struct {
+	// Name identifies the mirror: it keys the sync checkpoint and is the
+	// value of the mcpregistry.MirrorLabel on every mirrored object.
+	Name string
+	// URL is the upstream registry root serving /v0.1/servers.
+	URL string
+	// Namespace receives the mirrored MCPServer objects.
+	Namespace string
+	// Include and Exclude are path.Match globs over upstream server names
+	// ("io.github.acme/*"). A server is mirrored when it matches an Include
+	// pattern, or Include is empty, and matches no Exclude pattern.
+	Include  []string
+	Exclude  []string
+	Interval time.Duration
+	PageSize int
+}
+
+// Enabled reports whether an upstream is configured.
+func (c MCPSyncConfig) Enabled() bool {
+	return c.URL != ""
+}
+
+// Matches reports whether the upstream server serverName passes the
+// include/exclude filters. Malformed patterns never match.
+func (c MCPSyncConfig) Matches(serverName string) bool {
+	included := len(c.Include) == 0
+	for _, pattern := range c.Include {
+		if ok, _ := path.Match(pattern, serverName); ok {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return false
+	}
+	for _, pattern := range c.Exclude {
+		if ok, _ := path.Match(pattern, serverName); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// MCPSyncStores groups the store surfaces the MCP sync controller uses.
+// *v1alpha1store.Store and *v1alpha1store.MCPSyncStateStore satisfy them.
+// Mirrored servers are written and deleted through MCPSyncController.Apply
+// and Delete, not Servers.
+type MCPSyncStores struct {
+	Servers interface {
+		Get(ctx context.Context, namespace, name, tag string) (*v1alpha1.RawObject, error)
+	}
+	State interface {
+		Get(ctx context.Context, upstream string) (v1alpha1store.MCPSyncState, error)
+		Save(ctx context.Context, state v1alpha1store.MCPSyncState) error
+	}
+}
+
+// MCPSyncResult summarizes one sync pass.
+type MCPSyncResult struct {
+	Pages int
+	// Mirrored counts tag rows created or changed.
+	Mirrored int
+	// Deleted counts mirrored tag rows removed because the upstream
+	// marked the server deleted.
+	Deleted int
+	// Filtered counts servers the include/exclude filters skipped.
+	Filtered int
+	// Skipped counts servers that could not be mirrored: untranslatable or
+	// invalid servers, servers whose name and tag are taken by an object
+	// this mirror does not own, and servers the tag immutability or
+	// signature policy of the mirror namespace refuses. Deletes refused
+	// because other objects still reference the server count here too.
+	Skipped int
+}
+
+// MCPSyncController mirrors an upstream MCP registry's server list into
+// MCPServer objects. Each pass pages through /v0.1/servers from the last
+// checkpoint, so after the first full listing a pass only reads servers
+// changed upstream since the previous one. Every upstream version becomes a
+// tag; the version the upstream marks latest is also written to "latest".
+//
+// Mirrored objects carry mcpregistry.MirrorLabel. The controller never
+// writes over or deletes an object without the label naming this mirror, so
+// objects authored in this registry win over upstream servers of the same
+// name.
+//
+// Mirrored servers are written through the apply pipeline like any other
+// apply, so the mirror namespace's tag immutability, signature and approval
+// policies and the admission hook hold for them: an upstream that changes
+// the content of a published version cannot rewrite its protected tag.
+// Upstream deletions likewise go through the delete pipeline, which refuses
+// to remove a server other objects still reference. A mirror namespace not
+// yet declared is created on first use.
+type MCPSyncController struct {
+	Upstream *mcpregistry.Client
+	Stores   MCPSyncStores
+	Config   MCPSyncConfig
+	// Apply writes one mirrored server; see resource.ApplyObject.
+	Apply func(ctx context.Context, obj v1alpha1.Object) arv0.ApplyResult
+	// Delete removes one mirrored server; see resource.DeleteObject.
+	Delete func(ctx context.Context, obj v1alpha1.Object) arv0.ApplyResult
+	Now    func() time.Time
+}
+
+// NewMCPSyncController wires the MCP sync controller for config. Mirrored
+// servers go through resource.ApplyObject and resource.DeleteObject with
+// apply, labelled with types.AdmissionSourceMCPSync; apply.Stores defaults
+// to stores. It returns
+// nil when there is no database or no upstream is configured.
+func NewMCPSyncController(
+	db v1alpha1store.DB,
+	stores map[string]*v1alpha1store.Store,
+	config MCPSyncConfig,
+	apply resource.ApplyConfig,
+) (*MCPSyncController, error) {
+	if db == nil || !config.Enabled() {
+		return nil, nil
+	}
+	servers := stores[v1alpha1.KindMCPServer]
+	if servers == nil {
+		return nil, errors.New("mcp sync controller: MCPServer store is required")
+	}
+	if apply.Stores == nil {
+		apply.Stores = stores
+	}
+	apply.Source = types.AdmissionSourceMCPSync
+	return &MCPSyncController{
+		Upstream: &mcpregistry.Client{BaseURL: config.URL, HTTPClient: &http.Client{Timeout: time.Minute}},
+		Stores: MCPSyncStores{
+			Servers: servers,
+			State:   v1alpha1store.NewMCPSyncStateStore(db, pkgdb.MustNewSchema(pkgdb.OSSSchema)),
+		},
+		Config: config,
+		Apply: func(ctx context.Context, obj v1alpha1.Object) arv0.ApplyResult {
+			return resource.ApplyObject(ctx, apply, obj, false)
+		},
+		Delete: func(ctx context.Context, obj v1alpha1.Object) arv0.ApplyResult {
+			return resource.DeleteObject(ctx, apply, obj, false)
+		},
+	}, nil
+}
+
+// Run syncs every Config.Interval until ctx is cancelled. A failed pass
+// resumes from its last completed page on the next tick.
+func (c *MCPSyncController) Run(ctx context.Context) error {
+	if c == nil {
+		return errors.New("mcp sync controller: controller is required")
+	}
+	interval := c.Config.Interval
+	if interval <= 0 {
+		interval = defaultMCPSyncInterval
+	}
+	for {
+		result, err := c.Sync(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			logger.Error("mcp sync failed", "upstream", c.Config.Name, "error", err)
+		} else {
+			logger.Info("mcp sync completed", "upstream", c.Config.Name, "pages", result.Pages,
+				"mirrored", result.Mirrored, "deleted", result.Deleted, "filtered", result.Filtered, "skipped", result.Skipped)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Sync runs one pass to the last upstream page, checkpointing the cursor
+// after each page. Servers that cannot be mirrored are logged and skipped;
+// upstream and store errors stop the pass before its cursor advances.
+func (c *MCPSyncController) Sync(ctx context.Context) (MCPSyncResult, error) {
+	if c == nil || c.Upstream == nil || c.Apply == nil || c.Delete == nil || c.Stores.Servers == nil || c.Stores.State == nil {
+		return MCPSyncResult{}, errors.New("mcp sync controller: upstream client, apply, delete and stores are required")
+	}
+	state, err := c.Stores.State.Get(ctx, c.Config.Name)
+	if err != nil {
+		return MCPSyncResult{}, err
+	}
+	if state.Cursor == "" {
+		state.PassStartedAt = c.now()
+	}
+	pageSize := c.Config.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultMCPSyncPageSize
+	}
+
+	var result MCPSyncResult
+	for {
+		page, err := c.Upstream.ListServers(ctx, mcpregistry.ListServersOpts{
+			Cursor:         state.Cursor,
+			Limit:          pageSize,
+			UpdatedSince:   state.UpdatedSince,
+			IncludeDeleted: true,
+		})
+		if err != nil {
+			return result, err
+		}
+		result.Pages++
+		for _, entry := range page.Servers {
+			if err := c.syncServer(ctx, entry, &result); err != nil {
+				return result, err
+			}
+		}
+
+		state.Cursor = page.Metadata.NextCursor
+		if state.Cursor == "" {
+			state.UpdatedSince = state.PassStartedAt.Add(-mcpSyncClockSkew)
+			state.PassStartedAt = time.Time{}
+			state.LastSyncedAt = c.now()
+		}
+		if err := c.Stores.State.Save(ctx, state); err != nil {
+			return result, err
+		}
+		if state.Cursor == "" {
+			return result, nil
+		}
+	}
+}
+
+// syncServer mirrors, or for a deleted upstream server removes, one upstream
+// server version. It returns an error only for store failures, applies that
+// fail for reasons other than the namespace's tag or signature policy, and
+// deletes that fail for reasons other than live referrers.
+func (c *MCPSyncController) syncServer(ctx context.Context, entry mcpregistry.ServerResponse, result *MCPSyncResult) error {
+	detail := entry.Server
+	if !c.Config.Matches(detail.Name) {
+		result.Filtered++
+		return nil
+	}
+	var official mcpregistry.OfficialMeta
+	if entry.Meta != nil && entry.Meta.Official != nil {
+		official = *entry.Meta.Official
+	}
+
+	if official.Status == mcpregistry.StatusDeleted {
+		name, err := mcpregistry.MirrorName(detail.Name)
+		if err != nil {
+			return nil
+		}
+		for _, tag := range []string{detail.Version, "latest"} {
+			if err := c.deleteMirrored(ctx, name, tag, detail.Version, result); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	server, err := mcpregistry.ToMCPServer(detail, c.Config.Namespace)
+	if err == nil {
+		c.markMirrored(server, detail)
+		err = server.Validate()
+	}
+	if err != nil {
+		logger.Warn("mcp sync skipped server", "upstream", c.Config.Name, "server", detail.Name, "version", detail.Version, "error", err)
+		result.Skipped++
+		return nil
+	}
+	tags := []string{server.Metadata.Tag}
+	if official.IsLatest && server.Metadata.Tag != "latest" {
+		tags = append(tags, "latest")
+	}
+	for _, tag := range tags {
+		server.Metadata.Tag = tag
+		owned, err := c.ownedOrAbsent(ctx, server.Metadata.Name, tag)
+		if err != nil {
+			return err
+		}
+		if !owned {
+			logger.Warn("mcp sync skipped server: name and tag taken by an object this mirror does not own",
+				"upstream", c.Config.Name, "server", detail.Name, "namespace", c.Config.Namespace, "name", server.Metadata.Name, "tag", tag)
+			result.Skipped++
+			continue
+		}
+		applied := c.Apply(ctx, server)
+		if applied.Reason == arv0.ApplyReasonNamespaceNotFound {
+			if err := c.declareNamespace(ctx); err != nil {
+				return err
+			}
+			applied = c.Apply(ctx, server)
+		}
+		switch {
+		case applied.Status != arv0.ApplyStatusFailed:
+		case applied.Reason == arv0.ApplyReasonTagImmutable || applied.Reason == arv0.ApplyReasonSignatureRequired:
+			logger.Warn("mcp sync skipped server: refused by the namespace policy",
+				"upstream", c.Config.Name, "server", detail.Name, "namespace", c.Config.Namespace, "name", server.Metadata.Name, "tag", tag,
+				"reason", applied.Reason, "error", applied.Error)
+			result.Skipped++
+			continue
+		default:
+			return fmt.Errorf("mirror MCPServer %s/%s@%s: %s", c.Config.Namespace, server.Metadata.Name, tag, applied.Error)
+		}
+		if applied.Reason != "" {
+			logger.Info("mcp sync mirrored server held for review",
+				"upstream", c.Config.Name, "namespace", c.Config.Namespace, "name", server.Metadata.Name, "tag", tag, "reason", applied.Reason)
+		}
+		if applied.Status != arv0.ApplyStatusUnchanged {
+			result.Mirrored++
+		}
+	}
+	return nil
+}
+
+func (c *MCPSyncController) markMirrored(server *v1alpha1.MCPServer, detail mcpregistry.ServerDetail) {
+	server.Metadata.Labels = map[string]string{mcpregistry.MirrorLabel: c.Config.Name}
+	server.Metadata.Annotations = map[string]string{
+		mcpregistry.MirrorUpstreamURLAnnotation:   c.Config.URL,
+		mcpregistry.MirrorServerNameAnnotation:    detail.Name,
+		mcpregistry.MirrorServerVersionAnnotation: detail.Version,
+	}
+}
+
+// ownedOrAbsent reports whether the tag row is free or was mirrored by this
+// controller.
+func (c *MCPSyncController) ownedOrAbsent(ctx context.Context, name, tag string) (bool, error) {
+	existing, err := c.Stores.Servers.Get(ctx, c.Config.Namespace, name, tag)
+	if errors.Is(err, pkgdb.ErrNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("get MCPServer %s/%s@%s: %w", c.Config.Namespace, name, tag, err)
+	}
+	return existing.Metadata.Labels[mcpregistry.MirrorLabel] == c.Config.Name, nil
+}
+
+// declareNamespace creates the mirror namespace through the apply pipeline.
+func (c *MCPSyncController) declareNamespace(ctx context.Context) error {
+	applied := c.Apply(ctx, &v1alpha1.Namespace{
+		TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindNamespace},
+		Metadata: v1alpha1.ObjectMeta{Namespace: v1alpha1.DefaultNamespace, Name: c.Config.Namespace},
+	})
+	if applied.Status == arv0.ApplyStatusFailed {
+		return fmt.Errorf("declare mirror namespace %s: %s", c.Config.Namespace, applied.Error)
+	}
+	logger.Info("mcp sync declared mirror namespace", "upstream", c.Config.Name, "namespace", c.Config.Namespace)
+	return nil
+}
+
+// deleteMirrored removes the tag row when this mirror owns it and it holds
+// upstream version. A delete refused because other objects reference the
+// server is skipped and retried when the upstream reports it again.
+func (c *MCPSyncController) deleteMirrored(ctx context.Context, name, tag, version string, result *MCPSyncResult) error {
+	existing, err := c.Stores.Servers.Get(ctx, c.Config.Namespace, name, tag)
+	if errors.Is(err, pkgdb.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get MCPServer %s/%s@%s: %w", c.Config.Namespace, name, tag, err)
+	}
+	meta := existing.Metadata
+	if meta.Labels[mcpregistry.MirrorLabel] != c.Config.Name || meta.Annotations[mcpregistry.MirrorServerVersionAnnotation] != version {
+		return nil
+	}
+	deleted := c.Delete(ctx, &v1alpha1.MCPServer{
+		TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindMCPServer},
+		Metadata: v1alpha1.ObjectMeta{Namespace: c.Config.Namespace, Name: name, Tag: tag},
+	})
+	switch {
+	case deleted.Status != arv0.ApplyStatusFailed:
+		result.Deleted++
+	case deleted.Reason == arv0.ApplyReasonReferenced:
+		logger.Warn("mcp sync kept deleted server: still referenced",
+			"upstream", c.Config.Name, "namespace", c.Config.Namespace, "name", name, "tag", tag, "error", deleted.Error)
+		result.Skipped++
+	default:
+		return fmt.Errorf("delete mirrored MCPServer %s/%s@%s: %s", c.Config.Namespace, name, tag, deleted.Error)
+	}
+	return nil
+}
+
+func (c *MCPSyncController) now() time.Time {
+	if c.Now != nil {
+		return c.Now().UTC()
+	}
+	return time.Now().UTC()
+}
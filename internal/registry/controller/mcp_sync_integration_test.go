@@ -0,0 +1,156 @@
+//go:build integration
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/mcpregistry"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+func TestMCPSyncKeepsProtectedTagsImmutable(t *testing.T) {
+	ctx := context.Background()
+	stores := v1alpha1store.NewStores(v1alpha1store.NewTestDB(t), v1alpha1store.TestSchemaRegistry())
+	upstream := &fakeUpstream{servers: []mcpregistry.ServerResponse{
+		upstreamServer("io.github.acme/weather", "1.0.0", true, "active"),
+	}}
+	srv := httptest.NewServer(upstream)
+	t.Cleanup(srv.Close)
+	applyCfg := resource.ApplyConfig{
+		Stores:    stores,
+		TagPolicy: resource.NewTagPolicyLookup(stores, v1alpha1.DefaultTagPolicy()),
+	}
+	c := &MCPSyncController{
+		Upstream: &mcpregistry.Client{BaseURL: srv.URL},
+		Stores:   MCPSyncStores{Servers: stores[v1alpha1.KindMCPServer], State: &fakeMCPSyncState{}},
+		Apply: func(ctx context.Context, obj v1alpha1.Object) arv0.ApplyResult {
+			return resource.ApplyObject(ctx, applyCfg, obj, false)
+		},
+		Delete: func(ctx context.Context, obj v1alpha1.Object) arv0.ApplyResult {
+			return resource.DeleteObject(ctx, applyCfg, obj, false)
+		},
+		Config: MCPSyncConfig{Name: "official", URL: srv.URL, Namespace: v1alpha1.DefaultNamespace},
+	}
+
+	result, err := c.Sync(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Mirrored)
+
+	// The upstream republishes 1.0.0 with different content: the protected
+	// version tag keeps what was mirrored, the mutable latest tag follows.
+	changed := upstreamServer("io.github.acme/weather", "1.0.0", true, "active")
+	changed.Server.Description = "rewritten upstream"
+	upstream.servers = []mcpregistry.ServerResponse{changed}
+	result, err = c.Sync(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Mirrored)
+	require.Equal(t, 1, result.Skipped)
+
+	for tag, want := range map[string]string{"1.0.0": "mirrored io.github.acme/weather", "latest": "rewritten upstream"} {
+		row, err := stores[v1alpha1.KindMCPServer].Get(ctx, v1alpha1.DefaultNamespace, "io.github.acme-weather", tag)
+		require.NoError(t, err)
+		var spec v1alpha1.MCPServerSpec
+		require.NoError(t, json.Unmarshal(row.Spec, &spec))
+		require.Equal(t, want, spec.Description, tag)
+	}
+}
+
+func TestMCPSyncHoldsMirroredServersForReview(t *testing.T) {
+	ctx := context.Background()
+	stores := v1alpha1store.NewStores(v1alpha1store.NewTestDB(t), v1alpha1store.TestSchemaRegistry())
+	_, err := stores[v1alpha1.KindNamespace].Upsert(ctx, &v1alpha1.Namespace{
+		TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindNamespace},
+		Metadata: v1alpha1.ObjectMeta{Namespace: v1alpha1.DefaultNamespace, Name: "mirror"},
+		Spec:     v1alpha1.NamespaceSpec{ApprovalPolicy: &v1alpha1.ApprovalPolicy{Required: true}},
+	})
+	require.NoError(t, err)
+	upstream := &fakeUpstream{servers: []mcpregistry.ServerResponse{
+		upstreamServer("io.github.acme/weather", "1.0.0", true, "active"),
+	}}
+	srv := httptest.NewServer(upstream)
+	t.Cleanup(srv.Close)
+	applyCfg := resource.ApplyConfig{
+		Stores:          stores,
+		NamespaceExists: resource.NewNamespaceExists(stores),
+		ApprovalPolicy:  resource.NewApprovalPolicy(stores),
+	}
+	c := &MCPSyncController{
+		Upstream: &mcpregistry.Client{BaseURL: srv.URL},
+		Stores:   MCPSyncStores{Servers: stores[v1alpha1.KindMCPServer], State: &fakeMCPSyncState{}},
+		Apply: func(ctx context.Context, obj v1alpha1.Object) arv0.ApplyResult {
+			return resource.ApplyObject(ctx, applyCfg, obj, false)
+		},
+		Delete: func(ctx context.Context, obj v1alpha1.Object) arv0.ApplyResult {
+			return resource.DeleteObject(ctx, applyCfg, obj, false)
+		},
+		Config: MCPSyncConfig{Name: "official", URL: srv.URL, Namespace: "mirror"},
+	}
+
+	result, err := c.Sync(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Mirrored)
+
+	servers := stores[v1alpha1.KindMCPServer]
+	_, err = servers.GetUsableByRef(ctx, "mirror", "io.github.acme-weather", "1.0.0")
+	require.ErrorIs(t, err, v1alpha1store.ErrUnapproved)
+	pending, err := servers.ListApprovals(ctx, "mirror", v1alpha1store.ApprovalPending)
+	require.NoError(t, err)
+	require.Len(t, pending, 1, "both tags share one content digest")
+}
+
+func TestMCPSyncDeclaresNamespaceAndKeepsReferencedServers(t *testing.T) {
+	ctx := context.Background()
+	stores := v1alpha1store.NewStores(v1alpha1store.NewTestDB(t), v1alpha1store.TestSchemaRegistry())
+	upstream := &fakeUpstream{servers: []mcpregistry.ServerResponse{
+		upstreamServer("io.github.acme/weather", "1.0.0", true, "active"),
+	}}
+	srv := httptest.NewServer(upstream)
+	t.Cleanup(srv.Close)
+	applyCfg := resource.ApplyConfig{
+		Stores:          stores,
+		NamespaceExists: resource.NewNamespaceExists(stores),
+		Referrers:       resource.NewReferrerLookup(stores),
+	}
+	c := &MCPSyncController{
+		Upstream: &mcpregistry.Client{BaseURL: srv.URL},
+		Stores:   MCPSyncStores{Servers: stores[v1alpha1.KindMCPServer], State: &fakeMCPSyncState{}},
+		Apply: func(ctx context.Context, obj v1alpha1.Object) arv0.ApplyResult {
+			return resource.ApplyObject(ctx, applyCfg, obj, false)
+		},
+		Delete: func(ctx context.Context, obj v1alpha1.Object) arv0.ApplyResult {
+			return resource.DeleteObject(ctx, applyCfg, obj, false)
+		},
+		Config: MCPSyncConfig{Name: "official", URL: srv.URL, Namespace: "mirror"},
+	}
+
+	// The mirror namespace is not declared yet: the first pass declares it.
+	result, err := c.Sync(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Mirrored)
+	_, err = stores[v1alpha1.KindNamespace].GetLatest(ctx, v1alpha1.DefaultNamespace, "mirror")
+	require.NoError(t, err)
+
+	_, err = stores[v1alpha1.KindAgent].Upsert(ctx, &v1alpha1.Agent{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "mirror", Name: "planner", Tag: "latest"},
+		Spec:     v1alpha1.AgentSpec{MCPServers: []v1alpha1.ResourceRef{{Name: "io.github.acme-weather", Tag: "1.0.0"}}},
+	})
+	require.NoError(t, err)
+
+	// The upstream deletes 1.0.0: the referenced version tag stays.
+	upstream.servers = []mcpregistry.ServerResponse{upstreamServer("io.github.acme/weather", "1.0.0", false, mcpregistry.StatusDeleted)}
+	result, err = c.Sync(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Skipped)
+	require.Equal(t, 1, result.Deleted)
+	_, err = stores[v1alpha1.KindMCPServer].Get(ctx, "mirror", "io.github.acme-weather", "1.0.0")
+	require.NoError(t, err)
+}
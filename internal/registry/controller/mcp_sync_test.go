@@ -0,0 +1,314 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/mcpregistry"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// fakeUpstream serves servers from /v0.1/servers one per page, recording
+// each request's query.
+type fakeUpstream struct {
+	mu      sync.Mutex
+	servers []mcpregistry.ServerResponse
+	queries []map[string]string
+	failAt  int // page index that returns 500; 0 disables
+}
+
+func (u *fakeUpstream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	q := r.URL.Query()
+	u.queries = append(u.queries, map[string]string{"cursor": q.Get("cursor"), "updated_since": q.Get("updated_since")})
+	i, _ := strconv.Atoi(q.Get("cursor"))
+	if u.failAt != 0 && i == u.failAt {
+		http.Error(w, "upstream unavailable", http.StatusInternalServerError)
+		return
+	}
+	var page mcpregistry.ServerListResponse
+	if i < len(u.servers) {
+		page.Servers = u.servers[i : i+1]
+		page.Metadata.Count = 1
+	}
+	if i+1 < len(u.servers) {
+		page.Metadata.NextCursor = strconv.Itoa(i + 1)
+	}
+	_ = json.NewEncoder(w).Encode(page)
+}
+
+type fakeMCPServerStore struct {
+	rows map[string]*v1alpha1.RawObject
+}
+
+func mcpStoreKey(namespace, name, tag string) string { return namespace + "/" + name + "@" + tag }
+
+func (s *fakeMCPServerStore) Get(_ context.Context, namespace, name, tag string) (*v1alpha1.RawObject, error) {
+	row, ok := s.rows[mcpStoreKey(namespace, name, tag)]
+	if !ok {
+		return nil, pkgdb.ErrNotFound
+	}
+	return row, nil
+}
+
+func (s *fakeMCPServerStore) Upsert(_ context.Context, obj v1alpha1.Object, _ ...v1alpha1store.UpsertOpts) (v1alpha1store.UpsertResult, error) {
+	meta := *obj.GetMetadata()
+	spec, err := obj.MarshalSpec()
+	if err != nil {
+		return v1alpha1store.UpsertResult{}, err
+	}
+	key := mcpStoreKey(meta.Namespace, meta.Name, meta.Tag)
+	outcome := v1alpha1store.UpsertCreated
+	if have, ok := s.rows[key]; ok {
+		outcome = v1alpha1store.UpsertReplaced
+		if string(have.Spec) == string(spec) && maps.Equal(have.Metadata.Annotations, meta.Annotations) {
+			outcome = v1alpha1store.UpsertNoOp
+		}
+	}
+	s.rows[key] = &v1alpha1.RawObject{Metadata: meta, Spec: spec}
+	return v1alpha1store.UpsertResult{Tag: meta.Tag, Outcome: outcome}, nil
+}
+
+// apply stands in for resource.ApplyObject: it upserts obj and reports the
+// outcome the way an apply result would.
+func (s *fakeMCPServerStore) apply(ctx context.Context, obj v1alpha1.Object) arv0.ApplyResult {
+	meta := obj.GetMetadata()
+	res := arv0.ApplyResult{Kind: obj.GetKind(), Namespace: meta.Namespace, Name: meta.Name, Tag: meta.Tag}
+	up, err := s.Upsert(ctx, obj)
+	switch {
+	case err != nil:
+		res.Status, res.Error = arv0.ApplyStatusFailed, "upsert: "+err.Error()
+	case up.Outcome == v1alpha1store.UpsertCreated:
+		res.Status = arv0.ApplyStatusCreated
+	case up.Outcome == v1alpha1store.UpsertReplaced:
+		res.Status = arv0.ApplyStatusConfigured
+	default:
+		res.Status = arv0.ApplyStatusUnchanged
+	}
+	return res
+}
+
+// delete stands in for resource.DeleteObject.
+func (s *fakeMCPServerStore) delete(_ context.Context, obj v1alpha1.Object) arv0.ApplyResult {
+	meta := obj.GetMetadata()
+	delete(s.rows, mcpStoreKey(meta.Namespace, meta.Name, meta.Tag))
+	return arv0.ApplyResult{Kind: obj.GetKind(), Namespace: meta.Namespace, Name: meta.Name, Tag: meta.Tag, Status: arv0.ApplyStatusDeleted}
+}
+
+type fakeMCPSyncState struct {
+	state v1alpha1store.MCPSyncState
+}
+
+func (s *fakeMCPSyncState) Get(_ context.Context, upstream string) (v1alpha1store.MCPSyncState, error) {
+	state := s.state
+	state.Upstream = upstream
+	return state, nil
+}
+
+func (s *fakeMCPSyncState) Save(_ context.Context, state v1alpha1store.MCPSyncState) error {
+	s.state = state
+	return nil
+}
+
+func upstreamServer(name, version string, latest bool, status string) mcpregistry.ServerResponse {
+	return mcpregistry.ServerResponse{
+		Server: mcpregistry.ServerDetail{
+			Name:        name,
+			Description: "mirrored " + name,
+			Version:     version,
+			Packages: []mcpregistry.ServerPackage{{
+				RegistryType: "npm",
+				Identifier:   "@acme/" + version,
+				Version:      version,
+				Transport:    mcpregistry.ServerTransport{Type: "stdio"},
+			}},
+		},
+		Meta: &mcpregistry.ResponseMeta{Official: &mcpregistry.OfficialMeta{Status: status, IsLatest: latest}},
+	}
+}
+
+func newTestMCPSync(t *testing.T, upstream *fakeUpstream) (*MCPSyncController, *fakeMCPServerStore, *fakeMCPSyncState) {
+	t.Helper()
+	srv := httptest.NewServer(upstream)
+	t.Cleanup(srv.Close)
+	servers := &fakeMCPServerStore{rows: map[string]*v1alpha1.RawObject{}}
+	state := &fakeMCPSyncState{}
+	c := &MCPSyncController{
+		Upstream: &mcpregistry.Client{BaseURL: srv.URL},
+		Stores:   MCPSyncStores{Servers: servers, State: state},
+		Apply:    servers.apply,
+		Delete:   servers.delete,
+		Config: MCPSyncConfig{
+			Name:      "official",
+			URL:       srv.URL,
+			Namespace: "mirror",
+			Include:   []string{"io.github.acme/*"},
+			Exclude:   []string{"io.github.acme/internal-*"},
+		},
+		Now: func() time.Time { return time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC) },
+	}
+	return c, servers, state
+}
+
+func TestMCPSyncMirrorsFilteredServersAndCheckpoints(t *testing.T) {
+	upstream := &fakeUpstream{servers: []mcpregistry.ServerResponse{
+		upstreamServer("io.github.acme/weather", "1.0.0", false, "active"),
+		upstreamServer("io.github.acme/weather", "1.1.0", true, "active"),
+		upstreamServer("io.github.acme/internal-tools", "1.0.0", true, "active"),
+		upstreamServer("io.github.other/search", "3.0.0", true, "active"),
+	}}
+	c, servers, state := newTestMCPSync(t, upstream)
+
+	result, err := c.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("Sync returned error: %v", err)
+	}
+	if result != (MCPSyncResult{Pages: 4, Mirrored: 3, Filtered: 2}) {
+		t.Fatalf("result = %+v", result)
+	}
+	for _, tag := range []string{"1.0.0", "1.1.0", "latest"} {
+		row, ok := servers.rows[mcpStoreKey("mirror", "io.github.acme-weather", tag)]
+		if !ok {
+			t.Fatalf("tag %s not mirrored; rows = %v", tag, slices.Collect(maps.Keys(servers.rows)))
+		}
+		if row.Metadata.Labels[mcpregistry.MirrorLabel] != "official" ||
+			row.Metadata.Annotations[mcpregistry.MirrorServerNameAnnotation] != "io.github.acme/weather" {
+			t.Fatalf("tag %s metadata = %+v", tag, row.Metadata)
+		}
+	}
+	if got := servers.rows[mcpStoreKey("mirror", "io.github.acme-weather", "latest")].Metadata.Annotations[mcpregistry.MirrorServerVersionAnnotation]; got != "1.1.0" {
+		t.Fatalf("latest holds version %q, want 1.1.0", got)
+	}
+
+	want := time.Date(2026, 10, 1, 8, 59, 0, 0, time.UTC)
+	if state.state.Cursor != "" || !state.state.UpdatedSince.Equal(want) || state.state.LastSyncedAt.IsZero() {
+		t.Fatalf("checkpoint = %+v, want completed pass with updated_since %s", state.state, want)
+	}
+
+	// The next pass is incremental and sees the deletion of 1.0.0.
+	upstream.servers = []mcpregistry.ServerResponse{upstreamServer("io.github.acme/weather", "1.0.0", false, mcpregistry.StatusDeleted)}
+	upstream.queries = nil
+	result, err = c.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("second Sync returned error: %v", err)
+	}
+	if result != (MCPSyncResult{Pages: 1, Deleted: 1}) {
+		t.Fatalf("second result = %+v", result)
+	}
+	if got := upstream.queries[0]["updated_since"]; got != "2026-10-01T08:59:00Z" {
+		t.Fatalf("updated_since = %q", got)
+	}
+	if _, ok := servers.rows[mcpStoreKey("mirror", "io.github.acme-weather", "1.0.0")]; ok {
+		t.Fatal("deleted upstream version still mirrored")
+	}
+	if _, ok := servers.rows[mcpStoreKey("mirror", "io.github.acme-weather", "latest")]; !ok {
+		t.Fatal("latest (1.1.0) removed with the deleted 1.0.0")
+	}
+}
+
+func TestMCPSyncNeverOverwritesLocalObjects(t *testing.T) {
+	upstream := &fakeUpstream{servers: []mcpregistry.ServerResponse{
+		upstreamServer("io.github.acme/weather", "1.0.0", true, "active"),
+	}}
+	c, servers, _ := newTestMCPSync(t, upstream)
+	local := &v1alpha1.RawObject{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "mirror", Name: "io.github.acme-weather", Tag: "latest"},
+		Spec:     json.RawMessage(`{"title":"Local"}`),
+	}
+	servers.rows[mcpStoreKey("mirror", "io.github.acme-weather", "latest")] = local
+
+	result, err := c.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("Sync returned error: %v", err)
+	}
+	if result.Mirrored != 1 || result.Skipped != 1 {
+		t.Fatalf("result = %+v, want the version tag mirrored and latest skipped", result)
+	}
+	if servers.rows[mcpStoreKey("mirror", "io.github.acme-weather", "latest")] != local {
+		t.Fatal("local latest tag was overwritten")
+	}
+}
+
+func TestMCPSyncResumesFromCheckpointAfterFailure(t *testing.T) {
+	upstream := &fakeUpstream{
+		servers: []mcpregistry.ServerResponse{
+			upstreamServer("io.github.acme/a", "1.0.0", true, "active"),
+			upstreamServer("io.github.acme/b", "1.0.0", true, "active"),
+			upstreamServer("io.github.acme/c", "1.0.0", true, "active"),
+		},
+		failAt: 2,
+	}
+	c, _, state := newTestMCPSync(t, upstream)
+
+	if _, err := c.Sync(context.Background()); err == nil {
+		t.Fatal("Sync succeeded through a failing page")
+	}
+	if state.state.Cursor != "2" || !state.state.UpdatedSince.IsZero() {
+		t.Fatalf("checkpoint = %+v, want cursor at the failed page", state.state)
+	}
+
+	upstream.failAt = 0
+	upstream.queries = nil
+	result, err := c.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("resumed Sync returned error: %v", err)
+	}
+	if result.Pages != 1 || upstream.queries[0]["cursor"] != "2" {
+		t.Fatalf("resumed pass = %+v, queries %v; want one page from cursor 2", result, upstream.queries)
+	}
+	if state.state.Cursor != "" || state.state.UpdatedSince.IsZero() {
+		t.Fatalf("checkpoint = %+v, want completed pass", state.state)
+	}
+}
+
+func TestMCPSyncSkipsPolicyRefusalsAndStopsOnOtherApplyFailures(t *testing.T) {
+	upstream := &fakeUpstream{servers: []mcpregistry.ServerResponse{
+		upstreamServer("io.github.acme/weather", "1.0.0", false, "active"),
+	}}
+	c, _, state := newTestMCPSync(t, upstream)
+
+	c.Apply = func(context.Context, v1alpha1.Object) arv0.ApplyResult {
+		return arv0.ApplyResult{Status: arv0.ApplyStatusFailed, Reason: arv0.ApplyReasonSignatureRequired, Error: "unsigned"}
+	}
+	result, err := c.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("Sync returned error: %v", err)
+	}
+	if result.Skipped != 1 || result.Mirrored != 0 {
+		t.Fatalf("result = %+v, want the refused server skipped", result)
+	}
+
+	state.state = v1alpha1store.MCPSyncState{}
+	c.Apply = func(context.Context, v1alpha1.Object) arv0.ApplyResult {
+		return arv0.ApplyResult{Status: arv0.ApplyStatusFailed, Error: "upsert: database is locked"}
+	}
+	if _, err := c.Sync(context.Background()); err == nil {
+		t.Fatal("Sync succeeded through a failing apply")
+	}
+	if !state.state.LastSyncedAt.IsZero() {
+		t.Fatalf("checkpoint = %+v, want the failed pass not completed", state.state)
+	}
+}
+
+func TestMCPSyncConfigMatches(t *testing.T) {
+	config := MCPSyncConfig{Exclude: []string{"com.example/*"}}
+	if !config.Matches("io.github.acme/weather") || config.Matches("com.example/search") {
+		t.Fatal("empty include should mirror everything not excluded")
+	}
+	config.Include = []string{"io.github.*/*"}
+	if config.Matches("org.acme/weather") {
+		t.Fatal("servers outside include should be filtered")
+	}
+}
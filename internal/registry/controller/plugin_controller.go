@@ -8,7 +8,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/jackc/pgx/v5/pgxpool"
 	"k8s.io/client-go/util/workqueue"
 
 	"github.com/agentregistry-dev/agentregistry/internal/registry/plugins/bundle"
@@ -40,10 +39,10 @@ type pluginQueueKey struct {
 }
 
 // PluginController reconciles Plugin resources out of band of the API write: it
-// resolves each plugin's pinned source pointer (a git commit or — later — an
-// OCI digest) to a concrete commit/digest, scans the source for its manifest
-// and inventory, and records all of it in PluginStatus. It stores NOTHING: the
-// bundle stays at its origin and is materialized from source at deploy time.
+// resolves each plugin's source pointer (a git ref or an OCI tag/digest) to a
+// concrete commit/digest, scans the source for its manifest and inventory, and
+// records all of it in PluginStatus. It stores NOTHING: the bundle stays at its
+// origin and is materialized from source at deploy time.
 //
 // It is level-triggered — every control-plane wakeup (and the resync tick)
 // re-lists plugins and enqueues those whose status is behind their generation.
@@ -56,7 +55,7 @@ type PluginController struct {
 	Resolver source.Resolver
 	Wakeups  <-chan struct{}
 
-	pool   *pgxpool.Pool
+	db     v1alpha1store.DB
 	resync time.Duration
 
 	lifecycleMu sync.Mutex
@@ -70,11 +69,11 @@ type PluginController struct {
 // NewPluginController wires the Plugin controller without starting it. Start
 // owns the background goroutine and control-plane LISTEN subscription.
 func NewPluginController(
-	pool *pgxpool.Pool,
+	db v1alpha1store.DB,
 	stores map[string]*v1alpha1store.Store,
 	deps PluginControllerDeps,
 ) (*PluginController, error) {
-	if pool == nil {
+	if db == nil {
 		return nil, nil
 	}
 	store := stores[v1alpha1.KindPlugin]
@@ -87,7 +86,7 @@ func NewPluginController(
 	return &PluginController{
 		Store:    store,
 		Resolver: deps.Resolver,
-		pool:     pool,
+		db:       db,
 		resync:   defaultControllerResyncInterval,
 	}, nil
 }
@@ -109,8 +108,8 @@ func (c *PluginController) Start(ctx context.Context) error {
 	runCtx, cancel := context.WithCancel(ctx)
 	c.cancel = cancel
 	c.done = make(chan struct{})
-	if c.pool != nil {
-		c.Wakeups = controlPlaneWakeups(runCtx, c.pool)
+	if c.db != nil {
+		c.Wakeups = controlPlaneWakeups(runCtx, c.db)
 	}
 	resync := c.resync
 	if resync == 0 {
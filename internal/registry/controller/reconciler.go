@@ -27,6 +27,10 @@ type deploymentControllerDetails struct {
 	LastAppliedFingerprint string                          `json:"lastAppliedFingerprint,omitempty"`
 	LastForceToken         string                          `json:"lastForceToken,omitempty"`
 	Dependencies           []types.ApplyDependencySnapshot `json:"dependencies,omitempty"`
+	// ResolvedTags pins the semver-range refs resolved by the last apply of
+	// ResolvedGeneration.
+	ResolvedTags       []ResolvedTagRef `json:"resolvedTags,omitempty"`
+	ResolvedGeneration int64            `json:"resolvedGeneration,omitempty"`
 }
 
 func (c *DeploymentController) processQueueItem(
@@ -74,13 +78,34 @@ func (c *DeploymentController) reconcileKey(ctx context.Context, key deploymentQ
 }
 
 func (c *DeploymentController) apply(ctx context.Context, deployment *v1alpha1.Deployment) (string, string, error) {
-	target, err := c.resolveTarget(ctx, deployment)
+	if c.Getter == nil {
+		return "", "", errors.New("deployment controller: getter is nil")
+	}
+	forceToken := deploymentForceToken(deployment)
+	pins, err := deploymentTagPins(deployment, forceToken)
+	if err != nil {
+		return "", "", err
+	}
+	pinner := newTagPinner(c.Getter, pins)
+	target, err := c.resolveTarget(ctx, deployment, pinner.Get)
 	if err != nil {
 		if errors.Is(err, v1alpha1.ErrDanglingRef) {
 			return c.blockReference(ctx, deployment, err)
 		}
 		return "", "", err
 	}
+	if err := c.verifyTargetSignature(ctx, target); err != nil {
+		if errors.Is(err, v1alpha1store.ErrUnsigned) {
+			return c.block(ctx, deployment, "SignatureRequired", err.Error())
+		}
+		return "", "", err
+	}
+	if err := c.verifyTargetApproval(ctx, target); err != nil {
+		if errors.Is(err, v1alpha1store.ErrUnapproved) {
+			return c.block(ctx, deployment, "ApprovalPending", err.Error())
+		}
+		return "", "", err
+	}
 	runtime, err := c.resolveRuntime(ctx, deployment)
 	if err != nil {
 		if errors.Is(err, v1alpha1.ErrDanglingRef) {
@@ -100,7 +125,7 @@ func (c *DeploymentController) apply(ctx context.Context, deployment *v1alpha1.D
 		Deployment: deployment,
 		Target:     target,
 		Runtime:    runtime,
-		Getter:     c.Getter,
+		Getter:     pinner.Get,
 	}
 	fingerprintResult, err := desiredApplyFingerprint(ctx, adapter, input)
 	if err != nil {
@@ -110,12 +135,16 @@ func (c *DeploymentController) apply(ctx context.Context, deployment *v1alpha1.D
 		return "", "", err
 	}
 	fingerprint := fingerprintResult.Fingerprint
-	forceToken := deploymentForceToken(deployment)
 	if skip, err := shouldSkipApply(deployment, fingerprint, forceToken); err != nil {
 		return "", "", err
 	} else if skip {
 		return "unchanged", "deployment desired input unchanged", nil
 	}
+	// A yanked target keeps running where it already runs, but is not
+	// rolled out anew.
+	if meta := target.GetMetadata(); meta.Yanked != nil {
+		return c.block(ctx, deployment, "TargetYanked", yankedMessage(target))
+	}
 	result, err := adapter.Apply(ctx, input)
 	if err != nil {
 		if errors.Is(err, v1alpha1.ErrDanglingRef) {
@@ -123,7 +152,7 @@ func (c *DeploymentController) apply(ctx context.Context, deployment *v1alpha1.D
 		}
 		return "", "", fmt.Errorf("adapter %q apply: %w", adapter.Type(), err)
 	}
-	if err := c.persistApplyResult(ctx, deployment, result, fingerprint, forceToken, fingerprintResult.Dependencies); err != nil {
+	if err := c.persistApplyResult(ctx, deployment, result, fingerprint, forceToken, fingerprintResult.Dependencies, pinner.Resolved()); err != nil {
 		return "", "", err
 	}
 	return "success", "deployment applied", nil
@@ -178,15 +207,80 @@ func (c *DeploymentController) blockReference(ctx context.Context, deployment *v
 	if cause != nil {
 		message = cause.Error()
 	}
+	switch {
+	case errors.Is(cause, v1alpha1store.ErrUnapproved):
+		return c.block(ctx, deployment, "ApprovalPending", message)
+	case errors.Is(cause, v1alpha1store.ErrYanked):
+		return c.block(ctx, deployment, "TargetYanked", message)
+	}
+	return c.block(ctx, deployment, "ReferencePending", message)
+}
+
+// yankedMessage describes a yanked target for its Ready condition.
+func yankedMessage(target v1alpha1.Object) string {
+	meta := target.GetMetadata()
+	message := fmt.Sprintf("target %s %s/%s@%s is yanked", target.GetKind(), meta.Namespace, meta.Name, meta.Tag)
+	if meta.Yanked.Reason != "" {
+		message += ": " + meta.Yanked.Reason
+	}
+	return message
+}
+
+// verifyTargetSignature refuses a target the signature policy requires a
+// trusted signature for and that has none. Signing does not emit a
+// control-plane event, so a blocked Deployment picks up a new signature on
+// the next resync.
+func (c *DeploymentController) verifyTargetSignature(ctx context.Context, target v1alpha1.Object) error {
+	if c.VerifySignature == nil || !v1alpha1.IsTaggedArtifactKind(target.GetKind()) {
+		return nil
+	}
+	digest, err := v1alpha1store.ObjectDigest(target)
+	if err != nil {
+		return fmt.Errorf("target digest: %w", err)
+	}
+	meta := target.GetMetadata()
+	if err := c.VerifySignature(ctx, target.GetKind(), meta.Namespace, meta.Name, digest); err != nil {
+		return fmt.Errorf("target %s %s/%s@%s: %w", target.GetKind(), meta.Namespace, meta.Name, meta.Tag, err)
+	}
+	return nil
+}
+
+// verifyTargetApproval refuses a target whose content awaits review or was
+// rejected. The Getter already ignores such tags; this covers a pinned
+// spec.targetDigest, which deploys a revision the Getter never returned.
+// Like signing, a review emits no control-plane event, so a blocked
+// Deployment picks up the decision on the next resync.
+func (c *DeploymentController) verifyTargetApproval(ctx context.Context, target v1alpha1.Object) error {
+	store := c.Stores[target.GetKind()]
+	if !store.Approvals() {
+		return nil
+	}
+	digest, err := v1alpha1store.ObjectDigest(target)
+	if err != nil {
+		return fmt.Errorf("target digest: %w", err)
+	}
+	meta := target.GetMetadata()
+	state, err := store.ApprovalState(ctx, meta.Namespace, meta.Name, digest)
+	if err != nil {
+		return fmt.Errorf("target %s %s/%s@%s: %w", target.GetKind(), meta.Namespace, meta.Name, meta.Tag, err)
+	}
+	if state != v1alpha1store.ApprovalApproved {
+		return fmt.Errorf("target %s %s/%s@%s (%s): %w: %s", target.GetKind(), meta.Namespace, meta.Name, meta.Tag, digest, v1alpha1store.ErrUnapproved, state)
+	}
+	return nil
+}
+
+// block persists Ready=False with reason and leaves the runtime untouched.
+func (c *DeploymentController) block(ctx context.Context, deployment *v1alpha1.Deployment, reason, message string) (string, string, error) {
 	if err := c.persistApplyResult(ctx, deployment, &types.ApplyResult{
 		Conditions: []v1alpha1.Condition{{
 			Type:               "Ready",
 			Status:             v1alpha1.ConditionFalse,
-			Reason:             "ReferencePending",
+			Reason:             reason,
 			Message:            message,
 			ObservedGeneration: deployment.Metadata.Generation,
 		}},
-	}, "", "", nil); err != nil {
+	}, "", "", nil, nil); err != nil {
 		return "", "", err
 	}
 	return "blocked", message, nil
@@ -215,19 +309,75 @@ func (c *DeploymentController) loadDeployment(ctx context.Context, key deploymen
 	return deployment, true, nil
 }
 
-func (c *DeploymentController) resolveTarget(ctx context.Context, deployment *v1alpha1.Deployment) (v1alpha1.Object, error) {
-	if c.Getter == nil {
-		return nil, errors.New("deployment controller: getter is nil")
-	}
+func (c *DeploymentController) resolveTarget(ctx context.Context, deployment *v1alpha1.Deployment, getter v1alpha1.GetterFunc) (v1alpha1.Object, error) {
 	ref := deployment.Spec.TargetRef
 	ref.Namespace = refNamespace(ref.Namespace, deployment.Metadata.NamespaceOrDefault())
-	obj, err := c.Getter(ctx, ref)
+	obj, err := getter(ctx, ref)
+	if err != nil && deployment.Spec.TargetDigest != "" && errors.Is(err, v1alpha1store.ErrUnapproved) {
+		// The pin, not the content the tag holds now, decides what is
+		// deployed; verifyTargetApproval reviews the pinned revision.
+		obj, err = c.getUnreviewed(ctx, ref)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("resolve targetRef %s/%s@%s: %w", ref.Namespace, ref.Name, ref.Tag, err)
 	}
 	if obj == nil {
 		return nil, fmt.Errorf("resolve targetRef %s/%s: nil object", ref.Namespace, ref.Name)
 	}
+	if digest := deployment.Spec.TargetDigest; digest != "" {
+		return c.resolveTargetRevision(ctx, ref, obj, digest)
+	}
+	return obj, nil
+}
+
+// getUnreviewed reads ref straight from its Store, including tags whose
+// content is not approved.
+func (c *DeploymentController) getUnreviewed(ctx context.Context, ref v1alpha1.ResourceRef) (v1alpha1.Object, error) {
+	store := c.Stores[ref.Kind]
+	if store == nil {
+		return nil, fmt.Errorf("%w: no store for kind %q", v1alpha1.ErrInvalidRef, ref.Kind)
+	}
+	raw, err := store.GetByRef(ctx, ref.Namespace, ref.Name, ref.Tag)
+	if err != nil {
+		if errors.Is(err, pkgdb.ErrNotFound) {
+			return nil, v1alpha1.ErrDanglingRef
+		}
+		return nil, err
+	}
+	_, newObj, ok := v1alpha1.Default.Lookup(ref.Kind)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown kind %q in scheme", v1alpha1.ErrInvalidRef, ref.Kind)
+	}
+	return v1alpha1.EnvelopeFromRaw(func() v1alpha1.Object { return newObj().(v1alpha1.Object) }, raw, ref.Kind)
+}
+
+// resolveTargetRevision swaps the live target for the revision of its tag
+// that spec.targetDigest pins, so republishing the tag does not change
+// what the Deployment applies.
+func (c *DeploymentController) resolveTargetRevision(ctx context.Context, ref v1alpha1.ResourceRef, live v1alpha1.Object, digest string) (v1alpha1.Object, error) {
+	store := c.Stores[ref.Kind]
+	if !store.RevisionHistory() {
+		return nil, fmt.Errorf("%w: targetDigest: %s keeps no revision history", pkgdb.ErrInvalidInput, ref.Kind)
+	}
+	tag := live.GetMetadata().Tag
+	rev, err := store.GetRevision(ctx, ref.Namespace, ref.Name, tag, digest)
+	if err != nil {
+		if errors.Is(err, pkgdb.ErrNotFound) {
+			return nil, fmt.Errorf("%w: targetRef %s/%s@%s has no revision %s", v1alpha1.ErrDanglingRef, ref.Namespace, ref.Name, tag, digest)
+		}
+		return nil, fmt.Errorf("resolve targetDigest %s: %w", digest, err)
+	}
+	if rev.Current {
+		return live, nil
+	}
+	_, newObj, ok := v1alpha1.Default.Lookup(ref.Kind)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown kind %q in scheme", v1alpha1.ErrInvalidRef, ref.Kind)
+	}
+	obj, err := v1alpha1.EnvelopeFromRaw(func() v1alpha1.Object { return newObj().(v1alpha1.Object) }, rev.Object, ref.Kind)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s revision %d: %w", ref.Kind, rev.Revision, err)
+	}
 	return obj, nil
 }
 
@@ -263,13 +413,14 @@ func (c *DeploymentController) persistApplyResult(
 	fingerprint string,
 	forceToken string,
 	dependencies []types.ApplyDependencySnapshot,
+	resolvedTags []ResolvedTagRef,
 ) error {
 	patch := v1alpha1store.PatchOpts{
 		Finalizers: ensureFinalizer(DeploymentControllerFinalizer),
 	}
 	if result == nil {
 		if fingerprint != "" {
-			patch.Status = deploymentControllerStatusPatch(deployment, nil, fingerprint, forceToken, dependencies)
+			patch.Status = deploymentControllerStatusPatch(deployment, nil, fingerprint, forceToken, dependencies, resolvedTags)
 		}
 		if err := c.deploymentStore().ApplyPatch(ctx, deployment.Metadata.NamespaceOrDefault(), deployment.Metadata.Name, "", patch); err != nil {
 			return fmt.Errorf("persist apply result: %w", err)
@@ -277,7 +428,7 @@ func (c *DeploymentController) persistApplyResult(
 		return nil
 	}
 	if len(result.Conditions) > 0 || len(result.Details) > 0 || fingerprint != "" {
-		patch.Status = deploymentControllerStatusPatch(deployment, result, fingerprint, forceToken, dependencies)
+		patch.Status = deploymentControllerStatusPatch(deployment, result, fingerprint, forceToken, dependencies, resolvedTags)
 	}
 	if len(result.RuntimeMetadata) > 0 {
 		patch.Annotations = func(annotations map[string]string) map[string]string {
@@ -300,6 +451,7 @@ func deploymentControllerStatusPatch(
 	fingerprint string,
 	forceToken string,
 	dependencies []types.ApplyDependencySnapshot,
+	resolvedTags []ResolvedTagRef,
 ) func(current json.RawMessage) (json.RawMessage, error) {
 	return v1alpha1.StatusPatcher(func(s *v1alpha1.Status) {
 		if s.ObservedGeneration < deployment.Metadata.Generation {
@@ -318,6 +470,8 @@ func deploymentControllerStatusPatch(
 				LastAppliedFingerprint: fingerprint,
 				LastForceToken:         forceToken,
 				Dependencies:           dependencies,
+				ResolvedTags:           resolvedTags,
+				ResolvedGeneration:     deployment.Metadata.Generation,
 			})
 		}
 	})
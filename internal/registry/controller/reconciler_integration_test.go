@@ -4,7 +4,10 @@ package controller
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"errors"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -14,7 +17,9 @@ import (
 	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
 	"github.com/agentregistry-dev/agentregistry/pkg/types"
 )
 
@@ -169,6 +174,121 @@ func TestDeploymentController_BlocksMissingTargetWithoutAdapterCall(t *testing.T
 	require.Equal(t, "ReferencePending", ready.Reason)
 }
 
+func TestDeploymentController_BlocksUnsignedTargetUntilSigned(t *testing.T) {
+	ctx := context.Background()
+	stores := newControllerTestStores(t)
+	seedMCPServer(t, stores, "weather")
+	seedDeployment(t, stores, "weather-signed", v1alpha1.DesiredStateDeployed)
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	pubPEM, err := signing.MarshalPublicKey(pub)
+	require.NoError(t, err)
+	policy := v1alpha1.SignaturePolicy{Required: true, TrustedKeys: []v1alpha1.TrustedKey{{Name: "release", PublicKey: pubPEM}}}
+
+	adapter := &recordingDeploymentAdapter{}
+	controller := newDeploymentTestController(stores, adapter)
+	controller.VerifySignature = resource.NewSignatureVerifier(stores, policy)
+	reconcile := func() {
+		t.Helper()
+		_, err := controller.FullReconcile(ctx)
+		require.NoError(t, err)
+		processed, err := controller.RunOnce(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, processed)
+	}
+
+	reconcile()
+	require.Zero(t, adapter.applyCalls.Load())
+	ready := loadDeployment(t, stores, "weather-signed").Status.GetCondition("Ready")
+	require.NotNil(t, ready)
+	require.Equal(t, v1alpha1.ConditionFalse, ready.Status)
+	require.Equal(t, "SignatureRequired", ready.Reason)
+
+	store := stores[v1alpha1.KindMCPServer]
+	digest, err := store.TagDigest(ctx, "default", "weather", "")
+	require.NoError(t, err)
+	sig, err := signing.Sign(priv, digest)
+	require.NoError(t, err)
+	_, err = store.AddSignature(ctx, "default", "weather", v1alpha1store.Signature{Digest: digest, PublicKey: pubPEM, Signature: sig})
+	require.NoError(t, err)
+
+	reconcile()
+	require.Equal(t, int32(1), adapter.applyCalls.Load())
+}
+
+func TestDeploymentController_IgnoresTargetUntilApproved(t *testing.T) {
+	ctx := context.Background()
+	stores := newControllerTestStores(t)
+	seedMCPServer(t, stores, "weather")
+	seedDeployment(t, stores, "weather-reviewed", v1alpha1.DesiredStateDeployed)
+
+	store := stores[v1alpha1.KindMCPServer]
+	digest, err := store.TagDigest(ctx, "default", "weather", "")
+	require.NoError(t, err)
+	_, err = store.RequestApproval(ctx, "default", "weather", v1alpha1store.DefaultTag(), digest, "alice")
+	require.NoError(t, err)
+
+	adapter := &recordingDeploymentAdapter{}
+	controller := newDeploymentTestController(stores, adapter)
+	reconcile := func() {
+		t.Helper()
+		_, err := controller.FullReconcile(ctx)
+		require.NoError(t, err)
+		processed, err := controller.RunOnce(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, processed)
+	}
+
+	reconcile()
+	require.Zero(t, adapter.applyCalls.Load())
+	ready := loadDeployment(t, stores, "weather-reviewed").Status.GetCondition("Ready")
+	require.NotNil(t, ready)
+	require.Equal(t, v1alpha1.ConditionFalse, ready.Status)
+	require.Equal(t, "ApprovalPending", ready.Reason)
+
+	_, err = store.ReviewApproval(ctx, "default", "weather", digest, true, "bob", "")
+	require.NoError(t, err)
+
+	reconcile()
+	require.Equal(t, int32(1), adapter.applyCalls.Load())
+}
+
+func TestDeploymentController_RefusesYankedTarget(t *testing.T) {
+	ctx := context.Background()
+	stores := newControllerTestStores(t)
+	seedMCPServer(t, stores, "weather")
+	seedDeployment(t, stores, "weather-yanked", v1alpha1.DesiredStateDeployed)
+
+	store := stores[v1alpha1.KindMCPServer]
+	_, err := store.SetYank(ctx, "default", "weather", v1alpha1store.DefaultTag(), &v1alpha1.Yank{Reason: "crashes on start"})
+	require.NoError(t, err)
+
+	adapter := &recordingDeploymentAdapter{}
+	controller := newDeploymentTestController(stores, adapter)
+	reconcile := func() {
+		t.Helper()
+		_, err := controller.FullReconcile(ctx)
+		require.NoError(t, err)
+		processed, err := controller.RunOnce(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, processed)
+	}
+
+	reconcile()
+	require.Zero(t, adapter.applyCalls.Load())
+	ready := loadDeployment(t, stores, "weather-yanked").Status.GetCondition("Ready")
+	require.NotNil(t, ready)
+	require.Equal(t, v1alpha1.ConditionFalse, ready.Status)
+	require.Equal(t, "TargetYanked", ready.Reason)
+
+	_, err = store.SetYank(ctx, "default", "weather", v1alpha1store.DefaultTag(), nil)
+	require.NoError(t, err)
+
+	reconcile()
+	require.Equal(t, int32(1), adapter.applyCalls.Load())
+}
+
 func TestDeploymentController_ReappliesWhenMissingTargetAppears(t *testing.T) {
 	ctx := context.Background()
 	stores := newControllerTestStores(t)
@@ -262,6 +382,135 @@ func TestDeploymentController_ReappliesAgentDeploymentWhenReferencedMCPServerCha
 		"dependency material hash should change after the referenced MCPServer spec changes")
 }
 
+func TestDeploymentController_PinsSemverRangeRefsUntilForced(t *testing.T) {
+	ctx := context.Background()
+	stores := newControllerTestStores(t)
+	seedTaggedMCPServer(t, stores, "weather", "1.2.0", "ghcr.io/example/weather:1.2.0")
+	seedTaggedMCPServer(t, stores, "weather", "1.3.0", "ghcr.io/example/weather:1.3.0")
+	seedTaggedMCPServer(t, stores, "weather", "2.0.0", "ghcr.io/example/weather:2.0.0")
+	seedAgent(t, stores, "assistant", []v1alpha1.ResourceRef{{Name: "weather", Tag: "^1.2"}})
+	deployment := seedAgentDeployment(t, stores, "assistant-range", "assistant", v1alpha1.DesiredStateDeployed)
+
+	adapter := &recordingDeploymentAdapter{}
+	controller := newDeploymentTestController(stores, adapter)
+	_, err := controller.FullReconcile(ctx)
+	require.NoError(t, err)
+	processed, err := controller.RunOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, processed)
+	require.Equal(t, int32(1), adapter.applyCalls.Load())
+
+	requireResolvedTags := func(want string) {
+		t.Helper()
+		got := loadDeployment(t, stores, deployment.Metadata.Name)
+		var details deploymentControllerDetails
+		ok, err := got.Status.GetDetailsKey(deploymentControllerDetailsKey, &details)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, []ResolvedTagRef{{
+			Kind:        v1alpha1.KindMCPServer,
+			Namespace:   "default",
+			Name:        "weather",
+			Range:       "^1.2",
+			ResolvedTag: want,
+		}}, details.ResolvedTags)
+		require.Len(t, details.Dependencies, 1)
+		require.Equal(t, want, details.Dependencies[0].Tag)
+	}
+	requireResolvedTags("1.3.0")
+
+	// A newly published tag inside the range does not move the applied
+	// Deployment on its own.
+	seedTaggedMCPServer(t, stores, "weather", "1.4.0", "ghcr.io/example/weather:1.4.0")
+	_, err = controller.FullReconcile(ctx)
+	require.NoError(t, err)
+	processed, err = controller.RunOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, processed)
+	require.Equal(t, int32(1), adapter.applyCalls.Load())
+	requireResolvedTags("1.3.0")
+
+	require.NoError(t, stores[v1alpha1.KindDeployment].PatchAnnotations(ctx, "default", deployment.Metadata.Name, "", func(current map[string]string) map[string]string {
+		current[DeploymentForceAnnotation] = "bump-1"
+		return current
+	}))
+	_, err = controller.FullReconcile(ctx)
+	require.NoError(t, err)
+	processed, err = controller.RunOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, processed)
+	require.Equal(t, int32(2), adapter.applyCalls.Load())
+	requireResolvedTags("1.4.0")
+}
+
+func TestDeploymentController_TargetDigestPinsRevision(t *testing.T) {
+	ctx := context.Background()
+	stores := newControllerTestStores(t)
+	seedMCPServerWithIdentifier(t, stores, "weather", "ghcr.io/example/weather:1.0.0")
+	revisions, err := stores[v1alpha1.KindMCPServer].ListRevisions(ctx, "default", "weather", "")
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	pinned := revisions[0].Digest
+
+	deployment := &v1alpha1.Deployment{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "weather-pinned"},
+		Spec: v1alpha1.DeploymentSpec{
+			TargetRef:    v1alpha1.ResourceRef{Kind: v1alpha1.KindMCPServer, Name: "weather", Tag: v1alpha1store.DefaultTag()},
+			TargetDigest: pinned,
+			RuntimeRef:   v1alpha1.ResourceRef{Kind: v1alpha1.KindRuntime, Name: "kubernetes-default"},
+			DesiredState: v1alpha1.DesiredStateDeployed,
+		},
+	}
+	_, err = stores[v1alpha1.KindDeployment].Upsert(ctx, deployment, v1alpha1store.UpsertOpts{
+		InitialFinalizers: []string{DeploymentControllerFinalizer},
+	})
+	require.NoError(t, err)
+
+	adapter := &recordingDeploymentAdapter{}
+	controller := newDeploymentTestController(stores, adapter)
+	reconcile := func() {
+		t.Helper()
+		_, err := controller.FullReconcile(ctx)
+		require.NoError(t, err)
+		processed, err := controller.RunOnce(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, processed)
+	}
+	requireAppliedIdentifier := func(want string) {
+		t.Helper()
+		target, ok := adapter.lastTarget.Load().(*v1alpha1.MCPServer)
+		require.True(t, ok)
+		require.Equal(t, want, target.Spec.Source.Package.Origin.Identifier)
+	}
+	reconcile()
+	require.Equal(t, int32(1), adapter.applyCalls.Load())
+	requireAppliedIdentifier("ghcr.io/example/weather:1.0.0")
+
+	// Republishing the tag leaves the pinned Deployment alone.
+	seedMCPServerWithIdentifier(t, stores, "weather", "ghcr.io/example/weather:2.0.0")
+	reconcile()
+	require.Equal(t, int32(1), adapter.applyCalls.Load())
+
+	// Forcing a re-apply still deploys the pinned revision, not the tag.
+	require.NoError(t, stores[v1alpha1.KindDeployment].PatchAnnotations(ctx, "default", "weather-pinned", "", func(current map[string]string) map[string]string {
+		current[DeploymentForceAnnotation] = "bump-1"
+		return current
+	}))
+	reconcile()
+	require.Equal(t, int32(2), adapter.applyCalls.Load())
+	requireAppliedIdentifier("ghcr.io/example/weather:1.0.0")
+
+	// A digest the tag never held blocks the Deployment.
+	deployment.Spec.TargetDigest = v1alpha1store.ContentDigest(strings.Repeat("0", 64))
+	_, err = stores[v1alpha1.KindDeployment].Upsert(ctx, deployment)
+	require.NoError(t, err)
+	reconcile()
+	require.Equal(t, int32(2), adapter.applyCalls.Load())
+	ready := loadDeployment(t, stores, "weather-pinned").Status.GetCondition("Ready")
+	require.NotNil(t, ready)
+	require.Equal(t, "ReferencePending", ready.Reason)
+}
+
 func TestDeploymentController_DeleteWaitsForRemoveThenPurgesFinalizedRow(t *testing.T) {
 	ctx := context.Background()
 	stores := newControllerTestStores(t)
@@ -442,9 +691,14 @@ func seedMCPServer(t *testing.T, stores map[string]*v1alpha1store.Store, name st
 }
 
 func seedMCPServerWithIdentifier(t *testing.T, stores map[string]*v1alpha1store.Store, name, identifier string) {
+	t.Helper()
+	seedTaggedMCPServer(t, stores, name, "", identifier)
+}
+
+func seedTaggedMCPServer(t *testing.T, stores map[string]*v1alpha1store.Store, name, tag, identifier string) {
 	t.Helper()
 	_, err := stores[v1alpha1.KindMCPServer].Upsert(context.Background(), &v1alpha1.MCPServer{
-		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: name},
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: name, Tag: tag},
 		Spec: v1alpha1.MCPServerSpec{
 			Description: "test",
 			Source: &v1alpha1.MCPServerSource{
@@ -540,6 +794,7 @@ type recordingDeploymentAdapter struct {
 	applyCalls          atomic.Int32
 	removeCalls         atomic.Int32
 	lastApplyGeneration atomic.Int64
+	lastTarget          atomic.Value
 	applyErr            error
 	removeErr           error
 }
@@ -555,6 +810,9 @@ func (a *recordingDeploymentAdapter) Apply(_ context.Context, input types.ApplyI
 	if input.Deployment != nil {
 		a.lastApplyGeneration.Store(input.Deployment.Metadata.Generation)
 	}
+	if input.Target != nil {
+		a.lastTarget.Store(input.Target)
+	}
 	if a.applyErr != nil {
 		return nil, a.applyErr
 	}
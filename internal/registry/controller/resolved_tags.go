@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// ResolvedTagRef records the concrete tag a semver-range ref resolved to
+// during a Deployment apply. The controller persists these in the
+// Deployment's status and reuses them on later reconciles of the same
+// generation, so a newly published tag inside the range does not change an
+// already-applied Deployment. Editing the spec or setting the force
+// annotation resolves the ranges again.
+type ResolvedTagRef struct {
+	Kind        string `json:"kind"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name"`
+	Range       string `json:"range"`
+	ResolvedTag string `json:"resolvedTag"`
+}
+
+type tagRangeKey struct {
+	kind, namespace, name, rng string
+}
+
+// tagPinner wraps a GetterFunc so semver-range refs resolve to previously
+// recorded tags when available and records every range it resolves.
+type tagPinner struct {
+	getter v1alpha1.GetterFunc
+	pinned map[tagRangeKey]string
+
+	mu       sync.Mutex
+	resolved []ResolvedTagRef
+}
+
+func newTagPinner(getter v1alpha1.GetterFunc, pinned []ResolvedTagRef) *tagPinner {
+	p := &tagPinner{getter: getter, pinned: make(map[tagRangeKey]string, len(pinned))}
+	for _, ref := range pinned {
+		p.pinned[tagRangeKey{ref.Kind, ref.Namespace, ref.Name, ref.Range}] = ref.ResolvedTag
+	}
+	return p
+}
+
+func (p *tagPinner) Get(ctx context.Context, ref v1alpha1.ResourceRef) (v1alpha1.Object, error) {
+	if !v1alpha1.IsTagRange(ref.Tag) {
+		return p.getter(ctx, ref)
+	}
+	key := tagRangeKey{ref.Kind, ref.Namespace, ref.Name, ref.Tag}
+	if tag, ok := p.pinned[key]; ok {
+		pinnedRef := ref
+		pinnedRef.Tag = tag
+		obj, err := p.getter(ctx, pinnedRef)
+		if err == nil {
+			p.record(key, tag)
+			return obj, nil
+		}
+		// A pinned tag that has since been deleted falls back to resolving
+		// the range afresh.
+		if !errors.Is(err, v1alpha1.ErrDanglingRef) {
+			return nil, err
+		}
+	}
+	obj, err := p.getter(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if obj != nil && obj.GetMetadata() != nil {
+		p.record(key, obj.GetMetadata().Tag)
+	}
+	return obj, nil
+}
+
+func (p *tagPinner) record(key tagRangeKey, tag string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, ref := range p.resolved {
+		if (tagRangeKey{ref.Kind, ref.Namespace, ref.Name, ref.Range}) == key {
+			return
+		}
+	}
+	p.resolved = append(p.resolved, ResolvedTagRef{
+		Kind:        key.kind,
+		Namespace:   key.namespace,
+		Name:        key.name,
+		Range:       key.rng,
+		ResolvedTag: tag,
+	})
+}
+
+// Resolved returns the ranges resolved so far, in first-resolved order.
+func (p *tagPinner) Resolved() []ResolvedTagRef {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]ResolvedTagRef(nil), p.resolved...)
+}
+
+// deploymentTagPins returns the tags recorded by the last apply when they
+// still apply: the Deployment's generation and force token are unchanged.
+func deploymentTagPins(deployment *v1alpha1.Deployment, forceToken string) ([]ResolvedTagRef, error) {
+	var details deploymentControllerDetails
+	ok, err := deployment.Status.GetDetailsKey(deploymentControllerDetailsKey, &details)
+	if err != nil || !ok {
+		return nil, err
+	}
+	if details.ResolvedGeneration != deployment.Metadata.Generation || details.LastForceToken != forceToken {
+		return nil, nil
+	}
+	return details.ResolvedTags, nil
+}
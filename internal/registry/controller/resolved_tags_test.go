@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// fakeTagGetter serves MCPServers from a fixed tag set, resolving ranges the
+// way Store.GetByRef does.
+func fakeTagGetter(tags ...string) (v1alpha1.GetterFunc, *[]string) {
+	var requested []string
+	return func(_ context.Context, ref v1alpha1.ResourceRef) (v1alpha1.Object, error) {
+		requested = append(requested, ref.Tag)
+		tag := ref.Tag
+		if v1alpha1.IsTagRange(tag) {
+			resolved, ok := v1alpha1.HighestMatchingTag(tag, tags)
+			if !ok {
+				return nil, v1alpha1.ErrDanglingRef
+			}
+			tag = resolved
+		}
+		for _, candidate := range tags {
+			if candidate == tag {
+				return &v1alpha1.MCPServer{Metadata: v1alpha1.ObjectMeta{Namespace: ref.Namespace, Name: ref.Name, Tag: tag}}, nil
+			}
+		}
+		return nil, v1alpha1.ErrDanglingRef
+	}, &requested
+}
+
+func TestTagPinnerRecordsResolvedRanges(t *testing.T) {
+	getter, _ := fakeTagGetter("1.2.0", "1.3.0", "2.0.0")
+	pinner := newTagPinner(getter, nil)
+	ref := v1alpha1.ResourceRef{Kind: v1alpha1.KindMCPServer, Namespace: "default", Name: "weather", Tag: "^1.2"}
+
+	obj, err := pinner.Get(context.Background(), ref)
+	require.NoError(t, err)
+	require.Equal(t, "1.3.0", obj.GetMetadata().Tag)
+	_, err = pinner.Get(context.Background(), ref)
+	require.NoError(t, err)
+	_, err = pinner.Get(context.Background(), v1alpha1.ResourceRef{Kind: v1alpha1.KindMCPServer, Namespace: "default", Name: "weather", Tag: "2.0.0"})
+	require.NoError(t, err)
+
+	require.Equal(t, []ResolvedTagRef{{
+		Kind: v1alpha1.KindMCPServer, Namespace: "default", Name: "weather", Range: "^1.2", ResolvedTag: "1.3.0",
+	}}, pinner.Resolved(), "literal tags are not recorded and repeats are deduplicated")
+}
+
+func TestTagPinnerReusesPinnedTag(t *testing.T) {
+	getter, requested := fakeTagGetter("1.2.0", "1.3.0")
+	pins := []ResolvedTagRef{{Kind: v1alpha1.KindMCPServer, Namespace: "default", Name: "weather", Range: "^1.2", ResolvedTag: "1.2.0"}}
+	pinner := newTagPinner(getter, pins)
+
+	obj, err := pinner.Get(context.Background(), v1alpha1.ResourceRef{Kind: v1alpha1.KindMCPServer, Namespace: "default", Name: "weather", Tag: "^1.2"})
+	require.NoError(t, err)
+	require.Equal(t, "1.2.0", obj.GetMetadata().Tag)
+	require.Equal(t, []string{"1.2.0"}, *requested)
+	require.Equal(t, pins, pinner.Resolved())
+}
+
+func TestTagPinnerReresolvesDeletedPin(t *testing.T) {
+	getter, _ := fakeTagGetter("1.3.0")
+	pinner := newTagPinner(getter, []ResolvedTagRef{{Kind: v1alpha1.KindMCPServer, Namespace: "default", Name: "weather", Range: "^1.2", ResolvedTag: "1.2.0"}})
+
+	obj, err := pinner.Get(context.Background(), v1alpha1.ResourceRef{Kind: v1alpha1.KindMCPServer, Namespace: "default", Name: "weather", Tag: "^1.2"})
+	require.NoError(t, err)
+	require.Equal(t, "1.3.0", obj.GetMetadata().Tag)
+	require.Equal(t, "1.3.0", pinner.Resolved()[0].ResolvedTag)
+}
+
+func TestDeploymentTagPinsExpireWithGenerationOrForceToken(t *testing.T) {
+	deployment := &v1alpha1.Deployment{Metadata: v1alpha1.ObjectMeta{Generation: 2}}
+	pins := []ResolvedTagRef{{Kind: v1alpha1.KindMCPServer, Name: "weather", Range: "^1", ResolvedTag: "1.0.0"}}
+	require.NoError(t, deployment.Status.SetDetailsKey(deploymentControllerDetailsKey, deploymentControllerDetails{
+		LastForceToken:     "t1",
+		ResolvedTags:       pins,
+		ResolvedGeneration: 2,
+	}))
+
+	got, err := deploymentTagPins(deployment, "t1")
+	require.NoError(t, err)
+	require.Equal(t, pins, got)
+
+	got, err = deploymentTagPins(deployment, "t2")
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	deployment.Metadata.Generation = 3
+	got, err = deploymentTagPins(deployment, "t1")
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
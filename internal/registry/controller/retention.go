@@ -10,16 +10,17 @@ import (
 const defaultRetentionPruneInterval = time.Hour
 
 // RetentionPolicy is the bounded-history contract for the controller event
-// replay log. Durations <= 0 disable pruning.
+// replay log and the audit log. Durations <= 0 disable pruning.
 type RetentionPolicy struct {
 	ControlPlaneEvents time.Duration
 	EventKeepAfterRev  int64
+	AuditEvents        time.Duration
 	BatchLimit         int
 }
 
-// Enabled reports whether the policy prunes the controller event log.
+// Enabled reports whether the policy prunes either log.
 func (p RetentionPolicy) Enabled() bool {
-	return p.ControlPlaneEvents > 0
+	return p.ControlPlaneEvents > 0 || p.AuditEvents > 0
 }
 
 // PruneStores groups the store surfaces needed by RunRetentionPrune. Keeping
@@ -29,16 +30,20 @@ type PruneStores struct {
 	ControlPlaneEvents interface {
 		PruneBefore(ctx context.Context, before time.Time, keepAfterRevision int64, limit int) (int64, error)
 	}
+	AuditEvents interface {
+		PruneBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	}
 }
 
 // RetentionPruneResult reports how many event rows were removed in one
 // maintenance pass.
 type RetentionPruneResult struct {
 	ControlPlaneEvents int64
+	AuditEvents        int64
 }
 
 // RetentionPruner owns the periodic maintenance loop for controller event
-// replay rows.
+// replay rows and audit log entries.
 type RetentionPruner struct {
 	Stores PruneStores
 	Policy RetentionPolicy
@@ -96,13 +101,15 @@ func (p *RetentionPruner) runOnceLogged(ctx context.Context) {
 		logger.Info(
 			"deployment controller retention pruned bookkeeping rows",
 			"control_plane_events", result.ControlPlaneEvents,
+			"audit_events", result.AuditEvents,
 		)
 	}
 }
 
-// RunRetentionPrune applies a RetentionPolicy to the controller event log.
-// Canonical resource tables remain the source of truth, so controllers can
-// full-reconcile if their checkpoint falls behind the retained event range.
+// RunRetentionPrune applies a RetentionPolicy to the controller event log and
+// the audit log. Canonical resource tables remain the source of truth, so
+// controllers can full-reconcile if their checkpoint falls behind the
+// retained event range.
 func RunRetentionPrune(ctx context.Context, stores PruneStores, policy RetentionPolicy, now time.Time) (RetentionPruneResult, error) {
 	if now.IsZero() {
 		now = time.Now().UTC()
@@ -118,6 +125,11 @@ func RunRetentionPrune(ctx context.Context, stores PruneStores, policy Retention
 		result.ControlPlaneEvents = n
 		errs = errors.Join(errs, wrapRetentionErr("prune control-plane events", err))
 	}
+	if stores.AuditEvents != nil && policy.AuditEvents > 0 {
+		n, err := stores.AuditEvents.PruneBefore(ctx, now.Add(-policy.AuditEvents), limit)
+		result.AuditEvents = n
+		errs = errors.Join(errs, wrapRetentionErr("prune audit events", err))
+	}
 	return result, errs
 }
 
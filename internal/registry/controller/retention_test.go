@@ -31,6 +31,33 @@ func TestRunRetentionPruneAppliesConfiguredEventCutoff(t *testing.T) {
 	}
 }
 
+func TestRunRetentionPruneAppliesAuditCutoff(t *testing.T) {
+	now := time.Date(2026, 5, 21, 12, 0, 0, 0, time.UTC)
+	events := &fakeEventPruner{}
+	audit := &fakeAuditPruner{deleted: 3}
+
+	result, err := RunRetentionPrune(context.Background(), PruneStores{
+		ControlPlaneEvents: events,
+		AuditEvents:        audit,
+	}, RetentionPolicy{
+		AuditEvents: 90 * 24 * time.Hour,
+		BatchLimit:  17,
+	}, now)
+	if err != nil {
+		t.Fatalf("RunRetentionPrune returned error: %v", err)
+	}
+
+	if result != (RetentionPruneResult{AuditEvents: 3}) {
+		t.Fatalf("result = %+v, want audit deleted count 3", result)
+	}
+	if audit.before != now.Add(-90*24*time.Hour) || audit.limit != 17 {
+		t.Fatalf("audit prune args = before %s limit %d", audit.before, audit.limit)
+	}
+	if events.called {
+		t.Fatal("event pruner was called for disabled event retention")
+	}
+}
+
 func TestRunRetentionPruneSkipsDisabledPolicies(t *testing.T) {
 	events := &fakeEventPruner{}
 
@@ -71,6 +98,7 @@ func TestRetentionPolicyEnabled(t *testing.T) {
 	}{
 		{name: "empty", policy: RetentionPolicy{}, want: false},
 		{name: "events", policy: RetentionPolicy{ControlPlaneEvents: time.Hour}, want: true},
+		{name: "audit", policy: RetentionPolicy{AuditEvents: time.Hour}, want: true},
 		{name: "revision bound alone does not enable age pruning", policy: RetentionPolicy{EventKeepAfterRev: 42}, want: false},
 	}
 
@@ -124,3 +152,15 @@ func (f *fakeEventPruner) PruneBefore(_ context.Context, before time.Time, keepA
 	f.limit = limit
 	return f.deleted, f.err
 }
+
+type fakeAuditPruner struct {
+	before  time.Time
+	limit   int
+	deleted int64
+}
+
+func (f *fakeAuditPruner) PruneBefore(_ context.Context, before time.Time, limit int) (int64, error) {
+	f.before = before
+	f.limit = limit
+	return f.deleted, nil
+}
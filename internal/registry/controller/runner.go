@@ -4,13 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
-	"github.com/jackc/pgx/v5/pgxpool"
-
 	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
 	"github.com/agentregistry-dev/agentregistry/pkg/logging"
 	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
 	"github.com/agentregistry-dev/agentregistry/pkg/types"
 )
@@ -32,6 +32,16 @@ type ControllerHandle struct {
 	Controller *DeploymentController
 	Discovery  *DeploymentDiscoveryController
 	Retention  *RetentionPruner
+
+	loops sync.WaitGroup
+}
+
+// Wait blocks until every loop started by StartDeploymentController has
+// returned, which happens once its context is cancelled.
+func (h *ControllerHandle) Wait() {
+	if h != nil {
+		h.loops.Wait()
+	}
 }
 
 // ControllerConfig controls optional controller maintenance loops.
@@ -41,6 +51,24 @@ type ControllerConfig struct {
 	DiscoveryStaleAfterMisses  int
 	DiscoveryDeleteAfterMisses int
 	DependencyKinds            map[string]bool
+	// Plugins and Skills configure the source-resolving controllers
+	// started by StartControllers.
+	Plugins PluginControllerDeps
+	Skills  SkillControllerDeps
+	// MCPSync mirrors an upstream MCP registry; disabled without a URL.
+	MCPSync MCPSyncConfig
+	// MCPSyncApply is the apply pipeline mirrored servers are written and
+	// deleted through: its tag, signature and approval policies, admission
+	// hooks and referrer check hold for the mirror as they do for an apply.
+	MCPSyncApply resource.ApplyConfig
+	// TagRetention prunes artifact tags selected by retention policies.
+	TagRetention TagRetentionConfig
+	// VerifySignature is handed to the Deployment controller; see
+	// DeploymentController.VerifySignature.
+	VerifySignature resource.SignatureVerifier
+	// AuditEvents is the audit log the retention pruner trims; nil leaves
+	// it untrimmed.
+	AuditEvents *v1alpha1store.AuditStore
 }
 
 // StartDeploymentController constructs the Deployment controller, runs the
@@ -48,30 +76,31 @@ type ControllerConfig struct {
 // background. The returned handle is useful in tests and future health wiring.
 func StartDeploymentController(
 	ctx context.Context,
-	pool *pgxpool.Pool,
+	db v1alpha1store.DB,
 	stores map[string]*v1alpha1store.Store,
 	adapters map[string]types.DeploymentAdapter,
 	config ControllerConfig,
 ) (*ControllerHandle, error) {
-	if pool == nil {
+	if db == nil {
 		return nil, nil
 	}
 	if len(stores) == 0 {
 		return nil, errors.New("deployment controller: stores are required")
 	}
 
-	controlPlaneEventStore := v1alpha1store.NewControlPlaneEventStore(pool, pkgdb.MustNewSchema(pkgdb.OSSSchema))
+	controlPlaneEventStore := v1alpha1store.NewControlPlaneEventStore(db, pkgdb.MustNewSchema(pkgdb.OSSSchema))
 	controller := &DeploymentController{
 		Stores:          stores,
 		Adapters:        adapters,
 		Getter:          internaldb.NewGetter(stores),
 		Events:          controlPlaneEventStore,
 		DependencyKinds: config.DependencyKinds,
+		VerifySignature: config.VerifySignature,
 	}
 	if _, err := controller.Refresh(ctx); err != nil {
 		return nil, fmt.Errorf("deployment controller initial refresh: %w", err)
 	}
-	controller.Wakeups = controlPlaneWakeups(ctx, pool)
+	controller.Wakeups = controlPlaneWakeups(ctx, db)
 	discovery := &DeploymentDiscoveryController{
 		Stores:            stores,
 		Adapters:          adapters,
@@ -80,37 +109,125 @@ func StartDeploymentController(
 	}
 
 	retention := &RetentionPruner{
-		Stores: PruneStores{
-			ControlPlaneEvents: controlPlaneEventStore,
-		},
+		Stores: PruneStores{ControlPlaneEvents: controlPlaneEventStore},
 		Policy: config.Retention,
 	}
+	if config.AuditEvents != nil {
+		retention.Stores.AuditEvents = config.AuditEvents
+	}
 	handle := &ControllerHandle{Controller: controller, Discovery: discovery, Retention: retention}
 
-	go func() {
+	handle.loops.Go(func() {
 		if err := controller.Run(ctx, defaultControllerResyncInterval); err != nil && !errors.Is(err, context.Canceled) {
 			logger.Error("deployment controller stopped", "error", err)
 		}
-	}()
-	go func() {
+	})
+	handle.loops.Go(func() {
 		if err := discovery.Run(ctx, config.DiscoveryInterval); err != nil && !errors.Is(err, context.Canceled) {
 			logger.Error("deployment discovery controller stopped", "error", err)
 		}
-	}()
+	})
 	if retention.Enabled() {
-		go func() {
+		handle.loops.Go(func() {
 			if err := retention.Run(ctx, defaultRetentionPruneInterval); err != nil && !errors.Is(err, context.Canceled) {
 				logger.Error("deployment controller retention pruner stopped", "error", err)
 			}
-		}()
+		})
 	}
 	return handle, nil
 }
 
-func controlPlaneWakeups(ctx context.Context, pool *pgxpool.Pool) <-chan struct{} {
+// StartControllers starts the full controller set — Deployment, discovery,
+// retention, tag retention, Plugin, Skill and, when configured, the upstream
+// MCP sync. The returned stop cancels them and waits for every loop to exit.
+// On error, controllers already started are stopped.
+func StartControllers(
+	ctx context.Context,
+	db v1alpha1store.DB,
+	stores map[string]*v1alpha1store.Store,
+	adapters map[string]types.DeploymentAdapter,
+	config ControllerConfig,
+) (stop func(), err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	var stops []func()
+	stop = func() {
+		cancel()
+		for i := len(stops) - 1; i >= 0; i-- {
+			stops[i]()
+		}
+	}
+	defer func() {
+		if err != nil {
+			stop()
+		}
+	}()
+
+	handle, err := StartDeploymentController(ctx, db, stores, adapters, config)
+	if err != nil {
+		return nil, fmt.Errorf("start deployment controller: %w", err)
+	}
+	stops = append(stops, handle.Wait)
+
+	// The Plugin controller resolves each plugin's pinned source pointer to a
+	// concrete commit/digest and records the manifest/inventory in PluginStatus
+	// out of band of the API write — same pattern as the Deployment controller.
+	pluginController, err := NewPluginController(db, stores, config.Plugins)
+	if err != nil {
+		return nil, fmt.Errorf("create plugin controller: %w", err)
+	}
+	if pluginController != nil {
+		if err := pluginController.Start(ctx); err != nil {
+			return nil, fmt.Errorf("start plugin controller: %w", err)
+		}
+		stops = append(stops, pluginController.Stop)
+	}
+	// The Skill controller resolves each skill's pinned git source ref to a
+	// concrete commit and records it in SkillStatus out of band of the API write
+	// — the resolve-and-pin counterpart to the Plugin controller, minus the
+	// manifest/inventory scan (a skill has no bundle to enumerate).
+	skillController, err := NewSkillController(db, stores, config.Skills)
+	if err != nil {
+		return nil, fmt.Errorf("create skill controller: %w", err)
+	}
+	if skillController != nil {
+		if err := skillController.Start(ctx); err != nil {
+			return nil, fmt.Errorf("start skill controller: %w", err)
+		}
+		stops = append(stops, skillController.Stop)
+	}
+	mcpSync, err := NewMCPSyncController(db, stores, config.MCPSync, config.MCPSyncApply)
+	if err != nil {
+		return nil, fmt.Errorf("create mcp sync controller: %w", err)
+	}
+	if mcpSync != nil {
+		var loop sync.WaitGroup
+		loop.Go(func() {
+			if err := mcpSync.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				logger.Error("mcp sync controller stopped", "error", err)
+			}
+		})
+		stops = append(stops, loop.Wait)
+	}
+	tagRetention, err := NewTagRetentionController(db, stores, config.TagRetention)
+	if err != nil {
+		return nil, fmt.Errorf("create tag retention controller: %w", err)
+	}
+	if tagRetention != nil {
+		var loop sync.WaitGroup
+		loop.Go(func() {
+			if err := tagRetention.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				logger.Error("tag retention controller stopped", "error", err)
+			}
+		})
+		stops = append(stops, loop.Wait)
+	}
+	return stop, nil
+}
+
+func controlPlaneWakeups(ctx context.Context, db v1alpha1store.DB) <-chan struct{} {
 	ch := make(chan struct{}, 1)
 	go runControlPlaneWakeupLoop(ctx, ch, func(ctx context.Context, wakeups chan<- struct{}) error {
-		return listenForControlPlaneWakeups(ctx, pool, wakeups)
+		return listenForControlPlaneWakeups(ctx, db, wakeups)
 	}, defaultWakeupReconnectDelay)
 	return ch
 }
@@ -130,24 +247,13 @@ func runControlPlaneWakeupLoop(ctx context.Context, wakeups chan<- struct{}, lis
 	}
 }
 
-func listenForControlPlaneWakeups(ctx context.Context, pool *pgxpool.Pool, wakeups chan<- struct{}) error {
-	conn, err := pool.Acquire(ctx)
-	if err != nil {
-		return fmt.Errorf("acquire LISTEN connection: %w", err)
-	}
-	defer conn.Release()
-	if _, err := conn.Exec(ctx, "LISTEN "+v1alpha1store.ControlPlaneNotifyChannel); err != nil {
-		return fmt.Errorf("listen for control-plane changes: %w", err)
-	}
-	for {
-		if _, err := conn.Conn().WaitForNotification(ctx); err != nil {
-			return fmt.Errorf("wait for control-plane notification: %w", err)
-		}
+func listenForControlPlaneWakeups(ctx context.Context, db v1alpha1store.DB, wakeups chan<- struct{}) error {
+	return v1alpha1store.ListenForControlPlaneChanges(ctx, db, nil, func() {
 		select {
 		case wakeups <- struct{}{}:
 		default:
 		}
-	}
+	})
 }
 
 func waitForReconnect(ctx context.Context, delay time.Duration) bool {
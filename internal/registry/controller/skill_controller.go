@@ -8,7 +8,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/jackc/pgx/v5/pgxpool"
 	"k8s.io/client-go/util/workqueue"
 
 	"github.com/agentregistry-dev/agentregistry/internal/cli/common/gitutil"
@@ -24,24 +23,29 @@ type SkillResolveFunc func(ctx context.Context, repo *v1alpha1.Repository) (comm
 
 // SkillControllerDeps are the Skill controller's dependencies. Resolve pins a
 // skill's git source ref to a commit; it defaults to a git ls-remote resolver
-// when nil.
+// when nil. AllowedGitHosts restricts that default resolver to the listed git
+// hosts (empty allows github.com); it is ignored when Resolve is set.
 type SkillControllerDeps struct {
-	Resolve SkillResolveFunc
+	Resolve         SkillResolveFunc
+	AllowedGitHosts []string
 }
 
-// defaultSkillResolve pins a skill's git source by resolving its ref (an
-// explicit commit, else the branch, else the repo/HEAD default) to a concrete
-// commit SHA via ls-remote. It is intentionally lighter than the Plugin
-// resolver: a skill has no manifest/inventory to scan, so the controller only
-// needs the pin. Materialization (the actual checkout) happens at deploy time.
-func defaultSkillResolve(ctx context.Context, repo *v1alpha1.Repository) (string, error) {
-	ref := repo.Commit
-	if ref == "" {
-		ref = repo.Branch
+// newDefaultSkillResolve returns a resolver that pins a skill's git source by
+// resolving its ref (an explicit commit, else the branch, else the repo/HEAD
+// default) to a concrete commit SHA via ls-remote. It is intentionally lighter
+// than the Plugin resolver: a skill has no manifest/inventory to scan, so the
+// controller only needs the pin. Materialization (the actual checkout) happens
+// at deploy time.
+func newDefaultSkillResolve(allowedHosts []string) SkillResolveFunc {
+	return func(ctx context.Context, repo *v1alpha1.Repository) (string, error) {
+		ref := repo.Commit
+		if ref == "" {
+			ref = repo.Branch
+		}
+		ctx, cancel := context.WithTimeout(ctx, skillResolveTimeout)
+		defer cancel()
+		return gitutil.ResolveRefContext(ctx, repo.URL, ref, gitutil.WithAllowedHosts(allowedHosts))
 	}
-	ctx, cancel := context.WithTimeout(ctx, skillResolveTimeout)
-	defer cancel()
-	return gitutil.ResolveRefContext(ctx, repo.URL, ref)
 }
 
 const skillResolveTimeout = 2 * time.Minute
@@ -78,7 +82,7 @@ type SkillController struct {
 	Resolve SkillResolveFunc
 	Wakeups <-chan struct{}
 
-	pool   *pgxpool.Pool
+	db     v1alpha1store.DB
 	resync time.Duration
 
 	lifecycleMu sync.Mutex
@@ -92,11 +96,11 @@ type SkillController struct {
 // NewSkillController wires the Skill controller without starting it. Start owns
 // the background goroutine and control-plane LISTEN subscription.
 func NewSkillController(
-	pool *pgxpool.Pool,
+	db v1alpha1store.DB,
 	stores map[string]*v1alpha1store.Store,
 	deps SkillControllerDeps,
 ) (*SkillController, error) {
-	if pool == nil {
+	if db == nil {
 		return nil, nil
 	}
 	store := stores[v1alpha1.KindSkill]
@@ -105,12 +109,12 @@ func NewSkillController(
 	}
 	resolve := deps.Resolve
 	if resolve == nil {
-		resolve = defaultSkillResolve
+		resolve = newDefaultSkillResolve(deps.AllowedGitHosts)
 	}
 	return &SkillController{
 		Store:   store,
 		Resolve: resolve,
-		pool:    pool,
+		db:      db,
 		resync:  defaultControllerResyncInterval,
 	}, nil
 }
@@ -122,7 +126,7 @@ func (c *SkillController) Start(ctx context.Context) error {
 		return errors.New("skill controller: Skill store is required")
 	}
 	if c.Resolve == nil {
-		c.Resolve = defaultSkillResolve
+		c.Resolve = newDefaultSkillResolve(nil)
 	}
 	c.lifecycleMu.Lock()
 	defer c.lifecycleMu.Unlock()
@@ -132,8 +136,8 @@ func (c *SkillController) Start(ctx context.Context) error {
 	runCtx, cancel := context.WithCancel(ctx)
 	c.cancel = cancel
 	c.done = make(chan struct{})
-	if c.pool != nil {
-		c.Wakeups = controlPlaneWakeups(runCtx, c.pool)
+	if c.db != nil {
+		c.Wakeups = controlPlaneWakeups(runCtx, c.db)
 	}
 	resync := c.resync
 	if resync == 0 {
@@ -186,7 +190,7 @@ func (c *SkillController) Run(ctx context.Context, resync time.Duration) error {
 		return errors.New("skill controller: Skill store is required")
 	}
 	if c.Resolve == nil {
-		c.Resolve = defaultSkillResolve
+		c.Resolve = newDefaultSkillResolve(nil)
 	}
 	queue := c.workQueue()
 	defer queue.ShutDown()
@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/retention"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+const defaultTagRetentionInterval = time.Hour
+
+// TagRetentionConfig configures tag retention. The server policy may be
+// empty: a Namespace's spec.tagRetention still applies in its namespace.
+type TagRetentionConfig struct {
+	Policy v1alpha1.TagRetentionPolicy
+	// TagPolicy is the server tag immutability policy; pruned tags it or a
+	// Namespace policy protects cannot be re-created with new content.
+	TagPolicy v1alpha1.TagPolicy
+	Interval  time.Duration
+}
+
+// TagRetentionController periodically prunes artifact tags selected by the
+// tag retention policies, and counts what it prunes and holds back.
+type TagRetentionController struct {
+	Pruner interface {
+		Prune(ctx context.Context) (*arv0.TagRetentionReport, error)
+	}
+	Interval time.Duration
+
+	pruned metric.Int64Counter
+	held   metric.Int64Counter
+}
+
+// NewTagRetentionController wires the tag retention controller. It returns
+// nil when there is no database.
+func NewTagRetentionController(
+	db v1alpha1store.DB,
+	stores map[string]*v1alpha1store.Store,
+	config TagRetentionConfig,
+) (*TagRetentionController, error) {
+	if db == nil {
+		return nil, nil
+	}
+	c := &TagRetentionController{
+		Pruner: &retention.Pruner{
+			Stores:    stores,
+			Policy:    config.Policy,
+			Referrers: resource.NewReferrerLookup(stores),
+			TagPolicy: resource.NewTagPolicyLookup(stores, config.TagPolicy),
+		},
+		Interval: config.Interval,
+	}
+	if err := c.initMetrics(otel.Meter(telemetry.Namespace)); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *TagRetentionController) initMetrics(meter metric.Meter) error {
+	var err error
+	c.pruned, err = meter.Int64Counter(telemetry.Namespace+".tag_retention.pruned",
+		metric.WithDescription("Artifact tags deleted by tag retention, by kind and reason"))
+	if err != nil {
+		return fmt.Errorf("create tag retention pruned counter: %w", err)
+	}
+	c.held, err = meter.Int64Counter(telemetry.Namespace+".tag_retention.held",
+		metric.WithDescription("Artifact tags selected by tag retention but kept because live objects reference them, counted once per pass"))
+	if err != nil {
+		return fmt.Errorf("create tag retention held counter: %w", err)
+	}
+	return nil
+}
+
+// Run prunes every Interval until ctx is cancelled.
+func (c *TagRetentionController) Run(ctx context.Context) error {
+	if c == nil {
+		return errors.New("tag retention controller: controller is required")
+	}
+	interval := c.Interval
+	if interval <= 0 {
+		interval = defaultTagRetentionInterval
+	}
+	for {
+		report, err := c.RunOnce(ctx)
+		switch {
+		case errors.Is(err, context.Canceled):
+			return err
+		case err != nil:
+			logger.Error("tag retention failed", "error", err)
+		}
+		if report != nil && (len(report.Prune) > 0 || len(report.Held) > 0) {
+			logger.Info("tag retention pruned tags", "pruned", len(report.Prune), "held", len(report.Held))
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// RunOnce runs one pruning pass and records its counters. The report is
+// returned alongside any delete errors, covering the tags that were
+// deleted.
+func (c *TagRetentionController) RunOnce(ctx context.Context) (*arv0.TagRetentionReport, error) {
+	if c == nil || c.Pruner == nil {
+		return nil, errors.New("tag retention controller: pruner is required")
+	}
+	report, err := c.Pruner.Prune(ctx)
+	if report == nil {
+		return nil, err
+	}
+	for _, item := range report.Prune {
+		logger.Info("tag retention pruned tag", "kind", item.Kind, "namespace", item.Namespace,
+			"name", item.Name, "tag", item.Tag, "reason", item.Reason)
+		if c.pruned != nil {
+			c.pruned.Add(ctx, 1, metric.WithAttributes(attribute.String("kind", item.Kind), attribute.String("reason", item.Reason)))
+		}
+	}
+	for _, item := range report.Held {
+		if c.held != nil {
+			c.held.Add(ctx, 1, metric.WithAttributes(attribute.String("kind", item.Kind), attribute.String("reason", item.Reason)))
+		}
+	}
+	return report, err
+}
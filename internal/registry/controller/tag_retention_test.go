@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+)
+
+type fakeTagPruner struct {
+	report *arv0.TagRetentionReport
+	err    error
+}
+
+func (p fakeTagPruner) Prune(context.Context) (*arv0.TagRetentionReport, error) {
+	return p.report, p.err
+}
+
+// counterTotals sums each counter's data points by kind/reason.
+func counterTotals(t *testing.T, reader *sdkmetric.ManualReader) map[string]map[string]int64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	out := map[string]map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				continue
+			}
+			out[m.Name] = map[string]int64{}
+			for _, dp := range sum.DataPoints {
+				kind, _ := dp.Attributes.Value("kind")
+				reason, _ := dp.Attributes.Value("reason")
+				out[m.Name][kind.AsString()+"/"+reason.AsString()] += dp.Value
+			}
+		}
+	}
+	return out
+}
+
+func TestTagRetentionControllerRunOnceCounts(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	c := &TagRetentionController{Pruner: fakeTagPruner{
+		report: &arv0.TagRetentionReport{
+			Prune: []arv0.TagRetentionItem{
+				{Kind: "MCPServer", Name: "acme-fetch", Tag: "1.0.0", Reason: arv0.TagRetentionReasonKeepLast},
+				{Kind: "MCPServer", Name: "acme-fetch", Tag: "1.1.0", Reason: arv0.TagRetentionReasonKeepLast},
+				{Kind: "Skill", Name: "summarize", Tag: "dev-4f2a", Reason: arv0.TagRetentionReasonMaxAge},
+			},
+			Held: []arv0.TagRetentionItem{
+				{Kind: "MCPServer", Name: "acme-fetch", Tag: "1.2.0", Reason: arv0.TagRetentionReasonKeepLast, ReferencedBy: []string{"Agent default/support@1.0.0"}},
+			},
+		},
+		err: errors.New("delete Skill default/old@0.1.0: boom"),
+	}}
+	if err := c.initMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test")); err != nil {
+		t.Fatalf("initMetrics: %v", err)
+	}
+
+	report, err := c.RunOnce(context.Background())
+	if err == nil || report == nil {
+		t.Fatalf("RunOnce = %v, %v; want the partial report and the delete error", report, err)
+	}
+
+	got := counterTotals(t, reader)
+	if p := got["agent_registry.tag_retention.pruned"]; p["MCPServer/KeepLast"] != 2 || p["Skill/MaxAge"] != 1 {
+		t.Fatalf("pruned counter = %v", p)
+	}
+	if h := got["agent_registry.tag_retention.held"]; h["MCPServer/KeepLast"] != 1 {
+		t.Fatalf("held counter = %v", h)
+	}
+}
+
+func TestTagRetentionControllerRequiresPruner(t *testing.T) {
+	if _, err := (&TagRetentionController{}).RunOnce(context.Background()); err == nil {
+		t.Fatal("RunOnce without a pruner succeeded")
+	}
+}
@@ -16,19 +16,19 @@ import (
 //
 // Dangling references return v1alpha1.ErrDanglingRef so callers can
 // distinguish "row missing" from "database unavailable"; unknown
-// kinds return wrapped v1alpha1.ErrInvalidRef.
+// kinds return wrapped v1alpha1.ErrInvalidRef. Tags whose content awaits
+// review under a namespace approval policy, or was rejected, dangle too, as
+// does a yanked "latest" and a range matching only yanked tags (see
+// Store.GetUsableByRef).
 func NewResolver(stores map[string]*v1alpha1store.Store) v1alpha1.ResolverFunc {
 	return func(ctx context.Context, ref v1alpha1.ResourceRef) error {
 		store, ok := stores[ref.Kind]
 		if !ok {
 			return fmt.Errorf("%w: unknown kind %q", v1alpha1.ErrInvalidRef, ref.Kind)
 		}
-		_, err := store.GetByRef(ctx, ref.Namespace, ref.Name, ref.Tag)
+		_, err := store.GetUsableByRef(ctx, ref.Namespace, ref.Name, ref.Tag)
 		if err != nil {
-			if errors.Is(err, pkgdb.ErrNotFound) {
-				return v1alpha1.ErrDanglingRef
-			}
-			return err
+			return danglingRef(err)
 		}
 		return nil
 	}
@@ -40,20 +40,18 @@ func NewResolver(stores map[string]*v1alpha1store.Store) v1alpha1.ResolverFunc {
 // Consumers: reconcilers / runtime adapters that need the referenced
 // object's Spec (not just an existence check).
 //
-// Dangling references return v1alpha1.ErrDanglingRef; unknown kinds
-// return wrapped v1alpha1.ErrInvalidRef.
+// Dangling references, including refs to unapproved tags and refs only
+// yanked tags satisfy, return v1alpha1.ErrDanglingRef; unknown kinds return
+// wrapped v1alpha1.ErrInvalidRef.
 func NewGetter(stores map[string]*v1alpha1store.Store) v1alpha1.GetterFunc {
 	return func(ctx context.Context, ref v1alpha1.ResourceRef) (v1alpha1.Object, error) {
 		store, ok := stores[ref.Kind]
 		if !ok {
 			return nil, fmt.Errorf("%w: unknown kind %q", v1alpha1.ErrInvalidRef, ref.Kind)
 		}
-		raw, err := store.GetByRef(ctx, ref.Namespace, ref.Name, ref.Tag)
+		raw, err := store.GetUsableByRef(ctx, ref.Namespace, ref.Name, ref.Tag)
 		if err != nil {
-			if errors.Is(err, pkgdb.ErrNotFound) {
-				return nil, v1alpha1.ErrDanglingRef
-			}
-			return nil, err
+			return nil, danglingRef(err)
 		}
 		_, newObj, ok := v1alpha1.Default.Lookup(ref.Kind)
 		if !ok {
@@ -82,3 +80,17 @@ func NewGetter(stores map[string]*v1alpha1store.Store) v1alpha1.GetterFunc {
 		return obj, nil
 	}
 }
+
+// danglingRef maps a missing row to v1alpha1.ErrDanglingRef, and an
+// unapproved or yanked tag to an error wrapping both it and
+// v1alpha1store.ErrUnapproved or ErrYanked, so the reason survives into
+// conditions.
+func danglingRef(err error) error {
+	switch {
+	case errors.Is(err, v1alpha1store.ErrUnapproved), errors.Is(err, v1alpha1store.ErrYanked):
+		return fmt.Errorf("%w: %w", v1alpha1.ErrDanglingRef, err)
+	case errors.Is(err, pkgdb.ErrNotFound):
+		return v1alpha1.ErrDanglingRef
+	}
+	return err
+}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// SQLite is the root store of the embedded single-binary mode. It owns
+// one SQLite database file holding the whole v1alpha1 schema; per-kind
+// access happens via NewStores against db.DB().
+type SQLite struct {
+	db *v1alpha1store.SQLite
+}
+
+// NewSQLite opens (creating if needed) the SQLite database at path and
+// applies the embedded SQLite schema. The schema is always brought up to
+// date; SkipMigrations only governs the PostgreSQL migrator.
+func NewSQLite(ctx context.Context, path string) (*SQLite, error) {
+	db, err := v1alpha1store.OpenSQLite(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SQLite database %s: %w", path, err)
+	}
+	return &SQLite{db: db}, nil
+}
+
+// Pool returns nil: there is no PostgreSQL connection behind this store.
+func (db *SQLite) Pool() *pgxpool.Pool {
+	return nil
+}
+
+// DB exposes the database the v1alpha1 stores run on.
+func (db *SQLite) DB() *v1alpha1store.SQLite {
+	return db.db
+}
+
+// Close releases the database file.
+func (db *SQLite) Close() error {
+	return db.db.Close()
+}
@@ -0,0 +1,48 @@
+package registry
+
+import (
+	"context"
+	"maps"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+// registeredKinds lists every kind the app serves: the built-in kind
+// registry plus the downstream kinds declared through V1Alpha1StoreTables.
+func registeredKinds(options types.AppOptions) []string {
+	kinds := v1alpha1.DefaultKindRegistry.Kinds()
+	for kind := range options.V1Alpha1StoreTables {
+		kinds = append(kinds, kind)
+	}
+	return kinds
+}
+
+// withNamespaceAuthorizer folds options.NamespaceAuthorizer into the per-kind
+// Authorizers so every surface that consumes them (resource routes, batch
+// apply, the MCP bridge, compatibility endpoints) enforces the namespace gate
+// first. Kinds without a per-kind Authorizer get the namespace gate alone.
+func withNamespaceAuthorizer(options types.AppOptions) types.AppOptions {
+	gate := options.NamespaceAuthorizer
+	if gate == nil {
+		return options
+	}
+	authorizers := maps.Clone(options.Authorizers)
+	if authorizers == nil {
+		authorizers = map[string]types.Authorizer{}
+	}
+	for _, kind := range registeredKinds(options) {
+		next := authorizers[kind]
+		authorizers[kind] = func(ctx context.Context, in types.AuthorizeInput) error {
+			if err := gate(ctx, in); err != nil {
+				return err
+			}
+			if next == nil {
+				return nil
+			}
+			return next(ctx, in)
+		}
+	}
+	options.Authorizers = authorizers
+	return options
+}
@@ -0,0 +1,47 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+func TestWithNamespaceAuthorizer(t *testing.T) {
+	require.Nil(t, withNamespaceAuthorizer(types.AppOptions{}).Authorizers, "no gate leaves hooks untouched")
+
+	var calls []string
+	options := withNamespaceAuthorizer(types.AppOptions{
+		NamespaceAuthorizer: func(_ context.Context, in types.AuthorizeInput) error {
+			calls = append(calls, "namespace")
+			if in.Namespace != "team-a" {
+				return errors.New("outside team-a")
+			}
+			return nil
+		},
+		Authorizers: map[string]types.Authorizer{
+			v1alpha1.KindAgent: func(context.Context, types.AuthorizeInput) error {
+				calls = append(calls, "agent")
+				return nil
+			},
+		},
+		V1Alpha1StoreTables: map[string]string{"Widget": "widgets"},
+	})
+
+	ctx := context.Background()
+	require.NoError(t, options.Authorizers[v1alpha1.KindAgent](ctx, types.AuthorizeInput{Namespace: "team-a"}))
+	assert.Equal(t, []string{"namespace", "agent"}, calls, "namespace gate runs first")
+
+	calls = nil
+	require.Error(t, options.Authorizers[v1alpha1.KindAgent](ctx, types.AuthorizeInput{Namespace: "team-b"}))
+	assert.Equal(t, []string{"namespace"}, calls, "a namespace denial short-circuits the kind gate")
+
+	require.Contains(t, options.Authorizers, v1alpha1.KindNamespace, "kinds without a gate get the namespace gate")
+	require.Contains(t, options.Authorizers, "Widget", "extension kinds are gated too")
+	assert.Error(t, options.Authorizers["Widget"](ctx, types.AuthorizeInput{Namespace: "team-b"}))
+}
@@ -3,7 +3,8 @@
 // commands/*, agents/*, bin/*, and the real .claude-plugin/plugin.json). It is
 // loaded from a checked-out source tree (FromDir), scanned to derive the typed
 // manifest (ParseManifest) and the governance inventory (BuildInventory), and
-// translated into a harness's on-disk layout at deploy time.
+// translated into a harness's on-disk layout at deploy time. OCI-published
+// bundles arrive as a tar layer instead and are loaded with FromTar.
 //
 // The registry does NOT host bundles. A Plugin's spec points at an external
 // source (a pinned git commit or OCI digest); the controller resolves that
@@ -13,8 +14,10 @@
 package bundle
 
 import (
+	"archive/tar"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path"
@@ -115,6 +118,58 @@ func fromDir(dir string, maxFiles int, maxBytes int64) (*CanonicalBundle, error)
 	return &CanonicalBundle{Files: files}, nil
 }
 
+// FromTar reads a plugin bundle packaged as an (uncompressed) tar stream — the
+// bundle layer of an OCI artifact — into a CanonicalBundle. It applies the
+// same rules as FromDir: directories, symlinks, hardlinks and other irregular
+// entries are skipped, entries under .git/ are ignored, every path is
+// traversal-checked, and the file-count and byte ceilings are enforced.
+func FromTar(r io.Reader) (*CanonicalBundle, error) {
+	return fromTar(r, MaxBundleFiles, MaxBundleBytes)
+}
+
+// fromTar is FromTar with explicit limits, so tests can exercise the ceilings.
+func fromTar(r io.Reader, maxFiles int, maxBytes int64) (*CanonicalBundle, error) {
+	files := map[string][]byte{}
+	var totalBytes int64
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: read bundle archive: %v", ErrInvalidBundle, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		// Archives built with `tar -C dir .` prefix every entry with "./".
+		rel := strings.TrimPrefix(hdr.Name, "./")
+		if rel == ".git" || strings.HasPrefix(rel, ".git/") {
+			continue
+		}
+		if err := validateBundlePath(rel); err != nil {
+			return nil, err
+		}
+		if _, dup := files[rel]; dup {
+			return nil, fmt.Errorf("%w: duplicate path %q", ErrInvalidBundle, rel)
+		}
+		if len(files) >= maxFiles {
+			return nil, fmt.Errorf("%w: too many files (limit %d)", ErrInvalidBundle, maxFiles)
+		}
+		if hdr.Size < 0 || totalBytes+hdr.Size > maxBytes {
+			return nil, fmt.Errorf("%w: bundle exceeds %d bytes", ErrInvalidBundle, maxBytes)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, hdr.Size))
+		if err != nil {
+			return nil, fmt.Errorf("%w: read %q: %v", ErrInvalidBundle, rel, err)
+		}
+		totalBytes += int64(len(data))
+		files[rel] = data
+	}
+	return &CanonicalBundle{Files: files}, nil
+}
+
 // validateBundlePath rejects empty, absolute, non-clean, backslash, and
 // parent-traversal paths.
 func validateBundlePath(p string) error {
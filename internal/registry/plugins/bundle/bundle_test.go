@@ -1,6 +1,8 @@
 package bundle
 
 import (
+	"archive/tar"
+	"bytes"
 	"errors"
 	"os"
 	"path/filepath"
@@ -82,6 +84,85 @@ func TestFromDirRejectsTooManyBytes(t *testing.T) {
 	}
 }
 
+func TestFromTar(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	writeTarDir(t, tw, "./")
+	writeTarFile(t, tw, "./.claude-plugin/plugin.json", `{"name":"deploy"}`)
+	writeTarFile(t, tw, "skills/deploy/SKILL.md", "---\nname: deploy\n---\n")
+	// .git entries and symlinks must be skipped, exactly as FromDir does.
+	writeTarFile(t, tw, ".git/config", "[core]\n")
+	if err := tw.WriteHeader(&tar.Header{Name: "evil", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := FromTar(&buf)
+	if err != nil {
+		t.Fatalf("FromTar: %v", err)
+	}
+	want := map[string][]byte{
+		".claude-plugin/plugin.json": []byte(`{"name":"deploy"}`),
+		"skills/deploy/SKILL.md":     []byte("---\nname: deploy\n---\n"),
+	}
+	if !reflect.DeepEqual(b.Files, want) {
+		t.Fatalf("FromTar files mismatch:\n got  %v\n want %v", b.Files, want)
+	}
+}
+
+func TestFromTarRejectsInvalidArchives(t *testing.T) {
+	tests := []struct {
+		name     string
+		entries  []string
+		maxFiles int
+		maxBytes int64
+	}{
+		{name: "parent traversal", entries: []string{"../evil"}, maxFiles: MaxBundleFiles, maxBytes: MaxBundleBytes},
+		{name: "absolute path", entries: []string{"/etc/evil"}, maxFiles: MaxBundleFiles, maxBytes: MaxBundleBytes},
+		{name: "duplicate path", entries: []string{"a.txt", "./a.txt"}, maxFiles: MaxBundleFiles, maxBytes: MaxBundleBytes},
+		{name: "too many files", entries: []string{"a", "b", "c"}, maxFiles: 2, maxBytes: MaxBundleBytes},
+		{name: "too many bytes", entries: []string{"a", "b"}, maxFiles: MaxBundleFiles, maxBytes: 15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tw := tar.NewWriter(&buf)
+			for _, e := range tt.entries {
+				writeTarFile(t, tw, e, "0123456789")
+			}
+			if err := tw.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := fromTar(&buf, tt.maxFiles, tt.maxBytes); !errors.Is(err, ErrInvalidBundle) {
+				t.Fatalf("expected ErrInvalidBundle, got %v", err)
+			}
+		})
+	}
+
+	if _, err := FromTar(bytes.NewReader([]byte("not a tar archive at all"))); !errors.Is(err, ErrInvalidBundle) {
+		t.Fatalf("expected ErrInvalidBundle for a corrupt archive, got %v", err)
+	}
+}
+
+func writeTarDir(t *testing.T, tw *tar.Writer, name string) {
+	t.Helper()
+	if err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeDir, Mode: 0o755}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTarFile(t *testing.T, tw *tar.Writer, name, content string) {
+	t.Helper()
+	if err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func writeFile(t *testing.T, root, rel, content string) {
 	t.Helper()
 	full := filepath.Join(root, filepath.FromSlash(rel))
@@ -0,0 +1,140 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/plugins/bundle"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// BundleLayerMediaType is the layer media type of a plugin bundle published as
+// an OCI artifact: a gzip'd tar of the plugin source tree. A single-layer
+// artifact is accepted regardless of its layer media type, so bundles pushed
+// with generic tooling (oras, crane) also resolve.
+const BundleLayerMediaType = "application/vnd.agentregistry.plugin.bundle.v1.tar+gzip"
+
+// OCIResolver resolves OCI sources: it resolves the reference (tag or digest)
+// to a manifest digest and then pulls the bundle layer of that exact manifest.
+// Registry credentials come from the ambient docker keychain
+// (~/.docker/config.json, credential helpers), mirroring the git resolver's
+// use of ambient git credentials.
+type OCIResolver struct{}
+
+// NewOCIResolver returns an OCI-backed Resolver.
+func NewOCIResolver() *OCIResolver { return &OCIResolver{} }
+
+func (r *OCIResolver) Resolve(ctx context.Context, p *v1alpha1.Plugin) (*v1alpha1.PluginResolvedSource, *bundle.CanonicalBundle, error) {
+	if p == nil || p.Spec.Source == nil {
+		return nil, nil, fmt.Errorf("%w: plugin has no source", ErrUnsupportedSource)
+	}
+	if p.Spec.Source.Type != v1alpha1.PluginSourceTypeOCI {
+		return nil, nil, fmt.Errorf("%w: oci resolver cannot resolve %q plugin sources", ErrUnsupportedSource, p.Spec.Source.Type)
+	}
+	return r.resolveOCI(ctx, p.Spec.Source.OCI)
+}
+
+func (r *OCIResolver) resolveOCI(ctx context.Context, o *v1alpha1.PluginSourceOCI) (*v1alpha1.PluginResolvedSource, *bundle.CanonicalBundle, error) {
+	if o == nil || o.Reference == "" {
+		return nil, nil, fmt.Errorf("%w: oci source missing reference", ErrUnsupportedSource)
+	}
+	ref, err := name.ParseReference(o.Reference)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: invalid oci reference %q: %v", ErrUnsupportedSource, o.Reference, err)
+	}
+
+	// Same bound as the git path: manifest lookup + layer pull must not hang
+	// the worker on a slow/hostile registry.
+	ctx, cancel := context.WithTimeout(ctx, cloneTimeout)
+	defer cancel()
+
+	// Resolve the tag (or verify the digest) to the manifest digest first, so
+	// status records an immutable pin even when the user supplied a tag.
+	desc, err := remote.Get(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return nil, nil, classifyOCIErr(err, "resolve oci reference "+o.Reference)
+	}
+	if desc.MediaType.IsIndex() {
+		return nil, nil, fmt.Errorf("%w: oci reference %q is an image index; reference a single-artifact manifest", ErrUnsupportedSource, o.Reference)
+	}
+	img, err := desc.Image()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: read oci manifest: %v", bundle.ErrInvalidBundle, err)
+	}
+
+	layer, err := bundleLayer(img)
+	if err != nil {
+		return nil, nil, err
+	}
+	rc, err := layer.Uncompressed()
+	if err != nil {
+		return nil, nil, classifyOCIErr(err, "pull oci bundle layer")
+	}
+	defer func() { _ = rc.Close() }()
+
+	b, err := bundle.FromTar(rc)
+	if err != nil {
+		// A truncated stream from a dropped connection surfaces as a tar read
+		// error; retry those rather than marking the plugin invalid forever.
+		if ctx.Err() != nil {
+			return nil, nil, fmt.Errorf("pull oci bundle layer: %w", ctx.Err())
+		}
+		return nil, nil, err // wraps bundle.ErrInvalidBundle (terminal)
+	}
+	return &v1alpha1.PluginResolvedSource{Type: v1alpha1.PluginSourceTypeOCI, Digest: desc.Digest.String()}, b, nil
+}
+
+// bundleLayer picks the layer carrying the plugin bundle: the layer with
+// BundleLayerMediaType, or the only layer of a single-layer artifact.
+func bundleLayer(img v1.Image) (v1.Layer, error) {
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("%w: read oci layers: %v", bundle.ErrInvalidBundle, err)
+	}
+	var match []v1.Layer
+	for _, l := range layers {
+		mt, err := l.MediaType()
+		if err != nil {
+			return nil, fmt.Errorf("%w: read oci layer media type: %v", bundle.ErrInvalidBundle, err)
+		}
+		if string(mt) == BundleLayerMediaType {
+			match = append(match, l)
+		}
+	}
+	switch {
+	case len(match) == 1:
+		return match[0], nil
+	case len(match) > 1:
+		return nil, fmt.Errorf("%w: oci artifact has %d %s layers, want exactly one", bundle.ErrInvalidBundle, len(match), BundleLayerMediaType)
+	case len(layers) == 1:
+		return layers[0], nil
+	default:
+		return nil, fmt.Errorf("%w: oci artifact has %d layers and none of media type %s", bundle.ErrInvalidBundle, len(layers), BundleLayerMediaType)
+	}
+}
+
+// classifyOCIErr maps a registry error to the resolver's terminal/retryable
+// contract: an unknown manifest or repository is terminal (ErrSourceNotFound);
+// anything else (network, auth, rate limiting, 5xx) is retryable.
+func classifyOCIErr(err error, context string) error {
+	var terr *transport.Error
+	if errors.As(err, &terr) {
+		if terr.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("%w: %v", ErrSourceNotFound, err)
+		}
+		for _, d := range terr.Errors {
+			if d.Code == transport.ManifestUnknownErrorCode || d.Code == transport.NameUnknownErrorCode {
+				return fmt.Errorf("%w: %v", ErrSourceNotFound, err)
+			}
+		}
+	}
+	return fmt.Errorf("%s: %w", context, err) // retryable
+}
@@ -0,0 +1,168 @@
+package source
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/plugins/bundle"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// newTestRegistry starts an in-process OCI registry and returns its host:port.
+// Loopback hosts are addressed over plain http by go-containerregistry.
+func newTestRegistry(t *testing.T) string {
+	t.Helper()
+	srv := httptest.NewServer(registry.New())
+	t.Cleanup(srv.Close)
+	return strings.TrimPrefix(srv.URL, "http://")
+}
+
+// pushBundle pushes a single-artifact image whose layers are gzip'd tars of
+// the given file sets, and returns the manifest digest.
+func pushBundle(t *testing.T, ref string, mediaType types.MediaType, layers ...map[string]string) v1.Hash {
+	t.Helper()
+	img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	for _, files := range layers {
+		var err error
+		img, err = mutate.AppendLayers(img, static.NewLayer(tarGz(t, files), mediaType))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	r, err := name.ParseReference(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(r, img); err != nil {
+		t.Fatalf("push %s: %v", ref, err)
+	}
+	d, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for p, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: p, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func ociPlugin(ref string) *v1alpha1.Plugin {
+	return &v1alpha1.Plugin{Spec: v1alpha1.PluginSpec{Source: &v1alpha1.PluginSource{
+		Type: v1alpha1.PluginSourceTypeOCI,
+		OCI:  &v1alpha1.PluginSourceOCI{Reference: ref},
+	}}}
+}
+
+var testBundleFiles = map[string]string{
+	".claude-plugin/plugin.json": `{"name":"deploy"}`,
+	"skills/deploy/SKILL.md":     "---\nname: deploy\n---\n",
+}
+
+func TestOCIResolverResolvesTagToDigest(t *testing.T) {
+	host := newTestRegistry(t)
+	digest := pushBundle(t, host+"/org/deploy:1.0.0", BundleLayerMediaType, testBundleFiles)
+
+	resolved, b, err := NewResolver(nil).Resolve(context.Background(), ociPlugin(host+"/org/deploy:1.0.0"))
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if resolved.Type != v1alpha1.PluginSourceTypeOCI || resolved.Digest != digest.String() || resolved.Commit != "" {
+		t.Fatalf("resolved = %+v, want oci digest %s", resolved, digest)
+	}
+	for p, content := range testBundleFiles {
+		if string(b.Files[p]) != content {
+			t.Fatalf("bundle file %q = %q, want %q", p, b.Files[p], content)
+		}
+	}
+
+	// The recorded pin must itself resolve to the same digest and bundle.
+	repinned, _, err := NewOCIResolver().Resolve(context.Background(), ociPlugin(host+"/org/deploy@"+resolved.Digest))
+	if err != nil {
+		t.Fatalf("Resolve by digest: %v", err)
+	}
+	if repinned.Digest != resolved.Digest {
+		t.Fatalf("digest pin resolved to %s, want %s", repinned.Digest, resolved.Digest)
+	}
+}
+
+func TestOCIResolverSingleGenericLayer(t *testing.T) {
+	host := newTestRegistry(t)
+	pushBundle(t, host+"/org/generic:v1", types.OCILayer, testBundleFiles)
+
+	_, b, err := NewOCIResolver().Resolve(context.Background(), ociPlugin(host+"/org/generic:v1"))
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if _, ok := b.Files["skills/deploy/SKILL.md"]; !ok {
+		t.Fatalf("bundle missing SKILL.md: %v", b.Files)
+	}
+}
+
+func TestOCIResolverTerminalFailures(t *testing.T) {
+	host := newTestRegistry(t)
+	pushBundle(t, host+"/org/ambiguous:v1", types.OCILayer, testBundleFiles, testBundleFiles)
+	pushBundle(t, host+"/org/traversal:v1", BundleLayerMediaType, map[string]string{"../evil": "x"})
+
+	tests := []struct {
+		name string
+		ref  string
+		want error
+	}{
+		{"unknown tag", host + "/org/deploy:missing", ErrSourceNotFound},
+		{"unparseable reference", "Not A Reference", ErrUnsupportedSource},
+		{"no identifiable bundle layer", host + "/org/ambiguous:v1", bundle.ErrInvalidBundle},
+		{"traversal in bundle layer", host + "/org/traversal:v1", bundle.ErrInvalidBundle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := NewOCIResolver().Resolve(context.Background(), ociPlugin(tt.ref))
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestOCIResolverRejectsGitSources(t *testing.T) {
+	p := &v1alpha1.Plugin{Spec: v1alpha1.PluginSpec{Source: &v1alpha1.PluginSource{
+		Type: v1alpha1.PluginSourceTypeGit,
+		Git:  &v1alpha1.PluginSourceGit{Repository: &v1alpha1.Repository{URL: "https://github.com/o/r"}},
+	}}}
+	if _, _, err := NewOCIResolver().Resolve(context.Background(), p); !errors.Is(err, ErrUnsupportedSource) {
+		t.Fatalf("expected ErrUnsupportedSource, got %v", err)
+	}
+}
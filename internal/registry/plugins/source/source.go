@@ -2,7 +2,8 @@
 // commit (git) or digest (oci) and loads the bundle files at that pin. The
 // registry does NOT host plugin bundles — this package is how the controller
 // (at resolve time) and deploys (at materialize time) turn a Plugin.Spec.Source
-// into an in-memory bundle.CanonicalBundle.
+// into an in-memory bundle.CanonicalBundle. Resolver (NewResolver) dispatches on
+// the source type to GitResolver or OCIResolver.
 package source
 
 import (
@@ -17,18 +18,20 @@ import (
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 )
 
-// cloneTimeout bounds a single resolve (ls-remote + shallow clone) so a slow or
-// hostile origin cannot hang the controller worker indefinitely.
+// cloneTimeout bounds a single resolve (ls-remote + shallow clone, or manifest
+// lookup + layer pull) so a slow or hostile origin cannot hang the controller
+// worker indefinitely.
 const cloneTimeout = 2 * time.Minute
 
 var (
 	// ErrUnsupportedSource marks a source the resolver cannot handle — a
-	// TERMINAL condition (retrying will not help). OCI sources and non-GitHub
-	// git hosts are currently unsupported.
+	// TERMINAL condition (retrying will not help): a git host outside the
+	// allowlist, an unsupported URL scheme, or an OCI image index.
 	ErrUnsupportedSource = errors.New("source: unsupported plugin source")
-	// ErrSourceNotFound marks a ref that resolves to no commit on the remote
-	// (deleted/typo'd branch or tag, or a non-existent SHA) — TERMINAL.
-	ErrSourceNotFound = errors.New("source: git ref not found")
+	// ErrSourceNotFound marks a ref that resolves to no commit or manifest on
+	// the remote (deleted/typo'd branch or tag, a non-existent SHA, or an
+	// unknown OCI repository/tag/digest) — TERMINAL.
+	ErrSourceNotFound = errors.New("source: ref not found")
 )
 
 // Resolver pins a plugin's source and loads its bundle. Transient failures
@@ -39,15 +42,48 @@ type Resolver interface {
 	Resolve(ctx context.Context, p *v1alpha1.Plugin) (*v1alpha1.PluginResolvedSource, *bundle.CanonicalBundle, error)
 }
 
+// SourceResolver is the production Resolver: it dispatches on the plugin's
+// source type to the git or OCI resolver, so the Plugin controller handles both
+// through one pin-and-scan path.
+type SourceResolver struct {
+	Git *GitResolver
+	OCI *OCIResolver
+}
+
+// NewResolver returns a Resolver handling every supported source type. Git
+// sources are restricted to gitAllowedHosts (empty allows github.com).
+func NewResolver(gitAllowedHosts []string) *SourceResolver {
+	return &SourceResolver{Git: NewGitResolver(gitAllowedHosts), OCI: NewOCIResolver()}
+}
+
+func (r *SourceResolver) Resolve(ctx context.Context, p *v1alpha1.Plugin) (*v1alpha1.PluginResolvedSource, *bundle.CanonicalBundle, error) {
+	if p == nil || p.Spec.Source == nil {
+		return nil, nil, fmt.Errorf("%w: plugin has no source", ErrUnsupportedSource)
+	}
+	switch p.Spec.Source.Type {
+	case v1alpha1.PluginSourceTypeGit:
+		return r.Git.Resolve(ctx, p)
+	case v1alpha1.PluginSourceTypeOCI:
+		return r.OCI.Resolve(ctx, p)
+	default:
+		return nil, nil, fmt.Errorf("%w: unknown plugin source type %q", ErrUnsupportedSource, p.Spec.Source.Type)
+	}
+}
+
 // GitResolver resolves git sources: it resolves the ref to a commit SHA via
 // `git ls-remote` (no clone) and then shallow-clones that exact commit. It
-// shells out to system git with ambient credentials, and only github.com is
-// supported today (matching existing skill/agent source behavior). OCI sources
-// are not yet implemented.
-type GitResolver struct{}
+// shells out to system git with ambient credentials and works against any
+// https/ssh git host (GitHub, GitLab, Gitea, self-hosted) on its host
+// allowlist. OCI sources are handled by OCIResolver.
+type GitResolver struct {
+	allowedHosts []string
+}
 
-// NewGitResolver returns a git-backed Resolver.
-func NewGitResolver() *GitResolver { return &GitResolver{} }
+// NewGitResolver returns a git-backed Resolver restricted to allowedHosts
+// (empty allows github.com; see gitutil.WithAllowedHosts).
+func NewGitResolver(allowedHosts []string) *GitResolver {
+	return &GitResolver{allowedHosts: allowedHosts}
+}
 
 func (r *GitResolver) Resolve(ctx context.Context, p *v1alpha1.Plugin) (*v1alpha1.PluginResolvedSource, *bundle.CanonicalBundle, error) {
 	if p == nil || p.Spec.Source == nil {
@@ -58,7 +94,7 @@ func (r *GitResolver) Resolve(ctx context.Context, p *v1alpha1.Plugin) (*v1alpha
 	case v1alpha1.PluginSourceTypeGit:
 		return r.resolveGit(ctx, o.Git)
 	case v1alpha1.PluginSourceTypeOCI:
-		return nil, nil, fmt.Errorf("%w: oci plugin source not yet supported (use a git source)", ErrUnsupportedSource)
+		return nil, nil, fmt.Errorf("%w: git resolver cannot resolve oci plugin sources", ErrUnsupportedSource)
 	default:
 		return nil, nil, fmt.Errorf("%w: unknown plugin source type %q", ErrUnsupportedSource, o.Type)
 	}
@@ -81,7 +117,8 @@ func (r *GitResolver) resolveGit(ctx context.Context, g *v1alpha1.PluginSourceGi
 	if ref == "" {
 		ref = repo.Branch
 	}
-	commit, err := gitutil.ResolveRefContext(ctx, repo.URL, ref)
+	hosts := gitutil.WithAllowedHosts(r.allowedHosts)
+	commit, err := gitutil.ResolveRefContext(ctx, repo.URL, ref, hosts)
 	if err != nil {
 		return nil, nil, classifyGitErr(err, "resolve git ref "+ref)
 	}
@@ -94,7 +131,7 @@ func (r *GitResolver) resolveGit(ctx context.Context, g *v1alpha1.PluginSourceGi
 
 	// branch="" + commit=resolved => clone the default branch shallow, then
 	// fetch+checkout the exact pinned commit (gitutil does the fetch-by-SHA).
-	if err := gitutil.CloneAndCopyContext(ctx, repo.URL, "", commit, repo.Subfolder, dir, false); err != nil {
+	if err := gitutil.CloneAndCopyContext(ctx, repo.URL, "", commit, repo.Subfolder, dir, false, hosts); err != nil {
 		return nil, nil, classifyGitErr(err, "clone git source")
 	}
 
@@ -106,7 +143,7 @@ func (r *GitResolver) resolveGit(ctx context.Context, g *v1alpha1.PluginSourceGi
 }
 
 // classifyGitErr maps a gitutil error to the resolver's terminal/retryable
-// contract: a non-github host or a missing ref is terminal (wrapped in a
+// contract: a disallowed host or a missing ref is terminal (wrapped in a
 // terminal sentinel); anything else (network, transport) is retryable.
 func classifyGitErr(err error, context string) error {
 	switch {
@@ -9,12 +9,12 @@ import (
 )
 
 // TestGitResolverUnsupportedSources covers the terminal dispatch paths that do
-// not touch the network: nil source, OCI (not yet implemented), an unknown
+// not touch the network: nil source, OCI (OCIResolver's job), an unknown
 // type, and a git source missing its repository URL. Each must wrap
 // ErrUnsupportedSource so the controller marks the plugin terminally failed
 // rather than retrying forever.
 func TestGitResolverUnsupportedSources(t *testing.T) {
-	r := NewGitResolver()
+	r := NewGitResolver(nil)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -23,7 +23,7 @@ func TestGitResolverUnsupportedSources(t *testing.T) {
 	}{
 		{"nil source", &v1alpha1.Plugin{}},
 		{
-			name:   "oci source not yet supported",
+			name:   "oci source (handled by OCIResolver)",
 			plugin: &v1alpha1.Plugin{Spec: v1alpha1.PluginSpec{Source: &v1alpha1.PluginSource{Type: v1alpha1.PluginSourceTypeOCI, OCI: &v1alpha1.PluginSourceOCI{Reference: "ghcr.io/o/p@sha256:abc"}}}},
 		},
 		{
@@ -44,3 +44,17 @@ func TestGitResolverUnsupportedSources(t *testing.T) {
 		})
 	}
 }
+
+// TestGitResolverHostAllowlist covers the allowlist gate: a repository on a
+// host outside the configured list is rejected before git runs, as a terminal
+// ErrUnsupportedSource.
+func TestGitResolverHostAllowlist(t *testing.T) {
+	r := NewGitResolver([]string{"gitlab.internal"})
+	p := &v1alpha1.Plugin{Spec: v1alpha1.PluginSpec{Source: &v1alpha1.PluginSource{
+		Type: v1alpha1.PluginSourceTypeGit,
+		Git:  &v1alpha1.PluginSourceGit{Repository: &v1alpha1.Repository{URL: "https://github.com/o/r", Branch: "main"}},
+	}}}
+	if _, _, err := r.Resolve(context.Background(), p); !errors.Is(err, ErrUnsupportedSource) {
+		t.Fatalf("expected ErrUnsupportedSource, got %v", err)
+	}
+}
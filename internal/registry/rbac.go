@@ -0,0 +1,131 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+// withRBACHooks returns options with an Authorizer and a ListFilter enforcing
+// provider on every registered kind. Hooks the caller already supplied for a
+// kind win, so downstream builds can override single kinds.
+func withRBACHooks(options types.AppOptions, provider *auth.RBACAuthzProvider) types.AppOptions {
+	kinds := registeredKinds(options)
+	authorizers := maps.Clone(options.Authorizers)
+	if authorizers == nil {
+		authorizers = map[string]types.Authorizer{}
+	}
+	listFilters := maps.Clone(options.ListFilters)
+	if listFilters == nil {
+		listFilters = map[string]types.ListFilter{}
+	}
+	for _, kind := range kinds {
+		mutable := options.V1Alpha1MutableStoreKinds[kind]
+		if d, ok := v1alpha1.DefaultKindRegistry.Lookup(kind); ok {
+			mutable = mutable || d.Storage == v1alpha1.KindStorageMutableObject
+		}
+		if _, ok := authorizers[kind]; !ok {
+			authorizers[kind] = rbacAuthorizer(provider, mutable)
+		}
+		if _, ok := listFilters[kind]; !ok {
+			listFilters[kind] = rbacListFilter(provider)
+		}
+	}
+	options.Authorizers = authorizers
+	options.ListFilters = listFilters
+	return options
+}
+
+// rbacAuthorizer maps handler verbs onto RBAC actions:
+//
+//   - get, and list of one name's tags, need read.
+//   - A namespace-wide list is always admitted; rbacListFilter scopes its rows.
+//   - apply needs deploy on Deployments, edit on other mutable kinds and
+//     publish on tagged artifacts.
+//   - sign needs publish: a signature vouches for content the way
+//     publishing it does.
+//   - approve needs approve, which designates the reviewers of content
+//     held by a namespace's approval policy.
+//   - deprecate and yank need publish: marking a tag is part of
+//     maintaining what was published.
+//   - delete needs delete.
+func rbacAuthorizer(provider *auth.RBACAuthzProvider, mutable bool) types.Authorizer {
+	return func(ctx context.Context, in types.AuthorizeInput) error {
+		var action auth.PermissionAction
+		switch in.Verb {
+		case "get":
+			action = auth.PermissionActionRead
+		case "list":
+			if in.Name == "" {
+				return nil
+			}
+			action = auth.PermissionActionRead
+		case "apply":
+			switch {
+			case in.Kind == v1alpha1.KindDeployment:
+				action = auth.PermissionActionDeploy
+			case mutable:
+				action = auth.PermissionActionEdit
+			default:
+				action = auth.PermissionActionPublish
+			}
+		case "sign":
+			action = auth.PermissionActionPublish
+		case "approve":
+			action = auth.PermissionActionApprove
+		case "deprecate", "yank":
+			action = auth.PermissionActionPublish
+		case "delete":
+			action = auth.PermissionActionDelete
+		default:
+			return huma.Error403Forbidden("unsupported verb " + in.Verb)
+		}
+		s, _ := auth.AuthSessionFrom(ctx)
+		err := provider.Check(ctx, s, action, auth.Resource{
+			Type:      rbacResourceType(in.Kind),
+			Namespace: in.Namespace,
+			Name:      in.Name,
+		})
+		return rbacStatusError(err)
+	}
+}
+
+// rbacListFilter restricts list responses to rows the caller may read.
+func rbacListFilter(provider *auth.RBACAuthzProvider) types.ListFilter {
+	return func(ctx context.Context, in types.AuthorizeInput) (string, []any, error) {
+		s, _ := auth.AuthSessionFrom(ctx)
+		where, args := provider.ListPredicate(ctx, s, auth.PermissionActionRead, rbacResourceType(in.Kind), in.Namespace)
+		return where, args, nil
+	}
+}
+
+// rbacResourceType is the resource glob type of kind: the lower-cased kind,
+// except MCPServer which keeps the established "server" artifact type.
+func rbacResourceType(kind string) auth.PermissionArtifactType {
+	if kind == v1alpha1.KindMCPServer {
+		return auth.PermissionArtifactTypeServer
+	}
+	return auth.PermissionArtifactType(strings.ToLower(kind))
+}
+
+// rbacStatusError attaches the HTTP status the resource handlers emit to an
+// RBAC denial while keeping auth.ErrForbidden / auth.ErrUnauthenticated
+// reachable through errors.Is for the MCP surfaces.
+func rbacStatusError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return errors.Join(huma.Error401Unauthorized(err.Error()), err)
+	case errors.Is(err, auth.ErrForbidden):
+		return errors.Join(huma.Error403Forbidden(err.Error()), err)
+	}
+	return err
+}
@@ -0,0 +1,85 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+type rbacTestSession struct{ subject string }
+
+func (s rbacTestSession) Principal() auth.Principal {
+	return auth.Principal{User: auth.User{Subject: s.subject}}
+}
+
+func TestWithRBACHooks(t *testing.T) {
+	policy, err := auth.ParseRBACPolicy([]byte(`
+roles:
+  - name: ops
+    rules:
+      - actions: [read, deploy, edit]
+        resources: ["deployment:prod/*", "runtime:prod/*"]
+      - actions: [read]
+        resources: ["server:prod/io.example/*"]
+      - actions: [approve]
+        resources: ["skill:prod/*"]
+roleBindings:
+  - role: ops
+    subjects: [{user: ops}]
+`))
+	require.NoError(t, err)
+	provider, err := auth.NewRBACAuthzProvider(policy)
+	require.NoError(t, err)
+
+	custom := func(context.Context, types.AuthorizeInput) error { return errors.New("custom") }
+	options := withRBACHooks(types.AppOptions{
+		Authorizers: map[string]types.Authorizer{v1alpha1.KindAgent: custom},
+	}, provider)
+	require.ErrorContains(t, options.Authorizers[v1alpha1.KindAgent](context.Background(), types.AuthorizeInput{}), "custom",
+		"caller-supplied hooks win")
+	require.Contains(t, options.ListFilters, v1alpha1.KindAgent)
+
+	ctx := auth.AuthSessionTo(context.Background(), rbacTestSession{subject: "ops"})
+	authorize := func(kind, verb, ns, name string) error {
+		return options.Authorizers[kind](ctx, types.AuthorizeInput{Verb: verb, Kind: kind, Namespace: ns, Name: name})
+	}
+	assert.NoError(t, authorize(v1alpha1.KindDeployment, "apply", "prod", "api"), "apply on a Deployment needs deploy")
+	assert.NoError(t, authorize(v1alpha1.KindRuntime, "apply", "prod", "kube"), "apply on a mutable kind needs edit")
+	assert.NoError(t, authorize(v1alpha1.KindMCPServer, "get", "prod", "io.example/weather"))
+	assert.NoError(t, authorize(v1alpha1.KindSkill, "list", "", ""), "namespace-wide lists are filtered, not gated")
+
+	err = authorize(v1alpha1.KindMCPServer, "apply", "prod", "io.example/weather")
+	require.Error(t, err, "apply on a tagged artifact needs publish")
+	var se huma.StatusError
+	require.ErrorAs(t, err, &se)
+	assert.Equal(t, http.StatusForbidden, se.GetStatus())
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	assert.ErrorIs(t, authorize(v1alpha1.KindMCPServer, "sign", "prod", "io.example/weather"), auth.ErrForbidden,
+		"signing a tagged artifact needs publish")
+	assert.NoError(t, authorize(v1alpha1.KindSkill, "approve", "prod", "summarize"), "reviewing needs approve")
+	assert.ErrorIs(t, authorize(v1alpha1.KindMCPServer, "approve", "prod", "io.example/weather"), auth.ErrForbidden,
+		"read does not make a reviewer")
+	assert.ErrorIs(t, authorize(v1alpha1.KindMCPServer, "yank", "prod", "io.example/weather"), auth.ErrForbidden,
+		"yanking a tagged artifact needs publish")
+	assert.ErrorIs(t, authorize(v1alpha1.KindSkill, "deprecate", "prod", "summarize"), auth.ErrForbidden,
+		"approve does not cover deprecating")
+
+	err = options.Authorizers[v1alpha1.KindSkill](auth.WithPublicContext(context.Background()),
+		types.AuthorizeInput{Verb: "get", Kind: v1alpha1.KindSkill, Namespace: "default", Name: "x"})
+	require.ErrorAs(t, err, &se)
+	assert.Equal(t, http.StatusUnauthorized, se.GetStatus())
+
+	where, args, err := options.ListFilters[v1alpha1.KindMCPServer](ctx, types.AuthorizeInput{Verb: "list", Kind: v1alpha1.KindMCPServer})
+	require.NoError(t, err)
+	assert.Equal(t, "((namespace = $1 AND name LIKE $2))", where)
+	assert.Equal(t, []any{"prod", "io.example/%"}, args)
+}
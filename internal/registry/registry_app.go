@@ -15,7 +15,8 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
 	mcpauth "github.com/modelcontextprotocol/go-sdk/auth"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/modelcontextprotocol/go-sdk/oauthex"
@@ -23,6 +24,7 @@ import (
 	mcpregistry "github.com/agentregistry-dev/agentregistry/internal/mcp/registryserver"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/api"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/crud"
+	v0health "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/health"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/api/router"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
 	controller "github.com/agentregistry-dev/agentregistry/internal/registry/controller"
@@ -35,9 +37,11 @@ import (
 	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	"github.com/agentregistry-dev/agentregistry/pkg/logging"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/audit"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/retention"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
 	"github.com/agentregistry-dev/agentregistry/pkg/types"
 )
@@ -48,6 +52,11 @@ func App(ctx context.Context, opts ...types.AppOptions) error {
 		options = opts[0]
 	}
 	cfg := config.NewConfig()
+	// The --storage flag, passed in as AppOptions.Storage, wins over
+	// AGENT_REGISTRY_STORAGE.
+	if options.Storage != "" {
+		cfg.Storage = options.Storage
+	}
 	if err := config.Validate(cfg); err != nil {
 		return fmt.Errorf("configuration validation failed: %w", err)
 	}
@@ -59,15 +68,41 @@ func App(ctx context.Context, opts ...types.AppOptions) error {
 	setupLogging(cfg.LogLevel)
 
 	// The default configuration omits authentication. Integrators can supply
-	// authentication and authorization providers through AppOptions.
+	// authentication and authorization providers through AppOptions, or
+	// enable the built-in JWT provider with the AUTH_JWT_* settings.
 	authnProvider := options.AuthnProvider
+	var jwtAuthn *auth.JWTAuthnProvider
+	if authnProvider == nil && cfg.JWTAuthEnabled() {
+		provider, err := newJWTAuthnProvider(cfg)
+		if err != nil {
+			return fmt.Errorf("configure jwt authentication: %w", err)
+		}
+		slog.Info("using jwt authn provider", "issuer", cfg.AuthJWTIssuer)
+		jwtAuthn, authnProvider = provider, provider
+	}
 
-	// Resolve authz provider: use provided, or default to public authz
+	// Resolve authz provider: use provided, then the RBAC policy file, or
+	// default to public authz
 	authzProvider := options.AuthzProvider
+	if authzProvider == nil && cfg.AuthRBACPolicyFile != "" {
+		rbac, err := auth.LoadRBACAuthzProvider(cfg.AuthRBACPolicyFile)
+		if err != nil {
+			return fmt.Errorf("configure rbac authorization: %w", err)
+		}
+		go func() {
+			if err := rbac.Watch(ctx); err != nil {
+				slog.Error("rbac policy watcher stopped; policy edits need a restart", "error", err)
+			}
+		}()
+		slog.Info("using rbac authz provider", "policy", cfg.AuthRBACPolicyFile)
+		authzProvider = rbac
+		options = withRBACHooks(options, rbac)
+	}
 	if authzProvider == nil {
 		slog.Info("using public authz provider")
 		authzProvider = auth.NewPublicAuthzProvider()
 	}
+	options = withNamespaceAuthorizer(options)
 	authz := auth.Authorizer{Authz: authzProvider}
 
 	// Effective SkipMigrations: AppOptions wins when set, otherwise the
@@ -99,40 +134,44 @@ func App(ctx context.Context, opts ...types.AppOptions) error {
 		v1alpha1.TypeKubernetes: kubernetes.NewKubernetesDeploymentAdapter(),
 	}
 	maps.Copy(deploymentAdapters, options.DeploymentAdapters)
-	pool := db.Pool()
-	stores := buildStores(pool, options.V1Alpha1StoreTables, options.V1Alpha1MutableStoreKinds, options.Auditor)
-	controllerConfig := deploymentControllerConfig(cfg)
-	controllerConfig.DependencyKinds = maps.Clone(options.DeploymentDependencyKinds)
-	if _, err := controller.StartDeploymentController(ctx, pool, stores, deploymentAdapters, controllerConfig); err != nil {
-		return fmt.Errorf("start deployment controller: %w", err)
+	conn := storeConn(db)
+	// Every store change and authorization denial lands in the audit log;
+	// AppOptions.Auditor still receives each event. The stores write the
+	// entry of a change in its own transaction; denials go through the
+	// Recorder.
+	auditEvents := v1alpha1store.NewAuditStore(conn, pkgdb.OSSSchemaRegistry().MustGet(pkgdb.OSSSourceName))
+	auditor := audit.NewRecorder(auditEvents, options.Auditor)
+	options = withAuditedAuthorizers(options, auditor)
+	stores, err := buildStores(ctx, conn, options.V1Alpha1StoreTables, options.V1Alpha1MutableStoreKinds, options.Auditor, auditEvents)
+	if err != nil {
+		return err
 	}
-	// The Plugin controller resolves each plugin's pinned source pointer to a
-	// concrete commit/digest and records the manifest/inventory in PluginStatus
-	// out of band of the API write — same pattern as the Deployment controller.
-	pluginController, err := controller.NewPluginController(pool, stores, controller.PluginControllerDeps{Resolver: pluginsource.NewGitResolver()})
+	signaturePolicy, err := cfg.SignaturePolicy()
 	if err != nil {
-		return fmt.Errorf("create plugin controller: %w", err)
+		return err
 	}
-	if pluginController != nil {
-		if err := pluginController.Start(ctx); err != nil {
-			return fmt.Errorf("start plugin controller: %w", err)
-		}
-		defer pluginController.Stop()
+	verifySignature := resource.NewSignatureVerifier(stores, signaturePolicy)
+	controllerConfig := deploymentControllerConfig(cfg)
+	controllerConfig.VerifySignature = verifySignature
+	controllerConfig.AuditEvents = auditEvents
+	controllerConfig.MCPSyncApply = resource.ApplyConfig{
+		Stores:          stores,
+		Admission:       options.Admission,
+		NamespaceExists: resource.NewNamespaceExists(stores),
+		TagPolicy:       resource.NewTagPolicyLookup(stores, cfg.TagPolicy()),
+		VerifySignature: verifySignature,
+		ApprovalPolicy:  resource.NewApprovalPolicy(stores),
+		DeleteAdmission: options.DeleteAdmission,
+		Referrers:       resource.NewReferrerLookup(stores),
 	}
-	// The Skill controller resolves each skill's pinned git source ref to a
-	// concrete commit and records it in SkillStatus out of band of the API write
-	// — the resolve-and-pin counterpart to the Plugin controller, minus the
-	// manifest/inventory scan (a skill has no bundle to enumerate).
-	skillController, err := controller.NewSkillController(pool, stores, controller.SkillControllerDeps{})
+	controllerConfig.DependencyKinds = maps.Clone(options.DeploymentDependencyKinds)
+	controllerConfig.Plugins = controller.PluginControllerDeps{Resolver: pluginsource.NewResolver(cfg.GitAllowedHosts)}
+	controllerConfig.Skills = controller.SkillControllerDeps{AllowedGitHosts: cfg.GitAllowedHosts}
+	controllerHealth, stopControllers, err := startControllers(ctx, cfg, conn, stores, deploymentAdapters, controllerConfig)
 	if err != nil {
-		return fmt.Errorf("create skill controller: %w", err)
-	}
-	if skillController != nil {
-		if err := skillController.Start(ctx); err != nil {
-			return fmt.Errorf("start skill controller: %w", err)
-		}
-		defer skillController.Stop()
+		return err
 	}
+	defer stopControllers()
 
 	slog.Info("starting agentregistry", "version", version.Version, "commit", version.GitCommit)
 
@@ -156,6 +195,21 @@ func App(ctx context.Context, opts ...types.AppOptions) error {
 
 	perKindHooks := crudPerKindHooks(options)
 	routeOpts := buildRouteOptions(options, stores, deploymentAdapters, perKindHooks)
+	routeOpts.Watch = startWatch(ctx, conn)
+	routeOpts.ControllerHealth = controllerHealth
+	routeOpts.AuthorizeTagOverwrite = registryAdminTagOverwrite(authz, auditor)
+	routeOpts.VerifySignature = verifySignature
+	routeOpts.ApprovalPolicy = resource.NewApprovalPolicy(stores)
+	routeOpts.AuthorizeApprovalReview = registryAdminApprovalReview(authz, auditor)
+	routeOpts.AuditEvents = auditEvents
+	routeOpts.AuthorizeAudit = registryAdminAuditRead(authz, auditor)
+	routeOpts.TagRetention = &retention.Pruner{
+		Stores:    stores,
+		Policy:    cfg.TagRetentionPolicy(),
+		Referrers: resource.NewReferrerLookup(stores),
+		TagPolicy: resource.NewTagPolicyLookup(stores, cfg.TagPolicy()),
+	}
+	routeOpts.AuthorizeTagRetention = registryAdminTagRetentionRead(authz, auditor)
 
 	// Initialize HTTP server
 	baseServer, err := api.NewServer(cfg, metrics, versionInfo, options.UIHandler, authnProvider, routeOpts, options.OpenAPISchemaNamer)
@@ -176,11 +230,8 @@ func App(ctx context.Context, opts ...types.AppOptions) error {
 
 	// The bridge may use a dedicated authn provider (e.g. one that adds MCP
 	// audience validation) without affecting server traffic.
-	mcpAuthnProvider := options.MCPAuthnProvider
-	if mcpAuthnProvider == nil {
-		mcpAuthnProvider = authnProvider
-	}
-	mcpHTTPServer := startMCPServer(cfg, stores, mcpAuthnProvider, perKindHooks, options.MCPProtectedResourceMetadata, options.MCPResourceMetadataURL)
+	mcpAuthnProvider, mcpResourceMetadata, mcpResourceMetadataURL := mcpAuthn(cfg, options, authnProvider, jwtAuthn)
+	mcpHTTPServer := startMCPServer(cfg, stores, mcpAuthnProvider, perKindHooks, mcpResourceMetadata, mcpResourceMetadataURL)
 
 	// Start server in a goroutine so it doesn't block signal handling
 	go func() {
@@ -227,16 +278,33 @@ func resolveExtraStoreSchema(table string, ossSchema pkgdb.Schema) (pkgdb.Schema
 	return ossSchema, table
 }
 
-func buildStores(pool *pgxpool.Pool, extraStoreTables map[string]string, mutableExtraKinds map[string]bool, auditor types.Auditor) map[string]*v1alpha1store.Store {
+// storeConn returns the connection the v1alpha1 stores run on: the
+// embedded database in SQLite mode, otherwise the PostgreSQL pool. Nil
+// when the Store has neither (noop/DatabaseFactory).
+func storeConn(db pkgdb.Store) v1alpha1store.DB {
+	if sqlite, ok := db.(interface{ DB() *v1alpha1store.SQLite }); ok {
+		return sqlite.DB()
+	}
+	if pool := db.Pool(); pool != nil {
+		return pool
+	}
+	return nil
+}
+
+func buildStores(ctx context.Context, conn v1alpha1store.DB, extraStoreTables map[string]string, mutableExtraKinds map[string]bool, auditor types.Auditor, auditLog *v1alpha1store.AuditStore) (map[string]*v1alpha1store.Store, error) {
 	if auditor == nil {
 		auditor = types.NoopAuditor
 	}
+	storeOpts := []v1alpha1store.StoreOption{v1alpha1store.WithAuditor(auditor)}
+	if auditLog != nil {
+		storeOpts = append(storeOpts, v1alpha1store.WithAuditLog(auditLog, audit.Identify))
+	}
 	// Resolve schemas once and inject them, so the stores qualify their
 	// tables explicitly rather than depend on the connection's
 	// search_path.
 	schemas := pkgdb.OSSSchemaRegistry()
 	ossSchema := schemas.MustGet(pkgdb.OSSSourceName)
-	stores := v1alpha1store.NewStores(pool, schemas, v1alpha1store.WithAuditor(auditor))
+	stores := v1alpha1store.NewStores(conn, schemas, storeOpts...)
 	for kind, table := range extraStoreTables {
 		if kind == "" || table == "" {
 			slog.Warn("skipping v1alpha1 extra store with empty kind or table", "kind", kind, "table", table)
@@ -250,26 +318,38 @@ func buildStores(pool *pgxpool.Pool, extraStoreTables map[string]string, mutable
 			slog.Warn("skipping v1alpha1 extra store with empty table after schema qualifier", "kind", kind, "table", table)
 			continue
 		}
-		opts := []v1alpha1store.StoreOption{v1alpha1store.WithKind(kind), v1alpha1store.WithAuditor(auditor)}
+		opts := append([]v1alpha1store.StoreOption{v1alpha1store.WithKind(kind)}, storeOpts...)
+		// Tables that predate the resource_version column still serve
+		// reads and unconditional writes.
+		if conn != nil {
+			versioned, err := v1alpha1store.HasResourceVersions(ctx, conn, sch, tbl)
+			if err != nil {
+				return nil, err
+			}
+			if !versioned {
+				slog.Warn("v1alpha1 extra store table has no resource_version column; conditional writes will be refused", "kind", kind, "table", table)
+				opts = append(opts, v1alpha1store.WithoutResourceVersions())
+			}
+		}
 		if mutableExtraKinds[kind] {
-			stores[kind] = v1alpha1store.NewMutableObjectStore(pool, sch, tbl, opts...)
+			stores[kind] = v1alpha1store.NewMutableObjectStore(conn, sch, tbl, opts...)
 			continue
 		}
-		stores[kind] = v1alpha1store.NewStore(pool, sch, tbl, opts...)
+		stores[kind] = v1alpha1store.NewStore(conn, sch, tbl, opts...)
 	}
 
-	// pool == nil is the noop/DatabaseFactory path used by gen-openapi
+	// conn == nil is the noop/DatabaseFactory path used by gen-openapi
 	// and the release-openapi make target. Routes still register so the
 	// generated OpenAPI captures every endpoint, but actual queries
-	// would crash on the nil pool — that's fine because the noop path
-	// never serves real traffic.
-	if pool == nil {
-		slog.Info("v1alpha1 routes registered against nil pool: query path will panic if exercised (likely noop/DatabaseFactory)")
-		return stores
+	// would crash on the nil connection — that's fine because the noop
+	// path never serves real traffic.
+	if conn == nil {
+		slog.Info("v1alpha1 routes registered against nil connection: query path will panic if exercised (likely noop/DatabaseFactory)")
+		return stores, nil
 	}
 
 	slog.Info("v1alpha1 routes enabled")
-	return stores
+	return stores, nil
 }
 
 func deploymentControllerConfig(cfg *config.Config) controller.ControllerConfig {
@@ -277,11 +357,84 @@ func deploymentControllerConfig(cfg *config.Config) controller.ControllerConfig
 		Retention: controller.RetentionPolicy{
 			ControlPlaneEvents: cfg.ControllerEventRetention,
 			EventKeepAfterRev:  cfg.ControllerEventKeepAfterRevision,
+			AuditEvents:        cfg.AuditRetention,
 			BatchLimit:         cfg.ControllerRetentionPruneBatchLimit,
 		},
 		DiscoveryInterval:          cfg.ControllerDiscoveryInterval,
 		DiscoveryStaleAfterMisses:  cfg.ControllerDiscoveryStaleAfterMisses,
 		DiscoveryDeleteAfterMisses: cfg.ControllerDiscoveryDeleteAfterMisses,
+		MCPSync: controller.MCPSyncConfig{
+			Name:      cfg.MCPSyncName,
+			URL:       cfg.MCPSyncURL,
+			Namespace: cfg.MCPSyncNamespace,
+			Include:   cfg.MCPSyncInclude,
+			Exclude:   cfg.MCPSyncExclude,
+			Interval:  cfg.MCPSyncInterval,
+		},
+		TagRetention: controller.TagRetentionConfig{
+			Policy:    cfg.TagRetentionPolicy(),
+			TagPolicy: cfg.TagPolicy(),
+			Interval:  cfg.TagRetentionInterval,
+		},
+	}
+}
+
+// registryAdminTagOverwrite lets only registry admins override the tag
+// immutability policy. Refusals are recorded in the audit log.
+func registryAdminTagOverwrite(authz auth.Authorizer, auditor types.AuditRecorder) func(ctx context.Context, in resource.AuthorizeInput) error {
+	return func(ctx context.Context, in resource.AuthorizeInput) error {
+		if authz.IsRegistryAdmin(ctx) {
+			return nil
+		}
+		err := huma.Error403Forbidden(fmt.Sprintf(
+			"overwriting immutable tag %s/%s@%s requires registry admin", in.Namespace, in.Name, in.Tag))
+		recordDenial(ctx, auditor, types.AuthorizeInput{
+			Verb: in.Verb, Kind: in.Kind, Namespace: in.Namespace, Name: in.Name, Tag: in.Tag,
+		}, err)
+		return err
+	}
+}
+
+// registryAdminApprovalReview lets only registry admins review content of
+// kinds no per-kind authorizer gates. Refusals are recorded in the audit
+// log.
+func registryAdminApprovalReview(authz auth.Authorizer, auditor types.AuditRecorder) func(ctx context.Context, in resource.AuthorizeInput) error {
+	return func(ctx context.Context, in resource.AuthorizeInput) error {
+		if authz.IsRegistryAdmin(ctx) {
+			return nil
+		}
+		err := huma.Error403Forbidden(fmt.Sprintf(
+			"reviewing %s %s/%s requires registry admin", in.Kind, in.Namespace, in.Name))
+		recordDenial(ctx, auditor, types.AuthorizeInput{
+			Verb: in.Verb, Kind: in.Kind, Namespace: in.Namespace, Name: in.Name, Tag: in.Tag,
+		}, err)
+		return err
+	}
+}
+
+// registryAdminAuditRead lets only registry admins read the audit log.
+// Refusals are recorded in the audit log.
+func registryAdminAuditRead(authz auth.Authorizer, auditor types.AuditRecorder) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if authz.IsRegistryAdmin(ctx) {
+			return nil
+		}
+		err := huma.Error403Forbidden("reading the audit log requires registry admin")
+		recordDenial(ctx, auditor, types.AuthorizeInput{Verb: "read-audit"}, err)
+		return err
+	}
+}
+
+// registryAdminTagRetentionRead lets only registry admins preview tag
+// retention. Refusals are recorded in the audit log.
+func registryAdminTagRetentionRead(authz auth.Authorizer, auditor types.AuditRecorder) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if authz.IsRegistryAdmin(ctx) {
+			return nil
+		}
+		err := huma.Error403Forbidden("previewing tag retention requires registry admin")
+		recordDenial(ctx, auditor, types.AuthorizeInput{Verb: "read-retention"}, err)
+		return err
 	}
 }
 
@@ -313,6 +466,105 @@ func buildRouteOptions(
 	return routeOpts
 }
 
+// startControllers runs the controller set. With leader election enabled
+// it campaigns for the controller lease in the background and runs the
+// controllers only while this replica leads, reporting the role through
+// the returned health func. Otherwise the controllers start here and a
+// startup failure is fatal. The returned stop ends either mode.
+func startControllers(
+	ctx context.Context,
+	cfg *config.Config,
+	conn v1alpha1store.DB,
+	stores map[string]*v1alpha1store.Store,
+	adapters map[string]types.DeploymentAdapter,
+	controllerConfig controller.ControllerConfig,
+) (func() *v0health.ControllerHealth, func(), error) {
+	if conn == nil || !cfg.ControllerLeaderElection {
+		stop, err := controller.StartControllers(ctx, conn, stores, adapters, controllerConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, stop, nil
+	}
+
+	identity := cfg.ControllerLeaderIdentity
+	if identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil || hostname == "" {
+			hostname = "agentregistry"
+		}
+		identity = hostname + "-" + uuid.NewString()[:8]
+	}
+	elector := &controller.LeaderElector{
+		Leases:        v1alpha1store.NewLeaseStore(conn, pkgdb.MustNewSchema(pkgdb.OSSSchema)),
+		Name:          controller.ControllerLeaseName,
+		Identity:      identity,
+		LeaseDuration: cfg.ControllerLeaseDuration,
+		RenewInterval: cfg.ControllerLeaseRenewInterval,
+	}
+	slog.Info("controller leader election enabled", "identity", identity)
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		err := elector.Run(ctx, func(ctx context.Context) {
+			stop, err := controller.StartControllers(ctx, conn, stores, adapters, controllerConfig)
+			if err != nil {
+				slog.Error("start controllers", "error", err)
+				return
+			}
+			<-ctx.Done()
+			stop()
+		})
+		if err != nil && !errors.Is(err, context.Canceled) {
+			slog.Error("controller leader election stopped", "error", err)
+		}
+	}()
+	health := func() *v0health.ControllerHealth {
+		status := elector.Status()
+		return &v0health.ControllerHealth{
+			Role:     status.Role,
+			Identity: status.Identity,
+			Leader:   status.Leader,
+			Since:    status.Since,
+		}
+	}
+	stop := func() {
+		cancel()
+		<-done
+	}
+	return health, stop, nil
+}
+
+// startWatch backs the ?watch=true list streams with the control-plane
+// event log, waking every open stream from one shared change listener.
+// Returns nil without a connection, which leaves watch disabled.
+func startWatch(ctx context.Context, conn v1alpha1store.DB) *resource.WatchConfig {
+	if conn == nil {
+		return nil
+	}
+	broadcaster := v1alpha1store.NewControlPlaneBroadcaster(conn)
+	go func() {
+		for {
+			err := broadcaster.Listen(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Warn("watch change listener stopped; reconnecting", "error", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(5 * time.Second):
+			}
+		}
+	}()
+	return &resource.WatchConfig{
+		Events:  v1alpha1store.NewControlPlaneEventStore(conn, pkgdb.MustNewSchema(pkgdb.OSSSchema)),
+		Wakeups: broadcaster.Subscribe,
+	}
+}
+
 // crudPerKindHooks adapts the AppOptions per-kind authorizer +
 // list-filter maps (which use the public pkg/types signatures) into
 // the internal crud.PerKindHooks struct (which uses the
@@ -448,7 +700,10 @@ func runtimeAdapterDispatcher(
 }
 
 // openDatabase selects and constructs the base Store (plus any
-// DatabaseFactory wrap) and returns it. Two paths:
+// DatabaseFactory wrap) and returns it. Three paths:
+//   - Storage "sqlite:///path" opens the embedded SQLite database for
+//     single-binary local mode. DatabaseFactory and V1Alpha1StoreTables
+//     are PostgreSQL extensions and are refused.
 //   - DATABASE_URL="noop" requires options.DatabaseFactory to supply the
 //     Store entirely (e.g. in-memory or custom backend). Used by tests
 //     and noop runs.
@@ -465,6 +720,22 @@ func openDatabase(
 	authz auth.Authorizer,
 	skipMigrations bool,
 ) (pkgdb.Store, error) {
+	path, sqlite, err := v1alpha1store.ParseSQLiteStorage(cfg.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if sqlite {
+		if options.DatabaseFactory != nil || len(options.V1Alpha1StoreTables) > 0 {
+			return nil, fmt.Errorf("storage %q does not support DatabaseFactory or V1Alpha1StoreTables", cfg.Storage)
+		}
+		db, err := internaldb.NewSQLite(dbCtx, path)
+		if err != nil {
+			return nil, err
+		}
+		slog.Info("using embedded SQLite storage", "path", path)
+		return db, nil
+	}
+
 	if cfg.DatabaseURL == "noop" {
 		if options.DatabaseFactory == nil {
 			return nil, fmt.Errorf("DATABASE_URL=noop requires DatabaseFactory to be set in AppOptions")
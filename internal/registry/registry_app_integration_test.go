@@ -49,9 +49,10 @@ func (e *extensionApplyObject) UnmarshalStatus(data json.RawMessage) error {
 
 func TestBuildStores_ExtensionKindAppliesThroughBatchEndpoint(t *testing.T) {
 	pool := v1alpha1store.NewTestPool(t)
-	stores := buildStores(pool, map[string]string{
+	stores, err := buildStores(t.Context(), pool, map[string]string{
 		extensionApplyKind: "agents",
-	}, nil, nil)
+	}, nil, nil, nil)
+	require.NoError(t, err)
 	extensionStore := stores[extensionApplyKind]
 	require.NotNil(t, extensionStore)
 
@@ -99,12 +100,13 @@ spec:
 func TestBuildStores_PropagatesAuditor(t *testing.T) {
 	pool := v1alpha1store.NewTestPool(t)
 	auditor := &typestest.RecordingAuditor{}
-	stores := buildStores(pool, nil, nil, auditor)
+	stores, err := buildStores(t.Context(), pool, nil, nil, auditor, nil)
+	require.NoError(t, err)
 
 	agentStore := stores[v1alpha1.KindAgent]
 	require.NotNil(t, agentStore)
 
-	_, err := agentStore.Upsert(t.Context(), &v1alpha1.Agent{
+	_, err = agentStore.Upsert(t.Context(), &v1alpha1.Agent{
 		TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindAgent},
 		Metadata: v1alpha1.ObjectMeta{Namespace: v1alpha1.DefaultNamespace, Name: "audited"},
 		Spec:     v1alpha1.AgentSpec{Title: "Audited Agent"},
@@ -120,7 +122,8 @@ func TestBuildStores_PropagatesAuditor(t *testing.T) {
 
 	// Sanity: nil auditor still works (NoopAuditor fallback) — guards the
 	// nil-check branch in buildStores.
-	stores2 := buildStores(pool, nil, nil, nil)
+	stores2, err := buildStores(t.Context(), pool, nil, nil, nil, nil)
+	require.NoError(t, err)
 	require.NotNil(t, stores2[v1alpha1.KindAgent])
 	_ = types.NoopAuditor
 }
@@ -24,6 +24,7 @@ func TestDeploymentControllerConfigMapsRetentionSettings(t *testing.T) {
 		ControllerEventRetention:             2 * time.Hour,
 		ControllerEventKeepAfterRevision:     42,
 		ControllerRetentionPruneBatchLimit:   17,
+		AuditRetention:                       720 * time.Hour,
 		ControllerDiscoveryInterval:          15 * time.Second,
 		ControllerDiscoveryStaleAfterMisses:  2,
 		ControllerDiscoveryDeleteAfterMisses: 4,
@@ -33,6 +34,7 @@ func TestDeploymentControllerConfigMapsRetentionSettings(t *testing.T) {
 
 	require.Equal(t, 2*time.Hour, got.Retention.ControlPlaneEvents)
 	require.Equal(t, int64(42), got.Retention.EventKeepAfterRev)
+	require.Equal(t, 720*time.Hour, got.Retention.AuditEvents)
 	require.Equal(t, 17, got.Retention.BatchLimit)
 	require.Equal(t, 15*time.Second, got.DiscoveryInterval)
 	require.Equal(t, 2, got.DiscoveryStaleAfterMisses)
@@ -40,9 +42,12 @@ func TestDeploymentControllerConfigMapsRetentionSettings(t *testing.T) {
 }
 
 func TestBuildStoresAddsExtraStoreTables(t *testing.T) {
-	stores := buildStores(nil, map[string]string{
+	stores, err := buildStores(t.Context(), nil, map[string]string{
 		"ExtensionOnly": "extension_only",
-	}, nil, nil)
+	}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("buildStores: %v", err)
+	}
 	if stores["ExtensionOnly"] == nil {
 		t.Fatalf("extra v1alpha1 store was not registered")
 	}
@@ -115,14 +115,6 @@ func (a *kubernetesDeploymentAdapter) Remove(ctx context.Context, in types.Remov
 	}, nil
 }
 
-// Logs is not yet implemented for the kubernetes adapter. Returns an
-// immediately-closed channel so callers don't block.
-func (a *kubernetesDeploymentAdapter) Logs(ctx context.Context, in types.LogsInput) (<-chan types.LogLine, error) {
-	ch := make(chan types.LogLine)
-	close(ch)
-	return ch, nil
-}
-
 // buildDesiredStateFromV1Alpha1 constructs a *runtimetypes.DesiredState from
 // the v1alpha1 ApplyInput. Target dispatches by Kind — MCPServer goes
 // straight through translate; Agent walks every MCPServers ref via
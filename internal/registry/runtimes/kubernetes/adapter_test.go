@@ -211,17 +211,6 @@ func TestK8sV1Alpha1SupportedTargetKinds(t *testing.T) {
 	}
 }
 
-func TestK8sV1Alpha1Logs_ReturnsClosedChannel(t *testing.T) {
-	adapter := NewKubernetesDeploymentAdapter()
-	ch, err := adapter.Logs(context.Background(), adapterpkgtypes.LogsInput{})
-	if err != nil {
-		t.Fatalf("Logs: %v", err)
-	}
-	if _, open := <-ch; open {
-		t.Fatalf("expected closed channel")
-	}
-}
-
 // TestK8sV1Alpha1Apply_BundledMCPServerEnvFrom_CreatesResourceWithSecretRefs
 // walks the adapter's full apply path for a bundled MCPServer Deployment
 // carrying spec.envFrom and asserts the created kmcp MCPServer resource
@@ -0,0 +1,165 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+// Well-known Kubernetes recommended labels consulted when inferring the
+// registry name and tag of an unmanaged workload.
+const (
+	kubernetesAppNameLabelKey    = "app.kubernetes.io/name"
+	kubernetesAppVersionLabelKey = "app.kubernetes.io/version"
+)
+
+// Discover lists kagent Agents, kagent RemoteMCPServers and kmcp MCPServers
+// in the runtime namespace and reports the ones the registry did not create.
+// Objects carrying the managed labels/annotations Apply stamps (see
+// kubernetesDeploymentManagedLabels) already belong to a Deployment row and
+// are skipped; everything else is returned with its inferred target kind,
+// name and tag so the discovery controller can materialize it as an
+// origin=discovered Deployment.
+//
+// A kind whose CRD is not installed (kagent without kmcp, say) contributes
+// no objects rather than failing discovery for the whole runtime.
+//
+// The name comes from app.kubernetes.io/name, falling back to the object
+// name. The tag comes from app.kubernetes.io/version, falling back to the
+// container image tag when the object carries one.
+func (a *kubernetesDeploymentAdapter) Discover(ctx context.Context, in types.DiscoverInput) ([]types.DiscoveryResult, error) {
+	if in.Runtime == nil {
+		return nil, fmt.Errorf("discover: runtime is required")
+	}
+	namespace := kubernetesRuntimeNamespace(in.Runtime)
+	if namespace == "" {
+		namespace = kubernetesDefaultNamespace()
+	}
+	c, err := kubernetesGetClient(in.Runtime)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []types.DiscoveryResult
+
+	agents := &v1alpha2.AgentList{}
+	if err := kubernetesDiscoveryList(ctx, c, agents, namespace, "agents"); err != nil {
+		return nil, err
+	}
+	for i := range agents.Items {
+		agent := &agents.Items[i]
+		var image string
+		if agent.Spec.BYO != nil && agent.Spec.BYO.Deployment != nil {
+			image = agent.Spec.BYO.Deployment.Image
+		}
+		if r, ok := kubernetesDiscoveryResult(agent, v1alpha1.KindAgent, "Agent", image); ok {
+			out = append(out, r)
+		}
+	}
+
+	remotes := &v1alpha2.RemoteMCPServerList{}
+	if err := kubernetesDiscoveryList(ctx, c, remotes, namespace, "remote mcp servers"); err != nil {
+		return nil, err
+	}
+	for i := range remotes.Items {
+		if r, ok := kubernetesDiscoveryResult(&remotes.Items[i], v1alpha1.KindMCPServer, "RemoteMCPServer", ""); ok {
+			out = append(out, r)
+		}
+	}
+
+	servers := &kmcpv1alpha1.MCPServerList{}
+	if err := kubernetesDiscoveryList(ctx, c, servers, namespace, "mcp servers"); err != nil {
+		return nil, err
+	}
+	for i := range servers.Items {
+		server := &servers.Items[i]
+		if r, ok := kubernetesDiscoveryResult(server, v1alpha1.KindMCPServer, "MCPServer", server.Spec.Deployment.Image); ok {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+// kubernetesDiscoveryList lists one kind into list. A NoKindMatch or
+// NotFound error means the kind's CRD is not installed on the cluster; the
+// list is left empty and nil is returned so the other kinds are still
+// discovered.
+func kubernetesDiscoveryList(ctx context.Context, c client.Client, list client.ObjectList, namespace, what string) error {
+	err := c.List(ctx, list, client.InNamespace(namespace))
+	switch {
+	case err == nil:
+		return nil
+	case meta.IsNoMatchError(err) || apierrors.IsNotFound(err):
+		kubernetesLogger.Debug("skipping discovery of uninstalled kind", "kind", what, "namespace", namespace, "error", err)
+		return nil
+	default:
+		return fmt.Errorf("list %s in %s: %w", what, namespace, err)
+	}
+}
+
+// kubernetesDiscoveryResult builds the DiscoveryResult for one listed
+// object, or reports false when the object is owned by a registry
+// Deployment. resourceKind is the Kubernetes kind, recorded in
+// RuntimeMetadata so operators can find the object behind a discovered row.
+func kubernetesDiscoveryResult(obj metav1.Object, targetKind, resourceKind, image string) (types.DiscoveryResult, bool) {
+	if kubernetesIsRegistryManaged(obj) {
+		return types.DiscoveryResult{}, false
+	}
+	objLabels := obj.GetLabels()
+	name := strings.TrimSpace(objLabels[kubernetesAppNameLabelKey])
+	if name == "" {
+		name = obj.GetName()
+	}
+	tag := strings.TrimSpace(objLabels[kubernetesAppVersionLabelKey])
+	if tag == "" {
+		tag = kubernetesImageTag(image)
+	}
+	return types.DiscoveryResult{
+		TargetKind: targetKind,
+		Name:       name,
+		Tag:        tag,
+		RuntimeMetadata: map[string]string{
+			"kind":       resourceKind,
+			"namespace":  obj.GetNamespace(),
+			"remoteName": obj.GetName(),
+			"remoteId":   string(obj.GetUID()),
+		},
+	}, true
+}
+
+// kubernetesIsRegistryManaged reports whether obj was created by Apply for
+// a registry Deployment: it carries the managed label plus a deployment id
+// on either the label or the annotation.
+func kubernetesIsRegistryManaged(obj metav1.Object) bool {
+	if obj.GetLabels()[kubernetesManagedLabelKey] != "true" {
+		return false
+	}
+	return obj.GetLabels()[kubernetesDeploymentIDLabelKey] != "" ||
+		obj.GetAnnotations()[kubernetesDeploymentIDAnnotationKey] != ""
+}
+
+// kubernetesImageTag returns the tag of an image reference, or "" when the
+// reference is untagged or pinned only by digest. A registry port
+// (localhost:5000/img) is not mistaken for a tag.
+func kubernetesImageTag(image string) string {
+	image, _, _ = strings.Cut(strings.TrimSpace(image), "@")
+	if i := strings.LastIndex(image, "/"); i >= 0 {
+		image = image[i+1:]
+	}
+	_, tag, _ := strings.Cut(image, ":")
+	return tag
+}
+
+// Compile-time assertion that the kubernetes adapter can feed the
+// DeploymentDiscoveryController.
+var _ types.DeploymentDiscoverySource = (*kubernetesDeploymentAdapter)(nil)
@@ -0,0 +1,165 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	adapterpkgtypes "github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+func TestK8sV1Alpha1Discover_ReportsUnmanagedWorkloads(t *testing.T) {
+	withFakeKubeClient(t,
+		// Unmanaged BYO agent: name/tag from recommended labels.
+		&v1alpha2.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "kagent", Name: "planner-7f9c", UID: k8stypes.UID("uid-agent"),
+				Labels: map[string]string{kubernetesAppNameLabelKey: "planner", kubernetesAppVersionLabelKey: "2.1.0"},
+			},
+			Spec: v1alpha2.AgentSpec{
+				Type: v1alpha2.AgentType_BYO,
+				BYO:  &v1alpha2.BYOAgentSpec{Deployment: &v1alpha2.ByoDeploymentSpec{Image: "ghcr.io/org/planner:9.9.9"}},
+			},
+		},
+		// Registry-managed agent created by Apply: skipped.
+		&v1alpha2.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "kagent", Name: "weather-abc123",
+				Labels:      kubernetesDeploymentManagedLabels("weather-kube"),
+				Annotations: kubernetesDeploymentManagedAnnotations("weather-kube"),
+			},
+		},
+		// Unmanaged remote MCP server: no labels, no image => no tag.
+		&v1alpha2.RemoteMCPServer{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kagent", Name: "search"},
+			Spec:       v1alpha2.RemoteMCPServerSpec{URL: "https://search.example/mcp"},
+		},
+		// Unmanaged kmcp server: tag from image.
+		&kmcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kagent", Name: "fetch"},
+			Spec: kmcpv1alpha1.MCPServerSpec{
+				Deployment: kmcpv1alpha1.MCPServerDeployment{Image: "localhost:5000/mcp/fetch:1.4.0"},
+			},
+		},
+		// Outside the runtime namespace: not listed.
+		&kmcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "elsewhere"},
+		},
+	)
+	runtime := &v1alpha1.Runtime{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "kube-local"},
+		Spec:     v1alpha1.RuntimeSpec{Type: v1alpha1.TypeKubernetes, Config: map[string]any{"namespace": "kagent"}},
+	}
+
+	results, err := NewKubernetesDeploymentAdapter().Discover(context.Background(), adapterpkgtypes.DiscoverInput{Runtime: runtime})
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+
+	type key struct{ kind, name, tag string }
+	got := map[key]map[string]string{}
+	for _, r := range results {
+		got[key{r.TargetKind, r.Name, r.Tag}] = r.RuntimeMetadata
+	}
+	want := map[key]string{
+		{v1alpha1.KindAgent, "planner", "2.1.0"}:   "Agent",
+		{v1alpha1.KindMCPServer, "search", ""}:     "RemoteMCPServer",
+		{v1alpha1.KindMCPServer, "fetch", "1.4.0"}: "MCPServer",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("results = %+v, want %d entries", results, len(want))
+	}
+	for k, resourceKind := range want {
+		meta, ok := got[k]
+		if !ok {
+			t.Fatalf("missing discovery %+v in %+v", k, results)
+		}
+		if meta["kind"] != resourceKind || meta["namespace"] != "kagent" {
+			t.Fatalf("runtime metadata for %+v = %v", k, meta)
+		}
+	}
+	if meta := got[key{v1alpha1.KindAgent, "planner", "2.1.0"}]; meta["remoteName"] != "planner-7f9c" || meta["remoteId"] != "uid-agent" {
+		t.Fatalf("agent runtime metadata = %v, want object name and uid", meta)
+	}
+}
+
+func TestK8sV1Alpha1Discover_MissingCRDKeepsOtherKinds(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).
+		WithObjects(&v1alpha2.Agent{ObjectMeta: metav1.ObjectMeta{Namespace: "kagent", Name: "planner"}}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				switch list.(type) {
+				case *kmcpv1alpha1.MCPServerList:
+					return &apimeta.NoKindMatchError{GroupKind: schema.GroupKind{Group: "kagent.dev", Kind: "MCPServer"}}
+				case *v1alpha2.RemoteMCPServerList:
+					return apierrors.NewNotFound(schema.GroupResource{Group: "kagent.dev", Resource: "remotemcpservers"}, "")
+				}
+				return c.List(ctx, list, opts...)
+			},
+		}).Build()
+	withFakeKubeClient(t)
+	kubernetesNewClientForConfig = func(*rest.Config) (client.Client, error) { return fakeClient, nil }
+
+	runtime := &v1alpha1.Runtime{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "kube-local"},
+		Spec:     v1alpha1.RuntimeSpec{Type: v1alpha1.TypeKubernetes, Config: map[string]any{"namespace": "kagent"}},
+	}
+	results, err := NewKubernetesDeploymentAdapter().Discover(context.Background(), adapterpkgtypes.DiscoverInput{Runtime: runtime})
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+	if len(results) != 1 || results[0].TargetKind != v1alpha1.KindAgent || results[0].Name != "planner" {
+		t.Fatalf("results = %+v, want only the planner agent", results)
+	}
+}
+
+func TestK8sV1Alpha1Discover_ListErrorFails(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+				return apierrors.NewForbidden(schema.GroupResource{Group: "kagent.dev", Resource: "agents"}, "", errors.New("denied"))
+			},
+		}).Build()
+	withFakeKubeClient(t)
+	kubernetesNewClientForConfig = func(*rest.Config) (client.Client, error) { return fakeClient, nil }
+
+	runtime := &v1alpha1.Runtime{Spec: v1alpha1.RuntimeSpec{Type: v1alpha1.TypeKubernetes, Config: map[string]any{"namespace": "kagent"}}}
+	if _, err := NewKubernetesDeploymentAdapter().Discover(context.Background(), adapterpkgtypes.DiscoverInput{Runtime: runtime}); err == nil {
+		t.Fatalf("expected a forbidden list to fail discovery")
+	}
+}
+
+func TestK8sV1Alpha1Discover_RequiresRuntime(t *testing.T) {
+	if _, err := NewKubernetesDeploymentAdapter().Discover(context.Background(), adapterpkgtypes.DiscoverInput{}); err == nil {
+		t.Fatalf("expected error for missing runtime")
+	}
+}
+
+func TestKubernetesImageTag(t *testing.T) {
+	tests := map[string]string{
+		"ghcr.io/org/img:1.2.3":          "1.2.3",
+		"localhost:5000/img":             "",
+		"localhost:5000/img:v2":          "v2",
+		"img@sha256:abc":                 "",
+		"ghcr.io/org/img:1.0@sha256:abc": "1.0",
+		"":                               "",
+	}
+	for image, want := range tests {
+		if got := kubernetesImageTag(image); got != want {
+			t.Errorf("kubernetesImageTag(%q) = %q, want %q", image, got, want)
+		}
+	}
+}
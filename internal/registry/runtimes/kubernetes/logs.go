@@ -0,0 +1,166 @@
+package kubernetes
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+// maxKubernetesLogLineBytes bounds a single log record. Longer lines are
+// emitted as several records, each stamped with the line's timestamp,
+// rather than aborting the whole stream.
+const maxKubernetesLogLineBytes = 1 << 20
+
+// Logs streams container logs from every pod carrying the Deployment's
+// managed labels (see kubernetesDeploymentManagedLabels), which the
+// translators stamp on the kagent and kmcp pod templates. One stream is
+// opened per pod container; lines from all streams are interleaved on the
+// returned channel as they arrive, with LogLine.Stream set to
+// "<pod>/<container>". Follow and TailLines map onto PodLogOptions, so
+// TailLines bounds each container's backlog individually.
+//
+// A container whose stream cannot be opened (still creating, evicted) is
+// skipped; an error is returned only when no stream could be opened at all.
+// The channel closes once every stream has ended or ctx is cancelled.
+func (a *kubernetesDeploymentAdapter) Logs(ctx context.Context, in types.LogsInput) (<-chan types.LogLine, error) {
+	if in.Deployment == nil {
+		return nil, fmt.Errorf("logs: deployment is required")
+	}
+	namespace := namespaceFromV1Alpha1(in.Deployment, in.Runtime)
+	cs, err := kubernetesGetClientset(in.Runtime)
+	if err != nil {
+		return nil, err
+	}
+
+	selector := labels.SelectorFromSet(kubernetesDeploymentManagedLabels(in.Deployment.Metadata.Name)).String()
+	pods, err := cs.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("list pods for deployment %s: %w", in.Deployment.Metadata.Name, err)
+	}
+
+	var (
+		streams []kubernetesLogStream
+		errs    []error
+	)
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			opts := &corev1.PodLogOptions{
+				Container:  container.Name,
+				Follow:     in.Follow,
+				Timestamps: true,
+			}
+			if in.TailLines > 0 {
+				tail := int64(in.TailLines)
+				opts.TailLines = &tail
+			}
+			rc, err := cs.CoreV1().Pods(namespace).GetLogs(pod.Name, opts).Stream(ctx)
+			if err != nil {
+				kubernetesLogger.Warn("skipping container log stream", "pod", pod.Name, "container", container.Name, "error", err)
+				errs = append(errs, fmt.Errorf("open logs for %s/%s: %w", pod.Name, container.Name, err))
+				continue
+			}
+			streams = append(streams, kubernetesLogStream{id: pod.Name + "/" + container.Name, body: rc})
+		}
+	}
+	if len(streams) == 0 && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	ch := make(chan types.LogLine)
+	var wg sync.WaitGroup
+	for _, s := range streams {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.pump(ctx, ch)
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(ch)
+	}()
+	return ch, nil
+}
+
+// kubernetesLogStream is one open container log body.
+type kubernetesLogStream struct {
+	id   string
+	body io.ReadCloser
+}
+
+// pump forwards every line of the stream to out until the body ends or ctx
+// is cancelled. Closing the body on cancellation unblocks a pending read in
+// follow mode. A read error ends the stream with a final record saying so,
+// so a consumer can tell a broken stream from one that ended.
+func (s kubernetesLogStream) pump(ctx context.Context, out chan<- types.LogLine) {
+	stop := context.AfterFunc(ctx, func() { _ = s.body.Close() })
+	defer func() {
+		if stop() {
+			_ = s.body.Close()
+		}
+	}()
+
+	send := func(line types.LogLine) bool {
+		select {
+		case out <- line:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	r := bufio.NewReaderSize(s.body, maxKubernetesLogLineBytes)
+	var (
+		ts        time.Time
+		continued bool
+	)
+	for {
+		chunk, err := r.ReadSlice('\n')
+		split := errors.Is(err, bufio.ErrBufferFull)
+		if len(chunk) > 0 {
+			raw := string(chunk)
+			if !split {
+				raw = strings.TrimSuffix(strings.TrimSuffix(raw, "\n"), "\r")
+			}
+			line := raw
+			if !continued {
+				ts, line = splitKubernetesLogTimestamp(raw)
+			}
+			if !send(types.LogLine{Timestamp: ts, Stream: s.id, Line: line}) {
+				return
+			}
+		}
+		continued = split
+		switch {
+		case err == nil || split:
+		case errors.Is(err, io.EOF) || ctx.Err() != nil:
+			return
+		default:
+			kubernetesLogger.Warn("container log stream failed", "stream", s.id, "error", err)
+			send(types.LogLine{Timestamp: time.Now().UTC(), Stream: s.id, Line: fmt.Sprintf("log stream ended early: %v", err)})
+			return
+		}
+	}
+}
+
+// splitKubernetesLogTimestamp strips the RFC3339 prefix the kubelet adds
+// when PodLogOptions.Timestamps is set. Lines without one are stamped with
+// the time they were received.
+func splitKubernetesLogTimestamp(raw string) (time.Time, string) {
+	if prefix, rest, ok := strings.Cut(raw, " "); ok {
+		if ts, err := time.Parse(time.RFC3339Nano, prefix); err == nil {
+			return ts.UTC(), rest
+		}
+	}
+	return time.Now().UTC(), raw
+}
@@ -0,0 +1,246 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	k8sclientset "k8s.io/client-go/kubernetes"
+	clientsetfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+
+	runtimetypes "github.com/agentregistry-dev/agentregistry/internal/registry/runtimes/types"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	adapterpkgtypes "github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+func withFakeClientset(t *testing.T, objs ...k8sruntime.Object) *clientsetfake.Clientset {
+	t.Helper()
+	cs := clientsetfake.NewClientset(objs...)
+	originalAmbientRESTConfig := kubernetesGetAmbientRESTConfig
+	originalNewClientsetForConfig := kubernetesNewClientsetForConfig
+	t.Cleanup(func() {
+		kubernetesGetAmbientRESTConfig = originalAmbientRESTConfig
+		kubernetesNewClientsetForConfig = originalNewClientsetForConfig
+	})
+	kubernetesGetAmbientRESTConfig = func() (*rest.Config, error) {
+		return &rest.Config{Host: "https://fake.test"}, nil
+	}
+	kubernetesNewClientsetForConfig = func(*rest.Config) (k8sclientset.Interface, error) {
+		return cs, nil
+	}
+	return cs
+}
+
+func testLogsPod(name string, podLabels map[string]string, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "kagent", Name: name, Labels: podLabels}}
+	for _, c := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: c})
+	}
+	return pod
+}
+
+// testLogsDesiredPods translates one agent and one local MCP server owned by
+// deploymentID and labels their pods the way the kagent and kmcp controllers
+// build pod templates: their own default labels merged with the CR's
+// deployment labels.
+func testLogsDesiredPods(t *testing.T, deploymentID string) (agentPod, mcpPod map[string]string) {
+	t.Helper()
+	config, err := kubernetesTranslateRuntimeConfig(context.Background(), &runtimetypes.DesiredState{
+		Agents: []*runtimetypes.Agent{{
+			Name:         "weather",
+			DeploymentID: deploymentID,
+			Deployment: runtimetypes.AgentDeployment{
+				Image: "weather:latest",
+				Env:   map[string]string{"KAGENT_NAMESPACE": "kagent"},
+			},
+		}},
+		MCPServers: []*runtimetypes.MCPServer{{
+			Name:          "weather-tools",
+			DeploymentID:  deploymentID,
+			MCPServerType: runtimetypes.MCPServerTypeLocal,
+			Namespace:     "kagent",
+			Local: &runtimetypes.LocalMCPServer{
+				TransportType: runtimetypes.TransportTypeStdio,
+				Deployment:    runtimetypes.MCPServerDeployment{Image: "tools:latest"},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("kubernetesTranslateRuntimeConfig: %v", err)
+	}
+	agent := config.Agents[0]
+	agentPod = map[string]string{"app": "kagent", "kagent": agent.Name}
+	maps.Copy(agentPod, agent.Spec.BYO.Deployment.Labels)
+
+	server := config.MCPServers[0]
+	mcpPod = map[string]string{
+		"app.kubernetes.io/name":       server.Name,
+		"app.kubernetes.io/instance":   server.Name,
+		"app.kubernetes.io/managed-by": "kmcp",
+	}
+	maps.Copy(mcpPod, server.Spec.Deployment.Labels)
+	return agentPod, mcpPod
+}
+
+func TestK8sV1Alpha1Logs_StreamsManagedPodContainers(t *testing.T) {
+	agentPod, mcpPod := testLogsDesiredPods(t, "weather-kube")
+	otherAgentPod, _ := testLogsDesiredPods(t, "other")
+	cs := withFakeClientset(t,
+		testLogsPod("weather-0", agentPod, "agent", "sidecar"),
+		testLogsPod("weather-tools-0", mcpPod, "mcp"),
+		testLogsPod("other-0", otherAgentPod, "agent"),
+		testLogsPod("unmanaged-0", map[string]string{"app": "kagent", "kagent": "hand-made"}, "agent"),
+	)
+	runtime := &v1alpha1.Runtime{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "kube-local"},
+		Spec:     v1alpha1.RuntimeSpec{Type: v1alpha1.TypeKubernetes, Config: map[string]any{"namespace": "kagent"}},
+	}
+	deployment := &v1alpha1.Deployment{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "weather-kube"},
+	}
+
+	ch, err := NewKubernetesDeploymentAdapter().Logs(context.Background(), adapterpkgtypes.LogsInput{
+		Deployment: deployment,
+		Runtime:    runtime,
+		TailLines:  25,
+	})
+	if err != nil {
+		t.Fatalf("Logs: %v", err)
+	}
+	var streams []string
+	for line := range ch {
+		if line.Line != "fake logs" {
+			t.Fatalf("line = %q, want fake clientset body", line.Line)
+		}
+		if line.Timestamp.IsZero() {
+			t.Fatalf("line from %s has no timestamp", line.Stream)
+		}
+		streams = append(streams, line.Stream)
+	}
+	slices.Sort(streams)
+	want := []string{"weather-0/agent", "weather-0/sidecar", "weather-tools-0/mcp"}
+	if !slices.Equal(streams, want) {
+		t.Fatalf("streams = %v, want %v", streams, want)
+	}
+
+	var logOpts []*corev1.PodLogOptions
+	for _, action := range cs.Actions() {
+		if action.GetSubresource() != "log" {
+			continue
+		}
+		generic, ok := action.(k8stesting.GenericAction)
+		if !ok {
+			t.Fatalf("unexpected log action type %T", action)
+		}
+		if action.GetNamespace() != "kagent" {
+			t.Fatalf("log action namespace = %q, want kagent", action.GetNamespace())
+		}
+		logOpts = append(logOpts, generic.GetValue().(*corev1.PodLogOptions))
+	}
+	if len(logOpts) != len(want) {
+		t.Fatalf("opened %d log streams, want %d", len(logOpts), len(want))
+	}
+	for _, opts := range logOpts {
+		if opts.Follow || opts.TailLines == nil || *opts.TailLines != 25 || !opts.Timestamps {
+			t.Fatalf("PodLogOptions = %+v, want follow=false tailLines=25 timestamps=true", opts)
+		}
+	}
+}
+
+func TestK8sV1Alpha1Logs_NoPodsClosesChannel(t *testing.T) {
+	withFakeClientset(t)
+	ch, err := NewKubernetesDeploymentAdapter().Logs(context.Background(), adapterpkgtypes.LogsInput{
+		Deployment: &v1alpha1.Deployment{Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "missing"}},
+		Follow:     true,
+	})
+	if err != nil {
+		t.Fatalf("Logs: %v", err)
+	}
+	if _, open := <-ch; open {
+		t.Fatalf("expected closed channel")
+	}
+}
+
+func TestK8sV1Alpha1Logs_RequiresDeployment(t *testing.T) {
+	if _, err := NewKubernetesDeploymentAdapter().Logs(context.Background(), adapterpkgtypes.LogsInput{}); err == nil {
+		t.Fatalf("expected error for missing deployment")
+	}
+}
+
+func TestSplitKubernetesLogTimestamp(t *testing.T) {
+	ts, line := splitKubernetesLogTimestamp("2026-01-02T03:04:05.123456789Z listening on :8080")
+	if line != "listening on :8080" {
+		t.Fatalf("line = %q", line)
+	}
+	if want := time.Date(2026, 1, 2, 3, 4, 5, 123456789, time.UTC); !ts.Equal(want) {
+		t.Fatalf("timestamp = %v, want %v", ts, want)
+	}
+
+	before := time.Now().UTC()
+	ts, line = splitKubernetesLogTimestamp("no timestamp here")
+	if line != "no timestamp here" || ts.Before(before) {
+		t.Fatalf("got (%v, %q), want receive-time stamp and unchanged line", ts, line)
+	}
+}
+
+// failingLogBody returns data, then err instead of io.EOF.
+type failingLogBody struct {
+	data *strings.Reader
+	err  error
+}
+
+func (b *failingLogBody) Read(p []byte) (int, error) {
+	if b.data.Len() == 0 {
+		return 0, b.err
+	}
+	return b.data.Read(p)
+}
+
+func (b *failingLogBody) Close() error { return nil }
+
+func TestKubernetesLogStream_SplitsLongLinesAndReportsReadErrors(t *testing.T) {
+	long := strings.Repeat("x", 2*maxKubernetesLogLineBytes+10)
+	body := &failingLogBody{
+		data: strings.NewReader("2026-01-02T03:04:05Z " + long + "\nnext line\r\n"),
+		err:  errors.New("connection reset"),
+	}
+	out := make(chan adapterpkgtypes.LogLine)
+	go func() {
+		kubernetesLogStream{id: "weather-0/agent", body: body}.pump(context.Background(), out)
+		close(out)
+	}()
+	var lines []adapterpkgtypes.LogLine
+	for line := range out {
+		lines = append(lines, line)
+	}
+
+	if len(lines) != 5 {
+		t.Fatalf("got %d records, want 3 chunks, the next line and the error", len(lines))
+	}
+	var joined strings.Builder
+	want := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, line := range lines[:3] {
+		if !line.Timestamp.Equal(want) {
+			t.Fatalf("chunk timestamp = %v, want the line's %v", line.Timestamp, want)
+		}
+		joined.WriteString(line.Line)
+	}
+	if joined.String() != long {
+		t.Fatalf("chunks do not reassemble the long line")
+	}
+	if lines[3].Line != "next line" {
+		t.Fatalf("line after the long one = %q", lines[3].Line)
+	}
+	if !strings.Contains(lines[4].Line, "connection reset") {
+		t.Fatalf("last record = %q, want the read error", lines[4].Line)
+	}
+}
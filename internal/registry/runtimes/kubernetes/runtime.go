@@ -15,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	k8sclientset "k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -43,6 +44,11 @@ var (
 	kubernetesNewClientForConfig   = func(restConfig *rest.Config) (client.Client, error) {
 		return client.New(restConfig, client.Options{Scheme: kubernetesScheme})
 	}
+	// kubernetesNewClientsetForConfig builds the typed clientset used for
+	// subresources controller-runtime's client cannot stream (pod logs).
+	kubernetesNewClientsetForConfig = func(restConfig *rest.Config) (k8sclientset.Interface, error) {
+		return k8sclientset.NewForConfig(restConfig)
+	}
 	kubernetesLogger = logging.New("kubernetes-runtime")
 )
 
@@ -161,6 +167,19 @@ func kubernetesGetClient(runtime *v1alpha1.Runtime) (client.Client, error) {
 	return c, nil
 }
 
+func kubernetesGetClientset(runtime *v1alpha1.Runtime) (k8sclientset.Interface, error) {
+	restConfig, err := kubernetesRESTConfig(runtime)
+	if err != nil {
+		return nil, err
+	}
+
+	cs, err := kubernetesNewClientsetForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes clientset: %w", err)
+	}
+	return cs, nil
+}
+
 func kubernetesRuntimeNamespace(runtime *v1alpha1.Runtime) string {
 	runtimeCfg, err := kubernetesRuntimeConfig(runtime)
 	if err != nil || runtimeCfg == nil {
@@ -298,7 +317,13 @@ func kubernetesTranslateAgent(agent *runtimetypes.Agent) (*v1alpha2.Agent, error
 		}
 	}
 
-	sharedSpec := v1alpha2.SharedDeploymentSpec{Env: envVars}
+	// The managed labels go on the pod template as well as the Agent CR so
+	// pods can be found by Deployment (see Logs); kagent only labels its
+	// pods with app/kagent otherwise.
+	sharedSpec := v1alpha2.SharedDeploymentSpec{
+		Env:    envVars,
+		Labels: kubernetesDeploymentManagedLabels(agent.DeploymentID),
+	}
 	// MCP server config is now injected via MCP_SERVERS_CONFIG env var (set by ResolveAgent).
 	// ConfigMap volume mount is only needed for prompts.json.
 	if len(agent.ResolvedPrompts) > 0 {
@@ -486,6 +511,9 @@ func kubernetesTranslateLocalMCPServer(server *runtimetypes.MCPServer) (*kmcpv1a
 		Args:       server.Local.Deployment.Args,
 		Env:        server.Local.Deployment.Env,
 		SecretRefs: secretLocalObjectRefs(server.Local.Deployment.SecretRefs),
+		// kmcp merges these into its pod template labels, which is what
+		// Logs selects on.
+		Labels: kubernetesDeploymentManagedLabels(server.DeploymentID),
 	}
 
 	spec := kmcpv1alpha1.MCPServerSpec{Deployment: deployment}
@@ -57,6 +57,7 @@ func (r *AdapterResolver) Logs(ctx context.Context, deployment *v1alpha1.Deploym
 		return nil, err
 	}
 	in.Deployment = deployment
+	in.Runtime = runtime
 	return adapter.Logs(ctx, in)
 }
 
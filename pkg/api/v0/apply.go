@@ -9,6 +9,11 @@ type ApplyResult struct {
 	Namespace  string `json:"namespace,omitempty"`
 	Name       string `json:"name"`
 	Tag        string `json:"tag,omitempty"`
+	// Digest is the content digest (sha256:<hex>) of a tagged artifact: the
+	// content that was stored, or would be on a dry run. Also set when the
+	// apply failed with ApplyReasonSignatureRequired, naming the content
+	// to sign.
+	Digest string `json:"digest,omitempty"`
 	// Status is one of: created, configured, unchanged, staged, deleted,
 	// dry-run, failed. Matches kubectl-style apply output.
 	Status string `json:"status"`
@@ -17,10 +22,52 @@ type ApplyResult struct {
 	// convergence marker; hidden from the wire because generation is
 	// not part of the user-facing API today.
 	Generation int64 `json:"-"`
+	// ResourceVersion is the object's metadata.resourceVersion after a
+	// successful write.
+	ResourceVersion string `json:"resourceVersion,omitempty"`
 	// Error is the failure detail for Status=="failed".
 	Error string `json:"error,omitempty"`
+	// Reason classifies a failure when clients can act on it. See the
+	// ApplyReason* constants.
+	Reason string `json:"reason,omitempty"`
+	// Warnings name the deprecated or yanked tags the document refers to.
+	// They do not fail the apply.
+	Warnings []string `json:"warnings,omitempty"`
 }
 
+// ApplyReasonConflict marks a failed apply whose metadata.resourceVersion
+// no longer matched the stored object. Re-read the object, reconcile the
+// change and apply again.
+const ApplyReasonConflict = "Conflict"
+
+// ApplyReasonTagImmutable marks a failed apply that would have changed the
+// content of a tag the registry's tag policy protects. Publish the change
+// under a new tag.
+const ApplyReasonTagImmutable = "TagImmutable"
+
+// ApplyReasonNamespaceNotFound marks a failed apply into a namespace no
+// Namespace object declares. Apply the Namespace first.
+const ApplyReasonNamespaceNotFound = "NamespaceNotFound"
+
+// ApplyReasonReferenced marks a failed delete of a resource other live
+// objects still reference. Update or delete the referrers first, or delete
+// with force.
+const ApplyReasonReferenced = "Referenced"
+
+// ApplyReasonSignatureRequired marks a failed apply of content the
+// signature policy requires a trusted signature for and that has none.
+// Sign the reported digest with a trusted key and apply again.
+const ApplyReasonSignatureRequired = "SignatureRequired"
+
+// ApplyReasonApprovalPending marks a successful apply of content the
+// namespace's approval policy holds for review. The content is stored, but
+// refs and deployments ignore it until a reviewer approves its digest.
+const ApplyReasonApprovalPending = "ApprovalPending"
+
+// ApplyReasonApprovalRejected marks a successful apply of content a
+// reviewer has rejected. Refs and deployments keep ignoring it.
+const ApplyReasonApprovalRejected = "ApprovalRejected"
+
 // ApplyStatus* are the well-known Status values on ApplyResult.
 const (
 	ApplyStatusCreated    = "created"
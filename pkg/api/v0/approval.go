@@ -0,0 +1,56 @@
+package v0
+
+import "time"
+
+// Approval states of an ApprovalEntry. Content published into a namespace
+// with an approval policy is Pending until a reviewer approves or rejects
+// it; only Approved content is resolved by refs or deployed.
+const (
+	ApprovalPending  = "pending"
+	ApprovalApproved = "approved"
+	ApprovalRejected = "rejected"
+)
+
+// Review decisions accepted by POST /v0/approvals.
+const (
+	ApprovalDecisionApprove = "approve"
+	ApprovalDecisionReject  = "reject"
+)
+
+// ApprovalEntry is the review state of one content digest of a tagged
+// artifact, as listed by GET /v0/approvals.
+type ApprovalEntry struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Tag is the tag the content was published under when review was
+	// requested.
+	Tag string `json:"tag"`
+	// Digest is the content digest (sha256:<hex>) under review.
+	Digest      string    `json:"digest"`
+	State       string    `json:"state"`
+	RequestedBy string    `json:"requestedBy"`
+	RequestedAt time.Time `json:"requestedAt"`
+	// ReviewedBy, ReviewedAt and Comment are set once a reviewer decides.
+	ReviewedBy string     `json:"reviewedBy,omitempty"`
+	ReviewedAt *time.Time `json:"reviewedAt,omitempty"`
+	Comment    string     `json:"comment,omitempty"`
+}
+
+// ApprovalListResponse is the response body for GET /v0/approvals.
+type ApprovalListResponse struct {
+	Items []ApprovalEntry `json:"items"`
+}
+
+// ApprovalReview is the request body for POST /v0/approvals.
+type ApprovalReview struct {
+	Kind      string `json:"kind" doc:"Kind of the artifact under review, e.g. Agent."`
+	Namespace string `json:"namespace,omitempty" doc:"Namespace (defaults to 'default')."`
+	Name      string `json:"name"`
+	// Tag and Digest select the content: Digest when set, otherwise the
+	// content Tag holds now.
+	Tag      string `json:"tag,omitempty" doc:"Review the content this tag holds now. Defaults to 'latest'; ignored when digest is set."`
+	Digest   string `json:"digest,omitempty" doc:"Review this content digest (sha256:<hex>) instead of a tag's."`
+	Decision string `json:"decision" enum:"approve,reject"`
+	Comment  string `json:"comment,omitempty" doc:"Reviewer comment, recorded with the decision and in the audit log."`
+}
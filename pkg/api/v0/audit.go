@@ -0,0 +1,39 @@
+package v0
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEntry is one record of the audit log: a create, update, delete or
+// authorization denial.
+type AuditEntry struct {
+	ID   int64     `json:"id"`
+	Time time.Time `json:"time"`
+	// RequestID is the X-Request-Id of the HTTP request that made the
+	// change. Empty for changes made by the server itself.
+	RequestID string `json:"requestId,omitempty"`
+	// Principal is the authenticated subject, or "system", "public" or
+	// "anonymous" for callers without one.
+	Principal string `json:"principal"`
+	// Verb is create, update, delete, deny, sign, approve, reject,
+	// deprecate, undeprecate, yank or unyank.
+	Verb      string `json:"verb"`
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Tag       string `json:"tag,omitempty"`
+	// Reason explains denials and admin overrides.
+	Reason string `json:"reason,omitempty"`
+	// Diff is a JSON merge patch (RFC 7386) from the previous state to the
+	// new one: metadata.labels, metadata.annotations and spec for create
+	// and update.
+	Diff json.RawMessage `json:"diff,omitempty"`
+}
+
+// AuditListResponse is the response body for GET /v0/audit.
+type AuditListResponse struct {
+	Items []AuditEntry `json:"items"`
+	// NextCursor fetches the next, older page when set.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
@@ -0,0 +1,50 @@
+package v0
+
+// Graph node states. A Resolved node was fetched and its refs followed; a
+// Missing node is a ref nothing matches (deleted, never applied, awaiting
+// approval, or a range no live tag satisfies); a Terminating node is soft-deleted and waiting on
+// finalizers; a Forbidden node exists as a ref but the caller may not read
+// it, so it is neither fetched nor followed.
+const (
+	GraphNodeResolved    = "Resolved"
+	GraphNodeMissing     = "Missing"
+	GraphNodeTerminating = "Terminating"
+	GraphNodeForbidden   = "Forbidden"
+)
+
+// GraphNode is one resource in a GET /v0/graph response.
+type GraphNode struct {
+	// ID is "Kind/namespace/name", with an "@tag" suffix for tagged
+	// artifacts. Resolved nodes carry the concrete tag; unresolved nodes
+	// carry the tag as written on the ref.
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Tag       string `json:"tag,omitempty"`
+	Status    string `json:"status"`
+	// Message explains a Missing or Forbidden node.
+	Message string `json:"message,omitempty"`
+}
+
+// GraphEdge is one ref from a node's spec to another node.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Selector is the tag as written on the ref: empty (latest), a literal
+	// tag or a semver range. The To node carries what it resolved to.
+	Selector string `json:"selector,omitempty"`
+}
+
+// GraphResponse is the response body for GET /v0/graph.
+type GraphResponse struct {
+	Root  string      `json:"root"`
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+	// Cycles lists each ref cycle as the node IDs along it, starting and
+	// ending with the same node.
+	Cycles [][]string `json:"cycles,omitempty"`
+	// Truncated is set when the walk stopped at the node limit; the
+	// returned nodes are a prefix of the closure.
+	Truncated bool `json:"truncated,omitempty"`
+}
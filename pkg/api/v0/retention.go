@@ -0,0 +1,37 @@
+package v0
+
+import "time"
+
+// Reasons a tag is pruned by tag retention.
+const (
+	// TagRetentionReasonKeepLast: a newer KeepLast semver tags of the same
+	// name outrank it.
+	TagRetentionReasonKeepLast = "KeepLast"
+	// TagRetentionReasonMaxAge: it has not been written for longer than
+	// the rule's MaxAge.
+	TagRetentionReasonMaxAge = "MaxAge"
+)
+
+// TagRetentionItem is one tag a retention pass prunes, or would prune but
+// for live references to it.
+type TagRetentionItem struct {
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Tag       string    `json:"tag"`
+	Reason    string    `json:"reason"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	// ReferencedBy lists the live objects holding the tag, as
+	// "Kind namespace/name@tag". Set on held items only.
+	ReferencedBy []string `json:"referencedBy,omitempty"`
+}
+
+// TagRetentionReport is the response body for GET /v0/retention/tags: what
+// the next retention pass would prune.
+type TagRetentionReport struct {
+	// Prune lists the tags the pass deletes.
+	Prune []TagRetentionItem `json:"prune"`
+	// Held lists tags the policy selects but live objects still
+	// reference; they are kept until the references move.
+	Held []TagRetentionItem `json:"held"`
+}
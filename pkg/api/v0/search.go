@@ -0,0 +1,36 @@
+package v0
+
+// SearchHit is one ranked match in a GET /v0/search response.
+type SearchHit struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Tag is set for tagged artifacts; search matches the literal "latest"
+	// tag only, so it is always "latest" today.
+	Tag         string            `json:"tag,omitempty"`
+	Title       string            `json:"title,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	// Score is the full-text rank. Higher is better; values are only
+	// meaningful relative to other hits of the same query.
+	Score float64 `json:"score"`
+	// Highlight is an excerpt of the title/description with matched terms
+	// wrapped in <mark>…</mark>.
+	Highlight string `json:"highlight,omitempty"`
+}
+
+// SearchFacets counts every match, not just the returned page, per kind
+// and per label key/value.
+type SearchFacets struct {
+	Kinds  map[string]int            `json:"kinds"`
+	Labels map[string]map[string]int `json:"labels"`
+}
+
+// SearchResponse is the response body for GET /v0/search.
+type SearchResponse struct {
+	Query string `json:"query"`
+	// Total is the number of matches across every searched kind.
+	Total  int          `json:"total"`
+	Hits   []SearchHit  `json:"hits"`
+	Facets SearchFacets `json:"facets"`
+}
@@ -0,0 +1,41 @@
+package v0
+
+import "encoding/json"
+
+// Watch event types streamed by GET /v0/{plural}?watch=true. They follow
+// the Kubernetes watch protocol.
+const (
+	WatchEventAdded    = "ADDED"
+	WatchEventModified = "MODIFIED"
+	WatchEventDeleted  = "DELETED"
+	// WatchEventBookmark carries no object; it reports the revision the
+	// stream has read up to so an idle watcher can resume cheaply. The
+	// first bookmark of a watch started without resourceVersion also
+	// marks the end of the initial ADDED snapshot.
+	WatchEventBookmark = "BOOKMARK"
+	// WatchEventError ends the stream. Error.Status 410 means the
+	// watcher fell behind event retention: list again and watch from the
+	// list's resourceVersion.
+	WatchEventError = "ERROR"
+)
+
+// WatchEvent is one frame of a watch stream: a JSON line, or the data of
+// a server-sent event whose event name is Type and whose id is
+// ResourceVersion.
+type WatchEvent struct {
+	Type string `json:"type"`
+	// ResourceVersion is the control-plane revision of the change. Pass
+	// it back as ?resourceVersion= to resume after this event.
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+	// Object is the resource envelope as returned by GET. ADDED and
+	// MODIFIED carry the object as of delivery, so rapid changes may
+	// coalesce; DELETED carries identity metadata only.
+	Object json.RawMessage `json:"object,omitempty"`
+	Error  *WatchError     `json:"error,omitempty"`
+}
+
+// WatchError is the payload of an ERROR event.
+type WatchError struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
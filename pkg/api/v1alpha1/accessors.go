@@ -279,6 +279,21 @@ func (m *Model) UnmarshalStatus(data json.RawMessage) error {
 	return UnmarshalStatusFromStorage(data, &m.Status)
 }
 
+func (n *Namespace) GetMetadata() *ObjectMeta { return &n.Metadata }
+func (n *Namespace) SetMetadata(meta ObjectMeta) {
+	n.Metadata = meta
+}
+func (n *Namespace) MarshalSpec() (json.RawMessage, error) { return json.Marshal(n.Spec) }
+func (n *Namespace) UnmarshalSpec(data json.RawMessage) error {
+	return json.Unmarshal(data, &n.Spec)
+}
+func (n *Namespace) MarshalStatus() (json.RawMessage, error) {
+	return MarshalStatusForStorage(n.Status)
+}
+func (n *Namespace) UnmarshalStatus(data json.RawMessage) error {
+	return UnmarshalStatusFromStorage(data, &n.Status)
+}
+
 func (d *Deployment) GetMetadata() *ObjectMeta { return &d.Metadata }
 func (d *Deployment) SetMetadata(meta ObjectMeta) {
 	d.Metadata = meta
@@ -0,0 +1,47 @@
+package v1alpha1
+
+import "fmt"
+
+// ApprovalPolicy protects a namespace's curated catalog: publishes of the
+// covered kinds land pending review instead of being used straight away.
+// Approvals, like signatures, attach to the content digest of a tag, so
+// re-applying approved content needs no second review and changed content
+// always does.
+//
+// Until a reviewer approves it, a pending tag is stored and readable but
+// ignored by reference resolution: refs that select it dangle, semver
+// ranges skip it, and the Deployment controller does not deploy it.
+type ApprovalPolicy struct {
+	// Required turns review on.
+	Required bool `json:"required,omitempty" yaml:"required,omitempty"`
+	// Kinds limits the policy to the listed tagged kinds. Empty means every
+	// tagged kind.
+	Kinds []string `json:"kinds,omitempty" yaml:"kinds,omitempty"`
+}
+
+// Requires reports whether publishes of kind need review. Untagged kinds
+// are never covered.
+func (p ApprovalPolicy) Requires(kind string) bool {
+	if !p.Required || !IsTaggedArtifactKind(kind) {
+		return false
+	}
+	if len(p.Kinds) == 0 {
+		return true
+	}
+	for _, k := range p.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (p ApprovalPolicy) validate(prefix string) FieldErrors {
+	var errs FieldErrors
+	for i, kind := range p.Kinds {
+		if !IsTaggedArtifactKind(kind) {
+			errs.Append(fmt.Sprintf("%s.kinds[%d]", prefix, i), fmt.Errorf("%w: %q is not a tagged kind", ErrInvalidFormat, kind))
+		}
+	}
+	return errs
+}
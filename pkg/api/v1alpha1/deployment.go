@@ -57,8 +57,13 @@ const (
 // RuntimeRef is required and must name a top-level Runtime. The Runtime
 // resolves how and where the target is executed (Kubernetes or a downstream runtime).
 type DeploymentSpec struct {
-	TargetRef  ResourceRef `json:"targetRef" yaml:"targetRef"`
-	RuntimeRef ResourceRef `json:"runtimeRef" yaml:"runtimeRef"`
+	TargetRef ResourceRef `json:"targetRef" yaml:"targetRef"`
+	// TargetDigest pins the deployed content to one revision of
+	// TargetRef's tag, by its digest ("sha256:<hex>") as listed by
+	// GET /v0/{plural}/{name}/{tag}/revisions. Republishing the tag then
+	// leaves the Deployment on the pinned content. Empty follows the tag.
+	TargetDigest string      `json:"targetDigest,omitempty" yaml:"targetDigest,omitempty"`
+	RuntimeRef   ResourceRef `json:"runtimeRef" yaml:"runtimeRef"`
 	// ModelRef selects the tagged Model for this Deployment. When omitted from
 	// a harness Agent Deployment, it defaults to Model/default@latest in the
 	// Deployment namespace. It remains optional with no implicit selection for
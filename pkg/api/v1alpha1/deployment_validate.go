@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 )
 
@@ -73,6 +74,10 @@ func (d *Deployment) ResolveRefs(ctx context.Context, resolver ResolverFunc) err
 	return errs
 }
 
+// targetDigestRegex matches a tag revision digest: the sha256 content hash
+// of the revision's labels, annotations and spec.
+var targetDigestRegex = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
 func validateDeploymentSpec(s *DeploymentSpec) FieldErrors {
 	var errs FieldErrors
 
@@ -89,10 +94,18 @@ func validateDeploymentSpec(s *DeploymentSpec) FieldErrors {
 	}
 
 	if s.TargetRef.Tag != "" {
-		if err := validateTag(s.TargetRef.Tag); err != nil {
+		if err := validateRefTag(s.TargetRef.Tag); err != nil {
 			errs.Append("spec.targetRef.tag", err)
 		}
 	}
+	if s.TargetDigest != "" {
+		switch {
+		case !targetDigestRegex.MatchString(s.TargetDigest):
+			errs.Append("spec.targetDigest", fmt.Errorf("%w: must match %s", ErrInvalidFormat, targetDigestRegex.String()))
+		case IsTagRange(s.TargetRef.Tag):
+			errs.Append("spec.targetDigest", fmt.Errorf("%w: a digest pins a revision of one tag and cannot follow a semver range", ErrInvalidFormat))
+		}
+	}
 	if s.Harness != nil {
 		if s.TargetRef.Kind != KindAgent {
 			errs.Append("spec.harness", fmt.Errorf("%w: harness selection is only valid for Agent deployments", ErrInvalidFormat))
@@ -159,7 +172,7 @@ func validateModelRef(ref ModelRef) FieldErrors {
 		errs.Append("spec.modelRef.namespace", fmt.Errorf("%w: %q", ErrInvalidFormat, ref.Namespace))
 	}
 	if ref.Tag != "" {
-		if err := validateTag(ref.Tag); err != nil {
+		if err := validateRefTag(ref.Tag); err != nil {
 			errs.Append("spec.modelRef.tag", err)
 		}
 	}
@@ -0,0 +1,42 @@
+package v1alpha1
+
+// Namespace is the typed envelope for kind=Namespace resources. Every other
+// resource lives in a namespace; a Namespace object makes that tenancy
+// boundary visible so it can be listed, described, and governed. Namespace
+// objects themselves live in DefaultNamespace, and metadata.name is the
+// namespace they describe.
+//
+// Applying into a namespace other than DefaultNamespace requires its
+// Namespace object to exist. Deleting a Namespace is refused while any
+// resource still lives in it, and the default namespace can never be
+// deleted.
+type Namespace struct {
+	TypeMeta `json:",inline" yaml:",inline"`
+	Metadata ObjectMeta    `json:"metadata" yaml:"metadata"`
+	Spec     NamespaceSpec `json:"spec" yaml:"spec"`
+	Status   Status        `json:"status,omitzero" yaml:"status,omitempty"`
+}
+
+func init() {
+	MustRegisterKind[*Namespace, NamespaceSpec](KindNamespace, WithMutableObjectStorage())
+}
+
+// NamespaceSpec is the user-editable description of a namespace.
+type NamespaceSpec struct {
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// TagPolicy, when set, protects more tags of tagged artifacts in this
+	// namespace than the server's tag immutability policy does. Tags the
+	// server policy protects stay protected.
+	TagPolicy *TagPolicy `json:"tagPolicy,omitempty" yaml:"tagPolicy,omitempty"`
+	// TagRetention, when set, replaces the server's tag retention policy for
+	// tagged artifacts in this namespace. An empty policy prunes nothing.
+	TagRetention *TagRetentionPolicy `json:"tagRetention,omitempty" yaml:"tagRetention,omitempty"`
+	// SignaturePolicy, when set, tightens the server's artifact signature
+	// policy for tagged artifacts in this namespace: it can require
+	// signatures for more kinds and narrow the server's trusted keys, but
+	// what the server requires stays required and no other key is trusted.
+	SignaturePolicy *SignaturePolicy `json:"signaturePolicy,omitempty" yaml:"signaturePolicy,omitempty"`
+	// ApprovalPolicy, when required, holds publishes of tagged artifacts in
+	// this namespace for review before anything resolves them.
+	ApprovalPolicy *ApprovalPolicy `json:"approvalPolicy,omitempty" yaml:"approvalPolicy,omitempty"`
+}
@@ -0,0 +1,34 @@
+package v1alpha1
+
+import "fmt"
+
+// Validate runs Namespace's structural checks. metadata.name must itself be
+// a valid namespace, and the object must live in DefaultNamespace so there
+// is exactly one Namespace row per namespace.
+func (n *Namespace) Validate() error {
+	var errs FieldErrors
+	errs = append(errs, ValidateObjectMeta(n.Metadata)...)
+	if n.Metadata.Namespace != "" && n.Metadata.Namespace != DefaultNamespace {
+		errs.Append("metadata.namespace",
+			fmt.Errorf("%w: Namespace objects live in %q, got %q", ErrInvalidFormat, DefaultNamespace, n.Metadata.Namespace))
+	}
+	if n.Metadata.Name != "" && !namespaceRegex.MatchString(n.Metadata.Name) {
+		errs.Append("metadata.name", fmt.Errorf("%w: %q is not a valid namespace", ErrInvalidFormat, n.Metadata.Name))
+	}
+	if n.Spec.TagPolicy != nil {
+		errs = append(errs, n.Spec.TagPolicy.validate("spec.tagPolicy")...)
+	}
+	if n.Spec.TagRetention != nil {
+		errs = append(errs, n.Spec.TagRetention.validate("spec.tagRetention")...)
+	}
+	if n.Spec.SignaturePolicy != nil {
+		errs = append(errs, n.Spec.SignaturePolicy.validate("spec.signaturePolicy")...)
+	}
+	if n.Spec.ApprovalPolicy != nil {
+		errs = append(errs, n.Spec.ApprovalPolicy.validate("spec.approvalPolicy")...)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
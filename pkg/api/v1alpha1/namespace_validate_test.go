@@ -0,0 +1,79 @@
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNamespaceValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		meta    ObjectMeta
+		spec    NamespaceSpec
+		wantErr string // substring; empty means valid
+	}{
+		{name: "valid", meta: ObjectMeta{Namespace: DefaultNamespace, Name: "team-a"}},
+		{name: "dotted name", meta: ObjectMeta{Namespace: DefaultNamespace, Name: "eu.prod"}},
+		{
+			name:    "must live in the default namespace",
+			meta:    ObjectMeta{Namespace: "team-a", Name: "team-b"},
+			wantErr: "metadata.namespace",
+		},
+		{
+			name:    "name must be a valid namespace",
+			meta:    ObjectMeta{Namespace: DefaultNamespace, Name: strings.Repeat("a", 64)},
+			wantErr: "metadata.name",
+		},
+		{
+			name:    "name required",
+			meta:    ObjectMeta{Namespace: DefaultNamespace},
+			wantErr: "metadata.name",
+		},
+		{
+			name: "tag policy",
+			meta: ObjectMeta{Namespace: DefaultNamespace, Name: "team-a"},
+			spec: NamespaceSpec{TagPolicy: &TagPolicy{Immutable: []string{TagPatternSemver, "release-*"}}},
+		},
+		{
+			name:    "invalid tag policy pattern",
+			meta:    ObjectMeta{Namespace: DefaultNamespace, Name: "team-a"},
+			spec:    NamespaceSpec{TagPolicy: &TagPolicy{Immutable: []string{"[bad"}}},
+			wantErr: "spec.tagPolicy.immutable[0]",
+		},
+		{
+			name: "required signature policy trusting the server's keys",
+			meta: ObjectMeta{Namespace: DefaultNamespace, Name: "team-a"},
+			spec: NamespaceSpec{SignaturePolicy: &SignaturePolicy{Required: true}},
+		},
+		{
+			name:    "signature policy with a malformed key",
+			meta:    ObjectMeta{Namespace: DefaultNamespace, Name: "team-a"},
+			spec:    NamespaceSpec{SignaturePolicy: &SignaturePolicy{Required: true, TrustedKeys: []TrustedKey{{Name: "release", PublicKey: "not a key"}}}},
+			wantErr: "spec.signaturePolicy.trustedKeys[0].publicKey",
+		},
+		{
+			name:    "approval policy naming an untagged kind",
+			meta:    ObjectMeta{Namespace: DefaultNamespace, Name: "team-a"},
+			spec:    NamespaceSpec{ApprovalPolicy: &ApprovalPolicy{Required: true, Kinds: []string{KindDeployment}}},
+			wantErr: "spec.approvalPolicy.kinds[0]",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ns := &Namespace{
+				TypeMeta: TypeMeta{APIVersion: GroupVersion, Kind: KindNamespace},
+				Metadata: tc.meta,
+				Spec:     tc.spec,
+			}
+			err := ns.Validate()
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Fatalf("expected valid, got: %v", err)
+			case tc.wantErr != "" && err == nil:
+				t.Fatalf("expected an error mentioning %q, got nil", tc.wantErr)
+			case tc.wantErr != "" && !strings.Contains(err.Error(), tc.wantErr):
+				t.Fatalf("error %q does not mention %q", err.Error(), tc.wantErr)
+			}
+		})
+	}
+}
@@ -22,7 +22,7 @@ const DefaultNamespace = "default"
 //
 // Namespace, Name, Labels, Annotations, and Tag are user-settable. Tag is
 // meaningful for content-registry kinds. UID, Generation, CreatedAt,
-// UpdatedAt, and DeletionTimestamp are server-managed. Content resources use
+// UpdatedAt, DeletionTimestamp, Deprecated, and Yanked are server-managed. Content resources use
 // Tag and mutable resources use Namespace/Name.
 //
 // Generation is an internal coordination primitive that drives reconciler
@@ -38,10 +38,9 @@ const DefaultNamespace = "default"
 //
 // Mutable-object kinds (Runtime, Deployment, and additional downstream
 // control-plane/config kinds) use Namespace/Name as their full identity.
-// Namespace is an internal
-// detail today — it defaults to "default" on apply and is stripped from
-// responses when it equals "default" so the multi-tenant surface stays hidden
-// until we deliberately enable it.
+// Namespace is the tenancy boundary: it defaults to "default" on apply and
+// is always rendered on responses so clients see the fully qualified
+// identity. Namespaces themselves are managed as Namespace objects.
 //
 // UID is a server-assigned UUID stamped at row creation and never mutated
 // afterwards — same contract as Kubernetes' metadata.uid. Public identity may
@@ -76,42 +75,34 @@ type ObjectMeta struct {
 	// Generation is server-managed and internal. Populated from the DB row for
 	// internal Go consumers (coordinators, status reconcilers); hidden from the
 	// wire.
-	Generation int64     `json:"-" yaml:"-"`
-	CreatedAt  time.Time `json:"createdAt,omitzero" yaml:"createdAt,omitempty"`
-	UpdatedAt  time.Time `json:"updatedAt,omitzero" yaml:"updatedAt,omitempty"`
+	Generation int64 `json:"-" yaml:"-"`
+	// ResourceVersion is server-managed: the control-plane revision of the
+	// object's last spec, label, annotation or lifecycle change. Status
+	// writes leave it unchanged. Sending it back on PUT or apply makes the
+	// write conditional: the server rejects it with 409 Conflict if the
+	// object has changed since it was read. Omit it to overwrite
+	// unconditionally.
+	ResourceVersion string    `json:"resourceVersion,omitempty" yaml:"resourceVersion,omitempty"`
+	CreatedAt       time.Time `json:"createdAt,omitzero" yaml:"createdAt,omitempty"`
+	UpdatedAt       time.Time `json:"updatedAt,omitzero" yaml:"updatedAt,omitempty"`
 
 	// DeletionTimestamp is set by the Store when Delete is called. A non-nil
 	// DeletionTimestamp means the object is terminating; the row stays
 	// observable via Get until the GC pass purges it. Clients MUST NOT
 	// set this on apply.
 	DeletionTimestamp *time.Time `json:"deletionTimestamp,omitempty" yaml:"deletionTimestamp,omitempty"`
-}
-
-// objectMetaWire is the marshaling shape used by ObjectMeta.MarshalJSON.
-// Aliased so json.Marshal on the alias doesn't recurse into our custom
-// method.
-type objectMetaWire ObjectMeta
 
-// MarshalJSON strips Namespace when it equals DefaultNamespace so
-// responses don't leak the namespace surface while it remains hidden
-// from the user-facing API. Internal storage always carries the full
-// identity; this only affects wire rendering.
-//
-// Inbound defaulting from empty to "default" happens at the apply
-// boundary (see resource.prepareApplyDoc), not on UnmarshalJSON. Callers
-// need to keep the empty-namespace signal around so they can layer their
-// own default on top.
-func (m ObjectMeta) MarshalJSON() ([]byte, error) {
-	w := objectMetaWire(m)
-	if w.Namespace == DefaultNamespace {
-		w.Namespace = ""
-	}
-	return json.Marshal(w)
+	// Deprecated and Yanked are server-managed markers on one tag of a
+	// content kind, set through the tag's deprecation and yank endpoints.
+	// Apply ignores them; republishing the tag with new content clears
+	// them.
+	Deprecated *Deprecation `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Yanked     *Yank        `json:"yanked,omitempty" yaml:"yanked,omitempty"`
 }
 
 // NamespaceOrDefault returns m.Namespace, or DefaultNamespace when the
 // field is empty. Use when building display strings / ids that should include
-// the effective namespace even though the wire has elided the default namespace.
+// the effective namespace even when a manifest omitted it.
 func (m ObjectMeta) NamespaceOrDefault() string {
 	if m.Namespace == "" {
 		return DefaultNamespace
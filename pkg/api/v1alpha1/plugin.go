@@ -5,7 +5,7 @@ package v1alpha1
 // A Plugin is a self-contained, versioned bundle of harness extensions —
 // skills, MCP servers, hooks, and sub-agents — modeled on the Claude Code
 // plugin format. The Spec is USER INTENT ONLY: a pinned pointer to an external
-// source (a git commit or — later — an OCI digest), the same source-based model
+// source (a git commit or an OCI tag/digest), the same source-based model
 // agents and skills use. The registry hosts NOTHING; the Plugin controller
 // resolves the pointer to a concrete commit/digest and scans the source for its
 // manifest and inventory OUT OF BAND, recording that server-determined data in
@@ -40,8 +40,8 @@ type PluginSpec struct {
 	// deploy-time adapters decide which harnesses they can consume.
 	Harnesses []string `json:"harnesses,omitempty" yaml:"harnesses,omitempty"`
 
-	// Source is where the bundle is ingested from, pinned (git commit / OCI
-	// digest) so a published tag is reproducible.
+	// Source is where the bundle is ingested from. The controller pins it to a
+	// git commit / OCI digest so a published tag is reproducible.
 	Source *PluginSource `json:"source,omitempty" yaml:"source,omitempty"`
 }
 
@@ -76,7 +76,7 @@ type PluginResolvedSource struct {
 	Type PluginSourceType `json:"type" yaml:"type"`
 	// Commit is the resolved full git commit SHA (Type=git).
 	Commit string `json:"commit,omitempty" yaml:"commit,omitempty"`
-	// Digest is the resolved OCI digest, e.g. "sha256:…" (Type=oci; future).
+	// Digest is the resolved OCI manifest digest, e.g. "sha256:…" (Type=oci).
 	Digest string `json:"digest,omitempty" yaml:"digest,omitempty"`
 }
 
@@ -89,8 +89,9 @@ const (
 )
 
 // PluginSource identifies where the bundle came from. Exactly one of Git/OCI
-// is set, matching Type. The reference must be pinned (git commit / OCI digest)
-// so the published tag is reproducible.
+// is set, matching Type. A moving ref (git branch/tag, OCI tag) is allowed; the
+// controller pins it (git commit / OCI digest) in status.ResolvedSource so the
+// published tag is reproducible.
 type PluginSource struct {
 	Type PluginSourceType `json:"type" yaml:"type"`
 	Git  *PluginSourceGit `json:"git,omitempty" yaml:"git,omitempty"`
@@ -106,8 +107,11 @@ type PluginSourceGit struct {
 	Repository *Repository `json:"repository" yaml:"repository"`
 }
 
-// PluginSourceOCI is a digest-pinned OCI artifact reference, e.g.
-// "ghcr.io/org/plugin@sha256:...". Bare/tag-only refs are rejected.
+// PluginSourceOCI is an OCI artifact reference carrying the bundle as a tar
+// layer, e.g. "ghcr.io/org/plugin:1.2.0" or "ghcr.io/org/plugin@sha256:...".
+// The Plugin controller resolves a tag to its manifest digest and records that
+// immutable pin in status.ResolvedSource. Bare refs (no tag or digest) are
+// rejected — they would silently float on ":latest".
 type PluginSourceOCI struct {
 	Reference string `json:"reference" yaml:"reference"`
 }
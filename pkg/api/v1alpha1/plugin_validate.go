@@ -44,6 +44,20 @@ func isFullCommitSHA(s string) bool {
 	return true
 }
 
+// ociRefHasTagOrDigest reports whether ref carries an explicit tag or digest
+// after its final path component. A registry port (localhost:5000/p) is not
+// mistaken for a tag because it precedes the first "/".
+func ociRefHasTagOrDigest(ref string) bool {
+	if strings.Contains(ref, "@") {
+		return true
+	}
+	tail := ref
+	if i := strings.LastIndex(ref, "/"); i >= 0 {
+		tail = ref[i+1:]
+	}
+	return strings.Contains(tail, ":")
+}
+
 func validatePluginSource(o *PluginSource) FieldErrors {
 	var errs FieldErrors
 	switch o.Type {
@@ -81,9 +95,11 @@ func validatePluginSource(o *PluginSource) FieldErrors {
 			errs.Append("oci.reference", fmt.Errorf("%w", ErrRequiredField))
 			break
 		}
-		// Pin requirement: OCI source must be digest-pinned, not a floating tag.
-		if !strings.Contains(o.OCI.Reference, "@sha256:") {
-			errs.Append("oci.reference", fmt.Errorf("%w: oci source must be digest-pinned (…@sha256:…)", ErrInvalidFormat))
+		// A tag or a digest may be supplied; the controller resolves a tag to its
+		// manifest digest and records that pin in status.ResolvedSource. A bare
+		// ref is rejected: it would silently float on ":latest".
+		if !ociRefHasTagOrDigest(o.OCI.Reference) {
+			errs.Append("oci.reference", fmt.Errorf("%w: oci reference must include an explicit tag (…:1.0.0) or digest (…@sha256:…)", ErrInvalidFormat))
 		}
 	case "":
 		errs.Append("type", fmt.Errorf("%w", ErrRequiredField))
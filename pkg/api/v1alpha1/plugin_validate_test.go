@@ -55,6 +55,10 @@ func TestPluginValidate(t *testing.T) {
 			name: "valid oci digest source",
 			spec: PluginSpec{Source: &PluginSource{Type: PluginSourceTypeOCI, OCI: &PluginSourceOCI{Reference: "ghcr.io/org/plugin@sha256:" + strings.Repeat("a", 64)}}},
 		},
+		{
+			name: "oci tag source (controller resolves the digest)",
+			spec: PluginSpec{Source: &PluginSource{Type: PluginSourceTypeOCI, OCI: &PluginSourceOCI{Reference: "localhost:5000/org/plugin:1.2.0"}}},
+		},
 		{
 			name:    "missing source",
 			spec:    PluginSpec{Title: "x"},
@@ -84,9 +88,9 @@ func TestPluginValidate(t *testing.T) {
 			wantErr: "at most one of branch or commit",
 		},
 		{
-			name:    "oci source not digest-pinned",
-			spec:    PluginSpec{Source: &PluginSource{Type: PluginSourceTypeOCI, OCI: &PluginSourceOCI{Reference: "ghcr.io/org/plugin:latest"}}},
-			wantErr: "digest-pinned",
+			name:    "oci source without tag or digest",
+			spec:    PluginSpec{Source: &PluginSource{Type: PluginSourceTypeOCI, OCI: &PluginSourceOCI{Reference: "localhost:5000/org/plugin"}}},
+			wantErr: "explicit tag",
 		},
 		{
 			name:    "unknown source type",
@@ -7,7 +7,8 @@ package v1alpha1
 // Namespace is optional: blank means "same namespace as the referencing
 // object" (the common case). Tag is optional: blank means "resolve to the
 // literal latest tag" for taggable artifacts or "resolve by namespace/name"
-// for mutable object kinds.
+// for mutable object kinds. A semver range such as `^1.2` or `>=2.0 <3`
+// resolves to the highest matching tag; see IsTagRange.
 type ResourceRef struct {
 	Kind      string `json:"kind" yaml:"kind"`
 	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
@@ -35,7 +36,8 @@ const DefaultModelName = "default"
 // ModelRef selects a tagged Model. Kind is implicit (always Model).
 //
 // Namespace is optional: blank means "same namespace as the referencing
-// Deployment". Tag is optional: blank resolves the literal "latest" tag. When
+// Deployment". Tag is optional: blank resolves the literal "latest" tag, and a
+// semver range resolves to the highest matching tag. When
 // a harness Agent Deployment omits ModelRef entirely, it defaults to
 // {name: "default"} in the Deployment namespace.
 type ModelRef struct {
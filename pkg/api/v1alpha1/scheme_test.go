@@ -11,7 +11,7 @@ import (
 
 func TestScheme_RegisterAllBuiltins(t *testing.T) {
 	got := Default.Kinds()
-	want := []string{"agent", "deployment", "mcpserver", "model", "plugin", "prompt", "runtime", "skill"}
+	want := []string{"agent", "deployment", "mcpserver", "model", "namespace", "plugin", "prompt", "runtime", "skill"}
 	if !reflect.DeepEqual(got, want) {
 		t.Fatalf("built-in kinds = %v, want %v", got, want)
 	}
@@ -430,10 +430,9 @@ spec:
 }
 
 func TestEncode_RoundTrip_YAML(t *testing.T) {
-	// Empty Namespace survives a round trip. MarshalJSON strips "default"
-	// but UnmarshalJSON intentionally does not re-stamp it, so API
-	// entry points can layer their own default on top.
-	//
+	// Empty Namespace survives a round trip. UnmarshalJSON intentionally
+	// does not stamp "default", so API entry points can layer their own
+	// default on top.
 	original := &Agent{
 		TypeMeta: TypeMeta{APIVersion: GroupVersion, Kind: KindAgent},
 		Metadata: ObjectMeta{Name: "rt", Labels: map[string]string{"k": "v"}},
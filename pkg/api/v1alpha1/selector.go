@@ -0,0 +1,252 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidSelector reports a malformed label or field selector.
+var ErrInvalidSelector = errors.New("invalid selector")
+
+// SelectorOperator is the comparison a selector requirement applies.
+type SelectorOperator string
+
+const (
+	SelectorEquals       SelectorOperator = "="
+	SelectorNotEquals    SelectorOperator = "!="
+	SelectorIn           SelectorOperator = "in"
+	SelectorNotIn        SelectorOperator = "notin"
+	SelectorExists       SelectorOperator = "exists"
+	SelectorDoesNotExist SelectorOperator = "!"
+)
+
+// LabelRequirement is one clause of a LabelSelector. Values holds one
+// value for = and !=, one or more for in and notin, and none for exists
+// and !.
+type LabelRequirement struct {
+	Key      string
+	Operator SelectorOperator
+	Values   []string
+}
+
+// LabelSelector is a Kubernetes-style label selector: every requirement
+// must hold. As in Kubernetes, != and notin also match objects without
+// the key.
+type LabelSelector []LabelRequirement
+
+// LabelSelectorFromMap returns the equality selector matching every
+// key=value pair of m.
+func LabelSelectorFromMap(m map[string]string) LabelSelector {
+	out := make(LabelSelector, 0, len(m))
+	for key, value := range m {
+		out = append(out, LabelRequirement{Key: key, Operator: SelectorEquals, Values: []string{value}})
+	}
+	return out
+}
+
+// setRequirementRegex matches "key in (a,b)" and "key notin (a,b)".
+var setRequirementRegex = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+
+// ParseLabelSelector parses a comma-separated Kubernetes label selector:
+//
+//	env=prod, env==prod, tier!=experimental,
+//	env in (prod,staging), env notin (dev), team, !deprecated
+//
+// An empty string selects everything.
+func ParseLabelSelector(s string) (LabelSelector, error) {
+	clauses, err := splitSelector(s)
+	if err != nil {
+		return nil, err
+	}
+	out := make(LabelSelector, 0, len(clauses))
+	for _, clause := range clauses {
+		req, err := parseLabelRequirement(clause)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, req)
+	}
+	return out, nil
+}
+
+func parseLabelRequirement(clause string) (LabelRequirement, error) {
+	var req LabelRequirement
+	if m := setRequirementRegex.FindStringSubmatch(clause); m != nil {
+		req = LabelRequirement{Key: m[1], Operator: SelectorOperator(m[2])}
+		for value := range strings.SplitSeq(m[3], ",") {
+			req.Values = append(req.Values, strings.TrimSpace(value))
+		}
+	} else if key, ok := strings.CutPrefix(clause, "!"); ok {
+		req = LabelRequirement{Key: strings.TrimSpace(key), Operator: SelectorDoesNotExist}
+	} else if key, value, ok := cutOperator(clause, "!="); ok {
+		req = LabelRequirement{Key: key, Operator: SelectorNotEquals, Values: []string{value}}
+	} else if key, value, ok := cutOperator(clause, "=="); ok {
+		req = LabelRequirement{Key: key, Operator: SelectorEquals, Values: []string{value}}
+	} else if key, value, ok := cutOperator(clause, "="); ok {
+		req = LabelRequirement{Key: key, Operator: SelectorEquals, Values: []string{value}}
+	} else {
+		req = LabelRequirement{Key: clause, Operator: SelectorExists}
+	}
+
+	if !labelKeyRegex.MatchString(req.Key) {
+		return LabelRequirement{}, fmt.Errorf("%w: %q: invalid label key %q", ErrInvalidSelector, clause, req.Key)
+	}
+	if (req.Operator == SelectorIn || req.Operator == SelectorNotIn) && len(req.Values) == 1 && req.Values[0] == "" {
+		return LabelRequirement{}, fmt.Errorf("%w: %q: %s needs at least one value", ErrInvalidSelector, clause, req.Operator)
+	}
+	for _, value := range req.Values {
+		if !labelValueRegex.MatchString(value) {
+			return LabelRequirement{}, fmt.Errorf("%w: %q: invalid label value %q", ErrInvalidSelector, clause, value)
+		}
+	}
+	return req, nil
+}
+
+// FieldPathSegment is one step of a field selector path. Key is set for
+// a bracketed step such as conditions[Ready], which selects the element
+// of the Name array whose "type" is Key.
+type FieldPathSegment struct {
+	Name string
+	Key  string
+}
+
+// FieldRequirement is one clause of a FieldSelector.
+type FieldRequirement struct {
+	// Field is the dotted path as written, e.g. "spec.source.package.origin.type".
+	Field string
+	// Path is Field split into segments, the first one metadata, spec or
+	// status.
+	Path     []FieldPathSegment
+	Operator SelectorOperator
+	Value    string
+}
+
+// FieldSelector selects objects by spec, status and identity fields:
+// every requirement must hold. != also matches objects without the field.
+type FieldSelector []FieldRequirement
+
+// Metadata fields a field selector may compare.
+var selectableMetadataFields = map[string]bool{"name": true, "namespace": true, "tag": true}
+
+// fieldSegmentRegex matches one field path segment, optionally keyed:
+// "origin" or "conditions[Ready]".
+var fieldSegmentRegex = regexp.MustCompile(`^([A-Za-z0-9_-]+)(?:\[([^\[\]]+)\])?$`)
+
+// ParseFieldSelector parses a comma-separated field selector of
+// path=value, path==value and path!=value clauses:
+//
+//	spec.source.package.origin.type=npm, status.conditions[Ready]=True,
+//	metadata.name!=scratch
+//
+// Paths start with spec, status or metadata (name, namespace and tag
+// only). A bracketed segment such as conditions[Ready] selects the array
+// element whose "type" is Ready; when it ends the path, the element's
+// "status" is compared. Values compare as text, so booleans and numbers
+// are written as they print in JSON. An empty string selects everything.
+func ParseFieldSelector(s string) (FieldSelector, error) {
+	clauses, err := splitSelector(s)
+	if err != nil {
+		return nil, err
+	}
+	out := make(FieldSelector, 0, len(clauses))
+	for _, clause := range clauses {
+		var req FieldRequirement
+		if field, value, ok := cutOperator(clause, "!="); ok {
+			req = FieldRequirement{Field: field, Operator: SelectorNotEquals, Value: value}
+		} else if field, value, ok := cutOperator(clause, "=="); ok {
+			req = FieldRequirement{Field: field, Operator: SelectorEquals, Value: value}
+		} else if field, value, ok := cutOperator(clause, "="); ok {
+			req = FieldRequirement{Field: field, Operator: SelectorEquals, Value: value}
+		} else {
+			return nil, fmt.Errorf("%w: %q must be field=value or field!=value", ErrInvalidSelector, clause)
+		}
+		req.Path, err = parseFieldPath(req.Field)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", ErrInvalidSelector, clause, err)
+		}
+		out = append(out, req)
+	}
+	return out, nil
+}
+
+func parseFieldPath(field string) ([]FieldPathSegment, error) {
+	parts := strings.Split(field, ".")
+	path := make([]FieldPathSegment, 0, len(parts))
+	keyed := false
+	for _, part := range parts {
+		m := fieldSegmentRegex.FindStringSubmatch(part)
+		if m == nil {
+			return nil, fmt.Errorf("invalid field path %q", field)
+		}
+		if m[2] != "" {
+			if keyed {
+				return nil, fmt.Errorf("field path %q may select at most one array element", field)
+			}
+			keyed = true
+		}
+		path = append(path, FieldPathSegment{Name: m[1], Key: m[2]})
+	}
+	switch root := path[0]; {
+	case root.Key != "":
+		return nil, fmt.Errorf("invalid field path %q", field)
+	case root.Name == "metadata":
+		if len(path) != 2 || path[1].Key != "" || !selectableMetadataFields[path[1].Name] {
+			return nil, fmt.Errorf("only metadata.name, metadata.namespace and metadata.tag are selectable, got %q", field)
+		}
+	case root.Name == "spec" || root.Name == "status":
+		if len(path) < 2 {
+			return nil, fmt.Errorf("field path %q must name a field under %s", field, root.Name)
+		}
+	default:
+		return nil, fmt.Errorf("field path %q must start with spec, status or metadata", field)
+	}
+	return path, nil
+}
+
+// splitSelector splits s on the commas outside parentheses and trims
+// each clause, dropping empty ones.
+func splitSelector(s string) ([]string, error) {
+	var (
+		out   []string
+		depth int
+		start int
+	)
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("%w: unbalanced parentheses in %q", ErrInvalidSelector, s)
+			}
+		case ',':
+			if depth == 0 {
+				out = appendClause(out, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("%w: unbalanced parentheses in %q", ErrInvalidSelector, s)
+	}
+	return appendClause(out, s[start:]), nil
+}
+
+func appendClause(out []string, clause string) []string {
+	if clause = strings.TrimSpace(clause); clause != "" {
+		out = append(out, clause)
+	}
+	return out
+}
+
+// cutOperator splits clause around the first op, trimming both sides.
+func cutOperator(clause, op string) (string, string, bool) {
+	left, right, ok := strings.Cut(clause, op)
+	if !ok {
+		return "", "", false
+	}
+	return strings.TrimSpace(left), strings.TrimSpace(right), true
+}
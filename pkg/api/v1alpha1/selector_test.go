@@ -0,0 +1,72 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	got, err := ParseLabelSelector("env in (prod, staging), tier!=experimental,!deprecated, team, owner==x, app=web")
+	require.NoError(t, err)
+	require.Equal(t, LabelSelector{
+		{Key: "env", Operator: SelectorIn, Values: []string{"prod", "staging"}},
+		{Key: "tier", Operator: SelectorNotEquals, Values: []string{"experimental"}},
+		{Key: "deprecated", Operator: SelectorDoesNotExist},
+		{Key: "team", Operator: SelectorExists},
+		{Key: "owner", Operator: SelectorEquals, Values: []string{"x"}},
+		{Key: "app", Operator: SelectorEquals, Values: []string{"web"}},
+	}, got)
+
+	got, err = ParseLabelSelector("example.com/tier notin (dev)")
+	require.NoError(t, err)
+	require.Equal(t, LabelSelector{{Key: "example.com/tier", Operator: SelectorNotIn, Values: []string{"dev"}}}, got)
+
+	got, err = ParseLabelSelector(" ")
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	for _, bad := range []string{"env in (prod", "env in ()", "env)", "=prod", "env=pr od", "bad key=x", "!"} {
+		_, err := ParseLabelSelector(bad)
+		require.ErrorIs(t, err, ErrInvalidSelector, bad)
+	}
+}
+
+func TestParseFieldSelector(t *testing.T) {
+	got, err := ParseFieldSelector("spec.source.package.origin.type=npm,status.conditions[Ready]==True, metadata.name!=scratch")
+	require.NoError(t, err)
+	require.Equal(t, FieldSelector{
+		{
+			Field:    "spec.source.package.origin.type",
+			Path:     []FieldPathSegment{{Name: "spec"}, {Name: "source"}, {Name: "package"}, {Name: "origin"}, {Name: "type"}},
+			Operator: SelectorEquals,
+			Value:    "npm",
+		},
+		{
+			Field:    "status.conditions[Ready]",
+			Path:     []FieldPathSegment{{Name: "status"}, {Name: "conditions", Key: "Ready"}},
+			Operator: SelectorEquals,
+			Value:    "True",
+		},
+		{
+			Field:    "metadata.name",
+			Path:     []FieldPathSegment{{Name: "metadata"}, {Name: "name"}},
+			Operator: SelectorNotEquals,
+			Value:    "scratch",
+		},
+	}, got)
+
+	for _, bad := range []string{
+		"spec.title",                   // no operator
+		"labels.team=x",                // unknown root
+		"spec=x",                       // root only
+		"metadata.labels=x",            // metadata field not selectable
+		"spec.a[x].b[y]=z",             // two keyed segments
+		"spec..title=x",                // empty segment
+		"spec.title in (a,b)",          // set operators are label-only
+		"status.conditions[Ready=True", // unbalanced bracket
+	} {
+		_, err := ParseFieldSelector(bad)
+		require.ErrorIs(t, err, ErrInvalidSelector, bad)
+	}
+}
@@ -0,0 +1,145 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
+)
+
+// SignaturePolicy decides which tagged artifacts must carry a valid
+// detached signature before they are admitted. Signatures cover the
+// content digest of a tag (metadata labels, annotations and spec), so a
+// signature stays valid across re-applies of identical content and is void
+// the moment the content changes.
+//
+// When Required, an apply of a covered kind is refused unless the digest
+// it would store is signed by one of TrustedKeys, and the Deployment
+// controller refuses to deploy targets that are not.
+type SignaturePolicy struct {
+	// Required turns enforcement on. A policy that is not required only
+	// records which keys are trusted.
+	Required bool `json:"required,omitempty" yaml:"required,omitempty"`
+	// Kinds limits the policy to the listed tagged kinds. Empty means every
+	// tagged kind.
+	Kinds []string `json:"kinds,omitempty" yaml:"kinds,omitempty"`
+	// TrustedKeys lists the public keys whose signatures are accepted.
+	TrustedKeys []TrustedKey `json:"trustedKeys,omitempty" yaml:"trustedKeys,omitempty"`
+}
+
+// TrustedKey is a named public key trusted to sign artifacts.
+type TrustedKey struct {
+	Name string `json:"name" yaml:"name"`
+	// PublicKey is a PEM "PUBLIC KEY" block holding an Ed25519 or ECDSA
+	// key, such as the cosign.pub of a cosign key pair.
+	PublicKey string `json:"publicKey" yaml:"publicKey"`
+}
+
+// Requires reports whether the policy demands a signature for kind.
+// Untagged kinds are never covered.
+func (p SignaturePolicy) Requires(kind string) bool {
+	if !p.Required || !IsTaggedArtifactKind(kind) {
+		return false
+	}
+	if len(p.Kinds) == 0 {
+		return true
+	}
+	for _, k := range p.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Within layers p, a Namespace's policy, over floor, the server's: the
+// result requires a signature wherever either policy does, and trusts only
+// keys floor trusts. When p lists trusted keys, the result narrows floor's
+// keys to those; keys p lists that floor does not trust are ignored. A
+// Namespace writer can therefore demand more signatures or fewer signers,
+// never fewer signatures or other signers.
+func (p SignaturePolicy) Within(floor SignaturePolicy) SignaturePolicy {
+	out := SignaturePolicy{TrustedKeys: floor.TrustedKeys}
+	switch {
+	case p.Required && floor.Required:
+		out.Required = true
+		if len(p.Kinds) > 0 && len(floor.Kinds) > 0 {
+			out.Kinds = append(slices.Clone(floor.Kinds), p.Kinds...)
+		}
+	case p.Required:
+		out.Required, out.Kinds = true, p.Kinds
+	case floor.Required:
+		out.Required, out.Kinds = true, floor.Kinds
+	}
+	if len(p.TrustedKeys) > 0 {
+		narrowed := map[string]bool{}
+		for _, key := range p.TrustedKeys {
+			if id, ok := trustedKeyID(key); ok {
+				narrowed[id] = true
+			}
+		}
+		out.TrustedKeys = nil
+		for _, key := range floor.TrustedKeys {
+			if id, ok := trustedKeyID(key); ok && narrowed[id] {
+				out.TrustedKeys = append(out.TrustedKeys, key)
+			}
+		}
+	}
+	return out
+}
+
+// trustedKeyID returns the signing.KeyID of key's public key.
+func trustedKeyID(key TrustedKey) (string, bool) {
+	pub, err := signing.ParsePublicKey([]byte(key.PublicKey))
+	if err != nil {
+		return "", false
+	}
+	id, err := signing.KeyID(pub)
+	return id, err == nil
+}
+
+// Validate checks that every trusted key parses, key names are unique, a
+// required policy trusts at least one key, and every kind is a registered
+// tagged kind. A Namespace's policy may be required without keys of its
+// own: it trusts the server's.
+func (p SignaturePolicy) Validate() error {
+	errs := p.validate("")
+	if p.Required && len(p.TrustedKeys) == 0 {
+		errs.Append("trustedKeys", fmt.Errorf("%w: a required signature policy needs at least one trusted key", ErrRequiredField))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (p SignaturePolicy) validate(prefix string) FieldErrors {
+	var errs FieldErrors
+	field := func(name string) string {
+		if prefix != "" {
+			return prefix + "." + name
+		}
+		return name
+	}
+	seen := map[string]bool{}
+	for i, key := range p.TrustedKeys {
+		name := strings.TrimSpace(key.Name)
+		switch {
+		case name == "":
+			errs.Append(field(fmt.Sprintf("trustedKeys[%d].name", i)), ErrRequiredField)
+		case seen[name]:
+			errs.Append(field(fmt.Sprintf("trustedKeys[%d].name", i)), fmt.Errorf("%w: duplicate key name %q", ErrInvalidFormat, name))
+		}
+		seen[name] = true
+		if _, err := signing.ParsePublicKey([]byte(key.PublicKey)); err != nil {
+			errs.Append(field(fmt.Sprintf("trustedKeys[%d].publicKey", i)), fmt.Errorf("%w: %v", ErrInvalidFormat, err))
+		}
+	}
+	for i, kind := range p.Kinds {
+		if !IsTaggedArtifactKind(kind) {
+			errs.Append(field(fmt.Sprintf("kinds[%d]", i)), fmt.Errorf("%w: %q is not a tagged kind", ErrInvalidFormat, kind))
+		}
+	}
+	return errs
+}
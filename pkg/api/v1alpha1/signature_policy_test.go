@@ -0,0 +1,85 @@
+package v1alpha1
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
+)
+
+func testPublicKeyPEM(t *testing.T) string {
+	t.Helper()
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	pemKey, err := signing.MarshalPublicKey(pub)
+	require.NoError(t, err)
+	return pemKey
+}
+
+func TestSignaturePolicyRequires(t *testing.T) {
+	all := SignaturePolicy{Required: true}
+	require.True(t, all.Requires(KindAgent))
+	require.True(t, all.Requires(KindSkill))
+	require.False(t, all.Requires(KindDeployment), "untagged kinds are never covered")
+
+	scoped := SignaturePolicy{Required: true, Kinds: []string{KindMCPServer}}
+	require.True(t, scoped.Requires(KindMCPServer))
+	require.False(t, scoped.Requires(KindAgent), "kinds limits the policy")
+
+	require.False(t, SignaturePolicy{}.Requires(KindAgent), "a policy that is not required covers nothing")
+}
+
+func TestSignaturePolicyValidate(t *testing.T) {
+	key := testPublicKeyPEM(t)
+	require.NoError(t, SignaturePolicy{}.Validate())
+	require.NoError(t, SignaturePolicy{
+		Required:    true,
+		Kinds:       []string{KindAgent},
+		TrustedKeys: []TrustedKey{{Name: "release", PublicKey: key}},
+	}.Validate())
+
+	require.ErrorContains(t, SignaturePolicy{Required: true}.Validate(), "trustedKeys")
+
+	err := SignaturePolicy{
+		Kinds: []string{KindDeployment},
+		TrustedKeys: []TrustedKey{
+			{Name: "release", PublicKey: key},
+			{Name: "release", PublicKey: "not a key"},
+			{PublicKey: key},
+		},
+	}.Validate()
+	require.ErrorContains(t, err, "trustedKeys[1].name")
+	require.ErrorContains(t, err, "trustedKeys[1].publicKey")
+	require.ErrorContains(t, err, "trustedKeys[2].name")
+	require.ErrorContains(t, err, "kinds[0]")
+}
+
+func TestSignaturePolicyWithin(t *testing.T) {
+	release, ci, own := testPublicKeyPEM(t), testPublicKeyPEM(t), testPublicKeyPEM(t)
+	server := SignaturePolicy{
+		Required:    true,
+		Kinds:       []string{KindMCPServer},
+		TrustedKeys: []TrustedKey{{Name: "release", PublicKey: release}, {Name: "ci", PublicKey: ci}},
+	}
+
+	loosened := SignaturePolicy{TrustedKeys: []TrustedKey{{Name: "own", PublicKey: own}}}.Within(server)
+	require.True(t, loosened.Requires(KindMCPServer), "what the server requires stays required")
+	require.Empty(t, loosened.TrustedKeys, "keys the server does not trust are ignored")
+
+	tightened := SignaturePolicy{
+		Required:    true,
+		Kinds:       []string{KindAgent},
+		TrustedKeys: []TrustedKey{{Name: "release", PublicKey: release}},
+	}.Within(server)
+	require.True(t, tightened.Requires(KindMCPServer))
+	require.True(t, tightened.Requires(KindAgent))
+	require.False(t, tightened.Requires(KindSkill))
+	require.Equal(t, []TrustedKey{{Name: "release", PublicKey: release}}, tightened.TrustedKeys)
+
+	inherited := SignaturePolicy{Required: true}.Within(SignaturePolicy{TrustedKeys: server.TrustedKeys})
+	require.True(t, inherited.Requires(KindSkill))
+	require.Equal(t, server.TrustedKeys, inherited.TrustedKeys, "a Namespace without keys trusts the server's")
+}
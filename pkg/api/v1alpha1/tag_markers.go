@@ -0,0 +1,27 @@
+package v1alpha1
+
+import "time"
+
+// Deprecation marks a tag of a content kind as still usable but
+// discouraged. References to a deprecated tag keep resolving; apply and
+// arctl report a warning for them, pointing at Replacement when it is set.
+type Deprecation struct {
+	// Message tells users why the tag is deprecated.
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	// Replacement is the ref to move to. Kind and Namespace default to
+	// those of the deprecated tag.
+	Replacement *ResourceRef `json:"replacement,omitempty" yaml:"replacement,omitempty"`
+	// DeprecatedAt is server-managed: when the tag was marked.
+	DeprecatedAt time.Time `json:"deprecatedAt,omitzero" yaml:"deprecatedAt,omitempty"`
+}
+
+// Yank marks a tag of a content kind as bad without deleting it. A yanked
+// tag is still readable and refs pinned to it exactly keep resolving, so
+// nothing that names it breaks; but it no longer counts as "latest", semver
+// ranges skip it, and the Deployment controller refuses to roll it out.
+type Yank struct {
+	// Reason tells users why the tag was yanked.
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	// YankedAt is server-managed: when the tag was yanked.
+	YankedAt time.Time `json:"yankedAt,omitzero" yaml:"yankedAt,omitempty"`
+}
@@ -0,0 +1,141 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+)
+
+// TagPatternSemver is the TagPolicy pattern matching semver-shaped tags:
+// MAJOR.MINOR.PATCH with an optional leading "v" and prerelease suffix,
+// e.g. `1.0.0`, `v2.3.1`, `1.0.0-rc.1`.
+const TagPatternSemver = "semver"
+
+var semverTagRegex = regexp.MustCompile(`^v?(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)(-[0-9A-Za-z.-]+)?$`)
+
+// TagPolicy decides which tags of a tagged artifact are immutable: once
+// written, their content can only change through an audited admin
+// override. Every other tag, such as `latest` or `stable`, floats and is
+// replaced in place on apply.
+//
+// Patterns are TagPatternSemver or path.Match globs (`release-*`). A tag
+// is immutable when it matches an Immutable pattern and no Mutable one.
+type TagPolicy struct {
+	// Immutable lists the patterns of tags that may not be overwritten.
+	Immutable []string `json:"immutable,omitempty" yaml:"immutable,omitempty"`
+	// Mutable lists exceptions to Immutable.
+	Mutable []string `json:"mutable,omitempty" yaml:"mutable,omitempty"`
+	// Kinds limits the policy to the listed tagged kinds. Empty means every
+	// tagged kind.
+	Kinds []string `json:"kinds,omitempty" yaml:"kinds,omitempty"`
+
+	// floor, when set by Within, is a policy whose protected tags stay
+	// protected whatever this policy says.
+	floor *TagPolicy
+}
+
+// DefaultTagPolicy makes semver-shaped tags immutable for every tagged
+// kind.
+func DefaultTagPolicy() TagPolicy {
+	return TagPolicy{Immutable: []string{TagPatternSemver}}
+}
+
+// IsSemverTag reports whether tag matches TagPatternSemver.
+func IsSemverTag(tag string) bool {
+	return semverTagRegex.MatchString(tag)
+}
+
+// Within layers p over floor: a tag is immutable when either policy
+// protects it, so p can protect more tags than floor but never fewer. Its
+// Mutable exceptions only apply to its own Immutable patterns.
+func (p TagPolicy) Within(floor TagPolicy) TagPolicy {
+	p.floor = &floor
+	return p
+}
+
+// IsImmutable reports whether the policy protects tag of kind from being
+// overwritten. Untagged kinds are never protected.
+func (p TagPolicy) IsImmutable(kind, tag string) bool {
+	if p.floor != nil && p.floor.IsImmutable(kind, tag) {
+		return true
+	}
+	if !IsTaggedArtifactKind(kind) || !p.appliesTo(kind) {
+		return false
+	}
+	return matchesTagPattern(p.Immutable, tag) && !matchesTagPattern(p.Mutable, tag)
+}
+
+func (p TagPolicy) appliesTo(kind string) bool {
+	if len(p.Kinds) == 0 {
+		return true
+	}
+	for _, k := range p.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesTagPattern(patterns []string, tag string) bool {
+	for _, pattern := range patterns {
+		if pattern == TagPatternSemver {
+			if IsSemverTag(tag) {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(pattern, tag); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that every pattern is well formed and every kind is a
+// registered tagged kind.
+func (p TagPolicy) Validate() error {
+	if errs := p.validate(""); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (p TagPolicy) validate(prefix string) FieldErrors {
+	var errs FieldErrors
+	field := func(name string, i int) string {
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		return fmt.Sprintf("%s[%d]", name, i)
+	}
+	for i, pattern := range p.Immutable {
+		if err := validateTagPattern(pattern); err != nil {
+			errs.Append(field("immutable", i), err)
+		}
+	}
+	for i, pattern := range p.Mutable {
+		if err := validateTagPattern(pattern); err != nil {
+			errs.Append(field("mutable", i), err)
+		}
+	}
+	for i, kind := range p.Kinds {
+		if !IsTaggedArtifactKind(kind) {
+			errs.Append(field("kinds", i), fmt.Errorf("%w: %q is not a tagged kind", ErrInvalidFormat, kind))
+		}
+	}
+	return errs
+}
+
+func validateTagPattern(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("%w: tag pattern must not be empty", ErrInvalidFormat)
+	}
+	if pattern == TagPatternSemver {
+		return nil
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("%w: tag pattern %q: %v", ErrInvalidFormat, pattern, err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSemverTag(t *testing.T) {
+	for _, tag := range []string{"1.0.0", "v2.3.1", "0.1.0-rc.1", "10.20.30"} {
+		require.True(t, IsSemverTag(tag), tag)
+	}
+	for _, tag := range []string{"latest", "stable", "v1", "1.2", "01.0.0", "1.0.0.0", "1.x.0"} {
+		require.False(t, IsSemverTag(tag), tag)
+	}
+}
+
+func TestTagPolicyIsImmutable(t *testing.T) {
+	def := DefaultTagPolicy()
+	require.True(t, def.IsImmutable(KindMCPServer, "1.0.0"))
+	require.True(t, def.IsImmutable(KindAgent, "v1.2.3-beta"))
+	require.False(t, def.IsImmutable(KindMCPServer, "latest"))
+	require.False(t, def.IsImmutable(KindMCPServer, "stable"))
+	require.False(t, def.IsImmutable(KindDeployment, "1.0.0"), "untagged kinds are never protected")
+
+	custom := TagPolicy{
+		Immutable: []string{TagPatternSemver, "release-*"},
+		Mutable:   []string{"*-rc.*"},
+		Kinds:     []string{KindMCPServer},
+	}
+	require.True(t, custom.IsImmutable(KindMCPServer, "release-2024"))
+	require.False(t, custom.IsImmutable(KindMCPServer, "1.0.0-rc.1"), "mutable overrides immutable")
+	require.False(t, custom.IsImmutable(KindAgent, "1.0.0"), "kinds limits the policy")
+
+	require.False(t, TagPolicy{}.IsImmutable(KindMCPServer, "1.0.0"), "an empty policy protects nothing")
+}
+
+func TestTagPolicyWithin(t *testing.T) {
+	server := DefaultTagPolicy()
+
+	loosened := TagPolicy{Mutable: []string{"*"}}.Within(server)
+	require.True(t, loosened.IsImmutable(KindMCPServer, "1.0.0"), "mutable exceptions cannot unprotect floor tags")
+	require.True(t, TagPolicy{}.Within(server).IsImmutable(KindMCPServer, "1.0.0"), "an empty policy keeps the floor")
+	require.True(t, TagPolicy{Kinds: []string{KindSkill}}.Within(server).IsImmutable(KindAgent, "1.0.0"))
+
+	tightened := TagPolicy{Immutable: []string{"release-*"}, Mutable: []string{"release-dev"}}.Within(server)
+	require.True(t, tightened.IsImmutable(KindAgent, "release-1"))
+	require.True(t, tightened.IsImmutable(KindAgent, "2.0.0"))
+	require.False(t, tightened.IsImmutable(KindAgent, "release-dev"))
+	require.False(t, tightened.IsImmutable(KindAgent, "latest"))
+}
+
+func TestTagPolicyValidate(t *testing.T) {
+	require.NoError(t, DefaultTagPolicy().Validate())
+	require.NoError(t, TagPolicy{Immutable: []string{"release-*"}, Kinds: []string{KindSkill}}.Validate())
+
+	err := TagPolicy{Immutable: []string{"[bad"}, Mutable: []string{""}, Kinds: []string{KindDeployment}}.Validate()
+	require.ErrorContains(t, err, "immutable[0]")
+	require.ErrorContains(t, err, "mutable[0]")
+	require.ErrorContains(t, err, "kinds[0]")
+}
@@ -0,0 +1,67 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Tag ranges let a ref float within a semver range instead of naming one
+// tag: `^1.2`, `~1.4.0`, `>=2.0 <3`, `1.2 - 1.4`. A ref tag is a range
+// only when it is not a valid literal tag (see tagRegex) and parses as a
+// semver constraint, so every literal tag keeps its exact-match meaning —
+// `1.2` selects the tag named "1.2", not the highest 1.2.x.
+//
+// A range resolves to the highest published tag that parses as a semver
+// version (an optional leading "v" is accepted) and satisfies it. Tags that
+// are not versions, such as "latest", never match. Prerelease tags match
+// only when the range itself names a prerelease, e.g. `>=2.0.0-rc.1`.
+
+// IsTagRange reports whether tag is a semver range rather than a literal
+// tag.
+func IsTagRange(tag string) bool {
+	_, ok := parseTagRange(tag)
+	return ok
+}
+
+// HighestMatchingTag returns the highest of tags that satisfies the semver
+// range rng. ok is false when rng is not a range or nothing matches.
+func HighestMatchingTag(rng string, tags []string) (tag string, ok bool) {
+	constraint, isRange := parseTagRange(rng)
+	if !isRange {
+		return "", false
+	}
+	var best *semver.Version
+	for _, candidate := range tags {
+		version, err := semver.NewVersion(candidate)
+		if err != nil || !constraint.Check(version) {
+			continue
+		}
+		if best == nil || version.GreaterThan(best) {
+			best, tag = version, candidate
+		}
+	}
+	return tag, best != nil
+}
+
+func parseTagRange(tag string) (*semver.Constraints, bool) {
+	if tag == "" || tagRegex.MatchString(tag) {
+		return nil, false
+	}
+	constraint, err := semver.NewConstraint(tag)
+	if err != nil {
+		return nil, false
+	}
+	return constraint, true
+}
+
+// validateRefTag accepts a literal tag or a semver range.
+func validateRefTag(tag string) error {
+	if IsTagRange(tag) {
+		return nil
+	}
+	if err := ValidateTag(tag); err != nil {
+		return fmt.Errorf("%w: must be a tag matching %s or a semver range", ErrInvalidTag, tagRegex.String())
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTagRange(t *testing.T) {
+	for _, tag := range []string{"^1.2", "~1.4.0", ">=2.0 <3", "1.2 - 1.4", "*", ">=1.0.0-rc.1"} {
+		require.True(t, IsTagRange(tag), tag)
+	}
+	// Anything that is a valid literal tag keeps its exact meaning.
+	for _, tag := range []string{"", "latest", "1.2", "1.x", "v1.2.3", "stable", "not a tag"} {
+		require.False(t, IsTagRange(tag), tag)
+	}
+}
+
+func TestHighestMatchingTag(t *testing.T) {
+	tags := []string{"latest", "1.2.0", "v1.3.5", "1.10.0", "2.0.0-rc.1", "2.0.0", "2.1.0-beta", "stable"}
+	tests := []struct {
+		rng    string
+		want   string
+		wantOK bool
+	}{
+		{rng: "^1.2", want: "1.10.0", wantOK: true},
+		{rng: "~1.3.0", want: "v1.3.5", wantOK: true},
+		{rng: ">=2.0 <3", want: "2.0.0", wantOK: true},
+		{rng: ">=2.1.0-alpha", want: "2.1.0-beta", wantOK: true},
+		{rng: "^3", wantOK: false},
+		{rng: "latest", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rng, func(t *testing.T) {
+			got, ok := HighestMatchingTag(tt.rng, tags)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateRef_AcceptsSemverRangeTag(t *testing.T) {
+	require.Empty(t, validateRef(ResourceRef{Kind: KindMCPServer, Name: "tools", Tag: "^1.2"}))
+
+	errs := validateRef(ResourceRef{Kind: KindMCPServer, Name: "tools", Tag: "^^1"})
+	require.Len(t, errs, 1)
+	require.Equal(t, "tag", errs[0].Path)
+	require.ErrorIs(t, errs[0].Cause, ErrInvalidTag)
+}
@@ -0,0 +1,157 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TagRetentionPolicy decides which tags of tagged artifacts are pruned by
+// the retention controller. Each kind follows the first rule that lists it,
+// or else the first rule that lists no kinds; a kind no rule covers keeps
+// every tag.
+//
+// The "latest" tag, tags matching a rule's Keep patterns and tags a live
+// object references are never pruned.
+type TagRetentionPolicy struct {
+	Rules []TagRetentionRule `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+// TagRetentionRule is one retention rule.
+//
+// KeepLast keeps the N highest semver tags of each name. With MaxAge unset,
+// every older semver tag is pruned and other tags are kept. MaxAge prunes
+// semver tags, and tags matching Prune, not written for that long, except
+// the KeepLast newest versions. Any other tag is a floating pointer such as
+// "stable", which may go unmoved for long and is never pruned.
+type TagRetentionRule struct {
+	// Kinds limits the rule to the listed tagged kinds. Empty means every
+	// tagged kind.
+	Kinds []string `json:"kinds,omitempty" yaml:"kinds,omitempty"`
+	// KeepLast is the number of highest semver tags always kept. Zero
+	// keeps none by count.
+	KeepLast int `json:"keepLast,omitempty" yaml:"keepLast,omitempty"`
+	// MaxAge is a duration such as "720h" or "90d". Empty disables
+	// age-based pruning.
+	MaxAge string `json:"maxAge,omitempty" yaml:"maxAge,omitempty"`
+	// Keep lists tag patterns never pruned: TagPatternSemver or path.Match
+	// globs (`stable`, `release-*`).
+	Keep []string `json:"keep,omitempty" yaml:"keep,omitempty"`
+	// Prune lists the non-semver tag patterns MaxAge also prunes, such as
+	// per-build `dev-*` tags. TagPatternSemver or path.Match globs.
+	Prune []string `json:"prune,omitempty" yaml:"prune,omitempty"`
+}
+
+// RuleFor returns the rule governing kind. ok is false for untagged kinds
+// and kinds no rule covers.
+func (p TagRetentionPolicy) RuleFor(kind string) (rule TagRetentionRule, ok bool) {
+	if !IsTaggedArtifactKind(kind) {
+		return TagRetentionRule{}, false
+	}
+	for _, r := range p.Rules {
+		for _, k := range r.Kinds {
+			if k == kind {
+				return r, true
+			}
+		}
+	}
+	for _, r := range p.Rules {
+		if len(r.Kinds) == 0 {
+			return r, true
+		}
+	}
+	return TagRetentionRule{}, false
+}
+
+// Enabled reports whether any rule can prune a tag.
+func (p TagRetentionPolicy) Enabled() bool {
+	for _, r := range p.Rules {
+		if r.KeepLast > 0 || r.MaxAge != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Keeps reports whether tag is protected from pruning by the rule's Keep
+// patterns or by being "latest".
+func (r TagRetentionRule) Keeps(tag string) bool {
+	return tag == "latest" || matchesTagPattern(r.Keep, tag)
+}
+
+// AgesOut reports whether MaxAge may prune tag: semver tags and tags
+// matching the rule's Prune patterns do, floating tags do not.
+func (r TagRetentionRule) AgesOut(tag string) bool {
+	return IsSemverTag(tag) || matchesTagPattern(r.Prune, tag)
+}
+
+// MaxAgeDuration parses MaxAge. Zero means age-based pruning is off.
+func (r TagRetentionRule) MaxAgeDuration() (time.Duration, error) {
+	return ParseRetentionAge(r.MaxAge)
+}
+
+// ParseRetentionAge parses a Go duration ("720h") or a whole number of days
+// ("90d"). Empty parses as zero.
+func ParseRetentionAge(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	var d time.Duration
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("%w: max age %q is not a whole number of days", ErrInvalidFormat, s)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if d, err = time.ParseDuration(s); err != nil {
+			return 0, fmt.Errorf("%w: max age %q: %v", ErrInvalidFormat, s, err)
+		}
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%w: max age %q must be positive", ErrInvalidFormat, s)
+	}
+	return d, nil
+}
+
+// Validate checks every rule's counts, ages, patterns and kinds.
+func (p TagRetentionPolicy) Validate() error {
+	if errs := p.validate(""); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (p TagRetentionPolicy) validate(prefix string) FieldErrors {
+	var errs FieldErrors
+	for i, r := range p.Rules {
+		field := fmt.Sprintf("rules[%d]", i)
+		if prefix != "" {
+			field = prefix + "." + field
+		}
+		if r.KeepLast < 0 {
+			errs.Append(field+".keepLast", fmt.Errorf("%w: must not be negative", ErrInvalidFormat))
+		}
+		if _, err := r.MaxAgeDuration(); err != nil {
+			errs.Append(field+".maxAge", err)
+		}
+		for j, pattern := range r.Keep {
+			if err := validateTagPattern(pattern); err != nil {
+				errs.Append(fmt.Sprintf("%s.keep[%d]", field, j), err)
+			}
+		}
+		for j, pattern := range r.Prune {
+			if err := validateTagPattern(pattern); err != nil {
+				errs.Append(fmt.Sprintf("%s.prune[%d]", field, j), err)
+			}
+		}
+		for j, kind := range r.Kinds {
+			if !IsTaggedArtifactKind(kind) {
+				errs.Append(fmt.Sprintf("%s.kinds[%d]", field, j), fmt.Errorf("%w: %q is not a tagged kind", ErrInvalidFormat, kind))
+			}
+		}
+	}
+	return errs
+}
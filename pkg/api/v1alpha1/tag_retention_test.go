@@ -0,0 +1,63 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagRetentionPolicyRuleFor(t *testing.T) {
+	policy := TagRetentionPolicy{Rules: []TagRetentionRule{
+		{KeepLast: 10},
+		{Kinds: []string{KindMCPServer}, KeepLast: 3},
+	}}
+	rule, ok := policy.RuleFor(KindMCPServer)
+	require.True(t, ok)
+	require.Equal(t, 3, rule.KeepLast, "a rule naming the kind wins over a catch-all")
+	rule, ok = policy.RuleFor(KindSkill)
+	require.True(t, ok)
+	require.Equal(t, 10, rule.KeepLast)
+	_, ok = policy.RuleFor(KindDeployment)
+	require.False(t, ok, "untagged kinds have no retention")
+	_, ok = TagRetentionPolicy{Rules: []TagRetentionRule{{Kinds: []string{KindAgent}, KeepLast: 1}}}.RuleFor(KindSkill)
+	require.False(t, ok)
+
+	require.True(t, policy.Enabled())
+	require.False(t, TagRetentionPolicy{Rules: []TagRetentionRule{{Keep: []string{"stable"}}}}.Enabled())
+}
+
+func TestTagRetentionRuleKeeps(t *testing.T) {
+	rule := TagRetentionRule{Keep: []string{"stable", "release-*"}}
+	require.True(t, rule.Keeps("latest"))
+	require.True(t, rule.Keeps("stable"))
+	require.True(t, rule.Keeps("release-2026"))
+	require.False(t, rule.Keeps("1.0.0"))
+}
+
+func TestParseRetentionAge(t *testing.T) {
+	d, err := ParseRetentionAge("90d")
+	require.NoError(t, err)
+	require.Equal(t, 90*24*time.Hour, d)
+	d, err = ParseRetentionAge("36h")
+	require.NoError(t, err)
+	require.Equal(t, 36*time.Hour, d)
+	d, err = ParseRetentionAge("")
+	require.NoError(t, err)
+	require.Zero(t, d)
+	for _, bad := range []string{"1.5d", "soon", "-1h", "0d"} {
+		_, err := ParseRetentionAge(bad)
+		require.Error(t, err, bad)
+	}
+}
+
+func TestTagRetentionPolicyValidate(t *testing.T) {
+	require.NoError(t, TagRetentionPolicy{Rules: []TagRetentionRule{{KeepLast: 5, MaxAge: "30d", Keep: []string{"stable"}, Prune: []string{"dev-*"}}}}.Validate())
+
+	err := TagRetentionPolicy{Rules: []TagRetentionRule{{KeepLast: -1, MaxAge: "often", Keep: []string{"[bad"}, Prune: []string{"[bad"}, Kinds: []string{KindDeployment}}}}.Validate()
+	require.ErrorContains(t, err, "rules[0].keepLast")
+	require.ErrorContains(t, err, "rules[0].maxAge")
+	require.ErrorContains(t, err, "rules[0].keep[0]")
+	require.ErrorContains(t, err, "rules[0].prune[0]")
+	require.ErrorContains(t, err, "rules[0].kinds[0]")
+}
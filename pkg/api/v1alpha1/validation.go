@@ -95,6 +95,12 @@ var namespaceRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]{0,61}[a-z0-9])?$`)
 var labelKeyRegex = regexp.MustCompile(`^([a-z0-9]([-a-z0-9.]{0,251}[a-z0-9])?/)?[a-zA-Z0-9]([-a-zA-Z0-9._]{0,61}[a-zA-Z0-9])?$`)
 var labelValueRegex = regexp.MustCompile(`^([a-zA-Z0-9]([-a-zA-Z0-9._]{0,61}[a-zA-Z0-9])?)?$`)
 
+// scpLikeRemoteRegex: git's scp-like ssh remote syntax, [user@]host:path,
+// capturing host and path. A path starting with ":" is excluded so transport
+// helpers (ext::cmd) never pass as a remote. Use SplitSCPLikeRemote, which
+// also rules out URLs.
+var scpLikeRemoteRegex = regexp.MustCompile(`^(?:[^@/:\s]+@)?([^@/:\s]+):([^:\s]\S*)$`)
+
 var tagRegex = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
 
 // DNS-1123 subdomain form: lowercase alphanumeric, hyphens, and dots.
@@ -175,7 +181,9 @@ func ValidateObjectMeta(m ObjectMeta) FieldErrors {
 	return errs
 }
 
-func validateTag(tag string) error {
+// ValidateTag checks that tag is a literal tag: 1-128 characters of
+// [A-Za-z0-9_.-], not starting with "." or "-".
+func ValidateTag(tag string) error {
 	if tag == "" {
 		return fmt.Errorf("%w", ErrRequiredField)
 	}
@@ -239,6 +247,22 @@ func validateTitle(title string) error {
 	return nil
 }
 
+// SplitSCPLikeRemote returns the host and path of a remote in git's
+// scp-like ssh syntax ([user@]host:path, e.g.
+// git@gitlab.example.com:group/repo.git). ok is false for anything else,
+// URLs included. Validation and the git clients share it, so a remote that
+// validates is parsed the same way when it is cloned.
+func SplitSCPLikeRemote(remote string) (host, path string, ok bool) {
+	if strings.Contains(remote, "://") {
+		return "", "", false
+	}
+	m := scpLikeRemoteRegex.FindStringSubmatch(remote)
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], m[2], true
+}
+
 // validateRepository validates the Repository object
 func validateRepository(r *Repository) FieldErrors {
 	var errs FieldErrors
@@ -246,8 +270,12 @@ func validateRepository(r *Repository) FieldErrors {
 		return errs
 	}
 	if r.URL != "" {
-		if err := validateWebsiteURL(r.URL); err != nil {
-			errs.Append("repository.url", err)
+		// scp-style ssh remotes (git@host:group/repo.git) are not URLs but are
+		// the usual way to address a self-hosted git server over ssh.
+		if _, _, ok := SplitSCPLikeRemote(r.URL); !ok {
+			if err := validateWebsiteURL(r.URL); err != nil {
+				errs.Append("repository.url", err)
+			}
 		}
 	} else {
 		if r.Branch != "" {
@@ -278,11 +306,12 @@ func validateRef(r ResourceRef, allowedKinds ...string) FieldErrors {
 	if err := validateNameField(r.Name); err != nil {
 		errs.Append("name", err)
 	}
-	// Tag is optional on content refs — blank means "resolve to latest".
+	// Tag is optional on content refs — blank means "resolve to latest"; a
+	// semver range resolves to the highest matching tag.
 	if r.Tag != "" {
 		if !IsTaggedArtifactKind(r.Kind) {
 			errs.Append("tag", fmt.Errorf("%w: kind %q does not support tag pinning", ErrInvalidRef, r.Kind))
-		} else if err := validateTag(r.Tag); err != nil {
+		} else if err := validateRefTag(r.Tag); err != nil {
 			errs.Append("tag", err)
 		}
 	}
@@ -205,6 +205,39 @@ func TestAgentValidate_AcceptsRepositoryWithBranchAndCommit(t *testing.T) {
 	require.NoError(t, a.Validate())
 }
 
+func TestAgentValidate_RepositoryURLForms(t *testing.T) {
+	cases := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{"github https", "https://github.com/example/repo", false},
+		{"self-hosted https", "https://git.example.com/group/sub/repo.git", false},
+		{"ssh url", "ssh://git@git.example.com:2222/group/repo.git", false},
+		{"scp-style ssh", "git@git.example.com:group/repo.git", false},
+		{"scp-style absolute path", "git@git.example.com:/srv/git/repo.git", false},
+		{"scp-style with whitespace", "git@git.example.com:group/my repo.git", true},
+		{"transport helper", "ext::sh -c evil", true},
+		{"no host", "not-a-url", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := &Agent{
+				Metadata: ObjectMeta{Namespace: "default", Name: "a"},
+				Spec: AgentSpec{
+					Source: &AgentSource{Repository: &Repository{URL: tc.url}},
+				},
+			}
+			err := a.Validate()
+			if !tc.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			require.Contains(t, failedFields(t, err), "spec.source.repository.url")
+		})
+	}
+}
+
 func TestAgentValidate_RejectsBranchOrCommitWithoutURL(t *testing.T) {
 	cases := []struct {
 		name string
@@ -485,6 +518,35 @@ func TestDeploymentValidate_EnvFrom(t *testing.T) {
 	}
 }
 
+func TestDeploymentValidate_TargetDigest(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("ab", 32)
+	tests := []struct {
+		name       string
+		tag        string
+		digest     string
+		wantFields []string
+	}{
+		{name: "pinned exact tag", tag: "stable", digest: digest},
+		{name: "pinned default tag", digest: digest},
+		{name: "malformed digest", tag: "stable", digest: "sha256:abc", wantFields: []string{"spec.targetDigest"}},
+		{name: "missing algorithm", tag: "stable", digest: strings.Repeat("ab", 32), wantFields: []string{"spec.targetDigest"}},
+		{name: "semver range", tag: "^1.2.0", digest: digest, wantFields: []string{"spec.targetDigest"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Deployment{
+				Metadata: ObjectMeta{Namespace: "default", Name: "prod"},
+				Spec: DeploymentSpec{
+					TargetRef:    ResourceRef{Kind: KindMCPServer, Name: "weather", Tag: tt.tag},
+					TargetDigest: tt.digest,
+					RuntimeRef:   ResourceRef{Kind: KindRuntime, Name: "kagent"},
+				},
+			}
+			require.ElementsMatch(t, tt.wantFields, failedFields(t, d.Validate()))
+		})
+	}
+}
+
 func TestDeploymentValidate_RejectsHarnessSelectionWithoutType(t *testing.T) {
 	d := &Deployment{
 		Metadata: ObjectMeta{Namespace: "default", Name: "prod"},
@@ -616,6 +678,18 @@ func TestDeploymentValidate_RejectsBadTargetRefTag(t *testing.T) {
 	require.Contains(t, paths, "spec.targetRef.tag")
 }
 
+func TestDeploymentValidate_AcceptsSemverRangeTags(t *testing.T) {
+	d := &Deployment{
+		Metadata: ObjectMeta{Namespace: "default", Name: "prod"},
+		Spec: DeploymentSpec{
+			TargetRef:  ResourceRef{Kind: KindAgent, Name: "alice", Tag: "^1.2"},
+			RuntimeRef: ResourceRef{Kind: KindRuntime, Name: "kubernetes-default"},
+			ModelRef:   &ModelRef{Name: "claude", Tag: ">=2.0 <3"},
+		},
+	}
+	require.NoError(t, d.Validate())
+}
+
 func TestDeploymentResolveRefs_InheritsNamespace(t *testing.T) {
 	var seen []ResourceRef
 	resolver := func(ctx context.Context, ref ResourceRef) error {
@@ -80,6 +80,17 @@ func Root(cfg Config) *cobra.Command {
 	root.AddCommand(declarative.NewRunCmd(deps))
 	root.AddCommand(declarative.NewPullCmd(deps))
 	root.AddCommand(declarative.NewWaitCmd(deps))
+	root.AddCommand(declarative.NewSearchCmd(deps))
+	root.AddCommand(declarative.NewGraphCmd(deps))
+	root.AddCommand(declarative.NewSignCmd(deps))
+	root.AddCommand(declarative.NewVerifyCmd(deps))
+	root.AddCommand(declarative.NewApprovalsCmd(deps))
+	root.AddCommand(declarative.NewDeprecateCmd(deps))
+	root.AddCommand(declarative.NewYankCmd(deps))
+	root.AddCommand(declarative.NewAuditCmd(deps))
+	root.AddCommand(declarative.NewRolloutCmd(deps))
+	root.AddCommand(declarative.NewRollbackCmd(deps))
+	root.AddCommand(declarative.NewRegistryCmd(deps))
 	migrationSources := append([]migrate.Source{legacymigrate.OSSSource()}, cfg.ExtraMigrationSources...)
 	root.AddCommand(db.NewCommand(migrationSources...))
 
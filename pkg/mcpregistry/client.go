@@ -0,0 +1,77 @@
+package mcpregistry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client reads the v0.1 server list of an upstream MCP registry: the
+// official registry or another AgentRegistry's compatibility API.
+type Client struct {
+	// BaseURL is the registry root; "/v0.1/servers" is appended to it.
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// ListServersOpts selects one page of the upstream server list.
+type ListServersOpts struct {
+	Cursor string
+	Limit  int
+	// UpdatedSince, when set, limits the list to servers changed after it.
+	UpdatedSince time.Time
+	// IncludeDeleted also lists servers whose status is "deleted", so an
+	// incremental sync can see removals.
+	IncludeDeleted bool
+}
+
+// ListServers fetches one page of the upstream server list.
+func (c *Client) ListServers(ctx context.Context, opts ListServersOpts) (ServerListResponse, error) {
+	q := url.Values{}
+	if opts.Cursor != "" {
+		q.Set("cursor", opts.Cursor)
+	}
+	if opts.Limit > 0 {
+		q.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if !opts.UpdatedSince.IsZero() {
+		q.Set("updated_since", opts.UpdatedSince.UTC().Format(time.RFC3339))
+	}
+	if opts.IncludeDeleted {
+		q.Set("include_deleted", "true")
+	}
+	endpoint := strings.TrimRight(c.BaseURL, "/") + "/v0.1/servers"
+	if len(q) > 0 {
+		endpoint += "?" + q.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return ServerListResponse{}, fmt.Errorf("mcp registry: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return ServerListResponse{}, fmt.Errorf("mcp registry: list servers: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return ServerListResponse{}, fmt.Errorf("mcp registry: list servers: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	var out ServerListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return ServerListResponse{}, fmt.Errorf("mcp registry: decode server list: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,240 @@
+package mcpregistry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// Metadata written on MCPServer objects mirrored from an upstream registry.
+// The label marks the object as owned by the named mirror, so a sync never
+// overwrites or deletes objects authored in this registry; the annotations
+// record where the object came from.
+const (
+	MirrorLabel                   = "agentregistry.solo.io/mirrored-from"
+	MirrorUpstreamURLAnnotation   = "agentregistry.solo.io/mirror-upstream-url"
+	MirrorServerNameAnnotation    = "agentregistry.solo.io/mirror-server-name"
+	MirrorServerVersionAnnotation = "agentregistry.solo.io/mirror-server-version"
+)
+
+// StatusDeleted is the OfficialMeta status of a server the upstream registry
+// has removed from its catalogue.
+const StatusDeleted = "deleted"
+
+// maxMirrorNameLen keeps mirrored names within the DNS-1123 subdomain limit.
+const maxMirrorNameLen = 253
+
+// MirrorName maps an upstream server name ("io.github.acme/weather") to an
+// MCPServer name ("io.github.acme-weather"): lowercased, with every character
+// outside [a-z0-9.-] replaced by "-". Names that would still be invalid, or
+// that exceed the length limit, are truncated and suffixed with a hash of the
+// upstream name so distinct upstream servers never share a mirrored name.
+func MirrorName(serverName string) (string, error) {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(serverName)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '-' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('-')
+		}
+	}
+	var segments []string
+	for seg := range strings.SplitSeq(b.String(), ".") {
+		if seg = strings.Trim(seg, "-"); seg != "" {
+			segments = append(segments, seg)
+		}
+	}
+	name := strings.Join(segments, ".")
+	if name == "" {
+		return "", fmt.Errorf("server name %q has no characters usable in a resource name", serverName)
+	}
+	// Upstream names carry exactly one "/", so replacing it alone is
+	// injective. Any other rewrite ("Acme_X" and "acme-x" collide) gets a
+	// hash suffix.
+	if name != strings.ReplaceAll(serverName, "/", "-") || len(name) > maxMirrorNameLen {
+		sum := sha256.Sum256([]byte(serverName))
+		suffix := "-" + hex.EncodeToString(sum[:])[:10]
+		name = strings.TrimRight(name[:min(len(name), maxMirrorNameLen-len(suffix))], "-.") + suffix
+	}
+	if !v1alpha1.DNSSubdomainRegex.MatchString(name) {
+		return "", fmt.Errorf("server name %q does not map to a valid resource name (got %q)", serverName, name)
+	}
+	return name, nil
+}
+
+// ToMCPServer is the reverse of FromMCPServer: it translates an upstream
+// server.json document into an MCPServer in namespace, named by MirrorName
+// and tagged with the upstream version. The first package of a supported
+// registry type (npm, pypi, oci) becomes spec.source.package; servers with
+// no such package use their first remote. Versions that are not valid tags
+// are rejected; callers Validate the rest.
+func ToMCPServer(detail ServerDetail, namespace string) (*v1alpha1.MCPServer, error) {
+	name, err := MirrorName(detail.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := v1alpha1.ValidateTag(detail.Version); err != nil {
+		return nil, fmt.Errorf("server %s: version %q is not usable as a tag: %w", detail.Name, detail.Version, err)
+	}
+	s := &v1alpha1.MCPServer{
+		TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindMCPServer},
+		Metadata: v1alpha1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Tag:       detail.Version,
+		},
+		Spec: v1alpha1.MCPServerSpec{
+			Title:       detail.Title,
+			Description: detail.Description,
+		},
+	}
+
+	var repo *v1alpha1.Repository
+	if r := detail.Repository; r != nil && r.URL != "" {
+		repo = &v1alpha1.Repository{URL: r.URL, Subfolder: r.Subfolder}
+	}
+	for _, p := range detail.Packages {
+		pkg, ok, err := packageFrom(detail.Name, p)
+		if err != nil {
+			return nil, fmt.Errorf("server %s: package %s: %w", detail.Name, p.Identifier, err)
+		}
+		if ok {
+			s.Spec.Source = &v1alpha1.MCPServerSource{Package: pkg, Repository: repo}
+			return s, nil
+		}
+	}
+	if len(detail.Remotes) > 0 {
+		r := detail.Remotes[0]
+		s.Spec.Remote = &v1alpha1.MCPRemote{Type: r.Type, URL: r.URL}
+		for _, h := range r.Headers {
+			s.Spec.Remote.Headers = append(s.Spec.Remote.Headers, v1alpha1.HTTPHeader{Name: h.Name, Value: h.Value})
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("server %s has no npm, pypi or oci package and no remote", detail.Name)
+}
+
+// packageFrom maps an upstream package onto MCPPackage. It reports false for
+// registry types AgentRegistry cannot deploy (e.g. nuget, mcpb).
+func packageFrom(serverName string, p ServerPackage) (*v1alpha1.MCPPackage, bool, error) {
+	origin := v1alpha1.MCPPackageOrigin{
+		Type:       v1alpha1.MCPPackageOriginType(p.RegistryType),
+		Identifier: p.Identifier,
+	}
+	var defaultCommand string
+	var defaultRuntimeArgs, spec []v1alpha1.MCPArgument
+	switch origin.Type {
+	case v1alpha1.MCPPackageOriginTypeNPM:
+		origin.NPM = &v1alpha1.MCPPackageOriginNPM{Version: p.Version, Mirror: p.RegistryBaseURL, ServerName: serverName}
+		defaultCommand = "npx"
+		defaultRuntimeArgs = []v1alpha1.MCPArgument{positional("-y")}
+		spec = []v1alpha1.MCPArgument{positional(p.Identifier + "@" + p.Version)}
+	case v1alpha1.MCPPackageOriginTypePyPI:
+		origin.PyPI = &v1alpha1.MCPPackageOriginPyPI{Version: p.Version, Mirror: p.RegistryBaseURL, ServerName: serverName}
+		defaultCommand = "uvx"
+		spec = []v1alpha1.MCPArgument{positional(p.Identifier + "==" + p.Version)}
+	case v1alpha1.MCPPackageOriginTypeOCI:
+		origin.OCI = &v1alpha1.MCPPackageOriginOCI{ServerName: serverName}
+		if ociVersionFromIdentifier(p.Identifier) == "" && p.Version != "" {
+			origin.Identifier += ":" + p.Version
+		}
+	default:
+		return nil, false, nil
+	}
+
+	transport, err := transportFrom(p.Transport)
+	if err != nil {
+		return nil, false, err
+	}
+	pkg := &v1alpha1.MCPPackage{Origin: origin, Transport: transport}
+	if len(p.RuntimeArguments) == 0 && len(p.PackageArguments) == 0 && len(p.EnvironmentVariables) == 0 &&
+		(p.RuntimeHint == "" || p.RuntimeHint == defaultCommand) {
+		// The resolver's defaults launch the package as upstream does.
+		return pkg, true, nil
+	}
+
+	// A Launch block replaces the resolver defaults verbatim, so it must
+	// spell out the full command line: upstream runs
+	// "<runtimeHint> <runtimeArguments> <package> <packageArguments>".
+	launch := &v1alpha1.MCPPackageLaunch{Env: keyValuesFrom(p.EnvironmentVariables)}
+	if origin.Type == v1alpha1.MCPPackageOriginTypeOCI {
+		// The runtime hint and arguments configure the container runtime
+		// ("docker run ..."); the image entrypoint takes package arguments.
+		launch.Args = argumentsFrom(p.PackageArguments)
+	} else {
+		launch.Command = p.RuntimeHint
+		runtimeArgs := argumentsFrom(p.RuntimeArguments)
+		if launch.Command == "" {
+			launch.Command = defaultCommand
+		}
+		if len(runtimeArgs) == 0 && launch.Command == defaultCommand {
+			runtimeArgs = defaultRuntimeArgs
+		}
+		launch.Args = append(append(runtimeArgs, spec...), argumentsFrom(p.PackageArguments)...)
+	}
+	pkg.Launch = launch
+	return pkg, true, nil
+}
+
+// transportFrom is the inverse of packageTransportOf: "stdio" stays stdio,
+// and the HTTP transports become "http" listening on the port and path of
+// the upstream URL (a localhost URL describing where the package listens).
+func transportFrom(t ServerTransport) (v1alpha1.MCPTransport, error) {
+	switch t.Type {
+	case "", "stdio":
+		return v1alpha1.MCPTransport{Type: "stdio"}, nil
+	case "streamable-http", "sse", "http":
+		u, err := url.Parse(t.URL)
+		if err != nil {
+			return v1alpha1.MCPTransport{}, fmt.Errorf("transport url %q: %w", t.URL, err)
+		}
+		port, err := strconv.ParseUint(u.Port(), 10, 16)
+		if err != nil || port == 0 {
+			return v1alpha1.MCPTransport{}, fmt.Errorf("transport url %q has no listen port", t.URL)
+		}
+		return v1alpha1.MCPTransport{Type: "http", Port: uint16(port), Path: u.Path}, nil
+	default:
+		return v1alpha1.MCPTransport{}, fmt.Errorf("unsupported transport type %q", t.Type)
+	}
+}
+
+// argumentsFrom is the inverse of argumentsOf.
+func argumentsFrom(args []ServerArgument) []v1alpha1.MCPArgument {
+	if len(args) == 0 {
+		return nil
+	}
+	out := make([]v1alpha1.MCPArgument, 0, len(args))
+	for _, a := range args {
+		out = append(out, v1alpha1.MCPArgument{
+			Type:  v1alpha1.MCPArgumentType(a.Type),
+			Name:  a.Name,
+			Value: a.Value,
+		})
+	}
+	return out
+}
+
+// keyValuesFrom is the inverse of envOf.
+func keyValuesFrom(env []ServerInput) []v1alpha1.MCPKeyValueInput {
+	if len(env) == 0 {
+		return nil
+	}
+	out := make([]v1alpha1.MCPKeyValueInput, 0, len(env))
+	for _, e := range env {
+		out = append(out, v1alpha1.MCPKeyValueInput{
+			Name:       e.Name,
+			Value:      e.Value,
+			IsRequired: e.IsRequired,
+		})
+	}
+	return out
+}
+
+func positional(value string) v1alpha1.MCPArgument {
+	return v1alpha1.MCPArgument{Type: v1alpha1.MCPArgumentTypePositional, Value: value}
+}
@@ -0,0 +1,175 @@
+package mcpregistry_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/mcpregistry"
+)
+
+func TestMirrorName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string // regexp
+	}{
+		{"io.github.acme/weather", `^io\.github\.acme-weather$`},
+		{"team-a/weather", `^team-a-weather$`},
+		// Lossy rewrites are disambiguated with a hash of the upstream name.
+		{"com.example/My_Server", `^com\.example-my-server-[0-9a-f]{10}$`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := mcpregistry.MirrorName(tt.in)
+			require.NoError(t, err)
+			assert.Regexp(t, tt.want, got)
+		})
+	}
+
+	_, err := mcpregistry.MirrorName("///")
+	assert.Error(t, err)
+}
+
+func TestToMCPServer_NPMPackage(t *testing.T) {
+	detail := mcpregistry.ServerDetail{
+		Name:        "io.github.acme/weather",
+		Title:       "Weather",
+		Description: "Forecasts",
+		Version:     "1.2.0",
+		Repository:  &mcpregistry.ServerRepository{URL: "https://github.com/acme/weather", Source: "github", Subfolder: "server"},
+		Packages: []mcpregistry.ServerPackage{
+			{RegistryType: "nuget", Identifier: "Acme.Weather", Version: "1.2.0"},
+			{
+				RegistryType:         "npm",
+				Identifier:           "@acme/weather",
+				Version:              "1.2.0",
+				Transport:            mcpregistry.ServerTransport{Type: "stdio"},
+				PackageArguments:     []mcpregistry.ServerArgument{{Type: "named", Name: "--units", Value: "metric"}},
+				EnvironmentVariables: []mcpregistry.ServerInput{{Name: "API_KEY", IsRequired: true}},
+			},
+		},
+	}
+
+	s, err := mcpregistry.ToMCPServer(detail, "mirror")
+	require.NoError(t, err)
+	require.NoError(t, s.Validate())
+	assert.Equal(t, "mirror", s.Metadata.Namespace)
+	assert.Equal(t, "io.github.acme-weather", s.Metadata.Name)
+	assert.Equal(t, "1.2.0", s.Metadata.Tag)
+	assert.Equal(t, &v1alpha1.Repository{URL: "https://github.com/acme/weather", Subfolder: "server"}, s.Spec.Source.Repository)
+
+	pkg := s.Spec.Source.Package
+	assert.Equal(t, v1alpha1.MCPPackageOriginTypeNPM, pkg.Origin.Type, "unsupported registry types are passed over")
+	assert.Equal(t, &v1alpha1.MCPPackageOriginNPM{Version: "1.2.0", ServerName: "io.github.acme/weather"}, pkg.Origin.NPM)
+	require.NotNil(t, pkg.Launch, "package arguments need an explicit launch")
+	assert.Equal(t, "npx", pkg.Launch.Command)
+	assert.Equal(t, []v1alpha1.MCPArgument{
+		{Type: v1alpha1.MCPArgumentTypePositional, Value: "-y"},
+		{Type: v1alpha1.MCPArgumentTypePositional, Value: "@acme/weather@1.2.0"},
+		{Type: v1alpha1.MCPArgumentTypeNamed, Name: "--units", Value: "metric"},
+	}, pkg.Launch.Args)
+	assert.Equal(t, []v1alpha1.MCPKeyValueInput{{Name: "API_KEY", IsRequired: true}}, pkg.Launch.Env)
+}
+
+func TestToMCPServer_OCIAndRemote(t *testing.T) {
+	oci, err := mcpregistry.ToMCPServer(mcpregistry.ServerDetail{
+		Name:    "io.github.acme/search",
+		Version: "2.0.0",
+		Packages: []mcpregistry.ServerPackage{{
+			RegistryType: "oci",
+			Identifier:   "ghcr.io/acme/search",
+			Version:      "2.0.0",
+			Transport:    mcpregistry.ServerTransport{Type: "streamable-http", URL: "http://localhost:8080/mcp"},
+		}},
+	}, "default")
+	require.NoError(t, err)
+	require.NoError(t, oci.Validate())
+	pkg := oci.Spec.Source.Package
+	assert.Equal(t, "ghcr.io/acme/search:2.0.0", pkg.Origin.Identifier)
+	assert.Equal(t, v1alpha1.MCPTransport{Type: "http", Port: 8080, Path: "/mcp"}, pkg.Transport)
+	assert.Nil(t, pkg.Launch, "default launch needs no launch block")
+
+	remote, err := mcpregistry.ToMCPServer(mcpregistry.ServerDetail{
+		Name:    "com.example/hosted",
+		Version: "1.0.0",
+		Remotes: []mcpregistry.ServerTransport{{
+			Type:    "sse",
+			URL:     "https://mcp.example.com/sse",
+			Headers: []mcpregistry.ServerInput{{Name: "X-Team", Value: "a"}},
+		}},
+	}, "default")
+	require.NoError(t, err)
+	require.NoError(t, remote.Validate())
+	assert.Equal(t, &v1alpha1.MCPRemote{
+		Type:    "sse",
+		URL:     "https://mcp.example.com/sse",
+		Headers: []v1alpha1.HTTPHeader{{Name: "X-Team", Value: "a"}},
+	}, remote.Spec.Remote)
+
+	_, err = mcpregistry.ToMCPServer(mcpregistry.ServerDetail{Name: "com.example/empty", Version: "1.0.0"}, "default")
+	assert.ErrorContains(t, err, "no npm, pypi or oci package")
+	_, err = mcpregistry.ToMCPServer(mcpregistry.ServerDetail{Name: "com.example/build", Version: "1.0.0+build.7"}, "default")
+	assert.ErrorContains(t, err, "not usable as a tag")
+}
+
+func TestToMCPServer_RoundTripsFromMCPServer(t *testing.T) {
+	original := &v1alpha1.MCPServer{
+		TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindMCPServer},
+		Metadata: v1alpha1.ObjectMeta{Namespace: "team-a", Name: "weather", Tag: "1.2.0"},
+		Spec: v1alpha1.MCPServerSpec{
+			Title:       "Weather",
+			Description: "Forecasts",
+			Source: &v1alpha1.MCPServerSource{Package: &v1alpha1.MCPPackage{
+				Origin: v1alpha1.MCPPackageOrigin{
+					Type:       v1alpha1.MCPPackageOriginTypePyPI,
+					Identifier: "acme-weather",
+					PyPI:       &v1alpha1.MCPPackageOriginPyPI{Version: "1.2.0", ServerName: "team-a/weather"},
+				},
+				Transport: v1alpha1.MCPTransport{Type: "http", Port: 9000, Path: "/mcp"},
+			}},
+		},
+	}
+
+	mirrored, err := mcpregistry.ToMCPServer(mcpregistry.FromMCPServer(original).Server, "mirror")
+	require.NoError(t, err)
+	assert.Equal(t, "team-a-weather", mirrored.Metadata.Name)
+	assert.Equal(t, original.Spec, mirrored.Spec)
+}
+
+func TestClientListServers(t *testing.T) {
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v0.1/servers" {
+			http.NotFound(w, r)
+			return
+		}
+		gotQuery = r.URL.RawQuery
+		_ = json.NewEncoder(w).Encode(mcpregistry.ServerListResponse{
+			Servers:  []mcpregistry.ServerResponse{{Server: mcpregistry.ServerDetail{Name: "io.github.acme/weather", Version: "1.0.0"}}},
+			Metadata: mcpregistry.ListMetadata{NextCursor: "next", Count: 1},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	c := &mcpregistry.Client{BaseURL: srv.URL + "/"}
+	page, err := c.ListServers(context.Background(), mcpregistry.ListServersOpts{
+		Cursor:         "abc",
+		Limit:          50,
+		UpdatedSince:   time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+		IncludeDeleted: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "cursor=abc&include_deleted=true&limit=50&updated_since=2026-10-01T09%3A00%3A00Z", gotQuery)
+	assert.Equal(t, "next", page.Metadata.NextCursor)
+	require.Len(t, page.Servers, 1)
+
+	_, err = (&mcpregistry.Client{BaseURL: srv.URL + "/missing"}).ListServers(context.Background(), mcpregistry.ListServersOpts{})
+	assert.ErrorContains(t, err, "404")
+}
@@ -65,7 +65,7 @@ func FromMCPServer(s *v1alpha1.MCPServer) ServerResponse {
 	}
 	return ServerResponse{
 		Server: detail,
-		Meta:   &ResponseMeta{Official: officialMetaOf(s)},
+		Meta:   &ResponseMeta{Official: officialMetaOf(s), TagMarkers: tagMarkersMetaOf(s)},
 	}
 }
 
@@ -287,11 +287,12 @@ func headersOf(headers []v1alpha1.HTTPHeader) []ServerInput {
 
 // officialMetaOf builds the registry-managed _meta block. isLatest reflects
 // whether this row is the literal "latest" tag (the default list serves only
-// that tag; the versions endpoints can surface pinned older tags too). Status
-// reflects soft-deletion.
+// that tag; the versions endpoints can surface pinned older tags too); a
+// yanked "latest" no longer counts. Status reflects soft-deletion, then
+// deprecation or yanking, which the spec has no separate status for.
 func officialMetaOf(s *v1alpha1.MCPServer) *OfficialMeta {
 	tag := s.Metadata.Tag
-	m := &OfficialMeta{Status: "active", IsLatest: tag == "" || tag == "latest"}
+	m := &OfficialMeta{Status: "active", IsLatest: (tag == "" || tag == "latest") && s.Metadata.Yanked == nil}
 	if !s.Metadata.CreatedAt.IsZero() {
 		m.PublishedAt = s.Metadata.CreatedAt.UTC().Format(time.RFC3339)
 	}
@@ -299,8 +300,28 @@ func officialMetaOf(s *v1alpha1.MCPServer) *OfficialMeta {
 		m.UpdatedAt = s.Metadata.UpdatedAt.UTC().Format(time.RFC3339)
 		m.StatusChangedAt = m.UpdatedAt
 	}
-	if s.Metadata.DeletionTimestamp != nil {
+	switch {
+	case s.Metadata.DeletionTimestamp != nil:
 		m.Status = "deleted"
+	case s.Metadata.Yanked != nil:
+		m.Status = "deprecated"
+		if !s.Metadata.Yanked.YankedAt.IsZero() {
+			m.StatusChangedAt = s.Metadata.Yanked.YankedAt.UTC().Format(time.RFC3339)
+		}
+	case s.Metadata.Deprecated != nil:
+		m.Status = "deprecated"
+		if !s.Metadata.Deprecated.DeprecatedAt.IsZero() {
+			m.StatusChangedAt = s.Metadata.Deprecated.DeprecatedAt.UTC().Format(time.RFC3339)
+		}
 	}
 	return m
 }
+
+// tagMarkersMetaOf builds the tag-markers _meta block, or returns nil when
+// the tag is neither deprecated nor yanked.
+func tagMarkersMetaOf(s *v1alpha1.MCPServer) *TagMarkersMeta {
+	if s.Metadata.Deprecated == nil && s.Metadata.Yanked == nil {
+		return nil
+	}
+	return &TagMarkersMeta{Deprecated: s.Metadata.Deprecated, Yanked: s.Metadata.Yanked}
+}
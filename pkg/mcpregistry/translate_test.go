@@ -256,6 +256,41 @@ func TestFromMCPServer(t *testing.T) {
 				require.NotNil(t, r.Meta)
 				require.NotNil(t, r.Meta.Official)
 				assert.Equal(t, "deleted", r.Meta.Official.Status)
+				assert.Nil(t, r.Meta.TagMarkers)
+			},
+		},
+		{
+			name: "deprecated tag is marked deprecated and carries the deprecation",
+			mutate: func(s *v1alpha1.MCPServer) {
+				s.Metadata.Deprecated = &v1alpha1.Deprecation{
+					Message:      "use 2.x",
+					Replacement:  &v1alpha1.ResourceRef{Kind: v1alpha1.KindMCPServer, Name: "weather", Tag: "2.0.0"},
+					DeprecatedAt: updated.Add(time.Hour),
+				}
+			},
+			check: func(t *testing.T, r mcpregistry.ServerResponse) {
+				require.NotNil(t, r.Meta)
+				require.NotNil(t, r.Meta.Official)
+				assert.Equal(t, "deprecated", r.Meta.Official.Status)
+				assert.Equal(t, updated.Add(time.Hour).Format(time.RFC3339), r.Meta.Official.StatusChangedAt)
+				assert.True(t, r.Meta.Official.IsLatest)
+				require.NotNil(t, r.Meta.TagMarkers)
+				assert.Equal(t, "use 2.x", r.Meta.TagMarkers.Deprecated.Message)
+				assert.Nil(t, r.Meta.TagMarkers.Yanked)
+			},
+		},
+		{
+			name: "yanked latest tag is deprecated and no longer latest",
+			mutate: func(s *v1alpha1.MCPServer) {
+				s.Metadata.Yanked = &v1alpha1.Yank{Reason: "leaks credentials"}
+			},
+			check: func(t *testing.T, r mcpregistry.ServerResponse) {
+				require.NotNil(t, r.Meta)
+				require.NotNil(t, r.Meta.Official)
+				assert.Equal(t, "deprecated", r.Meta.Official.Status)
+				assert.False(t, r.Meta.Official.IsLatest)
+				require.NotNil(t, r.Meta.TagMarkers)
+				assert.Equal(t, "leaks credentials", r.Meta.TagMarkers.Yanked.Reason)
 			},
 		},
 	}
@@ -14,10 +14,14 @@
 // The types here mirror the v0.1 frozen spec exactly — field names use the
 // camelCase casing emitted by registry.modelcontextprotocol.io, list items are
 // wrapped in {server, _meta}, and the registry-managed metadata lives under the
-// reverse-DNS `_meta` key. The translation is one-directional (v1alpha1 →
-// server.json) and read-only; there is no publish/write path here.
+// reverse-DNS `_meta` key. FromMCPServer projects v1alpha1 → server.json for
+// the read-only v0.1 API; ToMCPServer and Client run the other way, so the
+// registry can mirror an upstream catalogue into MCPServer objects. There is
+// no publish/write path to an upstream here.
 package mcpregistry
 
+import "github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+
 // SchemaURL is the `$schema` value emitted on every ServerDetail. It pins the
 // frozen v0.1 schema revision this package targets.
 const SchemaURL = "https://static.modelcontextprotocol.io/schemas/2025-12-11/server.schema.json"
@@ -26,6 +30,12 @@ const SchemaURL = "https://static.modelcontextprotocol.io/schemas/2025-12-11/ser
 // registry exposes its server-managed metadata (status, timestamps, isLatest).
 const OfficialMetaKey = "io.modelcontextprotocol.registry/official"
 
+// TagMarkersMetaKey is the `_meta` extension key under which the registry
+// exposes the deprecation and yank markers of the tag a server was read
+// from. Clients that only know the official block still see a marked tag as
+// status "deprecated".
+const TagMarkersMetaKey = "dev.agentregistry/tag-markers"
+
 // ServerListResponse is the envelope returned by `GET /v0.1/servers` and the
 // versions-list endpoint: a page of servers plus pagination metadata.
 type ServerListResponse struct {
@@ -53,10 +63,18 @@ type ServerResponse struct {
 	Meta   *ResponseMeta `json:"_meta,omitempty"`
 }
 
-// ResponseMeta is the `_meta` extension block. Only the official
-// registry-managed sub-object is populated by this read-only shim.
+// ResponseMeta is the `_meta` extension block: the official
+// registry-managed sub-object, plus the tag markers when the tag is
+// deprecated or yanked.
 type ResponseMeta struct {
-	Official *OfficialMeta `json:"io.modelcontextprotocol.registry/official,omitempty"`
+	Official   *OfficialMeta   `json:"io.modelcontextprotocol.registry/official,omitempty"`
+	TagMarkers *TagMarkersMeta `json:"dev.agentregistry/tag-markers,omitempty"`
+}
+
+// TagMarkersMeta carries the markers of a deprecated or yanked tag.
+type TagMarkersMeta struct {
+	Deprecated *v1alpha1.Deprecation `json:"deprecated,omitempty"`
+	Yanked     *v1alpha1.Yank        `json:"yanked,omitempty"`
 }
 
 // OfficialMeta is the registry-managed metadata clients read to learn a
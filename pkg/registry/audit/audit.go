@@ -0,0 +1,121 @@
+// Package audit persists the registry's audit log. The v1alpha1 stores
+// append the entry of each change in the change's own transaction, stamped
+// by Identify with the calling principal and request ID; Recorder appends
+// the events made outside a store, such as authorization denials.
+package audit
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+// Principals recorded for callers without an authenticated subject.
+const (
+	// PrincipalSystem marks writes made by the server itself, such as
+	// controllers, outside any HTTP request.
+	PrincipalSystem = "system"
+	// PrincipalPublic marks requests on public paths.
+	PrincipalPublic = "public"
+	// PrincipalAnonymous marks requests without credentials, as when no
+	// authn provider is configured.
+	PrincipalAnonymous = "anonymous"
+)
+
+// RequestIDHeader carries the request ID on HTTP requests and responses.
+const RequestIDHeader = "X-Request-Id"
+
+type requestIDKey struct{}
+
+// WithRequestID returns ctx carrying the request ID recorded on audit
+// entries.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFrom returns the request ID carried by ctx, or "".
+func RequestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Principal names the caller carried by ctx: the session subject when
+// authenticated, otherwise one of the Principal constants.
+func Principal(ctx context.Context) string {
+	session, ok := auth.AuthSessionFrom(ctx)
+	switch {
+	case !ok:
+		// Requests always carry an ID; a bare context is the server's own
+		// background work.
+		if RequestIDFrom(ctx) == "" {
+			return PrincipalSystem
+		}
+		return PrincipalAnonymous
+	case auth.IsSystemSession(session):
+		return PrincipalSystem
+	case auth.IsPublicSession(session):
+		return PrincipalPublic
+	}
+	if subject := session.Principal().User.Subject; subject != "" {
+		return subject
+	}
+	return PrincipalAnonymous
+}
+
+// Identify names the caller carried by ctx for an audit entry. It is the
+// v1alpha1store.AuditIdentity the server hands the stores, which write the
+// entry of each change in the change's own transaction.
+func Identify(ctx context.Context) (principal, requestID string) {
+	return Principal(ctx), RequestIDFrom(ctx)
+}
+
+// Recorder is a types.AuditRecorder that appends events made outside a
+// store transaction, such as authorization denials, to the audit log.
+type Recorder struct {
+	store *v1alpha1store.AuditStore
+	next  types.Auditor
+}
+
+// NewRecorder returns a Recorder writing to store. Every event is also
+// forwarded to next when it implements types.AuditRecorder, so a
+// downstream audit sink keeps receiving it; nil means types.NoopAuditor.
+func NewRecorder(store *v1alpha1store.AuditStore, next types.Auditor) *Recorder {
+	if next == nil {
+		next = types.NoopAuditor
+	}
+	return &Recorder{store: store, next: next}
+}
+
+// Record appends event to the audit log. The event changed nothing, so a
+// failed insert is logged rather than returned, and the insert outlives a
+// cancelled request.
+func (r *Recorder) Record(ctx context.Context, event types.AuditEvent) {
+	principal, requestID := Identify(ctx)
+	entry := v1alpha1store.AuditEntry{
+		RequestID: requestID,
+		Principal: principal,
+		Verb:      event.Verb,
+		Kind:      event.Kind,
+		Namespace: event.Namespace,
+		Name:      event.Name,
+		Tag:       event.Tag,
+		Reason:    event.Reason,
+		Diff:      event.Diff,
+	}
+	if err := r.store.Insert(context.WithoutCancel(ctx), entry); err != nil {
+		slog.ErrorContext(ctx, "failed to record audit event",
+			"verb", event.Verb, "kind", event.Kind, "namespace", event.Namespace,
+			"name", event.Name, "tag", event.Tag, "principal", entry.Principal, "error", err)
+	}
+	if next, ok := r.next.(types.AuditRecorder); ok {
+		next.Record(ctx, event)
+	}
+}
+
+var (
+	_ types.AuditRecorder         = (*Recorder)(nil)
+	_ v1alpha1store.AuditIdentity = Identify
+)
@@ -0,0 +1,73 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+	"github.com/agentregistry-dev/agentregistry/pkg/types/typestest"
+)
+
+type subjectSession struct{ subject string }
+
+func (s subjectSession) Principal() auth.Principal {
+	return auth.Principal{User: auth.User{Subject: s.subject}}
+}
+
+func TestPrincipal(t *testing.T) {
+	request := WithRequestID(context.Background(), "req-1")
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"authenticated subject", auth.AuthSessionTo(request, subjectSession{"alice@example.com"}), "alice@example.com"},
+		{"session without subject", auth.AuthSessionTo(request, subjectSession{}), PrincipalAnonymous},
+		{"public path", auth.WithPublicContext(request), PrincipalPublic},
+		{"system session", auth.WithSystemContext(context.Background()), PrincipalSystem},
+		{"request without authn", request, PrincipalAnonymous},
+		{"background work", context.Background(), PrincipalSystem},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Principal(tt.ctx))
+		})
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	assert.Empty(t, RequestIDFrom(context.Background()))
+	assert.Equal(t, "req-1", RequestIDFrom(WithRequestID(context.Background(), "req-1")))
+}
+
+func TestIdentify(t *testing.T) {
+	ctx := auth.AuthSessionTo(WithRequestID(context.Background(), "req-1"), subjectSession{"alice@example.com"})
+	principal, requestID := Identify(ctx)
+	assert.Equal(t, "alice@example.com", principal)
+	assert.Equal(t, "req-1", requestID)
+}
+
+func TestRecorderForwardsEvents(t *testing.T) {
+	next := &typestest.RecordingAuditor{}
+	// A store without a pool fails every insert; the failure is logged and
+	// the event still reaches the next auditor.
+	r := NewRecorder(v1alpha1store.NewAuditStore(nil, pkgdb.MustNewSchema(pkgdb.OSSSchema)), next)
+
+	event := types.AuditEvent{Verb: types.AuditVerbDeny, Kind: "Agent", Namespace: "default", Name: "summarizer", Tag: "latest", Reason: "get: forbidden"}
+	r.Record(context.Background(), event)
+
+	require.Equal(t, []types.AuditEvent{event}, next.Records())
+}
+
+func TestRecorderSkipsNextWithoutRecord(t *testing.T) {
+	r := NewRecorder(v1alpha1store.NewAuditStore(nil, pkgdb.MustNewSchema(pkgdb.OSSSchema)), types.NoopAuditor)
+	require.NotPanics(t, func() {
+		r.Record(context.Background(), types.AuditEvent{Verb: types.AuditVerbDeny})
+	})
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"net/url"
@@ -19,6 +20,11 @@ const (
 	PermissionArtifactTypeServer  PermissionArtifactType = "server"
 	PermissionArtifactTypePrompt  PermissionArtifactType = "prompt"
 	PermissionArtifactTypeRuntime PermissionArtifactType = "runtime"
+
+	PermissionArtifactTypeDeployment PermissionArtifactType = "deployment"
+	PermissionArtifactTypePlugin     PermissionArtifactType = "plugin"
+	PermissionArtifactTypeModel      PermissionArtifactType = "model"
+	PermissionArtifactTypeNamespace  PermissionArtifactType = "namespace"
 )
 
 // PermissionAction represents an action that can be performed on a resource.
@@ -30,6 +36,9 @@ const (
 	PermissionActionEdit    PermissionAction = "edit"
 	PermissionActionDelete  PermissionAction = "delete"
 	PermissionActionDeploy  PermissionAction = "deploy"
+	// PermissionActionApprove designates reviewers: it allows approving or
+	// rejecting content held by a namespace's approval policy.
+	PermissionActionApprove PermissionAction = "approve"
 )
 
 type Permission struct {
@@ -37,12 +46,59 @@ type Permission struct {
 	ResourcePattern string           `json:"resource"`
 }
 
+// ParsePermission parses the "<action>" or "<action>:<resource>" form used by
+// token scopes and configured grants. A bare action applies to every
+// resource; "*" as the action grants every action.
+func ParsePermission(s string) (Permission, error) {
+	action, pattern, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok || pattern == "" {
+		pattern = "*"
+	}
+	if !validPermissionAction(PermissionAction(action)) {
+		return Permission{}, fmt.Errorf("unknown permission action %q", action)
+	}
+	return Permission{Action: PermissionAction(action), ResourcePattern: pattern}, nil
+}
+
+func validPermissionAction(a PermissionAction) bool {
+	switch a {
+	case PermissionActionRead, PermissionActionPublish, PermissionActionEdit,
+		PermissionActionDelete, PermissionActionDeploy, PermissionActionApprove, "*":
+		return true
+	}
+	return false
+}
+
+// ParsePermissions parses a whitespace-separated list of ParsePermission
+// entries.
+func ParsePermissions(s string) ([]Permission, error) {
+	var out []Permission
+	for _, field := range strings.Fields(s) {
+		p, err := ParsePermission(field)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
 type Resource struct {
 	Name string
 	Type PermissionArtifactType
+	// Namespace scopes Name. Empty means DefaultNamespace.
+	Namespace string
 }
 
+// DefaultNamespace is the namespace a Resource without one belongs to.
+const DefaultNamespace = "default"
+
 type User struct {
+	// Subject is the authenticated identity (the JWT "sub" claim). Empty for
+	// public and system sessions.
+	Subject string
+	// Groups are the group memberships asserted by the identity provider.
+	Groups      []string
 	Permissions []Permission
 }
 
@@ -0,0 +1,308 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// jwksRefreshInterval bounds how long a fetched key set is trusted
+	// before it is refetched on the next request.
+	jwksRefreshInterval = 10 * time.Minute
+	// jwksMinRefreshInterval rate-limits refetches triggered by unknown key
+	// ids, so a flood of forged tokens cannot hammer the issuer.
+	jwksMinRefreshInterval = 30 * time.Second
+	// maxJWKSDocumentBytes caps discovery and JWKS response bodies.
+	maxJWKSDocumentBytes = 1 << 20
+)
+
+// jwksCache holds an issuer's remote key set. The JWKS URL is resolved
+// through OpenID discovery on first use when it was not configured.
+type jwksCache struct {
+	client *http.Client
+	issuer string
+
+	mu      sync.Mutex
+	url     string
+	keys    map[string]crypto.PublicKey
+	fetched time.Time
+}
+
+// lookup returns the key for kid, or every key when kid is empty.
+func (c *jwksCache) lookup(ctx context.Context, kid string) ([]jwt.VerificationKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stale := time.Since(c.fetched) > jwksRefreshInterval
+	_, known := c.keys[kid]
+	if stale || (kid != "" && !known && time.Since(c.fetched) > jwksMinRefreshInterval) {
+		if err := c.refreshLocked(ctx); err != nil && c.keys == nil {
+			return nil, err
+		}
+	}
+	if kid != "" {
+		if k, ok := c.keys[kid]; ok {
+			return []jwt.VerificationKey{k}, nil
+		}
+		return nil, fmt.Errorf("no verification key for kid %q", kid)
+	}
+	out := make([]jwt.VerificationKey, 0, len(c.keys))
+	for _, k := range c.keys {
+		out = append(out, k)
+	}
+	return out, nil
+}
+
+// refreshLocked refetches the key set. A failed refresh keeps the previous
+// keys so a transient issuer outage does not reject valid tokens.
+func (c *jwksCache) refreshLocked(ctx context.Context) error {
+	c.fetched = time.Now()
+	if c.url == "" {
+		jwksURL, err := c.discover(ctx)
+		if err != nil {
+			return err
+		}
+		c.url = jwksURL
+	}
+	body, err := c.get(ctx, c.url)
+	if err != nil {
+		return fmt.Errorf("fetch JWKS: %w", err)
+	}
+	keys, err := parseJWKS(body)
+	if err != nil {
+		return err
+	}
+	c.keys = keys
+	return nil
+}
+
+func (c *jwksCache) discover(ctx context.Context) (string, error) {
+	if c.issuer == "" {
+		return "", errors.New("jwt authn: no JWKS URL and no issuer to discover it from")
+	}
+	body, err := c.get(ctx, strings.TrimSuffix(c.issuer, "/")+"/.well-known/openid-configuration")
+	if err != nil {
+		return "", fmt.Errorf("fetch OpenID configuration: %w", err)
+	}
+	var doc struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return "", fmt.Errorf("decode OpenID configuration: %w", err)
+	}
+	// OpenID Connect Discovery §4.3: the document must name the issuer it
+	// was fetched for, or a compromised redirect could substitute keys.
+	if doc.Issuer != c.issuer {
+		return "", fmt.Errorf("OpenID configuration issuer %q does not match %q", doc.Issuer, c.issuer)
+	}
+	if doc.JWKSURI == "" {
+		return "", errors.New("OpenID configuration has no jwks_uri")
+	}
+	return doc.JWKSURI, nil
+}
+
+func (c *jwksCache) get(ctx context.Context, u string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", u, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxJWKSDocumentBytes))
+}
+
+// ParseStaticKeys loads JWTConfig.StaticKeys from either a JWKS document or
+// PEM data (PUBLIC KEY, RSA PUBLIC KEY or CERTIFICATE blocks). PEM keys carry
+// no key id.
+func ParseStaticKeys(data []byte) ([]StaticKey, error) {
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "{") {
+		keys, err := parseJWKS(data)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]StaticKey, 0, len(keys))
+		for kid, k := range keys {
+			out = append(out, StaticKey{KeyID: kid, Key: k})
+		}
+		return out, nil
+	}
+	var out []StaticKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		var (
+			key any
+			err error
+		)
+		switch block.Type {
+		case "PUBLIC KEY":
+			key, err = x509.ParsePKIXPublicKey(block.Bytes)
+		case "RSA PUBLIC KEY":
+			key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		case "CERTIFICATE":
+			var cert *x509.Certificate
+			if cert, err = x509.ParseCertificate(block.Bytes); err == nil {
+				key = cert.PublicKey
+			}
+		default:
+			return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", block.Type, err)
+		}
+		out = append(out, StaticKey{Key: key})
+	}
+	if len(out) == 0 {
+		return nil, errors.New("no JWKS document or PEM public keys found")
+	}
+	return out, nil
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS decodes the signature keys of a JWKS document. Encryption keys
+// and key types this package cannot verify with are skipped.
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var doc struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode JWKS: %w", err)
+	}
+	keys := map[string]crypto.PublicKey{}
+	for _, k := range doc.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("JWKS key %q: %w", k.Kid, err)
+		}
+		if pub != nil {
+			keys[k.Kid] = pub
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("JWKS contains no usable signature keys")
+	}
+	return keys, nil
+}
+
+// publicKey converts k, returning nil for unsupported key types.
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := b64Int(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("modulus: %w", err)
+		}
+		e, err := b64Int(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("exponent: %w", err)
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var (
+			curve elliptic.Curve
+			ec    ecdh.Curve
+		)
+		switch k.Crv {
+		case "P-256":
+			curve, ec = elliptic.P256(), ecdh.P256()
+		case "P-384":
+			curve, ec = elliptic.P384(), ecdh.P384()
+		case "P-521":
+			curve, ec = elliptic.P521(), ecdh.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		x, err := b64Fixed(k.X, size)
+		if err != nil {
+			return nil, fmt.Errorf("x: %w", err)
+		}
+		y, err := b64Fixed(k.Y, size)
+		if err != nil {
+			return nil, fmt.Errorf("y: %w", err)
+		}
+		// Round-trip through crypto/ecdh to reject points off the curve.
+		if _, err := ec.NewPublicKey(append(append([]byte{4}, x...), y...)); err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := b64Fixed(k.X, ed25519.PublicKeySize)
+		if err != nil {
+			return nil, fmt.Errorf("x: %w", err)
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, nil
+}
+
+func b64Int(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty value")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func b64Fixed(s string, size int) ([]byte, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != size {
+		return nil, fmt.Errorf("got %d bytes, want %d", len(b), size)
+	}
+	return b, nil
+}
@@ -0,0 +1,275 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/modelcontextprotocol/go-sdk/oauthex"
+)
+
+// jwtSigningMethods are the asymmetric algorithms accepted on bearer tokens.
+// Symmetric (HS*) algorithms are deliberately excluded: a JWKS or public key
+// must never double as an HMAC secret.
+var jwtSigningMethods = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+// JWTConfig configures a JWTAuthnProvider.
+type JWTConfig struct {
+	// Issuer is the required "iss" claim. When JWKSURL is empty and no
+	// StaticKeys are set, the key set is located through the issuer's
+	// OpenID discovery document.
+	Issuer string
+	// JWKSURL points directly at the issuer's key set, skipping discovery.
+	JWKSURL string
+	// StaticKeys verify tokens without network access (offline or
+	// air-gapped installs). See ParseStaticKeys.
+	StaticKeys []StaticKey
+	// Audiences, when non-empty, requires the "aud" claim to carry at least
+	// one of them.
+	Audiences []string
+	// GroupsClaim names the claim holding group memberships. Defaults to
+	// "groups".
+	GroupsClaim string
+	// GroupPermissions grants permissions to every member of a group, on top
+	// of the permissions carried by the token's scopes.
+	GroupPermissions map[string][]Permission
+	// Leeway tolerates clock skew on exp/nbf/iat. Defaults to one minute.
+	Leeway time.Duration
+	// HTTPClient fetches discovery and JWKS documents. Defaults to a client
+	// with a ten second timeout.
+	HTTPClient *http.Client
+}
+
+// StaticKey is a locally configured verification key. A key with a KeyID
+// only verifies tokens carrying that "kid"; a key without one is tried for
+// any token whose kid matches no other key.
+type StaticKey struct {
+	KeyID string
+	Key   crypto.PublicKey
+}
+
+// JWTAuthnProvider authenticates bearer JWTs signed by a configured issuer.
+// The verified claims map onto the session Principal:
+//
+//   - "sub" becomes User.Subject.
+//   - The groups claim becomes User.Groups, and each group contributes its
+//     JWTConfig.GroupPermissions.
+//   - Each scope ("scope" as a space-separated string, or "scp") that parses
+//     with ParsePermission becomes a Permission. Other scopes (openid,
+//     profile, ...) are ignored.
+type JWTAuthnProvider struct {
+	cfg    JWTConfig
+	keys   *jwksCache
+	parser *jwt.Parser
+}
+
+var _ AuthnProvider = (*JWTAuthnProvider)(nil)
+
+// NewJWTAuthnProvider validates cfg and returns a provider. Remote key sets
+// are fetched lazily on the first request, so a temporarily unreachable
+// issuer does not block startup.
+func NewJWTAuthnProvider(cfg JWTConfig) (*JWTAuthnProvider, error) {
+	cfg.Issuer = strings.TrimSpace(cfg.Issuer)
+	cfg.JWKSURL = strings.TrimSpace(cfg.JWKSURL)
+	if cfg.Issuer == "" && cfg.JWKSURL == "" && len(cfg.StaticKeys) == 0 {
+		return nil, errors.New("jwt authn: one of issuer, JWKS URL or static keys is required")
+	}
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = "groups"
+	}
+	if cfg.Leeway == 0 {
+		cfg.Leeway = time.Minute
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	p := &JWTAuthnProvider{cfg: cfg}
+	if cfg.JWKSURL != "" || len(cfg.StaticKeys) == 0 {
+		p.keys = &jwksCache{client: cfg.HTTPClient, issuer: cfg.Issuer, url: cfg.JWKSURL}
+	}
+	p.parser = p.newParser()
+	return p, nil
+}
+
+func (p *JWTAuthnProvider) newParser() *jwt.Parser {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(jwtSigningMethods),
+		jwt.WithLeeway(p.cfg.Leeway),
+		jwt.WithExpirationRequired(),
+	}
+	if p.cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(p.cfg.Issuer))
+	}
+	if len(p.cfg.Audiences) > 0 {
+		opts = append(opts, jwt.WithAudience(p.cfg.Audiences...))
+	}
+	return jwt.NewParser(opts...)
+}
+
+// ForAudience returns a provider sharing this provider's issuer, keys and
+// claim mapping but requiring one of the given audiences. The MCP bridge
+// uses it to bind tokens to its own resource URI (RFC 8707) without a
+// second key cache.
+func (p *JWTAuthnProvider) ForAudience(audiences ...string) *JWTAuthnProvider {
+	clone := &JWTAuthnProvider{cfg: p.cfg, keys: p.keys}
+	clone.cfg.Audiences = slices.Clone(audiences)
+	clone.parser = clone.newParser()
+	return clone
+}
+
+// ProtectedResourceMetadata describes resource (the canonical URI of the
+// server being protected) as an RFC 9728 document naming this provider's
+// issuer as its authorization server. Suitable for
+// AppOptions.MCPProtectedResourceMetadata.
+func (p *JWTAuthnProvider) ProtectedResourceMetadata(resource string) *oauthex.ProtectedResourceMetadata {
+	md := &oauthex.ProtectedResourceMetadata{
+		Resource:                          resource,
+		BearerMethodsSupported:            []string{"header"},
+		ResourceSigningAlgValuesSupported: slices.Clone(jwtSigningMethods),
+	}
+	if p.cfg.Issuer != "" {
+		md.AuthorizationServers = []string{p.cfg.Issuer}
+	}
+	return md
+}
+
+// ProtectedResourceMetadataURL returns the RFC 9728 well-known location of
+// the metadata for resource: the well-known segment is inserted between the
+// host and the resource path.
+func ProtectedResourceMetadataURL(resource string) (string, error) {
+	u, err := url.Parse(resource)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("resource %q is not an absolute URL", resource)
+	}
+	u.Path = "/.well-known/oauth-protected-resource" + strings.TrimSuffix(u.Path, "/")
+	u.RawPath = ""
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String(), nil
+}
+
+// Authenticate verifies the "Authorization: Bearer <jwt>" header. Every
+// failure wraps ErrUnauthenticated.
+func (p *JWTAuthnProvider) Authenticate(ctx context.Context, reqHeaders func(name string) string, _ url.Values) (Session, error) {
+	raw, ok := bearerToken(reqHeaders("Authorization"))
+	if !ok {
+		return nil, fmt.Errorf("%w: missing bearer token", ErrUnauthenticated)
+	}
+	claims := jwt.MapClaims{}
+	if _, err := p.parser.ParseWithClaims(raw, claims, func(t *jwt.Token) (any, error) {
+		return p.verificationKey(ctx, t)
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+	return &JWTSession{principal: p.principal(claims), Claims: claims}, nil
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// verificationKey picks the key for t: the static or JWKS key matching its
+// kid, else every kid-less static key. An unknown kid forces a JWKS refresh
+// so issuer key rotation is picked up without a restart.
+func (p *JWTAuthnProvider) verificationKey(ctx context.Context, t *jwt.Token) (any, error) {
+	kid, _ := t.Header["kid"].(string)
+	var set jwt.VerificationKeySet
+	for _, k := range p.cfg.StaticKeys {
+		switch k.KeyID {
+		case "":
+			set.Keys = append(set.Keys, k.Key)
+		case kid:
+			return k.Key, nil
+		}
+	}
+	if p.keys != nil {
+		keys, err := p.keys.lookup(ctx, kid)
+		if err != nil && len(set.Keys) == 0 {
+			return nil, err
+		}
+		set.Keys = append(set.Keys, keys...)
+	}
+	if len(set.Keys) == 0 {
+		return nil, fmt.Errorf("no verification key for kid %q", kid)
+	}
+	return set, nil
+}
+
+func (p *JWTAuthnProvider) principal(claims jwt.MapClaims) Principal {
+	user := User{}
+	user.Subject, _ = claims["sub"].(string)
+	user.Groups = stringListClaim(claims[p.cfg.GroupsClaim])
+
+	seen := map[Permission]bool{}
+	add := func(perm Permission) {
+		if !seen[perm] {
+			seen[perm] = true
+			user.Permissions = append(user.Permissions, perm)
+		}
+	}
+	scopes := stringListClaim(claims["scope"])
+	scopes = append(scopes, stringListClaim(claims["scp"])...)
+	for _, scope := range scopes {
+		if perm, err := ParsePermission(scope); err == nil {
+			add(perm)
+		}
+	}
+	for _, group := range user.Groups {
+		for _, perm := range p.cfg.GroupPermissions[group] {
+			add(perm)
+		}
+	}
+	return Principal{User: user}
+}
+
+// stringListClaim reads a claim that identity providers encode either as a
+// JSON array of strings or as one space-separated string.
+func stringListClaim(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				out = append(out, s)
+			}
+		}
+		return out
+	case []string:
+		return slices.Clone(v)
+	}
+	return nil
+}
+
+// JWTSession is the session produced by JWTAuthnProvider.
+type JWTSession struct {
+	principal Principal
+	// Claims holds every verified token claim, for downstream hooks that
+	// need more than the mapped Principal.
+	Claims map[string]any
+}
+
+func (s *JWTSession) Principal() Principal {
+	return s.principal
+}
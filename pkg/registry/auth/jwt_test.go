@@ -0,0 +1,257 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+)
+
+// testIssuer serves an OpenID discovery document and a JWKS holding one RSA
+// signing key.
+type testIssuer struct {
+	URL      string
+	key      *rsa.PrivateKey
+	kid      string
+	jwksHits int
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	iss := &testIssuer{key: key, kid: "key-1"}
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	iss.URL = srv.URL
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": iss.URL, "jwks_uri": iss.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		iss.jwksHits++
+		b64 := base64.RawURLEncoding.EncodeToString
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA", "kid": iss.kid, "use": "sig", "alg": "RS256",
+			"n": b64(key.N.Bytes()),
+			"e": b64(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	return iss
+}
+
+func (i *testIssuer) token(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	base := jwt.MapClaims{"iss": i.URL, "exp": time.Now().Add(time.Hour).Unix()}
+	for k, v := range claims {
+		base[k] = v
+	}
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, base)
+	tok.Header["kid"] = i.kid
+	signed, err := tok.SignedString(i.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func bearer(token string) func(string) string {
+	return func(name string) string {
+		if name == "Authorization" {
+			return "Bearer " + token
+		}
+		return ""
+	}
+}
+
+func TestJWTAuthnProvider_MapsClaimsToPrincipal(t *testing.T) {
+	iss := newTestIssuer(t)
+	provider, err := auth.NewJWTAuthnProvider(auth.JWTConfig{
+		Issuer: iss.URL,
+		GroupPermissions: map[string][]auth.Permission{
+			"platform": {{Action: auth.PermissionActionDeploy, ResourcePattern: "*"}},
+		},
+	})
+	require.NoError(t, err)
+
+	token := iss.token(t, jwt.MapClaims{
+		"sub":    "alice",
+		"groups": []string{"platform", "eng"},
+		"scope":  "openid read publish:team-a/*",
+	})
+	session, err := provider.Authenticate(context.Background(), bearer(token), nil)
+	require.NoError(t, err)
+
+	user := session.Principal().User
+	assert.Equal(t, "alice", user.Subject)
+	assert.Equal(t, []string{"platform", "eng"}, user.Groups)
+	assert.ElementsMatch(t, []auth.Permission{
+		{Action: auth.PermissionActionRead, ResourcePattern: "*"},
+		{Action: auth.PermissionActionPublish, ResourcePattern: "team-a/*"},
+		{Action: auth.PermissionActionDeploy, ResourcePattern: "*"},
+	}, user.Permissions)
+	jwtSession, ok := session.(*auth.JWTSession)
+	require.True(t, ok)
+	assert.Equal(t, "alice", jwtSession.Claims["sub"])
+
+	// The key set is cached across requests.
+	_, err = provider.Authenticate(context.Background(), bearer(token), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, iss.jwksHits)
+}
+
+func TestJWTAuthnProvider_RejectsInvalidTokens(t *testing.T) {
+	iss := newTestIssuer(t)
+	provider, err := auth.NewJWTAuthnProvider(auth.JWTConfig{
+		Issuer:    iss.URL,
+		JWKSURL:   iss.URL + "/keys",
+		Audiences: []string{"agentregistry"},
+	})
+	require.NoError(t, err)
+
+	hmac, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": iss.URL, "aud": "agentregistry", "exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		headers func(string) string
+	}{
+		{"no header", func(string) string { return "" }},
+		{"not a bearer", func(string) string { return "Basic dXNlcjpwYXNz" }},
+		{"garbage", bearer("not-a-jwt")},
+		{"expired", bearer(iss.token(t, jwt.MapClaims{"aud": "agentregistry", "exp": time.Now().Add(-time.Hour).Unix()}))},
+		{"no expiry", bearer(iss.token(t, jwt.MapClaims{"aud": "agentregistry", "exp": nil}))},
+		{"wrong issuer", bearer(iss.token(t, jwt.MapClaims{"aud": "agentregistry", "iss": "https://evil.example"}))},
+		{"wrong audience", bearer(iss.token(t, jwt.MapClaims{"aud": "someone-else"}))},
+		{"symmetric algorithm", bearer(hmac)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := provider.Authenticate(context.Background(), tt.headers, nil)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, auth.ErrUnauthenticated), "got %v", err)
+		})
+	}
+
+	_, err = provider.Authenticate(context.Background(), bearer(iss.token(t, jwt.MapClaims{"aud": "agentregistry"})), nil)
+	require.NoError(t, err)
+}
+
+func TestJWTAuthnProvider_ForAudienceSharesKeys(t *testing.T) {
+	iss := newTestIssuer(t)
+	provider, err := auth.NewJWTAuthnProvider(auth.JWTConfig{Issuer: iss.URL})
+	require.NoError(t, err)
+	mcp := provider.ForAudience("https://mcp.example/mcp")
+
+	unbound := iss.token(t, jwt.MapClaims{"sub": "bob"})
+	_, err = provider.Authenticate(context.Background(), bearer(unbound), nil)
+	require.NoError(t, err)
+	_, err = mcp.Authenticate(context.Background(), bearer(unbound), nil)
+	require.ErrorIs(t, err, auth.ErrUnauthenticated)
+
+	bound := iss.token(t, jwt.MapClaims{"sub": "bob", "aud": []string{"https://mcp.example/mcp"}})
+	_, err = mcp.Authenticate(context.Background(), bearer(bound), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, iss.jwksHits, "audience-bound provider must reuse the key cache")
+
+	md := provider.ProtectedResourceMetadata("https://mcp.example/mcp")
+	assert.Equal(t, "https://mcp.example/mcp", md.Resource)
+	assert.Equal(t, []string{iss.URL}, md.AuthorizationServers)
+}
+
+func TestJWTAuthnProvider_StaticKeysOffline(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	keys, err := auth.ParseStaticKeys(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	require.NoError(t, err)
+
+	provider, err := auth.NewJWTAuthnProvider(auth.JWTConfig{
+		Issuer:     "https://issuer.invalid",
+		StaticKeys: keys,
+	})
+	require.NoError(t, err)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{
+		"iss": "https://issuer.invalid", "sub": "ci-bot", "exp": time.Now().Add(time.Hour).Unix(),
+		"scp": []string{"deploy:prod/*"},
+	}).SignedString(priv)
+	require.NoError(t, err)
+
+	session, err := provider.Authenticate(context.Background(), bearer(token), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ci-bot", session.Principal().User.Subject)
+	assert.Equal(t, []auth.Permission{{Action: auth.PermissionActionDeploy, ResourcePattern: "prod/*"}}, session.Principal().User.Permissions)
+}
+
+func TestNewJWTAuthnProvider_RequiresKeySource(t *testing.T) {
+	_, err := auth.NewJWTAuthnProvider(auth.JWTConfig{})
+	require.Error(t, err)
+}
+
+func TestParseStaticKeys_JWKS(t *testing.T) {
+	keys, err := auth.ParseStaticKeys([]byte(`{"keys":[
+		{"kty":"OKP","crv":"Ed25519","kid":"a","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"},
+		{"kty":"RSA","use":"enc","kid":"b","n":"AQAB","e":"AQAB"},
+		{"kty":"oct","kid":"c","k":"c2VjcmV0"}
+	]}`))
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, "a", keys[0].KeyID)
+
+	_, err = auth.ParseStaticKeys([]byte("not a key"))
+	require.Error(t, err)
+}
+
+func TestParsePermission(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    auth.Permission
+		wantErr bool
+	}{
+		{in: "read", want: auth.Permission{Action: auth.PermissionActionRead, ResourcePattern: "*"}},
+		{in: "publish:team-a/*", want: auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "team-a/*"}},
+		{in: "*:*", want: auth.Permission{Action: "*", ResourcePattern: "*"}},
+		{in: "openid", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := auth.ParsePermission(tt.in)
+		if tt.wantErr {
+			assert.Error(t, err, tt.in)
+			continue
+		}
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got)
+	}
+}
+
+func TestProtectedResourceMetadataURL(t *testing.T) {
+	got, err := auth.ProtectedResourceMetadataURL("https://registry.example.com/mcp/")
+	require.NoError(t, err)
+	assert.Equal(t, "https://registry.example.com/.well-known/oauth-protected-resource/mcp", got)
+
+	got, err = auth.ProtectedResourceMetadataURL("https://registry.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "https://registry.example.com/.well-known/oauth-protected-resource", got)
+
+	_, err = auth.ProtectedResourceMetadataURL("/relative")
+	require.Error(t, err)
+}
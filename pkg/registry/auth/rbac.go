@@ -0,0 +1,441 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"sigs.k8s.io/yaml"
+)
+
+// Built-in RBAC subject groups. Every caller belongs to exactly one of them,
+// so a policy can grant anonymous read access or a baseline to every
+// signed-in user without enumerating identities.
+const (
+	GroupAuthenticated   = "system:authenticated"
+	GroupUnauthenticated = "system:unauthenticated"
+)
+
+// rbacReloadDebounce coalesces the burst of events an editor save or a
+// ConfigMap symlink swap produces into one reload.
+const rbacReloadDebounce = 200 * time.Millisecond
+
+// RBACPolicy is the declarative document loaded by RBACAuthzProvider:
+//
+//	roles:
+//	  - name: team-a-publisher
+//	    rules:
+//	      - actions: [read, publish, edit]
+//	        resources: ["agent:team-a/*", "skill:team-a/*"]
+//	roleBindings:
+//	  - role: team-a-publisher
+//	    subjects:
+//	      - user: alice
+//	      - group: team-a
+//
+// Resource globs take the form "[<type>:]<namespace>/<name>". The type is a
+// PermissionArtifactType ("agent", "server", "deployment", ...) and may be
+// omitted or "*" to cover every type. A pattern without a "/" matches names
+// in every namespace, and "*" alone matches everything. Within a segment "*"
+// matches any run of characters, including "/" inside names.
+type RBACPolicy struct {
+	Roles        []RBACRole        `json:"roles"`
+	RoleBindings []RBACRoleBinding `json:"roleBindings"`
+}
+
+// RBACRole is a named set of rules.
+type RBACRole struct {
+	Name  string     `json:"name"`
+	Rules []RBACRule `json:"rules"`
+}
+
+// RBACRule grants every listed action on every listed resource glob. The
+// action "*" grants every action.
+type RBACRule struct {
+	Actions   []PermissionAction `json:"actions"`
+	Resources []string           `json:"resources"`
+}
+
+// RBACRoleBinding grants Role to each subject.
+type RBACRoleBinding struct {
+	Name     string        `json:"name,omitempty"`
+	Role     string        `json:"role"`
+	Subjects []RBACSubject `json:"subjects"`
+}
+
+// RBACSubject names exactly one user (matched against User.Subject) or group
+// (matched against User.Groups and the built-in system groups).
+type RBACSubject struct {
+	User  string `json:"user,omitempty"`
+	Group string `json:"group,omitempty"`
+}
+
+// ParseRBACPolicy decodes and validates a YAML or JSON policy document.
+func ParseRBACPolicy(data []byte) (*RBACPolicy, error) {
+	var policy RBACPolicy
+	if err := yaml.UnmarshalStrict(data, &policy); err != nil {
+		return nil, fmt.Errorf("decode rbac policy: %w", err)
+	}
+	if _, err := compileRBACPolicy(&policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// resourceGlob is a parsed "[<type>:]<namespace>/<name>" pattern.
+type resourceGlob struct {
+	typ       string
+	namespace string
+	name      string
+}
+
+func parseResourceGlob(pattern string) (resourceGlob, error) {
+	s := strings.TrimSpace(pattern)
+	if s == "" {
+		return resourceGlob{}, errors.New("empty resource pattern")
+	}
+	g := resourceGlob{typ: "*", namespace: "*", name: "*"}
+	// A type prefix is a ":" ahead of the first "/"; names may contain ":".
+	if i := strings.Index(s, ":"); i >= 0 && !strings.Contains(s[:i], "/") {
+		g.typ, s = s[:i], s[i+1:]
+		if g.typ == "" || s == "" {
+			return resourceGlob{}, fmt.Errorf("malformed resource pattern %q", pattern)
+		}
+	}
+	if ns, name, ok := strings.Cut(s, "/"); ok {
+		if ns == "" || name == "" {
+			return resourceGlob{}, fmt.Errorf("malformed resource pattern %q", pattern)
+		}
+		g.namespace, g.name = ns, name
+	} else {
+		g.name = s
+	}
+	return g, nil
+}
+
+func (g resourceGlob) matches(r Resource) bool {
+	ns := r.Namespace
+	if ns == "" {
+		ns = DefaultNamespace
+	}
+	return globMatch(g.typ, string(r.Type)) && globMatch(g.namespace, ns) && globMatch(g.name, r.Name)
+}
+
+func (g resourceGlob) all() bool {
+	return g.typ == "*" && g.namespace == "*" && g.name == "*"
+}
+
+// globMatch reports whether s matches pattern, where "*" matches any run of
+// characters and everything else is literal.
+func globMatch(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, last)
+}
+
+// globToLike converts a glob to a LIKE pattern, escaping LIKE's own
+// metacharacters with the default backslash escape.
+func globToLike(pattern string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`, `*`, `%`)
+	return r.Replace(pattern)
+}
+
+// rbacGrant is one (action, resource glob) pair a subject holds.
+type rbacGrant struct {
+	action PermissionAction
+	glob   resourceGlob
+}
+
+func (g rbacGrant) allows(action PermissionAction) bool {
+	return g.action == "*" || g.action == action
+}
+
+// compiledRBACPolicy indexes grants by subject for lookup on every request.
+type compiledRBACPolicy struct {
+	users  map[string][]rbacGrant
+	groups map[string][]rbacGrant
+}
+
+func compileRBACPolicy(policy *RBACPolicy) (*compiledRBACPolicy, error) {
+	roles := make(map[string][]rbacGrant, len(policy.Roles))
+	for _, role := range policy.Roles {
+		if role.Name == "" {
+			return nil, errors.New("rbac policy: role without a name")
+		}
+		if _, dup := roles[role.Name]; dup {
+			return nil, fmt.Errorf("rbac policy: duplicate role %q", role.Name)
+		}
+		var grants []rbacGrant
+		for _, rule := range role.Rules {
+			if len(rule.Actions) == 0 || len(rule.Resources) == 0 {
+				return nil, fmt.Errorf("rbac policy: role %q has a rule without actions or resources", role.Name)
+			}
+			for _, action := range rule.Actions {
+				if !validPermissionAction(action) {
+					return nil, fmt.Errorf("rbac policy: role %q: unknown action %q", role.Name, action)
+				}
+				for _, res := range rule.Resources {
+					glob, err := parseResourceGlob(res)
+					if err != nil {
+						return nil, fmt.Errorf("rbac policy: role %q: %w", role.Name, err)
+					}
+					grants = append(grants, rbacGrant{action: action, glob: glob})
+				}
+			}
+		}
+		roles[role.Name] = grants
+	}
+
+	compiled := &compiledRBACPolicy{users: map[string][]rbacGrant{}, groups: map[string][]rbacGrant{}}
+	for i, binding := range policy.RoleBindings {
+		grants, ok := roles[binding.Role]
+		if !ok {
+			return nil, fmt.Errorf("rbac policy: role binding %d references unknown role %q", i, binding.Role)
+		}
+		for _, subject := range binding.Subjects {
+			switch {
+			case subject.User != "" && subject.Group == "":
+				compiled.users[subject.User] = append(compiled.users[subject.User], grants...)
+			case subject.Group != "" && subject.User == "":
+				compiled.groups[subject.Group] = append(compiled.groups[subject.Group], grants...)
+			default:
+				return nil, fmt.Errorf("rbac policy: role binding %d: a subject names exactly one of user or group", i)
+			}
+		}
+	}
+	return compiled, nil
+}
+
+// RBACAuthzProvider enforces an RBACPolicy. A session's grants are the union
+// of the roles bound to its subject and groups plus the Permissions its
+// authn provider attached (for example JWT scopes), whose ResourcePattern is
+// read as a resource glob. SystemSession bypasses the policy.
+//
+// The policy can be swapped at runtime with SetPolicy, or reloaded from its
+// file by Reload and Watch.
+type RBACAuthzProvider struct {
+	path   string
+	policy atomic.Pointer[compiledRBACPolicy]
+}
+
+var _ AuthzProvider = (*RBACAuthzProvider)(nil)
+
+// NewRBACAuthzProvider returns a provider enforcing policy.
+func NewRBACAuthzProvider(policy *RBACPolicy) (*RBACAuthzProvider, error) {
+	p := &RBACAuthzProvider{}
+	if err := p.SetPolicy(policy); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// LoadRBACAuthzProvider reads the policy at path. Call Watch to pick up later
+// edits.
+func LoadRBACAuthzProvider(path string) (*RBACAuthzProvider, error) {
+	p := &RBACAuthzProvider{path: path}
+	if err := p.Reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// SetPolicy validates policy and atomically replaces the enforced one.
+func (p *RBACAuthzProvider) SetPolicy(policy *RBACPolicy) error {
+	if policy == nil {
+		policy = &RBACPolicy{}
+	}
+	compiled, err := compileRBACPolicy(policy)
+	if err != nil {
+		return err
+	}
+	p.policy.Store(compiled)
+	return nil
+}
+
+// Reload re-reads the policy file. An unreadable or invalid file leaves the
+// current policy in force.
+func (p *RBACAuthzProvider) Reload() error {
+	if p.path == "" {
+		return errors.New("rbac policy: provider has no policy file")
+	}
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return fmt.Errorf("read rbac policy: %w", err)
+	}
+	policy, err := ParseRBACPolicy(data)
+	if err != nil {
+		return err
+	}
+	return p.SetPolicy(policy)
+}
+
+// Watch reloads the policy whenever its file changes, until ctx is done. The
+// parent directory is watched rather than the file so atomic renames and
+// Kubernetes ConfigMap symlink swaps are seen. A failed reload is logged and
+// the previous policy stays in force.
+func (p *RBACAuthzProvider) Watch(ctx context.Context) error {
+	if p.path == "" {
+		return errors.New("rbac policy: provider has no policy file")
+	}
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = w.Close() }()
+	if err := w.Add(filepath.Dir(p.path)); err != nil {
+		return fmt.Errorf("watch rbac policy: %w", err)
+	}
+
+	debounce := time.NewTimer(time.Hour)
+	debounce.Stop()
+	for {
+		select {
+		case e, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+			if e.Has(fsnotify.Chmod) {
+				continue
+			}
+			debounce.Reset(rbacReloadDebounce)
+		case <-debounce.C:
+			if err := p.Reload(); err != nil {
+				slog.Error("rbac policy reload failed; keeping previous policy", "path", p.path, "error", err)
+				continue
+			}
+			slog.Info("rbac policy reloaded", "path", p.path)
+		case err, ok := <-w.Errors:
+			if !ok {
+				return nil
+			}
+			slog.Warn("rbac policy watcher error", "path", p.path, "error", err)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// grants collects every grant held by s.
+func (p *RBACAuthzProvider) grants(s Session) []rbacGrant {
+	policy := p.policy.Load()
+	if s == nil || IsPublicSession(s) {
+		return policy.groups[GroupUnauthenticated]
+	}
+	user := s.Principal().User
+	grants := slices.Clone(policy.groups[GroupAuthenticated])
+	if user.Subject != "" {
+		grants = append(grants, policy.users[user.Subject]...)
+	}
+	for _, group := range user.Groups {
+		grants = append(grants, policy.groups[group]...)
+	}
+	for _, perm := range user.Permissions {
+		if glob, err := parseResourceGlob(perm.ResourcePattern); err == nil {
+			grants = append(grants, rbacGrant{action: perm.Action, glob: glob})
+		}
+	}
+	return grants
+}
+
+// Check allows the action when any grant covers it. Denials wrap
+// ErrUnauthenticated for anonymous callers and ErrForbidden otherwise.
+func (p *RBACAuthzProvider) Check(_ context.Context, s Session, action PermissionAction, resource Resource) error {
+	if IsSystemSession(s) {
+		return nil
+	}
+	for _, g := range p.grants(s) {
+		if g.allows(action) && g.glob.matches(resource) {
+			return nil
+		}
+	}
+	ns := resource.Namespace
+	if ns == "" {
+		ns = DefaultNamespace
+	}
+	denied := ErrForbidden
+	if s == nil || IsPublicSession(s) {
+		denied = ErrUnauthenticated
+	}
+	return fmt.Errorf("%w: %s on %s:%s/%s", denied, action, resource.Type, ns, resource.Name)
+}
+
+// IsRegistryAdmin reports whether s holds every action on every resource.
+func (p *RBACAuthzProvider) IsRegistryAdmin(_ context.Context, s Session) bool {
+	if IsSystemSession(s) {
+		return true
+	}
+	for _, g := range p.grants(s) {
+		if g.action == "*" && g.glob.all() {
+			return true
+		}
+	}
+	return false
+}
+
+// ListPredicate returns a v1alpha1store ListOpts.ExtraWhere fragment and its
+// bind args restricting a list of typ rows to those s may perform action
+// on. namespace, when set, drops grants that cannot match it. An empty
+// fragment means no restriction; a session without any matching grant gets
+// "FALSE". Patterns are passed as LIKE bind args, never interpolated.
+func (p *RBACAuthzProvider) ListPredicate(_ context.Context, s Session, action PermissionAction, typ PermissionArtifactType, namespace string) (string, []any) {
+	if IsSystemSession(s) {
+		return "", nil
+	}
+	var (
+		clauses []string
+		args    []any
+	)
+	column := func(col, pattern string) string {
+		if !strings.Contains(pattern, "*") {
+			args = append(args, pattern)
+			return fmt.Sprintf("%s = $%d", col, len(args))
+		}
+		args = append(args, globToLike(pattern))
+		return fmt.Sprintf("%s LIKE $%d", col, len(args))
+	}
+	for _, g := range p.grants(s) {
+		if !g.allows(action) || !globMatch(g.glob.typ, string(typ)) {
+			continue
+		}
+		if namespace != "" && !globMatch(g.glob.namespace, namespace) {
+			continue
+		}
+		if g.glob.namespace == "*" && g.glob.name == "*" {
+			return "", nil
+		}
+		var conds []string
+		if g.glob.namespace != "*" {
+			conds = append(conds, column("namespace", g.glob.namespace))
+		}
+		if g.glob.name != "*" {
+			conds = append(conds, column("name", g.glob.name))
+		}
+		clauses = append(clauses, "("+strings.Join(conds, " AND ")+")")
+	}
+	if len(clauses) == 0 {
+		return "FALSE", nil
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
@@ -0,0 +1,195 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+)
+
+const testRBACPolicy = `
+roles:
+  - name: viewer
+    rules:
+      - actions: [read]
+        resources: ["agent:*"]
+  - name: team-a-publisher
+    rules:
+      - actions: [read, publish, delete]
+        resources: ["agent:team-a/*", "server:team-a/io.example/*"]
+  - name: admin
+    rules:
+      - actions: ["*"]
+        resources: ["*"]
+roleBindings:
+  - role: viewer
+    subjects:
+      - group: system:authenticated
+  - role: team-a-publisher
+    subjects:
+      - group: team-a
+  - role: admin
+    subjects:
+      - user: root
+`
+
+type userSession struct{ user auth.User }
+
+func (s userSession) Principal() auth.Principal { return auth.Principal{User: s.user} }
+
+func newTestRBAC(t *testing.T, doc string) *auth.RBACAuthzProvider {
+	t.Helper()
+	policy, err := auth.ParseRBACPolicy([]byte(doc))
+	require.NoError(t, err)
+	provider, err := auth.NewRBACAuthzProvider(policy)
+	require.NoError(t, err)
+	return provider
+}
+
+func TestRBACAuthzProvider_Check(t *testing.T) {
+	provider := newTestRBAC(t, testRBACPolicy)
+	alice := userSession{auth.User{Subject: "alice", Groups: []string{"team-a"}}}
+	bob := userSession{auth.User{Subject: "bob"}}
+	scoped := userSession{auth.User{Subject: "ci", Permissions: []auth.Permission{
+		{Action: auth.PermissionActionDeploy, ResourcePattern: "deployment:prod/*"},
+	}}}
+
+	tests := []struct {
+		name    string
+		session auth.Session
+		action  auth.PermissionAction
+		res     auth.Resource
+		want    error
+	}{
+		{"authenticated group reads any agent", bob, auth.PermissionActionRead, auth.Resource{Type: "agent", Namespace: "team-b", Name: "x"}, nil},
+		{"no grant on other types", bob, auth.PermissionActionRead, auth.Resource{Type: "skill", Name: "x"}, auth.ErrForbidden},
+		{"team publishes in its namespace", alice, auth.PermissionActionPublish, auth.Resource{Type: "agent", Namespace: "team-a", Name: "planner"}, nil},
+		{"team cannot publish elsewhere", alice, auth.PermissionActionPublish, auth.Resource{Type: "agent", Namespace: "team-b", Name: "planner"}, auth.ErrForbidden},
+		{"glob spans slashes in names", alice, auth.PermissionActionDelete, auth.Resource{Type: "server", Namespace: "team-a", Name: "io.example/weather"}, nil},
+		{"glob prefix is literal", alice, auth.PermissionActionDelete, auth.Resource{Type: "server", Namespace: "team-a", Name: "io.other/weather"}, auth.ErrForbidden},
+		{"action not granted", alice, auth.PermissionActionEdit, auth.Resource{Type: "agent", Namespace: "team-a", Name: "planner"}, auth.ErrForbidden},
+		{"session permissions are grants", scoped, auth.PermissionActionDeploy, auth.Resource{Type: "deployment", Namespace: "prod", Name: "api"}, nil},
+		{"anonymous is unauthenticated", &auth.PublicSession{}, auth.PermissionActionRead, auth.Resource{Type: "agent", Name: "x"}, auth.ErrUnauthenticated},
+		{"no session is unauthenticated", nil, auth.PermissionActionRead, auth.Resource{Type: "agent", Name: "x"}, auth.ErrUnauthenticated},
+		{"system bypasses", &auth.SystemSession{}, auth.PermissionActionDelete, auth.Resource{Type: "runtime", Name: "x"}, nil},
+		{"admin wildcard", userSession{auth.User{Subject: "root"}}, auth.PermissionActionDeploy, auth.Resource{Type: "deployment", Name: "x"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := provider.Check(context.Background(), tt.session, tt.action, tt.res)
+			if tt.want == nil {
+				require.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, tt.want), "got %v, want %v", err, tt.want)
+		})
+	}
+
+	assert.True(t, provider.IsRegistryAdmin(context.Background(), userSession{auth.User{Subject: "root"}}))
+	assert.True(t, provider.IsRegistryAdmin(context.Background(), &auth.SystemSession{}))
+	assert.False(t, provider.IsRegistryAdmin(context.Background(), alice))
+}
+
+func TestRBACAuthzProvider_ListPredicate(t *testing.T) {
+	provider := newTestRBAC(t, testRBACPolicy)
+	ctx := context.Background()
+	alice := userSession{auth.User{Subject: "alice", Groups: []string{"team-a"}}}
+
+	where, args := provider.ListPredicate(ctx, alice, auth.PermissionActionRead, auth.PermissionArtifactTypeAgent, "")
+	assert.Empty(t, where, "viewer role covers every agent")
+	assert.Empty(t, args)
+
+	where, args = provider.ListPredicate(ctx, alice, auth.PermissionActionRead, auth.PermissionArtifactTypeServer, "")
+	assert.Equal(t, "((namespace = $1 AND name LIKE $2))", where)
+	assert.Equal(t, []any{"team-a", "io.example/%"}, args)
+
+	where, args = provider.ListPredicate(ctx, alice, auth.PermissionActionRead, auth.PermissionArtifactTypeServer, "team-b")
+	assert.Equal(t, "FALSE", where, "grants outside the listed namespace are dropped")
+	assert.Empty(t, args)
+
+	where, _ = provider.ListPredicate(ctx, &auth.PublicSession{}, auth.PermissionActionRead, auth.PermissionArtifactTypeAgent, "")
+	assert.Equal(t, "FALSE", where)
+
+	// LIKE metacharacters in a glob are escaped rather than widening it.
+	provider = newTestRBAC(t, `
+roles:
+  - name: r
+    rules:
+      - actions: [read]
+        resources: ["skill:ns_1/100%*", "skill:*/exact"]
+roleBindings:
+  - role: r
+    subjects: [{user: u}]
+`)
+	where, args = provider.ListPredicate(ctx, userSession{auth.User{Subject: "u"}}, auth.PermissionActionRead, auth.PermissionArtifactTypeSkill, "")
+	assert.Equal(t, "((namespace = $1 AND name LIKE $2) OR (name = $3))", where)
+	assert.Equal(t, []any{"ns_1", `100\%%`, "exact"}, args)
+}
+
+func TestParseRBACPolicy_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":  "rolez: []",
+		"unknown action": "roles: [{name: r, rules: [{actions: [write], resources: ['*']}]}]",
+		"empty rule":     "roles: [{name: r, rules: [{actions: [read]}]}]",
+		"bad glob":       "roles: [{name: r, rules: [{actions: [read], resources: ['agent:/x']}]}]",
+		"duplicate role": "roles: [{name: r}, {name: r}]",
+		"unknown role":   "roleBindings: [{role: missing, subjects: [{user: u}]}]",
+		"subject":        "roles: [{name: r}]\nroleBindings: [{role: r, subjects: [{user: u, group: g}]}]",
+	}
+	for name, doc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := auth.ParseRBACPolicy([]byte(doc))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRBACAuthzProvider_WatchReloadsPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	write := func(doc string) {
+		// Write-then-rename, the way editors and ConfigMap updates land.
+		tmp := path + ".tmp"
+		require.NoError(t, os.WriteFile(tmp, []byte(doc), 0o600))
+		require.NoError(t, os.Rename(tmp, path))
+	}
+	grant := func(action string) string {
+		return "roles: [{name: r, rules: [{actions: [" + action + "], resources: ['*']}]}]\n" +
+			"roleBindings: [{role: r, subjects: [{user: alice}]}]\n"
+	}
+	write(grant("read"))
+
+	provider, err := auth.LoadRBACAuthzProvider(path)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- provider.Watch(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+
+	alice := userSession{auth.User{Subject: "alice"}}
+	res := auth.Resource{Type: auth.PermissionArtifactTypeAgent, Name: "planner"}
+	require.NoError(t, provider.Check(ctx, alice, auth.PermissionActionRead, res))
+	require.ErrorIs(t, provider.Check(ctx, alice, auth.PermissionActionPublish, res), auth.ErrForbidden)
+
+	// Give the watcher a moment to register before the first edit.
+	time.Sleep(50 * time.Millisecond)
+	write(grant("publish"))
+	require.Eventually(t, func() bool {
+		return provider.Check(ctx, alice, auth.PermissionActionPublish, res) == nil
+	}, 5*time.Second, 20*time.Millisecond)
+	require.ErrorIs(t, provider.Check(ctx, alice, auth.PermissionActionRead, res), auth.ErrForbidden)
+
+	// An invalid edit keeps the previous policy in force.
+	write("roles: [{name: r, rules: [{actions: [bogus], resources: ['*']}]}]")
+	time.Sleep(500 * time.Millisecond)
+	require.NoError(t, provider.Check(ctx, alice, auth.PermissionActionPublish, res))
+}
@@ -26,7 +26,9 @@ var (
 // Store is the root persistence contract AppOptions.DatabaseFactory
 // wraps. The OSS implementation (internal/registry/database.PostgreSQL)
 // is a pgxpool-backed Store; downstream builds layer authz / caching /
-// secondary indices on top by wrapping a base Store.
+// secondary indices on top by wrapping a base Store. The embedded
+// single-binary mode (internal/registry/database.SQLite) has no pool and
+// exposes its connection through a DB() method instead.
 //
 // The contract is intentionally thin: v1alpha1 consumers reach through
 // Pool() to construct their own generic Stores via
@@ -72,10 +72,42 @@ type ApplyConfig struct {
 	// Store and runs the per-kind PostDelete hook.
 	DeleteAdmission types.DeleteAdmission
 
+	// Referrers mirrors resource.Config.Referrers: deletes of documents
+	// other live objects reference fail with Reason=Referenced unless the
+	// request sets ?force=true. Nil skips the check.
+	Referrers types.ReferrerLookup
+
 	// Prepare optionally mutates an object after validation and before
 	// admission. Import uses this to merge scanner output while still
 	// persisting through the shared apply path.
 	Prepare func(ctx context.Context, obj v1alpha1.Object) error
+
+	// NamespaceExists mirrors resource.Config.NamespaceExists: documents
+	// bound for an undeclared namespace fail with Reason=NamespaceNotFound.
+	NamespaceExists func(ctx context.Context, namespace string) (bool, error)
+
+	// TagPolicy returns the tag immutability policy for a tagged kind in a
+	// namespace. Applying different content to an existing protected tag
+	// fails with Reason=TagImmutable. Nil protects no tags.
+	TagPolicy func(ctx context.Context, kind, namespace string) (v1alpha1.TagPolicy, error)
+
+	// AuthorizeTagOverwrite gates ?overwriteImmutableTags=true, which lets
+	// an apply replace the content of protected tags. It runs once per
+	// protected document with Verb="overwrite-tag". Nil refuses every
+	// override.
+	AuthorizeTagOverwrite func(ctx context.Context, in AuthorizeInput) error
+
+	// VerifySignature enforces the artifact signature policy. Tagged
+	// content it finds unsigned fails with Reason=SignatureRequired, dry
+	// runs included. Nil requires no signatures.
+	VerifySignature SignatureVerifier
+
+	// ApprovalPolicy enforces the namespace approval policy. Tagged content
+	// it covers is stored but held for review: the write that stores it
+	// puts it up for review, the result carries Reason=ApprovalPending (or
+	// ApprovalRejected) and refs ignore the tag until a reviewer approves
+	// its digest. Nil requires no approvals.
+	ApprovalPolicy ApprovalPolicy
 }
 
 // applyInput receives a raw multi-doc YAML stream. RawBody keeps bytes
@@ -86,7 +118,16 @@ type ApplyConfig struct {
 // DryRun runs validate + resolve + registries + uniqueness but does not
 // mutate the store.
 type applyInput struct {
+	DryRun                 bool   `query:"dryRun" doc:"Run validation without mutating the store. Defaults to false."`
+	OverwriteImmutableTags bool   `query:"overwriteImmutableTags" doc:"Replace the content of tags the tag policy protects. Registry admins only; every overwrite is audited. Defaults to false."`
+	RawBody                []byte `contentType:"application/yaml" doc:"Multi-document YAML stream of v1alpha1 resources."`
+}
+
+// deleteBatchInput is applyInput without the apply-only options, plus
+// Force, which skips the referrer check.
+type deleteBatchInput struct {
 	DryRun  bool   `query:"dryRun" doc:"Run validation without mutating the store. Defaults to false."`
+	Force   bool   `query:"force" doc:"Delete resources even though other objects reference them. Defaults to false."`
 	RawBody []byte `contentType:"application/yaml" doc:"Multi-document YAML stream of v1alpha1 resources."`
 }
 
@@ -100,10 +141,11 @@ type applyOutput struct {
 // (when Resolver is set), runs registry + uniqueness checks, and
 // Upserts via the kind-matched Store.
 //
-// DELETE: for each document, calls Store.Delete on the named resource. Tagged
-// artifacts use metadata.tag when supplied; omitted tag deletes all tags for
-// that namespace/name. Mutable objects delete by namespace/name. Validation
-// still runs so clients get the same error surface as apply.
+// DELETE: for each document, in reverse order, calls Store.Delete on the
+// named resource. Tagged artifacts use metadata.tag when supplied; omitted
+// tag deletes all tags for that namespace/name. Mutable objects delete by
+// namespace/name. Validation still runs so clients get the same error
+// surface as apply.
 //
 // Both endpoints always return 200 with a per-document Results slice;
 // document-level failures are surfaced as Status="failed" entries and
@@ -121,7 +163,7 @@ func RegisterApply(api huma.API, cfg ApplyConfig) {
 		Path:        cfg.BasePrefix + "/apply",
 		Summary:     "Apply a multi-doc YAML stream of v1alpha1 resources",
 	}, func(ctx context.Context, in *applyInput) (*applyOutput, error) {
-		return runApplyBatch(ctx, cfg, scheme, in, false), nil
+		return runApplyBatch(ctx, cfg, scheme, in, false, false), nil
 	})
 
 	huma.Register(api, huma.Operation{
@@ -129,12 +171,12 @@ func RegisterApply(api huma.API, cfg ApplyConfig) {
 		Method:      http.MethodDelete,
 		Path:        cfg.BasePrefix + "/apply",
 		Summary:     "Delete v1alpha1 resources identified by a multi-doc YAML stream",
-	}, func(ctx context.Context, in *applyInput) (*applyOutput, error) {
-		return runApplyBatch(ctx, cfg, scheme, in, true), nil
+	}, func(ctx context.Context, in *deleteBatchInput) (*applyOutput, error) {
+		return runApplyBatch(ctx, cfg, scheme, &applyInput{DryRun: in.DryRun, RawBody: in.RawBody}, true, in.Force), nil
 	})
 }
 
-func runApplyBatch(ctx context.Context, cfg ApplyConfig, scheme *v1alpha1.Scheme, in *applyInput, del bool) *applyOutput {
+func runApplyBatch(ctx context.Context, cfg ApplyConfig, scheme *v1alpha1.Scheme, in *applyInput, del, force bool) *applyOutput {
 	out := &applyOutput{}
 	docs, err := scheme.DecodeMulti(in.RawBody)
 	if err != nil {
@@ -144,20 +186,26 @@ func runApplyBatch(ctx context.Context, cfg ApplyConfig, scheme *v1alpha1.Scheme
 		}}
 		return out
 	}
-	out.Body.Results = make([]arv0.ApplyResult, 0, len(docs))
-	for _, d := range docs {
-		obj, ok := d.(v1alpha1.Object)
+	out.Body.Results = make([]arv0.ApplyResult, len(docs))
+	for i := range docs {
+		// Deletes run in reverse document order: files list dependencies
+		// before their dependents (apply order), so dependents go first and
+		// no longer block the referrer check. Results keep document order.
+		if del {
+			i = len(docs) - 1 - i
+		}
+		obj, ok := docs[i].(v1alpha1.Object)
 		if !ok {
-			out.Body.Results = append(out.Body.Results, arv0.ApplyResult{
+			out.Body.Results[i] = arv0.ApplyResult{
 				Status: arv0.ApplyStatusFailed,
-				Error:  fmt.Sprintf("decoded value does not satisfy v1alpha1.Object: %T", d),
-			})
+				Error:  fmt.Sprintf("decoded value does not satisfy v1alpha1.Object: %T", docs[i]),
+			}
 			continue
 		}
 		if del {
-			out.Body.Results = append(out.Body.Results, deleteOne(ctx, cfg, obj, in.DryRun))
+			out.Body.Results[i] = deleteOne(ctx, cfg, obj, in.DryRun, force)
 		} else {
-			out.Body.Results = append(out.Body.Results, applyOne(ctx, cfg, obj, in.DryRun))
+			out.Body.Results[i] = applyOne(ctx, cfg, obj, in.DryRun, in.OverwriteImmutableTags)
 		}
 	}
 	return out
@@ -168,18 +216,18 @@ func runApplyBatch(ctx context.Context, cfg ApplyConfig, scheme *v1alpha1.Scheme
 // a previously accepted object without duplicating validation, authz,
 // persistence, or post-upsert behavior.
 func ApplyObject(ctx context.Context, cfg ApplyConfig, obj v1alpha1.Object, dryRun bool) arv0.ApplyResult {
-	return applyOne(ctx, cfg, obj, dryRun)
+	return applyOne(ctx, cfg, obj, dryRun, false)
 }
 
 // DeleteObject runs one already-decoded object through the same production
 // delete path used by DELETE /v0/apply.
 func DeleteObject(ctx context.Context, cfg ApplyConfig, obj v1alpha1.Object, dryRun bool) arv0.ApplyResult {
-	return deleteOne(ctx, cfg, obj, dryRun)
+	return deleteOne(ctx, cfg, obj, dryRun, false)
 }
 
 // applyOne runs a single document through the shared apply pipeline.
 // Never errors; encodes any failure into the returned ApplyResult.
-func applyOne(ctx context.Context, cfg ApplyConfig, obj v1alpha1.Object, dryRun bool) arv0.ApplyResult {
+func applyOne(ctx context.Context, cfg ApplyConfig, obj v1alpha1.Object, dryRun, overwriteImmutableTags bool) arv0.ApplyResult {
 	store, meta, ae := resolveBatchTarget(cfg, obj, "apply")
 	res := arv0.ApplyResult{
 		APIVersion: obj.GetAPIVersion(),
@@ -191,18 +239,30 @@ func applyOne(ctx context.Context, cfg ApplyConfig, obj v1alpha1.Object, dryRun
 		return failResult(res, ae)
 	}
 
+	refs := &refWarnings{stores: cfg.Stores}
 	admitted, ae := applyCore(ctx, store, obj, applyOpts{
 		Authorize:         batchAuthorize(cfg, obj.GetKind()),
-		Resolver:          cfg.Resolver,
+		Resolver:          refs.wrap(cfg.Resolver),
 		RegistryValidator: cfg.RegistryValidator,
 		PostUpsert:        cfg.PostUpserts[obj.GetKind()],
 		InitialFinalizers: cfg.InitialFinalizers[obj.GetKind()],
 		Admission:         cfg.Admission,
 		Source:            cfg.Source,
 		Prepare:           cfg.Prepare,
+		NamespaceExists:   cfg.NamespaceExists,
+
+		TagPolicy:              cfg.TagPolicy,
+		AuthorizeTagOverwrite:  cfg.AuthorizeTagOverwrite,
+		OverwriteImmutableTags: overwriteImmutableTags,
+		VerifySignature:        cfg.VerifySignature,
+		ApprovalPolicy:         cfg.ApprovalPolicy,
 	}, dryRun)
 	if ae != nil {
-		return failResult(res, ae)
+		res = failResult(res, ae)
+		if ae.Unsigned {
+			res.Digest = objectDigest(obj)
+		}
+		return res
 	}
 
 	res.Status = admitted.Status
@@ -210,10 +270,32 @@ func applyOne(ctx context.Context, cfg ApplyConfig, obj v1alpha1.Object, dryRun
 		res.Status = arv0.ApplyStatusUnchanged
 	}
 	res.Tag = admitted.Tag
+	res.Digest = objectDigest(obj)
 	res.Generation = admitted.Generation
+	res.ResourceVersion = admitted.ResourceVersion
+	res.Warnings = refs.warnings(ctx)
+	switch admitted.ApprovalState {
+	case v1alpha1store.ApprovalPending:
+		res.Reason = arv0.ApplyReasonApprovalPending
+	case v1alpha1store.ApprovalRejected:
+		res.Reason = arv0.ApplyReasonApprovalRejected
+	}
 	return res
 }
 
+// objectDigest returns the content digest of a tagged artifact, or "" for
+// other kinds and for content that cannot be hashed.
+func objectDigest(obj v1alpha1.Object) string {
+	if !v1alpha1.IsTaggedArtifactKind(obj.GetKind()) {
+		return ""
+	}
+	digest, err := v1alpha1store.ObjectDigest(obj)
+	if err != nil {
+		return ""
+	}
+	return digest
+}
+
 // deleteOne runs a single document through Authorize + the per-mode
 // store delete + PostDelete. No validation: deleting a row should not
 // require its spec to validate. The PostDelete hook receives the
@@ -224,7 +306,7 @@ func applyOne(ctx context.Context, cfg ApplyConfig, obj v1alpha1.Object, dryRun
 // deletes every tag for (namespace, name); setting metadata.tag deletes that
 // exact tag. Mutable-object rows keep their single-row delete since those rows
 // are control-plane state rather than append-only tags.
-func deleteOne(ctx context.Context, cfg ApplyConfig, obj v1alpha1.Object, dryRun bool) arv0.ApplyResult {
+func deleteOne(ctx context.Context, cfg ApplyConfig, obj v1alpha1.Object, dryRun, force bool) arv0.ApplyResult {
 	store, meta, ae := resolveBatchTarget(cfg, obj, "delete")
 	res := arv0.ApplyResult{
 		APIVersion: obj.GetAPIVersion(),
@@ -242,6 +324,11 @@ func deleteOne(ctx context.Context, cfg ApplyConfig, obj v1alpha1.Object, dryRun
 		PreDeleteObject: obj,
 		DeleteAdmission: cfg.DeleteAdmission,
 		Source:          cfg.Source,
+		Referrers:       cfg.Referrers,
+		Force:           force,
+		// Authorizers gate the referrers a refusal names the same way
+		// resource.Config.ReferrerAuthorizers does.
+		ReferrerAuthorizers: cfg.Authorizers,
 	}, dryRun)
 	if ae != nil {
 		return failResult(res, ae)
@@ -320,12 +407,35 @@ func failResult(res arv0.ApplyResult, ae *applyError) arv0.ApplyResult {
 		if ae.Terminating {
 			res.Error = fmt.Sprintf("object %s/%s is terminating; delete + re-apply once GC purges the row",
 				res.Namespace, res.Name)
+		} else if ae.Conflict {
+			res.Error = "conflict: " + ae.Err.Error()
+			res.Reason = arv0.ApplyReasonConflict
+		} else if ae.Immutable {
+			res.Error = "conflict: " + ae.Err.Error()
+			res.Reason = arv0.ApplyReasonTagImmutable
 		} else {
 			res.Error = "upsert: " + ae.Err.Error()
 		}
+	case stageSignature:
+		if ae.Unsigned {
+			res.Error = "forbidden: " + ae.Err.Error()
+			res.Reason = arv0.ApplyReasonSignatureRequired
+		} else {
+			res.Error = ae.Error()
+		}
+	case stageNamespace:
+		if ae.NotFound {
+			res.Error = "not found: " + ae.Err.Error()
+			res.Reason = arv0.ApplyReasonNamespaceNotFound
+		} else {
+			res.Error = ae.Error()
+		}
 	case stageDelete:
 		if ae.NotFound {
 			res.Error = fmt.Sprintf("not found: %s/%s", res.Namespace, res.Name)
+		} else if ae.Referenced {
+			res.Error = "conflict: " + ae.Err.Error()
+			res.Reason = arv0.ApplyReasonReferenced
 		} else {
 			res.Error = "delete: " + ae.Err.Error()
 		}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/humatest"
 	"github.com/stretchr/testify/require"
 
@@ -21,9 +22,9 @@ import (
 )
 
 func TestRegisterApply_MultiDocRoundTrip(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	agents := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
-	mcps := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "mcp_servers")
+	db := v1alpha1store.NewTestDB(t)
+	agents := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
+	mcps := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "mcp_servers")
 
 	_, api := humatest.New(t)
 	resource.RegisterApply(api, resource.ApplyConfig{
@@ -79,8 +80,8 @@ spec:
 }
 
 func TestRegisterApply_PerDocFailureDoesntAbortBatch(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	agents := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
+	db := v1alpha1store.NewTestDB(t)
+	agents := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
 
 	_, api := humatest.New(t)
 	resource.RegisterApply(api, resource.ApplyConfig{
@@ -121,8 +122,8 @@ spec:
 }
 
 func TestRegisterApply_AdmissionCanStageInsteadOfProductionUpsert(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	agents := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
+	db := v1alpha1store.NewTestDB(t)
+	agents := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
 
 	var admitted types.AdmissionInput
 	postUpsertCalled := false
@@ -176,8 +177,8 @@ spec:
 }
 
 func TestRegisterApply_DeleteAdmissionCanStageInsteadOfProductionDelete(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	agents := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
+	db := v1alpha1store.NewTestDB(t)
+	agents := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
 	_, err := agents.Upsert(t.Context(), &v1alpha1.Agent{
 		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "staged-delete", Tag: "stable"},
 		Spec:     v1alpha1.AgentSpec{Title: "Staged Delete"},
@@ -238,8 +239,8 @@ metadata:
 }
 
 func TestApplyObject_ReusesProductionApplyPath(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	agents := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
+	db := v1alpha1store.NewTestDB(t)
+	agents := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
 
 	obj := &v1alpha1.Agent{
 		TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindAgent},
@@ -264,9 +265,9 @@ func TestApplyObject_ReusesProductionApplyPath(t *testing.T) {
 }
 
 func TestRegisterApply_MutableObjectResultsDoNotExposeVersion(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	runtimes := v1alpha1store.NewMutableObjectStore(pool, v1alpha1store.TestSchema(), "runtimes")
-	deployments := v1alpha1store.NewMutableObjectStore(pool, v1alpha1store.TestSchema(), "deployments")
+	db := v1alpha1store.NewTestDB(t)
+	runtimes := v1alpha1store.NewMutableObjectStore(db, v1alpha1store.TestSchema(), "runtimes")
+	deployments := v1alpha1store.NewMutableObjectStore(db, v1alpha1store.TestSchema(), "deployments")
 
 	_, api := humatest.New(t)
 	resource.RegisterApply(api, resource.ApplyConfig{
@@ -334,8 +335,8 @@ spec:
 }
 
 func TestRegisterDeleteApply_OmittedTagDeletesAllTags(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	agents := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
+	db := v1alpha1store.NewTestDB(t)
+	agents := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
 
 	_, api := humatest.New(t)
 	resource.RegisterApply(api, resource.ApplyConfig{
@@ -379,8 +380,8 @@ metadata:
 }
 
 func TestRegisterDeleteApply_TagDeletesOnlyExactTag(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	agents := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
+	db := v1alpha1store.NewTestDB(t)
+	agents := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
 
 	_, api := humatest.New(t)
 	resource.RegisterApply(api, resource.ApplyConfig{
@@ -425,8 +426,8 @@ metadata:
 }
 
 func TestRegisterApply_DefaultsRemoteMCPServerTagBeforeAuthorize(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	mcpServers := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "mcp_servers")
+	db := v1alpha1store.NewTestDB(t)
+	mcpServers := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "mcp_servers")
 
 	_, err := mcpServers.Upsert(t.Context(), &v1alpha1.MCPServer{
 		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "test-mcp-server"},
@@ -491,9 +492,9 @@ spec:
 // some kinds but forgets others — would silently bypass authz on the
 // /v0/apply path for the missing kinds.
 func TestRegisterApply_DeniesKindWithNoAuthorizer(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	agents := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
-	mcps := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "mcp_servers")
+	db := v1alpha1store.NewTestDB(t)
+	agents := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
+	mcps := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "mcp_servers")
 
 	_, api := humatest.New(t)
 	resource.RegisterApply(api, resource.ApplyConfig{
@@ -531,3 +532,110 @@ spec:
 	_, err := mcps.Get(t.Context(), "default", "should-be-denied", "1")
 	require.Error(t, err, "fail-closed must short-circuit before Upsert")
 }
+
+func TestRegisterApply_StaleResourceVersionReportsConflict(t *testing.T) {
+	db := v1alpha1store.NewTestDB(t)
+	agents := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
+
+	_, api := humatest.New(t)
+	resource.RegisterApply(api, resource.ApplyConfig{
+		BasePrefix: "/v0",
+		Stores:     map[string]*v1alpha1store.Store{v1alpha1.KindAgent: agents},
+	})
+
+	apply := func(doc string) arv0.ApplyResult {
+		t.Helper()
+		resp := api.Post("/v0/apply", "Content-Type: application/yaml", strings.NewReader(doc))
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+		var out struct {
+			Results []arv0.ApplyResult `json:"results"`
+		}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &out))
+		require.Len(t, out.Results, 1)
+		return out.Results[0]
+	}
+	doc := func(title, resourceVersion string) string {
+		return `apiVersion: ar.dev/v1alpha1
+kind: Agent
+metadata:
+  namespace: default
+  name: alice
+  resourceVersion: "` + resourceVersion + `"
+spec:
+  title: ` + title + "\n"
+	}
+
+	created := apply(doc("one", ""))
+	require.Equal(t, arv0.ApplyStatusCreated, created.Status)
+	require.NotEmpty(t, created.ResourceVersion)
+
+	updated := apply(doc("two", created.ResourceVersion))
+	require.Equal(t, arv0.ApplyStatusConfigured, updated.Status, updated.Error)
+
+	stale := apply(doc("three", created.ResourceVersion))
+	require.Equal(t, arv0.ApplyStatusFailed, stale.Status)
+	require.Equal(t, arv0.ApplyReasonConflict, stale.Reason)
+	require.True(t, strings.HasPrefix(stale.Error, "conflict: "), stale.Error)
+}
+
+func TestRegisterApply_ImmutableTagPolicy(t *testing.T) {
+	db := v1alpha1store.NewTestDB(t)
+	agents := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
+
+	admin := false
+	_, api := humatest.New(t)
+	resource.RegisterApply(api, resource.ApplyConfig{
+		BasePrefix: "/v0",
+		Stores:     map[string]*v1alpha1store.Store{v1alpha1.KindAgent: agents},
+		TagPolicy: func(context.Context, string, string) (v1alpha1.TagPolicy, error) {
+			return v1alpha1.DefaultTagPolicy(), nil
+		},
+		AuthorizeTagOverwrite: func(_ context.Context, in resource.AuthorizeInput) error {
+			require.Equal(t, "overwrite-tag", in.Verb)
+			if !admin {
+				return huma.Error403Forbidden("registry admin required")
+			}
+			return nil
+		},
+	})
+
+	apply := func(query, tag, title string) arv0.ApplyResult {
+		t.Helper()
+		doc := `apiVersion: ar.dev/v1alpha1
+kind: Agent
+metadata:
+  namespace: default
+  name: alice
+  tag: ` + tag + `
+spec:
+  title: ` + title + "\n"
+		resp := api.Post("/v0/apply"+query, "Content-Type: application/yaml", strings.NewReader(doc))
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+		var out struct {
+			Results []arv0.ApplyResult `json:"results"`
+		}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &out))
+		require.Len(t, out.Results, 1)
+		return out.Results[0]
+	}
+
+	require.Equal(t, arv0.ApplyStatusCreated, apply("", "1.0.0", "one").Status)
+	require.Equal(t, arv0.ApplyStatusUnchanged, apply("", "1.0.0", "one").Status)
+
+	rejected := apply("", "1.0.0", "two")
+	require.Equal(t, arv0.ApplyStatusFailed, rejected.Status)
+	require.Equal(t, arv0.ApplyReasonTagImmutable, rejected.Reason)
+	require.Contains(t, rejected.Error, "publish the change under a new tag")
+
+	// Floating tags stay mutable.
+	require.Equal(t, arv0.ApplyStatusCreated, apply("", "stable", "one").Status)
+	require.Equal(t, arv0.ApplyStatusConfigured, apply("", "stable", "two").Status)
+
+	forbidden := apply("?overwriteImmutableTags=true", "1.0.0", "two")
+	require.Equal(t, arv0.ApplyStatusFailed, forbidden.Status)
+	require.True(t, strings.HasPrefix(forbidden.Error, "forbidden: "), forbidden.Error)
+
+	admin = true
+	overwritten := apply("?overwriteImmutableTags=true", "1.0.0", "two")
+	require.Equal(t, arv0.ApplyStatusConfigured, overwritten.Status, overwritten.Error)
+}
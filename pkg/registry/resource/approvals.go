@@ -0,0 +1,227 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/audit"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// ApprovalPolicy reports whether the approval policy of namespace holds
+// content of kind for review.
+type ApprovalPolicy func(ctx context.Context, kind, namespace string) (bool, error)
+
+// NewApprovalPolicy returns an ApprovalPolicy over stores. Only a
+// Namespace's spec.approvalPolicy requires approval; there is no server-wide
+// policy.
+func NewApprovalPolicy(stores map[string]*v1alpha1store.Store) ApprovalPolicy {
+	return func(ctx context.Context, kind, namespace string) (bool, error) {
+		spec, err := namespaceSpec(ctx, stores, namespace)
+		if err != nil {
+			return false, err
+		}
+		return spec != nil && spec.ApprovalPolicy != nil && spec.ApprovalPolicy.Requires(kind), nil
+	}
+}
+
+// dryRunApprovalState returns the review state a publish of obj would
+// leave its content in, without putting it up for review.
+func dryRunApprovalState(ctx context.Context, store *v1alpha1store.Store, obj v1alpha1.Object) (string, error) {
+	digest, err := v1alpha1store.ObjectDigest(obj)
+	if err != nil {
+		return "", err
+	}
+	meta := obj.GetMetadata()
+	a, err := store.GetApproval(ctx, meta.Namespace, meta.Name, digest)
+	switch {
+	case errors.Is(err, pkgdb.ErrNotFound):
+		return v1alpha1store.ApprovalPending, nil
+	case err != nil:
+		return "", err
+	case a.State == v1alpha1store.ApprovalRejected:
+		// Publishing rejected content again asks for another review.
+		return v1alpha1store.ApprovalPending, nil
+	}
+	return a.State, nil
+}
+
+// ApprovalsConfig configures the approvals endpoints.
+type ApprovalsConfig struct {
+	// BasePrefix is the HTTP route prefix shared with the generic resource
+	// handler (e.g. "/v0"). The endpoints mount at "{BasePrefix}/approvals".
+	BasePrefix string
+	// Stores maps Kind to its Store. Tagged kinds whose Store keeps
+	// approvals are listed and reviewable.
+	Stores map[string]*v1alpha1store.Store
+	// Authorizers are the per-kind hooks the resource endpoints consult.
+	// Listing checks Verb="get" per entry and drops entries the caller may
+	// not read; missing keys allow. Reviewing checks Verb="approve".
+	Authorizers map[string]func(ctx context.Context, in AuthorizeInput) error
+	// AuthorizeReview gates reviews of kinds without an Authorizers entry,
+	// with Verb="approve". Nil refuses those reviews: a review queue that
+	// anyone may clear protects nothing.
+	AuthorizeReview func(ctx context.Context, in AuthorizeInput) error
+}
+
+type listApprovalsInput struct {
+	Namespace string `query:"namespace" doc:"Namespace to list. Defaults to 'default'; 'all' lists every namespace."`
+	State     string `query:"state" enum:"pending,approved,rejected,all" doc:"Review state to list. Defaults to 'pending'; 'all' lists every state."`
+}
+
+type approvalListOutput struct {
+	Body arv0.ApprovalListResponse
+}
+
+type reviewApprovalInput struct {
+	Body arv0.ApprovalReview
+}
+
+type approvalOutput struct {
+	Body arv0.ApprovalEntry
+}
+
+// RegisterApprovals wires GET and POST {BasePrefix}/approvals: the review
+// queue of content published into namespaces whose approval policy holds
+// it, and the approve/reject decision on one content digest. A reviewer may
+// not decide on content they published themselves.
+func RegisterApprovals(api huma.API, cfg ApprovalsConfig) {
+	kinds := make([]string, 0, len(cfg.Stores))
+	for kind, store := range cfg.Stores {
+		if store.Approvals() {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-approvals",
+		Method:      http.MethodGet,
+		Path:        cfg.BasePrefix + "/approvals",
+		Summary:     "List content awaiting or past review",
+	}, func(ctx context.Context, in *listApprovalsInput) (*approvalListOutput, error) {
+		ns := resolveNamespace(in.Namespace, true)
+		state := in.State
+		switch state {
+		case "":
+			state = v1alpha1store.ApprovalPending
+		case "all":
+			state = ""
+		}
+		out := &approvalListOutput{}
+		out.Body.Items = []arv0.ApprovalEntry{}
+		for _, kind := range kinds {
+			approvals, err := cfg.Stores[kind].ListApprovals(ctx, ns, state)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("list approvals of "+kind, err)
+			}
+			for _, a := range approvals {
+				if authorize := cfg.Authorizers[kind]; authorize != nil {
+					if authorize(ctx, AuthorizeInput{Verb: "get", Kind: kind, Namespace: a.Namespace, Name: a.Name, Tag: a.Tag}) != nil {
+						continue
+					}
+				}
+				out.Body.Items = append(out.Body.Items, toApprovalEntry(kind, a))
+			}
+		}
+		sort.SliceStable(out.Body.Items, func(i, j int) bool {
+			return out.Body.Items[i].RequestedAt.Before(out.Body.Items[j].RequestedAt)
+		})
+		return out, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "review-approval",
+		Method:      http.MethodPost,
+		Path:        cfg.BasePrefix + "/approvals",
+		Summary:     "Approve or reject published content",
+	}, func(ctx context.Context, in *reviewApprovalInput) (*approvalOutput, error) {
+		review := in.Body
+		kind, ok := lookupSearchKind(cfg.Stores, review.Kind)
+		if !ok {
+			return nil, huma.Error400BadRequest(fmt.Sprintf("unknown kind %q", review.Kind))
+		}
+		store := cfg.Stores[kind]
+		if !store.Approvals() {
+			return nil, huma.Error501NotImplemented(fmt.Sprintf("%s keeps no approvals", kind))
+		}
+		if review.Name == "" {
+			return nil, huma.Error400BadRequest("name is required")
+		}
+		ns := resolveNamespace(review.Namespace, false)
+		authorize := cfg.Authorizers[kind]
+		if authorize == nil {
+			authorize = cfg.AuthorizeReview
+		}
+		if authorize == nil {
+			return nil, huma.Error403Forbidden("reviewing approvals is not enabled on this registry")
+		}
+		if err := authorize(ctx, AuthorizeInput{Verb: "approve", Kind: kind, Namespace: ns, Name: review.Name, Tag: review.Tag}); err != nil {
+			return nil, err
+		}
+		digest := review.Digest
+		if digest == "" {
+			var err error
+			digest, err = store.TagDigest(ctx, ns, review.Name, review.Tag)
+			if err != nil {
+				return nil, mapNotFound(err, kind, ns, review.Name, review.Tag)
+			}
+		}
+
+		current, err := store.GetApproval(ctx, ns, review.Name, digest)
+		switch {
+		case errors.Is(err, pkgdb.ErrNotFound):
+			return nil, huma.Error404NotFound(fmt.Sprintf("%s %q/%q has no content %s awaiting review", kind, ns, review.Name, digest))
+		case errors.Is(err, v1alpha1.ErrInvalidFormat):
+			return nil, huma.Error400BadRequest(err.Error())
+		case err != nil:
+			return nil, huma.Error500InternalServerError("get approval of "+kind, err)
+		}
+		reviewer := audit.Principal(ctx)
+		if reviewer == current.RequestedBy && isSubject(reviewer) {
+			return nil, huma.Error403Forbidden(fmt.Sprintf("%s may not review content they published", reviewer))
+		}
+
+		reviewed, err := store.ReviewApproval(ctx, ns, review.Name, digest,
+			review.Decision == arv0.ApprovalDecisionApprove, reviewer, review.Comment)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("review approval of "+kind, err)
+		}
+		return &approvalOutput{Body: toApprovalEntry(kind, *reviewed)}, nil
+	})
+}
+
+// isSubject reports whether principal names an authenticated caller rather
+// than the server or an unauthenticated request. Only a subject's own
+// publishes are barred from its review.
+func isSubject(principal string) bool {
+	switch principal {
+	case audit.PrincipalSystem, audit.PrincipalPublic, audit.PrincipalAnonymous:
+		return false
+	}
+	return true
+}
+
+func toApprovalEntry(kind string, a v1alpha1store.Approval) arv0.ApprovalEntry {
+	return arv0.ApprovalEntry{
+		Kind:        kind,
+		Namespace:   a.Namespace,
+		Name:        a.Name,
+		Tag:         a.Tag,
+		Digest:      a.Digest,
+		State:       a.State,
+		RequestedBy: a.RequestedBy,
+		RequestedAt: a.RequestedAt,
+		ReviewedBy:  a.ReviewedBy,
+		ReviewedAt:  a.ReviewedAt,
+		Comment:     a.Comment,
+	}
+}
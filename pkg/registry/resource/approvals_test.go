@@ -0,0 +1,159 @@
+//go:build integration
+
+package resource_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/require"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+type approvalTestSession struct{ subject string }
+
+func (s approvalTestSession) Principal() auth.Principal {
+	return auth.Principal{User: auth.User{Subject: s.subject}}
+}
+
+func TestApprovals_ProtectedNamespaceHoldsPublishes(t *testing.T) {
+	ctx := context.Background()
+	db := v1alpha1store.NewTestDB(t)
+	schema := v1alpha1store.TestSchema()
+	agents := v1alpha1store.NewStore(db, schema, "agents", v1alpha1store.WithApprovals(schema))
+	namespaces := v1alpha1store.NewMutableObjectStore(db, schema, "namespaces")
+	stores := map[string]*v1alpha1store.Store{v1alpha1.KindAgent: agents, v1alpha1.KindNamespace: namespaces}
+
+	_, err := namespaces.Upsert(ctx, &v1alpha1.Namespace{
+		TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindNamespace},
+		Metadata: v1alpha1.ObjectMeta{Namespace: v1alpha1.DefaultNamespace, Name: "catalog"},
+		Spec:     v1alpha1.NamespaceSpec{ApprovalPolicy: &v1alpha1.ApprovalPolicy{Required: true}},
+	})
+	require.NoError(t, err)
+
+	_, api := humatest.New(t)
+	// X-Subject stands in for authentication, so reviews have a principal.
+	api.UseMiddleware(func(hctx huma.Context, next func(huma.Context)) {
+		if subject := hctx.Header("X-Subject"); subject != "" {
+			hctx = huma.WithContext(hctx, auth.AuthSessionTo(hctx.Context(), approvalTestSession{subject}))
+		}
+		next(hctx)
+	})
+	resource.RegisterApply(api, resource.ApplyConfig{
+		BasePrefix:     "/v0",
+		Stores:         stores,
+		ApprovalPolicy: resource.NewApprovalPolicy(stores),
+	})
+	resource.RegisterApprovals(api, resource.ApprovalsConfig{
+		BasePrefix: "/v0",
+		Stores:     stores,
+		// Agents have no per-kind authorizer, so this gates their reviews.
+		AuthorizeReview: func(ctx context.Context, _ resource.AuthorizeInput) error {
+			if _, ok := auth.AuthSessionFrom(ctx); !ok {
+				return huma.Error403Forbidden("reviews need a session")
+			}
+			return nil
+		},
+	})
+
+	doc := func(namespace, tag, title string) string {
+		return `apiVersion: ar.dev/v1alpha1
+kind: Agent
+metadata:
+  namespace: ` + namespace + `
+  name: alice
+  tag: ` + tag + `
+spec:
+  title: ` + title + `
+`
+	}
+	publish := func(yaml string) arv0.ApplyResult {
+		t.Helper()
+		resp := api.Post("/v0/apply", "Content-Type: application/yaml", "X-Subject: alice", strings.NewReader(yaml))
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+		var out arv0.ApplyResultsResponse
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &out))
+		require.Len(t, out.Results, 1)
+		return out.Results[0]
+	}
+	review := func(subject string, body arv0.ApprovalReview) *httptest.ResponseRecorder {
+		t.Helper()
+		return api.Post("/v0/approvals", "X-Subject: "+subject, body)
+	}
+
+	approved := publish(doc("catalog", "1.0.0", "one"))
+	require.Equal(t, arv0.ApplyStatusCreated, approved.Status, approved.Error)
+	require.Equal(t, arv0.ApplyReasonApprovalPending, approved.Reason)
+	_, err = agents.GetUsableByRef(ctx, "catalog", "alice", "1.0.0")
+	require.ErrorIs(t, err, v1alpha1store.ErrUnapproved)
+
+	resp := api.Get("/v0/approvals?namespace=catalog")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var listed arv0.ApprovalListResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &listed))
+	require.Len(t, listed.Items, 1)
+	require.Equal(t, approved.Digest, listed.Items[0].Digest)
+	require.Equal(t, "alice", listed.Items[0].RequestedBy)
+
+	body := arv0.ApprovalReview{Kind: "agents", Namespace: "catalog", Name: "alice", Tag: "1.0.0", Decision: arv0.ApprovalDecisionApprove, Comment: "lgtm"}
+	got := review("alice", body)
+	require.Equal(t, http.StatusForbidden, got.Code, "publishers do not review their own content: %s", got.Body.String())
+	got = review("", body)
+	require.Equal(t, http.StatusForbidden, got.Code, "anonymous callers do not review: %s", got.Body.String())
+	got = review("bob", body)
+	require.Equal(t, http.StatusOK, got.Code, got.Body.String())
+
+	obj, err := agents.GetUsableByRef(ctx, "catalog", "alice", "1.0.0")
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0", obj.Metadata.Tag)
+
+	// A newer pending tag is skipped by ranges until it is approved.
+	pending := publish(doc("catalog", "1.1.0", "two"))
+	require.Equal(t, arv0.ApplyReasonApprovalPending, pending.Reason)
+	obj, err = agents.GetUsableByRef(ctx, "catalog", "alice", "^1.0.0")
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0", obj.Metadata.Tag)
+
+	got = review("bob", arv0.ApprovalReview{Kind: v1alpha1.KindAgent, Namespace: "catalog", Name: "alice", Digest: pending.Digest, Decision: arv0.ApprovalDecisionReject})
+	require.Equal(t, http.StatusOK, got.Code, got.Body.String())
+	rejected := publish(doc("catalog", "1.1.0", "two"))
+	require.Equal(t, arv0.ApplyStatusUnchanged, rejected.Status)
+	require.Equal(t, arv0.ApplyReasonApprovalRejected, rejected.Reason)
+
+	// Approved content needs no second review under another tag.
+	retagged := publish(doc("catalog", "1.0.1", "one"))
+	require.Equal(t, arv0.ApplyStatusCreated, retagged.Status, retagged.Error)
+	require.Empty(t, retagged.Reason)
+
+	// Namespaces without a policy publish straight through.
+	open := publish(doc("default", "1.0.0", "one"))
+	require.Equal(t, arv0.ApplyStatusCreated, open.Status, open.Error)
+	require.Empty(t, open.Reason)
+
+	got = review("bob", arv0.ApprovalReview{Kind: v1alpha1.KindAgent, Name: "alice", Tag: "1.0.0", Decision: arv0.ApprovalDecisionApprove})
+	require.Equal(t, http.StatusNotFound, got.Code, got.Body.String())
+}
+
+func TestApprovals_ReviewRefusedWithoutAuthorizer(t *testing.T) {
+	db := v1alpha1store.NewTestDB(t)
+	schema := v1alpha1store.TestSchema()
+	stores := map[string]*v1alpha1store.Store{
+		v1alpha1.KindAgent: v1alpha1store.NewStore(db, schema, "agents", v1alpha1store.WithApprovals(schema)),
+	}
+	_, api := humatest.New(t)
+	resource.RegisterApprovals(api, resource.ApprovalsConfig{BasePrefix: "/v0", Stores: stores})
+
+	resp := api.Post("/v0/approvals", arv0.ApprovalReview{Kind: v1alpha1.KindAgent, Name: "alice", Tag: "1.0.0", Decision: arv0.ApprovalDecisionApprove})
+	require.Equal(t, http.StatusForbidden, resp.Code, resp.Body.String())
+}
@@ -3,10 +3,14 @@ package resource
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 
+	"github.com/danielgtaylor/huma/v2"
+
 	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/audit"
 	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
 	"github.com/agentregistry-dev/agentregistry/pkg/types"
@@ -26,6 +30,18 @@ type applyOpts struct {
 	Admission         types.Admission
 	Source            string
 	Prepare           func(ctx context.Context, obj v1alpha1.Object) error
+	// NamespaceExists mirrors Config.NamespaceExists.
+	NamespaceExists func(ctx context.Context, namespace string) (bool, error)
+	// TagPolicy and AuthorizeTagOverwrite mirror the ApplyConfig fields.
+	// OverwriteImmutableTags is the caller's request to override the
+	// policy.
+	TagPolicy              func(ctx context.Context, kind, namespace string) (v1alpha1.TagPolicy, error)
+	AuthorizeTagOverwrite  func(ctx context.Context, in AuthorizeInput) error
+	OverwriteImmutableTags bool
+	// VerifySignature mirrors ApplyConfig.VerifySignature.
+	VerifySignature SignatureVerifier
+	// ApprovalPolicy mirrors ApplyConfig.ApprovalPolicy.
+	ApprovalPolicy ApprovalPolicy
 }
 
 // applyStage tags which step of the pipeline produced an error so
@@ -36,8 +52,12 @@ type applyStage string
 const (
 	stageAuth       applyStage = "auth"
 	stageValidation applyStage = "validation"
+	stageNamespace  applyStage = "namespace"
 	stageRefs       applyStage = "refs"
 	stageRegistries applyStage = "registries"
+	stageTagPolicy  applyStage = "tag-policy"
+	stageApproval   applyStage = "approval-policy"
+	stageSignature  applyStage = "signature"
 	stageAdmission  applyStage = "admission"
 	stagePrepare    applyStage = "prepare"
 	stageMarshal    applyStage = "marshal"
@@ -50,13 +70,20 @@ const (
 // applyError is the typed error applyCore + deleteCore return.
 // Stage drives caller-side response shaping; Terminating distinguishes
 // the soft-delete-in-progress case from generic upsert failures so
-// callers can map it to 409 instead of 500. NotFound mirrors the same
-// for delete-against-missing-row.
+// callers can map it to 409 instead of 500, and Conflict does the same
+// for a stale metadata.resourceVersion and Immutable for a write to a
+// protected tag. NotFound mirrors the same for delete-against-missing-row,
+// and Referenced for a delete refused because other objects reference the
+// target.
 type applyError struct {
 	Stage       applyStage
 	Err         error
 	Terminating bool
+	Conflict    bool
+	Immutable   bool
 	NotFound    bool
+	Referenced  bool
+	Unsigned    bool
 }
 
 func (e *applyError) Error() string {
@@ -66,8 +93,9 @@ func (e *applyError) Error() string {
 // applyCore runs the shared upsert pipeline on a single
 // already-decoded, metadata-stamped object:
 //
-//	canonicalize metadata → authorize → validate → resolve refs →
-//	validate registries → prepare → admission
+//	canonicalize metadata → authorize → namespace → tag policy →
+//	approval policy → validate →
+//	resolve refs → validate registries → prepare → signature → admission
 //
 // The admission implementation owns the final write result. The OSS default
 // ProductionAdmission maps dry-runs to ApplyStatusDryRun and real writes to
@@ -102,6 +130,19 @@ func applyCore(
 		}
 	}
 
+	if ae := checkNamespace(ctx, obj, opts); ae != nil {
+		return types.AdmissionResult{}, ae
+	}
+
+	immutableTag, ae := checkTagPolicy(ctx, obj, opts)
+	if ae != nil {
+		return types.AdmissionResult{}, ae
+	}
+	requestApproval, ae := checkApprovalPolicy(ctx, store, obj, opts)
+	if ae != nil {
+		return types.AdmissionResult{}, ae
+	}
+
 	if err := v1alpha1.ValidateObject(obj); err != nil {
 		return types.AdmissionResult{}, &applyError{Stage: stageValidation, Err: err}
 	}
@@ -127,6 +168,10 @@ func applyCore(
 		}
 	}
 
+	if ae := checkSignature(ctx, obj, opts); ae != nil {
+		return types.AdmissionResult{}, ae
+	}
+
 	source := opts.Source
 	if source == "" {
 		source = types.AdmissionSourceApply
@@ -147,6 +192,10 @@ func applyCore(
 		Store:             store,
 		PostUpsert:        opts.PostUpsert,
 		InitialFinalizers: opts.InitialFinalizers,
+
+		ImmutableTag:          immutableTag,
+		OverwriteImmutableTag: immutableTag && opts.OverwriteImmutableTags,
+		RequestApproval:       requestApproval,
 	})
 	if err != nil {
 		if ae, ok := err.(*applyError); ok {
@@ -157,19 +206,122 @@ func applyCore(
 	return result, nil
 }
 
+// checkNamespace refuses objects bound for a namespace no Namespace object
+// declares. The default namespace always exists, and Namespace objects
+// themselves live in it.
+func checkNamespace(ctx context.Context, obj v1alpha1.Object, opts applyOpts) *applyError {
+	namespace := obj.GetMetadata().Namespace
+	if opts.NamespaceExists == nil || obj.GetKind() == v1alpha1.KindNamespace ||
+		namespace == "" || namespace == v1alpha1.DefaultNamespace {
+		return nil
+	}
+	exists, err := opts.NamespaceExists(ctx, namespace)
+	if err != nil {
+		return &applyError{Stage: stageNamespace, Err: err}
+	}
+	if !exists {
+		return &applyError{Stage: stageNamespace, NotFound: true, Err: fmt.Errorf(
+			"namespace %q does not exist; apply a Namespace object named %q first", namespace, namespace)}
+	}
+	return nil
+}
+
+// checkTagPolicy reports whether the tag policy protects obj's tag and,
+// when the caller asked to override the policy for it, authorizes the
+// override. Nil AuthorizeTagOverwrite refuses every override.
+func checkTagPolicy(ctx context.Context, obj v1alpha1.Object, opts applyOpts) (bool, *applyError) {
+	kind := obj.GetKind()
+	if opts.TagPolicy == nil || !v1alpha1.IsTaggedArtifactKind(kind) {
+		return false, nil
+	}
+	meta := obj.GetMetadata()
+	policy, err := opts.TagPolicy(ctx, kind, meta.Namespace)
+	if err != nil {
+		return false, &applyError{Stage: stageTagPolicy, Err: err}
+	}
+	immutable := policy.IsImmutable(kind, meta.Tag)
+	if !immutable || !opts.OverwriteImmutableTags {
+		return immutable, nil
+	}
+	if opts.AuthorizeTagOverwrite == nil {
+		return false, &applyError{Stage: stageAuth, Err: huma.Error403Forbidden("overwriting immutable tags is not enabled on this registry")}
+	}
+	if err := opts.AuthorizeTagOverwrite(ctx, AuthorizeInput{
+		Verb: "overwrite-tag", Kind: kind,
+		Namespace: meta.Namespace, Name: meta.Name, Tag: meta.Tag,
+		Object: obj,
+	}); err != nil {
+		return false, &applyError{Stage: stageAuth, Err: err}
+	}
+	return true, nil
+}
+
+// checkApprovalPolicy reports whether the approval policy holds obj's
+// content for review. Content the policy covers cannot be stored where no
+// review could release it, so a store without approvals is an error.
+func checkApprovalPolicy(ctx context.Context, store *v1alpha1store.Store, obj v1alpha1.Object, opts applyOpts) (bool, *applyError) {
+	kind := obj.GetKind()
+	if opts.ApprovalPolicy == nil || !v1alpha1.IsTaggedArtifactKind(kind) {
+		return false, nil
+	}
+	required, err := opts.ApprovalPolicy(ctx, kind, obj.GetMetadata().Namespace)
+	if err != nil {
+		return false, &applyError{Stage: stageApproval, Err: err}
+	}
+	if required && !store.Approvals() {
+		return false, &applyError{Stage: stageApproval, Err: fmt.Errorf("%w: %s keeps no approvals", v1alpha1store.ErrApprovalsDisabled, kind)}
+	}
+	return required, nil
+}
+
+// checkSignature refuses tagged content the signature policy requires a
+// trusted signature for and that has none. It runs after Prepare, on the
+// content admission would store, and on dry runs too so a dry run reports
+// what a real apply would do.
+func checkSignature(ctx context.Context, obj v1alpha1.Object, opts applyOpts) *applyError {
+	kind := obj.GetKind()
+	if opts.VerifySignature == nil || !v1alpha1.IsTaggedArtifactKind(kind) {
+		return nil
+	}
+	digest, err := v1alpha1store.ObjectDigest(obj)
+	if err != nil {
+		return &applyError{Stage: stageMarshal, Err: err}
+	}
+	meta := obj.GetMetadata()
+	if err := opts.VerifySignature(ctx, kind, meta.Namespace, meta.Name, digest); err != nil {
+		return &applyError{Stage: stageSignature, Err: err, Unsigned: errors.Is(err, v1alpha1store.ErrUnsigned)}
+	}
+	return nil
+}
+
 // ProductionAdmission is the OSS admission implementation: dry-runs stop after
 // validation, and real writes upsert the object into the production store and
-// run the per-kind post-upsert hook.
+// run the per-kind post-upsert hook. Content the approval policy covers is
+// put up for review by the upsert itself.
 func ProductionAdmission(ctx context.Context, in types.AdmissionInput) (types.AdmissionResult, error) {
 	if in.DryRun {
-		return types.AdmissionResult{Status: arv0.ApplyStatusDryRun, Tag: in.Tag}, nil
+		result := types.AdmissionResult{Status: arv0.ApplyStatusDryRun, Tag: in.Tag}
+		if in.RequestApproval {
+			store, _ := in.Store.(*v1alpha1store.Store)
+			state, err := dryRunApprovalState(ctx, store, in.Object)
+			if err != nil {
+				return types.AdmissionResult{}, &applyError{Stage: stageApproval, Err: err}
+			}
+			result.ApprovalState = state
+		}
+		return result, nil
 	}
 	store, ok := in.Store.(*v1alpha1store.Store)
 	if !ok || store == nil {
 		return types.AdmissionResult{}, errors.New("production store is required")
 	}
 
-	upsertOpts := v1alpha1store.UpsertOpts{}
+	upsertOpts := v1alpha1store.UpsertOpts{
+		ImmutableTag:          in.ImmutableTag,
+		OverwriteImmutableTag: in.OverwriteImmutableTag,
+		RequestApproval:       in.RequestApproval,
+		RequestedBy:           audit.Principal(ctx),
+	}
 	if in.InitialFinalizers != nil {
 		upsertOpts.InitialFinalizers = in.InitialFinalizers(in.Object)
 	}
@@ -179,13 +331,24 @@ func ProductionAdmission(ctx context.Context, in types.AdmissionInput) (types.Ad
 			Stage:       stageUpsert,
 			Err:         err,
 			Terminating: errors.Is(err, v1alpha1store.ErrTerminating),
+			Conflict:    errors.Is(err, v1alpha1store.ErrResourceVersionConflict),
+			Immutable:   errors.Is(err, v1alpha1store.ErrTagImmutable),
 		}
 	}
+	if up.Outcome == v1alpha1store.UpsertReplaced && in.ImmutableTag {
+		slog.WarnContext(ctx, "immutable tag overwritten by admin override",
+			"kind", in.Kind,
+			"namespace", in.Namespace,
+			"name", in.Name,
+			"tag", up.Tag,
+		)
+	}
 
 	if in.PostUpsert != nil {
 		meta := in.Object.GetMetadata()
 		meta.Generation = up.Generation
 		meta.UID = up.UID
+		meta.ResourceVersion = up.ResourceVersion
 		in.Object.SetMetadata(*meta)
 		if err := in.PostUpsert(ctx, in.Object); err != nil {
 			return types.AdmissionResult{}, &applyError{Stage: stagePostUpsert, Err: err}
@@ -193,9 +356,11 @@ func ProductionAdmission(ctx context.Context, in types.AdmissionInput) (types.Ad
 	}
 
 	return types.AdmissionResult{
-		Status:     applyStatusFromUpsert(up.Outcome),
-		Tag:        up.Tag,
-		Generation: up.Generation,
+		Status:          applyStatusFromUpsert(up.Outcome),
+		Tag:             up.Tag,
+		Generation:      up.Generation,
+		ResourceVersion: up.ResourceVersion,
+		ApprovalState:   up.ApprovalState,
 	}, nil
 }
 
@@ -216,13 +381,18 @@ func applyStatusFromUpsert(outcome v1alpha1store.UpsertOutcome) string {
 // applyOpts, every field is optional. PreDeleteObject is the object
 // passed to PostDelete; callers fill it from a fresh Store.Get
 // (handler.go DELETE) or from the decoded YAML body (apply.go batch
-// delete). When PostDelete is nil, PreDeleteObject is unused.
+// delete). When PostDelete is nil, PreDeleteObject is unused. Referrers
+// and Force feed the admission's referrer check; ReferrerAuthorizers
+// redact the referrers a refusal names.
 type deleteOpts struct {
-	Authorize       func(ctx context.Context, in AuthorizeInput) error
-	PostDelete      func(ctx context.Context, obj v1alpha1.Object) error
-	PreDeleteObject v1alpha1.Object
-	DeleteAdmission types.DeleteAdmission
-	Source          string
+	Authorize           func(ctx context.Context, in AuthorizeInput) error
+	PostDelete          func(ctx context.Context, obj v1alpha1.Object) error
+	PreDeleteObject     v1alpha1.Object
+	DeleteAdmission     types.DeleteAdmission
+	Source              string
+	Referrers           types.ReferrerLookup
+	ReferrerAuthorizers map[string]func(ctx context.Context, in AuthorizeInput) error
+	Force               bool
 }
 
 // deleteCore runs Authorize → delete admission for a single resource.
@@ -268,9 +438,16 @@ func deleteCore(
 		Object:     opts.PreDeleteObject,
 		Store:      store,
 		PostDelete: opts.PostDelete,
+		Referrers:  opts.Referrers,
+		Force:      opts.Force,
 	})
 	if err != nil {
 		if ae, ok := err.(*applyError); ok {
+			// The refusal must not name referrers the caller cannot read.
+			var referenced *ReferencedError
+			if ae.Referenced && errors.As(ae.Err, &referenced) {
+				referenced.redact(ctx, opts.ReferrerAuthorizers)
+			}
 			return types.DeleteAdmissionResult{}, ae
 		}
 		return types.DeleteAdmissionResult{}, &applyError{Stage: stageAdmission, Err: err}
@@ -279,9 +456,25 @@ func deleteCore(
 }
 
 // ProductionDeleteAdmission is the OSS delete admission implementation. It
-// removes the selected production row(s) and then runs the per-kind post-delete
-// hook when present.
+// refuses to delete a target that live objects still reference unless the
+// caller forces it, then removes the selected production row(s) and runs the
+// per-kind post-delete hook when present. The referrer check also runs on
+// dry runs so they report the refusal.
 func ProductionDeleteAdmission(ctx context.Context, in types.DeleteAdmissionInput) (types.DeleteAdmissionResult, error) {
+	if in.Referrers != nil && !in.Force {
+		target := v1alpha1.ResourceRef{Kind: in.Kind, Namespace: in.Namespace, Name: in.Name, Tag: in.Tag}
+		referrers, err := in.Referrers(ctx, target)
+		if err != nil {
+			return types.DeleteAdmissionResult{}, &applyError{Stage: stageDelete, Err: fmt.Errorf("find referrers: %w", err)}
+		}
+		if len(referrers) > 0 {
+			return types.DeleteAdmissionResult{}, &applyError{
+				Stage:      stageDelete,
+				Err:        &ReferencedError{Target: target, Referrers: referrers},
+				Referenced: true,
+			}
+		}
+	}
 	if in.DryRun {
 		return types.DeleteAdmissionResult{Status: arv0.ApplyStatusDryRun, Tag: in.Tag}, nil
 	}
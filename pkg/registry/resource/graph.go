@@ -0,0 +1,284 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// maxGraphNodes bounds one graph walk. Closures are normally a handful of
+// nodes; the limit only stops a pathological fan-out from turning one GET
+// into thousands of Store reads.
+const maxGraphNodes = 500
+
+// GraphConfig wires the dependency graph endpoint.
+type GraphConfig struct {
+	// BasePrefix is the HTTP route prefix shared with the generic resource
+	// handler (e.g. "/v0"). The endpoint mounts at "{BasePrefix}/graph".
+	BasePrefix string
+	// Stores maps Kind to its Store. It resolves the root kind and finds
+	// terminating rows the Getter no longer returns.
+	Stores map[string]*v1alpha1store.Store
+	// Getter fetches every node. It should be the GetterFunc reconcilers
+	// use, so the graph resolves refs exactly as they do.
+	Getter v1alpha1.GetterFunc
+	// Authorizers are the per-kind hooks the get endpoints consult
+	// (Verb="get"). A rejected root fails the request; a rejected
+	// dependency is reported as a Forbidden node and not followed.
+	Authorizers map[string]func(ctx context.Context, in AuthorizeInput) error
+}
+
+type graphInput struct {
+	Root      string `query:"root" required:"true" doc:"Root resource as Kind/name or Kind/namespace/name, with an optional :tag (e.g. Agent/planner:1.2.0). Kind may be a route plural."`
+	Namespace string `query:"namespace" doc:"Namespace of the root when it is not part of root (defaults to 'default')."`
+}
+
+type graphOutput struct {
+	Body arv0.GraphResponse
+}
+
+// RegisterGraph wires GET {BasePrefix}/graph: the closure of every
+// ResourceRef reachable from a root object, with each ref resolved to the
+// tag it selects today, dangling and terminating refs marked, and ref
+// cycles listed.
+func RegisterGraph(api huma.API, cfg GraphConfig) {
+	huma.Register(api, huma.Operation{
+		OperationID: "graph",
+		Method:      http.MethodGet,
+		Path:        cfg.BasePrefix + "/graph",
+		Summary:     "Resolve the dependency graph of a resource",
+	}, func(ctx context.Context, in *graphInput) (*graphOutput, error) {
+		root, err := parseGraphRoot(cfg.Stores, in.Root, in.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := BuildGraph(ctx, cfg, root)
+		if err != nil {
+			return nil, err
+		}
+		return &graphOutput{Body: *resp}, nil
+	})
+}
+
+// parseGraphRoot parses "Kind/name[:tag]" or "Kind/namespace/name[:tag]".
+func parseGraphRoot(stores map[string]*v1alpha1store.Store, raw, namespace string) (v1alpha1.ResourceRef, error) {
+	ref, tag, _ := strings.Cut(strings.TrimSpace(raw), ":")
+	parts := strings.Split(ref, "/")
+	var ns, name string
+	switch len(parts) {
+	case 2:
+		name = parts[1]
+	case 3:
+		ns, name = parts[1], parts[2]
+		if namespace != "" && namespace != ns {
+			return v1alpha1.ResourceRef{}, huma.Error400BadRequest(fmt.Sprintf("root namespace %q conflicts with namespace %q", ns, namespace))
+		}
+		namespace = ns
+	default:
+		return v1alpha1.ResourceRef{}, huma.Error400BadRequest(fmt.Sprintf("root %q must be Kind/name or Kind/namespace/name", raw))
+	}
+	kind, ok := lookupSearchKind(stores, parts[0])
+	if !ok {
+		return v1alpha1.ResourceRef{}, huma.Error400BadRequest(fmt.Sprintf("unknown kind %q", parts[0]))
+	}
+	if name == "" {
+		return v1alpha1.ResourceRef{}, huma.Error400BadRequest(fmt.Sprintf("root %q has no name", raw))
+	}
+	if tag != "" && !v1alpha1.IsTaggedArtifactKind(kind) {
+		return v1alpha1.ResourceRef{}, huma.Error400BadRequest(fmt.Sprintf("%s is not tagged", kind))
+	}
+	return v1alpha1.ResourceRef{Kind: kind, Namespace: resolveNamespace(namespace, false), Name: name, Tag: tag}, nil
+}
+
+// BuildGraph walks the refs reachable from root depth-first, in the order
+// each object's ResolveRefs reports them. Errors are huma errors: the
+// root Authorizer's own error, 404 when the root does not resolve, 500 for
+// store failures.
+func BuildGraph(ctx context.Context, cfg GraphConfig, root v1alpha1.ResourceRef) (*arv0.GraphResponse, error) {
+	if authorize := cfg.Authorizers[root.Kind]; authorize != nil {
+		if err := authorize(ctx, AuthorizeInput{Verb: "get", Kind: root.Kind, Namespace: root.Namespace, Name: root.Name, Tag: root.Tag}); err != nil {
+			return nil, err
+		}
+	}
+	w := &graphWalker{
+		cfg:     cfg,
+		resp:    &arv0.GraphResponse{Nodes: []arv0.GraphNode{}, Edges: []arv0.GraphEdge{}},
+		nodes:   map[string]bool{},
+		objects: map[string]v1alpha1.Object{},
+		state:   map[string]int{},
+		edges:   map[arv0.GraphEdge]bool{},
+	}
+	id, err := w.node(ctx, root, true)
+	if err != nil {
+		return nil, err
+	}
+	w.resp.Root = id
+	if w.objects[id] == nil {
+		return nil, huma.Error404NotFound(fmt.Sprintf("%s not found", formatRef(root)))
+	}
+	if err := w.walk(ctx, id); err != nil {
+		return nil, err
+	}
+	return w.resp, nil
+}
+
+const (
+	graphUnvisited = iota
+	graphOnStack
+	graphDone
+)
+
+type graphWalker struct {
+	cfg  GraphConfig
+	resp *arv0.GraphResponse
+	// nodes holds every node ID in resp.Nodes; objects holds the fetched
+	// object of each Resolved or Terminating node until it is walked.
+	nodes   map[string]bool
+	objects map[string]v1alpha1.Object
+	state   map[string]int
+	stack   []string
+	edges   map[arv0.GraphEdge]bool
+}
+
+func (w *graphWalker) walk(ctx context.Context, id string) error {
+	obj := w.objects[id]
+	delete(w.objects, id)
+	w.state[id] = graphOnStack
+	w.stack = append(w.stack, id)
+
+	var refs []v1alpha1.ResourceRef
+	_ = v1alpha1.ResolveObjectRefs(ctx, obj, func(_ context.Context, ref v1alpha1.ResourceRef) error {
+		refs = append(refs, ref)
+		return nil
+	})
+	for _, ref := range refs {
+		child, err := w.node(ctx, ref, false)
+		if err != nil {
+			return err
+		}
+		if child == "" {
+			continue
+		}
+		edge := arv0.GraphEdge{From: id, To: child, Selector: ref.Tag}
+		if !w.edges[edge] {
+			w.edges[edge] = true
+			w.resp.Edges = append(w.resp.Edges, edge)
+		}
+		switch w.state[child] {
+		case graphOnStack:
+			for i, on := range w.stack {
+				if on == child {
+					w.resp.Cycles = append(w.resp.Cycles, append(append([]string{}, w.stack[i:]...), child))
+					break
+				}
+			}
+		case graphUnvisited:
+			if w.objects[child] != nil {
+				if err := w.walk(ctx, child); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	w.stack = w.stack[:len(w.stack)-1]
+	w.state[id] = graphDone
+	return nil
+}
+
+// node resolves ref and records it in the response, returning its ID. It
+// returns "" when the node limit stops the walk.
+func (w *graphWalker) node(ctx context.Context, ref v1alpha1.ResourceRef, root bool) (string, error) {
+	n := arv0.GraphNode{Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name, Tag: ref.Tag}
+	if n.Tag == "" && v1alpha1.IsTaggedArtifactKind(ref.Kind) {
+		n.Tag = v1alpha1store.DefaultTag()
+	}
+	var obj v1alpha1.Object
+	switch authorize := w.cfg.Authorizers[ref.Kind]; {
+	case !root && authorize != nil && authorize(ctx, AuthorizeInput{Verb: "get", Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name, Tag: ref.Tag}) != nil:
+		n.Status = arv0.GraphNodeForbidden
+		n.Message = "not authorized to read " + ref.Kind
+	default:
+		var err error
+		obj, err = w.fetch(ctx, ref)
+		switch {
+		case err == nil && obj.GetMetadata().DeletionTimestamp != nil:
+			n.Status = arv0.GraphNodeTerminating
+			n.Tag = obj.GetMetadata().Tag
+		case err == nil:
+			n.Status = arv0.GraphNodeResolved
+			n.Tag = obj.GetMetadata().Tag
+		case errors.Is(err, v1alpha1store.ErrUnapproved):
+			n.Status = arv0.GraphNodeMissing
+			n.Message = err.Error()
+		case errors.Is(err, v1alpha1.ErrDanglingRef):
+			n.Status = arv0.GraphNodeMissing
+			n.Message = "no live object matches the ref"
+		case errors.Is(err, v1alpha1.ErrInvalidRef):
+			n.Status = arv0.GraphNodeMissing
+			n.Message = err.Error()
+		default:
+			return "", huma.Error500InternalServerError("resolve "+formatRef(ref), err)
+		}
+	}
+
+	n.ID = graphNodeID(n)
+	if w.nodes[n.ID] {
+		return n.ID, nil
+	}
+	if len(w.resp.Nodes) >= maxGraphNodes {
+		w.resp.Truncated = true
+		return "", nil
+	}
+	w.nodes[n.ID] = true
+	w.resp.Nodes = append(w.resp.Nodes, n)
+	if obj != nil {
+		w.objects[n.ID] = obj
+	}
+	return n.ID, nil
+}
+
+// fetch reads ref through the Getter. The Getter skips terminating rows,
+// so a dangling blank-tag ref falls back to the Store to tell "being
+// deleted" from "gone".
+func (w *graphWalker) fetch(ctx context.Context, ref v1alpha1.ResourceRef) (v1alpha1.Object, error) {
+	obj, err := w.cfg.Getter(ctx, ref)
+	if !errors.Is(err, v1alpha1.ErrDanglingRef) || ref.Tag != "" {
+		return obj, err
+	}
+	store := w.cfg.Stores[ref.Kind]
+	_, newAny, ok := v1alpha1.Default.Lookup(ref.Kind)
+	if store == nil || !ok {
+		return nil, err
+	}
+	raw, getErr := store.GetLatestIncludingTerminating(ctx, ref.Namespace, ref.Name)
+	if errors.Is(getErr, pkgdb.ErrNotFound) {
+		return nil, err
+	}
+	if getErr != nil {
+		return nil, getErr
+	}
+	if raw.Metadata.DeletionTimestamp == nil {
+		return nil, err
+	}
+	return v1alpha1.EnvelopeFromRaw(func() v1alpha1.Object { return newAny().(v1alpha1.Object) }, raw, ref.Kind)
+}
+
+// graphNodeID renders "Kind/namespace/name" with an "@tag" suffix when the
+// node names a tag.
+func graphNodeID(n arv0.GraphNode) string {
+	id := n.Kind + "/" + n.Namespace + "/" + n.Name
+	if n.Tag != "" {
+		id += "@" + n.Tag
+	}
+	return id
+}
@@ -0,0 +1,130 @@
+//go:build integration
+
+package resource_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/require"
+
+	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+func TestRegisterGraph_ResolvesClosure(t *testing.T) {
+	db := v1alpha1store.NewTestDB(t)
+	agents := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
+	servers := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "mcp_servers")
+	skills := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "skills")
+	deployments := v1alpha1store.NewMutableObjectStore(db, v1alpha1store.TestSchema(), "deployments")
+	ctx := t.Context()
+
+	for _, tag := range []string{"1.2.0", "1.3.0"} {
+		_, err := servers.Upsert(ctx, &v1alpha1.MCPServer{
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "acme-fetch", Tag: tag},
+			Spec:     v1alpha1.MCPServerSpec{Title: "Fetch"},
+		})
+		require.NoError(t, err)
+	}
+	_, err := agents.Upsert(ctx, &v1alpha1.Agent{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "planner"},
+		Spec: v1alpha1.AgentSpec{
+			Title:      "Planner",
+			MCPServers: []v1alpha1.ResourceRef{{Name: "acme-fetch", Tag: "^1.2"}},
+			Skills:     []v1alpha1.ResourceRef{{Name: "summarize"}},
+		},
+	})
+	require.NoError(t, err)
+	// Deployments "web" and "worker" bind each other; "worker" is
+	// terminating, held by a finalizer.
+	for _, d := range []struct{ name, peer string }{{"web", "worker"}, {"worker", "web"}} {
+		_, err := deployments.Upsert(ctx, &v1alpha1.Deployment{
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: d.name},
+			Spec: v1alpha1.DeploymentSpec{
+				TargetRef:      v1alpha1.ResourceRef{Kind: v1alpha1.KindAgent, Name: "planner"},
+				RuntimeRef:     v1alpha1.ResourceRef{Kind: v1alpha1.KindRuntime, Name: "local"},
+				DeploymentRefs: []v1alpha1.DeploymentRef{{Name: d.peer}},
+			},
+		}, v1alpha1store.UpsertOpts{InitialFinalizers: []string{"test/hold"}})
+		require.NoError(t, err)
+	}
+	require.NoError(t, deployments.Delete(ctx, "default", "worker", ""))
+
+	stores := map[string]*v1alpha1store.Store{
+		v1alpha1.KindAgent:      agents,
+		v1alpha1.KindMCPServer:  servers,
+		v1alpha1.KindSkill:      skills,
+		v1alpha1.KindDeployment: deployments,
+	}
+	cfg := resource.GraphConfig{BasePrefix: "/v0", Stores: stores, Getter: internaldb.NewGetter(stores)}
+	_, api := humatest.New(t)
+	resource.RegisterGraph(api, cfg)
+
+	graph := func(root string) arv0.GraphResponse {
+		t.Helper()
+		resp := api.Get("/v0/graph?root=" + url.QueryEscape(root))
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+		var out arv0.GraphResponse
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &out))
+		return out
+	}
+	status := func(g arv0.GraphResponse) map[string]string {
+		out := map[string]string{}
+		for _, n := range g.Nodes {
+			out[n.ID] = n.Status
+		}
+		return out
+	}
+
+	out := graph("agents/planner")
+	require.Equal(t, "Agent/default/planner@latest", out.Root)
+	require.Equal(t, map[string]string{
+		"Agent/default/planner@latest":       arv0.GraphNodeResolved,
+		"MCPServer/default/acme-fetch@1.3.0": arv0.GraphNodeResolved,
+		"Skill/default/summarize@latest":     arv0.GraphNodeMissing,
+	}, status(out))
+	require.Contains(t, out.Edges, arv0.GraphEdge{From: out.Root, To: "MCPServer/default/acme-fetch@1.3.0", Selector: "^1.2"})
+	require.Empty(t, out.Cycles)
+
+	out = graph("Deployment/default/web")
+	require.Equal(t, map[string]string{
+		"Deployment/default/web":             arv0.GraphNodeResolved,
+		"Deployment/default/worker":          arv0.GraphNodeTerminating,
+		"Agent/default/planner@latest":       arv0.GraphNodeResolved,
+		"MCPServer/default/acme-fetch@1.3.0": arv0.GraphNodeResolved,
+		"Skill/default/summarize@latest":     arv0.GraphNodeMissing,
+		"Runtime/default/local":              arv0.GraphNodeMissing,
+	}, status(out))
+	require.Equal(t, [][]string{{"Deployment/default/web", "Deployment/default/worker", "Deployment/default/web"}}, out.Cycles)
+
+	resp := api.Get("/v0/graph?root=Agent/missing")
+	require.Equal(t, http.StatusNotFound, resp.Code, resp.Body.String())
+	resp = api.Get("/v0/graph?root=Deployment/web:1.0.0")
+	require.Equal(t, http.StatusBadRequest, resp.Code, resp.Body.String())
+	resp = api.Get("/v0/graph?root=Widget/web")
+	require.Equal(t, http.StatusBadRequest, resp.Code, resp.Body.String())
+
+	// Dependencies the caller may not read are reported, not followed.
+	cfg.Authorizers = map[string]func(context.Context, resource.AuthorizeInput) error{
+		v1alpha1.KindMCPServer: func(context.Context, resource.AuthorizeInput) error {
+			return huma.Error403Forbidden("no servers for you")
+		},
+	}
+	got, err := resource.BuildGraph(ctx, cfg, v1alpha1.ResourceRef{Kind: v1alpha1.KindAgent, Namespace: "default", Name: "planner"})
+	require.NoError(t, err)
+	require.Equal(t, arv0.GraphNodeForbidden, status(*got)["MCPServer/default/acme-fetch@^1.2"])
+
+	_, err = resource.BuildGraph(ctx, cfg, v1alpha1.ResourceRef{Kind: v1alpha1.KindMCPServer, Namespace: "default", Name: "acme-fetch"})
+	var se huma.StatusError
+	require.ErrorAs(t, err, &se)
+	require.Equal(t, http.StatusForbidden, se.GetStatus())
+}
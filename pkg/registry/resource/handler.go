@@ -6,13 +6,19 @@
 // `?namespace=all` widens list scope to every namespace):
 //
 //	GET    {basePrefix}/{pluralKind}?namespace={ns}                   list
+//	GET    {basePrefix}/{pluralKind}?watch=true&resourceVersion={rv}  watch (see watch.go)
 //	GET    {basePrefix}/{pluralKind}/{name}?namespace={ns}            get latest
 //	GET    {basePrefix}/{pluralKind}/{name}/tags?namespace={ns}      list tags of one (tagged content kinds only)
+//	GET    {basePrefix}/{pluralKind}/{name}/referrers?namespace={ns}  objects referencing one (see referrers.go)
 //	GET    {basePrefix}/{pluralKind}/{name}/{tag}?namespace={ns}     get exact tag (tagged content kinds only)
+//	GET    {basePrefix}/{pluralKind}/{name}/{tag}/revisions?namespace={ns}  revision history of a tag (tagged content kinds only; see revisions.go)
 //	PUT    {basePrefix}/{pluralKind}/{name}?namespace={ns}           apply mutable object (Provider/Deployment/config)
 //	DELETE {basePrefix}/{pluralKind}/{name}?namespace={ns}           delete mutable object
 //	DELETE {basePrefix}/{pluralKind}/{name}/{tag}?namespace={ns}     delete exact tag (tagged content kinds only)
 //
+// When Config.Referrers is set, deletes are refused with 409 while other
+// live objects reference the target, unless ?force=true.
+//
 // Direct PUT is registered only for mutable object stores. Content-registry
 // artifact kinds (Agent, MCPServer, Model, Plugin, Skill, Prompt) use
 // metadata.tag and are
@@ -26,6 +32,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
@@ -120,11 +127,28 @@ type Config struct {
 	// write and surface to the caller.
 	Prepare func(ctx context.Context, obj v1alpha1.Object) error
 
+	// NamespaceExists is optional; when set, applies into a namespace it
+	// reports missing fail with 404, so objects only land in namespaces a
+	// Namespace object declares. The default namespace and Namespace
+	// objects themselves are never checked. Nil accepts any namespace.
+	NamespaceExists func(ctx context.Context, namespace string) (bool, error)
+
 	// DeleteAdmission optionally owns the final delete after authz. Nil uses
 	// ProductionDeleteAdmission, which deletes from the configured Store and
 	// runs PostDelete.
 	DeleteAdmission types.DeleteAdmission
 
+	// Referrers is optional; when set, deletes are refused while other
+	// live objects reference the target (unless ?force=true), and
+	// GET {name}/referrers lists them. See NewReferrerLookup.
+	Referrers types.ReferrerLookup
+
+	// ReferrerAuthorizers are the per-kind hooks the get endpoints consult
+	// (Verb="get"). Referrers the caller may not read are counted instead
+	// of named, both in GET {name}/referrers and in the 409 a referenced
+	// delete returns. Missing keys allow.
+	ReferrerAuthorizers map[string]func(ctx context.Context, in AuthorizeInput) error
+
 	// InitialFinalizers, when non-nil, seeds finalizers atomically on create.
 	// Updates preserve existing finalizers.
 	InitialFinalizers func(obj v1alpha1.Object) []string
@@ -182,6 +206,12 @@ type Config struct {
 	// the caller can still force inclusion but never exclusion when
 	// the kind has opted in.
 	IncludeTerminatingByDefault bool
+
+	// Watch is optional; when set, the list route also serves
+	// ?watch=true change streams replayed from the control-plane event
+	// log, and list responses carry the resourceVersion to watch from.
+	// nil rejects watch requests with 501.
+	Watch *WatchConfig
 }
 
 // AuthorizeInput is the context passed to Config.Authorize on every handler
@@ -190,7 +220,9 @@ type Config struct {
 // in future releases — callers should use named-field initialization and
 // tolerate unknown verbs by defaulting to deny.
 type AuthorizeInput struct {
-	// Verb is "get" | "list" | "apply" | "delete".
+	// Verb is "get" | "list" | "apply" | "delete" | "sign" | "approve" |
+	// "deprecate" | "yank", or "overwrite-tag" for
+	// ApplyConfig.AuthorizeTagOverwrite.
 	Verb string
 	// Kind is the canonical Kind the handler is serving (e.g. "Role").
 	Kind string
@@ -202,7 +234,7 @@ type AuthorizeInput struct {
 	// Tag is populated for exact tagged content resource operations.
 	// Batch delete leaves Tag empty when deleting every tag for a name.
 	Tag string
-	// Object is non-nil only when Verb == "apply"; it carries the decoded
+	// Object is non-nil only when Verb is "apply" or "overwrite-tag"; it carries the decoded
 	// request body post-validation-stamping (path identity already merged
 	// into metadata), so the hook can inspect labels / annotations / spec
 	// in authz decisions.
@@ -211,9 +243,8 @@ type AuthorizeInput struct {
 
 // Input/output wire types. Registered per-kind so OpenAPI schemas stay typed.
 //
-// Namespace is a `query:"namespace"` param (hidden from the user-facing
-// API while the surface stays minimal; empty → "default", "all" → list
-// across every namespace). Defaulting happens in resolveNamespace below
+// Namespace is a `query:"namespace"` param (empty → "default", "all" →
+// list across every namespace). Defaulting happens in resolveNamespace below
 // so every endpoint sees the same semantics.
 
 // namespaceAll is the query-param sentinel that asks the list endpoint
@@ -237,30 +268,32 @@ func resolveNamespace(raw string, allowAll bool) string {
 }
 
 type getInput struct {
-	Namespace string `query:"namespace" doc:"Namespace (internal; defaults to 'default')."`
+	Namespace string `query:"namespace" doc:"Namespace (defaults to 'default')."`
 	Name      string `path:"name"`
 	Tag       string `path:"tag"`
 }
 
 type getLatestInput struct {
-	Namespace string `query:"namespace" doc:"Namespace (internal; defaults to 'default')."`
+	Namespace string `query:"namespace" doc:"Namespace (defaults to 'default')."`
 	Name      string `path:"name"`
 }
 
 type listTagsInput struct {
-	Namespace string `query:"namespace" doc:"Namespace (internal; defaults to 'default')."`
+	Namespace string `query:"namespace" doc:"Namespace (defaults to 'default')."`
 	Name      string `path:"name"`
 }
 
 type deleteInput struct {
-	Namespace string `query:"namespace" doc:"Namespace (internal; defaults to 'default')."`
+	Namespace string `query:"namespace" doc:"Namespace (defaults to 'default')."`
 	Name      string `path:"name"`
 	Tag       string `path:"tag"`
+	Force     bool   `query:"force" doc:"Delete even though other objects reference the resource. Defaults to false."`
 }
 
 type deleteMutableInput struct {
-	Namespace string `query:"namespace" doc:"Namespace (internal; defaults to 'default')."`
+	Namespace string `query:"namespace" doc:"Namespace (defaults to 'default')."`
 	Name      string `path:"name"`
+	Force     bool   `query:"force" doc:"Delete even though other objects reference the resource. Defaults to false."`
 }
 
 // ListInput defines the common list query parameters used by Huma route inputs.
@@ -268,15 +301,23 @@ type deleteMutableInput struct {
 type ListInput struct {
 	// Namespace scopes the list. Empty / missing → "default";
 	// literal "all" → cross-namespace.
-	Namespace  string `query:"namespace" doc:"Namespace (defaults to 'default'; 'all' lists across all namespaces)."`
-	Limit      int    `query:"limit" doc:"Max items to return (default 50)." default:"50"`
-	Cursor     string `query:"cursor" doc:"Opaque pagination cursor."`
-	Labels     string `query:"labels" doc:"Label selector: key=value,key2=value2."`
-	Tag        string `query:"tag" doc:"Restrict the result set to one tag value (tagged artifact kinds only)."`
-	LatestOnly bool   `query:"latestOnly" doc:"Only return the literal latest tag per (namespace, name). Equivalent to tag=latest for tagged kinds."`
+	Namespace string `query:"namespace" doc:"Namespace (defaults to 'default'; 'all' lists across all namespaces)."`
+	Limit     int    `query:"limit" doc:"Max items to return (default 50)." default:"50"`
+	Cursor    string `query:"cursor" doc:"Opaque pagination cursor."`
+	Labels    string `query:"labels" doc:"Label selector: key=value, key!=value, key in (a,b), key notin (a,b), key and !key, comma-separated."`
+	// FieldSelector filters on spec, status and identity fields; see
+	// v1alpha1.ParseFieldSelector.
+	FieldSelector string `query:"fieldSelector" doc:"Field selector: path=value or path!=value, comma-separated. Paths start with spec, status or metadata (name, namespace, tag), e.g. spec.source.package.origin.type=npm or status.conditions[Ready]=True."`
+	Tag           string `query:"tag" doc:"Restrict the result set to one tag value (tagged artifact kinds only)."`
+	LatestOnly    bool   `query:"latestOnly" doc:"Only return the literal latest tag per (namespace, name). Equivalent to tag=latest for tagged kinds."`
 	// IncludeTerminating surfaces soft-deleted rows (deletionTimestamp != nil)
 	// which are hidden by default.
 	IncludeTerminating bool `query:"includeTerminating" doc:"Include rows with a deletionTimestamp."`
+	// Watch and ResourceVersion are served by the watch middleware before
+	// the list handler runs; they are declared here so OpenAPI documents
+	// them.
+	Watch           bool   `query:"watch" doc:"Stream ADDED/MODIFIED/DELETED/BOOKMARK events instead of returning a page. Newline-delimited JSON, or server-sent events when Accept is text/event-stream."`
+	ResourceVersion string `query:"resourceVersion" doc:"With watch=true, stream changes after this revision (from a list response or a previous event). Omit to start with an ADDED event per current item. 410 Gone when the revision is older than retained history."`
 }
 
 type listInput = ListInput
@@ -297,11 +338,13 @@ type listOutput[T v1alpha1.Object] struct {
 	Body struct {
 		Items      []T    `json:"items"`
 		NextCursor string `json:"nextCursor,omitempty"`
+		// ResourceVersion is set when the server supports watch.
+		ResourceVersion string `json:"resourceVersion,omitempty" doc:"Control-plane revision the list was read at. Pass it to ?watch=true&resourceVersion= to stream changes after this list."`
 	}
 }
 
 type putMutableInput[T v1alpha1.Object] struct {
-	Namespace string `query:"namespace" doc:"Namespace (internal; defaults to 'default')."`
+	Namespace string `query:"namespace" doc:"Namespace (defaults to 'default')."`
 	Name      string `path:"name"`
 	Body      T
 }
@@ -319,8 +362,8 @@ func Register[T v1alpha1.Object](api huma.API, cfg Config, newObj func() T) {
 	}
 	base := strings.TrimRight(cfg.BasePrefix, "/")
 
-	// Flat URL shape: namespace is an internal detail carried as a query
-	// param, not a path segment. Defaults to "default"; a special value
+	// Flat URL shape: namespace is carried as a query param, not a path
+	// segment. Defaults to "default"; a special value
 	// "all" on the list endpoint widens the scope to every namespace.
 	listPath := base + "/" + plural
 	itemPath := listPath + "/{name}"
@@ -332,6 +375,7 @@ func Register[T v1alpha1.Object](api huma.API, cfg Config, newObj func() T) {
 		Method:      http.MethodGet,
 		Path:        listPath,
 		Summary:     fmt.Sprintf("List %s (scoped by ?namespace)", kind),
+		Middlewares: huma.Middlewares{watchMiddleware(api, cfg, newObj)},
 	}
 	if cfg.EnableOriginFilter {
 		huma.Register(api, listOperation, func(ctx context.Context, in *listWithOriginInput) (*listOutput[T], error) {
@@ -384,10 +428,17 @@ func Register[T v1alpha1.Object](api huma.API, cfg Config, newObj func() T) {
 	// (routes match in registration order).
 	if v1alpha1.IsTaggedArtifactKind(kind) {
 		registerListTags(api, cfg, newObj, kind, itemPath)
+		registerSignatures(api, cfg, kind, itemPath)
+	}
+	// Referrers share the same literal-segment precedence as tags.
+	if cfg.Referrers != nil {
+		registerListReferrers(api, cfg, kind, itemPath)
 	}
 
 	if v1alpha1.IsTaggedArtifactKind(kind) {
 		registerGetTagged(api, cfg, newObj, kind, itemTagPath)
+		registerListRevisions(api, cfg, newObj, kind, itemTagPath)
+		registerTagMarkers(api, cfg, newObj, kind, itemTagPath)
 		registerDeleteTagged(api, cfg, newObj, kind, itemTagPath)
 	} else {
 		registerApplyMutable(api, cfg, newObj, kind, itemPath)
@@ -511,6 +562,7 @@ func registerApplyMutable[T v1alpha1.Object](api huma.API, cfg Config, newObj fu
 			PostUpsert:        cfg.PostUpsert,
 			InitialFinalizers: cfg.InitialFinalizers,
 			Prepare:           cfg.Prepare,
+			NamespaceExists:   cfg.NamespaceExists,
 		}, false); ae != nil {
 			return nil, mapApplyErrorToHuma(ae, kind, ns, name, "")
 		}
@@ -557,7 +609,7 @@ func registerDelete[T v1alpha1.Object](api huma.API, cfg Config, newObj func() T
 			if err != nil {
 				return nil, err
 			}
-			return runDelete(ctx, cfg, newObj, kind, ns, name, tag)
+			return runDelete(ctx, cfg, newObj, kind, ns, name, tag, in.Force)
 		})
 		return
 	}
@@ -567,11 +619,11 @@ func registerDelete[T v1alpha1.Object](api huma.API, cfg Config, newObj func() T
 		if err != nil {
 			return nil, err
 		}
-		return runDeleteLatest(ctx, cfg, newObj, kind, ns, name)
+		return runDeleteLatest(ctx, cfg, newObj, kind, ns, name, in.Force)
 	})
 }
 
-func runDeleteLatest[T v1alpha1.Object](ctx context.Context, cfg Config, newObj func() T, kind, ns, name string) (*deleteOutput, error) {
+func runDeleteLatest[T v1alpha1.Object](ctx context.Context, cfg Config, newObj func() T, kind, ns, name string, force bool) (*deleteOutput, error) {
 	// Use the terminating-aware lookup so a repeated DELETE on a row that's
 	// already mid-teardown stays idempotent. Without this the second call
 	// 404s the moment deletion_timestamp lands (GetLatest filters those
@@ -589,7 +641,7 @@ func runDeleteLatest[T v1alpha1.Object](ctx context.Context, cfg Config, newObj
 	if err != nil {
 		return nil, huma.Error500InternalServerError("decode "+kind, err)
 	}
-	dopts := deleteOpts{Authorize: cfg.Authorize}
+	dopts := deleteOpts{Authorize: cfg.Authorize, Referrers: cfg.Referrers, ReferrerAuthorizers: cfg.ReferrerAuthorizers, Force: force}
 	if cfg.PostDelete != nil {
 		dopts.PostDelete = cfg.PostDelete
 	}
@@ -614,7 +666,7 @@ func getLatestForRead(ctx context.Context, cfg Config, ns, name string) (*v1alph
 	return cfg.Store.GetLatest(ctx, ns, name)
 }
 
-func runDelete[T v1alpha1.Object](ctx context.Context, cfg Config, newObj func() T, kind, ns, name, tag string) (*deleteOutput, error) {
+func runDelete[T v1alpha1.Object](ctx context.Context, cfg Config, newObj func() T, kind, ns, name, tag string, force bool) (*deleteOutput, error) {
 	var preDelete v1alpha1.Object
 	if cfg.PostDelete != nil {
 		row, err := cfg.Store.Get(ctx, ns, name, tag)
@@ -629,8 +681,11 @@ func runDelete[T v1alpha1.Object](ctx context.Context, cfg Config, newObj func()
 	}
 
 	dopts := deleteOpts{
-		Authorize:       cfg.Authorize,
-		PreDeleteObject: preDelete,
+		Authorize:           cfg.Authorize,
+		PreDeleteObject:     preDelete,
+		Referrers:           cfg.Referrers,
+		ReferrerAuthorizers: cfg.ReferrerAuthorizers,
+		Force:               force,
 	}
 	if cfg.PostDelete != nil {
 		dopts.PostDelete = cfg.PostDelete
@@ -661,12 +716,22 @@ func mapApplyErrorToHuma(ae *applyError, kind, ns, name, tag string) error {
 		return ae.Err
 	case stageMarshal:
 		return huma.Error400BadRequest("marshal spec: " + ae.Err.Error())
+	case stageNamespace:
+		if ae.NotFound {
+			return huma.Error404NotFound(ae.Err.Error())
+		}
+		return huma.Error500InternalServerError("check namespace", ae.Err)
+	case stageTagPolicy:
+		return huma.Error500InternalServerError(kind+" tag policy", ae.Err)
 	case stageUpsert:
 		if ae.Terminating {
 			return huma.Error409Conflict(fmt.Sprintf(
 				"%s %s/%s/%s is terminating; delete + re-apply once GC purges the row",
 				kind, ns, name, tag))
 		}
+		if ae.Conflict || ae.Immutable {
+			return huma.Error409Conflict(ae.Err.Error())
+		}
 		return huma.Error500InternalServerError("upsert "+kind, ae.Err)
 	case stagePostUpsert:
 		return huma.Error500InternalServerError(kind+" post-upsert", ae.Err)
@@ -674,7 +739,15 @@ func mapApplyErrorToHuma(ae *applyError, kind, ns, name, tag string) error {
 		if ae.NotFound {
 			return mapNotFound(ae.Err, kind, ns, name, tag)
 		}
+		if ae.Referenced {
+			return huma.Error409Conflict(ae.Err.Error())
+		}
 		return huma.Error500InternalServerError("delete "+kind, ae.Err)
+	case stageSignature:
+		if ae.Unsigned {
+			return huma.Error403Forbidden(ae.Err.Error())
+		}
+		return huma.Error500InternalServerError(kind+" signature policy", ae.Err)
 	case stagePostDelete:
 		return huma.Error500InternalServerError(kind+" post-delete", ae.Err)
 	}
@@ -688,6 +761,7 @@ func mapApplyErrorToHuma(ae *applyError, kind, ns, name, tag string) error {
 type listParams struct {
 	Namespace          string
 	Labels             string
+	FieldSelector      string
 	Limit              int
 	Cursor             string
 	Tag                string
@@ -708,6 +782,7 @@ func handleList[T v1alpha1.Object](
 	return runList(ctx, cfg, newObj, listParams{
 		Namespace:          ns,
 		Labels:             in.Labels,
+		FieldSelector:      in.FieldSelector,
 		Limit:              in.Limit,
 		Cursor:             in.Cursor,
 		Tag:                in.Tag,
@@ -722,10 +797,53 @@ func handleList[T v1alpha1.Object](
 func runList[T v1alpha1.Object](
 	ctx context.Context, cfg Config, newObj func() T, p listParams,
 ) (*listOutput[T], error) {
+	opts, err := listOptsFor(ctx, cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	// Read the revision before the rows so a watch resumed from it can
+	// only replay changes the list may already include, never miss one.
+	var revision int64
+	if cfg.Watch != nil && cfg.Watch.Events != nil {
+		if revision, err = cfg.Watch.Events.CurrentRevision(ctx); err != nil {
+			return nil, huma.Error500InternalServerError("list "+cfg.Kind, err)
+		}
+	}
+	rows, nextCursor, err := cfg.Store.List(ctx, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, v1alpha1store.ErrInvalidCursor):
+			return nil, huma.Error400BadRequest("invalid cursor")
+		case errors.Is(err, v1alpha1.ErrInvalidSelector):
+			return nil, huma.Error400BadRequest(err.Error())
+		}
+		return nil, huma.Error500InternalServerError("list "+cfg.Kind, err)
+	}
+	items := make([]T, 0, len(rows))
+	for _, row := range rows {
+		obj, err := v1alpha1.EnvelopeFromRaw(newObj, row, cfg.Kind)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("decode "+cfg.Kind, err)
+		}
+		items = append(items, obj)
+	}
+	out := &listOutput[T]{}
+	out.Body.Items = items
+	out.Body.NextCursor = nextCursor
+	if cfg.Watch != nil && cfg.Watch.Events != nil {
+		out.Body.ResourceVersion = strconv.FormatInt(revision, 10)
+	}
+	return out, nil
+}
+
+// listOptsFor translates list query parameters into Store options,
+// including the kind's ListFilter predicate. Shared by list and watch so
+// a watch streams exactly the rows the equivalent list returns.
+func listOptsFor(ctx context.Context, cfg Config, p listParams) (v1alpha1store.ListOpts, error) {
 	switch p.Origin {
 	case "", "managed", "discovered":
 	default:
-		return nil, huma.Error400BadRequest("invalid origin filter: expected managed or discovered")
+		return v1alpha1store.ListOpts{}, huma.Error400BadRequest("invalid origin filter: expected managed or discovered")
 	}
 
 	opts := v1alpha1store.ListOpts{
@@ -737,40 +855,34 @@ func runList[T v1alpha1.Object](
 		IncludeTerminating: p.IncludeTerminating || cfg.IncludeTerminatingByDefault,
 	}
 	if p.Labels != "" {
-		selector, err := parseLabelSelector(p.Labels)
+		selector, err := v1alpha1.ParseLabelSelector(p.Labels)
 		if err != nil {
-			return nil, huma.Error400BadRequest("invalid labels selector: " + err.Error())
+			return v1alpha1store.ListOpts{}, huma.Error400BadRequest("invalid labels selector: " + err.Error())
 		}
 		opts.LabelSelector = selector
 	}
+	if p.FieldSelector != "" {
+		selector, err := v1alpha1.ParseFieldSelector(p.FieldSelector)
+		if err != nil {
+			return v1alpha1store.ListOpts{}, huma.Error400BadRequest("invalid field selector: " + err.Error())
+		}
+		for _, req := range selector {
+			if req.Field == "metadata.tag" && !v1alpha1.IsTaggedArtifactKind(cfg.Kind) {
+				return v1alpha1store.ListOpts{}, huma.Error400BadRequest(fmt.Sprintf("invalid field selector: %s has no tags", cfg.Kind))
+			}
+		}
+		opts.FieldSelector = selector
+	}
 	if cfg.ListFilter != nil {
 		extra, extraArgs, err := cfg.ListFilter(ctx, AuthorizeInput{Verb: "list", Kind: cfg.Kind, Namespace: p.Namespace})
 		if err != nil {
-			return nil, err
+			return v1alpha1store.ListOpts{}, err
 		}
 		opts.ExtraWhere = extra
 		opts.ExtraArgs = extraArgs
 	}
 	applyOriginFilter(&opts, p.Origin)
-	rows, nextCursor, err := cfg.Store.List(ctx, opts)
-	if err != nil {
-		if errors.Is(err, v1alpha1store.ErrInvalidCursor) {
-			return nil, huma.Error400BadRequest("invalid cursor")
-		}
-		return nil, huma.Error500InternalServerError("list "+cfg.Kind, err)
-	}
-	items := make([]T, 0, len(rows))
-	for _, row := range rows {
-		obj, err := v1alpha1.EnvelopeFromRaw(newObj, row, cfg.Kind)
-		if err != nil {
-			return nil, huma.Error500InternalServerError("decode "+cfg.Kind, err)
-		}
-		items = append(items, obj)
-	}
-	out := &listOutput[T]{}
-	out.Body.Items = items
-	out.Body.NextCursor = nextCursor
-	return out, nil
+	return opts, nil
 }
 
 func applyOriginFilter(opts *v1alpha1store.ListOpts, origin string) {
@@ -816,27 +928,3 @@ func mapNotFound(err error, kind, namespace, name, tag string) error {
 	}
 	return huma.Error500InternalServerError("fetch "+kind, err)
 }
-
-// parseLabelSelector decodes "key=value,key2=value2" into a map. Values
-// may contain `=` (split is on the first `=` only); values with `,` are
-// not supported and would split mid-pair.
-func parseLabelSelector(s string) (map[string]string, error) {
-	out := make(map[string]string)
-	for pair := range strings.SplitSeq(s, ",") {
-		pair = strings.TrimSpace(pair)
-		if pair == "" {
-			continue
-		}
-		eq := strings.IndexByte(pair, '=')
-		if eq <= 0 {
-			return nil, fmt.Errorf("label %q must be key=value", pair)
-		}
-		key := strings.TrimSpace(pair[:eq])
-		val := strings.TrimSpace(pair[eq+1:])
-		if key == "" {
-			return nil, fmt.Errorf("label %q has empty key", pair)
-		}
-		out[key] = val
-	}
-	return out, nil
-}
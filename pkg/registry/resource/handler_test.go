@@ -74,8 +74,8 @@ func applyAgentYAML(t *testing.T, api humatest.TestAPI, yaml string) arv0.ApplyR
 func TestResourceRegister_AgentCRUD(t *testing.T) {
 	t.Helper()
 
-	pool := v1alpha1store.NewTestPool(t)
-	store := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
 
 	_, api := humatest.New(t)
 	registerAgent(api, store)
@@ -133,6 +133,21 @@ spec:
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &list))
 	require.Len(t, list.Items, 1)
 
+	// Set-based label selectors and field selectors.
+	resp = api.Get("/v0/agents?labels=" + url.QueryEscape("team in (platform,infra),!deprecated") +
+		"&fieldSelector=" + url.QueryEscape("spec.title=Alice"))
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &list))
+	require.Len(t, list.Items, 1)
+	resp = api.Get("/v0/agents?fieldSelector=" + url.QueryEscape("spec.title!=Alice"))
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &list))
+	require.Empty(t, list.Items)
+	resp = api.Get("/v0/agents?fieldSelector=" + url.QueryEscape("labels.team=platform"))
+	require.Equal(t, http.StatusBadRequest, resp.Code, resp.Body.String())
+	resp = api.Get("/v0/agents?labels=" + url.QueryEscape("team in (platform"))
+	require.Equal(t, http.StatusBadRequest, resp.Code, resp.Body.String())
+
 	// Re-apply with the same spec is a no-op at the Store layer; the
 	// row remains at tag latest.
 	res = applyAgentYAML(t, api, createYAML)
@@ -194,8 +209,8 @@ spec:
 }
 
 func TestResourceRegister_DeleteTaggedPassesTagToAuthorizer(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	store := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
 	_, err := store.Upsert(t.Context(), &v1alpha1.Agent{
 		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "alice", Tag: "stable"},
 		Spec:     v1alpha1.AgentSpec{Title: "Stable Alice"},
@@ -226,8 +241,8 @@ func TestResourceRegister_DeleteTaggedPassesTagToAuthorizer(t *testing.T) {
 }
 
 func TestResourceRegister_DeleteAdmissionCanStageTaggedDelete(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	store := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
 	_, err := store.Upsert(t.Context(), &v1alpha1.Agent{
 		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "alice", Tag: "stable"},
 		Spec:     v1alpha1.AgentSpec{Title: "Stable Alice"},
@@ -269,9 +284,162 @@ func TestResourceRegister_DeleteAdmissionCanStageTaggedDelete(t *testing.T) {
 	require.Equal(t, "stable", row.Metadata.Tag)
 }
 
+func TestResourceRegister_DeleteRefusedWhileReferenced(t *testing.T) {
+	db := v1alpha1store.NewTestDB(t)
+	stores := map[string]*v1alpha1store.Store{
+		v1alpha1.KindAgent:     v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents"),
+		v1alpha1.KindMCPServer: v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "mcp_servers"),
+	}
+	for _, tag := range []string{"1.0.0", "1.1.0"} {
+		_, err := stores[v1alpha1.KindMCPServer].Upsert(t.Context(), &v1alpha1.MCPServer{
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "tools", Tag: tag},
+			Spec:     v1alpha1.MCPServerSpec{Title: "Tools " + tag},
+		})
+		require.NoError(t, err)
+	}
+	_, err := stores[v1alpha1.KindAgent].Upsert(t.Context(), &v1alpha1.Agent{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "planner", Tag: "latest"},
+		Spec:     v1alpha1.AgentSpec{MCPServers: []v1alpha1.ResourceRef{{Name: "tools", Tag: "^1.0.0"}}},
+	})
+	require.NoError(t, err)
+
+	referrers := resource.NewReferrerLookup(stores)
+	_, api := humatest.New(t)
+	resource.Register[*v1alpha1.MCPServer](api, resource.Config{
+		Kind:       v1alpha1.KindMCPServer,
+		PluralKind: "mcpservers",
+		BasePrefix: "/v0",
+		Store:      stores[v1alpha1.KindMCPServer],
+		Referrers:  referrers,
+	}, func() *v1alpha1.MCPServer { return &v1alpha1.MCPServer{} })
+	resource.RegisterApply(api, resource.ApplyConfig{
+		BasePrefix: "/v0",
+		Stores:     stores,
+		Referrers:  referrers,
+	})
+
+	resp := api.Get("/v0/mcpservers/tools/referrers")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var list struct {
+		Items []v1alpha1.ResourceRef `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &list))
+	require.Equal(t, []v1alpha1.ResourceRef{{Kind: v1alpha1.KindAgent, Namespace: "default", Name: "planner", Tag: "latest"}}, list.Items)
+
+	// The range selects 1.1.0, so 1.0.0 is free to go.
+	resp = api.Get("/v0/mcpservers/tools/referrers?tag=1.0.0")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &list))
+	require.Empty(t, list.Items)
+	resp = api.Delete("/v0/mcpservers/tools/1.0.0")
+	require.Equal(t, http.StatusNoContent, resp.Code, resp.Body.String())
+
+	resp = api.Delete("/v0/mcpservers/tools/1.1.0")
+	require.Equal(t, http.StatusConflict, resp.Code, resp.Body.String())
+	require.Contains(t, resp.Body.String(), "Agent default/planner@latest")
+
+	batch := `apiVersion: ar.dev/v1alpha1
+kind: MCPServer
+metadata:
+  name: tools
+`
+	resp = api.Do(http.MethodDelete, "/v0/apply", "Content-Type: application/yaml", strings.NewReader(batch))
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var out struct {
+		Results []arv0.ApplyResult `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &out))
+	require.Len(t, out.Results, 1)
+	require.Equal(t, arv0.ApplyStatusFailed, out.Results[0].Status)
+	require.Equal(t, arv0.ApplyReasonReferenced, out.Results[0].Reason)
+
+	resp = api.Delete("/v0/mcpservers/tools/1.1.0?force=true")
+	require.Equal(t, http.StatusNoContent, resp.Code, resp.Body.String())
+}
+
+func TestResourceRegister_ReferrersHideUnreadableObjects(t *testing.T) {
+	db := v1alpha1store.NewTestDB(t)
+	stores := map[string]*v1alpha1store.Store{
+		v1alpha1.KindAgent:     v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents"),
+		v1alpha1.KindMCPServer: v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "mcp_servers"),
+	}
+	_, err := stores[v1alpha1.KindMCPServer].Upsert(t.Context(), &v1alpha1.MCPServer{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "tools"},
+		Spec:     v1alpha1.MCPServerSpec{Title: "Tools"},
+	})
+	require.NoError(t, err)
+	for _, ns := range []string{"default", "secret"} {
+		_, err := stores[v1alpha1.KindAgent].Upsert(t.Context(), &v1alpha1.Agent{
+			Metadata: v1alpha1.ObjectMeta{Namespace: ns, Name: "planner-" + ns},
+			Spec:     v1alpha1.AgentSpec{MCPServers: []v1alpha1.ResourceRef{{Namespace: "default", Name: "tools"}}},
+		})
+		require.NoError(t, err)
+	}
+
+	// The caller may read everything outside the "secret" namespace.
+	authorize := func(_ context.Context, in resource.AuthorizeInput) error {
+		if in.Namespace == "secret" {
+			return huma.Error403Forbidden("forbidden")
+		}
+		return nil
+	}
+	authorizers := map[string]func(context.Context, resource.AuthorizeInput) error{
+		v1alpha1.KindAgent:     authorize,
+		v1alpha1.KindMCPServer: authorize,
+	}
+	referrers := resource.NewReferrerLookup(stores)
+	_, api := humatest.New(t)
+	resource.Register[*v1alpha1.MCPServer](api, resource.Config{
+		Kind:                v1alpha1.KindMCPServer,
+		PluralKind:          "mcpservers",
+		BasePrefix:          "/v0",
+		Store:               stores[v1alpha1.KindMCPServer],
+		Authorize:           authorize,
+		Referrers:           referrers,
+		ReferrerAuthorizers: authorizers,
+	}, func() *v1alpha1.MCPServer { return &v1alpha1.MCPServer{} })
+	resource.RegisterApply(api, resource.ApplyConfig{
+		BasePrefix:  "/v0",
+		Stores:      stores,
+		Authorizers: authorizers,
+		Referrers:   referrers,
+	})
+
+	resp := api.Get("/v0/mcpservers/tools/referrers")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var list struct {
+		Items  []v1alpha1.ResourceRef `json:"items"`
+		Hidden int                    `json:"hidden"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &list))
+	require.Equal(t, []v1alpha1.ResourceRef{{Kind: v1alpha1.KindAgent, Namespace: "default", Name: "planner-default", Tag: "latest"}}, list.Items)
+	require.Equal(t, 1, list.Hidden)
+
+	resp = api.Delete("/v0/mcpservers/tools/latest")
+	require.Equal(t, http.StatusConflict, resp.Code, resp.Body.String())
+	require.Contains(t, resp.Body.String(), "Agent default/planner-default@latest, 1 object you cannot read")
+	require.NotContains(t, resp.Body.String(), "planner-secret")
+
+	batch := `apiVersion: ar.dev/v1alpha1
+kind: MCPServer
+metadata:
+  name: tools
+`
+	resp = api.Do(http.MethodDelete, "/v0/apply", "Content-Type: application/yaml", strings.NewReader(batch))
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var out struct {
+		Results []arv0.ApplyResult `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &out))
+	require.Len(t, out.Results, 1)
+	require.Equal(t, arv0.ApplyReasonReferenced, out.Results[0].Reason)
+	require.Contains(t, out.Results[0].Error, "1 object you cannot read")
+	require.NotContains(t, out.Results[0].Error, "planner-secret")
+}
+
 func TestResourceRegister_AgentNamespaceIsolation(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	store := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
 
 	_, api := humatest.New(t)
 	registerAgent(api, store)
@@ -329,8 +497,8 @@ spec:
 }
 
 func TestResourceRegister_AgentListCursorPagination(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	store := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
 
 	_, api := humatest.New(t)
 	registerAgent(api, store)
@@ -380,8 +548,8 @@ spec:
 // TestResourceRegister_AgentListTags pins the GET /v0/{plural}/{name}/tags
 // contract: every non-deleted tag row for (namespace, name) is returned.
 func TestResourceRegister_AgentListTags(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	store := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
 
 	_, api := humatest.New(t)
 	registerAgent(api, store)
@@ -423,9 +591,57 @@ func TestResourceRegister_AgentListTags(t *testing.T) {
 	require.Empty(t, empty.Items)
 }
 
+func TestResourceRegister_AgentListRevisions(t *testing.T) {
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents", v1alpha1store.WithRevisionHistory(v1alpha1store.TestSchema()))
+
+	_, api := humatest.New(t)
+	registerAgent(api, store)
+
+	body := v1alpha1.Agent{
+		TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindAgent},
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "foo", Tag: "stable"},
+		Spec:     v1alpha1.AgentSpec{Title: "one"},
+	}
+	for _, title := range []string{"one", "two"} {
+		body.Spec.Title = title
+		_, err := store.Upsert(t.Context(), &body)
+		require.NoError(t, err)
+	}
+
+	resp := api.Get("/v0/agents/foo/stable/revisions")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var list struct {
+		Items []struct {
+			Revision int            `json:"revision"`
+			Digest   string         `json:"digest"`
+			Current  bool           `json:"current"`
+			Object   v1alpha1.Agent `json:"object"`
+		} `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &list))
+	require.Len(t, list.Items, 2)
+	require.Equal(t, 2, list.Items[0].Revision)
+	require.True(t, list.Items[0].Current)
+	require.Equal(t, "two", list.Items[0].Object.Spec.Title)
+	require.Equal(t, 1, list.Items[1].Revision)
+	require.False(t, list.Items[1].Current)
+	require.Equal(t, "one", list.Items[1].Object.Spec.Title)
+	require.Equal(t, "stable", list.Items[1].Object.Metadata.Tag)
+	require.True(t, strings.HasPrefix(list.Items[1].Digest, v1alpha1store.DigestPrefix))
+
+	resp = api.Get("/v0/agents/missing/stable/revisions")
+	require.Equal(t, http.StatusNotFound, resp.Code, resp.Body.String())
+
+	_, noHistory := humatest.New(t)
+	registerAgent(noHistory, v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents"))
+	resp = noHistory.Get("/v0/agents/foo/stable/revisions")
+	require.Equal(t, http.StatusNotImplemented, resp.Code, resp.Body.String())
+}
+
 func TestResourceRegister_AgentListRejectsInvalidCursor(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	store := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
 
 	_, api := humatest.New(t)
 	registerAgent(api, store)
@@ -436,9 +652,9 @@ func TestResourceRegister_AgentListRejectsInvalidCursor(t *testing.T) {
 }
 
 func TestResourceRegister_OriginFilterIsOptIn(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	agents := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
-	deployments := v1alpha1store.NewMutableObjectStore(pool, v1alpha1store.TestSchema(), "deployments")
+	db := v1alpha1store.NewTestDB(t)
+	agents := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
+	deployments := v1alpha1store.NewMutableObjectStore(db, v1alpha1store.TestSchema(), "deployments")
 
 	_, plainAPI := humatest.New(t)
 	resource.Register[*v1alpha1.Agent](plainAPI, resource.Config{
@@ -484,8 +700,8 @@ func requireListQueryParam(t *testing.T, api humatest.TestAPI, path, name string
 // "ok-". Three rows are seeded; the unfiltered list returns all three,
 // the filtered list returns just the two matches.
 func TestResourceRegister_ListFilter(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	store := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
 
 	for _, name := range []string{"ok-one", "ok-two", "blocked-three"} {
 		_, err := store.Upsert(t.Context(), &v1alpha1.Agent{
@@ -543,8 +759,8 @@ func TestResourceRegister_ListFilter(t *testing.T) {
 // not in the registered method set. The Allow header on the response
 // confirms PUT is excluded.
 func TestResourceRegister_PutNotRegisteredForContentKinds(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	store := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
 
 	_, api := humatest.New(t)
 	registerAgent(api, store)
@@ -574,8 +790,8 @@ func TestResourceRegister_PutNotRegisteredForContentKinds(t *testing.T) {
 }
 
 func TestResourceRegister_MutableObjectUsesNameOnlyRoute(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	store := v1alpha1store.NewMutableObjectStore(pool, v1alpha1store.TestSchema(), "runtimes")
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewMutableObjectStore(db, v1alpha1store.TestSchema(), "runtimes")
 
 	_, api := humatest.New(t)
 	registerProvider(api, store)
@@ -603,9 +819,9 @@ func TestResourceRegister_MutableObjectUsesNameOnlyRoute(t *testing.T) {
 }
 
 func TestResourceRegister_ResolverDetectsDanglingRef(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	agentStore := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
-	mcpStore := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "mcp_servers")
+	db := v1alpha1store.NewTestDB(t)
+	agentStore := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
+	mcpStore := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "mcp_servers")
 
 	// Resolver: only MCPServer "tools" in namespace "default" exists.
 	resolver := func(ctx context.Context, ref v1alpha1.ResourceRef) error {
@@ -670,8 +886,8 @@ spec:
 // Reported by josh-pritchard on PR #455 ("Soft-delete blocks re-apply
 // for every v1alpha1 kind"); fixed at the Store layer.
 func TestResourceRegister_DeleteHardDeletesFinalizerFree(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	store := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
 
 	_, api := humatest.New(t)
 	registerAgent(api, store)
@@ -725,8 +941,8 @@ spec:
 // behavior so future changes are forced through documentation +
 // reviewer awareness.
 func TestResourceRegister_PostUpsertFailureLeavesPersistedRow(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	store := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
 
 	hookCalls := 0
 	hookErr := fmt.Errorf("simulated type-adapter failure")
@@ -804,8 +1020,8 @@ spec:
 // needed), and ?latestOnly=true remains a no-op for mutable objects because
 // namespace/name is already unique.
 func TestResourceRegister_IncludeTerminatingByDefault(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	store := v1alpha1store.NewMutableObjectStore(pool, v1alpha1store.TestSchema(), "runtimes")
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewMutableObjectStore(db, v1alpha1store.TestSchema(), "runtimes")
 	const testNamespace = "terminating-test"
 
 	_, api := humatest.New(t)
@@ -878,8 +1094,8 @@ func TestResourceRegister_IncludeTerminatingByDefault(t *testing.T) {
 // returns 204 rather than 404. The handler uses the terminating-aware
 // lookup so retry scripts get a coherent response shape.
 func TestResourceRegister_DeleteIdempotentOnTerminating(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
-	store := v1alpha1store.NewMutableObjectStore(pool, v1alpha1store.TestSchema(), "runtimes")
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewMutableObjectStore(db, v1alpha1store.TestSchema(), "runtimes")
 	const testNamespace = "delete-idempotent"
 
 	_, api := humatest.New(t)
@@ -912,3 +1128,32 @@ func TestResourceRegister_DeleteIdempotentOnTerminating(t *testing.T) {
 	require.Equal(t, http.StatusNoContent, resp.Code, resp.Body.String(),
 		"DELETE on an already-terminating row must stay idempotent")
 }
+
+func TestResourceRegister_PutRejectsStaleResourceVersion(t *testing.T) {
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewMutableObjectStore(db, v1alpha1store.TestSchema(), "runtimes")
+
+	_, api := humatest.New(t)
+	registerProvider(api, store)
+
+	runtime := v1alpha1.Runtime{
+		TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindRuntime},
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "kubernetes-test"},
+		Spec:     v1alpha1.RuntimeSpec{Type: v1alpha1.TypeKubernetes},
+	}
+	resp := api.Put("/v0/runtimes/kubernetes-test", runtime)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var first v1alpha1.Runtime
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &first))
+	require.NotEmpty(t, first.Metadata.ResourceVersion)
+
+	// Someone else updates the runtime after we read it.
+	runtime.Metadata.Labels = map[string]string{"owner": "b"}
+	resp = api.Put("/v0/runtimes/kubernetes-test", runtime)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	first.Metadata.Labels = map[string]string{"owner": "a"}
+	resp = api.Put("/v0/runtimes/kubernetes-test", first)
+	require.Equal(t, http.StatusConflict, resp.Code, resp.Body.String())
+	require.Contains(t, resp.Body.String(), "resourceVersion")
+}
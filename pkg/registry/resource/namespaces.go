@@ -0,0 +1,75 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// NewNamespaceExists returns the NamespaceExists lookup over stores: it
+// reports whether a Namespace object declares namespace. Apply and PUT
+// consult it so resources only land in declared namespaces, which is what
+// lets a Namespace delete refuse while the namespace still holds
+// resources. Without a Namespace store every namespace exists.
+func NewNamespaceExists(stores map[string]*v1alpha1store.Store) func(ctx context.Context, namespace string) (bool, error) {
+	return func(ctx context.Context, namespace string) (bool, error) {
+		store := stores[v1alpha1.KindNamespace]
+		if store == nil {
+			return true, nil
+		}
+		_, err := store.GetLatest(ctx, v1alpha1.DefaultNamespace, namespace)
+		if errors.Is(err, pkgdb.ErrNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("load namespace %q: %w", namespace, err)
+		}
+		return true, nil
+	}
+}
+
+// NewTagPolicyLookup returns the TagPolicy lookup over stores: the
+// server-wide policy, tightened by a Namespace's spec.tagPolicy when it
+// sets one. A Namespace writer can protect more tags but never unprotect
+// one the server policy covers.
+func NewTagPolicyLookup(stores map[string]*v1alpha1store.Store, serverPolicy v1alpha1.TagPolicy) func(ctx context.Context, kind, namespace string) (v1alpha1.TagPolicy, error) {
+	return func(ctx context.Context, _, namespace string) (v1alpha1.TagPolicy, error) {
+		spec, err := namespaceSpec(ctx, stores, namespace)
+		if err != nil {
+			return v1alpha1.TagPolicy{}, err
+		}
+		if spec == nil || spec.TagPolicy == nil {
+			return serverPolicy, nil
+		}
+		return spec.TagPolicy.Within(serverPolicy), nil
+	}
+}
+
+// namespaceSpec returns the spec of the Namespace object named namespace,
+// or nil when there is none or no Namespace store. The tag, signature and
+// approval policy lookups all read a namespace's policies through it.
+func namespaceSpec(ctx context.Context, stores map[string]*v1alpha1store.Store, namespace string) (*v1alpha1.NamespaceSpec, error) {
+	store := stores[v1alpha1.KindNamespace]
+	if store == nil {
+		return nil, nil
+	}
+	row, err := store.GetLatest(ctx, v1alpha1.DefaultNamespace, namespace)
+	if errors.Is(err, pkgdb.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load namespace %q: %w", namespace, err)
+	}
+	var spec v1alpha1.NamespaceSpec
+	if len(row.Spec) > 0 {
+		if err := json.Unmarshal(row.Spec, &spec); err != nil {
+			return nil, fmt.Errorf("decode namespace %q: %w", namespace, err)
+		}
+	}
+	return &spec, nil
+}
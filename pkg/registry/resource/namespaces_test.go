@@ -0,0 +1,49 @@
+//go:build integration
+
+package resource_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+func TestNamespaceTagPolicyTightensServerPolicy(t *testing.T) {
+	db := v1alpha1store.NewTestDB(t)
+	stores := v1alpha1store.NewStores(db, v1alpha1store.TestSchemaRegistry())
+	ctx := t.Context()
+
+	for name, policy := range map[string]*v1alpha1.TagPolicy{
+		"team-a": {Immutable: []string{"release-*"}},
+		"team-b": {},
+		"team-c": {Mutable: []string{"*"}},
+	} {
+		_, err := stores[v1alpha1.KindNamespace].Upsert(ctx, &v1alpha1.Namespace{
+			Metadata: v1alpha1.ObjectMeta{Namespace: v1alpha1.DefaultNamespace, Name: name},
+			Spec:     v1alpha1.NamespaceSpec{TagPolicy: policy},
+		})
+		require.NoError(t, err)
+	}
+
+	policy := resource.NewTagPolicyLookup(stores, v1alpha1.DefaultTagPolicy())
+
+	def, err := policy(ctx, v1alpha1.KindAgent, v1alpha1.DefaultNamespace)
+	require.NoError(t, err)
+	require.True(t, def.IsImmutable(v1alpha1.KindAgent, "1.0.0"))
+	require.False(t, def.IsImmutable(v1alpha1.KindAgent, "release-1"))
+
+	teamA, err := policy(ctx, v1alpha1.KindAgent, "team-a")
+	require.NoError(t, err)
+	require.True(t, teamA.IsImmutable(v1alpha1.KindAgent, "1.0.0"), "the server policy still applies")
+	require.True(t, teamA.IsImmutable(v1alpha1.KindAgent, "release-1"))
+
+	for _, ns := range []string{"team-b", "team-c"} {
+		loosened, err := policy(ctx, v1alpha1.KindAgent, ns)
+		require.NoError(t, err)
+		require.True(t, loosened.IsImmutable(v1alpha1.KindAgent, "1.0.0"), "%s cannot unprotect server-protected tags", ns)
+	}
+}
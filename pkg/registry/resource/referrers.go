@@ -0,0 +1,223 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+// NewReferrerLookup returns a types.ReferrerLookup over stores. Every kind
+// whose objects carry ResourceRefs (v1alpha1.RefResolver) is scanned; a
+// candidate row is decoded and its refs collected through ResolveRefs, so
+// the lookup sees exactly the refs apply-time resolution checks, defaulted
+// kinds and namespaces included. Terminating rows are ignored.
+//
+// A ref selects a tag the way GetByRef resolves it: blank means "latest"
+// and a semver range means the highest live tag it matches today.
+func NewReferrerLookup(stores map[string]*v1alpha1store.Store) types.ReferrerLookup {
+	return func(ctx context.Context, target v1alpha1.ResourceRef) ([]v1alpha1.ResourceRef, error) {
+		kinds := make([]string, 0, len(stores))
+		for kind := range stores {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+
+		opts := v1alpha1store.FindReferrersOpts{Mentions: target.Name}
+		// A harness Deployment without spec.modelRef references the
+		// default Model implicitly, so its spec never mentions the name.
+		if target.Kind == v1alpha1.KindModel && target.Name == v1alpha1.DefaultModelName {
+			opts.Mentions = ""
+		}
+
+		var out []v1alpha1.ResourceRef
+		for _, kind := range kinds {
+			store := stores[kind]
+			_, newAny, ok := v1alpha1.Default.Lookup(kind)
+			if store == nil || !ok {
+				continue
+			}
+			if _, ok := newAny().(v1alpha1.RefResolver); !ok {
+				continue
+			}
+			newObj := func() v1alpha1.Object { return newAny().(v1alpha1.Object) }
+			rows, err := store.FindReferrers(ctx, nil, opts)
+			if err != nil {
+				return nil, fmt.Errorf("find %s referrers: %w", kind, err)
+			}
+			for _, row := range rows {
+				obj, err := v1alpha1.EnvelopeFromRaw(newObj, row, kind)
+				if err != nil {
+					return nil, fmt.Errorf("decode %s %s/%s: %w", kind, row.Metadata.Namespace, row.Metadata.Name, err)
+				}
+				refers, err := refersTo(ctx, stores[target.Kind], obj, target)
+				if err != nil {
+					return nil, err
+				}
+				if refers {
+					meta := obj.GetMetadata()
+					out = append(out, v1alpha1.ResourceRef{Kind: kind, Namespace: meta.Namespace, Name: meta.Name, Tag: meta.Tag})
+				}
+			}
+		}
+		return out, nil
+	}
+}
+
+// refersTo reports whether any of obj's refs selects target. targetStore
+// resolves range tags and may be nil when the target kind has no store.
+func refersTo(ctx context.Context, targetStore *v1alpha1store.Store, obj v1alpha1.Object, target v1alpha1.ResourceRef) (bool, error) {
+	var refs []v1alpha1.ResourceRef
+	_ = v1alpha1.ResolveObjectRefs(ctx, obj, func(_ context.Context, ref v1alpha1.ResourceRef) error {
+		refs = append(refs, ref)
+		return nil
+	})
+	for _, ref := range refs {
+		if ref.Kind != target.Kind || ref.Namespace != target.Namespace || ref.Name != target.Name {
+			continue
+		}
+		if target.Tag == "" {
+			return true, nil
+		}
+		tag := ref.Tag
+		if tag == "" {
+			tag = v1alpha1store.DefaultTag()
+		}
+		if v1alpha1.IsTagRange(tag) && targetStore != nil {
+			// A range selects what resolution would pick, which skips
+			// tags awaiting approval and yanked tags.
+			resolved, err := targetStore.GetUsableByRef(ctx, ref.Namespace, ref.Name, tag)
+			if errors.Is(err, pkgdb.ErrNotFound) || errors.Is(err, v1alpha1store.ErrUnapproved) || errors.Is(err, v1alpha1store.ErrYanked) {
+				continue
+			}
+			if err != nil {
+				return false, fmt.Errorf("resolve %s %s/%s@%s: %w", ref.Kind, ref.Namespace, ref.Name, tag, err)
+			}
+			tag = resolved.Metadata.Tag
+		}
+		if tag == target.Tag {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// visibleReferrers splits refs into the referrers the caller may read under
+// authorizers (Verb="get") and a count of the others. A kind without a hook
+// is readable.
+func visibleReferrers(ctx context.Context, authorizers map[string]func(ctx context.Context, in AuthorizeInput) error, refs []v1alpha1.ResourceRef) ([]v1alpha1.ResourceRef, int) {
+	if len(authorizers) == 0 {
+		return refs, 0
+	}
+	visible := make([]v1alpha1.ResourceRef, 0, len(refs))
+	hidden := 0
+	for _, ref := range refs {
+		if authorize := authorizers[ref.Kind]; authorize != nil &&
+			authorize(ctx, AuthorizeInput{Verb: "get", Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name, Tag: ref.Tag}) != nil {
+			hidden++
+			continue
+		}
+		visible = append(visible, ref)
+	}
+	return visible, hidden
+}
+
+// ReferencedError is the delete refusal for a target other live objects
+// still reference. Hidden counts referrers the caller may not read, which
+// are left out of Referrers.
+type ReferencedError struct {
+	Target    v1alpha1.ResourceRef
+	Referrers []v1alpha1.ResourceRef
+	Hidden    int
+}
+
+// redact moves the referrers the caller may not read under authorizers
+// into Hidden.
+func (e *ReferencedError) redact(ctx context.Context, authorizers map[string]func(ctx context.Context, in AuthorizeInput) error) {
+	visible, hidden := visibleReferrers(ctx, authorizers, e.Referrers)
+	e.Referrers = visible
+	e.Hidden += hidden
+}
+
+func (e *ReferencedError) Error() string {
+	names := make([]string, 0, len(e.Referrers)+1)
+	for _, r := range e.Referrers {
+		names = append(names, formatRef(r))
+	}
+	switch {
+	case e.Hidden == 1:
+		names = append(names, "1 object you cannot read")
+	case e.Hidden > 1:
+		names = append(names, fmt.Sprintf("%d objects you cannot read", e.Hidden))
+	}
+	return fmt.Sprintf("%s is referenced by %s; update or delete them first, or delete with force=true",
+		formatRef(e.Target), strings.Join(names, ", "))
+}
+
+// formatRef renders ref as "Kind namespace/name" with an "@tag" suffix
+// when it names a tag.
+func formatRef(ref v1alpha1.ResourceRef) string {
+	s := ref.Kind + " " + ref.Namespace + "/" + ref.Name
+	if ref.Tag != "" {
+		s += "@" + ref.Tag
+	}
+	return s
+}
+
+type listReferrersInput struct {
+	Namespace string `query:"namespace" doc:"Namespace (defaults to 'default')."`
+	Name      string `path:"name"`
+	Tag       string `query:"tag" doc:"Only objects whose references select this tag (tagged artifact kinds only). Omit for references to any tag."`
+}
+
+type referrersOutput struct {
+	Body struct {
+		Items  []v1alpha1.ResourceRef `json:"items" doc:"Live objects that reference the resource, by kind, namespace, name and tag."`
+		Hidden int                    `json:"hidden,omitempty" doc:"Number of further referrers the caller is not authorized to read. They block deletes too."`
+	}
+}
+
+// registerListReferrers wires GET /{name}/referrers: the live objects that
+// reference (namespace, name), and would block deleting it. Referrers the
+// caller may not read are counted, not listed.
+func registerListReferrers(api huma.API, cfg Config, kind, itemPath string) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-referrers-" + strings.ToLower(kind),
+		Method:      http.MethodGet,
+		Path:        itemPath + "/referrers",
+		Summary:     fmt.Sprintf("List the objects that reference a %s", kind),
+	}, func(ctx context.Context, in *listReferrersInput) (*referrersOutput, error) {
+		ns := resolveNamespace(in.Namespace, false)
+		name, err := unescapePath("name", in.Name)
+		if err != nil {
+			return nil, err
+		}
+		if in.Tag != "" && !v1alpha1.IsTaggedArtifactKind(kind) {
+			return nil, huma.Error400BadRequest(fmt.Sprintf("%s is not tagged", kind))
+		}
+		if cfg.Authorize != nil {
+			if err := cfg.Authorize(ctx, AuthorizeInput{Verb: "get", Kind: kind, Namespace: ns, Name: name, Tag: in.Tag}); err != nil {
+				return nil, err
+			}
+		}
+		refs, err := cfg.Referrers(ctx, v1alpha1.ResourceRef{Kind: kind, Namespace: ns, Name: name, Tag: in.Tag})
+		if err != nil {
+			return nil, huma.Error500InternalServerError("list referrers of "+kind, err)
+		}
+		out := &referrersOutput{}
+		out.Body.Items, out.Body.Hidden = visibleReferrers(ctx, cfg.ReferrerAuthorizers, refs)
+		if out.Body.Items == nil {
+			out.Body.Items = []v1alpha1.ResourceRef{}
+		}
+		return out, nil
+	})
+}
@@ -0,0 +1,87 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+type listRevisionsInput struct {
+	Namespace string `query:"namespace" doc:"Namespace (defaults to 'default')."`
+	Name      string `path:"name"`
+	Tag       string `path:"tag"`
+}
+
+// revisionItem is one entry of a tag's revision history.
+type revisionItem[T v1alpha1.Object] struct {
+	Revision  int       `json:"revision" doc:"Revision number, counting the tag's distinct contents from 1 in the order they were first applied."`
+	Digest    string    `json:"digest" doc:"Content digest (sha256:<hex>) of the revision's labels, annotations and spec. Pin a Deployment to it with spec.targetDigest."`
+	CreatedAt time.Time `json:"createdAt" doc:"When the revision was first applied."`
+	Current   bool      `json:"current" doc:"Whether the tag holds this revision now."`
+	Object    T         `json:"object" doc:"The tag as of this revision. Only the current revision carries status."`
+}
+
+type revisionListOutput[T v1alpha1.Object] struct {
+	Body struct {
+		Items []revisionItem[T] `json:"items"`
+	}
+}
+
+// registerListRevisions wires GET /{name}/{tag}/revisions for a
+// tagged-artifact kind: every content the tag has held, newest first.
+// Rolling back is a re-apply of an earlier revision's object, so it goes
+// through the normal apply path and its admission and tag policy.
+func registerListRevisions[T v1alpha1.Object](api huma.API, cfg Config, newObj func() T, kind, itemTagPath string) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-revisions-" + strings.ToLower(kind),
+		Method:      http.MethodGet,
+		Path:        itemTagPath + "/revisions",
+		Summary:     fmt.Sprintf("List the revision history of a %s tag", kind),
+	}, func(ctx context.Context, in *listRevisionsInput) (*revisionListOutput[T], error) {
+		ns := resolveNamespace(in.Namespace, false)
+		name, err := unescapePath("name", in.Name)
+		if err != nil {
+			return nil, err
+		}
+		tag, err := unescapePath("tag", in.Tag)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Authorize != nil {
+			if err := cfg.Authorize(ctx, AuthorizeInput{Verb: "get", Kind: kind, Namespace: ns, Name: name, Tag: tag}); err != nil {
+				return nil, err
+			}
+		}
+		revisions, err := cfg.Store.ListRevisions(ctx, ns, name, tag)
+		if err != nil {
+			if errors.Is(err, v1alpha1store.ErrRevisionHistoryDisabled) {
+				return nil, huma.Error501NotImplemented(fmt.Sprintf("%s keeps no revision history", kind))
+			}
+			return nil, mapNotFound(err, kind, ns, name, tag)
+		}
+		out := &revisionListOutput[T]{}
+		out.Body.Items = make([]revisionItem[T], 0, len(revisions))
+		for _, rev := range revisions {
+			obj, err := v1alpha1.EnvelopeFromRaw(newObj, rev.Object, kind)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("decode "+kind, err)
+			}
+			out.Body.Items = append(out.Body.Items, revisionItem[T]{
+				Revision:  rev.Revision,
+				Digest:    rev.Digest,
+				CreatedAt: rev.CreatedAt,
+				Current:   rev.Current,
+				Object:    obj,
+			})
+		}
+		return out, nil
+	})
+}
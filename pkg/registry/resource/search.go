@@ -0,0 +1,273 @@
+package resource
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+	// maxSearchOffset bounds how deep a caller can page. Every searched
+	// Store ranks offset+limit rows to merge them, so deep pages cost
+	// linearly; narrow the query instead.
+	maxSearchOffset = 1000
+)
+
+// SearchConfig wires the cross-kind full-text search endpoint.
+type SearchConfig struct {
+	// BasePrefix is the HTTP route prefix shared with the generic resource
+	// handler (e.g. "/v0"). The endpoint mounts at "{BasePrefix}/search".
+	BasePrefix string
+	// Stores maps Kind to its Store. Every Store is searched unless the
+	// caller narrows by kind.
+	Stores map[string]*v1alpha1store.Store
+	// Authorizers and ListFilters are the same per-kind hooks the list
+	// endpoints consult (Verb="list"). A kind whose Authorizer rejects the
+	// caller is left out of the results rather than failing the search;
+	// ListFilter predicates scope each kind's matches and facet counts.
+	Authorizers map[string]func(ctx context.Context, in AuthorizeInput) error
+	ListFilters map[string]func(ctx context.Context, in AuthorizeInput) (string, []any, error)
+}
+
+// SearchQuery is a parsed search request.
+type SearchQuery struct {
+	// Query is a web-search style query: bare words are AND-ed,
+	// "quoted phrases" match in order, `or` separates alternatives and a
+	// leading `-` excludes a word.
+	Query string
+	// Kinds narrows the search to these kinds (canonical names or route
+	// plurals, case-insensitive). Empty searches every kind.
+	Kinds []string
+	// Namespace narrows the search to one namespace. Empty searches every
+	// namespace.
+	Namespace string
+	// Labels narrows matches to rows satisfying the label selector.
+	Labels v1alpha1.LabelSelector
+	Limit  int
+	Offset int
+}
+
+type searchInput struct {
+	Query     string   `query:"q" required:"true" doc:"Search query. Words are AND-ed; \"quoted phrases\", 'or' and '-exclusions' are supported."`
+	Kind      []string `query:"kind,explode" doc:"Restrict to these kinds (e.g. MCPServer or mcpservers). Repeat or comma-separate for several; empty searches every kind."`
+	Namespace string   `query:"namespace" doc:"Restrict to one namespace. Empty or 'all' searches every namespace."`
+	Labels    string   `query:"labels" doc:"Label selector: key=value, key!=value, key in (a,b), key notin (a,b), key and !key, comma-separated."`
+	Limit     int      `query:"limit" doc:"Max hits to return (1-100, default 20)."`
+	Offset    int      `query:"offset" doc:"Number of ranked hits to skip (max 1000)."`
+}
+
+type searchOutput struct {
+	Body arv0.SearchResponse
+}
+
+// RegisterSearch wires GET {BasePrefix}/search: ranked full-text search
+// over every kind's name, title, description, labels and (for Plugins) the
+// scanned inventory, with per-kind and per-label facet counts. Tagged
+// artifacts match on their "latest" tag only.
+func RegisterSearch(api huma.API, cfg SearchConfig) {
+	huma.Register(api, huma.Operation{
+		OperationID: "search",
+		Method:      http.MethodGet,
+		Path:        cfg.BasePrefix + "/search",
+		Summary:     "Full-text search across every resource kind",
+	}, func(ctx context.Context, in *searchInput) (*searchOutput, error) {
+		q := SearchQuery{
+			Query:     in.Query,
+			Namespace: in.Namespace,
+			Limit:     in.Limit,
+			Offset:    in.Offset,
+		}
+		if q.Namespace == namespaceAll {
+			q.Namespace = ""
+		}
+		for _, k := range in.Kind {
+			for part := range strings.SplitSeq(k, ",") {
+				if part = strings.TrimSpace(part); part != "" {
+					q.Kinds = append(q.Kinds, part)
+				}
+			}
+		}
+		if in.Labels != "" {
+			selector, err := v1alpha1.ParseLabelSelector(in.Labels)
+			if err != nil {
+				return nil, huma.Error400BadRequest("invalid labels selector: " + err.Error())
+			}
+			q.Labels = selector
+		}
+		resp, err := Search(ctx, cfg, q)
+		if err != nil {
+			return nil, err
+		}
+		return &searchOutput{Body: *resp}, nil
+	})
+}
+
+// Search runs q against every configured Store and merges the per-kind
+// results by score. Errors are huma errors: 400 for a malformed query or
+// unknown kind, the Authorizer's own error when it rejects every
+// searched kind, 500 for store failures. Shared by the REST endpoint and
+// the MCP search tool.
+func Search(ctx context.Context, cfg SearchConfig, q SearchQuery) (*arv0.SearchResponse, error) {
+	q.Query = strings.TrimSpace(q.Query)
+	if q.Query == "" {
+		return nil, huma.Error400BadRequest("search query is required")
+	}
+	switch {
+	case q.Limit <= 0:
+		q.Limit = defaultSearchLimit
+	case q.Limit > maxSearchLimit:
+		q.Limit = maxSearchLimit
+	}
+	if q.Offset < 0 || q.Offset > maxSearchOffset {
+		return nil, huma.Error400BadRequest(fmt.Sprintf("offset must be between 0 and %d", maxSearchOffset))
+	}
+	kinds, err := searchKinds(cfg.Stores, q.Kinds)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &arv0.SearchResponse{
+		Query: q.Query,
+		Hits:  []arv0.SearchHit{},
+		Facets: arv0.SearchFacets{
+			Kinds:  map[string]int{},
+			Labels: map[string]map[string]int{},
+		},
+	}
+	var (
+		denied   error
+		searched int
+	)
+	for _, kind := range kinds {
+		in := AuthorizeInput{Verb: "list", Kind: kind, Namespace: q.Namespace}
+		if authorize := cfg.Authorizers[kind]; authorize != nil {
+			if err := authorize(ctx, in); err != nil {
+				if denied == nil {
+					denied = err
+				}
+				continue
+			}
+		}
+		opts := v1alpha1store.SearchOpts{
+			Query:         q.Query,
+			Namespace:     q.Namespace,
+			LabelSelector: q.Labels,
+			LatestOnly:    true,
+			Limit:         q.Offset + q.Limit,
+		}
+		if filter := cfg.ListFilters[kind]; filter != nil {
+			extra, extraArgs, err := filter(ctx, in)
+			if err != nil {
+				return nil, err
+			}
+			opts.ExtraWhere = extra
+			opts.ExtraArgs = extraArgs
+		}
+		res, err := cfg.Stores[kind].Search(ctx, opts)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("search "+kind, err)
+		}
+		searched++
+		resp.Total += res.Total
+		if res.Total > 0 {
+			resp.Facets.Kinds[kind] = res.Total
+		}
+		for key, values := range res.LabelFacets {
+			if resp.Facets.Labels[key] == nil {
+				resp.Facets.Labels[key] = map[string]int{}
+			}
+			for value, n := range values {
+				resp.Facets.Labels[key][value] += n
+			}
+		}
+		for _, hit := range res.Hits {
+			resp.Hits = append(resp.Hits, searchHitFromStore(kind, hit))
+		}
+	}
+	if searched == 0 && denied != nil {
+		return nil, denied
+	}
+
+	slices.SortStableFunc(resp.Hits, func(a, b arv0.SearchHit) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+	if q.Offset >= len(resp.Hits) {
+		resp.Hits = resp.Hits[:0]
+	} else {
+		resp.Hits = resp.Hits[q.Offset:min(len(resp.Hits), q.Offset+q.Limit)]
+	}
+	return resp, nil
+}
+
+// searchKinds resolves the caller's kind filter against the configured
+// Stores, accepting canonical kind names and route plurals in any case.
+// The result is sorted so facet and tie-break order is stable.
+func searchKinds(stores map[string]*v1alpha1store.Store, requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return slices.Sorted(maps.Keys(stores)), nil
+	}
+	var out []string
+	for _, raw := range requested {
+		kind, ok := lookupSearchKind(stores, raw)
+		if !ok {
+			return nil, huma.Error400BadRequest(fmt.Sprintf("unknown kind %q", raw))
+		}
+		if !slices.Contains(out, kind) {
+			out = append(out, kind)
+		}
+	}
+	slices.Sort(out)
+	return out, nil
+}
+
+func lookupSearchKind(stores map[string]*v1alpha1store.Store, raw string) (string, bool) {
+	for kind := range stores {
+		if strings.EqualFold(kind, raw) {
+			return kind, true
+		}
+		if d, ok := v1alpha1.DefaultKindRegistry.Lookup(kind); ok && strings.EqualFold(d.Plural, raw) {
+			return kind, true
+		}
+	}
+	return "", false
+}
+
+// searchHitFromStore projects a Store hit onto the wire summary. Title and
+// description are read leniently from the spec: kinds without them (or a
+// spec that fails to decode) simply leave them empty.
+func searchHitFromStore(kind string, hit v1alpha1store.SearchHit) arv0.SearchHit {
+	var spec struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	}
+	_ = json.Unmarshal(hit.Object.Spec, &spec)
+	return arv0.SearchHit{
+		Kind:        kind,
+		Namespace:   hit.Object.Metadata.Namespace,
+		Name:        hit.Object.Metadata.Name,
+		Tag:         hit.Object.Metadata.Tag,
+		Title:       spec.Title,
+		Description: spec.Description,
+		Labels:      hit.Object.Metadata.Labels,
+		Score:       hit.Rank,
+		Highlight:   hit.Highlight,
+	}
+}
@@ -0,0 +1,103 @@
+//go:build integration
+
+package resource_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/require"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+func TestRegisterSearch_MergesKindsWithFacets(t *testing.T) {
+	db := v1alpha1store.NewTestDB(t)
+	agents := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
+	servers := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "mcp_servers")
+	ctx := t.Context()
+
+	_, err := agents.Upsert(ctx, &v1alpha1.Agent{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "forecaster", Labels: map[string]string{"team": "climate"}},
+		Spec:     v1alpha1.AgentSpec{Title: "Weather forecaster", Description: "Summarizes the weather."},
+	})
+	require.NoError(t, err)
+	_, err = servers.Upsert(ctx, &v1alpha1.MCPServer{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "team-a", Name: "io.example/weather", Labels: map[string]string{"team": "climate"}},
+		Spec:     v1alpha1.MCPServerSpec{Description: "Current conditions and weather alerts."},
+	})
+	require.NoError(t, err)
+	_, err = servers.Upsert(ctx, &v1alpha1.MCPServer{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "io.example/github"},
+		Spec:     v1alpha1.MCPServerSpec{Description: "Issues and pull requests."},
+	})
+	require.NoError(t, err)
+
+	cfg := resource.SearchConfig{
+		BasePrefix: "/v0",
+		Stores: map[string]*v1alpha1store.Store{
+			v1alpha1.KindAgent:     agents,
+			v1alpha1.KindMCPServer: servers,
+		},
+	}
+	_, api := humatest.New(t)
+	resource.RegisterSearch(api, cfg)
+
+	search := func(query string) arv0.SearchResponse {
+		t.Helper()
+		resp := api.Get("/v0/search?" + query)
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+		var out arv0.SearchResponse
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &out))
+		return out
+	}
+
+	out := search("q=weather")
+	require.Equal(t, 2, out.Total)
+	require.Len(t, out.Hits, 2)
+	require.Equal(t, "forecaster", out.Hits[0].Name, "title matches outrank description matches")
+	require.Equal(t, "Weather forecaster", out.Hits[0].Title)
+	require.Equal(t, map[string]int{v1alpha1.KindAgent: 1, v1alpha1.KindMCPServer: 1}, out.Facets.Kinds)
+	require.Equal(t, map[string]map[string]int{"team": {"climate": 2}}, out.Facets.Labels)
+
+	out = search("q=weather&kind=mcpservers&namespace=team-a")
+	require.Equal(t, 1, out.Total)
+	require.Equal(t, "io.example/weather", out.Hits[0].Name)
+	require.Contains(t, out.Hits[0].Highlight, "<mark>weather</mark>")
+
+	out = search("q=weather&limit=1&offset=1")
+	require.Equal(t, 2, out.Total, "total ignores paging")
+	require.Len(t, out.Hits, 1)
+	require.Equal(t, "io.example/weather", out.Hits[0].Name)
+
+	resp := api.Get("/v0/search?q=weather&kind=Widget")
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+
+	// Kinds the caller may not list drop out; ListFilter scopes the rest.
+	cfg.Authorizers = map[string]func(context.Context, resource.AuthorizeInput) error{
+		v1alpha1.KindAgent: func(context.Context, resource.AuthorizeInput) error {
+			return huma.Error403Forbidden("no agents for you")
+		},
+	}
+	cfg.ListFilters = map[string]func(context.Context, resource.AuthorizeInput) (string, []any, error){
+		v1alpha1.KindMCPServer: func(context.Context, resource.AuthorizeInput) (string, []any, error) {
+			return "namespace = $1", []any{"default"}, nil
+		},
+	}
+	got, err := resource.Search(ctx, cfg, resource.SearchQuery{Query: "weather or github"})
+	require.NoError(t, err)
+	require.Equal(t, 1, got.Total)
+	require.Equal(t, "io.example/github", got.Hits[0].Name)
+
+	_, err = resource.Search(ctx, cfg, resource.SearchQuery{Query: "weather", Kinds: []string{"agent"}})
+	var se huma.StatusError
+	require.ErrorAs(t, err, &se)
+	require.Equal(t, http.StatusForbidden, se.GetStatus(), "a search denied on every kind surfaces the denial")
+}
@@ -0,0 +1,184 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
+)
+
+// SignatureVerifier checks that the content of a tagged artifact, named by
+// its content digest, carries a signature the signature policy of its
+// namespace accepts. It returns nil when the policy does not cover kind and
+// an error wrapping v1alpha1store.ErrUnsigned when it does and no trusted
+// signature verifies.
+type SignatureVerifier func(ctx context.Context, kind, namespace, name, digest string) error
+
+// NewSignatureVerifier returns a SignatureVerifier over stores. A
+// Namespace's spec.signaturePolicy tightens serverPolicy for the artifacts
+// in that namespace; see v1alpha1.SignaturePolicy.Within.
+func NewSignatureVerifier(stores map[string]*v1alpha1store.Store, serverPolicy v1alpha1.SignaturePolicy) SignatureVerifier {
+	return func(ctx context.Context, kind, namespace, name, digest string) error {
+		policy, err := namespaceSignaturePolicy(ctx, stores, namespace, serverPolicy)
+		if err != nil {
+			return err
+		}
+		if !policy.Requires(kind) {
+			return nil
+		}
+		store := stores[kind]
+		if !store.Signatures() {
+			return fmt.Errorf("%w: %s keeps no signatures", v1alpha1store.ErrUnsigned, kind)
+		}
+		sigs, err := store.ListSignatures(ctx, namespace, name, digest)
+		if err != nil {
+			return err
+		}
+		_, err = v1alpha1store.VerifyTrusted(policy, digest, sigs)
+		return err
+	}
+}
+
+// namespaceSignaturePolicy returns serverPolicy, tightened by the
+// Namespace's spec.signaturePolicy when it sets one.
+func namespaceSignaturePolicy(ctx context.Context, stores map[string]*v1alpha1store.Store, namespace string, serverPolicy v1alpha1.SignaturePolicy) (v1alpha1.SignaturePolicy, error) {
+	spec, err := namespaceSpec(ctx, stores, namespace)
+	if err != nil {
+		return v1alpha1.SignaturePolicy{}, err
+	}
+	if spec == nil || spec.SignaturePolicy == nil {
+		return serverPolicy, nil
+	}
+	return spec.SignaturePolicy.Within(serverPolicy), nil
+}
+
+type listSignaturesInput struct {
+	Namespace string `query:"namespace" doc:"Namespace (defaults to 'default')."`
+	Name      string `path:"name"`
+	Tag       string `query:"tag" doc:"List the signatures of the content this tag holds now. Defaults to 'latest'; ignored when digest is set."`
+	Digest    string `query:"digest" doc:"List the signatures of this content digest (sha256:<hex>) instead of a tag's."`
+}
+
+// signatureItem is one detached signature over a content digest.
+type signatureItem struct {
+	KeyID     string    `json:"keyId" doc:"Hex SHA-256 of the signing key's PKIX encoding."`
+	PublicKey string    `json:"publicKey" doc:"PEM public key the signature verifies against."`
+	Signature string    `json:"signature" doc:"Base64 signature of the digest string."`
+	CreatedAt time.Time `json:"createdAt" doc:"When the signature was recorded."`
+}
+
+type signatureListOutput struct {
+	Body struct {
+		Digest string          `json:"digest" doc:"Content digest the signatures cover."`
+		Items  []signatureItem `json:"items"`
+	}
+}
+
+type addSignatureInput struct {
+	Namespace string `query:"namespace" doc:"Namespace (defaults to 'default')."`
+	Name      string `path:"name"`
+	Body      struct {
+		Digest    string `json:"digest" doc:"Content digest (sha256:<hex>) being signed, as reported by apply or the revisions endpoint."`
+		PublicKey string `json:"publicKey" doc:"PEM public key (Ed25519 or ECDSA) the signature verifies against."`
+		Signature string `json:"signature" doc:"Base64 signature of the digest string, e.g. from cosign sign-blob."`
+	}
+}
+
+type signatureOutput struct {
+	Body signatureItem
+}
+
+// registerSignatures wires GET and POST /{name}/signatures for a
+// tagged-artifact kind. Signatures attach to content, not tags: a digest
+// may be signed before it is first applied, and a signature follows its
+// content to every tag and revision that holds it. Registered before the
+// get-exact route so the literal "signatures" segment wins over `{tag}`.
+func registerSignatures(api huma.API, cfg Config, kind, itemPath string) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-signatures-" + strings.ToLower(kind),
+		Method:      http.MethodGet,
+		Path:        itemPath + "/signatures",
+		Summary:     fmt.Sprintf("List the signatures of a %s's content", kind),
+	}, func(ctx context.Context, in *listSignaturesInput) (*signatureListOutput, error) {
+		ns := resolveNamespace(in.Namespace, false)
+		name, err := unescapePath("name", in.Name)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Authorize != nil {
+			if err := cfg.Authorize(ctx, AuthorizeInput{Verb: "get", Kind: kind, Namespace: ns, Name: name, Tag: in.Tag}); err != nil {
+				return nil, err
+			}
+		}
+		if !cfg.Store.Signatures() {
+			return nil, huma.Error501NotImplemented(fmt.Sprintf("%s keeps no signatures", kind))
+		}
+		digest := in.Digest
+		if digest == "" {
+			digest, err = cfg.Store.TagDigest(ctx, ns, name, in.Tag)
+			if err != nil {
+				return nil, mapNotFound(err, kind, ns, name, in.Tag)
+			}
+		}
+		sigs, err := cfg.Store.ListSignatures(ctx, ns, name, digest)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("list signatures of "+kind, err)
+		}
+		out := &signatureListOutput{}
+		out.Body.Digest = digest
+		out.Body.Items = make([]signatureItem, 0, len(sigs))
+		for _, sig := range sigs {
+			out.Body.Items = append(out.Body.Items, toSignatureItem(sig))
+		}
+		return out, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "add-signature-" + strings.ToLower(kind),
+		Method:      http.MethodPost,
+		Path:        itemPath + "/signatures",
+		Summary:     fmt.Sprintf("Attach a detached signature to a %s's content", kind),
+	}, func(ctx context.Context, in *addSignatureInput) (*signatureOutput, error) {
+		ns := resolveNamespace(in.Namespace, false)
+		name, err := unescapePath("name", in.Name)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Authorize != nil {
+			if err := cfg.Authorize(ctx, AuthorizeInput{Verb: "sign", Kind: kind, Namespace: ns, Name: name}); err != nil {
+				return nil, err
+			}
+		}
+		sig, err := cfg.Store.AddSignature(ctx, ns, name, v1alpha1store.Signature{
+			Digest:    in.Body.Digest,
+			PublicKey: in.Body.PublicKey,
+			Signature: in.Body.Signature,
+		})
+		switch {
+		case errors.Is(err, v1alpha1store.ErrSignaturesDisabled):
+			return nil, huma.Error501NotImplemented(fmt.Sprintf("%s keeps no signatures", kind))
+		case errors.Is(err, v1alpha1.ErrInvalidFormat), errors.Is(err, signing.ErrInvalidSignature):
+			return nil, huma.Error400BadRequest(err.Error())
+		case err != nil:
+			return nil, huma.Error500InternalServerError("add signature to "+kind, err)
+		}
+		return &signatureOutput{Body: toSignatureItem(*sig)}, nil
+	})
+}
+
+func toSignatureItem(sig v1alpha1store.Signature) signatureItem {
+	return signatureItem{
+		KeyID:     sig.KeyID,
+		PublicKey: sig.PublicKey,
+		Signature: sig.Signature,
+		CreatedAt: sig.CreatedAt,
+	}
+}
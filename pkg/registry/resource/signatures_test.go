@@ -0,0 +1,119 @@
+//go:build integration
+
+package resource_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/require"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
+)
+
+func TestSignatures_RequiredPolicyGatesApply(t *testing.T) {
+	db := v1alpha1store.NewTestDB(t)
+	schema := v1alpha1store.TestSchema()
+	agents := v1alpha1store.NewStore(db, schema, "agents", v1alpha1store.WithSignatures(schema))
+	namespaces := v1alpha1store.NewMutableObjectStore(db, schema, "namespaces")
+	stores := map[string]*v1alpha1store.Store{v1alpha1.KindAgent: agents, v1alpha1.KindNamespace: namespaces}
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	pubPEM, err := signing.MarshalPublicKey(pub)
+	require.NoError(t, err)
+	policy := v1alpha1.SignaturePolicy{Required: true, TrustedKeys: []v1alpha1.TrustedKey{{Name: "release", PublicKey: pubPEM}}}
+
+	_, api := humatest.New(t)
+	resource.Register[*v1alpha1.Agent](api, resource.Config{
+		Kind:       v1alpha1.KindAgent,
+		BasePrefix: "/v0",
+		Store:      agents,
+	}, func() *v1alpha1.Agent { return &v1alpha1.Agent{} })
+	resource.RegisterApply(api, resource.ApplyConfig{
+		BasePrefix:      "/v0",
+		Stores:          stores,
+		VerifySignature: resource.NewSignatureVerifier(stores, policy),
+	})
+
+	doc := func(namespace string) string {
+		return `apiVersion: ar.dev/v1alpha1
+kind: Agent
+metadata:
+  namespace: ` + namespace + `
+  name: alice
+  tag: 1.0.0
+spec:
+  title: one
+`
+	}
+
+	unsigned := applyAgentYAML(t, api, doc("default"))
+	require.Equal(t, arv0.ApplyStatusFailed, unsigned.Status)
+	require.Equal(t, arv0.ApplyReasonSignatureRequired, unsigned.Reason)
+	require.NotEmpty(t, unsigned.Digest, "the refusal names the content to sign")
+
+	sig, err := signing.Sign(priv, unsigned.Digest)
+	require.NoError(t, err)
+	resp := api.Post("/v0/agents/alice/signatures", map[string]string{
+		"digest": unsigned.Digest, "publicKey": pubPEM, "signature": sig,
+	})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	resp = api.Post("/v0/agents/alice/signatures", map[string]string{
+		"digest": unsigned.Digest, "publicKey": pubPEM, "signature": "bm90IGEgc2lnbmF0dXJl",
+	})
+	require.Equal(t, http.StatusBadRequest, resp.Code, resp.Body.String())
+
+	signed := applyAgentYAML(t, api, doc("default"))
+	require.Equal(t, arv0.ApplyStatusCreated, signed.Status, signed.Error)
+	require.Equal(t, unsigned.Digest, signed.Digest)
+
+	resp = api.Get("/v0/agents/alice/signatures?tag=1.0.0")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var listed struct {
+		Digest string `json:"digest"`
+		Items  []struct {
+			KeyID string `json:"keyId"`
+		} `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &listed))
+	require.Equal(t, signed.Digest, listed.Digest)
+	require.Len(t, listed.Items, 1)
+
+	// A Namespace writer cannot weaken the server policy: turning it off or
+	// trusting a key of their own leaves unsigned content refused.
+	otherPub, otherPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherPEM, err := signing.MarshalPublicKey(otherPub)
+	require.NoError(t, err)
+	_, err = namespaces.Upsert(context.Background(), &v1alpha1.Namespace{
+		TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindNamespace},
+		Metadata: v1alpha1.ObjectMeta{Namespace: v1alpha1.DefaultNamespace, Name: "sandbox"},
+		Spec: v1alpha1.NamespaceSpec{SignaturePolicy: &v1alpha1.SignaturePolicy{
+			TrustedKeys: []v1alpha1.TrustedKey{{Name: "mine", PublicKey: otherPEM}},
+		}},
+	})
+	require.NoError(t, err)
+	weakened := applyAgentYAML(t, api, doc("sandbox"))
+	require.Equal(t, arv0.ApplyStatusFailed, weakened.Status)
+	require.Equal(t, arv0.ApplyReasonSignatureRequired, weakened.Reason)
+
+	otherSig, err := signing.Sign(otherPriv, weakened.Digest)
+	require.NoError(t, err)
+	resp = api.Post("/v0/agents/alice/signatures?namespace=sandbox", map[string]string{
+		"digest": weakened.Digest, "publicKey": otherPEM, "signature": otherSig,
+	})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	selfSigned := applyAgentYAML(t, api, doc("sandbox"))
+	require.Equal(t, arv0.ApplyReasonSignatureRequired, selfSigned.Reason, "a key only the Namespace trusts is ignored")
+}
@@ -0,0 +1,226 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+type deprecateInput struct {
+	Namespace string `query:"namespace" doc:"Namespace (defaults to 'default')."`
+	Name      string `path:"name"`
+	Tag       string `path:"tag"`
+	Body      struct {
+		Message     string          `json:"message,omitempty" doc:"Why the tag is deprecated."`
+		Replacement *replacementRef `json:"replacement,omitempty" doc:"Ref to move to."`
+	}
+}
+
+// replacementRef is the ref a deprecation points users at. Unlike a
+// ResourceRef, Kind and Namespace are optional here and default to those of
+// the deprecated tag.
+type replacementRef struct {
+	Kind      string `json:"kind,omitempty" doc:"Defaults to the deprecated tag's kind."`
+	Namespace string `json:"namespace,omitempty" doc:"Defaults to the deprecated tag's namespace."`
+	Name      string `json:"name"`
+	Tag       string `json:"tag,omitempty"`
+}
+
+type yankInput struct {
+	Namespace string `query:"namespace" doc:"Namespace (defaults to 'default')."`
+	Name      string `path:"name"`
+	Tag       string `path:"tag"`
+	Body      struct {
+		Reason string `json:"reason,omitempty" doc:"Why the tag is yanked."`
+	}
+}
+
+// registerTagMarkers wires PUT and DELETE /{name}/{tag}/deprecation and
+// /{name}/{tag}/yank for a tagged-artifact kind. The markers are set on the
+// tag rather than its content: deleting the tag would break the refs that
+// name it, while a marker keeps them resolving and tells their users.
+// Marking needs the "deprecate" or "yank" verb; each returns the marked
+// tag.
+func registerTagMarkers[T v1alpha1.Object](api huma.API, cfg Config, newObj func() T, kind, itemTagPath string) {
+	huma.Register(api, huma.Operation{
+		OperationID: "deprecate-" + strings.ToLower(kind),
+		Method:      http.MethodPut,
+		Path:        itemTagPath + "/deprecation",
+		Summary:     fmt.Sprintf("Deprecate a %s tag", kind),
+	}, func(ctx context.Context, in *deprecateInput) (*bodyOutput[T], error) {
+		return setTagMarker(ctx, cfg, newObj, kind, "deprecate", in.Namespace, in.Name, in.Tag,
+			func(ns, name, tag string) (*v1alpha1.RawObject, error) {
+				d := &v1alpha1.Deprecation{Message: in.Body.Message}
+				if r := in.Body.Replacement; r != nil {
+					if r.Name == "" {
+						return nil, huma.Error400BadRequest("replacement.name is required")
+					}
+					replacement := v1alpha1.ResourceRef(*r)
+					if replacement.Kind == "" {
+						replacement.Kind = kind
+					}
+					if replacement.Namespace == "" {
+						replacement.Namespace = ns
+					}
+					d.Replacement = &replacement
+				}
+				return cfg.Store.SetDeprecation(ctx, ns, name, tag, d)
+			})
+	})
+	huma.Register(api, huma.Operation{
+		OperationID: "undeprecate-" + strings.ToLower(kind),
+		Method:      http.MethodDelete,
+		Path:        itemTagPath + "/deprecation",
+		Summary:     fmt.Sprintf("Clear the deprecation of a %s tag", kind),
+	}, func(ctx context.Context, in *getInput) (*bodyOutput[T], error) {
+		return setTagMarker(ctx, cfg, newObj, kind, "deprecate", in.Namespace, in.Name, in.Tag,
+			func(ns, name, tag string) (*v1alpha1.RawObject, error) {
+				return cfg.Store.SetDeprecation(ctx, ns, name, tag, nil)
+			})
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "yank-" + strings.ToLower(kind),
+		Method:      http.MethodPut,
+		Path:        itemTagPath + "/yank",
+		Summary:     fmt.Sprintf("Yank a %s tag", kind),
+	}, func(ctx context.Context, in *yankInput) (*bodyOutput[T], error) {
+		return setTagMarker(ctx, cfg, newObj, kind, "yank", in.Namespace, in.Name, in.Tag,
+			func(ns, name, tag string) (*v1alpha1.RawObject, error) {
+				return cfg.Store.SetYank(ctx, ns, name, tag, &v1alpha1.Yank{Reason: in.Body.Reason})
+			})
+	})
+	huma.Register(api, huma.Operation{
+		OperationID: "unyank-" + strings.ToLower(kind),
+		Method:      http.MethodDelete,
+		Path:        itemTagPath + "/yank",
+		Summary:     fmt.Sprintf("Un-yank a %s tag", kind),
+	}, func(ctx context.Context, in *getInput) (*bodyOutput[T], error) {
+		return setTagMarker(ctx, cfg, newObj, kind, "yank", in.Namespace, in.Name, in.Tag,
+			func(ns, name, tag string) (*v1alpha1.RawObject, error) {
+				return cfg.Store.SetYank(ctx, ns, name, tag, nil)
+			})
+	})
+}
+
+// setTagMarker authorizes verb on one tag, runs set and decodes the
+// marked row.
+func setTagMarker[T v1alpha1.Object](
+	ctx context.Context,
+	cfg Config,
+	newObj func() T,
+	kind, verb, namespace, rawName, rawTag string,
+	set func(ns, name, tag string) (*v1alpha1.RawObject, error),
+) (*bodyOutput[T], error) {
+	ns := resolveNamespace(namespace, false)
+	name, err := unescapePath("name", rawName)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := unescapePath("tag", rawTag)
+	if err != nil {
+		return nil, err
+	}
+	if v1alpha1.IsTagRange(tag) {
+		return nil, huma.Error400BadRequest(fmt.Sprintf("tag %q is a range; %s a literal tag", tag, verb))
+	}
+	if cfg.Authorize != nil {
+		if err := cfg.Authorize(ctx, AuthorizeInput{Verb: verb, Kind: kind, Namespace: ns, Name: name, Tag: tag}); err != nil {
+			return nil, err
+		}
+	}
+	row, err := set(ns, name, tag)
+	if err != nil {
+		var se huma.StatusError
+		switch {
+		case errors.As(err, &se):
+			return nil, err
+		case errors.Is(err, v1alpha1store.ErrTagMarkersDisabled):
+			return nil, huma.Error501NotImplemented(fmt.Sprintf("%s keeps no tag markers", kind))
+		}
+		return nil, mapNotFound(err, kind, ns, name, tag)
+	}
+	obj, err := v1alpha1.EnvelopeFromRaw(newObj, row, kind)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("decode "+kind, err)
+	}
+	return &bodyOutput[T]{Body: obj}, nil
+}
+
+// refWarnings records the refs an apply resolves and reports the deprecated
+// or yanked tags among them. Yanked tags are reported too: a ref pinned to
+// one exactly still resolves.
+type refWarnings struct {
+	stores map[string]*v1alpha1store.Store
+	refs   []v1alpha1.ResourceRef
+}
+
+// wrap returns resolver recording every ref it resolves, or nil for a nil
+// resolver.
+func (w *refWarnings) wrap(resolver v1alpha1.ResolverFunc) v1alpha1.ResolverFunc {
+	if resolver == nil {
+		return nil
+	}
+	return func(ctx context.Context, ref v1alpha1.ResourceRef) error {
+		err := resolver(ctx, ref)
+		if err == nil {
+			w.refs = append(w.refs, ref)
+		}
+		return err
+	}
+}
+
+// warnings describes the marked tags the recorded refs select, in ref
+// order. Lookup errors drop the warning rather than fail the apply.
+func (w *refWarnings) warnings(ctx context.Context) []string {
+	var out []string
+	for _, ref := range w.refs {
+		store := w.stores[ref.Kind]
+		if !store.TagMarkers() {
+			continue
+		}
+		row, err := store.GetUsableByRef(ctx, ref.Namespace, ref.Name, ref.Tag)
+		if err != nil {
+			continue
+		}
+		if warning := TagMarkerWarning(ref.Kind, &row.Metadata); warning != "" {
+			out = append(out, warning)
+		}
+	}
+	return out
+}
+
+// TagMarkerWarning describes the deprecation or yank of a tag for users of
+// a ref to it, or returns "" when the tag is not marked.
+func TagMarkerWarning(kind string, meta *v1alpha1.ObjectMeta) string {
+	target := fmt.Sprintf("%s %s/%s:%s", kind, meta.NamespaceOrDefault(), meta.Name, meta.Tag)
+	switch {
+	case meta.Yanked != nil:
+		warning := target + " is yanked"
+		if meta.Yanked.Reason != "" {
+			warning += ": " + meta.Yanked.Reason
+		}
+		return warning
+	case meta.Deprecated != nil:
+		warning := target + " is deprecated"
+		if meta.Deprecated.Message != "" {
+			warning += ": " + meta.Deprecated.Message
+		}
+		if r := meta.Deprecated.Replacement; r != nil {
+			warning += fmt.Sprintf(" (use %s %s/%s", r.Kind, r.Namespace, r.Name)
+			if r.Tag != "" {
+				warning += ":" + r.Tag
+			}
+			warning += ")"
+		}
+		return warning
+	}
+	return ""
+}
@@ -0,0 +1,106 @@
+//go:build integration
+
+package resource_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/require"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+func TestTagMarkers_EndpointsAndApplyWarnings(t *testing.T) {
+	ctx := context.Background()
+	db := v1alpha1store.NewTestDB(t)
+	schema := v1alpha1store.TestSchema()
+	agents := v1alpha1store.NewStore(db, schema, "agents", v1alpha1store.WithTagMarkers())
+	mcps := v1alpha1store.NewStore(db, schema, "mcp_servers", v1alpha1store.WithTagMarkers())
+	stores := map[string]*v1alpha1store.Store{v1alpha1.KindAgent: agents, v1alpha1.KindMCPServer: mcps}
+
+	for _, tag := range []string{"1.0.0", "2.0.0"} {
+		_, err := mcps.Upsert(ctx, &v1alpha1.MCPServer{
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "tools", Tag: tag},
+			Spec:     v1alpha1.MCPServerSpec{Title: "Tools " + tag},
+		})
+		require.NoError(t, err)
+	}
+
+	_, api := humatest.New(t)
+	resource.Register[*v1alpha1.MCPServer](api, resource.Config{
+		Kind:       v1alpha1.KindMCPServer,
+		BasePrefix: "/v0",
+		Store:      mcps,
+	}, func() *v1alpha1.MCPServer { return &v1alpha1.MCPServer{} })
+	resource.RegisterApply(api, resource.ApplyConfig{
+		BasePrefix: "/v0",
+		Stores:     stores,
+		Resolver: func(ctx context.Context, ref v1alpha1.ResourceRef) error {
+			_, err := stores[ref.Kind].GetUsableByRef(ctx, ref.Namespace, ref.Name, ref.Tag)
+			return err
+		},
+	})
+
+	resp := api.Put("/v0/mcpservers/tools/1.0.0/deprecation", map[string]any{
+		"message":     "moved to 2.x",
+		"replacement": map[string]any{"name": "tools", "tag": "2.0.0"},
+	})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var marked v1alpha1.MCPServer
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &marked))
+	require.NotNil(t, marked.Metadata.Deprecated)
+	require.Equal(t, v1alpha1.ResourceRef{Kind: v1alpha1.KindMCPServer, Namespace: "default", Name: "tools", Tag: "2.0.0"},
+		*marked.Metadata.Deprecated.Replacement, "replacement kind and namespace default to the tag's")
+
+	resp = api.Put("/v0/mcpservers/tools/2.0.0/yank", map[string]any{"reason": "crashes"})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	resp = api.Put("/v0/mcpservers/tools/%5E1.0.0/yank", map[string]any{})
+	require.Equal(t, http.StatusBadRequest, resp.Code, "ranges cannot be marked: %s", resp.Body.String())
+	resp = api.Put("/v0/mcpservers/tools/9.9.9/yank", map[string]any{})
+	require.Equal(t, http.StatusNotFound, resp.Code, resp.Body.String())
+
+	apply := func(tag string) arv0.ApplyResult {
+		t.Helper()
+		yaml := `apiVersion: ar.dev/v1alpha1
+kind: Agent
+metadata:
+  name: alice
+spec:
+  title: Alice
+  mcpServers:
+    - kind: MCPServer
+      name: tools
+      tag: "` + tag + `"
+`
+		resp := api.Post("/v0/apply", "Content-Type: application/yaml", strings.NewReader(yaml))
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+		var out arv0.ApplyResultsResponse
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &out))
+		require.Len(t, out.Results, 1)
+		require.NotEqual(t, arv0.ApplyStatusFailed, out.Results[0].Status, out.Results[0].Error)
+		return out.Results[0]
+	}
+
+	got := apply("1.0.0")
+	require.Equal(t, []string{"MCPServer default/tools:1.0.0 is deprecated: moved to 2.x (use MCPServer default/tools:2.0.0)"}, got.Warnings)
+	got = apply("2.0.0")
+	require.Equal(t, []string{"MCPServer default/tools:2.0.0 is yanked: crashes"}, got.Warnings)
+	got = apply("^1.0.0")
+	require.Len(t, got.Warnings, 1, "a range resolving to a deprecated tag warns too")
+
+	resp = api.Delete("/v0/mcpservers/tools/1.0.0/deprecation")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	resp = api.Delete("/v0/mcpservers/tools/2.0.0/yank")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	got = apply("1.0.0")
+	require.Empty(t, got.Warnings)
+}
@@ -0,0 +1,486 @@
+package resource
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+const (
+	defaultWatchPollInterval       = time.Second
+	defaultWatchWakeupPollInterval = 30 * time.Second
+	defaultWatchBookmarkInterval   = 30 * time.Second
+	watchEventBatchLimit           = 500
+	watchSnapshotPageSize          = 500
+
+	watchContentTypeJSON = "application/json"
+	watchContentTypeSSE  = "text/event-stream"
+)
+
+// WatchEventSource is the revision-ordered change log a watch replays.
+// *v1alpha1store.ControlPlaneEventStore satisfies it.
+type WatchEventSource interface {
+	ListAfter(ctx context.Context, afterRevision int64, limit int) ([]v1alpha1store.ControlPlaneEvent, error)
+	OldestRevision(ctx context.Context) (revision int64, ok bool, err error)
+	CurrentRevision(ctx context.Context) (int64, error)
+}
+
+// WatchConfig wires ?watch=true on list routes. One value is shared by
+// every kind.
+type WatchConfig struct {
+	// Events is the control-plane event log streams replay. Required.
+	Events WatchEventSource
+	// Wakeups, when set, subscribes a stream to commit notifications
+	// (e.g. v1alpha1store.ControlPlaneBroadcaster.Subscribe) so changes
+	// are delivered as soon as they commit. nil falls back to polling.
+	Wakeups func() (<-chan struct{}, func())
+	// PollInterval is how often a stream re-reads the event log without a
+	// wakeup. Defaults to 1s, or 30s when Wakeups is set, where it only
+	// bounds the delay of a missed notification.
+	PollInterval time.Duration
+	// BookmarkInterval is how often a stream reports the revision it has
+	// read up to. Bookmarks double as keep-alives through idle-timeout
+	// proxies. Defaults to 30s.
+	BookmarkInterval time.Duration
+}
+
+// watchMiddleware serves ?watch=true on a list route. It runs in front of
+// the typed list handler because a stream has no fixed response body for
+// Huma to encode; every other request falls through to the handler.
+//
+// Events come from the control-plane event log, so a watch sees exactly
+// the changes controllers see: spec, label, annotation, finalizer and
+// deletion changes. Status-only writes are not events.
+func watchMiddleware[T v1alpha1.Object](api huma.API, cfg Config, newObj func() T) func(huma.Context, func(huma.Context)) {
+	return func(hctx huma.Context, next func(huma.Context)) {
+		if watch, _ := strconv.ParseBool(hctx.Query("watch")); !watch {
+			next(hctx)
+			return
+		}
+		if err := serveWatch(hctx, cfg, newObj); err != nil {
+			status := http.StatusInternalServerError
+			var se huma.StatusError
+			if errors.As(err, &se) {
+				status = se.GetStatus()
+			}
+			_ = huma.WriteErr(api, hctx, status, err.Error())
+		}
+	}
+}
+
+// serveWatch validates the request and streams until the client goes
+// away. Errors returned before the stream starts become regular HTTP
+// error responses; later failures end the stream with an ERROR event.
+func serveWatch[T v1alpha1.Object](hctx huma.Context, cfg Config, newObj func() T) error {
+	if cfg.Watch == nil || cfg.Watch.Events == nil {
+		return huma.NewError(http.StatusNotImplemented, "watch is not enabled on this server")
+	}
+	ctx := hctx.Context()
+	p, err := watchListParams(hctx, cfg)
+	if err != nil {
+		return err
+	}
+	if cfg.Authorize != nil {
+		if err := cfg.Authorize(ctx, AuthorizeInput{Verb: "list", Kind: cfg.Kind, Namespace: p.Namespace}); err != nil {
+			return err
+		}
+	}
+	opts, err := listOptsFor(ctx, cfg, p)
+	if err != nil {
+		return err
+	}
+
+	sse := strings.Contains(hctx.Header("Accept"), watchContentTypeSSE)
+	rawVersion := hctx.Query("resourceVersion")
+	if rawVersion == "" && sse {
+		// EventSource reconnects resume from the last event id.
+		rawVersion = hctx.Header("Last-Event-ID")
+	}
+	w := &watcher[T]{
+		cfg:    cfg,
+		newObj: newObj,
+		opts:   opts,
+		seen:   map[v1alpha1store.ResourceKey]bool{},
+	}
+	if rawVersion != "" {
+		if w.cursor, err = strconv.ParseInt(rawVersion, 10, 64); err != nil || w.cursor < 0 {
+			return huma.Error400BadRequest(fmt.Sprintf("invalid resourceVersion %q", rawVersion))
+		}
+		if err := w.checkRetained(ctx); err != nil {
+			return err
+		}
+	} else if w.cursor, err = cfg.Watch.Events.CurrentRevision(ctx); err != nil {
+		return huma.Error500InternalServerError("watch "+cfg.Kind, err)
+	}
+
+	// Subscribe before the first read so a commit racing the snapshot
+	// still wakes the stream.
+	var wakeups <-chan struct{}
+	if cfg.Watch.Wakeups != nil {
+		ch, unsubscribe := cfg.Watch.Wakeups()
+		defer unsubscribe()
+		wakeups = ch
+	}
+	w.stream = startWatchStream(hctx, sse)
+	if rawVersion == "" {
+		if err := w.sendSnapshot(ctx); err != nil {
+			w.fail(ctx, err)
+			return nil
+		}
+	}
+	w.run(ctx, wakeups)
+	return nil
+}
+
+// watchListParams reads the list query parameters a watch shares with
+// list. Huma has not parsed the input yet when the middleware runs.
+func watchListParams(hctx huma.Context, cfg Config) (listParams, error) {
+	p := listParams{
+		Namespace:     resolveNamespace(hctx.Query("namespace"), true),
+		Labels:        hctx.Query("labels"),
+		FieldSelector: hctx.Query("fieldSelector"),
+		Tag:           hctx.Query("tag"),
+	}
+	for name, dst := range map[string]*bool{
+		"latestOnly":         &p.LatestOnly,
+		"includeTerminating": &p.IncludeTerminating,
+	} {
+		raw := hctx.Query(name)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return listParams{}, huma.Error400BadRequest(fmt.Sprintf("invalid %s %q", name, raw))
+		}
+		*dst = v
+	}
+	if cfg.EnableOriginFilter {
+		p.Origin = hctx.Query("origin")
+	}
+	return p, nil
+}
+
+// watcher is the state of one watch stream.
+type watcher[T v1alpha1.Object] struct {
+	cfg    Config
+	newObj func() T
+	opts   v1alpha1store.ListOpts
+	stream *watchStream
+	// cursor is the last event revision read.
+	cursor int64
+	// seen tracks objects this stream has shown the client: true while
+	// the object matches the watch, false once a DELETED was sent for it
+	// so the hard delete that follows a soft delete is not reported twice.
+	seen map[v1alpha1store.ResourceKey]bool
+}
+
+// checkRetained returns 410 when events after the cursor may have been
+// pruned by retention, using the same gap rule as the controllers.
+// resourceVersion=0 replays whatever is retained.
+func (w *watcher[T]) checkRetained(ctx context.Context) error {
+	oldest, ok, err := w.cfg.Watch.Events.OldestRevision(ctx)
+	if err != nil {
+		return huma.Error500InternalServerError("watch "+w.cfg.Kind, err)
+	}
+	if ok && w.cursor > 0 && w.cursor < oldest-1 {
+		return huma.NewError(http.StatusGone, fmt.Sprintf(
+			"resourceVersion %d is older than the retained event history (oldest %d); list again and watch from its resourceVersion",
+			w.cursor, oldest))
+	}
+	return nil
+}
+
+// sendSnapshot emits an ADDED event per object the equivalent list
+// returns, then a BOOKMARK marking the end of the initial state.
+func (w *watcher[T]) sendSnapshot(ctx context.Context) error {
+	rv := strconv.FormatInt(w.cursor, 10)
+	opts := w.opts
+	opts.Limit = watchSnapshotPageSize
+	for {
+		rows, next, err := w.cfg.Store.List(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("list %s: %w", w.cfg.Kind, err)
+		}
+		for _, row := range rows {
+			obj, err := v1alpha1.EnvelopeFromRaw(w.newObj, row, w.cfg.Kind)
+			if err != nil {
+				return fmt.Errorf("decode %s: %w", w.cfg.Kind, err)
+			}
+			meta := obj.GetMetadata()
+			w.seen[v1alpha1store.ResourceKey{Kind: w.cfg.Kind, Namespace: meta.Namespace, Name: meta.Name, Tag: meta.Tag}] = true
+			if err := w.stream.sendObject(arv0.WatchEventAdded, rv, obj); err != nil {
+				return err
+			}
+		}
+		if next == "" {
+			break
+		}
+		opts.Cursor = next
+	}
+	return w.stream.send(arv0.WatchEvent{Type: arv0.WatchEventBookmark, ResourceVersion: rv})
+}
+
+func (w *watcher[T]) run(ctx context.Context, wakeups <-chan struct{}) {
+	pollInterval := w.cfg.Watch.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultWatchPollInterval
+		if wakeups != nil {
+			pollInterval = defaultWatchWakeupPollInterval
+		}
+	}
+	bookmarkInterval := w.cfg.Watch.BookmarkInterval
+	if bookmarkInterval <= 0 {
+		bookmarkInterval = defaultWatchBookmarkInterval
+	}
+	poll := time.NewTicker(pollInterval)
+	defer poll.Stop()
+	bookmark := time.NewTicker(bookmarkInterval)
+	defer bookmark.Stop()
+
+	for {
+		if err := w.catchUp(ctx); err != nil {
+			if ctx.Err() == nil {
+				w.fail(ctx, err)
+			}
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-wakeups:
+		case <-poll.C:
+		case <-bookmark.C:
+			if err := w.stream.send(arv0.WatchEvent{
+				Type:            arv0.WatchEventBookmark,
+				ResourceVersion: strconv.FormatInt(w.cursor, 10),
+			}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// catchUp replays every retained event after the cursor.
+func (w *watcher[T]) catchUp(ctx context.Context) error {
+	if err := w.checkRetained(ctx); err != nil {
+		return err
+	}
+	for {
+		events, err := w.cfg.Watch.Events.ListAfter(ctx, w.cursor, watchEventBatchLimit)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if event.Key.Kind == w.cfg.Kind {
+				if err := w.handle(ctx, event); err != nil {
+					return err
+				}
+			}
+			w.cursor = event.Revision
+		}
+		if len(events) < watchEventBatchLimit {
+			return nil
+		}
+	}
+}
+
+// handle turns one event into at most one watch event. Events carry
+// identity only, so the object is re-read through the same filters the
+// list applies: a match is ADDED or MODIFIED, and an object the stream
+// has shown that no longer matches (deleted, terminating, relabelled) is
+// DELETED.
+func (w *watcher[T]) handle(ctx context.Context, event v1alpha1store.ControlPlaneEvent) error {
+	key := event.Key
+	if !w.inScope(key) {
+		return nil
+	}
+	rv := strconv.FormatInt(event.Revision, 10)
+	shown, tracked := w.seen[key]
+	deleted := event.Operation == "delete"
+	if !deleted {
+		obj, found, err := w.lookup(ctx, key)
+		if err != nil {
+			return err
+		}
+		if found {
+			eventType := arv0.WatchEventModified
+			if event.Operation == "insert" || (tracked && !shown) {
+				eventType = arv0.WatchEventAdded
+			}
+			w.seen[key] = true
+			return w.stream.sendObject(eventType, rv, obj)
+		}
+	}
+	switch {
+	case shown:
+		if deleted {
+			delete(w.seen, key)
+		} else {
+			w.seen[key] = false
+		}
+	case tracked:
+		// Already reported when the object left the watch.
+		if deleted {
+			delete(w.seen, key)
+		}
+		return nil
+	case !deleted:
+		return nil
+	case !w.mayGet(ctx, key):
+		// A delete of an object this stream never showed: the row is gone,
+		// so only identity-level scoping and the get gate apply.
+		return nil
+	}
+	return w.stream.sendObject(arv0.WatchEventDeleted, rv, w.tombstone(key, event.UID))
+}
+
+// inScope applies the identity filters an event can be checked against
+// without reading the row.
+func (w *watcher[T]) inScope(key v1alpha1store.ResourceKey) bool {
+	if w.opts.Namespace != "" && key.Namespace != w.opts.Namespace {
+		return false
+	}
+	if !v1alpha1.IsTaggedArtifactKind(w.cfg.Kind) {
+		return true
+	}
+	tag := w.opts.Tag
+	if w.opts.LatestOnly {
+		tag = v1alpha1store.DefaultTag()
+	}
+	return tag == "" || key.Tag == tag
+}
+
+// lookup reads one row through the watch's list filters, so found means
+// the equivalent list would return it.
+func (w *watcher[T]) lookup(ctx context.Context, key v1alpha1store.ResourceKey) (T, bool, error) {
+	var zero T
+	opts := w.opts
+	opts.Cursor = ""
+	opts.Limit = 1
+	args := append(slices.Clone(opts.ExtraArgs), key.Namespace, key.Name)
+	predicate := fmt.Sprintf("namespace = $%d AND name = $%d", len(args)-1, len(args))
+	if v1alpha1.IsTaggedArtifactKind(w.cfg.Kind) {
+		args = append(args, key.Tag)
+		predicate += fmt.Sprintf(" AND tag = $%d", len(args))
+	}
+	if opts.ExtraWhere != "" {
+		predicate = "(" + opts.ExtraWhere + ") AND (" + predicate + ")"
+	}
+	opts.ExtraWhere, opts.ExtraArgs = predicate, args
+	rows, _, err := w.cfg.Store.List(ctx, opts)
+	if err != nil {
+		return zero, false, fmt.Errorf("read %s %s/%s: %w", w.cfg.Kind, key.Namespace, key.Name, err)
+	}
+	if len(rows) == 0 {
+		return zero, false, nil
+	}
+	obj, err := v1alpha1.EnvelopeFromRaw(w.newObj, rows[0], w.cfg.Kind)
+	if err != nil {
+		return zero, false, fmt.Errorf("decode %s: %w", w.cfg.Kind, err)
+	}
+	return obj, true, nil
+}
+
+func (w *watcher[T]) mayGet(ctx context.Context, key v1alpha1store.ResourceKey) bool {
+	if w.cfg.Authorize == nil {
+		return true
+	}
+	return w.cfg.Authorize(ctx, AuthorizeInput{
+		Verb: "get", Kind: w.cfg.Kind, Namespace: key.Namespace, Name: key.Name, Tag: key.Tag,
+	}) == nil
+}
+
+// tombstone is the identity-only object a DELETED event carries.
+func (w *watcher[T]) tombstone(key v1alpha1store.ResourceKey, uid string) T {
+	obj := w.newObj()
+	obj.SetTypeMeta(v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: w.cfg.Kind})
+	obj.SetMetadata(v1alpha1.ObjectMeta{Namespace: key.Namespace, Name: key.Name, Tag: key.Tag, UID: uid})
+	return obj
+}
+
+// fail ends the stream with an ERROR event carrying err's HTTP status.
+func (w *watcher[T]) fail(ctx context.Context, err error) {
+	watchErr := &arv0.WatchError{Status: http.StatusInternalServerError, Message: "watch " + w.cfg.Kind + " failed"}
+	var se huma.StatusError
+	if errors.As(err, &se) {
+		watchErr.Status, watchErr.Message = se.GetStatus(), se.Error()
+	} else {
+		slog.ErrorContext(ctx, "watch stream failed", "kind", w.cfg.Kind, "error", err)
+	}
+	_ = w.stream.send(arv0.WatchEvent{Type: arv0.WatchEventError, Error: watchErr})
+}
+
+// watchStream frames WatchEvents as newline-delimited JSON or, for
+// EventSource clients, server-sent events, flushing after each one.
+type watchStream struct {
+	w     io.Writer
+	flush func() error
+	sse   bool
+}
+
+func startWatchStream(hctx huma.Context, sse bool) *watchStream {
+	contentType := watchContentTypeJSON
+	if sse {
+		contentType = watchContentTypeSSE
+	}
+	hctx.SetHeader("Content-Type", contentType)
+	hctx.SetHeader("Cache-Control", "no-cache")
+	hctx.SetStatus(http.StatusOK)
+	s := &watchStream{w: hctx.BodyWriter(), sse: sse, flush: func() error { return nil }}
+	if rw, ok := s.w.(http.ResponseWriter); ok {
+		s.flush = http.NewResponseController(rw).Flush
+	}
+	_ = s.flush()
+	return s
+}
+
+func (s *watchStream) sendObject(eventType, rv string, obj any) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("encode watch object: %w", err)
+	}
+	return s.send(arv0.WatchEvent{Type: eventType, ResourceVersion: rv, Object: data})
+}
+
+func (s *watchStream) send(event arv0.WatchEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encode watch event: %w", err)
+	}
+	var buf bytes.Buffer
+	if s.sse {
+		fmt.Fprintf(&buf, "event: %s\n", event.Type)
+		if event.ResourceVersion != "" {
+			fmt.Fprintf(&buf, "id: %s\n", event.ResourceVersion)
+		}
+		buf.WriteString("data: ")
+		buf.Write(data)
+		buf.WriteString("\n\n")
+	} else {
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	if _, err := s.w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	if err := s.flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+//go:build integration
+
+package resource_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/require"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+func registerWatchedAgent(api huma.API, store *v1alpha1store.Store, watch *resource.WatchConfig) {
+	resource.Register[*v1alpha1.Agent](api, resource.Config{
+		Kind:       v1alpha1.KindAgent,
+		BasePrefix: "/v0",
+		Store:      store,
+		Watch:      watch,
+	}, func() *v1alpha1.Agent { return &v1alpha1.Agent{} })
+}
+
+// watchFor runs a watch request until d elapses and returns the raw body.
+func watchFor(t *testing.T, api humatest.TestAPI, d time.Duration, path string, headers ...any) string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(t.Context(), d)
+	defer cancel()
+	resp := api.GetCtx(ctx, path, headers...)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	return resp.Body.String()
+}
+
+func decodeWatchEvents(t *testing.T, body string) []arv0.WatchEvent {
+	t.Helper()
+	var events []arv0.WatchEvent
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		var event arv0.WatchEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event), scanner.Text())
+		events = append(events, event)
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+func watchEventName(t *testing.T, event arv0.WatchEvent) string {
+	t.Helper()
+	if len(event.Object) == 0 {
+		return event.Type
+	}
+	var agent v1alpha1.Agent
+	require.NoError(t, json.Unmarshal(event.Object, &agent))
+	return event.Type + " " + agent.Metadata.Name
+}
+
+func upsertWatchAgent(t *testing.T, store *v1alpha1store.Store, name, title string) {
+	t.Helper()
+	_, err := store.Upsert(t.Context(), &v1alpha1.Agent{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: name, Labels: map[string]string{"team": "a"}},
+		Spec:     v1alpha1.AgentSpec{Title: title},
+	})
+	require.NoError(t, err)
+}
+
+func TestResourceWatch_NotEnabled(t *testing.T) {
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
+	_, api := humatest.New(t)
+	registerWatchedAgent(api, store, nil)
+
+	resp := api.Get("/v0/agents?watch=true")
+	require.Equal(t, http.StatusNotImplemented, resp.Code, resp.Body.String())
+
+	resp = api.Get("/v0/agents?watch=false")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+}
+
+func TestResourceWatch_SnapshotThenBookmark(t *testing.T) {
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
+	events := v1alpha1store.NewControlPlaneEventStore(db, v1alpha1store.TestSchema())
+	_, api := humatest.New(t)
+	registerWatchedAgent(api, store, &resource.WatchConfig{Events: events, PollInterval: 20 * time.Millisecond})
+
+	upsertWatchAgent(t, store, "alice", "Alice")
+	upsertWatchAgent(t, store, "bob", "Bob")
+
+	got := decodeWatchEvents(t, watchFor(t, api, 300*time.Millisecond, "/v0/agents?watch=true"))
+	require.Len(t, got, 3)
+	require.Equal(t, "ADDED alice", watchEventName(t, got[0]))
+	require.Equal(t, "ADDED bob", watchEventName(t, got[1]))
+	require.Equal(t, arv0.WatchEventBookmark, got[2].Type)
+	require.Equal(t, got[0].ResourceVersion, got[2].ResourceVersion)
+}
+
+func TestResourceWatch_ReplaysChangesAfterResourceVersion(t *testing.T) {
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
+	events := v1alpha1store.NewControlPlaneEventStore(db, v1alpha1store.TestSchema())
+	_, api := humatest.New(t)
+	registerWatchedAgent(api, store, &resource.WatchConfig{Events: events, PollInterval: 20 * time.Millisecond})
+
+	upsertWatchAgent(t, store, "alice", "Alice")
+	upsertWatchAgent(t, store, "dave", "Dave")
+	resp := api.Get("/v0/agents")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var list struct {
+		ResourceVersion string `json:"resourceVersion"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &list))
+	require.NotEmpty(t, list.ResourceVersion, "list must report the revision to watch from")
+
+	// Replayed events re-read the current row, so each object changes once.
+	upsertWatchAgent(t, store, "alice", "Alice v2")
+	upsertWatchAgent(t, store, "bob", "Bob")
+	require.NoError(t, store.Delete(t.Context(), "default", "dave", v1alpha1store.DefaultTag()))
+	// Never matched the selector: not reported.
+	_, err := store.Upsert(t.Context(), &v1alpha1.Agent{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "carol", Labels: map[string]string{"team": "b"}},
+		Spec:     v1alpha1.AgentSpec{Title: "Carol"},
+	})
+	require.NoError(t, err)
+
+	got := decodeWatchEvents(t, watchFor(t, api, 300*time.Millisecond,
+		"/v0/agents?watch=true&labels=team%3Da&resourceVersion="+list.ResourceVersion))
+	var names []string
+	for _, event := range got {
+		names = append(names, watchEventName(t, event))
+	}
+	require.Equal(t, []string{"MODIFIED alice", "ADDED bob", "DELETED dave"}, names)
+}
+
+func TestResourceWatch_ServerSentEvents(t *testing.T) {
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
+	events := v1alpha1store.NewControlPlaneEventStore(db, v1alpha1store.TestSchema())
+	_, api := humatest.New(t)
+	registerWatchedAgent(api, store, &resource.WatchConfig{Events: events, PollInterval: 20 * time.Millisecond})
+
+	upsertWatchAgent(t, store, "alice", "Alice")
+
+	body := watchFor(t, api, 300*time.Millisecond, "/v0/agents?watch=true", "Accept: text/event-stream")
+	require.Contains(t, body, "event: ADDED\nid: ")
+	require.Contains(t, body, "event: BOOKMARK\nid: ")
+	require.Contains(t, body, "data: {")
+}
+
+// prunedEvents reports an oldest revision past every cursor, as if
+// retention had pruned the history.
+type prunedEvents struct {
+	resource.WatchEventSource
+}
+
+func (prunedEvents) OldestRevision(context.Context) (int64, bool, error) {
+	return 1 << 40, true, nil
+}
+
+func TestResourceWatch_GoneWhenHistoryPruned(t *testing.T) {
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
+	events := v1alpha1store.NewControlPlaneEventStore(db, v1alpha1store.TestSchema())
+	_, api := humatest.New(t)
+	registerWatchedAgent(api, store, &resource.WatchConfig{Events: prunedEvents{events}})
+
+	resp := api.Get("/v0/agents?watch=true&resourceVersion=5")
+	require.Equal(t, http.StatusGone, resp.Code, resp.Body.String())
+
+	resp = api.Get("/v0/agents?watch=true&resourceVersion=abc")
+	require.Equal(t, http.StatusBadRequest, resp.Code, resp.Body.String())
+}
@@ -0,0 +1,296 @@
+// Package retention applies tag retention policies to tagged artifacts.
+// Pruner plans which tags a v1alpha1.TagRetentionPolicy selects, holds back
+// the ones live objects still reference, and deletes the rest. The
+// retention controller runs it periodically; the dry-run report endpoint
+// serves its plan.
+package retention
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+// listPageSize is the page size used to scan every tag of a kind.
+const listPageSize = 500
+
+// Pruner plans and applies tag retention.
+type Pruner struct {
+	// Stores maps Kind to its Store. Tagged kinds are pruned; the
+	// Namespace store supplies per-namespace policies.
+	Stores map[string]*v1alpha1store.Store
+	// Policy is the server-wide policy. A Namespace's spec.tagRetention
+	// replaces it for that namespace.
+	Policy v1alpha1.TagRetentionPolicy
+	// Referrers finds the live objects referencing a tag. Referenced tags
+	// are never pruned. Nil skips the check.
+	Referrers types.ReferrerLookup
+	// TagPolicy reports the tag immutability policy of a namespace. A
+	// pruned tag it protects keeps its revision history, so the tag cannot
+	// be re-created with different content (see
+	// v1alpha1store.Store.DeleteRetainingHistory). Nil treats every tag as
+	// mutable.
+	TagPolicy func(ctx context.Context, kind, namespace string) (v1alpha1.TagPolicy, error)
+	Now       func() time.Time
+}
+
+// Plan reports what Prune would delete now, without deleting anything.
+func (p *Pruner) Plan(ctx context.Context) (*arv0.TagRetentionReport, error) {
+	report := &arv0.TagRetentionReport{Prune: []arv0.TagRetentionItem{}, Held: []arv0.TagRetentionItem{}}
+	namespaces, err := p.namespacePolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	enabled := p.Policy.Enabled()
+	for _, policy := range namespaces {
+		enabled = enabled || policy.Enabled()
+	}
+	if !enabled {
+		return report, nil
+	}
+
+	now := p.now()
+	for _, kind := range slices.Sorted(maps.Keys(p.Stores)) {
+		if !v1alpha1.IsTaggedArtifactKind(kind) {
+			continue
+		}
+		groups, err := p.listTags(ctx, kind)
+		if err != nil {
+			return nil, err
+		}
+		for _, rows := range groups {
+			policy, ok := namespaces[rows[0].Metadata.Namespace]
+			if !ok {
+				policy = p.Policy
+			}
+			rule, ok := policy.RuleFor(kind)
+			if !ok {
+				continue
+			}
+			items, err := SelectTags(kind, rule, rows, now)
+			if err != nil {
+				return nil, fmt.Errorf("namespace %s: %w", rows[0].Metadata.Namespace, err)
+			}
+			for _, item := range items {
+				held, err := p.referencedBy(ctx, item)
+				if err != nil {
+					return nil, err
+				}
+				if len(held) > 0 {
+					item.ReferencedBy = held
+					report.Held = append(report.Held, item)
+					continue
+				}
+				report.Prune = append(report.Prune, item)
+			}
+		}
+	}
+	return report, nil
+}
+
+// Prune deletes every tag Plan selects and returns the report with Prune
+// narrowed to the tags it deleted. Each tag's referrers are looked up again
+// right before its delete, so a tag an object started referencing while the
+// pass ran moves to Held instead of being pruned. A tag that fails to
+// delete is left for the next pass; the failures are joined into the
+// returned error.
+func (p *Pruner) Prune(ctx context.Context) (*arv0.TagRetentionReport, error) {
+	report, err := p.Plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var errs error
+	pruned := report.Prune[:0]
+	for _, item := range report.Prune {
+		held, err := p.referencedBy(ctx, item)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		if len(held) > 0 {
+			item.ReferencedBy = held
+			report.Held = append(report.Held, item)
+			continue
+		}
+		err = p.delete(ctx, item)
+		switch {
+		case errors.Is(err, pkgdb.ErrNotFound):
+		case err != nil:
+			errs = errors.Join(errs, fmt.Errorf("delete %s %s/%s@%s: %w", item.Kind, item.Namespace, item.Name, item.Tag, err))
+		default:
+			pruned = append(pruned, item)
+		}
+	}
+	report.Prune = pruned
+	return report, errs
+}
+
+// delete removes one selected tag, keeping the history of a protected tag.
+func (p *Pruner) delete(ctx context.Context, item arv0.TagRetentionItem) error {
+	store := p.Stores[item.Kind]
+	if p.TagPolicy != nil {
+		policy, err := p.TagPolicy(ctx, item.Kind, item.Namespace)
+		if err != nil {
+			return err
+		}
+		if policy.IsImmutable(item.Kind, item.Tag) {
+			return store.DeleteRetainingHistory(ctx, item.Namespace, item.Name, item.Tag)
+		}
+	}
+	return store.Delete(ctx, item.Namespace, item.Name, item.Tag)
+}
+
+// SelectTags applies rule to rows, every tag of one (namespace, name), and
+// returns the tags it prunes, before the reference check. The KeepLast
+// highest semver tags, "latest" and Keep patterns always stay. With MaxAge
+// set, other semver tags and tags matching Prune are pruned once not
+// written for MaxAge; without it, the remaining semver tags are pruned by
+// count. Floating tags such as "stable" are never pruned.
+func SelectTags(kind string, rule v1alpha1.TagRetentionRule, rows []*v1alpha1.RawObject, now time.Time) ([]arv0.TagRetentionItem, error) {
+	maxAge, err := rule.MaxAgeDuration()
+	if err != nil {
+		return nil, err
+	}
+	type version struct {
+		tag string
+		v   *semver.Version
+	}
+	var versions []version
+	for _, row := range rows {
+		if !v1alpha1.IsSemverTag(row.Metadata.Tag) {
+			continue
+		}
+		if v, err := semver.NewVersion(row.Metadata.Tag); err == nil {
+			versions = append(versions, version{row.Metadata.Tag, v})
+		}
+	}
+	slices.SortFunc(versions, func(a, b version) int { return b.v.Compare(a.v) })
+	newest := map[string]bool{}
+	for _, v := range versions[:min(max(rule.KeepLast, 0), len(versions))] {
+		newest[v.tag] = true
+	}
+
+	var out []arv0.TagRetentionItem
+	for _, row := range rows {
+		meta := row.Metadata
+		if rule.Keeps(meta.Tag) || newest[meta.Tag] {
+			continue
+		}
+		item := arv0.TagRetentionItem{Kind: kind, Namespace: meta.Namespace, Name: meta.Name, Tag: meta.Tag, UpdatedAt: meta.UpdatedAt}
+		switch {
+		case maxAge > 0:
+			if !rule.AgesOut(meta.Tag) || !meta.UpdatedAt.Before(now.Add(-maxAge)) {
+				continue
+			}
+			item.Reason = arv0.TagRetentionReasonMaxAge
+		case rule.KeepLast > 0 && v1alpha1.IsSemverTag(meta.Tag):
+			item.Reason = arv0.TagRetentionReasonKeepLast
+		default:
+			continue
+		}
+		out = append(out, item)
+	}
+	slices.SortFunc(out, func(a, b arv0.TagRetentionItem) int { return cmp.Compare(a.Tag, b.Tag) })
+	return out, nil
+}
+
+// listTags returns every live tag row of kind grouped by (namespace, name),
+// in list order.
+func (p *Pruner) listTags(ctx context.Context, kind string) ([][]*v1alpha1.RawObject, error) {
+	var (
+		groups [][]*v1alpha1.RawObject
+		index  = map[[2]string]int{}
+		cursor string
+	)
+	for {
+		rows, next, err := p.Stores[kind].List(ctx, v1alpha1store.ListOpts{Limit: listPageSize, Cursor: cursor})
+		if err != nil {
+			return nil, fmt.Errorf("list %s tags: %w", kind, err)
+		}
+		for _, row := range rows {
+			key := [2]string{row.Metadata.Namespace, row.Metadata.Name}
+			i, ok := index[key]
+			if !ok {
+				i = len(groups)
+				index[key] = i
+				groups = append(groups, nil)
+			}
+			groups[i] = append(groups[i], row)
+		}
+		if next == "" {
+			return groups, nil
+		}
+		cursor = next
+	}
+}
+
+// namespacePolicies returns the spec.tagRetention of every Namespace that
+// sets one, keyed by namespace name.
+func (p *Pruner) namespacePolicies(ctx context.Context) (map[string]v1alpha1.TagRetentionPolicy, error) {
+	out := map[string]v1alpha1.TagRetentionPolicy{}
+	store := p.Stores[v1alpha1.KindNamespace]
+	if store == nil {
+		return out, nil
+	}
+	var cursor string
+	for {
+		rows, next, err := store.List(ctx, v1alpha1store.ListOpts{Limit: listPageSize, Cursor: cursor})
+		if err != nil {
+			return nil, fmt.Errorf("list namespaces: %w", err)
+		}
+		for _, row := range rows {
+			var spec v1alpha1.NamespaceSpec
+			if len(row.Spec) > 0 {
+				if err := json.Unmarshal(row.Spec, &spec); err != nil {
+					return nil, fmt.Errorf("decode namespace %q: %w", row.Metadata.Name, err)
+				}
+			}
+			if spec.TagRetention != nil {
+				out[row.Metadata.Name] = *spec.TagRetention
+			}
+		}
+		if next == "" {
+			return out, nil
+		}
+		cursor = next
+	}
+}
+
+func (p *Pruner) referencedBy(ctx context.Context, item arv0.TagRetentionItem) ([]string, error) {
+	if p.Referrers == nil {
+		return nil, nil
+	}
+	refs, err := p.Referrers(ctx, v1alpha1.ResourceRef{Kind: item.Kind, Namespace: item.Namespace, Name: item.Name, Tag: item.Tag})
+	if err != nil {
+		return nil, fmt.Errorf("find referrers of %s %s/%s@%s: %w", item.Kind, item.Namespace, item.Name, item.Tag, err)
+	}
+	out := make([]string, 0, len(refs))
+	for _, r := range refs {
+		s := r.Kind + " " + r.Namespace + "/" + r.Name
+		if r.Tag != "" {
+			s += "@" + r.Tag
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+func (p *Pruner) now() time.Time {
+	if p.Now != nil {
+		return p.Now().UTC()
+	}
+	return time.Now().UTC()
+}
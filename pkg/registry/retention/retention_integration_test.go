@@ -0,0 +1,166 @@
+//go:build integration
+
+package retention_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/retention"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+func TestPruner_HoldsReferencedTags(t *testing.T) {
+	db := v1alpha1store.NewTestDB(t)
+	agents := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
+	servers := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "mcp_servers")
+	namespaces := v1alpha1store.NewMutableObjectStore(db, v1alpha1store.TestSchema(), "namespaces")
+	ctx := t.Context()
+
+	for _, ns := range []string{"default", "team-a"} {
+		for _, tag := range []string{"1.0.0", "1.1.0", "1.2.0", "1.3.0"} {
+			_, err := servers.Upsert(ctx, &v1alpha1.MCPServer{
+				Metadata: v1alpha1.ObjectMeta{Namespace: ns, Name: "acme-fetch", Tag: tag},
+				Spec:     v1alpha1.MCPServerSpec{Title: "Fetch " + tag},
+			})
+			require.NoError(t, err)
+		}
+	}
+	_, err := agents.Upsert(ctx, &v1alpha1.Agent{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "support", Tag: "1.0.0"},
+		Spec: v1alpha1.AgentSpec{
+			Title:      "Support",
+			MCPServers: []v1alpha1.ResourceRef{{Name: "acme-fetch", Tag: "1.0.0"}},
+		},
+	})
+	require.NoError(t, err)
+	// team-a keeps everything: its Namespace replaces the server policy
+	// with a rule that only spares tags.
+	_, err = namespaces.Upsert(ctx, &v1alpha1.Namespace{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "team-a"},
+		Spec: v1alpha1.NamespaceSpec{TagRetention: &v1alpha1.TagRetentionPolicy{
+			Rules: []v1alpha1.TagRetentionRule{{Keep: []string{v1alpha1.TagPatternSemver}}},
+		}},
+	})
+	require.NoError(t, err)
+
+	stores := map[string]*v1alpha1store.Store{
+		v1alpha1.KindAgent:     agents,
+		v1alpha1.KindMCPServer: servers,
+		v1alpha1.KindNamespace: namespaces,
+	}
+	pruner := &retention.Pruner{
+		Stores:    stores,
+		Policy:    v1alpha1.TagRetentionPolicy{Rules: []v1alpha1.TagRetentionRule{{Kinds: []string{v1alpha1.KindMCPServer}, KeepLast: 2}}},
+		Referrers: resource.NewReferrerLookup(stores),
+		Now:       time.Now,
+	}
+
+	plan, err := pruner.Plan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []arv0.TagRetentionItem{{
+		Kind: v1alpha1.KindMCPServer, Namespace: "default", Name: "acme-fetch", Tag: "1.1.0",
+		Reason: arv0.TagRetentionReasonKeepLast, UpdatedAt: plan.Prune[0].UpdatedAt,
+	}}, plan.Prune)
+	require.Len(t, plan.Held, 1)
+	require.Equal(t, "1.0.0", plan.Held[0].Tag)
+	require.Equal(t, []string{"Agent default/support@1.0.0"}, plan.Held[0].ReferencedBy)
+
+	_, err = servers.Get(ctx, "default", "acme-fetch", "1.1.0")
+	require.NoError(t, err, "Plan deletes nothing")
+
+	report, err := pruner.Prune(ctx)
+	require.NoError(t, err)
+	require.Len(t, report.Prune, 1)
+	_, err = servers.Get(ctx, "default", "acme-fetch", "1.1.0")
+	require.ErrorIs(t, err, pkgdb.ErrNotFound)
+	for _, tag := range []string{"1.0.0", "1.2.0", "1.3.0"} {
+		_, err = servers.Get(ctx, "default", "acme-fetch", tag)
+		require.NoError(t, err, tag)
+	}
+	_, err = servers.Get(ctx, "team-a", "acme-fetch", "1.0.0")
+	require.NoError(t, err, "team-a's policy keeps its tags")
+}
+
+func TestPruner_RechecksReferrersBeforeDelete(t *testing.T) {
+	db := v1alpha1store.NewTestDB(t)
+	servers := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "mcp_servers")
+	ctx := t.Context()
+	for _, tag := range []string{"1.0.0", "1.1.0"} {
+		_, err := servers.Upsert(ctx, &v1alpha1.MCPServer{
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "acme-fetch", Tag: tag},
+			Spec:     v1alpha1.MCPServerSpec{Title: "Fetch " + tag},
+		})
+		require.NoError(t, err)
+	}
+
+	// The first lookup is Plan's; an Agent starts referencing 1.0.0 before
+	// Prune deletes it.
+	lookups := 0
+	pruner := &retention.Pruner{
+		Stores: map[string]*v1alpha1store.Store{v1alpha1.KindMCPServer: servers},
+		Policy: v1alpha1.TagRetentionPolicy{Rules: []v1alpha1.TagRetentionRule{{KeepLast: 1}}},
+		Referrers: func(context.Context, v1alpha1.ResourceRef) ([]v1alpha1.ResourceRef, error) {
+			lookups++
+			if lookups == 1 {
+				return nil, nil
+			}
+			return []v1alpha1.ResourceRef{{Kind: v1alpha1.KindAgent, Namespace: "default", Name: "support", Tag: "1.0.0"}}, nil
+		},
+		Now: time.Now,
+	}
+
+	report, err := pruner.Prune(ctx)
+	require.NoError(t, err)
+	require.Empty(t, report.Prune)
+	require.Len(t, report.Held, 1)
+	require.Equal(t, []string{"Agent default/support@1.0.0"}, report.Held[0].ReferencedBy)
+	_, err = servers.Get(ctx, "default", "acme-fetch", "1.0.0")
+	require.NoError(t, err, "a tag referenced since the plan is kept")
+}
+
+func TestPruner_KeepsProtectedTagsClosed(t *testing.T) {
+	db := v1alpha1store.NewTestDB(t)
+	servers := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "mcp_servers", v1alpha1store.WithRevisionHistory(v1alpha1store.TestSchema()))
+	ctx := t.Context()
+	for _, tag := range []string{"1.0.0", "1.1.0", "dev-1"} {
+		_, err := servers.Upsert(ctx, &v1alpha1.MCPServer{
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "acme-fetch", Tag: tag},
+			Spec:     v1alpha1.MCPServerSpec{Title: "Fetch " + tag},
+		})
+		require.NoError(t, err)
+	}
+
+	policy := v1alpha1.TagPolicy{Immutable: []string{v1alpha1.TagPatternSemver}}
+	pruner := &retention.Pruner{
+		Stores: map[string]*v1alpha1store.Store{v1alpha1.KindMCPServer: servers},
+		Policy: v1alpha1.TagRetentionPolicy{Rules: []v1alpha1.TagRetentionRule{{KeepLast: 1, MaxAge: "1m", Prune: []string{"dev-*"}}}},
+		TagPolicy: func(context.Context, string, string) (v1alpha1.TagPolicy, error) {
+			return policy, nil
+		},
+		Now: func() time.Time { return time.Now().Add(time.Hour) },
+	}
+	report, err := pruner.Prune(ctx)
+	require.NoError(t, err)
+	require.Len(t, report.Prune, 2)
+
+	// The pruned semver tag cannot come back with different content; the
+	// pruned mutable tag can.
+	_, err = servers.Upsert(ctx, &v1alpha1.MCPServer{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "acme-fetch", Tag: "1.0.0"},
+		Spec:     v1alpha1.MCPServerSpec{Title: "Replaced"},
+	}, v1alpha1store.UpsertOpts{ImmutableTag: true})
+	require.ErrorIs(t, err, v1alpha1store.ErrTagImmutable)
+	_, err = servers.Upsert(ctx, &v1alpha1.MCPServer{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "acme-fetch", Tag: "dev-1"},
+		Spec:     v1alpha1.MCPServerSpec{Title: "Replaced"},
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,75 @@
+package retention
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+func tagRows(now time.Time, ages map[string]time.Duration) []*v1alpha1.RawObject {
+	var rows []*v1alpha1.RawObject
+	for tag, age := range ages {
+		rows = append(rows, &v1alpha1.RawObject{Metadata: v1alpha1.ObjectMeta{
+			Namespace: "default", Name: "acme-fetch", Tag: tag, UpdatedAt: now.Add(-age),
+		}})
+	}
+	return rows
+}
+
+func prunedTags(items []arv0.TagRetentionItem) map[string]string {
+	out := map[string]string{}
+	for _, item := range items {
+		out[item.Tag] = item.Reason
+	}
+	return out
+}
+
+func TestSelectTags(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	rows := tagRows(now, map[string]time.Duration{
+		"latest":     0,
+		"stable":     400 * day,
+		"dev-4f2a":   100 * day,
+		"1.0.0":      300 * day,
+		"1.2.0":      200 * day,
+		"1.10.0":     100 * day,
+		"2.0.0-rc.1": 50 * day,
+		"2.0.0":      10 * day,
+	})
+
+	items, err := SelectTags(v1alpha1.KindMCPServer, v1alpha1.TagRetentionRule{KeepLast: 3, Keep: []string{"stable"}}, rows, now)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"1.0.0": arv0.TagRetentionReasonKeepLast,
+		"1.2.0": arv0.TagRetentionReasonKeepLast,
+	}, prunedTags(items), "the three highest versions stay, 1.10.0 ranking above 1.2.0; non-semver tags are not counted")
+
+	items, err = SelectTags(v1alpha1.KindMCPServer, v1alpha1.TagRetentionRule{KeepLast: 1, MaxAge: "90d"}, rows, now)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"1.0.0":  arv0.TagRetentionReasonMaxAge,
+		"1.2.0":  arv0.TagRetentionReasonMaxAge,
+		"1.10.0": arv0.TagRetentionReasonMaxAge,
+	}, prunedTags(items), "MaxAge prunes old semver tags; recent, newest and floating tags stay")
+
+	items, err = SelectTags(v1alpha1.KindMCPServer, v1alpha1.TagRetentionRule{KeepLast: 1, MaxAge: "90d", Prune: []string{"dev-*"}}, rows, now)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"dev-4f2a": arv0.TagRetentionReasonMaxAge,
+		"1.0.0":    arv0.TagRetentionReasonMaxAge,
+		"1.2.0":    arv0.TagRetentionReasonMaxAge,
+		"1.10.0":   arv0.TagRetentionReasonMaxAge,
+	}, prunedTags(items), "Prune patterns opt non-semver tags into MaxAge; stable still stays")
+
+	items, err = SelectTags(v1alpha1.KindMCPServer, v1alpha1.TagRetentionRule{Keep: []string{v1alpha1.TagPatternSemver}}, rows, now)
+	require.NoError(t, err)
+	require.Empty(t, items, "a rule with neither KeepLast nor MaxAge prunes nothing")
+
+	_, err = SelectTags(v1alpha1.KindMCPServer, v1alpha1.TagRetentionRule{MaxAge: "often"}, rows, now)
+	require.Error(t, err)
+}
@@ -12,8 +12,8 @@ import (
 )
 
 func TestStore_AnnotationsRoundTrip(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 	ctx := context.Background()
 
 	annotations := map[string]string{
@@ -38,8 +38,8 @@ func TestStore_AnnotationsRoundTrip(t *testing.T) {
 // incoming object replaces annotations to match what the caller sent.
 // Annotations are user-managed, not server-managed, in the new world.
 func TestStore_AnnotationsReplacedOnReapplyWithEmpty(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 	ctx := context.Background()
 
 	_, err := store.Upsert(ctx, &v1alpha1.Agent{
@@ -65,8 +65,8 @@ func TestStore_AnnotationsReplacedOnReapplyWithEmpty(t *testing.T) {
 }
 
 func TestStore_AnnotationsClearedOnEmptyMap(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 	ctx := context.Background()
 
 	_, err := store.Upsert(ctx, &v1alpha1.Agent{
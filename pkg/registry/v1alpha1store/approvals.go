@@ -0,0 +1,342 @@
+package v1alpha1store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+var (
+	// ErrApprovalsDisabled reports an approval read or write on a Store
+	// built without WithApprovals.
+	ErrApprovalsDisabled = errors.New("v1alpha1 store: artifact approvals are not enabled")
+	// ErrUnapproved reports a tag whose content awaits review or was
+	// rejected, and which reference resolution therefore ignores.
+	ErrUnapproved = errors.New("not approved for use")
+)
+
+// Approval states.
+const (
+	ApprovalPending  = "pending"
+	ApprovalApproved = "approved"
+	ApprovalRejected = "rejected"
+)
+
+// WithApprovals stores review decisions in schema's artifact_approvals
+// table. Ignored on mutable-object stores. NewStores enables it for every
+// built-in tagged kind; extension stores opt in by passing it with the
+// schema that holds artifact_approvals.
+func WithApprovals(schema pkgdb.Schema) StoreOption {
+	return func(s *Store) {
+		if s.behavior == TaggedArtifactStore {
+			s.approvals = qualifyTable(s.db, schema, "artifact_approvals")
+		}
+	}
+}
+
+// Approval is the review state of one content digest of (Namespace, Name).
+type Approval struct {
+	Namespace string
+	Name      string
+	// Tag is the tag the content was published under when review was
+	// requested.
+	Tag string
+	// Digest is the ContentDigest under review.
+	Digest string
+	// State is one of the Approval* constants.
+	State       string
+	RequestedBy string
+	RequestedAt time.Time
+	// ReviewedBy, ReviewedAt and Comment are set once a reviewer decides.
+	ReviewedBy string
+	ReviewedAt *time.Time
+	Comment    string
+}
+
+// Approvals reports whether the Store keeps review decisions.
+func (s *Store) Approvals() bool {
+	return s != nil && s.approvals != ""
+}
+
+const approvalColumns = `namespace, name, tag, content_hash, state, requested_by, requested_at,
+	COALESCE(reviewed_by, ''), reviewed_at, COALESCE(comment, '')`
+
+func scanApproval(row rowScanner) (Approval, error) {
+	var (
+		a    Approval
+		hash string
+	)
+	if err := row.Scan(&a.Namespace, &a.Name, &a.Tag, &hash, &a.State, &a.RequestedBy, &a.RequestedAt,
+		&a.ReviewedBy, &a.ReviewedAt, &a.Comment); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Approval{}, pkgdb.ErrNotFound
+		}
+		return Approval{}, fmt.Errorf("scan approval: %w", err)
+	}
+	a.Digest = ContentDigest(strings.TrimSpace(hash))
+	return a, nil
+}
+
+// RequestApproval puts the content of (namespace, name) with digest up for
+// review after it was published under tag. Content already approved or
+// already pending keeps its state; rejected content goes back to pending,
+// since publishing it again asks for another review.
+func (s *Store) RequestApproval(ctx context.Context, namespace, name, tag, digest, requestedBy string) (*Approval, error) {
+	if !s.Approvals() {
+		return nil, ErrApprovalsDisabled
+	}
+	hash, err := digestHash(digest)
+	if err != nil {
+		return nil, err
+	}
+	var out Approval
+	err = runInTx(ctx, s.db, func(tx DB) error {
+		out, err = s.requestApproval(ctx, tx, namespace, name, tag, hash, requestedBy)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// requestApproval is RequestApproval within tx, for the content hash.
+func (s *Store) requestApproval(ctx context.Context, tx DB, namespace, name, tag, hash, requestedBy string) (Approval, error) {
+	current, err := s.loadApproval(ctx, tx, namespace, name, hash)
+	switch {
+	case err == nil && current.State != ApprovalRejected:
+		return current, nil
+	case err == nil:
+		_, err = tx.Exec(ctx,
+			fmt.Sprintf(`
+				UPDATE %s
+				SET state=$5, tag=$6, requested_by=$7, requested_at=now(),
+					reviewed_by=NULL, reviewed_at=NULL, comment=NULL
+				WHERE resource_table=$1 AND namespace=$2 AND name=$3 AND content_hash=$4`, s.approvals),
+			s.table, namespace, name, hash, ApprovalPending, tag, requestedBy)
+	case errors.Is(err, pkgdb.ErrNotFound):
+		_, err = tx.Exec(ctx,
+			fmt.Sprintf(`
+				INSERT INTO %s (resource_table, namespace, name, content_hash, tag, state, requested_by)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)`, s.approvals),
+			s.table, namespace, name, hash, tag, ApprovalPending, requestedBy)
+	}
+	if err != nil {
+		return Approval{}, fmt.Errorf("request approval: %w", err)
+	}
+	return s.loadApproval(ctx, tx, namespace, name, hash)
+}
+
+// loadApproval reads the review entry of the content hash through db.
+func (s *Store) loadApproval(ctx context.Context, db DB, namespace, name, hash string) (Approval, error) {
+	return scanApproval(db.QueryRow(ctx,
+		fmt.Sprintf(`SELECT %s FROM %s WHERE resource_table=$1 AND namespace=$2 AND name=$3 AND content_hash=$4`,
+			approvalColumns, s.approvals),
+		s.table, namespace, name, hash))
+}
+
+// publishApproval applies opts.RequestApproval to the content an Upsert
+// just wrote, in the write's transaction, and records the resulting review
+// state in result. New content is put up for review; unchanged content
+// keeps the state it is in, approved when it was published before review
+// was required.
+func (s *Store) publishApproval(ctx context.Context, tx DB, meta *v1alpha1.ObjectMeta, hash string, opts UpsertOpts, result *UpsertResult) error {
+	if !opts.RequestApproval {
+		return nil
+	}
+	if !s.Approvals() {
+		return fmt.Errorf("%w: %s keeps no approvals", ErrApprovalsDisabled, s.kind)
+	}
+	if result.Outcome == UpsertNoOp {
+		a, err := s.loadApproval(ctx, tx, meta.Namespace, meta.Name, hash)
+		switch {
+		case errors.Is(err, pkgdb.ErrNotFound):
+			result.ApprovalState = ApprovalApproved
+		case err != nil:
+			return err
+		default:
+			result.ApprovalState = a.State
+		}
+		return nil
+	}
+	a, err := s.requestApproval(ctx, tx, meta.Namespace, meta.Name, meta.Tag, hash, opts.RequestedBy)
+	if err != nil {
+		return err
+	}
+	result.ApprovalState = a.State
+	return nil
+}
+
+// GetApproval returns the review state of the content of (namespace, name)
+// with digest. Returns pkgdb.ErrNotFound when the content was never put up
+// for review.
+func (s *Store) GetApproval(ctx context.Context, namespace, name, digest string) (*Approval, error) {
+	if !s.Approvals() {
+		return nil, ErrApprovalsDisabled
+	}
+	hash, err := digestHash(digest)
+	if err != nil {
+		return nil, err
+	}
+	a, err := scanApproval(s.db.QueryRow(ctx,
+		fmt.Sprintf(`SELECT %s FROM %s WHERE resource_table=$1 AND namespace=$2 AND name=$3 AND content_hash=$4`,
+			approvalColumns, s.approvals),
+		s.table, namespace, name, hash))
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// ReviewApproval records a reviewer's decision on the content of
+// (namespace, name) with digest. A decision may be revised: approving
+// rejected content, or rejecting approved content, takes effect at the
+// next resolution. Returns pkgdb.ErrNotFound when the content was never put
+// up for review.
+func (s *Store) ReviewApproval(ctx context.Context, namespace, name, digest string, approve bool, reviewer, comment string) (*Approval, error) {
+	if !s.Approvals() {
+		return nil, ErrApprovalsDisabled
+	}
+	hash, err := digestHash(digest)
+	if err != nil {
+		return nil, err
+	}
+	state, verb := ApprovalRejected, types.AuditVerbReject
+	if approve {
+		state, verb = ApprovalApproved, types.AuditVerbApprove
+	}
+	var (
+		a     Approval
+		event types.AuditEvent
+	)
+	err = runInTx(ctx, s.db, func(tx DB) error {
+		var err error
+		a, err = scanApproval(tx.QueryRow(ctx,
+			fmt.Sprintf(`
+				UPDATE %s
+				SET state=$5, reviewed_by=$6, reviewed_at=now(), comment=NULLIF($7, '')
+				WHERE resource_table=$1 AND namespace=$2 AND name=$3 AND content_hash=$4
+				RETURNING %s`, s.approvals, approvalColumns),
+			s.table, namespace, name, hash, state, reviewer, comment))
+		if err != nil {
+			return err
+		}
+		reason := a.Digest
+		if comment != "" {
+			reason += ": " + comment
+		}
+		event = types.AuditEvent{
+			Verb: verb, Kind: s.kind, Namespace: namespace, Name: name, Tag: a.Tag,
+			Reason: reason,
+		}
+		return s.audit(ctx, tx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.record(ctx, event)
+	return &a, nil
+}
+
+// ListApprovals returns the review entries of the Store, oldest request
+// first. A blank namespace lists every namespace and a blank state every
+// state.
+func (s *Store) ListApprovals(ctx context.Context, namespace, state string) ([]Approval, error) {
+	if !s.Approvals() {
+		return nil, ErrApprovalsDisabled
+	}
+	where := []string{"resource_table=$1"}
+	args := []any{s.table}
+	if namespace != "" {
+		args = append(args, namespace)
+		where = append(where, fmt.Sprintf("namespace=$%d", len(args)))
+	}
+	if state != "" {
+		args = append(args, state)
+		where = append(where, fmt.Sprintf("state=$%d", len(args)))
+	}
+	rows, err := s.db.Query(ctx,
+		fmt.Sprintf(`SELECT %s FROM %s WHERE %s ORDER BY requested_at, namespace, name`,
+			approvalColumns, s.approvals, strings.Join(where, " AND ")),
+		args...)
+	if err != nil {
+		return nil, fmt.Errorf("list approvals: %w", err)
+	}
+	defer rows.Close()
+
+	out := []Approval{}
+	for rows.Next() {
+		a, err := scanApproval(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ApprovalState returns the review state of the content of (namespace,
+// name) with digest: ApprovalApproved for content never put up for review,
+// which was published outside any approval policy.
+func (s *Store) ApprovalState(ctx context.Context, namespace, name, digest string) (string, error) {
+	if !s.Approvals() {
+		return ApprovalApproved, nil
+	}
+	a, err := s.GetApproval(ctx, namespace, name, digest)
+	if errors.Is(err, pkgdb.ErrNotFound) {
+		return ApprovalApproved, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return a.State, nil
+}
+
+// rowApprovalState returns the review state of a live tag's content.
+func (s *Store) rowApprovalState(ctx context.Context, obj *v1alpha1.RawObject) (string, error) {
+	hash, err := ContentHash(&obj.Metadata, obj.Spec)
+	if err != nil {
+		return "", fmt.Errorf("v1alpha1 store: content hash: %w", err)
+	}
+	return s.ApprovalState(ctx, obj.Metadata.Namespace, obj.Metadata.Name, ContentDigest(hash))
+}
+
+func describeApprovalState(state string) string {
+	if state == ApprovalPending {
+		return "pending review"
+	}
+	return state
+}
+
+// deleteApprovals drops every review entry recorded for (namespace, name).
+func (s *Store) deleteApprovals(ctx context.Context, tx DB, namespace, name string) error {
+	if s.approvals == "" {
+		return nil
+	}
+	if _, err := tx.Exec(ctx,
+		fmt.Sprintf(`DELETE FROM %s WHERE resource_table=$1 AND namespace=$2 AND name=$3`, s.approvals),
+		s.table, namespace, name); err != nil {
+		return fmt.Errorf("delete approvals: %w", err)
+	}
+	return nil
+}
+
+// digestHash returns the content hash a ContentDigest names.
+func digestHash(digest string) (string, error) {
+	hash, ok := strings.CutPrefix(digest, DigestPrefix)
+	if !ok || len(hash) != 64 {
+		return "", fmt.Errorf("%w: digest %q is not a %s content digest", v1alpha1.ErrInvalidFormat, digest, DigestPrefix)
+	}
+	return hash, nil
+}
@@ -0,0 +1,127 @@
+//go:build integration
+
+package v1alpha1store_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+func TestApprovals_UpsertRequestsApproval(t *testing.T) {
+	ctx := context.Background()
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents", v1alpha1store.WithApprovals(v1alpha1store.TestSchema()))
+	held := v1alpha1store.UpsertOpts{RequestApproval: true, RequestedBy: "alice"}
+
+	// Content stored before review was required stays approved when it is
+	// re-applied unchanged.
+	_, err := store.Upsert(ctx, taggedAgentObj("alice", "1.0.0", "one", nil))
+	require.NoError(t, err)
+	res, err := store.Upsert(ctx, taggedAgentObj("alice", "1.0.0", "one", nil), held)
+	require.NoError(t, err)
+	require.Equal(t, v1alpha1store.UpsertNoOp, res.Outcome)
+	require.Equal(t, v1alpha1store.ApprovalApproved, res.ApprovalState)
+
+	next := taggedAgentObj("alice", "1.1.0", "two", nil)
+	res, err = store.Upsert(ctx, next, held)
+	require.NoError(t, err)
+	require.Equal(t, v1alpha1store.UpsertCreated, res.Outcome)
+	require.Equal(t, v1alpha1store.ApprovalPending, res.ApprovalState)
+	digest, err := v1alpha1store.ObjectDigest(next)
+	require.NoError(t, err)
+	pending, err := store.GetApproval(ctx, "default", "alice", digest)
+	require.NoError(t, err)
+	require.Equal(t, "alice", pending.RequestedBy)
+	require.Equal(t, "1.1.0", pending.Tag)
+
+	_, err = store.ReviewApproval(ctx, "default", "alice", digest, false, "bob", "")
+	require.NoError(t, err)
+	res, err = store.Upsert(ctx, taggedAgentObj("alice", "1.1.0", "two", nil), held)
+	require.NoError(t, err)
+	require.Equal(t, v1alpha1store.ApprovalRejected, res.ApprovalState, "unchanged content keeps its decision")
+	res, err = store.Upsert(ctx, taggedAgentObj("alice", "1.2.0", "two", nil), held)
+	require.NoError(t, err)
+	require.Equal(t, v1alpha1store.ApprovalPending, res.ApprovalState, "rejected content published again is reviewed again")
+
+	plain := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
+	_, err = plain.Upsert(ctx, taggedAgentObj("alice", "2.0.0", "three", nil), held)
+	require.ErrorIs(t, err, v1alpha1store.ErrApprovalsDisabled)
+	_, err = plain.Get(ctx, "default", "alice", "2.0.0")
+	require.ErrorIs(t, err, pkgdb.ErrNotFound)
+}
+
+func TestApprovals_RequestReviewResolve(t *testing.T) {
+	ctx := context.Background()
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents", v1alpha1store.WithApprovals(v1alpha1store.TestSchema()))
+	require.True(t, store.Approvals())
+
+	// 1.0.0 predates the policy and has no review entry: it counts as
+	// approved.
+	_, err := store.Upsert(ctx, taggedAgentObj("alice", "1.0.0", "one", nil))
+	require.NoError(t, err)
+	_, err = store.GetUsableByRef(ctx, "default", "alice", "1.0.0")
+	require.NoError(t, err)
+
+	next := taggedAgentObj("alice", "1.1.0", "two", nil)
+	_, err = store.Upsert(ctx, next)
+	require.NoError(t, err)
+	digest, err := v1alpha1store.ObjectDigest(next)
+	require.NoError(t, err)
+	pending, err := store.RequestApproval(ctx, "default", "alice", "1.1.0", digest, "bob")
+	require.NoError(t, err)
+	require.Equal(t, v1alpha1store.ApprovalPending, pending.State)
+	require.Equal(t, "bob", pending.RequestedBy)
+
+	// The pending tag is readable but resolution ignores it.
+	_, err = store.GetByRef(ctx, "default", "alice", "1.1.0")
+	require.NoError(t, err)
+	_, err = store.GetUsableByRef(ctx, "default", "alice", "1.1.0")
+	require.ErrorIs(t, err, v1alpha1store.ErrUnapproved)
+	got, err := store.GetUsableByRef(ctx, "default", "alice", "^1.0")
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0", got.Metadata.Tag, "ranges skip unapproved tags")
+
+	listed, err := store.ListApprovals(ctx, "", v1alpha1store.ApprovalPending)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.Equal(t, digest, listed[0].Digest)
+
+	rejected, err := store.ReviewApproval(ctx, "default", "alice", digest, false, "carol", "missing docs")
+	require.NoError(t, err)
+	require.Equal(t, v1alpha1store.ApprovalRejected, rejected.State)
+	require.Equal(t, "missing docs", rejected.Comment)
+	require.NotNil(t, rejected.ReviewedAt)
+
+	// Publishing rejected content again asks for another review.
+	again, err := store.RequestApproval(ctx, "default", "alice", "1.1.0", digest, "bob")
+	require.NoError(t, err)
+	require.Equal(t, v1alpha1store.ApprovalPending, again.State)
+	require.Empty(t, again.ReviewedBy)
+
+	approved, err := store.ReviewApproval(ctx, "default", "alice", digest, true, "carol", "")
+	require.NoError(t, err)
+	require.Equal(t, v1alpha1store.ApprovalApproved, approved.State)
+	got, err = store.GetUsableByRef(ctx, "default", "alice", "^1.0")
+	require.NoError(t, err)
+	require.Equal(t, "1.1.0", got.Metadata.Tag)
+
+	// Approved content stays approved when published again.
+	kept, err := store.RequestApproval(ctx, "default", "alice", "stable", digest, "bob")
+	require.NoError(t, err)
+	require.Equal(t, v1alpha1store.ApprovalApproved, kept.State)
+
+	_, err = store.ReviewApproval(ctx, "default", "alice", v1alpha1store.ContentDigest(strings.Repeat("0", 64)), true, "carol", "")
+	require.ErrorIs(t, err, pkgdb.ErrNotFound)
+
+	require.NoError(t, store.DeleteAllTags(ctx, "default", "alice"))
+	listed, err = store.ListApprovals(ctx, "default", "")
+	require.NoError(t, err)
+	require.Empty(t, listed)
+}
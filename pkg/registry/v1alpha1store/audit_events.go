@@ -0,0 +1,274 @@
+package v1alpha1store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+const defaultAuditListLimit = 50
+
+// AuditEntry is one stored row of the audit log.
+type AuditEntry struct {
+	ID         int64
+	OccurredAt time.Time
+	RequestID  string
+	Principal  string
+	Verb       string
+	Kind       string
+	Namespace  string
+	Name       string
+	Tag        string
+	Reason     string
+	Diff       json.RawMessage
+}
+
+// AuditQuery filters AuditStore.List. Empty fields match every row.
+type AuditQuery struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Tag       string
+	Principal string
+	Verb      string
+	RequestID string
+	// Since and Until bound occurred_at: Since is inclusive, Until
+	// exclusive. Zero leaves the side open.
+	Since time.Time
+	Until time.Time
+	// Limit caps the number of rows returned. Zero means default (50).
+	Limit int
+	// Cursor is the opaque token returned by the previous page.
+	Cursor string
+}
+
+// AuditIdentity names the caller carried by ctx for an audit entry.
+type AuditIdentity func(ctx context.Context) (principal, requestID string)
+
+// AuditStore appends to and reads the audit_events table.
+type AuditStore struct {
+	db        DB
+	qualified string
+}
+
+// NewAuditStore constructs an audit log store.
+func NewAuditStore(db DB, schema pkgdb.Schema) *AuditStore {
+	db = normalizeDB(db)
+	return &AuditStore{
+		db:        db,
+		qualified: qualifyTable(db, schema, "audit_events"),
+	}
+}
+
+// Insert appends entry to the audit log. ID and OccurredAt are assigned by
+// the database.
+func (s *AuditStore) Insert(ctx context.Context, entry AuditEntry) error {
+	if s == nil || s.db == nil {
+		return errors.New("v1alpha1 store: audit store has no database")
+	}
+	return s.insert(ctx, s.db, entry)
+}
+
+// insert appends entry through db, which may be a transaction of another
+// store on the same database.
+func (s *AuditStore) insert(ctx context.Context, db DB, entry AuditEntry) error {
+	if entry.Verb == "" {
+		return errors.New("v1alpha1 store: audit verb is required")
+	}
+	var diff []byte
+	if len(entry.Diff) > 0 {
+		diff = entry.Diff
+	}
+	if _, err := db.Exec(ctx, `
+		INSERT INTO `+s.qualified+` (request_id, principal, verb, kind, namespace, name, tag, reason, diff)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		entry.RequestID, entry.Principal, entry.Verb, entry.Kind, entry.Namespace, entry.Name, entry.Tag, entry.Reason, diff,
+	); err != nil {
+		return fmt.Errorf("insert audit event: %w", err)
+	}
+	return nil
+}
+
+// List returns the entries matching q, newest first. A non-empty cursor is
+// returned when more rows are available; pass it back via AuditQuery.Cursor
+// to continue.
+func (s *AuditStore) List(ctx context.Context, q AuditQuery) ([]AuditEntry, string, error) {
+	if s == nil || s.db == nil {
+		return nil, "", errors.New("v1alpha1 store: audit store has no database")
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultAuditListLimit
+	}
+
+	var (
+		where []string
+		args  []any
+	)
+	add := func(predicate string, arg any) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(predicate, len(args)))
+	}
+	for _, f := range []struct {
+		column, value string
+	}{
+		{"kind", q.Kind},
+		{"namespace", q.Namespace},
+		{"name", q.Name},
+		{"tag", q.Tag},
+		{"principal", q.Principal},
+		{"verb", q.Verb},
+		{"request_id", q.RequestID},
+	} {
+		if f.value != "" {
+			add(f.column+" = $%d", f.value)
+		}
+	}
+	if !q.Since.IsZero() {
+		add("occurred_at >= $%d", q.Since)
+	}
+	if !q.Until.IsZero() {
+		add("occurred_at < $%d", q.Until)
+	}
+	if q.Cursor != "" {
+		afterID, err := strconv.ParseInt(q.Cursor, 10, 64)
+		if err != nil || afterID <= 0 {
+			return nil, "", fmt.Errorf("%w: %q", ErrInvalidCursor, q.Cursor)
+		}
+		add("id < $%d", afterID)
+	}
+	query := `
+		SELECT id, occurred_at, request_id, principal, verb, kind, namespace, name, tag, reason, diff
+		FROM ` + s.qualified
+	if len(where) > 0 {
+		query += "\n\t\tWHERE " + strings.Join(where, " AND ")
+	}
+	args = append(args, limit+1)
+	query += fmt.Sprintf("\n\t\tORDER BY id DESC\n\t\tLIMIT $%d", len(args))
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("list audit events: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]AuditEntry, 0, limit)
+	for rows.Next() {
+		var (
+			entry AuditEntry
+			diff  []byte
+		)
+		if err := rows.Scan(
+			&entry.ID, &entry.OccurredAt, &entry.RequestID, &entry.Principal, &entry.Verb,
+			&entry.Kind, &entry.Namespace, &entry.Name, &entry.Tag, &entry.Reason, &diff,
+		); err != nil {
+			return nil, "", fmt.Errorf("scan audit event: %w", err)
+		}
+		if len(diff) > 0 {
+			entry.Diff = json.RawMessage(diff)
+		}
+		out = append(out, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("read audit events: %w", err)
+	}
+
+	var next string
+	if len(out) > limit {
+		out = out[:limit]
+		next = strconv.FormatInt(out[limit-1].ID, 10)
+	}
+	return out, next, nil
+}
+
+// PruneBefore deletes entries older than before in bounded batches and
+// returns how many it removed.
+func (s *AuditStore) PruneBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	if s == nil || s.db == nil {
+		return 0, errors.New("v1alpha1 store: audit store has no database")
+	}
+	if before.IsZero() {
+		return 0, errors.New("v1alpha1 store: audit prune requires an age bound")
+	}
+	if limit <= 0 {
+		limit = defaultEventBatchLimit
+	}
+	cmdTag, err := s.db.Exec(ctx, `
+		DELETE FROM `+s.qualified+`
+		WHERE id IN (
+			SELECT id
+			FROM `+s.qualified+`
+			WHERE occurred_at < $1
+			ORDER BY id
+			LIMIT $2
+		)`, before, limit)
+	if err != nil {
+		return 0, fmt.Errorf("prune audit events: %w", err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+// auditDiff returns the JSON merge patch from the audited view of a row —
+// metadata.labels, metadata.annotations and spec — before a write to the
+// view after it. Nil old columns (a create) diff against an empty
+// document, so the patch carries the whole new view. Returns nil when the
+// diff cannot be computed; the audit entry is still recorded without it.
+func auditDiff(oldLabels, oldAnnotations, oldSpec, newLabels, newAnnotations, newSpec []byte) json.RawMessage {
+	oldDoc, err := auditView(oldLabels, oldAnnotations, oldSpec)
+	if err != nil {
+		return nil
+	}
+	newDoc, err := auditView(newLabels, newAnnotations, newSpec)
+	if err != nil {
+		return nil
+	}
+	return mergePatch(oldDoc, newDoc)
+}
+
+// auditView leaves out empty label and annotation maps, so a create
+// without metadata diffs to its spec alone.
+func auditView(labels, annotations, spec []byte) ([]byte, error) {
+	view := map[string]json.RawMessage{}
+	metadata := map[string]json.RawMessage{}
+	if !equalJSONMap(labels, nil) {
+		metadata["labels"] = labels
+	}
+	if !equalJSONMap(annotations, nil) {
+		metadata["annotations"] = annotations
+	}
+	if len(metadata) > 0 {
+		raw, err := json.Marshal(metadata)
+		if err != nil {
+			return nil, err
+		}
+		view["metadata"] = raw
+	}
+	if len(spec) > 0 {
+		view["spec"] = spec
+	}
+	return json.Marshal(view)
+}
+
+// mergePatch returns the JSON merge patch turning oldDoc into newDoc, or
+// nil when either side is not a JSON object.
+func mergePatch(oldDoc, newDoc []byte) json.RawMessage {
+	if len(oldDoc) == 0 || string(oldDoc) == "null" {
+		oldDoc = []byte("{}")
+	}
+	if len(newDoc) == 0 || string(newDoc) == "null" {
+		newDoc = []byte("{}")
+	}
+	patch, err := jsonpatch.CreateMergePatch(oldDoc, newDoc)
+	if err != nil {
+		return nil
+	}
+	return patch
+}
@@ -0,0 +1,134 @@
+//go:build integration
+
+package v1alpha1store
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+func TestAuditStore_ListFiltersAndPages(t *testing.T) {
+	db := NewTestDB(t)
+	audit := NewAuditStore(db, TestSchema())
+	ctx := context.Background()
+
+	for _, entry := range []AuditEntry{
+		{Verb: "create", Kind: "Agent", Namespace: "default", Name: "a", Tag: "latest", Principal: "alice", RequestID: "r1", Diff: json.RawMessage(`{"spec":{"title":"x"}}`)},
+		{Verb: "update", Kind: "Agent", Namespace: "default", Name: "a", Tag: "latest", Principal: "bob", RequestID: "r2"},
+		{Verb: "deny", Kind: "Agent", Namespace: "team-a", Name: "b", Principal: "bob", Reason: "apply: forbidden"},
+		{Verb: "delete", Kind: "Runtime", Namespace: "default", Name: "k8s", Principal: "system"},
+	} {
+		require.NoError(t, audit.Insert(ctx, entry))
+	}
+
+	all, next, err := audit.List(ctx, AuditQuery{})
+	require.NoError(t, err)
+	require.Empty(t, next)
+	require.Len(t, all, 4)
+	require.Equal(t, "delete", all[0].Verb, "newest first")
+	require.JSONEq(t, `{"spec":{"title":"x"}}`, string(all[3].Diff))
+
+	byPrincipal, _, err := audit.List(ctx, AuditQuery{Principal: "bob"})
+	require.NoError(t, err)
+	require.Len(t, byPrincipal, 2)
+
+	byResource, _, err := audit.List(ctx, AuditQuery{Kind: "Agent", Namespace: "default", Name: "a"})
+	require.NoError(t, err)
+	require.Len(t, byResource, 2)
+
+	byRequest, _, err := audit.List(ctx, AuditQuery{RequestID: "r1"})
+	require.NoError(t, err)
+	require.Len(t, byRequest, 1)
+	require.Equal(t, "alice", byRequest[0].Principal)
+
+	future, _, err := audit.List(ctx, AuditQuery{Since: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	require.Empty(t, future)
+
+	page, next, err := audit.List(ctx, AuditQuery{Limit: 3})
+	require.NoError(t, err)
+	require.Len(t, page, 3)
+	require.NotEmpty(t, next)
+	rest, next, err := audit.List(ctx, AuditQuery{Limit: 3, Cursor: next})
+	require.NoError(t, err)
+	require.Empty(t, next)
+	require.Len(t, rest, 1)
+	require.Equal(t, "create", rest[0].Verb)
+
+	_, _, err = audit.List(ctx, AuditQuery{Cursor: "not-a-cursor"})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestAuditStore_PruneBefore(t *testing.T) {
+	db := NewTestDB(t)
+	audit := NewAuditStore(db, TestSchema())
+	ctx := context.Background()
+
+	for range 3 {
+		require.NoError(t, audit.Insert(ctx, AuditEntry{Verb: "create", Kind: "Agent", Namespace: "default", Name: "a"}))
+	}
+	_, err := db.Exec(ctx, `UPDATE `+audit.qualified+` SET occurred_at = $1
+		WHERE id IN (SELECT id FROM `+audit.qualified+` ORDER BY id LIMIT 2)`, time.Now().Add(-100*24*time.Hour))
+	require.NoError(t, err)
+
+	n, err := audit.PruneBefore(ctx, time.Now().Add(-90*24*time.Hour), 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n, "one batch per call")
+	n, err = audit.PruneBefore(ctx, time.Now().Add(-90*24*time.Hour), 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	left, _, err := audit.List(ctx, AuditQuery{})
+	require.NoError(t, err)
+	require.Len(t, left, 1)
+}
+
+// TestStore_WritesAuditLogInTransaction checks that a Store with an audit
+// log commits each change together with its entry: a change whose entry
+// cannot be written does not happen.
+func TestStore_WritesAuditLogInTransaction(t *testing.T) {
+	db := NewTestDB(t)
+	audit := NewAuditStore(db, TestSchema())
+	store := NewStore(db, TestSchema(), "agents",
+		WithKind(v1alpha1.KindAgent),
+		WithAuditLog(audit, func(context.Context) (string, string) { return "alice", "req-1" }),
+	)
+	ctx := context.Background()
+	agent := func(name string) *v1alpha1.Agent {
+		return &v1alpha1.Agent{
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:     v1alpha1.AgentSpec{Title: name},
+		}
+	}
+
+	_, err := store.Upsert(ctx, agent("logged"))
+	require.NoError(t, err)
+	require.NoError(t, store.PatchStatus(ctx, "default", "logged", "latest", func(json.RawMessage) (json.RawMessage, error) {
+		return json.RawMessage(`{"phase":"Ready"}`), nil
+	}))
+
+	entries, _, err := audit.List(ctx, AuditQuery{})
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "status patches are not audited")
+	require.Equal(t, "create", entries[0].Verb)
+	require.Equal(t, "alice", entries[0].Principal)
+	require.Equal(t, "req-1", entries[0].RequestID)
+	require.JSONEq(t, `{"spec":{"title":"logged"}}`, string(entries[0].Diff))
+
+	_, err = db.Exec(ctx, `DROP TABLE `+audit.qualified)
+	require.NoError(t, err)
+	_, err = store.Upsert(ctx, agent("unlogged"))
+	require.Error(t, err)
+	_, err = store.Get(ctx, "default", "unlogged", "latest")
+	require.ErrorIs(t, err, pkgdb.ErrNotFound, "the change rolls back with its audit entry")
+	require.Error(t, store.Delete(ctx, "default", "logged", "latest"))
+	_, err = store.Get(ctx, "default", "logged", "latest")
+	require.NoError(t, err)
+}
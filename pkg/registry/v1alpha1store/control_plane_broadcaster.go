@@ -0,0 +1,112 @@
+package v1alpha1store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ListenForControlPlaneChanges calls changed for every control-plane
+// change committed through db until ctx is cancelled or the listener
+// fails. On PostgreSQL it holds one LISTEN session on
+// ControlPlaneNotifyChannel; on the SQLite backend it subscribes to the
+// commits of this process, the only writer. listening, when set, is called
+// once the listener is established. Callers own the reconnect policy.
+func ListenForControlPlaneChanges(ctx context.Context, db DB, listening, changed func()) error {
+	switch v := normalizeDB(db).(type) {
+	case *pgxpool.Pool:
+		conn, err := v.Acquire(ctx)
+		if err != nil {
+			return fmt.Errorf("acquire LISTEN connection: %w", err)
+		}
+		defer conn.Release()
+		if _, err := conn.Exec(ctx, "LISTEN "+ControlPlaneNotifyChannel); err != nil {
+			return fmt.Errorf("listen for control-plane changes: %w", err)
+		}
+		if listening != nil {
+			listening()
+		}
+		for {
+			if _, err := conn.Conn().WaitForNotification(ctx); err != nil {
+				return fmt.Errorf("wait for control-plane notification: %w", err)
+			}
+			changed()
+		}
+	case *SQLite:
+		ch, unsubscribe := v.Subscribe()
+		defer unsubscribe()
+		if listening != nil {
+			listening()
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ch:
+				changed()
+			}
+		}
+	case nil:
+		return errors.New("v1alpha1 store: control-plane listener has no database")
+	default:
+		return fmt.Errorf("v1alpha1 store: cannot listen for control-plane changes on %T", db)
+	}
+}
+
+// ControlPlaneBroadcaster fans ControlPlaneNotifyChannel wakeups out to any
+// number of in-process subscribers over one LISTEN connection, so API
+// watch streams do not each hold a database connection. Like the controller
+// wakeups it carries no payload: subscribers replay control_plane_events
+// after their own cursor.
+type ControlPlaneBroadcaster struct {
+	db DB
+
+	mu   sync.Mutex
+	subs map[chan struct{}]struct{}
+}
+
+// NewControlPlaneBroadcaster constructs a broadcaster. Call Listen to start
+// delivering notifications.
+func NewControlPlaneBroadcaster(db DB) *ControlPlaneBroadcaster {
+	return &ControlPlaneBroadcaster{db: normalizeDB(db), subs: map[chan struct{}]struct{}{}}
+}
+
+// Subscribe registers a wakeup channel. The channel is buffered to one
+// pending wakeup; bursts coalesce. Call the returned func to unsubscribe.
+func (b *ControlPlaneBroadcaster) Subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch, func() {
+		b.mu.Lock()
+		delete(b.subs, ch)
+		b.mu.Unlock()
+	}
+}
+
+// Listen holds one control-plane listener (see
+// ListenForControlPlaneChanges) until ctx is cancelled or it fails, waking
+// every subscriber per notification. It also wakes them once the listener
+// is established so notifications missed while reconnecting are picked up.
+// Callers own the reconnect policy.
+func (b *ControlPlaneBroadcaster) Listen(ctx context.Context) error {
+	if b == nil || b.db == nil {
+		return errors.New("v1alpha1 store: control-plane broadcaster has no database")
+	}
+	return ListenForControlPlaneChanges(ctx, b.db, b.broadcast, b.broadcast)
+}
+
+func (b *ControlPlaneBroadcaster) broadcast() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
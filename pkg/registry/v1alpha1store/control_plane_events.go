@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 
 	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 )
@@ -43,27 +42,28 @@ type ControlPlaneEvent struct {
 // ControlPlaneEventStore reads and prunes the durable invalidation cursor used
 // by controllers.
 type ControlPlaneEventStore struct {
-	pool      *pgxpool.Pool
+	db        DB
 	qualified string
 }
 
 // NewControlPlaneEventStore constructs a control-plane event reader.
-func NewControlPlaneEventStore(pool *pgxpool.Pool, schema pkgdb.Schema) *ControlPlaneEventStore {
+func NewControlPlaneEventStore(db DB, schema pkgdb.Schema) *ControlPlaneEventStore {
+	db = normalizeDB(db)
 	return &ControlPlaneEventStore{
-		pool:      pool,
-		qualified: schema.Qualify("control_plane_events"),
+		db:        db,
+		qualified: qualifyTable(db, schema, "control_plane_events"),
 	}
 }
 
 // ListAfter returns events with revision > afterRevision, ordered by revision.
 func (s *ControlPlaneEventStore) ListAfter(ctx context.Context, afterRevision int64, limit int) ([]ControlPlaneEvent, error) {
-	if s == nil || s.pool == nil {
-		return nil, errors.New("v1alpha1 store: control-plane event store has nil pool")
+	if s == nil || s.db == nil {
+		return nil, errors.New("v1alpha1 store: control-plane event store has no database")
 	}
 	if limit <= 0 {
 		limit = defaultEventBatchLimit
 	}
-	rows, err := s.pool.Query(ctx, `
+	rows, err := s.db.Query(ctx, `
 		SELECT revision, kind, namespace, name, tag, uid::text, generation, op, committed_at
 		FROM `+s.qualified+`
 		WHERE revision > $1
@@ -91,11 +91,11 @@ func (s *ControlPlaneEventStore) ListAfter(ctx context.Context, afterRevision in
 // OldestRevision returns the oldest retained event revision. ok=false means the
 // table is empty.
 func (s *ControlPlaneEventStore) OldestRevision(ctx context.Context) (revision int64, ok bool, err error) {
-	if s == nil || s.pool == nil {
-		return 0, false, errors.New("v1alpha1 store: control-plane event store has nil pool")
+	if s == nil || s.db == nil {
+		return 0, false, errors.New("v1alpha1 store: control-plane event store has no database")
 	}
 	var oldest sql.NullInt64
-	if err := s.pool.QueryRow(ctx, `SELECT MIN(revision) FROM `+s.qualified).Scan(&oldest); err != nil {
+	if err := s.db.QueryRow(ctx, `SELECT MIN(revision) FROM `+s.qualified).Scan(&oldest); err != nil {
 		return 0, false, fmt.Errorf("load oldest control-plane event revision: %w", err)
 	}
 	if !oldest.Valid {
@@ -107,11 +107,11 @@ func (s *ControlPlaneEventStore) OldestRevision(ctx context.Context) (revision i
 // CurrentRevision returns the current high-water revision, or 0 when the event
 // table is empty.
 func (s *ControlPlaneEventStore) CurrentRevision(ctx context.Context) (int64, error) {
-	if s == nil || s.pool == nil {
-		return 0, errors.New("v1alpha1 store: control-plane event store has nil pool")
+	if s == nil || s.db == nil {
+		return 0, errors.New("v1alpha1 store: control-plane event store has no database")
 	}
 	var revision int64
-	if err := s.pool.QueryRow(ctx, `SELECT COALESCE(MAX(revision), 0) FROM `+s.qualified).Scan(&revision); err != nil {
+	if err := s.db.QueryRow(ctx, `SELECT COALESCE(MAX(revision), 0) FROM `+s.qualified).Scan(&revision); err != nil {
 		return 0, fmt.Errorf("load current control-plane event revision: %w", err)
 	}
 	return revision, nil
@@ -121,8 +121,8 @@ func (s *ControlPlaneEventStore) CurrentRevision(ctx context.Context) (int64, er
 // before or keepAfterRevision must be set. Controllers must use gap detection
 // before relying on pruning in production.
 func (s *ControlPlaneEventStore) PruneBefore(ctx context.Context, before time.Time, keepAfterRevision int64, limit int) (int64, error) {
-	if s == nil || s.pool == nil {
-		return 0, errors.New("v1alpha1 store: control-plane event store has nil pool")
+	if s == nil || s.db == nil {
+		return 0, errors.New("v1alpha1 store: control-plane event store has no database")
 	}
 	if before.IsZero() && keepAfterRevision <= 0 {
 		return 0, errors.New("v1alpha1 store: control-plane event prune requires an age or revision bound")
@@ -134,18 +134,16 @@ func (s *ControlPlaneEventStore) PruneBefore(ctx context.Context, before time.Ti
 	if !before.IsZero() {
 		beforeArg = before
 	}
-	cmdTag, err := s.pool.Exec(ctx, `
-		WITH doomed AS (
+	cmdTag, err := s.db.Exec(ctx, `
+		DELETE FROM `+s.qualified+`
+		WHERE revision IN (
 			SELECT revision
 			FROM `+s.qualified+`
 			WHERE ($1::timestamptz IS NULL OR committed_at < $1)
 			  AND ($2::bigint <= 0 OR revision < $2)
 			ORDER BY revision
 			LIMIT $3
-		)
-		DELETE FROM `+s.qualified+` e
-		USING doomed
-		WHERE e.revision = doomed.revision`, beforeArg, keepAfterRevision, limit)
+		)`, beforeArg, keepAfterRevision, limit)
 	if err != nil {
 		return 0, fmt.Errorf("prune control-plane events: %w", err)
 	}
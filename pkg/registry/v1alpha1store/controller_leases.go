@@ -0,0 +1,98 @@
+package v1alpha1store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// Lease is the current state of a named controller lease.
+type Lease struct {
+	Name       string
+	Holder     string
+	AcquiredAt time.Time
+	ExpiresAt  time.Time
+}
+
+// LeaseStore grants time-bounded, named leases out of controller_leases so
+// that exactly one server replica runs a given set of controllers. Expiry is
+// computed with the database clock.
+type LeaseStore struct {
+	db        DB
+	qualified string
+}
+
+// NewLeaseStore constructs a lease store.
+func NewLeaseStore(db DB, schema pkgdb.Schema) *LeaseStore {
+	db = normalizeDB(db)
+	return &LeaseStore{
+		db:        db,
+		qualified: qualifyTable(db, schema, "controller_leases"),
+	}
+}
+
+// TryAcquire takes the named lease for holder, or renews it when holder
+// already has it, so that it expires ttl from now. It fails without error
+// while another holder's lease is unexpired, returning that lease and false.
+func (s *LeaseStore) TryAcquire(ctx context.Context, name, holder string, ttl time.Duration) (Lease, bool, error) {
+	if s == nil || s.db == nil {
+		return Lease{}, false, errors.New("v1alpha1 store: lease store has no database")
+	}
+	if ttl <= 0 {
+		return Lease{}, false, fmt.Errorf("v1alpha1 store: lease ttl must be positive, got %s", ttl)
+	}
+	// Expiry is computed on the database clock; the SQLite backend's
+	// now() is this process's clock, so it is computed here instead.
+	expires, expiresArg := "now() + make_interval(secs => $3)", any(ttl.Seconds())
+	if isSQLite(s.db) {
+		expires, expiresArg = "$3", time.Now().Add(ttl)
+	}
+	lease := Lease{Name: name}
+	err := s.db.QueryRow(ctx, `
+		INSERT INTO `+s.qualified+` AS l (name, holder, acquired_at, renewed_at, expires_at)
+		VALUES ($1, $2, now(), now(), `+expires+`)
+		ON CONFLICT (name) DO UPDATE SET
+			holder = EXCLUDED.holder,
+			acquired_at = CASE WHEN l.holder = EXCLUDED.holder THEN l.acquired_at ELSE now() END,
+			renewed_at = now(),
+			expires_at = EXCLUDED.expires_at
+		WHERE l.holder = EXCLUDED.holder OR l.expires_at <= now()
+		RETURNING holder, acquired_at, expires_at`,
+		name, holder, expiresArg,
+	).Scan(&lease.Holder, &lease.AcquiredAt, &lease.ExpiresAt)
+	if err == nil {
+		return lease, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return Lease{}, false, fmt.Errorf("acquire lease %s: %w", name, err)
+	}
+	// Another holder's lease is still live; report who has it.
+	err = s.db.QueryRow(ctx, `
+		SELECT holder, acquired_at, expires_at
+		FROM `+s.qualified+`
+		WHERE name = $1`, name,
+	).Scan(&lease.Holder, &lease.AcquiredAt, &lease.ExpiresAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return Lease{}, false, fmt.Errorf("read lease %s: %w", name, err)
+	}
+	return lease, false, nil
+}
+
+// Release gives up the named lease if holder still has it, so another
+// replica can take over without waiting for it to expire.
+func (s *LeaseStore) Release(ctx context.Context, name, holder string) error {
+	if s == nil || s.db == nil {
+		return errors.New("v1alpha1 store: lease store has no database")
+	}
+	if _, err := s.db.Exec(ctx, `
+		DELETE FROM `+s.qualified+`
+		WHERE name = $1 AND holder = $2`, name, holder); err != nil {
+		return fmt.Errorf("release lease %s: %w", name, err)
+	}
+	return nil
+}
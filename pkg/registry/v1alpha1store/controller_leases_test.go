@@ -0,0 +1,53 @@
+//go:build integration
+
+package v1alpha1store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaseStore_SingleHolderUntilReleaseOrExpiry(t *testing.T) {
+	db := NewTestDB(t)
+	leases := NewLeaseStore(db, TestSchema())
+	ctx := context.Background()
+
+	lease, held, err := leases.TryAcquire(ctx, "controllers", "replica-a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, held)
+	require.Equal(t, "replica-a", lease.Holder)
+	acquiredAt := lease.AcquiredAt
+
+	// Renewing keeps the original acquisition time.
+	lease, held, err = leases.TryAcquire(ctx, "controllers", "replica-a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, held)
+	require.Equal(t, acquiredAt, lease.AcquiredAt)
+
+	lease, held, err = leases.TryAcquire(ctx, "controllers", "replica-b", time.Minute)
+	require.NoError(t, err)
+	require.False(t, held)
+	require.Equal(t, "replica-a", lease.Holder)
+
+	// Releasing someone else's lease is a no-op.
+	require.NoError(t, leases.Release(ctx, "controllers", "replica-b"))
+	_, held, err = leases.TryAcquire(ctx, "controllers", "replica-b", time.Minute)
+	require.NoError(t, err)
+	require.False(t, held)
+
+	require.NoError(t, leases.Release(ctx, "controllers", "replica-a"))
+	lease, held, err = leases.TryAcquire(ctx, "controllers", "replica-b", 50*time.Millisecond)
+	require.NoError(t, err)
+	require.True(t, held)
+	require.Equal(t, "replica-b", lease.Holder)
+
+	// An expired lease can be taken over.
+	time.Sleep(100 * time.Millisecond)
+	lease, held, err = leases.TryAcquire(ctx, "controllers", "replica-a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, held)
+	require.Equal(t, "replica-a", lease.Holder)
+}
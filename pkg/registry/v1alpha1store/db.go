@@ -0,0 +1,88 @@
+package v1alpha1store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// DB is the query surface every store in this package runs on. Both a
+// *pgxpool.Pool and a pgx.Tx satisfy it, as do the embedded *SQLite
+// backend and its transactions. Statements are written in the PostgreSQL
+// dialect; the SQLite backend translates the small subset the stores use
+// and the stores branch where the dialects differ (see isSQLite).
+type DB interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// normalizeDB maps a typed-nil backend to a nil DB, so a nil
+// *pgxpool.Pool from a database-less composition still reads as "no
+// database" through the stores' nil checks.
+func normalizeDB(db DB) DB {
+	switch v := db.(type) {
+	case *pgxpool.Pool:
+		if v == nil {
+			return nil
+		}
+	case *SQLite:
+		if v == nil {
+			return nil
+		}
+	}
+	return db
+}
+
+// isSQLite reports whether db is the embedded SQLite backend.
+func isSQLite(db DB) bool {
+	_, ok := db.(*SQLite)
+	return ok
+}
+
+// qualifyTable returns the table reference a store uses in SQL. The
+// SQLite backend keeps every table in its main database, so the schema
+// is dropped there.
+func qualifyTable(db DB, schema pkgdb.Schema, table string) string {
+	if isSQLite(db) {
+		return pgx.Identifier{table}.Sanitize()
+	}
+	return schema.Qualify(table)
+}
+
+// runInTx executes fn within a read-committed transaction, committing on nil
+// return and rolling back on error. SQLite transactions take the database
+// write lock up front, which serializes writers the way the advisory and
+// row locks do on PostgreSQL.
+func runInTx(ctx context.Context, db DB, fn func(DB) error) error {
+	var (
+		tx  pgx.Tx
+		err error
+	)
+	switch v := db.(type) {
+	case *pgxpool.Pool:
+		tx, err = v.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	case *SQLite:
+		return v.runInTx(ctx, fn)
+	default:
+		return errors.New("v1alpha1 store: database does not support transactions")
+	}
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
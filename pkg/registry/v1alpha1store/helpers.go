@@ -1,14 +1,13 @@
 package v1alpha1store
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
@@ -33,7 +32,8 @@ type rowScanner interface {
 // Column order must match:
 //
 //	namespace, name, tag-or-empty, uid, generation, labels, annotations, spec, status,
-//	deletion_timestamp, finalizers, created_at, updated_at
+//	deletion_timestamp, finalizers, created_at, updated_at, resource_version,
+//	deprecated, yanked
 func scanRow(row rowScanner, tagged bool) (*v1alpha1.RawObject, error) {
 	var (
 		namespace         string
@@ -49,13 +49,17 @@ func scanRow(row rowScanner, tagged bool) (*v1alpha1.RawObject, error) {
 		finalizersJSON    []byte
 		createdAt         time.Time
 		updatedAt         time.Time
+		resourceVersion   int64
+		deprecatedJSON    []byte
+		yankedJSON        []byte
 	)
 
 	if err := row.Scan(
 		&namespace, &name, &tag, &uid, &generation,
 		&labelsJSON, &annotationsJSON, &specJSON, &statusJSON,
 		&deletionTimestamp, &finalizersJSON,
-		&createdAt, &updatedAt,
+		&createdAt, &updatedAt, &resourceVersion,
+		&deprecatedJSON, &yankedJSON,
 	); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, pkgdb.ErrNotFound
@@ -88,10 +92,21 @@ func scanRow(row rowScanner, tagged bool) (*v1alpha1.RawObject, error) {
 		Labels:            labels,
 		Annotations:       annotations,
 		Generation:        generation,
+		ResourceVersion:   formatResourceVersion(resourceVersion),
 		CreatedAt:         createdAt,
 		UpdatedAt:         updatedAt,
 		DeletionTimestamp: deletionTimestamp,
 	}
+	if len(deprecatedJSON) > 0 {
+		if err := json.Unmarshal(deprecatedJSON, &meta.Deprecated); err != nil {
+			return nil, fmt.Errorf("decode deprecated: %w", err)
+		}
+	}
+	if len(yankedJSON) > 0 {
+		if err := json.Unmarshal(yankedJSON, &meta.Yanked); err != nil {
+			return nil, fmt.Errorf("decode yanked: %w", err)
+		}
+	}
 	raw := &v1alpha1.RawObject{
 		Metadata: meta,
 		Spec:     json.RawMessage(specJSON),
@@ -105,20 +120,30 @@ func scanRow(row rowScanner, tagged bool) (*v1alpha1.RawObject, error) {
 	return raw, nil
 }
 
-// runInTx executes fn within a read-committed transaction, committing on nil
-// return and rolling back on error.
-func runInTx(ctx context.Context, pool *pgxpool.Pool, fn func(pgx.Tx) error) error {
-	tx, err := pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
-	if err != nil {
-		return fmt.Errorf("begin tx: %w", err)
+// formatResourceVersion renders a resource_version column value as the
+// opaque metadata.resourceVersion string. The 0 of an unversioned table
+// renders empty.
+func formatResourceVersion(v int64) string {
+	if v == 0 {
+		return ""
 	}
-	defer func() { _ = tx.Rollback(ctx) }()
+	return strconv.FormatInt(v, 10)
+}
 
-	if err := fn(tx); err != nil {
-		return err
+// checkResourceVersion enforces a conditional write: an empty expected
+// version always passes; otherwise the row must exist at that version.
+func checkResourceVersion(expected string, found bool, current int64) error {
+	if expected == "" {
+		return nil
+	}
+	if !found {
+		return fmt.Errorf("%w: object no longer exists (resourceVersion %s)", ErrResourceVersionConflict, expected)
+	}
+	if current == 0 {
+		return fmt.Errorf("%w: the object's table keeps no resource versions", ErrResourceVersionConflict)
 	}
-	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("commit tx: %w", err)
+	if expected != formatResourceVersion(current) {
+		return fmt.Errorf("%w: resourceVersion %s is stale; the object is now at %d", ErrResourceVersionConflict, expected, current)
 	}
 	return nil
 }
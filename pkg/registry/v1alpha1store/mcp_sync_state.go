@@ -0,0 +1,104 @@
+package v1alpha1store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// MCPSyncState is the checkpoint of one upstream MCP registry mirror.
+type MCPSyncState struct {
+	Upstream string
+	// Cursor is the upstream list cursor of the next page of the pass in
+	// progress; empty when no pass is in progress.
+	Cursor string
+	// UpdatedSince is the updated_since filter of the pass in progress, or
+	// of the next pass when none is in progress. Zero means a full listing.
+	UpdatedSince time.Time
+	// PassStartedAt is when the pass in progress began; it becomes the
+	// next pass's UpdatedSince once this pass reaches the last page.
+	PassStartedAt time.Time
+	// LastSyncedAt is when the most recent pass completed.
+	LastSyncedAt time.Time
+}
+
+// MCPSyncStateStore persists MCPSyncState rows in mcp_sync_state.
+type MCPSyncStateStore struct {
+	db        DB
+	qualified string
+}
+
+// NewMCPSyncStateStore constructs a sync-state store.
+func NewMCPSyncStateStore(db DB, schema pkgdb.Schema) *MCPSyncStateStore {
+	db = normalizeDB(db)
+	return &MCPSyncStateStore{
+		db:        db,
+		qualified: qualifyTable(db, schema, "mcp_sync_state"),
+	}
+}
+
+// Get returns the checkpoint of upstream, or a zero state naming upstream
+// when it has never synced.
+func (s *MCPSyncStateStore) Get(ctx context.Context, upstream string) (MCPSyncState, error) {
+	if s == nil || s.db == nil {
+		return MCPSyncState{}, errors.New("v1alpha1 store: mcp sync state store has no database")
+	}
+	state := MCPSyncState{Upstream: upstream}
+	var updatedSince, passStartedAt, lastSyncedAt *time.Time
+	err := s.db.QueryRow(ctx, `
+		SELECT cursor, updated_since, pass_started_at, last_synced_at
+		FROM `+s.qualified+`
+		WHERE upstream = $1`, upstream,
+	).Scan(&state.Cursor, &updatedSince, &passStartedAt, &lastSyncedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return state, nil
+	}
+	if err != nil {
+		return MCPSyncState{}, fmt.Errorf("read mcp sync state %s: %w", upstream, err)
+	}
+	state.UpdatedSince = timeOrZero(updatedSince)
+	state.PassStartedAt = timeOrZero(passStartedAt)
+	state.LastSyncedAt = timeOrZero(lastSyncedAt)
+	return state, nil
+}
+
+// Save writes state, replacing the upstream's previous checkpoint.
+func (s *MCPSyncStateStore) Save(ctx context.Context, state MCPSyncState) error {
+	if s == nil || s.db == nil {
+		return errors.New("v1alpha1 store: mcp sync state store has no database")
+	}
+	if _, err := s.db.Exec(ctx, `
+		INSERT INTO `+s.qualified+` (upstream, cursor, updated_since, pass_started_at, last_synced_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, now())
+		ON CONFLICT (upstream) DO UPDATE SET
+			cursor = EXCLUDED.cursor,
+			updated_since = EXCLUDED.updated_since,
+			pass_started_at = EXCLUDED.pass_started_at,
+			last_synced_at = EXCLUDED.last_synced_at,
+			updated_at = now()`,
+		state.Upstream, state.Cursor,
+		nullTime(state.UpdatedSince), nullTime(state.PassStartedAt), nullTime(state.LastSyncedAt),
+	); err != nil {
+		return fmt.Errorf("save mcp sync state %s: %w", state.Upstream, err)
+	}
+	return nil
+}
+
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.UTC()
+}
@@ -0,0 +1,40 @@
+//go:build integration
+
+package v1alpha1store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPSyncStateStore_RoundTrip(t *testing.T) {
+	db := NewTestDB(t)
+	states := NewMCPSyncStateStore(db, TestSchema())
+	ctx := context.Background()
+
+	state, err := states.Get(ctx, "official")
+	require.NoError(t, err)
+	require.Equal(t, MCPSyncState{Upstream: "official"}, state, "a new upstream starts with a full listing")
+
+	passStarted := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, states.Save(ctx, MCPSyncState{Upstream: "official", Cursor: "page-2", PassStartedAt: passStarted}))
+	state, err = states.Get(ctx, "official")
+	require.NoError(t, err)
+	require.Equal(t, "page-2", state.Cursor)
+	require.True(t, state.UpdatedSince.IsZero())
+	require.True(t, passStarted.Equal(state.PassStartedAt))
+
+	require.NoError(t, states.Save(ctx, MCPSyncState{Upstream: "official", UpdatedSince: passStarted, LastSyncedAt: passStarted.Add(time.Minute)}))
+	state, err = states.Get(ctx, "official")
+	require.NoError(t, err)
+	require.Empty(t, state.Cursor)
+	require.True(t, passStarted.Equal(state.UpdatedSince))
+	require.True(t, state.PassStartedAt.IsZero())
+
+	other, err := states.Get(ctx, "mirror-b")
+	require.NoError(t, err)
+	require.Empty(t, other.Cursor, "upstreams keep separate checkpoints")
+}
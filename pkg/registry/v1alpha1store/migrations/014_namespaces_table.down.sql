@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS namespaces_control_plane_event ON namespaces;
+DROP TRIGGER IF EXISTS namespaces_notify_status ON namespaces;
+DROP TRIGGER IF EXISTS namespaces_set_updated_at ON namespaces;
+DROP TABLE IF EXISTS namespaces;
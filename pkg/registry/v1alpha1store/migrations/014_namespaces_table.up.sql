@@ -0,0 +1,55 @@
+-- Namespaces: the user-visible tenancy boundary. A mutable-object kind
+-- keyed by (namespace, name); every Namespace row lives in the 'default'
+-- namespace and its name is the namespace it describes. Seeds the default
+-- namespace so it is listed from the first boot, and declares every
+-- namespace that already holds objects so applies into it keep working. Wires the standard
+-- updated-at, status-notify, and control-plane event triggers used by
+-- mutable resources.
+
+CREATE TABLE IF NOT EXISTS namespaces (
+    namespace character varying(255) NOT NULL,
+    name character varying(255) NOT NULL,
+    uid uuid DEFAULT gen_random_uuid() NOT NULL,
+    generation bigint DEFAULT 1 NOT NULL,
+    labels jsonb DEFAULT '{}'::jsonb NOT NULL,
+    annotations jsonb DEFAULT '{}'::jsonb NOT NULL,
+    spec jsonb NOT NULL,
+    status jsonb DEFAULT '{}'::jsonb NOT NULL,
+    deletion_timestamp timestamp with time zone,
+    finalizers jsonb DEFAULT '[]'::jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    PRIMARY KEY (namespace, name)
+);
+
+CREATE INDEX IF NOT EXISTS namespaces_labels_gin ON namespaces USING gin (labels);
+CREATE INDEX IF NOT EXISTS namespaces_terminating ON namespaces USING btree (deletion_timestamp) WHERE (deletion_timestamp IS NOT NULL);
+CREATE INDEX IF NOT EXISTS namespaces_updated_at_desc ON namespaces USING btree (updated_at DESC);
+
+CREATE OR REPLACE TRIGGER namespaces_set_updated_at
+    BEFORE UPDATE ON namespaces
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE OR REPLACE TRIGGER namespaces_notify_status
+    AFTER INSERT OR UPDATE OR DELETE ON namespaces
+    FOR EACH ROW EXECUTE FUNCTION notify_status_change('namespaces_status');
+CREATE OR REPLACE TRIGGER namespaces_control_plane_event
+    AFTER INSERT OR UPDATE OR DELETE ON namespaces
+    FOR EACH ROW EXECUTE FUNCTION record_control_plane_event('Namespace');
+
+INSERT INTO namespaces (namespace, name, spec)
+VALUES ('default', 'default', '{"description":"The default namespace."}'::jsonb)
+ON CONFLICT (namespace, name) DO NOTHING;
+
+INSERT INTO namespaces (namespace, name, spec)
+SELECT 'default', used.namespace, '{}'::jsonb
+FROM (
+    SELECT namespace FROM agents
+    UNION SELECT namespace FROM mcp_servers
+    UNION SELECT namespace FROM skills
+    UNION SELECT namespace FROM prompts
+    UNION SELECT namespace FROM runtimes
+    UNION SELECT namespace FROM deployments
+    UNION SELECT namespace FROM plugins
+    UNION SELECT namespace FROM models
+) AS used
+ON CONFLICT (namespace, name) DO NOTHING;
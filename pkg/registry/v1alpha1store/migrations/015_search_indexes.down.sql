@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS agents_search_gin;
+DROP INDEX IF EXISTS mcp_servers_search_gin;
+DROP INDEX IF EXISTS skills_search_gin;
+DROP INDEX IF EXISTS prompts_search_gin;
+DROP INDEX IF EXISTS plugins_search_gin;
+DROP INDEX IF EXISTS models_search_gin;
+DROP INDEX IF EXISTS runtimes_search_gin;
+DROP INDEX IF EXISTS deployments_search_gin;
+DROP INDEX IF EXISTS namespaces_search_gin;
@@ -0,0 +1,16 @@
+-- Full-text search: one GIN expression index per built-in kind table over
+-- the weighted search document (name + spec.title, spec.description, label
+-- keys and values, Plugin status.inventory). The indexed expression must
+-- stay identical to v1alpha1store.searchDocumentSQL, which is what the
+-- Store's Search query repeats; TestSearchDocumentMatchesMigration pins
+-- the two together.
+
+CREATE INDEX IF NOT EXISTS agents_search_gin ON agents USING gin ((setweight(to_tsvector('english'::regconfig, translate(name, '/._-', '    ')), 'A') || setweight(to_tsvector('english'::regconfig, coalesce(spec->>'title', '')), 'A') || setweight(to_tsvector('english'::regconfig, coalesce(spec->>'description', '')), 'B') || setweight(jsonb_to_tsvector('english'::regconfig, labels, '["key", "string"]'::jsonb), 'C') || setweight(jsonb_to_tsvector('english'::regconfig, coalesce(status->'inventory', '{}'::jsonb), '["string"]'::jsonb), 'D')));
+CREATE INDEX IF NOT EXISTS mcp_servers_search_gin ON mcp_servers USING gin ((setweight(to_tsvector('english'::regconfig, translate(name, '/._-', '    ')), 'A') || setweight(to_tsvector('english'::regconfig, coalesce(spec->>'title', '')), 'A') || setweight(to_tsvector('english'::regconfig, coalesce(spec->>'description', '')), 'B') || setweight(jsonb_to_tsvector('english'::regconfig, labels, '["key", "string"]'::jsonb), 'C') || setweight(jsonb_to_tsvector('english'::regconfig, coalesce(status->'inventory', '{}'::jsonb), '["string"]'::jsonb), 'D')));
+CREATE INDEX IF NOT EXISTS skills_search_gin ON skills USING gin ((setweight(to_tsvector('english'::regconfig, translate(name, '/._-', '    ')), 'A') || setweight(to_tsvector('english'::regconfig, coalesce(spec->>'title', '')), 'A') || setweight(to_tsvector('english'::regconfig, coalesce(spec->>'description', '')), 'B') || setweight(jsonb_to_tsvector('english'::regconfig, labels, '["key", "string"]'::jsonb), 'C') || setweight(jsonb_to_tsvector('english'::regconfig, coalesce(status->'inventory', '{}'::jsonb), '["string"]'::jsonb), 'D')));
+CREATE INDEX IF NOT EXISTS prompts_search_gin ON prompts USING gin ((setweight(to_tsvector('english'::regconfig, translate(name, '/._-', '    ')), 'A') || setweight(to_tsvector('english'::regconfig, coalesce(spec->>'title', '')), 'A') || setweight(to_tsvector('english'::regconfig, coalesce(spec->>'description', '')), 'B') || setweight(jsonb_to_tsvector('english'::regconfig, labels, '["key", "string"]'::jsonb), 'C') || setweight(jsonb_to_tsvector('english'::regconfig, coalesce(status->'inventory', '{}'::jsonb), '["string"]'::jsonb), 'D')));
+CREATE INDEX IF NOT EXISTS plugins_search_gin ON plugins USING gin ((setweight(to_tsvector('english'::regconfig, translate(name, '/._-', '    ')), 'A') || setweight(to_tsvector('english'::regconfig, coalesce(spec->>'title', '')), 'A') || setweight(to_tsvector('english'::regconfig, coalesce(spec->>'description', '')), 'B') || setweight(jsonb_to_tsvector('english'::regconfig, labels, '["key", "string"]'::jsonb), 'C') || setweight(jsonb_to_tsvector('english'::regconfig, coalesce(status->'inventory', '{}'::jsonb), '["string"]'::jsonb), 'D')));
+CREATE INDEX IF NOT EXISTS models_search_gin ON models USING gin ((setweight(to_tsvector('english'::regconfig, translate(name, '/._-', '    ')), 'A') || setweight(to_tsvector('english'::regconfig, coalesce(spec->>'title', '')), 'A') || setweight(to_tsvector('english'::regconfig, coalesce(spec->>'description', '')), 'B') || setweight(jsonb_to_tsvector('english'::regconfig, labels, '["key", "string"]'::jsonb), 'C') || setweight(jsonb_to_tsvector('english'::regconfig, coalesce(status->'inventory', '{}'::jsonb), '["string"]'::jsonb), 'D')));
+CREATE INDEX IF NOT EXISTS runtimes_search_gin ON runtimes USING gin ((setweight(to_tsvector('english'::regconfig, translate(name, '/._-', '    ')), 'A') || setweight(to_tsvector('english'::regconfig, coalesce(spec->>'title', '')), 'A') || setweight(to_tsvector('english'::regconfig, coalesce(spec->>'description', '')), 'B') || setweight(jsonb_to_tsvector('english'::regconfig, labels, '["key", "string"]'::jsonb), 'C') || setweight(jsonb_to_tsvector('english'::regconfig, coalesce(status->'inventory', '{}'::jsonb), '["string"]'::jsonb), 'D')));
+CREATE INDEX IF NOT EXISTS deployments_search_gin ON deployments USING gin ((setweight(to_tsvector('english'::regconfig, translate(name, '/._-', '    ')), 'A') || setweight(to_tsvector('english'::regconfig, coalesce(spec->>'title', '')), 'A') || setweight(to_tsvector('english'::regconfig, coalesce(spec->>'description', '')), 'B') || setweight(jsonb_to_tsvector('english'::regconfig, labels, '["key", "string"]'::jsonb), 'C') || setweight(jsonb_to_tsvector('english'::regconfig, coalesce(status->'inventory', '{}'::jsonb), '["string"]'::jsonb), 'D')));
+CREATE INDEX IF NOT EXISTS namespaces_search_gin ON namespaces USING gin ((setweight(to_tsvector('english'::regconfig, translate(name, '/._-', '    ')), 'A') || setweight(to_tsvector('english'::regconfig, coalesce(spec->>'title', '')), 'A') || setweight(to_tsvector('english'::regconfig, coalesce(spec->>'description', '')), 'B') || setweight(jsonb_to_tsvector('english'::regconfig, labels, '["key", "string"]'::jsonb), 'C') || setweight(jsonb_to_tsvector('english'::regconfig, coalesce(status->'inventory', '{}'::jsonb), '["string"]'::jsonb), 'D')));
@@ -0,0 +1,94 @@
+DROP TRIGGER IF EXISTS agents_stamp_resource_version ON agents;
+DROP TRIGGER IF EXISTS mcp_servers_stamp_resource_version ON mcp_servers;
+DROP TRIGGER IF EXISTS skills_stamp_resource_version ON skills;
+DROP TRIGGER IF EXISTS prompts_stamp_resource_version ON prompts;
+DROP TRIGGER IF EXISTS plugins_stamp_resource_version ON plugins;
+DROP TRIGGER IF EXISTS models_stamp_resource_version ON models;
+DROP TRIGGER IF EXISTS runtimes_stamp_resource_version ON runtimes;
+DROP TRIGGER IF EXISTS deployments_stamp_resource_version ON deployments;
+DROP TRIGGER IF EXISTS namespaces_stamp_resource_version ON namespaces;
+
+-- Restore the 009 event recorder, which does not read resource_version.
+CREATE OR REPLACE FUNCTION record_control_plane_event()
+RETURNS TRIGGER AS $$
+DECLARE
+    event_kind TEXT := TG_ARGV[0];
+    event_op TEXT;
+    event_revision BIGINT;
+    row_json JSONB;
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        -- Status-only writes already have their own public watch channel. They
+        -- do not usually change desired source state and must not wake
+        -- controllers. Plugin/Skill resolvedSource is the narrow exception:
+        -- harness Deployments consume that material pin.
+        IF NEW.spec = OLD.spec
+           AND NEW.labels = OLD.labels
+           AND NEW.annotations = OLD.annotations
+           AND (
+               NEW.deletion_timestamp = OLD.deletion_timestamp
+               OR (NEW.deletion_timestamp IS NULL AND OLD.deletion_timestamp IS NULL)
+           )
+           AND COALESCE(to_jsonb(NEW)->'finalizers', '[]'::jsonb) =
+               COALESCE(to_jsonb(OLD)->'finalizers', '[]'::jsonb) THEN
+            IF NOT (
+                event_kind IN ('Plugin', 'Skill')
+                AND COALESCE(NEW.status->'resolvedSource', 'null'::jsonb)
+                    IS DISTINCT FROM COALESCE(OLD.status->'resolvedSource', 'null'::jsonb)
+            ) THEN
+                RETURN NEW;
+            END IF;
+        END IF;
+        event_op := 'update';
+        row_json := to_jsonb(NEW);
+    ELSIF TG_OP = 'DELETE' THEN
+        event_op := 'delete';
+        row_json := to_jsonb(OLD);
+    ELSE
+        event_op := 'insert';
+        row_json := to_jsonb(NEW);
+    END IF;
+
+    INSERT INTO control_plane_events (
+        kind,
+        namespace,
+        name,
+        tag,
+        uid,
+        generation,
+        op
+    ) VALUES (
+        event_kind,
+        row_json->>'namespace',
+        row_json->>'name',
+        COALESCE(row_json->>'tag', ''),
+        (row_json->>'uid')::uuid,
+        (row_json->>'generation')::bigint,
+        event_op
+    )
+    RETURNING revision INTO event_revision;
+
+    PERFORM pg_notify(
+        'v1alpha1_control_plane_changed',
+        json_build_object('revision', event_revision)::text
+    );
+
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS stamp_resource_version();
+DROP FUNCTION IF EXISTS control_plane_source_changed(TEXT, JSONB, JSONB);
+
+ALTER TABLE agents DROP COLUMN IF EXISTS resource_version;
+ALTER TABLE mcp_servers DROP COLUMN IF EXISTS resource_version;
+ALTER TABLE skills DROP COLUMN IF EXISTS resource_version;
+ALTER TABLE prompts DROP COLUMN IF EXISTS resource_version;
+ALTER TABLE plugins DROP COLUMN IF EXISTS resource_version;
+ALTER TABLE models DROP COLUMN IF EXISTS resource_version;
+ALTER TABLE runtimes DROP COLUMN IF EXISTS resource_version;
+ALTER TABLE deployments DROP COLUMN IF EXISTS resource_version;
+ALTER TABLE namespaces DROP COLUMN IF EXISTS resource_version;
@@ -0,0 +1,144 @@
+-- Optimistic concurrency: every source row carries resource_version, the
+-- control_plane_events revision of its last source change. Inserts take the
+-- next revision from the column default; source updates take one in a BEFORE
+-- trigger; record_control_plane_event then logs the change under that same
+-- revision, so an object's metadata.resourceVersion matches the watch event
+-- that reported it. Status-only writes keep the version, exactly as they
+-- emit no event. Existing rows are numbered by the ADD COLUMN default.
+--
+-- Extension tables wired to record_control_plane_event without this column
+-- keep working: their events take a fresh revision as before, and the
+-- server detects the missing column at startup and serves them without
+-- resource versions (see v1alpha1store.WithoutResourceVersions).
+
+ALTER TABLE agents ADD COLUMN IF NOT EXISTS resource_version BIGINT NOT NULL DEFAULT nextval('control_plane_events_revision_seq');
+ALTER TABLE mcp_servers ADD COLUMN IF NOT EXISTS resource_version BIGINT NOT NULL DEFAULT nextval('control_plane_events_revision_seq');
+ALTER TABLE skills ADD COLUMN IF NOT EXISTS resource_version BIGINT NOT NULL DEFAULT nextval('control_plane_events_revision_seq');
+ALTER TABLE prompts ADD COLUMN IF NOT EXISTS resource_version BIGINT NOT NULL DEFAULT nextval('control_plane_events_revision_seq');
+ALTER TABLE plugins ADD COLUMN IF NOT EXISTS resource_version BIGINT NOT NULL DEFAULT nextval('control_plane_events_revision_seq');
+ALTER TABLE models ADD COLUMN IF NOT EXISTS resource_version BIGINT NOT NULL DEFAULT nextval('control_plane_events_revision_seq');
+ALTER TABLE runtimes ADD COLUMN IF NOT EXISTS resource_version BIGINT NOT NULL DEFAULT nextval('control_plane_events_revision_seq');
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS resource_version BIGINT NOT NULL DEFAULT nextval('control_plane_events_revision_seq');
+ALTER TABLE namespaces ADD COLUMN IF NOT EXISTS resource_version BIGINT NOT NULL DEFAULT nextval('control_plane_events_revision_seq');
+
+-- control_plane_source_changed reports whether an UPDATE from old_row to
+-- new_row is a source change: anything but a status-only write, plus the
+-- Plugin/Skill resolvedSource pin harness Deployments consume.
+CREATE OR REPLACE FUNCTION control_plane_source_changed(event_kind TEXT, new_row JSONB, old_row JSONB)
+RETURNS BOOLEAN AS $$
+BEGIN
+    IF new_row->'spec' IS NOT DISTINCT FROM old_row->'spec'
+       AND new_row->'labels' IS NOT DISTINCT FROM old_row->'labels'
+       AND new_row->'annotations' IS NOT DISTINCT FROM old_row->'annotations'
+       AND new_row->'deletion_timestamp' IS NOT DISTINCT FROM old_row->'deletion_timestamp'
+       AND COALESCE(new_row->'finalizers', '[]'::jsonb) =
+           COALESCE(old_row->'finalizers', '[]'::jsonb) THEN
+        RETURN event_kind IN ('Plugin', 'Skill')
+            AND COALESCE(new_row->'status'->'resolvedSource', 'null'::jsonb)
+                IS DISTINCT FROM COALESCE(old_row->'status'->'resolvedSource', 'null'::jsonb);
+    END IF;
+    RETURN TRUE;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+-- stamp_resource_version assigns the next revision on a source change and
+-- otherwise pins the stored version, so no statement can set it directly.
+CREATE OR REPLACE FUNCTION stamp_resource_version()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF control_plane_source_changed(TG_ARGV[0], to_jsonb(NEW), to_jsonb(OLD)) THEN
+        NEW.resource_version := nextval('control_plane_events_revision_seq');
+    ELSE
+        NEW.resource_version := OLD.resource_version;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION record_control_plane_event()
+RETURNS TRIGGER AS $$
+DECLARE
+    event_kind TEXT := TG_ARGV[0];
+    event_op TEXT;
+    event_revision BIGINT;
+    row_json JSONB;
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        -- Status-only writes already have their own public watch channel. They
+        -- do not usually change desired source state and must not wake
+        -- controllers.
+        IF NOT control_plane_source_changed(event_kind, to_jsonb(NEW), to_jsonb(OLD)) THEN
+            RETURN NEW;
+        END IF;
+        event_op := 'update';
+        row_json := to_jsonb(NEW);
+        event_revision := (row_json->>'resource_version')::bigint;
+    ELSIF TG_OP = 'DELETE' THEN
+        event_op := 'delete';
+        row_json := to_jsonb(OLD);
+    ELSE
+        event_op := 'insert';
+        row_json := to_jsonb(NEW);
+        event_revision := (row_json->>'resource_version')::bigint;
+    END IF;
+
+    INSERT INTO control_plane_events (
+        revision,
+        kind,
+        namespace,
+        name,
+        tag,
+        uid,
+        generation,
+        op
+    ) VALUES (
+        COALESCE(event_revision, nextval('control_plane_events_revision_seq')),
+        event_kind,
+        row_json->>'namespace',
+        row_json->>'name',
+        COALESCE(row_json->>'tag', ''),
+        (row_json->>'uid')::uuid,
+        (row_json->>'generation')::bigint,
+        event_op
+    )
+    RETURNING revision INTO event_revision;
+
+    PERFORM pg_notify(
+        'v1alpha1_control_plane_changed',
+        json_build_object('revision', event_revision)::text
+    );
+
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER agents_stamp_resource_version
+    BEFORE UPDATE ON agents
+    FOR EACH ROW EXECUTE FUNCTION stamp_resource_version('Agent');
+CREATE OR REPLACE TRIGGER mcp_servers_stamp_resource_version
+    BEFORE UPDATE ON mcp_servers
+    FOR EACH ROW EXECUTE FUNCTION stamp_resource_version('MCPServer');
+CREATE OR REPLACE TRIGGER skills_stamp_resource_version
+    BEFORE UPDATE ON skills
+    FOR EACH ROW EXECUTE FUNCTION stamp_resource_version('Skill');
+CREATE OR REPLACE TRIGGER prompts_stamp_resource_version
+    BEFORE UPDATE ON prompts
+    FOR EACH ROW EXECUTE FUNCTION stamp_resource_version('Prompt');
+CREATE OR REPLACE TRIGGER plugins_stamp_resource_version
+    BEFORE UPDATE ON plugins
+    FOR EACH ROW EXECUTE FUNCTION stamp_resource_version('Plugin');
+CREATE OR REPLACE TRIGGER models_stamp_resource_version
+    BEFORE UPDATE ON models
+    FOR EACH ROW EXECUTE FUNCTION stamp_resource_version('Model');
+CREATE OR REPLACE TRIGGER runtimes_stamp_resource_version
+    BEFORE UPDATE ON runtimes
+    FOR EACH ROW EXECUTE FUNCTION stamp_resource_version('Runtime');
+CREATE OR REPLACE TRIGGER deployments_stamp_resource_version
+    BEFORE UPDATE ON deployments
+    FOR EACH ROW EXECUTE FUNCTION stamp_resource_version('Deployment');
+CREATE OR REPLACE TRIGGER namespaces_stamp_resource_version
+    BEFORE UPDATE ON namespaces
+    FOR EACH ROW EXECUTE FUNCTION stamp_resource_version('Namespace');
@@ -0,0 +1,13 @@
+-- Controller leader election. Each row is a named lease held by one server
+-- replica; the holder renews expires_at while it runs the controllers, and
+-- any replica may take the lease over once it has expired. Expiry is judged
+-- against the database clock so replicas with skewed clocks agree on it.
+
+CREATE TABLE IF NOT EXISTS controller_leases (
+    name text NOT NULL,
+    holder text NOT NULL,
+    acquired_at timestamp with time zone DEFAULT now() NOT NULL,
+    renewed_at timestamp with time zone DEFAULT now() NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    PRIMARY KEY (name)
+);
@@ -0,0 +1,24 @@
+-- Audit log. One row per recorded create, update, delete, status change or
+-- authorization denial, written after the change commits. Rows are
+-- append-only; the retention pruner deletes the oldest ones once they age
+-- past AUDIT_RETENTION.
+
+CREATE TABLE IF NOT EXISTS audit_events (
+    id bigserial NOT NULL,
+    occurred_at timestamp with time zone DEFAULT now() NOT NULL,
+    request_id text DEFAULT '' NOT NULL,
+    principal text DEFAULT '' NOT NULL,
+    verb text NOT NULL,
+    kind text DEFAULT '' NOT NULL,
+    namespace text DEFAULT '' NOT NULL,
+    name text DEFAULT '' NOT NULL,
+    tag text DEFAULT '' NOT NULL,
+    reason text DEFAULT '' NOT NULL,
+    diff jsonb,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS audit_events_occurred_at_idx ON audit_events (occurred_at);
+CREATE INDEX IF NOT EXISTS audit_events_resource_idx ON audit_events (kind, namespace, name, id);
+CREATE INDEX IF NOT EXISTS audit_events_principal_idx ON audit_events (principal, id);
+CREATE INDEX IF NOT EXISTS audit_events_request_id_idx ON audit_events (request_id) WHERE request_id <> '';
@@ -0,0 +1,24 @@
+-- Tag revision history. Every distinct content a tagged artifact's
+-- (namespace, name, tag) has held is kept as an immutable revision, keyed by
+-- its content hash and numbered from 1 in the order it was first applied.
+-- Re-applying earlier content (a rollback) makes that revision current again
+-- rather than adding a new one. Revisions are deleted with their tag.
+--
+-- resource_table is the unqualified table of the tagged kind (e.g. agents),
+-- so one table serves every tagged store. Tags written before this migration
+-- record their current content as revision 1 on their next change.
+
+CREATE TABLE IF NOT EXISTS tag_revisions (
+    resource_table text NOT NULL,
+    namespace character varying(255) NOT NULL,
+    name character varying(255) NOT NULL,
+    tag character varying(255) NOT NULL,
+    revision integer NOT NULL,
+    content_hash character(64) NOT NULL,
+    labels jsonb DEFAULT '{}'::jsonb NOT NULL,
+    annotations jsonb DEFAULT '{}'::jsonb NOT NULL,
+    spec jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    PRIMARY KEY (resource_table, namespace, name, tag, revision),
+    UNIQUE (resource_table, namespace, name, tag, content_hash)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS mcp_sync_state;
@@ -0,0 +1,14 @@
+-- Upstream MCP registry sync checkpoints. One row per configured mirror:
+-- cursor resumes an interrupted pass through the upstream server list, and
+-- updated_since is where the next pass starts, so a steady-state pass only
+-- pages through servers that changed upstream since the last one finished.
+
+CREATE TABLE IF NOT EXISTS mcp_sync_state (
+    upstream text NOT NULL,
+    cursor text DEFAULT ''::text NOT NULL,
+    updated_since timestamp with time zone,
+    pass_started_at timestamp with time zone,
+    last_synced_at timestamp with time zone,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    PRIMARY KEY (upstream)
+);
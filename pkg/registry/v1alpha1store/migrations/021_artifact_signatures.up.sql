@@ -0,0 +1,22 @@
+-- Detached artifact signatures. A signature covers the content digest of a
+-- tagged artifact ("sha256:" + content_hash, see ContentHash), not a tag, so
+-- it follows identical content across tags and re-applies and never vouches
+-- for content it was not made over. One row per (content, signing key);
+-- re-signing with the same key replaces the signature.
+--
+-- resource_table is the unqualified table of the tagged kind (e.g. agents),
+-- as in tag_revisions. Signatures are dropped when every tag of their name
+-- is deleted at once; deleting single tags keeps them, since the content
+-- may still be held by another tag or revision.
+
+CREATE TABLE IF NOT EXISTS artifact_signatures (
+    resource_table text NOT NULL,
+    namespace character varying(255) NOT NULL,
+    name character varying(255) NOT NULL,
+    content_hash character(64) NOT NULL,
+    key_id character(64) NOT NULL,
+    public_key text NOT NULL,
+    signature text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    PRIMARY KEY (resource_table, namespace, name, content_hash, key_id)
+);
@@ -0,0 +1,28 @@
+-- Review decisions for tagged artifacts published into namespaces with an
+-- approval policy. Like signatures, an approval covers the content digest
+-- of a tag ("sha256:" + content_hash, see ContentHash) rather than the tag,
+-- so approved content stays approved across re-applies and changed content
+-- needs a new review. One row per content; tag records the tag the content
+-- was first published under, for reviewers.
+--
+-- Content without a row was published outside any approval policy and is
+-- treated as approved. state is pending, approved or rejected; reviewed_by,
+-- reviewed_at and comment are set once a reviewer decides.
+
+CREATE TABLE IF NOT EXISTS artifact_approvals (
+    resource_table text NOT NULL,
+    namespace character varying(255) NOT NULL,
+    name character varying(255) NOT NULL,
+    content_hash character(64) NOT NULL,
+    tag character varying(255) NOT NULL,
+    state text NOT NULL,
+    requested_by text NOT NULL,
+    requested_at timestamp with time zone DEFAULT now() NOT NULL,
+    reviewed_by text,
+    reviewed_at timestamp with time zone,
+    comment text,
+    PRIMARY KEY (resource_table, namespace, name, content_hash)
+);
+
+CREATE INDEX IF NOT EXISTS artifact_approvals_state_idx
+    ON artifact_approvals (state, namespace);
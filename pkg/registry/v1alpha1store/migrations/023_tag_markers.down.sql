@@ -0,0 +1,12 @@
+ALTER TABLE agents DROP COLUMN IF EXISTS deprecated;
+ALTER TABLE agents DROP COLUMN IF EXISTS yanked;
+ALTER TABLE mcp_servers DROP COLUMN IF EXISTS deprecated;
+ALTER TABLE mcp_servers DROP COLUMN IF EXISTS yanked;
+ALTER TABLE skills DROP COLUMN IF EXISTS deprecated;
+ALTER TABLE skills DROP COLUMN IF EXISTS yanked;
+ALTER TABLE prompts DROP COLUMN IF EXISTS deprecated;
+ALTER TABLE prompts DROP COLUMN IF EXISTS yanked;
+ALTER TABLE plugins DROP COLUMN IF EXISTS deprecated;
+ALTER TABLE plugins DROP COLUMN IF EXISTS yanked;
+ALTER TABLE models DROP COLUMN IF EXISTS deprecated;
+ALTER TABLE models DROP COLUMN IF EXISTS yanked;
@@ -0,0 +1,18 @@
+-- Deprecation and yank markers on individual tags of tagged artifacts
+-- (see v1alpha1.Deprecation and v1alpha1.Yank). Each column holds the
+-- marker as JSON, or NULL when the tag is not marked. Markers are
+-- server-managed like status: setting or clearing one is no source change,
+-- so it keeps resource_version and emits no control-plane event.
+
+ALTER TABLE agents ADD COLUMN IF NOT EXISTS deprecated JSONB;
+ALTER TABLE agents ADD COLUMN IF NOT EXISTS yanked JSONB;
+ALTER TABLE mcp_servers ADD COLUMN IF NOT EXISTS deprecated JSONB;
+ALTER TABLE mcp_servers ADD COLUMN IF NOT EXISTS yanked JSONB;
+ALTER TABLE skills ADD COLUMN IF NOT EXISTS deprecated JSONB;
+ALTER TABLE skills ADD COLUMN IF NOT EXISTS yanked JSONB;
+ALTER TABLE prompts ADD COLUMN IF NOT EXISTS deprecated JSONB;
+ALTER TABLE prompts ADD COLUMN IF NOT EXISTS yanked JSONB;
+ALTER TABLE plugins ADD COLUMN IF NOT EXISTS deprecated JSONB;
+ALTER TABLE plugins ADD COLUMN IF NOT EXISTS yanked JSONB;
+ALTER TABLE models ADD COLUMN IF NOT EXISTS deprecated JSONB;
+ALTER TABLE models ADD COLUMN IF NOT EXISTS yanked JSONB;
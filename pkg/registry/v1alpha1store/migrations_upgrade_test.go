@@ -93,3 +93,31 @@ func TestRemoveLocalRuntimeSeedMigrationPreservesModifiedSeed(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "value", modifiedLocal.Metadata.Annotations["keep"])
 }
+
+func TestNamespacesMigrationDeclaresNamespacesInUse(t *testing.T) {
+	pool, dsn := NewTestPoolWithDSN(t, adminDSN())
+	ctx := context.Background()
+
+	migrator, err := NewOSSMigrator(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = migrator.Close()
+	})
+	require.NoError(t, migrator.Migrate(13))
+
+	_, err = pool.Exec(ctx, `
+		INSERT INTO agents (namespace, name, tag, spec, content_hash)
+		VALUES ('team-a', 'summarizer', '1.0.0', '{}'::jsonb, repeat('0', 64));
+		INSERT INTO runtimes (namespace, name, spec)
+		VALUES ('team-b', 'cluster', '{"type":"Kubernetes"}'::jsonb)
+	`)
+	require.NoError(t, err)
+
+	require.NoError(t, migrator.Up())
+
+	namespaces := NewMutableObjectStore(pool, TestSchema(), "namespaces")
+	for _, name := range []string{v1alpha1.DefaultNamespace, "team-a", "team-b"} {
+		_, err := namespaces.GetLatest(ctx, v1alpha1.DefaultNamespace, name)
+		require.NoError(t, err, name)
+	}
+}
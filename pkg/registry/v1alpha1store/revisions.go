@@ -0,0 +1,269 @@
+package v1alpha1store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// DigestPrefix prefixes a content hash in its public digest form,
+// e.g. "sha256:3f1c…".
+const DigestPrefix = "sha256:"
+
+// ErrRevisionHistoryDisabled reports a revision read on a Store built
+// without WithRevisionHistory.
+var ErrRevisionHistoryDisabled = errors.New("v1alpha1 store: revision history is not enabled")
+
+// ContentDigest renders a ContentHash as its public digest.
+func ContentDigest(hash string) string {
+	return DigestPrefix + hash
+}
+
+// WithRevisionHistory keeps every content a tag has held as an immutable
+// revision in schema's tag_revisions table, so earlier content can be
+// listed, pinned by digest and rolled back to. Ignored on mutable-object
+// stores. NewStores enables it for every built-in tagged kind; extension
+// stores opt in by passing it with the schema that holds tag_revisions.
+func WithRevisionHistory(schema pkgdb.Schema) StoreOption {
+	return func(s *Store) {
+		if s.behavior == TaggedArtifactStore {
+			s.revisions = qualifyTable(s.db, schema, "tag_revisions")
+		}
+	}
+}
+
+// TagRevision is one content a tag has held.
+type TagRevision struct {
+	// Revision numbers a tag's contents from 1 in the order they were
+	// first applied.
+	Revision int
+	// Digest is the ContentDigest of the revision's labels, annotations
+	// and spec.
+	Digest    string
+	CreatedAt time.Time
+	// Current reports whether the tag holds this content now.
+	Current bool
+	// Object is the tag as of this revision: the live row's identity with
+	// the revision's labels, annotations and spec. Only the current
+	// revision carries status and a resourceVersion.
+	Object *v1alpha1.RawObject
+}
+
+// RevisionHistory reports whether the Store records tag revisions.
+func (s *Store) RevisionHistory() bool {
+	return s != nil && s.revisions != ""
+}
+
+// ListRevisions returns every revision of (namespace, name, tag), newest
+// first; a blank tag means "latest". A tag last written before revision
+// history was enabled reports its live content as revision 1. Returns
+// pkgdb.ErrNotFound when the tag does not exist.
+func (s *Store) ListRevisions(ctx context.Context, namespace, name, tag string) ([]TagRevision, error) {
+	if !s.RevisionHistory() {
+		return nil, ErrRevisionHistoryDisabled
+	}
+	if tag == "" {
+		tag = DefaultTag()
+	}
+	live, liveHash, err := s.getWithHash(ctx, namespace, name, tag)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.db.Query(ctx,
+		fmt.Sprintf(`
+			SELECT revision, content_hash, created_at, labels, annotations, spec
+			FROM %s
+			WHERE resource_table=$1 AND namespace=$2 AND name=$3 AND tag=$4
+			ORDER BY revision DESC`, s.revisions),
+		s.table, namespace, name, tag)
+	if err != nil {
+		return nil, fmt.Errorf("list revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var out []TagRevision
+	for rows.Next() {
+		rev, err := scanRevision(rows, live, liveHash)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(out) == 0 {
+		out = append(out, TagRevision{Revision: 1, Digest: ContentDigest(liveHash), CreatedAt: live.Metadata.UpdatedAt, Current: true, Object: live})
+	}
+	return out, nil
+}
+
+// GetRevision returns the revision of (namespace, name, tag) whose
+// ContentDigest is digest; a blank tag means "latest". Returns pkgdb.ErrNotFound when the tag does not
+// exist or never held that content.
+func (s *Store) GetRevision(ctx context.Context, namespace, name, tag, digest string) (*TagRevision, error) {
+	if !s.RevisionHistory() {
+		return nil, ErrRevisionHistoryDisabled
+	}
+	if tag == "" {
+		tag = DefaultTag()
+	}
+	live, liveHash, err := s.getWithHash(ctx, namespace, name, tag)
+	if err != nil {
+		return nil, err
+	}
+	hash := strings.TrimPrefix(digest, DigestPrefix)
+	rev, err := scanRevision(s.db.QueryRow(ctx,
+		fmt.Sprintf(`
+			SELECT revision, content_hash, created_at, labels, annotations, spec
+			FROM %s
+			WHERE resource_table=$1 AND namespace=$2 AND name=$3 AND tag=$4 AND content_hash=$5`, s.revisions),
+		s.table, namespace, name, tag, hash), live, liveHash)
+	switch {
+	case err == nil:
+		return &rev, nil
+	case errors.Is(err, pkgdb.ErrNotFound) && hash == liveHash:
+		// Written before revision history was enabled; see ListRevisions.
+		return &TagRevision{Revision: 1, Digest: ContentDigest(liveHash), CreatedAt: live.Metadata.UpdatedAt, Current: true, Object: live}, nil
+	default:
+		return nil, err
+	}
+}
+
+// getWithHash loads the live tag row and its ContentHash, recomputed from
+// the row rather than read from content_hash so rows migrated with a
+// sentinel hash still match their revisions.
+func (s *Store) getWithHash(ctx context.Context, namespace, name, tag string) (*v1alpha1.RawObject, string, error) {
+	live, err := s.Get(ctx, namespace, name, tag)
+	if err != nil {
+		return nil, "", err
+	}
+	hash, err := ContentHash(&live.Metadata, live.Spec)
+	if err != nil {
+		return nil, "", fmt.Errorf("v1alpha1 store: content hash: %w", err)
+	}
+	return live, hash, nil
+}
+
+// scanRevision reads one tag_revisions row into a TagRevision built on
+// the live row's identity.
+func scanRevision(row rowScanner, live *v1alpha1.RawObject, liveHash string) (TagRevision, error) {
+	var (
+		rev                         TagRevision
+		hash                        string
+		labelsJSON, annotationsJSON []byte
+		specJSON                    []byte
+		labels, annotations         map[string]string
+	)
+	if err := row.Scan(&rev.Revision, &hash, &rev.CreatedAt, &labelsJSON, &annotationsJSON, &specJSON); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return TagRevision{}, pkgdb.ErrNotFound
+		}
+		return TagRevision{}, fmt.Errorf("scan revision: %w", err)
+	}
+	if err := json.Unmarshal(labelsJSON, &labels); err != nil {
+		return TagRevision{}, fmt.Errorf("decode revision labels: %w", err)
+	}
+	if err := json.Unmarshal(annotationsJSON, &annotations); err != nil {
+		return TagRevision{}, fmt.Errorf("decode revision annotations: %w", err)
+	}
+	rev.Digest = ContentDigest(hash)
+	rev.Current = hash == liveHash
+	if rev.Current {
+		rev.Object = live
+		return rev, nil
+	}
+	obj := *live
+	obj.Metadata.Labels = labels
+	obj.Metadata.Annotations = annotations
+	obj.Metadata.ResourceVersion = ""
+	obj.Spec = json.RawMessage(specJSON)
+	obj.Status = nil
+	rev.Object = &obj
+	return rev, nil
+}
+
+// recordRevision appends content to the tag's revision history unless the
+// tag has held it before. Runs inside the upsert transaction, under its
+// advisory lock, so revision numbers are assigned without gaps or races.
+func (s *Store) recordRevision(ctx context.Context, tx DB, namespace, name, tag, hash string, labels, annotations, spec []byte) error {
+	if s.revisions == "" {
+		return nil
+	}
+	if _, err := tx.Exec(ctx,
+		fmt.Sprintf(`
+			INSERT INTO %[1]s (resource_table, namespace, name, tag, revision, content_hash, labels, annotations, spec)
+			SELECT $1::text, $2::varchar, $3::varchar, $4::varchar, COALESCE(MAX(revision), 0) + 1, $5::char(64), $6::jsonb, $7::jsonb, $8::jsonb
+			FROM %[1]s
+			WHERE resource_table=$1 AND namespace=$2 AND name=$3 AND tag=$4
+			ON CONFLICT (resource_table, namespace, name, tag, content_hash) DO NOTHING`, s.revisions),
+		s.table, namespace, name, tag, hash, labels, annotations, spec); err != nil {
+		return fmt.Errorf("record revision: %w", err)
+	}
+	return nil
+}
+
+// checkRetiredContent refuses to re-create a tag whose revision history
+// outlived it (see DeleteRetainingHistory) with content it never held.
+func (s *Store) checkRetiredContent(ctx context.Context, tx DB, namespace, name, tag, hash string) error {
+	if s.revisions == "" {
+		return nil
+	}
+	var revisions, matching int64
+	if err := tx.QueryRow(ctx,
+		fmt.Sprintf(`
+			SELECT COUNT(*), COALESCE(SUM(CASE WHEN content_hash=$5 THEN 1 ELSE 0 END), 0)
+			FROM %s
+			WHERE resource_table=$1 AND namespace=$2 AND name=$3 AND tag=$4`, s.revisions),
+		s.table, namespace, name, tag, hash).Scan(&revisions, &matching); err != nil {
+		return fmt.Errorf("load retired revisions: %w", err)
+	}
+	if revisions > 0 && matching == 0 {
+		return fmt.Errorf("%w: %s/%s@%s was deleted after holding different content; publish the change under a new tag",
+			ErrTagImmutable, namespace, name, tag)
+	}
+	return nil
+}
+
+// deleteRevisions drops the revision history of a deleted tag, or of every
+// tag of (namespace, name) when tag is empty.
+func (s *Store) deleteRevisions(ctx context.Context, tx DB, namespace, name, tag string) error {
+	if s.revisions == "" {
+		return nil
+	}
+	query := fmt.Sprintf(`DELETE FROM %s WHERE resource_table=$1 AND namespace=$2 AND name=$3`, s.revisions)
+	args := []any{s.table, namespace, name}
+	if tag != "" {
+		query += ` AND tag=$4`
+		args = append(args, tag)
+	}
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("delete revisions: %w", err)
+	}
+	return nil
+}
+
+// storedContentHash recomputes ContentHash from a row's stored labels,
+// annotations and spec.
+func storedContentHash(labelsJSON, annotationsJSON, specJSON []byte) (string, error) {
+	var meta v1alpha1.ObjectMeta
+	if len(labelsJSON) > 0 {
+		if err := json.Unmarshal(labelsJSON, &meta.Labels); err != nil {
+			return "", err
+		}
+	}
+	if len(annotationsJSON) > 0 {
+		if err := json.Unmarshal(annotationsJSON, &meta.Annotations); err != nil {
+			return "", err
+		}
+	}
+	return ContentHash(&meta, specJSON)
+}
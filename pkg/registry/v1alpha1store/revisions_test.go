@@ -0,0 +1,152 @@
+//go:build integration
+
+package v1alpha1store_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+func setupRevisionStore(t *testing.T) *v1alpha1store.Store {
+	t.Helper()
+	db := v1alpha1store.NewTestDB(t)
+	return v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents", v1alpha1store.WithRevisionHistory(v1alpha1store.TestSchema()))
+}
+
+func revisionTitles(t *testing.T, revisions []v1alpha1store.TagRevision) []string {
+	t.Helper()
+	titles := make([]string, 0, len(revisions))
+	for _, rev := range revisions {
+		agent, err := v1alpha1.EnvelopeFromRaw(func() *v1alpha1.Agent { return &v1alpha1.Agent{} }, rev.Object, v1alpha1.KindAgent)
+		require.NoError(t, err)
+		titles = append(titles, agent.Spec.Title)
+	}
+	return titles
+}
+
+func TestRevisions_RecordEachContentOnce(t *testing.T) {
+	ctx := context.Background()
+	store := setupRevisionStore(t)
+
+	for _, title := range []string{"one", "two", "two", "three"} {
+		_, err := store.Upsert(ctx, taggedAgentObj("alice", "stable", title, nil))
+		require.NoError(t, err)
+	}
+	revisions, err := store.ListRevisions(ctx, "default", "alice", "stable")
+	require.NoError(t, err)
+	require.Equal(t, []string{"three", "two", "one"}, revisionTitles(t, revisions))
+	require.Equal(t, []int{3, 2, 1}, []int{revisions[0].Revision, revisions[1].Revision, revisions[2].Revision})
+	require.True(t, revisions[0].Current)
+	require.NotEmpty(t, revisions[0].Object.Metadata.ResourceVersion)
+	require.False(t, revisions[2].Current)
+	require.Empty(t, revisions[2].Object.Metadata.ResourceVersion)
+
+	// Rolling back re-applies revision 1's content: it becomes current
+	// again without adding a revision.
+	_, err = store.Upsert(ctx, taggedAgentObj("alice", "stable", "one", nil))
+	require.NoError(t, err)
+	revisions, err = store.ListRevisions(ctx, "default", "alice", "stable")
+	require.NoError(t, err)
+	require.Len(t, revisions, 3)
+	require.True(t, revisions[2].Current)
+	require.False(t, revisions[0].Current)
+
+	rev, err := store.GetRevision(ctx, "default", "alice", "stable", revisions[1].Digest)
+	require.NoError(t, err)
+	require.Equal(t, 2, rev.Revision)
+	require.Equal(t, []string{"two"}, revisionTitles(t, []v1alpha1store.TagRevision{*rev}))
+
+	_, err = store.GetRevision(ctx, "default", "alice", "stable", v1alpha1store.ContentDigest("0000"))
+	require.ErrorIs(t, err, pkgdb.ErrNotFound)
+}
+
+func TestRevisions_BaselineForTagsWrittenWithoutHistory(t *testing.T) {
+	ctx := context.Background()
+	db := v1alpha1store.NewTestDB(t)
+	plain := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents", v1alpha1store.WithRevisionHistory(v1alpha1store.TestSchema()))
+
+	_, err := plain.Upsert(ctx, agentObj("alice", "before", map[string]string{"team": "a"}))
+	require.NoError(t, err)
+	revisions, err := store.ListRevisions(ctx, "default", "alice", "")
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	require.True(t, revisions[0].Current)
+	baseline := revisions[0].Digest
+
+	_, err = store.Upsert(ctx, agentObj("alice", "after", nil))
+	require.NoError(t, err)
+	revisions, err = store.ListRevisions(ctx, "default", "alice", "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"after", "before"}, revisionTitles(t, revisions))
+	require.Equal(t, baseline, revisions[1].Digest)
+	require.Equal(t, map[string]string{"team": "a"}, revisions[1].Object.Metadata.Labels)
+
+	_, err = plain.ListRevisions(ctx, "default", "alice", "")
+	require.ErrorIs(t, err, v1alpha1store.ErrRevisionHistoryDisabled)
+}
+
+func TestRevisions_RetainedHistoryKeepsImmutableTagsClosed(t *testing.T) {
+	ctx := context.Background()
+	store := setupRevisionStore(t)
+	immutable := v1alpha1store.UpsertOpts{ImmutableTag: true}
+
+	_, err := store.Upsert(ctx, taggedAgentObj("alice", "1.0.0", "one", nil), immutable)
+	require.NoError(t, err)
+	require.NoError(t, store.DeleteRetainingHistory(ctx, "default", "alice", "1.0.0"))
+	_, err = store.Get(ctx, "default", "alice", "1.0.0")
+	require.ErrorIs(t, err, pkgdb.ErrNotFound)
+
+	// Different content under the deleted protected tag is refused.
+	_, err = store.Upsert(ctx, taggedAgentObj("alice", "1.0.0", "two", nil), immutable)
+	require.ErrorIs(t, err, v1alpha1store.ErrTagImmutable)
+
+	// The content it held may come back, and the admin override still
+	// replaces it.
+	res, err := store.Upsert(ctx, taggedAgentObj("alice", "1.0.0", "one", nil), immutable)
+	require.NoError(t, err)
+	require.Equal(t, v1alpha1store.UpsertCreated, res.Outcome)
+	require.NoError(t, store.DeleteRetainingHistory(ctx, "default", "alice", "1.0.0"))
+	_, err = store.Upsert(ctx, taggedAgentObj("alice", "1.0.0", "two", nil), v1alpha1store.UpsertOpts{ImmutableTag: true, OverwriteImmutableTag: true})
+	require.NoError(t, err)
+}
+
+func TestRevisions_DeletedWithTheirTag(t *testing.T) {
+	ctx := context.Background()
+	store := setupRevisionStore(t)
+
+	for _, title := range []string{"one", "two"} {
+		_, err := store.Upsert(ctx, taggedAgentObj("alice", "stable", title, nil))
+		require.NoError(t, err)
+		_, err = store.Upsert(ctx, taggedAgentObj("alice", "canary", title, nil))
+		require.NoError(t, err)
+	}
+	require.NoError(t, store.Delete(ctx, "default", "alice", "stable"))
+	_, err := store.ListRevisions(ctx, "default", "alice", "stable")
+	require.ErrorIs(t, err, pkgdb.ErrNotFound)
+
+	// A recreated tag starts a fresh history.
+	_, err = store.Upsert(ctx, taggedAgentObj("alice", "stable", "three", nil))
+	require.NoError(t, err)
+	revisions, err := store.ListRevisions(ctx, "default", "alice", "stable")
+	require.NoError(t, err)
+	require.Equal(t, []string{"three"}, revisionTitles(t, revisions))
+	require.Equal(t, 1, revisions[0].Revision)
+
+	revisions, err = store.ListRevisions(ctx, "default", "alice", "canary")
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+
+	require.NoError(t, store.DeleteAllTags(ctx, "default", "alice"))
+	_, err = store.Upsert(ctx, taggedAgentObj("alice", "canary", "four", nil))
+	require.NoError(t, err)
+	revisions, err = store.ListRevisions(ctx, "default", "alice", "canary")
+	require.NoError(t, err)
+	require.Equal(t, []string{"four"}, revisionTitles(t, revisions))
+}
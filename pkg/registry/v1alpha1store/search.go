@@ -0,0 +1,236 @@
+package v1alpha1store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// searchDocumentSQL is the weighted full-text document every kind is
+// searched over: name and spec.title rank highest, then spec.description,
+// then label keys and values, then the Plugin inventory (skills, commands,
+// sub-agents, hooks, MCP servers) the controller records in status. Kinds
+// without a title, description or inventory contribute empty vectors.
+//
+// The expression must stay byte-identical to the one indexed by
+// migrations/015_search_indexes.up.sql; Postgres only uses an expression
+// index when the query repeats the indexed expression exactly.
+// TestSearchDocumentMatchesMigration pins the two together.
+const searchDocumentSQL = `(` +
+	`setweight(to_tsvector('english'::regconfig, translate(name, '/._-', '    ')), 'A') || ` +
+	`setweight(to_tsvector('english'::regconfig, coalesce(spec->>'title', '')), 'A') || ` +
+	`setweight(to_tsvector('english'::regconfig, coalesce(spec->>'description', '')), 'B') || ` +
+	`setweight(jsonb_to_tsvector('english'::regconfig, labels, '["key", "string"]'::jsonb), 'C') || ` +
+	`setweight(jsonb_to_tsvector('english'::regconfig, coalesce(status->'inventory', '{}'::jsonb), '["string"]'::jsonb), 'D')` +
+	`)`
+
+// searchHeadlineSQL is the text ts_headline highlights: the human-facing
+// title and description, falling back to the name for kinds without them.
+const searchHeadlineSQL = `coalesce(nullif(concat_ws(' — ', nullif(spec->>'title', ''), nullif(spec->>'description', '')), ''), name)`
+
+// Search highlight delimiters. Callers rendering for a terminal strip or
+// restyle them; HTML callers can pass them through.
+const (
+	SearchHighlightStart = "<mark>"
+	SearchHighlightStop  = "</mark>"
+)
+
+// SearchOpts controls a full-text Search over one Store.
+type SearchOpts struct {
+	// Query is a web-search style query: bare words are AND-ed,
+	// "quoted phrases" match in order, `or` separates alternatives and a
+	// leading `-` excludes a word. Required.
+	Query string
+	// Namespace narrows results to a specific namespace. Empty means "across
+	// all namespaces".
+	Namespace string
+	// LabelSelector and FieldSelector follow the ListOpts fields of the
+	// same name.
+	LabelSelector v1alpha1.LabelSelector
+	FieldSelector v1alpha1.FieldSelector
+	// LatestOnly restricts tagged-artifact stores to the literal "latest"
+	// tag so each artifact matches at most once. Ignored on mutable-object
+	// stores.
+	LatestOnly bool
+	// Limit caps the number of hits returned. Zero means default (50).
+	// Total and LabelFacets always cover every match regardless of Limit.
+	Limit int
+	// ExtraWhere / ExtraArgs follow the ListOpts.ExtraWhere contract: a
+	// parameterized predicate numbered from $1 that the Store rebases.
+	ExtraWhere string
+	ExtraArgs  []any
+}
+
+// SearchHit is one ranked match.
+type SearchHit struct {
+	Object *v1alpha1.RawObject
+	// Rank is the ts_rank_cd score of the match. Scores are comparable
+	// across Stores because every kind is searched over the same weighted
+	// document shape.
+	Rank float64
+	// Highlight is an excerpt of the title/description with matched terms
+	// wrapped in SearchHighlightStart / SearchHighlightStop.
+	Highlight string
+}
+
+// SearchResult is the outcome of a Search over one Store.
+type SearchResult struct {
+	// Hits are ordered by descending Rank, then namespace/name/tag.
+	Hits []SearchHit
+	// Total is the number of matching rows, independent of Limit.
+	Total int
+	// LabelFacets counts matching rows per label key and value.
+	LabelFacets map[string]map[string]int
+}
+
+// Search runs a ranked full-text query over the Store's table. Terminating
+// rows never match. An empty or stop-word-only query matches nothing.
+func (s *Store) Search(ctx context.Context, opts SearchOpts) (SearchResult, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	// The SQLite backend scores rows with the search_rank and
+	// search_headline functions it registers (see sqlite_search.go).
+	var (
+		match    = searchDocumentSQL + " @@ websearch_to_tsquery('english'::regconfig, $1)"
+		rank     = "ts_rank_cd(" + searchDocumentSQL + ", websearch_to_tsquery('english'::regconfig, $1))::float8"
+		headline = fmt.Sprintf(`ts_headline('english'::regconfig, %s, websearch_to_tsquery('english'::regconfig, $1),
+		                   'StartSel=%s, StopSel=%s, MaxFragments=2, MaxWords=30, MinWords=8')`,
+			searchHeadlineSQL, SearchHighlightStart, SearchHighlightStop)
+		labelPairs = "jsonb_each_text(labels) AS l(key, value)"
+	)
+	if isSQLite(s.db) {
+		rank = "search_rank(name, spec, labels, status, $1)"
+		match = rank + " > 0"
+		headline = "search_headline(name, spec, $1)"
+		labelPairs = "json_each(labels) AS l"
+	}
+
+	args := []any{opts.Query}
+	where := []string{
+		"deletion_timestamp IS NULL",
+		match,
+	}
+	if opts.Namespace != "" {
+		args = append(args, opts.Namespace)
+		where = append(where, fmt.Sprintf("namespace = $%d", len(args)))
+	}
+	if opts.LatestOnly && s.behavior == TaggedArtifactStore {
+		args = append(args, DefaultTag())
+		where = append(where, fmt.Sprintf("tag = $%d", len(args)))
+	}
+	selectors, args, err := s.selectorPredicates(opts.LabelSelector, opts.FieldSelector, args)
+	if err != nil {
+		return SearchResult{}, err
+	}
+	where = append(where, selectors...)
+	if opts.ExtraWhere != "" || len(opts.ExtraArgs) > 0 {
+		placeholders := countDistinctPlaceholders(opts.ExtraWhere)
+		if placeholders != len(opts.ExtraArgs) {
+			return SearchResult{}, fmt.Errorf("%w: fragment references %d distinct placeholder(s) but %d arg(s) supplied",
+				ErrInvalidExtraWhere, placeholders, len(opts.ExtraArgs))
+		}
+		args = append(args, opts.ExtraArgs...)
+		if opts.ExtraWhere != "" {
+			where = append(where, rebaseSQLPlaceholders(opts.ExtraWhere, len(args)-len(opts.ExtraArgs)))
+		}
+	}
+	whereSQL := strings.Join(where, " AND ")
+
+	out := SearchResult{LabelFacets: map[string]map[string]int{}}
+	if err := s.db.QueryRow(ctx,
+		fmt.Sprintf(`SELECT count(*) FROM %s WHERE %s`, s.qualified, whereSQL),
+		args...,
+	).Scan(&out.Total); err != nil {
+		return SearchResult{}, fmt.Errorf("search count: %w", err)
+	}
+	if out.Total == 0 {
+		return out, nil
+	}
+
+	facetRows, err := s.db.Query(ctx, fmt.Sprintf(`
+		SELECT l.key, l.value, count(*)
+		FROM %s, %s
+		WHERE %s
+		GROUP BY l.key, l.value`, s.qualified, labelPairs, whereSQL), args...)
+	if err != nil {
+		return SearchResult{}, fmt.Errorf("search facets: %w", err)
+	}
+	for facetRows.Next() {
+		var (
+			key, value string
+			count      int
+		)
+		if err := facetRows.Scan(&key, &value, &count); err != nil {
+			facetRows.Close()
+			return SearchResult{}, fmt.Errorf("search facets: %w", err)
+		}
+		if out.LabelFacets[key] == nil {
+			out.LabelFacets[key] = map[string]int{}
+		}
+		out.LabelFacets[key][value] = count
+	}
+	facetRows.Close()
+	if err := facetRows.Err(); err != nil {
+		return SearchResult{}, fmt.Errorf("search facets: %w", err)
+	}
+
+	// Rank and cut in the inner query so ts_headline, which re-parses the
+	// source text, only runs over the rows actually returned.
+	args = append(args, limit)
+	query := fmt.Sprintf(`
+		SELECT %s, rank,
+		       %s
+		FROM (
+			SELECT *, %s AS rank
+			FROM %s
+			WHERE %s
+			ORDER BY rank DESC, %s
+			LIMIT $%d
+		) ranked
+		ORDER BY rank DESC, %s`,
+		s.selectColumns(), headline, rank, s.qualified, whereSQL, s.listOrderBy(), len(args), s.listOrderBy())
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return SearchResult{}, fmt.Errorf("search: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		hit, err := scanSearchRow(rows, s.behavior == TaggedArtifactStore)
+		if err != nil {
+			return SearchResult{}, err
+		}
+		out.Hits = append(out.Hits, hit)
+	}
+	if err := rows.Err(); err != nil {
+		return SearchResult{}, fmt.Errorf("search: %w", err)
+	}
+	return out, nil
+}
+
+// scanSearchRow reads the scanRow column layout followed by the rank and
+// headline columns Search appends.
+func scanSearchRow(row rowScanner, tagged bool) (SearchHit, error) {
+	var hit SearchHit
+	obj, err := scanRow(trailingScanner{row: row, extra: []any{&hit.Rank, &hit.Highlight}}, tagged)
+	if err != nil {
+		return SearchHit{}, err
+	}
+	hit.Object = obj
+	return hit, nil
+}
+
+// trailingScanner appends extra destinations to every Scan so scanRow can
+// read rows that carry columns beyond the standard layout.
+type trailingScanner struct {
+	row   rowScanner
+	extra []any
+}
+
+func (t trailingScanner) Scan(dest ...any) error {
+	return t.row.Scan(append(dest, t.extra...)...)
+}
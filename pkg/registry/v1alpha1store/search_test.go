@@ -0,0 +1,31 @@
+package v1alpha1store
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// TestSearchDocumentMatchesMigration guards the expression-index contract:
+// every built-in kind table carries a GIN index over exactly the expression
+// Store.Search queries, or Postgres silently falls back to a sequential scan.
+func TestSearchDocumentMatchesMigration(t *testing.T) {
+	raw, err := fs.ReadFile(MigrationFiles, path.Join(MigrationsDir, "015_search_indexes.up.sql"))
+	if err != nil {
+		t.Fatalf("read migration: %v", err)
+	}
+	migration := string(raw)
+	for _, descriptor := range v1alpha1.KindDescriptors() {
+		if _, ok := builtInKinds[descriptor.Kind]; !ok {
+			continue
+		}
+		table := storeTableNameFromDescriptor(descriptor)
+		want := "ON " + table + " USING gin (" + searchDocumentSQL + ");"
+		if !strings.Contains(migration, want) {
+			t.Errorf("015_search_indexes.up.sql does not index %s (kind %s) with searchDocumentSQL", table, descriptor.Kind)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package v1alpha1store
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// selectorPredicates compiles a label and a field selector into WHERE
+// predicates over the Store's table. Every key, path and value is bound
+// as a parameter appended to args; only operators and column names come
+// from this function. Errors wrap v1alpha1.ErrInvalidSelector.
+func (s *Store) selectorPredicates(labels v1alpha1.LabelSelector, fields v1alpha1.FieldSelector, args []any) ([]string, []any, error) {
+	var where []string
+	bind := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	// The SQLite backend has no jsonb operators; json_path_text stands in
+	// for #>> there, taking the path as a JSON array of keys.
+	sqlite := isSQLite(s.db)
+	labelValue := func(key string) string {
+		if sqlite {
+			return fmt.Sprintf("json_path_text(labels, %s)", bind([]string{key}))
+		}
+		return fmt.Sprintf("(labels->>%s)", bind(key))
+	}
+	distinct := "IS DISTINCT FROM"
+	if sqlite {
+		distinct = "IS NOT"
+	}
+
+	for _, req := range labels {
+		switch req.Operator {
+		case v1alpha1.SelectorEquals:
+			if sqlite {
+				where = append(where, fmt.Sprintf("%s = %s", labelValue(req.Key), bind(req.Values[0])))
+				continue
+			}
+			// Containment keeps equality on the labels GIN index.
+			doc, err := json.Marshal(map[string]string{req.Key: req.Values[0]})
+			if err != nil {
+				return nil, nil, fmt.Errorf("marshal labels: %w", err)
+			}
+			where = append(where, fmt.Sprintf("labels @> %s::jsonb", bind(doc)))
+		case v1alpha1.SelectorNotEquals:
+			where = append(where, fmt.Sprintf("%s %s %s", labelValue(req.Key), distinct, bind(req.Values[0])))
+		case v1alpha1.SelectorIn, v1alpha1.SelectorNotIn:
+			var in string
+			if sqlite {
+				// The backend binds a string slice as a JSON array.
+				in = fmt.Sprintf("%s IN (SELECT value FROM json_each(%s))", labelValue(req.Key), bind(req.Values))
+			} else {
+				in = fmt.Sprintf("%s = ANY(%s::text[])", labelValue(req.Key), bind(req.Values))
+			}
+			if req.Operator == v1alpha1.SelectorNotIn {
+				in = fmt.Sprintf("NOT coalesce(%s, false)", in)
+			}
+			where = append(where, in)
+		case v1alpha1.SelectorExists:
+			if sqlite {
+				where = append(where, fmt.Sprintf("%s IS NOT NULL", labelValue(req.Key)))
+				continue
+			}
+			where = append(where, fmt.Sprintf("labels ? %s", bind(req.Key)))
+		case v1alpha1.SelectorDoesNotExist:
+			if sqlite {
+				where = append(where, fmt.Sprintf("%s IS NULL", labelValue(req.Key)))
+				continue
+			}
+			where = append(where, fmt.Sprintf("NOT (labels ? %s)", bind(req.Key)))
+		default:
+			return nil, nil, fmt.Errorf("%w: unsupported label operator %q", v1alpha1.ErrInvalidSelector, req.Operator)
+		}
+	}
+
+	for _, req := range fields {
+		if req.Operator != v1alpha1.SelectorEquals && req.Operator != v1alpha1.SelectorNotEquals {
+			return nil, nil, fmt.Errorf("%w: unsupported field operator %q", v1alpha1.ErrInvalidSelector, req.Operator)
+		}
+		if len(req.Path) < 2 {
+			return nil, nil, fmt.Errorf("%w: invalid field path %q", v1alpha1.ErrInvalidSelector, req.Field)
+		}
+		root := req.Path[0].Name
+		if root == "metadata" {
+			// The column name reaches the query text, so it is checked
+			// here as well as by the parser.
+			column := req.Path[1].Name
+			switch {
+			case column == "tag" && s.behavior != TaggedArtifactStore:
+				return nil, nil, fmt.Errorf("%w: %s: kind has no tags", v1alpha1.ErrInvalidSelector, req.Field)
+			case column != "name" && column != "namespace" && column != "tag":
+				return nil, nil, fmt.Errorf("%w: %s is not selectable", v1alpha1.ErrInvalidSelector, req.Field)
+			}
+			op := "="
+			if req.Operator == v1alpha1.SelectorNotEquals {
+				op = "<>"
+			}
+			where = append(where, fmt.Sprintf("%s %s %s", column, op, bind(req.Value)))
+			continue
+		}
+		if root != "spec" && root != "status" {
+			return nil, nil, fmt.Errorf("%w: field path %q must start with spec, status or metadata", v1alpha1.ErrInvalidSelector, req.Field)
+		}
+
+		keyed := -1
+		for i, seg := range req.Path {
+			if seg.Key != "" {
+				keyed = i
+				break
+			}
+		}
+		if keyed < 0 {
+			path := make([]string, 0, len(req.Path)-1)
+			for _, seg := range req.Path[1:] {
+				path = append(path, seg.Name)
+			}
+			op := "="
+			if req.Operator == v1alpha1.SelectorNotEquals {
+				op = distinct
+			}
+			value := fmt.Sprintf("(%s #>> %s::text[])", root, bind(path))
+			if sqlite {
+				value = fmt.Sprintf("json_path_text(%s, $%d)", root, len(args))
+			}
+			where = append(where, fmt.Sprintf("%s %s %s", value, op, bind(req.Value)))
+			continue
+		}
+
+		// A keyed segment selects the element of an array whose "type"
+		// is the key, as status.conditions[Ready] selects the Ready
+		// condition; the rest of the path, or "status" when there is
+		// none, is compared within it.
+		arrayPath := make([]string, 0, keyed)
+		for _, seg := range req.Path[1 : keyed+1] {
+			arrayPath = append(arrayPath, seg.Name)
+		}
+		elemPath := []string{"status"}
+		if rest := req.Path[keyed+1:]; len(rest) > 0 {
+			elemPath = elemPath[:0]
+			for _, seg := range rest {
+				elemPath = append(elemPath, seg.Name)
+			}
+		}
+		var predicate string
+		if sqlite {
+			predicate = fmt.Sprintf(
+				"EXISTS (SELECT 1 FROM json_each(json_path_array(%s, %s)) AS elem"+
+					` WHERE json_path_text(elem.value, '["type"]') = %s AND json_path_text(elem.value, %s) = %s)`,
+				root, bind(arrayPath), bind(req.Path[keyed].Key), bind(elemPath), bind(req.Value))
+		} else {
+			array := fmt.Sprintf("(%s #> %s::text[])", root, bind(arrayPath))
+			predicate = fmt.Sprintf(
+				"EXISTS (SELECT 1 FROM jsonb_array_elements(CASE WHEN jsonb_typeof(%[1]s) = 'array' THEN %[1]s ELSE '[]'::jsonb END) AS elem"+
+					" WHERE elem->>'type' = %[2]s AND (elem #>> %[3]s::text[]) = %[4]s)",
+				array, bind(req.Path[keyed].Key), bind(elemPath), bind(req.Value))
+		}
+		if req.Operator == v1alpha1.SelectorNotEquals {
+			predicate = "NOT " + predicate
+		}
+		where = append(where, predicate)
+	}
+	return where, args, nil
+}
@@ -0,0 +1,82 @@
+//go:build integration
+
+package v1alpha1store
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+func listNames(t *testing.T, store *Store, opts ListOpts) []string {
+	t.Helper()
+	rows, _, err := store.List(context.Background(), opts)
+	require.NoError(t, err)
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		names = append(names, row.Metadata.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestStore_ListSelectors(t *testing.T) {
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
+	ctx := context.Background()
+
+	upsertAgent(t, store, "prod", v1alpha1.AgentSpec{Title: "Prod", Description: "npm"}, map[string]string{"env": "prod", "tier": "core"})
+	upsertAgent(t, store, "staging", v1alpha1.AgentSpec{Title: "Staging"}, map[string]string{"env": "staging", "tier": "experimental"})
+	upsertAgent(t, store, "old", v1alpha1.AgentSpec{Title: "Old"}, map[string]string{"env": "prod", "deprecated": "true"})
+	upsertAgent(t, store, "bare", v1alpha1.AgentSpec{Title: "Bare"}, nil)
+	require.NoError(t, store.PatchStatus(ctx, testNS, "prod", DefaultTag(), v1alpha1.StatusPatcher(func(s *v1alpha1.Status) {
+		s.SetCondition(v1alpha1.Condition{Type: "Ready", Status: v1alpha1.ConditionTrue, Reason: "Converged"})
+	})))
+	require.NoError(t, store.PatchStatus(ctx, testNS, "staging", DefaultTag(), v1alpha1.StatusPatcher(func(s *v1alpha1.Status) {
+		s.SetCondition(v1alpha1.Condition{Type: "Ready", Status: v1alpha1.ConditionFalse, Reason: "Pending"})
+	})))
+
+	labels := func(s string) v1alpha1.LabelSelector {
+		sel, err := v1alpha1.ParseLabelSelector(s)
+		require.NoError(t, err)
+		return sel
+	}
+	fields := func(s string) v1alpha1.FieldSelector {
+		sel, err := v1alpha1.ParseFieldSelector(s)
+		require.NoError(t, err)
+		return sel
+	}
+
+	require.Equal(t, []string{"old", "prod", "staging"}, listNames(t, store, ListOpts{LabelSelector: labels("env in (prod,staging)")}))
+	require.Equal(t, []string{"bare", "old"}, listNames(t, store, ListOpts{LabelSelector: labels("env notin (staging),tier notin (core)")}))
+	require.Equal(t, []string{"bare", "old", "prod"}, listNames(t, store, ListOpts{LabelSelector: labels("tier!=experimental")}),
+		"!= matches objects without the key")
+	require.Equal(t, []string{"prod", "staging"}, listNames(t, store, ListOpts{LabelSelector: labels("env,!deprecated")}))
+	require.Equal(t, []string{"old", "prod"}, listNames(t, store, ListOpts{LabelSelector: labels("env=prod")}))
+
+	require.Equal(t, []string{"prod"}, listNames(t, store, ListOpts{FieldSelector: fields("spec.description=npm")}))
+	require.Equal(t, []string{"bare", "old", "staging"}, listNames(t, store, ListOpts{FieldSelector: fields("spec.description!=npm")}))
+	require.Equal(t, []string{"prod"}, listNames(t, store, ListOpts{FieldSelector: fields("status.conditions[Ready]=True")}))
+	require.Equal(t, []string{"staging"}, listNames(t, store, ListOpts{FieldSelector: fields("status.conditions[Ready].reason=Pending")}))
+	require.Equal(t, []string{"bare", "old", "staging"}, listNames(t, store, ListOpts{FieldSelector: fields("status.conditions[Ready]!=True")}))
+	require.Equal(t, []string{"staging"}, listNames(t, store, ListOpts{
+		LabelSelector: labels("env"),
+		FieldSelector: fields("metadata.name!=prod,metadata.tag=latest,status.conditions[Ready]=False"),
+	}))
+
+	// Keys and values are bound, never interpolated.
+	require.Empty(t, listNames(t, store, ListOpts{FieldSelector: v1alpha1.FieldSelector{{
+		Field:    "spec.title",
+		Path:     []v1alpha1.FieldPathSegment{{Name: "spec"}, {Name: "title"}},
+		Operator: v1alpha1.SelectorEquals,
+		Value:    "x' OR '1'='1",
+	}}}))
+
+	deployments := NewMutableObjectStore(db, TestSchema(), "deployments")
+	_, _, err := deployments.List(ctx, ListOpts{FieldSelector: fields("metadata.tag=latest")})
+	require.ErrorIs(t, err, v1alpha1.ErrInvalidSelector)
+}
@@ -0,0 +1,209 @@
+package v1alpha1store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+var (
+	// ErrSignaturesDisabled reports a signature read or write on a Store
+	// built without WithSignatures.
+	ErrSignaturesDisabled = errors.New("v1alpha1 store: artifact signatures are not enabled")
+	// ErrUnsigned reports content that carries no valid signature from a
+	// key the signature policy trusts.
+	ErrUnsigned = errors.New("no valid signature from a trusted key")
+)
+
+// WithSignatures stores detached artifact signatures in schema's
+// artifact_signatures table. Ignored on mutable-object stores. NewStores
+// enables it for every built-in tagged kind; extension stores opt in by
+// passing it with the schema that holds artifact_signatures.
+func WithSignatures(schema pkgdb.Schema) StoreOption {
+	return func(s *Store) {
+		if s.behavior == TaggedArtifactStore {
+			s.signatures = qualifyTable(s.db, schema, "artifact_signatures")
+		}
+	}
+}
+
+// Signature is one detached signature over a content digest.
+type Signature struct {
+	// Digest is the ContentDigest the signature covers.
+	Digest string
+	// KeyID is signing.KeyID of PublicKey.
+	KeyID string
+	// PublicKey is the PEM public key the signature verifies against.
+	PublicKey string
+	// Signature is the base64 signature of Digest.
+	Signature string
+	CreatedAt time.Time
+}
+
+// Signatures reports whether the Store keeps artifact signatures.
+func (s *Store) Signatures() bool {
+	return s != nil && s.signatures != ""
+}
+
+// ObjectDigest returns the ContentDigest obj would be stored under.
+func ObjectDigest(obj v1alpha1.Object) (string, error) {
+	spec, err := obj.MarshalSpec()
+	if err != nil {
+		return "", fmt.Errorf("marshal spec: %w", err)
+	}
+	hash, err := ContentHash(obj.GetMetadata(), spec)
+	if err != nil {
+		return "", err
+	}
+	return ContentDigest(hash), nil
+}
+
+// TagDigest returns the ContentDigest of the content (namespace, name, tag)
+// holds now; a blank tag means "latest". Returns pkgdb.ErrNotFound when the
+// tag does not exist.
+func (s *Store) TagDigest(ctx context.Context, namespace, name, tag string) (string, error) {
+	if tag == "" {
+		tag = DefaultTag()
+	}
+	_, hash, err := s.getWithHash(ctx, namespace, name, tag)
+	if err != nil {
+		return "", err
+	}
+	return ContentDigest(hash), nil
+}
+
+// AddSignature records sig for the content of (namespace, name) with
+// digest sig.Digest, replacing an earlier signature by the same key. The
+// signature is verified against sig.PublicKey first; whether that key is
+// trusted is decided at admission time, not here. The content does not
+// have to be stored yet, so it can be signed before its first apply.
+func (s *Store) AddSignature(ctx context.Context, namespace, name string, sig Signature) (*Signature, error) {
+	if !s.Signatures() {
+		return nil, ErrSignaturesDisabled
+	}
+	if namespace == "" || name == "" {
+		return nil, errors.New("v1alpha1 store: namespace and name are required")
+	}
+	hash, err := digestHash(sig.Digest)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := signing.ParsePublicKey([]byte(sig.PublicKey))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", v1alpha1.ErrInvalidFormat, err)
+	}
+	if err := signing.Verify(pub, sig.Digest, sig.Signature); err != nil {
+		return nil, err
+	}
+	keyID, err := signing.KeyID(pub)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := signing.MarshalPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	out := Signature{Digest: sig.Digest, KeyID: keyID, PublicKey: publicKey, Signature: strings.TrimSpace(sig.Signature)}
+	event := types.AuditEvent{
+		Verb: types.AuditVerbSign, Kind: s.kind, Namespace: namespace, Name: name,
+		Reason: fmt.Sprintf("signed %s with key %s", sig.Digest, keyID),
+	}
+	err = runInTx(ctx, s.db, func(tx DB) error {
+		if err := tx.QueryRow(ctx,
+			fmt.Sprintf(`
+				INSERT INTO %s (resource_table, namespace, name, content_hash, key_id, public_key, signature)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)
+				ON CONFLICT (resource_table, namespace, name, content_hash, key_id)
+				DO UPDATE SET public_key = EXCLUDED.public_key, signature = EXCLUDED.signature, created_at = now()
+				RETURNING created_at`, s.signatures),
+			s.table, namespace, name, hash, keyID, out.PublicKey, out.Signature,
+		).Scan(&out.CreatedAt); err != nil {
+			return fmt.Errorf("add signature: %w", err)
+		}
+		return s.audit(ctx, tx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.record(ctx, event)
+	return &out, nil
+}
+
+// ListSignatures returns the signatures recorded for the content of
+// (namespace, name) with the given digest, oldest first.
+func (s *Store) ListSignatures(ctx context.Context, namespace, name, digest string) ([]Signature, error) {
+	if !s.Signatures() {
+		return nil, ErrSignaturesDisabled
+	}
+	rows, err := s.db.Query(ctx,
+		fmt.Sprintf(`
+			SELECT key_id, public_key, signature, created_at
+			FROM %s
+			WHERE resource_table=$1 AND namespace=$2 AND name=$3 AND content_hash=$4
+			ORDER BY created_at, key_id`, s.signatures),
+		s.table, namespace, name, strings.TrimPrefix(digest, DigestPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("list signatures: %w", err)
+	}
+	defer rows.Close()
+
+	out := []Signature{}
+	for rows.Next() {
+		sig := Signature{Digest: digest}
+		if err := rows.Scan(&sig.KeyID, &sig.PublicKey, &sig.Signature, &sig.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan signature: %w", err)
+		}
+		out = append(out, sig)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// deleteSignatures drops every signature recorded for (namespace, name).
+func (s *Store) deleteSignatures(ctx context.Context, tx DB, namespace, name string) error {
+	if s.signatures == "" {
+		return nil
+	}
+	if _, err := tx.Exec(ctx,
+		fmt.Sprintf(`DELETE FROM %s WHERE resource_table=$1 AND namespace=$2 AND name=$3`, s.signatures),
+		s.table, namespace, name); err != nil {
+		return fmt.Errorf("delete signatures: %w", err)
+	}
+	return nil
+}
+
+// VerifyTrusted returns the name of the first trusted key of policy with a
+// valid signature over digest among sigs. Signatures are re-verified
+// against the trusted key itself rather than the stored public key.
+// Returns an error wrapping ErrUnsigned when none verifies.
+func VerifyTrusted(policy v1alpha1.SignaturePolicy, digest string, sigs []Signature) (string, error) {
+	for _, trusted := range policy.TrustedKeys {
+		pub, err := signing.ParsePublicKey([]byte(trusted.PublicKey))
+		if err != nil {
+			continue
+		}
+		keyID, err := signing.KeyID(pub)
+		if err != nil {
+			continue
+		}
+		for _, sig := range sigs {
+			if sig.KeyID != keyID {
+				continue
+			}
+			if signing.Verify(pub, digest, sig.Signature) == nil {
+				return trusted.Name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsigned, digest)
+}
@@ -0,0 +1,90 @@
+//go:build integration
+
+package v1alpha1store_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
+)
+
+func testSigner(t *testing.T) (ed25519.PrivateKey, string) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	pemKey, err := signing.MarshalPublicKey(pub)
+	require.NoError(t, err)
+	return priv, pemKey
+}
+
+func TestSignatures_AddListVerify(t *testing.T) {
+	ctx := context.Background()
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents", v1alpha1store.WithSignatures(v1alpha1store.TestSchema()))
+	require.True(t, store.Signatures())
+
+	agent := taggedAgentObj("alice", "1.0.0", "one", nil)
+	digest, err := v1alpha1store.ObjectDigest(agent)
+	require.NoError(t, err)
+
+	// Content can be signed before it is applied.
+	priv, pub := testSigner(t)
+	sig, err := signing.Sign(priv, digest)
+	require.NoError(t, err)
+	added, err := store.AddSignature(ctx, "default", "alice", v1alpha1store.Signature{Digest: digest, PublicKey: pub, Signature: sig})
+	require.NoError(t, err)
+	require.Len(t, added.KeyID, 64)
+
+	// Re-signing with the same key replaces the signature.
+	sig2, err := signing.Sign(priv, digest)
+	require.NoError(t, err)
+	_, err = store.AddSignature(ctx, "default", "alice", v1alpha1store.Signature{Digest: digest, PublicKey: pub, Signature: sig2})
+	require.NoError(t, err)
+
+	// A signature that does not verify against its own key is refused.
+	_, otherPub := testSigner(t)
+	_, err = store.AddSignature(ctx, "default", "alice", v1alpha1store.Signature{Digest: digest, PublicKey: otherPub, Signature: sig})
+	require.ErrorIs(t, err, signing.ErrInvalidSignature)
+
+	sigs, err := store.ListSignatures(ctx, "default", "alice", digest)
+	require.NoError(t, err)
+	require.Len(t, sigs, 1)
+	require.Equal(t, added.KeyID, sigs[0].KeyID)
+
+	trusted := v1alpha1.SignaturePolicy{Required: true, TrustedKeys: []v1alpha1.TrustedKey{{Name: "release", PublicKey: pub}}}
+	name, err := v1alpha1store.VerifyTrusted(trusted, digest, sigs)
+	require.NoError(t, err)
+	require.Equal(t, "release", name)
+
+	untrusted := v1alpha1.SignaturePolicy{Required: true, TrustedKeys: []v1alpha1.TrustedKey{{Name: "other", PublicKey: otherPub}}}
+	_, err = v1alpha1store.VerifyTrusted(untrusted, digest, sigs)
+	require.ErrorIs(t, err, v1alpha1store.ErrUnsigned)
+
+	// Different content has a different digest and no signatures.
+	changed, err := v1alpha1store.ObjectDigest(taggedAgentObj("alice", "1.0.0", "two", nil))
+	require.NoError(t, err)
+	sigs, err = store.ListSignatures(ctx, "default", "alice", changed)
+	require.NoError(t, err)
+	require.Empty(t, sigs)
+
+	// Deleting every tag of the name drops its signatures.
+	_, err = store.Upsert(ctx, agent)
+	require.NoError(t, err)
+	require.NoError(t, store.DeleteAllTags(ctx, "default", "alice"))
+	sigs, err = store.ListSignatures(ctx, "default", "alice", digest)
+	require.NoError(t, err)
+	require.Empty(t, sigs)
+}
+
+func TestSignatures_Disabled(t *testing.T) {
+	store := setupAgentStore(t)
+	_, err := store.ListSignatures(context.Background(), "default", "alice", v1alpha1store.ContentDigest("00"))
+	require.ErrorIs(t, err, v1alpha1store.ErrSignaturesDisabled)
+}
@@ -0,0 +1,562 @@
+package v1alpha1store
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mattn/go-sqlite3"
+	"k8s.io/utils/lru"
+)
+
+// SQLiteStoragePrefix introduces a SQLite storage location, as in
+// sqlite:///var/lib/agentregistry/registry.db.
+const SQLiteStoragePrefix = "sqlite://"
+
+// sqliteTimeLayout is how the SQLite backend stores timestamps: UTC with a
+// fixed-width fraction, so text order is time order.
+const sqliteTimeLayout = "2006-01-02T15:04:05.000000000Z"
+
+// sqliteInsertOp is SQLITE_INSERT, the update-hook operation code for an
+// inserted row.
+const sqliteInsertOp = 18
+
+// sqliteMaxConns bounds the connection pool. WAL mode lets readers run
+// alongside the single writer; idle connections are kept open so their
+// per-connection state lives as long as the database.
+const sqliteMaxConns = 8
+
+// SQLite is the embedded storage backend for single-binary local mode. It
+// keeps the same tables, triggers and control_plane_events log as the
+// PostgreSQL schema in one database file and satisfies DB, so every store
+// in this package runs on it unchanged apart from the few dialect branches
+// marked with isSQLite.
+//
+// Statements are translated from the PostgreSQL dialect: casts and
+// FOR UPDATE are dropped and $N placeholders become ?N. Transactions take
+// the write lock when they begin, which serializes writers the way the
+// advisory and row locks do on PostgreSQL. Revisions come from an
+// in-process sequence, so one database file must be opened by one process
+// at a time.
+type SQLite struct {
+	db *sql.DB
+
+	// revision is the last control_plane_events revision handed out by
+	// nextval().
+	revision atomic.Int64
+	// conns maps each driver connection to its state.
+	conns sync.Map
+
+	mu   sync.Mutex
+	subs map[chan struct{}]struct{}
+}
+
+// sqliteConnState is the per-connection state the update hook records.
+// Only the goroutine holding the connection touches it.
+type sqliteConnState struct {
+	eventsWritten bool
+}
+
+// ParseSQLiteStorage returns the database path of a sqlite:// storage
+// location. ok is false when storage names another backend.
+func ParseSQLiteStorage(storage string) (path string, ok bool, err error) {
+	rest, found := strings.CutPrefix(storage, SQLiteStoragePrefix)
+	if !found {
+		return "", false, nil
+	}
+	if rest == "" {
+		return "", true, fmt.Errorf("storage %q: missing database path", storage)
+	}
+	return rest, true, nil
+}
+
+// OpenSQLite opens (creating if needed) the SQLite database at path and
+// brings its schema up to date. Close releases it.
+func OpenSQLite(ctx context.Context, path string) (*SQLite, error) {
+	s := &SQLite{subs: map[chan struct{}]struct{}{}}
+	params := url.Values{}
+	params.Set("_journal_mode", "WAL")
+	params.Set("_busy_timeout", "10000")
+	params.Set("_txlock", "immediate")
+	// LIKE is case-sensitive on PostgreSQL; ILIKE is translated to lower().
+	params.Set("_cslike", "true")
+	params.Set("_synchronous", "NORMAL")
+	connector := &sqliteConnector{
+		dsn:    "file:" + path + "?" + params.Encode(),
+		driver: &sqlite3.SQLiteDriver{ConnectHook: s.connect},
+	}
+	s.db = sql.OpenDB(connector)
+	s.db.SetMaxOpenConns(sqliteMaxConns)
+	s.db.SetMaxIdleConns(sqliteMaxConns)
+
+	if err := s.db.PingContext(ctx); err != nil {
+		_ = s.db.Close()
+		return nil, fmt.Errorf("open sqlite database %s: %w", path, err)
+	}
+	if err := migrateSQLite(ctx, s.db); err != nil {
+		_ = s.db.Close()
+		return nil, err
+	}
+	if err := s.initRevision(ctx); err != nil {
+		_ = s.db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close closes the database.
+func (s *SQLite) Close() error {
+	return s.db.Close()
+}
+
+// connect prepares each new driver connection: the SQL functions the
+// schema and translated statements call, and the update hook that notes
+// control_plane_events writes.
+func (s *SQLite) connect(conn *sqlite3.SQLiteConn) error {
+	functions := []struct {
+		name string
+		impl any
+		pure bool
+	}{
+		{"now", sqliteNow, false},
+		{"gen_random_uuid", sqliteUUID, false},
+		{"nextval", s.nextval, false},
+		{"json_contains", sqliteJSONContains, true},
+		{"json_mentions", sqliteJSONMentions, true},
+		{"json_path_text", sqliteJSONPathText, true},
+		{"json_path_array", sqliteJSONPathArray, true},
+		{"search_rank", sqliteSearchRank, true},
+		{"search_headline", sqliteSearchHeadline, true},
+	}
+	for _, fn := range functions {
+		if err := conn.RegisterFunc(fn.name, fn.impl, fn.pure); err != nil {
+			return fmt.Errorf("register sqlite function %s: %w", fn.name, err)
+		}
+	}
+	state := &sqliteConnState{}
+	conn.RegisterUpdateHook(func(op int, _ string, table string, _ int64) {
+		if op == sqliteInsertOp && table == controlPlaneEventsTable {
+			state.eventsWritten = true
+		}
+	})
+	s.conns.Store(conn, state)
+	return nil
+}
+
+// initRevision starts the revision sequence after every revision the
+// database already holds. AUTOINCREMENT keeps control_plane_events'
+// high-water mark in sqlite_sequence even after its rows are pruned.
+func (s *SQLite) initRevision(ctx context.Context) error {
+	var revision int64
+	if err := s.db.QueryRowContext(ctx,
+		`SELECT COALESCE(MAX(seq), 0) FROM sqlite_sequence WHERE name = ?`, controlPlaneEventsTable,
+	).Scan(&revision); err != nil {
+		return fmt.Errorf("load sqlite revision: %w", err)
+	}
+	for _, table := range sqliteKindTables() {
+		var v int64
+		if err := s.db.QueryRowContext(ctx,
+			`SELECT COALESCE(MAX(resource_version), 0) FROM `+pgx.Identifier{table.Table}.Sanitize(),
+		).Scan(&v); err != nil {
+			return fmt.Errorf("load sqlite revision: %w", err)
+		}
+		revision = max(revision, v)
+	}
+	s.revision.Store(revision)
+	return nil
+}
+
+func (s *SQLite) nextval(name string) (int64, error) {
+	if name != "control_plane_events_revision_seq" {
+		return 0, fmt.Errorf("unknown sequence %q", name)
+	}
+	return s.revision.Add(1), nil
+}
+
+// Exec runs sql outside a transaction.
+func (s *SQLite) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	conn, state, err := s.acquire(ctx)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	defer conn.Close()
+	state.eventsWritten = false
+	res, err := conn.ExecContext(ctx, translateSQL(sql), sqliteArgs(args)...)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	s.notifyIfWritten(state)
+	return sqliteCommandTag(res)
+}
+
+// Query runs sql outside a transaction. Writes made through Query do not
+// wake control-plane listeners; write through Exec or a transaction.
+func (s *SQLite) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	rows, err := s.db.QueryContext(ctx, translateSQL(sql), sqliteArgs(args)...)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteRows{rows: rows}, nil
+}
+
+// QueryRow runs sql outside a transaction. Like Query, it does not wake
+// control-plane listeners.
+func (s *SQLite) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return sqliteRow{row: s.db.QueryRowContext(ctx, translateSQL(sql), sqliteArgs(args)...)}
+}
+
+// runInTx runs fn in a transaction that holds the write lock from its
+// first statement, and wakes control-plane listeners once it commits.
+func (s *SQLite) runInTx(ctx context.Context, fn func(DB) error) error {
+	conn, state, err := s.acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer conn.Close()
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	state.eventsWritten = false
+
+	if err := fn(sqliteTx{tx: tx}); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	s.notifyIfWritten(state)
+	return nil
+}
+
+// acquire reserves a connection and looks up its state.
+func (s *SQLite) acquire(ctx context.Context) (*sql.Conn, *sqliteConnState, error) {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	var state *sqliteConnState
+	if err := conn.Raw(func(driverConn any) error {
+		v, ok := s.conns.Load(driverConn)
+		if !ok {
+			return errors.New("sqlite connection was not initialized")
+		}
+		state = v.(*sqliteConnState)
+		return nil
+	}); err != nil {
+		_ = conn.Close()
+		return nil, nil, err
+	}
+	return conn, state, nil
+}
+
+// Subscribe registers a channel woken after every committed write that
+// appends to control_plane_events — the SQLite counterpart of LISTEN on
+// ControlPlaneNotifyChannel. The channel is buffered to one pending
+// wakeup; bursts coalesce. Call the returned func to unsubscribe.
+func (s *SQLite) Subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	s.mu.Lock()
+	s.subs[ch] = struct{}{}
+	s.mu.Unlock()
+	return ch, func() {
+		s.mu.Lock()
+		delete(s.subs, ch)
+		s.mu.Unlock()
+	}
+}
+
+func (s *SQLite) notifyIfWritten(state *sqliteConnState) {
+	if !state.eventsWritten {
+		return
+	}
+	state.eventsWritten = false
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subs {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// sqliteTx is a SQLite transaction seen as a DB.
+type sqliteTx struct {
+	tx *sql.Tx
+}
+
+func (t sqliteTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	res, err := t.tx.ExecContext(ctx, translateSQL(sql), sqliteArgs(args)...)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	return sqliteCommandTag(res)
+}
+
+func (t sqliteTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	rows, err := t.tx.QueryContext(ctx, translateSQL(sql), sqliteArgs(args)...)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteRows{rows: rows}, nil
+}
+
+func (t sqliteTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return sqliteRow{row: t.tx.QueryRowContext(ctx, translateSQL(sql), sqliteArgs(args)...)}
+}
+
+func sqliteCommandTag(res sql.Result) (pgconn.CommandTag, error) {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	// CommandTag.RowsAffected reads the trailing count.
+	return pgconn.NewCommandTag("SQLITE " + strconv.FormatInt(n, 10)), nil
+}
+
+var (
+	sqlitePlaceholderPattern = regexp.MustCompile(`\$(\d+)`)
+	sqliteCastPattern        = regexp.MustCompile(`::[a-z][a-z0-9_]*(\(\d+\))?(\[\])?`)
+	sqliteForUpdatePattern   = regexp.MustCompile(`\s+FOR UPDATE\b`)
+	sqliteContainsPattern    = regexp.MustCompile(`\b([a-z_]+) @> (\$\d+)`)
+	sqliteILikePattern       = regexp.MustCompile(`\b([a-z_]+) ILIKE (\$\d+)`)
+	sqliteLikePattern        = regexp.MustCompile(`\b([a-z_]+) LIKE (\$\d+)`)
+	// sqliteTranslations caches translateSQL results. Store statements are
+	// a fixed set, but selector and search filters build statements per
+	// request, so the cache is bounded.
+	sqliteTranslations = lru.New(sqliteTranslationCacheSize)
+)
+
+// sqliteTranslationCacheSize bounds sqliteTranslations; it comfortably holds
+// every fixed store statement.
+const sqliteTranslationCacheSize = 1024
+
+// translateSQL rewrites a statement from the PostgreSQL dialect the stores
+// are written in: casts and row locks are dropped (SQLite columns carry
+// their own affinity and a transaction already holds the write lock), and
+// $N placeholders become ?N. The operators callers use in ExtraWhere
+// fragments are rewritten too: `column @> $N` becomes json_contains, and
+// LIKE/ILIKE take PostgreSQL's default backslash escape.
+func translateSQL(query string) string {
+	if v, ok := sqliteTranslations.Get(query); ok {
+		return v.(string)
+	}
+	out := sqliteCastPattern.ReplaceAllString(query, "")
+	out = sqliteForUpdatePattern.ReplaceAllString(out, "")
+	out = sqliteContainsPattern.ReplaceAllString(out, "json_contains($1, $2)")
+	out = sqliteLikePattern.ReplaceAllString(out, `$1 LIKE $2 ESCAPE '\'`)
+	out = sqliteILikePattern.ReplaceAllString(out, `lower($1) LIKE lower($2) ESCAPE '\'`)
+	out = sqlitePlaceholderPattern.ReplaceAllString(out, "?$1")
+	sqliteTranslations.Add(query, out)
+	return out
+}
+
+// sqliteArgs converts bind values to their SQLite storage form: JSON as
+// text, timestamps as sqliteTimeLayout text, and string slices as JSON
+// arrays.
+func sqliteArgs(args []any) []any {
+	out := make([]any, len(args))
+	for i, arg := range args {
+		out[i] = sqliteArg(arg)
+	}
+	return out
+}
+
+func sqliteArg(arg any) any {
+	switch v := arg.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		return formatSQLiteTime(v)
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return formatSQLiteTime(*v)
+	case json.RawMessage:
+		if v == nil {
+			return nil
+		}
+		return string(v)
+	case []byte:
+		if v == nil {
+			return nil
+		}
+		return string(v)
+	case []string:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return arg
+		}
+		return string(raw)
+	case driver.Valuer:
+		value, err := v.Value()
+		if err != nil {
+			return arg
+		}
+		return sqliteArg(value)
+	}
+	return arg
+}
+
+func formatSQLiteTime(t time.Time) string {
+	return t.UTC().Format(sqliteTimeLayout)
+}
+
+// parseSQLiteTime parses a timestamp read back as text, which happens
+// when a result column has no declared type.
+func parseSQLiteTime(s string) (time.Time, error) {
+	for _, layout := range []string{sqliteTimeLayout, time.RFC3339Nano, "2006-01-02 15:04:05.999999999-07:00"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("parse timestamp %q", s)
+}
+
+// sqliteRow adapts *sql.Row to pgx.Row.
+type sqliteRow struct {
+	row *sql.Row
+}
+
+func (r sqliteRow) Scan(dest ...any) error {
+	err := r.row.Scan(sqliteDests(dest)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return pgx.ErrNoRows
+	}
+	return err
+}
+
+// sqliteRows adapts *sql.Rows to pgx.Rows. The pgx-only accessors
+// (FieldDescriptions, RawValues, Conn) report nothing.
+type sqliteRows struct {
+	rows *sql.Rows
+}
+
+func (r *sqliteRows) Close()                                       { _ = r.rows.Close() }
+func (r *sqliteRows) Err() error                                   { return r.rows.Err() }
+func (r *sqliteRows) CommandTag() pgconn.CommandTag                { return pgconn.CommandTag{} }
+func (r *sqliteRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+func (r *sqliteRows) Next() bool                                   { return r.rows.Next() }
+func (r *sqliteRows) RawValues() [][]byte                          { return nil }
+func (r *sqliteRows) Conn() *pgx.Conn                              { return nil }
+
+func (r *sqliteRows) Scan(dest ...any) error {
+	return r.rows.Scan(sqliteDests(dest)...)
+}
+
+func (r *sqliteRows) Values() ([]any, error) {
+	columns, err := r.rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]any, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := r.rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// sqliteDests wraps the scan destinations database/sql cannot fill from
+// SQLite values on its own: timestamps stored as text and JSON columns
+// read into json.RawMessage.
+func sqliteDests(dest []any) []any {
+	out := make([]any, len(dest))
+	for i, d := range dest {
+		switch d.(type) {
+		case *time.Time, **time.Time, *pgtype.Timestamptz, *json.RawMessage:
+			out[i] = sqliteScanner{dest: d}
+		default:
+			out[i] = d
+		}
+	}
+	return out
+}
+
+type sqliteScanner struct {
+	dest any
+}
+
+func (s sqliteScanner) Scan(src any) error {
+	var (
+		t     time.Time
+		valid bool
+	)
+	switch v := src.(type) {
+	case nil:
+	case time.Time:
+		t, valid = v.UTC(), true
+	case string:
+		if raw, ok := s.dest.(*json.RawMessage); ok {
+			*raw = json.RawMessage(v)
+			return nil
+		}
+		parsed, err := parseSQLiteTime(v)
+		if err != nil {
+			return err
+		}
+		t, valid = parsed, true
+	case []byte:
+		if raw, ok := s.dest.(*json.RawMessage); ok {
+			*raw = append(json.RawMessage(nil), v...)
+			return nil
+		}
+		return s.Scan(string(v))
+	default:
+		return fmt.Errorf("cannot scan %T into %T", src, s.dest)
+	}
+
+	switch d := s.dest.(type) {
+	case *time.Time:
+		if !valid {
+			return errors.New("cannot scan NULL into *time.Time")
+		}
+		*d = t
+	case **time.Time:
+		if !valid {
+			*d = nil
+			return nil
+		}
+		*d = &t
+	case *pgtype.Timestamptz:
+		*d = pgtype.Timestamptz{Time: t, Valid: valid}
+	case *json.RawMessage:
+		*d = nil
+	}
+	return nil
+}
+
+// sqliteConnector opens connections through a private driver instance, so
+// the ConnectHook is bound to one SQLite value without registering a
+// global driver name.
+type sqliteConnector struct {
+	dsn    string
+	driver *sqlite3.SQLiteDriver
+}
+
+func (c *sqliteConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c *sqliteConnector) Driver() driver.Driver {
+	return c.driver
+}
@@ -0,0 +1,155 @@
+-- 001_initial_schema is the SQLite counterpart of the PostgreSQL
+-- migrations up to 020: the same tables, keys and seeds, with the
+-- PostgreSQL triggers rewritten as SQLite AFTER triggers. It is a
+-- text/template executed once per built-in kind table.
+--
+-- now(), gen_random_uuid() and nextval() are Go functions the backend
+-- registers on every connection. JSON columns are TEXT and timestamps are
+-- fixed-width UTC text, so comparing them as text compares their values.
+
+CREATE TABLE control_plane_events (
+    revision     INTEGER   PRIMARY KEY AUTOINCREMENT,
+    kind         TEXT      NOT NULL,
+    namespace    TEXT      NOT NULL,
+    name         TEXT      NOT NULL,
+    tag          TEXT      NOT NULL DEFAULT '',
+    uid          TEXT      NOT NULL,
+    generation   INTEGER   NOT NULL,
+    op           TEXT      NOT NULL CHECK (op IN ('insert', 'update', 'delete')),
+    committed_at TIMESTAMP NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX control_plane_events_committed_at ON control_plane_events (committed_at, revision);
+CREATE INDEX control_plane_events_identity ON control_plane_events (kind, namespace, name, tag, generation);
+
+CREATE TABLE controller_leases (
+    name        TEXT      NOT NULL PRIMARY KEY,
+    holder      TEXT      NOT NULL,
+    acquired_at TIMESTAMP NOT NULL DEFAULT (now()),
+    renewed_at  TIMESTAMP NOT NULL DEFAULT (now()),
+    expires_at  TIMESTAMP NOT NULL
+);
+
+CREATE TABLE audit_events (
+    id          INTEGER   PRIMARY KEY AUTOINCREMENT,
+    occurred_at TIMESTAMP NOT NULL DEFAULT (now()),
+    request_id  TEXT      NOT NULL DEFAULT '',
+    principal   TEXT      NOT NULL DEFAULT '',
+    verb        TEXT      NOT NULL,
+    kind        TEXT      NOT NULL DEFAULT '',
+    namespace   TEXT      NOT NULL DEFAULT '',
+    name        TEXT      NOT NULL DEFAULT '',
+    tag         TEXT      NOT NULL DEFAULT '',
+    reason      TEXT      NOT NULL DEFAULT '',
+    diff        TEXT
+);
+
+CREATE INDEX audit_events_occurred_at_idx ON audit_events (occurred_at);
+CREATE INDEX audit_events_resource_idx ON audit_events (kind, namespace, name, id);
+CREATE INDEX audit_events_principal_idx ON audit_events (principal, id);
+
+CREATE TABLE tag_revisions (
+    resource_table TEXT      NOT NULL,
+    namespace      TEXT      NOT NULL,
+    name           TEXT      NOT NULL,
+    tag            TEXT      NOT NULL,
+    revision       INTEGER   NOT NULL,
+    content_hash   TEXT      NOT NULL,
+    labels         TEXT      NOT NULL DEFAULT '{}',
+    annotations    TEXT      NOT NULL DEFAULT '{}',
+    spec           TEXT      NOT NULL,
+    created_at     TIMESTAMP NOT NULL DEFAULT (now()),
+    PRIMARY KEY (resource_table, namespace, name, tag, revision),
+    UNIQUE (resource_table, namespace, name, tag, content_hash)
+);
+
+CREATE TABLE mcp_sync_state (
+    upstream        TEXT      NOT NULL PRIMARY KEY,
+    cursor          TEXT      NOT NULL DEFAULT '',
+    updated_since   TIMESTAMP,
+    pass_started_at TIMESTAMP,
+    last_synced_at  TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT (now())
+);
+{{range .}}
+CREATE TABLE {{.Table}} (
+    namespace          TEXT      NOT NULL,
+    name               TEXT      NOT NULL,
+{{- if .Tagged}}
+    tag                TEXT      NOT NULL,
+{{- end}}
+    uid                TEXT      NOT NULL DEFAULT (gen_random_uuid()),
+    generation         INTEGER   NOT NULL DEFAULT 1,
+    labels             TEXT      NOT NULL DEFAULT '{}',
+    annotations        TEXT      NOT NULL DEFAULT '{}',
+    spec               TEXT      NOT NULL,
+{{- if .Tagged}}
+    content_hash       TEXT      NOT NULL,
+{{- end}}
+    status             TEXT      NOT NULL DEFAULT '{}',
+    deletion_timestamp TIMESTAMP,
+{{- if not .Tagged}}
+    finalizers         TEXT      NOT NULL DEFAULT '[]',
+{{- end}}
+    created_at         TIMESTAMP NOT NULL DEFAULT (now()),
+    updated_at         TIMESTAMP NOT NULL DEFAULT (now()),
+    resource_version   INTEGER   NOT NULL DEFAULT (nextval('control_plane_events_revision_seq')),
+{{- if .Tagged}}
+    PRIMARY KEY (namespace, name, tag)
+{{- else}}
+    PRIMARY KEY (namespace, name)
+{{- end}}
+);
+
+CREATE INDEX {{.Table}}_updated_at_desc ON {{.Table}} (updated_at DESC);
+
+CREATE TRIGGER {{.Table}}_control_plane_insert AFTER INSERT ON {{.Table}}
+BEGIN
+    INSERT INTO control_plane_events (revision, kind, namespace, name, tag, uid, generation, op)
+    VALUES (NEW.resource_version, '{{.Kind}}', NEW.namespace, NEW.name, {{if .Tagged}}NEW.tag{{else}}''{{end}}, NEW.uid, NEW.generation, 'insert');
+END;
+
+-- Every update stamps updated_at. A source change — anything but a
+-- status-only write, plus the Plugin/Skill resolvedSource pin — also takes
+-- the next revision as resource_version and logs it; otherwise the stored
+-- version is kept. recursive_triggers is off, so the UPDATE below does not
+-- fire this trigger again.
+CREATE TRIGGER {{.Table}}_after_update AFTER UPDATE ON {{.Table}}
+BEGIN
+    UPDATE {{.Table}}
+    SET updated_at = now(),
+        resource_version = CASE WHEN {{template "changed" .}}
+            THEN nextval('control_plane_events_revision_seq')
+            ELSE OLD.resource_version END
+    WHERE rowid = NEW.rowid;
+    INSERT INTO control_plane_events (revision, kind, namespace, name, tag, uid, generation, op)
+    SELECT resource_version, '{{.Kind}}', namespace, name, {{if .Tagged}}tag{{else}}''{{end}}, uid, generation, 'update'
+    FROM {{.Table}}
+    WHERE rowid = NEW.rowid AND {{template "changed" .}};
+END;
+
+CREATE TRIGGER {{.Table}}_control_plane_delete AFTER DELETE ON {{.Table}}
+BEGIN
+    INSERT INTO control_plane_events (revision, kind, namespace, name, tag, uid, generation, op)
+    VALUES (nextval('control_plane_events_revision_seq'), '{{.Kind}}', OLD.namespace, OLD.name, {{if .Tagged}}OLD.tag{{else}}''{{end}}, OLD.uid, OLD.generation, 'delete');
+END;
+{{end}}
+INSERT INTO runtimes (namespace, name, spec)
+VALUES ('default', 'kubernetes-default', '{"type":"Kubernetes"}')
+ON CONFLICT (namespace, name) DO NOTHING;
+
+INSERT INTO namespaces (namespace, name, spec)
+VALUES ('default', 'default', '{"description":"The default namespace."}')
+ON CONFLICT (namespace, name) DO NOTHING;
+{{define "changed" -}}
+(NEW.spec IS NOT OLD.spec
+            OR NEW.labels IS NOT OLD.labels
+            OR NEW.annotations IS NOT OLD.annotations
+            OR NEW.deletion_timestamp IS NOT OLD.deletion_timestamp
+{{- if not .Tagged}}
+            OR NEW.finalizers IS NOT OLD.finalizers
+{{- end}}
+{{- if .ResolvedSource}}
+            OR json_extract(NEW.status, '$.resolvedSource') IS NOT json_extract(OLD.status, '$.resolvedSource')
+{{- end}})
+{{- end}}
@@ -0,0 +1,14 @@
+-- Detached artifact signatures; see
+-- migrations/021_artifact_signatures.up.sql.
+
+CREATE TABLE artifact_signatures (
+    resource_table TEXT      NOT NULL,
+    namespace      TEXT      NOT NULL,
+    name           TEXT      NOT NULL,
+    content_hash   TEXT      NOT NULL,
+    key_id         TEXT      NOT NULL,
+    public_key     TEXT      NOT NULL,
+    signature      TEXT      NOT NULL,
+    created_at     TIMESTAMP NOT NULL DEFAULT (now()),
+    PRIMARY KEY (resource_table, namespace, name, content_hash, key_id)
+);
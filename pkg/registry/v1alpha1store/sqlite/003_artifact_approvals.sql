@@ -0,0 +1,19 @@
+-- Review decisions for tagged artifacts; see
+-- migrations/022_artifact_approvals.up.sql.
+
+CREATE TABLE artifact_approvals (
+    resource_table TEXT      NOT NULL,
+    namespace      TEXT      NOT NULL,
+    name           TEXT      NOT NULL,
+    content_hash   TEXT      NOT NULL,
+    tag            TEXT      NOT NULL,
+    state          TEXT      NOT NULL,
+    requested_by   TEXT      NOT NULL,
+    requested_at   TIMESTAMP NOT NULL DEFAULT (now()),
+    reviewed_by    TEXT,
+    reviewed_at    TIMESTAMP,
+    comment        TEXT,
+    PRIMARY KEY (resource_table, namespace, name, content_hash)
+);
+
+CREATE INDEX artifact_approvals_state_idx ON artifact_approvals (state, namespace);
@@ -0,0 +1,6 @@
+-- Deprecation and yank markers on tags; see
+-- migrations/023_tag_markers.up.sql.
+{{range .}}{{if .Tagged}}
+ALTER TABLE {{.Table}} ADD COLUMN deprecated TEXT;
+ALTER TABLE {{.Table}} ADD COLUMN yanked TEXT;
+{{end}}{{end}}
@@ -0,0 +1,7 @@
+//go:build cgo
+
+package v1alpha1store
+
+// SQLiteSupported reports whether this binary can open SQLite databases.
+// The SQLite driver needs cgo; without it the driver is a stub.
+const SQLiteSupported = true
@@ -0,0 +1,188 @@
+package v1alpha1store
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// This file holds the Go implementations of the SQL functions the SQLite
+// backend registers on every connection. JSON arguments arrive as TEXT
+// (string) or BLOB ([]byte), and NULL as nil.
+
+func sqliteNow() string {
+	return formatSQLiteTime(time.Now())
+}
+
+func sqliteUUID() string {
+	return uuid.NewString()
+}
+
+// sqliteJSONContains is PostgreSQL's jsonb `doc @> pattern`: every member
+// of a pattern object is contained in the same member of doc, every
+// element of a pattern array in some element of doc, and scalars equal.
+func sqliteJSONContains(doc, pattern any) (bool, error) {
+	d, ok, err := decodeSQLiteJSON(doc)
+	if err != nil || !ok {
+		return false, err
+	}
+	p, ok, err := decodeSQLiteJSON(pattern)
+	if err != nil || !ok {
+		return false, err
+	}
+	return jsonContains(d, p), nil
+}
+
+func jsonContains(doc, pattern any) bool {
+	switch p := pattern.(type) {
+	case map[string]any:
+		d, ok := doc.(map[string]any)
+		if !ok {
+			return false
+		}
+		for key, want := range p {
+			got, ok := d[key]
+			if !ok || !jsonContains(got, want) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		d, ok := doc.([]any)
+		if !ok {
+			return false
+		}
+		for _, want := range p {
+			found := false
+			for _, got := range d {
+				if jsonContains(got, want) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(doc, pattern)
+	}
+}
+
+// sqliteJSONMentions reports whether doc holds the string value at any
+// depth, as the jsonb_path_exists `$.** ? (@ == $v)` prefilter does.
+func sqliteJSONMentions(doc any, value string) (bool, error) {
+	d, ok, err := decodeSQLiteJSON(doc)
+	if err != nil || !ok {
+		return false, err
+	}
+	return jsonMentions(d, value), nil
+}
+
+func jsonMentions(doc any, value string) bool {
+	switch d := doc.(type) {
+	case string:
+		return d == value
+	case map[string]any:
+		for _, v := range d {
+			if jsonMentions(v, value) {
+				return true
+			}
+		}
+	case []any:
+		for _, v := range d {
+			if jsonMentions(v, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sqliteJSONPathText is PostgreSQL's `doc #>> path`, with path a JSON array
+// of keys and array indexes: the addressed value as text, strings
+// unquoted, or NULL when the path does not resolve or holds null.
+func sqliteJSONPathText(doc any, path string) (any, error) {
+	v, ok, err := sqliteJSONPath(doc, path)
+	if err != nil || !ok || v == nil {
+		return nil, err
+	}
+	if s, isString := v.(string); isString {
+		return s, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(raw), nil
+}
+
+// sqliteJSONPathArray returns the array at path in doc as JSON text, or an
+// empty array when the path does not hold one.
+func sqliteJSONPathArray(doc any, path string) (string, error) {
+	v, ok, err := sqliteJSONPath(doc, path)
+	if err != nil {
+		return "", err
+	}
+	arr, isArray := v.([]any)
+	if !ok || !isArray {
+		return "[]", nil
+	}
+	raw, err := json.Marshal(arr)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+func sqliteJSONPath(doc any, path string) (any, bool, error) {
+	d, ok, err := decodeSQLiteJSON(doc)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	var steps []string
+	if err := json.Unmarshal([]byte(path), &steps); err != nil {
+		return nil, false, fmt.Errorf("json path %q: %w", path, err)
+	}
+	for _, step := range steps {
+		switch v := d.(type) {
+		case map[string]any:
+			if d, ok = v[step]; !ok {
+				return nil, false, nil
+			}
+		case []any:
+			i, err := strconv.Atoi(step)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false, nil
+			}
+			d = v[i]
+		default:
+			return nil, false, nil
+		}
+	}
+	return d, true, nil
+}
+
+// decodeSQLiteJSON decodes a JSON argument; ok is false for NULL.
+func decodeSQLiteJSON(arg any) (v any, ok bool, err error) {
+	var raw []byte
+	switch a := arg.(type) {
+	case nil:
+		return nil, false, nil
+	case string:
+		raw = []byte(a)
+	case []byte:
+		raw = a
+	default:
+		return nil, false, fmt.Errorf("expected JSON text, got %T", arg)
+	}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, false, fmt.Errorf("decode JSON: %w", err)
+	}
+	return v, true, nil
+}
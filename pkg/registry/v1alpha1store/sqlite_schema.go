@@ -0,0 +1,113 @@
+package v1alpha1store
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"slices"
+	"text/template"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// controlPlaneEventsTable is the unqualified control-plane event log table.
+const controlPlaneEventsTable = "control_plane_events"
+
+//go:embed sqlite/*.sql
+var sqliteMigrationFiles embed.FS
+
+// sqliteKindTable is the template input for one built-in kind's table.
+type sqliteKindTable struct {
+	Kind   string
+	Table  string
+	Tagged bool
+	// ResolvedSource marks the kinds whose status.resolvedSource is a
+	// source change (see migrations/016_resource_versions.up.sql).
+	ResolvedSource bool
+}
+
+// sqliteKindTables lists the built-in kind tables in a stable order, from
+// the same descriptors NewStores binds.
+func sqliteKindTables() []sqliteKindTable {
+	var out []sqliteKindTable
+	for _, descriptor := range v1alpha1.KindDescriptors() {
+		if _, ok := builtInKinds[descriptor.Kind]; !ok {
+			continue
+		}
+		out = append(out, sqliteKindTable{
+			Kind:           descriptor.Kind,
+			Table:          storeTableNameFromDescriptor(descriptor),
+			Tagged:         descriptor.Storage != v1alpha1.KindStorageMutableObject,
+			ResolvedSource: descriptor.Kind == v1alpha1.KindPlugin || descriptor.Kind == v1alpha1.KindSkill,
+		})
+	}
+	slices.SortFunc(out, func(a, b sqliteKindTable) int {
+		switch {
+		case a.Table < b.Table:
+			return -1
+		case a.Table > b.Table:
+			return 1
+		}
+		return 0
+	})
+	return out
+}
+
+// migrateSQLite applies the embedded SQLite migrations the database has
+// not seen yet, in file order. PRAGMA user_version records how many have
+// been applied; each one runs in its own transaction with the bump.
+func migrateSQLite(ctx context.Context, db *sql.DB) error {
+	files, err := fs.Glob(sqliteMigrationFiles, "sqlite/*.sql")
+	if err != nil {
+		return fmt.Errorf("list sqlite migrations: %w", err)
+	}
+	slices.Sort(files)
+
+	var applied int
+	if err := db.QueryRowContext(ctx, `PRAGMA user_version`).Scan(&applied); err != nil {
+		return fmt.Errorf("read sqlite schema version: %w", err)
+	}
+	for i := applied; i < len(files); i++ {
+		stmts, err := renderSQLiteMigration(files[i])
+		if err != nil {
+			return err
+		}
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("apply %s: %w", files[i], err)
+		}
+		if _, err := tx.ExecContext(ctx, stmts); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("apply %s: %w", files[i], err)
+		}
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`PRAGMA user_version = %d`, i+1)); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("apply %s: %w", files[i], err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("apply %s: %w", files[i], err)
+		}
+	}
+	return nil
+}
+
+// renderSQLiteMigration executes a migration template over the built-in
+// kind tables.
+func renderSQLiteMigration(name string) (string, error) {
+	raw, err := sqliteMigrationFiles.ReadFile(name)
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", name, err)
+	}
+	tmpl, err := template.New(name).Parse(string(raw))
+	if err != nil {
+		return "", fmt.Errorf("parse %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, sqliteKindTables()); err != nil {
+		return "", fmt.Errorf("render %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
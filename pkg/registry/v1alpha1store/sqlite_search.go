@@ -0,0 +1,250 @@
+package v1alpha1store
+
+import (
+	"regexp"
+	"strings"
+)
+
+// SQLite has no full-text search over JSON documents, so Search on the
+// SQLite backend scores rows with these Go functions instead. They keep
+// the shape of the PostgreSQL search — the same weighted document, the
+// same web-search query syntax and highlighted excerpts — with simpler
+// matching: words match by prefix after a plural suffix is trimmed, and
+// there are no stop words.
+
+// Search document weights, as ts_rank_cd weighs the A-D labels.
+const (
+	searchWeightA = 1.0
+	searchWeightB = 0.4
+	searchWeightC = 0.2
+	searchWeightD = 0.1
+)
+
+var searchWordPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// searchTerm is one word or "quoted phrase" of a query.
+type searchTerm struct {
+	words  []string
+	negate bool
+}
+
+// parseSearchQuery splits a web-search style query into alternatives
+// separated by `or`; every term of an alternative must hold.
+func parseSearchQuery(query string) [][]searchTerm {
+	var (
+		groups  [][]searchTerm
+		current []searchTerm
+	)
+	for query != "" {
+		query = strings.TrimLeft(query, " \t\r\n")
+		if query == "" {
+			break
+		}
+		negate := false
+		if query[0] == '-' {
+			negate = true
+			query = query[1:]
+		}
+		var token string
+		if strings.HasPrefix(query, `"`) {
+			end := strings.Index(query[1:], `"`)
+			if end < 0 {
+				token, query = query[1:], ""
+			} else {
+				token, query = query[1:end+1], query[end+2:]
+			}
+		} else {
+			end := strings.IndexAny(query, " \t\r\n")
+			if end < 0 {
+				end = len(query)
+			}
+			token, query = query[:end], query[end:]
+			if !negate && strings.EqualFold(token, "or") {
+				if len(current) > 0 {
+					groups = append(groups, current)
+					current = nil
+				}
+				continue
+			}
+		}
+		if words := searchWords(token); len(words) > 0 {
+			current = append(current, searchTerm{words: words, negate: negate})
+		}
+	}
+	if len(current) > 0 {
+		groups = append(groups, current)
+	}
+	return groups
+}
+
+func searchWords(s string) []string {
+	words := searchWordPattern.FindAllString(strings.ToLower(s), -1)
+	for i, w := range words {
+		words[i] = searchStem(w)
+	}
+	return words
+}
+
+// searchStem trims a plural suffix so "servers" and "server" match.
+func searchStem(w string) string {
+	if len(w) > 4 && strings.HasSuffix(w, "es") {
+		return strings.TrimSuffix(w, "es")
+	}
+	if len(w) > 3 && strings.HasSuffix(w, "s") {
+		return strings.TrimSuffix(w, "s")
+	}
+	return w
+}
+
+// searchField is one weighted part of the search document.
+type searchField struct {
+	words  []string
+	weight float64
+}
+
+func searchDocument(name, spec, labels, status any) []searchField {
+	var (
+		title, description string
+		labelText          []string
+		inventory          []string
+	)
+	if doc, ok, _ := decodeSQLiteJSON(spec); ok {
+		if m, isMap := doc.(map[string]any); isMap {
+			title, _ = m["title"].(string)
+			description, _ = m["description"].(string)
+		}
+	}
+	if doc, ok, _ := decodeSQLiteJSON(labels); ok {
+		if m, isMap := doc.(map[string]any); isMap {
+			for key, value := range m {
+				labelText = append(labelText, key)
+				if s, isString := value.(string); isString {
+					labelText = append(labelText, s)
+				}
+			}
+		}
+	}
+	if doc, ok, _ := decodeSQLiteJSON(status); ok {
+		if m, isMap := doc.(map[string]any); isMap {
+			inventory = collectStrings(m["inventory"], inventory)
+		}
+	}
+	nameText, _ := name.(string)
+	return []searchField{
+		{words: searchWords(nameText), weight: searchWeightA},
+		{words: searchWords(title), weight: searchWeightA},
+		{words: searchWords(description), weight: searchWeightB},
+		{words: searchWords(strings.Join(labelText, " ")), weight: searchWeightC},
+		{words: searchWords(strings.Join(inventory, " ")), weight: searchWeightD},
+	}
+}
+
+func collectStrings(v any, out []string) []string {
+	switch x := v.(type) {
+	case string:
+		out = append(out, x)
+	case map[string]any:
+		for _, item := range x {
+			out = collectStrings(item, out)
+		}
+	case []any:
+		for _, item := range x {
+			out = collectStrings(item, out)
+		}
+	}
+	return out
+}
+
+// termWeight returns the weight of the heaviest field holding term, or 0.
+func termWeight(fields []searchField, term searchTerm) float64 {
+	best := 0.0
+	for _, field := range fields {
+		if field.weight > best && containsPhrase(field.words, term.words) {
+			best = field.weight
+		}
+	}
+	return best
+}
+
+func containsPhrase(words, phrase []string) bool {
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		matched := true
+		for j, w := range phrase {
+			if !strings.HasPrefix(words[i+j], w) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// sqliteSearchRank scores a row against query: the best-scoring
+// alternative's summed term weights, or 0 when no alternative matches.
+func sqliteSearchRank(name, spec, labels, status any, query string) float64 {
+	fields := searchDocument(name, spec, labels, status)
+	best := 0.0
+	for _, group := range parseSearchQuery(query) {
+		score := 0.0
+		matched := true
+		for _, term := range group {
+			weight := termWeight(fields, term)
+			if term.negate {
+				if weight > 0 {
+					matched = false
+					break
+				}
+				continue
+			}
+			if weight == 0 {
+				matched = false
+				break
+			}
+			score += weight
+		}
+		if matched && score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// sqliteSearchHeadline returns the title and description, or the name for
+// kinds without them, with the query's words highlighted.
+func sqliteSearchHeadline(name, spec any, query string) string {
+	var parts []string
+	if doc, ok, _ := decodeSQLiteJSON(spec); ok {
+		if m, isMap := doc.(map[string]any); isMap {
+			for _, key := range []string{"title", "description"} {
+				if s, _ := m[key].(string); s != "" {
+					parts = append(parts, s)
+				}
+			}
+		}
+	}
+	text := strings.Join(parts, " — ")
+	if text == "" {
+		text, _ = name.(string)
+	}
+
+	var want []string
+	for _, group := range parseSearchQuery(query) {
+		for _, term := range group {
+			if !term.negate {
+				want = append(want, term.words...)
+			}
+		}
+	}
+	return searchWordPattern.ReplaceAllStringFunc(text, func(word string) string {
+		stem := searchStem(strings.ToLower(word))
+		for _, w := range want {
+			if strings.HasPrefix(stem, w) {
+				return SearchHighlightStart + word + SearchHighlightStop
+			}
+		}
+		return word
+	})
+}
@@ -0,0 +1,110 @@
+package v1alpha1store
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTranslateSQL(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{
+			in:   "SELECT spec FROM t WHERE name = $1 AND labels @> $2::jsonb FOR UPDATE",
+			want: "SELECT spec FROM t WHERE name = ?1 AND json_contains(labels, ?2)",
+		},
+		{
+			in:   "SELECT 1 FROM t WHERE name LIKE $1 OR tag ILIKE $2",
+			want: `SELECT 1 FROM t WHERE name LIKE ?1 ESCAPE '\' OR lower(tag) LIKE lower(?2) ESCAPE '\'`,
+		},
+		{
+			in:   "SELECT (spec #>> $1::text[]) FROM t WHERE x = ANY($2::text[])",
+			want: "SELECT (spec #>> ?1) FROM t WHERE x = ANY(?2)",
+		},
+	}
+	for _, tc := range cases {
+		if got := translateSQL(tc.in); got != tc.want {
+			t.Errorf("translateSQL(%q)\n got %q\nwant %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestTranslateSQLCacheIsBounded(t *testing.T) {
+	for i := range 2 * sqliteTranslationCacheSize {
+		translateSQL(fmt.Sprintf("SELECT 1 FROM t WHERE labels @> $1::jsonb AND name = '%d'", i))
+	}
+	if n := sqliteTranslations.Len(); n > sqliteTranslationCacheSize {
+		t.Fatalf("cache holds %d statements, want at most %d", n, sqliteTranslationCacheSize)
+	}
+}
+
+func TestParseSQLiteStorage(t *testing.T) {
+	cases := []struct {
+		in      string
+		path    string
+		ok      bool
+		wantErr bool
+	}{
+		{in: "postgres"},
+		{in: ""},
+		{in: "sqlite:///tmp/registry.db", path: "/tmp/registry.db", ok: true},
+		{in: "sqlite://", ok: true, wantErr: true},
+	}
+	for _, tc := range cases {
+		path, ok, err := ParseSQLiteStorage(tc.in)
+		if path != tc.path || ok != tc.ok || (err != nil) != tc.wantErr {
+			t.Errorf("ParseSQLiteStorage(%q) = %q, %v, %v; want %q, %v, err=%v", tc.in, path, ok, err, tc.path, tc.ok, tc.wantErr)
+		}
+	}
+}
+
+func TestSQLiteJSONContains(t *testing.T) {
+	doc := `{"labels":{"team":"a","tier":"gold"},"refs":[{"kind":"Skill","name":"x"},{"kind":"Agent","name":"y"}]}`
+	cases := []struct {
+		pattern string
+		want    bool
+	}{
+		{`{"labels":{"team":"a"}}`, true},
+		{`{"labels":{"team":"b"}}`, false},
+		{`{"refs":[{"kind":"Agent"}]}`, true},
+		{`{"refs":[{"kind":"Prompt"}]}`, false},
+		{`{}`, true},
+	}
+	for _, tc := range cases {
+		got, err := sqliteJSONContains(doc, tc.pattern)
+		if err != nil {
+			t.Fatalf("sqliteJSONContains(%s): %v", tc.pattern, err)
+		}
+		if got != tc.want {
+			t.Errorf("sqliteJSONContains(%s) = %v, want %v", tc.pattern, got, tc.want)
+		}
+	}
+}
+
+func TestSQLiteSearchRank(t *testing.T) {
+	spec := `{"title":"Weather Servers","description":"Forecasts for any city"}`
+	cases := []struct {
+		query string
+		match bool
+	}{
+		{"weather", true},
+		{"server", true},
+		{`"weather server"`, true},
+		{`"server weather"`, false},
+		{"weather -forecasts", false},
+		{"stocks or forecast", true},
+		{"stocks", false},
+	}
+	for _, tc := range cases {
+		rank := sqliteSearchRank("acme/weather", spec, `{}`, `{}`, tc.query)
+		if (rank > 0) != tc.match {
+			t.Errorf("sqliteSearchRank(%q) = %v, want match=%v", tc.query, rank, tc.match)
+		}
+	}
+
+	title := sqliteSearchRank("acme/x", spec, `{}`, `{}`, "weather")
+	description := sqliteSearchRank("acme/x", spec, `{}`, `{}`, "city")
+	if title <= description {
+		t.Errorf("title match ranked %v, description match %v; want title higher", title, description)
+	}
+}
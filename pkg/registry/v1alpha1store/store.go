@@ -15,7 +15,6 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
@@ -61,7 +60,7 @@ const (
 // caller explicitly includes terminating rows. PurgeFinalized removes
 // terminating mutable rows after finalizers are empty.
 type Store struct {
-	pool *pgxpool.Pool
+	db DB
 	// table is the unqualified table name (e.g. "agents") — the identity
 	// used for the advisory-lock key and audit events.
 	table string
@@ -74,6 +73,25 @@ type Store struct {
 	behavior  StoreBehavior
 	kind      string
 	auditor   types.Auditor
+	// auditLog receives an entry for every audited change, written in the
+	// change's own transaction, or is nil (see WithAuditLog).
+	auditLog      *AuditStore
+	auditIdentity AuditIdentity
+	// revisions is the qualified tag_revisions table, or empty when
+	// the Store keeps no revision history (see WithRevisionHistory).
+	revisions string
+	// signatures is the qualified artifact_signatures table, or empty when
+	// the Store keeps no signatures (see WithSignatures).
+	signatures string
+	// approvals is the qualified artifact_approvals table, or empty when
+	// the Store keeps no review decisions (see WithApprovals).
+	approvals string
+	// markers reports whether the table carries the deprecated and yanked
+	// columns (see WithTagMarkers).
+	markers bool
+	// unversioned reports that the table has no resource_version column
+	// (see WithoutResourceVersions).
+	unversioned bool
 }
 
 // Behavior reports which private persistence behavior this Store uses. Generic
@@ -93,8 +111,9 @@ type StoreOption func(*Store)
 
 // WithAuditor plugs a types.Auditor into the Store so every state
 // change the Store considers significant fires the matching audit
-// event after the underlying transaction commits. Default is
-// types.NoopAuditor.
+// event after the underlying transaction commits; the Auditor receives
+// the audit log entries themselves when it implements
+// types.AuditRecorder. Default is types.NoopAuditor.
 func WithAuditor(a types.Auditor) StoreOption {
 	return func(s *Store) {
 		if a != nil {
@@ -103,6 +122,16 @@ func WithAuditor(a types.Auditor) StoreOption {
 	}
 }
 
+// WithAuditLog makes the Store append an entry to log for every change it
+// audits, in the same transaction as the change: a change commits only
+// with its entry. identify names the caller of each entry.
+func WithAuditLog(log *AuditStore, identify AuditIdentity) StoreOption {
+	return func(s *Store) {
+		s.auditLog = log
+		s.auditIdentity = identify
+	}
+}
+
 // WithKind tags a Store with the canonical v1alpha1 Kind name (e.g.
 // v1alpha1.KindAgent) so audit events can name the kind without the
 // caller having to set obj.TypeMeta. NewStores sets this for every
@@ -113,14 +142,45 @@ func WithKind(kind string) StoreOption {
 	return func(s *Store) { s.kind = kind }
 }
 
+// WithoutResourceVersions makes the Store treat the table as lacking the
+// resource_version column that migration 016 adds to the OSS tables: rows
+// read back without metadata.resourceVersion and conditional writes fail
+// with ErrResourceVersionConflict. Extension tables created before the
+// column existed opt in; see HasResourceVersions.
+func WithoutResourceVersions() StoreOption {
+	return func(s *Store) { s.unversioned = true }
+}
+
+// HasResourceVersions reports whether table in schema carries the
+// resource_version column. The embedded SQLite backend holds only the OSS
+// tables, which always do.
+func HasResourceVersions(ctx context.Context, db DB, schema pkgdb.Schema, table string) (bool, error) {
+	db = normalizeDB(db)
+	if isSQLite(db) {
+		return true, nil
+	}
+	var found bool
+	if err := db.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM pg_attribute
+			WHERE attrelid = to_regclass($1) AND attname = 'resource_version'
+			  AND attnum > 0 AND NOT attisdropped
+		)`, schema.Qualify(table)).Scan(&found); err != nil {
+		return false, fmt.Errorf("v1alpha1 store: probe %s.resource_version: %w", table, err)
+	}
+	return found, nil
+}
+
 // NewStore constructs a tagged-artifact Store bound to a single table
 // (e.g. "agents") in schema. The table must exist; NewStore does not
 // validate it. Queries qualify the table with schema explicitly, so the
-// Store does not depend on the connection's search_path.
+// Store does not depend on the connection's search_path. db is either a
+// *pgxpool.Pool or the embedded *SQLite backend.
 //
 // For mutable object tables, use NewMutableObjectStore.
-func NewStore(pool *pgxpool.Pool, schema pkgdb.Schema, table string, opts ...StoreOption) *Store {
-	s := &Store{pool: pool, table: table, qualified: schema.Qualify(table), behavior: TaggedArtifactStore, auditor: types.NoopAuditor}
+func NewStore(db DB, schema pkgdb.Schema, table string, opts ...StoreOption) *Store {
+	db = normalizeDB(db)
+	s := &Store{db: db, table: table, qualified: qualifyTable(db, schema, table), behavior: TaggedArtifactStore, auditor: types.NoopAuditor}
 	for _, opt := range opts {
 		opt(s)
 	}
@@ -129,8 +189,9 @@ func NewStore(pool *pgxpool.Pool, schema pkgdb.Schema, table string, opts ...Sto
 
 // NewMutableObjectStore constructs a mutable-object Store for tables keyed by
 // namespace/name in schema.
-func NewMutableObjectStore(pool *pgxpool.Pool, schema pkgdb.Schema, table string, opts ...StoreOption) *Store {
-	s := &Store{pool: pool, table: table, qualified: schema.Qualify(table), behavior: MutableObjectStore, auditor: types.NoopAuditor}
+func NewMutableObjectStore(db DB, schema pkgdb.Schema, table string, opts ...StoreOption) *Store {
+	db = normalizeDB(db)
+	s := &Store{db: db, table: table, qualified: qualifyTable(db, schema, table), behavior: MutableObjectStore, auditor: types.NoopAuditor}
 	for _, opt := range opts {
 		opt(s)
 	}
@@ -159,8 +220,13 @@ type UpsertResult struct {
 	UID string
 	// Generation is the server-managed row generation after the call.
 	Generation int64
+	// ResourceVersion is the row's metadata.resourceVersion after the call.
+	ResourceVersion string
 	// Outcome categorises what the call did. See UpsertOutcome constants.
 	Outcome UpsertOutcome
+	// ApprovalState is the review state of the written content when the
+	// call set UpsertOpts.RequestApproval; see the Approval* constants.
+	ApprovalState string
 }
 
 // UpsertOpts customizes create-time behavior for Store.Upsert.
@@ -168,6 +234,20 @@ type UpsertOpts struct {
 	// InitialFinalizers is applied only on the create path for mutable-object
 	// stores. Updates preserve existing finalizers.
 	InitialFinalizers []string
+	// ImmutableTag marks the tag being written as protected by the tag
+	// policy: on tagged-artifact stores, an existing row whose content
+	// differs is not replaced and Upsert returns ErrTagImmutable.
+	ImmutableTag bool
+	// OverwriteImmutableTag replaces an ImmutableTag row anyway. The
+	// caller is responsible for authorizing the override; the store
+	// reports each overwrite to the Auditor.
+	OverwriteImmutableTag bool
+	// RequestApproval puts new content written to a tagged-artifact store
+	// up for review in the same transaction, recording RequestedBy as the
+	// requester, so content never lands without its pending entry. The
+	// Store must keep approvals.
+	RequestApproval bool
+	RequestedBy     string
 }
 
 // ErrInvalidCursor reports that a list pagination cursor could not be parsed.
@@ -186,14 +266,27 @@ var ErrInvalidExtraWhere = errors.New("v1alpha1 store: ExtraWhere / ExtraArgs pl
 // recreate").
 var ErrTerminating = errors.New("v1alpha1 store: object is terminating")
 
+// ErrResourceVersionConflict reports that an Upsert carried a
+// metadata.resourceVersion that no longer matches the stored row: the
+// object changed (or was deleted) since the caller read it.
+var ErrResourceVersionConflict = errors.New("v1alpha1 store: resourceVersion conflict")
+
+// ErrTagImmutable reports that an Upsert would change the content of a tag
+// the tag policy protects. Publish the change under a new tag instead.
+var ErrTagImmutable = errors.New("v1alpha1 store: tag is immutable")
+
 // ListOpts controls paginated list queries.
 type ListOpts struct {
 	// Namespace narrows results to a specific namespace. Empty means "across
 	// all namespaces".
 	Namespace string
-	// LabelSelector narrows results to rows whose labels JSONB contains
-	// this subset (uses `@>` with a GIN index).
-	LabelSelector map[string]string
+	// LabelSelector narrows results to rows whose labels satisfy every
+	// requirement. Equality requirements use `@>` on the labels GIN index.
+	LabelSelector v1alpha1.LabelSelector
+	// FieldSelector narrows results by spec, status, name, namespace and
+	// tag values. A malformed selector fails List with an error wrapping
+	// v1alpha1.ErrInvalidSelector.
+	FieldSelector v1alpha1.FieldSelector
 	// Limit caps the number of rows returned. Zero means default (50).
 	Limit int
 	// Cursor is an opaque pagination token. Empty starts from the beginning.
@@ -256,10 +349,16 @@ type listCursor struct {
 //   - missing metadata.tag → default to the literal "latest" tag
 //   - new (namespace, name, tag) → insert the row
 //   - same tag and same canonical content hash → no-op
-//   - same tag and different content hash → replace the row in place
+//   - same tag and different content hash → replace the row in place,
+//     unless UpsertOpts.ImmutableTag protects it (ErrTagImmutable)
 //   - Mutable-object tables follow Kubernetes-like update-in-place
 //     semantics behind namespace/name key.
 //
+// A non-empty metadata.resourceVersion makes the write conditional on the
+// stored row still carrying that version; otherwise Upsert returns
+// ErrResourceVersionConflict. Tagged-artifact tables compare it against
+// the row for metadata.tag.
+//
 // Status is never touched by Upsert — use PatchStatus for that.
 func (s *Store) Upsert(ctx context.Context, obj v1alpha1.Object, opts ...UpsertOpts) (UpsertResult, error) {
 	if obj == nil {
@@ -282,21 +381,82 @@ func (s *Store) Upsert(ctx context.Context, obj v1alpha1.Object, opts ...UpsertO
 		opt = opts[0]
 	}
 
+	kind := s.kindFor(obj)
 	if s.behavior == TaggedArtifactStore {
-		res, err := s.upsertTagged(ctx, meta, specJSON)
+		res, event, err := s.upsertTagged(ctx, kind, meta, specJSON, opt)
 		if err != nil {
 			return res, err
 		}
-		// Fire the audit event AFTER the transaction commits. If the tx
-		// rolls back (err != nil above) the event is suppressed. Branch 2
-		// outcomes (UpsertNoOp, UpsertLabelsUpdated) do not introduce a
-		// new tag row, so they are not recorded.
-		if res.Outcome == UpsertCreated {
-			s.auditor.ResourceTagCreated(ctx, s.kindFor(obj), meta.Namespace, meta.Name, res.Tag)
+		// Fire the audit events AFTER the transaction commits. If the tx
+		// rolls back (err != nil above) the events are suppressed.
+		// UpsertNoOp writes nothing, so it is not recorded.
+		switch res.Outcome {
+		case UpsertCreated:
+			s.auditor.ResourceTagCreated(ctx, kind, meta.Namespace, meta.Name, res.Tag)
+		case UpsertReplaced:
+			if opt.ImmutableTag {
+				if a, ok := s.auditor.(types.TagOverwriteAuditor); ok {
+					a.ImmutableTagOverwritten(ctx, kind, meta.Namespace, meta.Name, res.Tag)
+				}
+			}
 		}
+		s.record(ctx, event)
 		return res, nil
 	}
-	return s.upsertMutable(ctx, meta, specJSON, opt)
+	res, event, err := s.upsertMutable(ctx, kind, meta, specJSON, opt)
+	if err != nil {
+		return res, err
+	}
+	s.record(ctx, event)
+	return res, nil
+}
+
+// upsertEvent returns the audit log entry of an upsert with outcome, or
+// the zero event for UpsertNoOp, which writes nothing.
+func upsertEvent(kind string, meta *v1alpha1.ObjectMeta, tag string, outcome UpsertOutcome, diff json.RawMessage) types.AuditEvent {
+	event := types.AuditEvent{Kind: kind, Namespace: meta.Namespace, Name: meta.Name, Tag: tag, Diff: diff}
+	switch outcome {
+	case UpsertCreated:
+		event.Verb = types.AuditVerbCreate
+	case UpsertReplaced:
+		event.Verb = types.AuditVerbUpdate
+	default:
+		return types.AuditEvent{}
+	}
+	return event
+}
+
+// audit appends event to the audit log through tx, so the entry commits or
+// rolls back with the change it describes. A zero event, or a Store
+// without an audit log, writes nothing.
+func (s *Store) audit(ctx context.Context, tx DB, event types.AuditEvent) error {
+	if s.auditLog == nil || event.Verb == "" {
+		return nil
+	}
+	entry := AuditEntry{
+		Verb:      event.Verb,
+		Kind:      event.Kind,
+		Namespace: event.Namespace,
+		Name:      event.Name,
+		Tag:       event.Tag,
+		Reason:    event.Reason,
+		Diff:      event.Diff,
+	}
+	if s.auditIdentity != nil {
+		entry.Principal, entry.RequestID = s.auditIdentity(ctx)
+	}
+	return s.auditLog.insert(ctx, tx, entry)
+}
+
+// record hands a committed audit log entry to the Auditor when it
+// implements types.AuditRecorder. A zero event is skipped.
+func (s *Store) record(ctx context.Context, event types.AuditEvent) {
+	if event.Verb == "" {
+		return
+	}
+	if r, ok := s.auditor.(types.AuditRecorder); ok {
+		r.Record(ctx, event)
+	}
 }
 
 // kindFor returns the canonical Kind name to attach to audit events.
@@ -311,26 +471,31 @@ func (s *Store) kindFor(obj v1alpha1.Object) string {
 }
 
 // upsertTagged implements the tag apply semantics for tagged artifact tables.
-// See Upsert for the full state machine.
-func (s *Store) upsertTagged(ctx context.Context, meta *v1alpha1.ObjectMeta, specJSON json.RawMessage) (UpsertResult, error) {
+// See Upsert for the full state machine. The returned event is the audit
+// log entry written with the change; it is zero for UpsertNoOp.
+func (s *Store) upsertTagged(ctx context.Context, kind string, meta *v1alpha1.ObjectMeta, specJSON json.RawMessage, opts UpsertOpts) (UpsertResult, types.AuditEvent, error) {
 	if meta.Tag == "" {
 		meta.Tag = DefaultTag()
 	}
 	incomingHash, err := ContentHash(meta, specJSON)
 	if err != nil {
-		return UpsertResult{}, fmt.Errorf("v1alpha1 store: content hash: %w", err)
+		return UpsertResult{}, types.AuditEvent{}, fmt.Errorf("v1alpha1 store: content hash: %w", err)
 	}
 	incomingLabelsJSON, err := canonicalJSONMap(meta.Labels)
 	if err != nil {
-		return UpsertResult{}, fmt.Errorf("v1alpha1 store: marshal labels: %w", err)
+		return UpsertResult{}, types.AuditEvent{}, fmt.Errorf("v1alpha1 store: marshal labels: %w", err)
 	}
 	incomingAnnotationsJSON, err := canonicalJSONMap(meta.Annotations)
 	if err != nil {
-		return UpsertResult{}, fmt.Errorf("v1alpha1 store: marshal annotations: %w", err)
+		return UpsertResult{}, types.AuditEvent{}, fmt.Errorf("v1alpha1 store: marshal annotations: %w", err)
 	}
 
-	var result UpsertResult
-	err = runInTx(ctx, s.pool, func(tx pgx.Tx) error {
+	var (
+		result                             UpsertResult
+		event                              types.AuditEvent
+		oldLabels, oldAnnotations, oldSpec []byte
+	)
+	err = runInTx(ctx, s.db, func(tx DB) error {
 		// Serialize concurrent applies for the same (namespace, name).
 		// `SELECT ... FOR UPDATE` is row-level and provides no gap-lock
 		// semantics: goroutines that see "no prior row" can all proceed
@@ -338,9 +503,12 @@ func (s *Store) upsertTagged(ctx context.Context, meta *v1alpha1.ObjectMeta, spe
 		// An advisory transaction lock serializes the entire
 		// (lookup, insert) decision per resource name. The lock auto-releases
 		// at COMMIT/ROLLBACK because we use pg_advisory_xact_lock.
-		key := s.advisoryLockKey(s.table, meta.Namespace, meta.Name)
-		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, key); err != nil {
-			return fmt.Errorf("advisory lock: %w", err)
+		// SQLite transactions already hold the database write lock.
+		if !isSQLite(s.db) {
+			key := s.advisoryLockKey(s.table, meta.Namespace, meta.Name)
+			if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, key); err != nil {
+				return fmt.Errorf("advisory lock: %w", err)
+			}
 		}
 
 		var (
@@ -348,15 +516,16 @@ func (s *Store) upsertTagged(ctx context.Context, meta *v1alpha1.ObjectMeta, spe
 			existingDeletionTS pgtype.Timestamptz
 			existingGeneration int64
 			existingUID        string
+			existingVersion    int64
 			found              bool
 		)
 		err := tx.QueryRow(ctx,
 			fmt.Sprintf(`
-						SELECT content_hash, deletion_timestamp, generation, uid::text
+						SELECT content_hash, deletion_timestamp, generation, uid::text, %s, labels, annotations, spec
 						FROM %s
 						WHERE namespace=$1 AND name=$2 AND tag=$3
-						FOR UPDATE`, s.qualified),
-			meta.Namespace, meta.Name, meta.Tag).Scan(&existingHash, &existingDeletionTS, &existingGeneration, &existingUID)
+						FOR UPDATE`, s.versionColumn(), s.qualified),
+			meta.Namespace, meta.Name, meta.Tag).Scan(&existingHash, &existingDeletionTS, &existingGeneration, &existingUID, &existingVersion, &oldLabels, &oldAnnotations, &oldSpec)
 		switch {
 		case err == nil:
 			found = true
@@ -371,76 +540,136 @@ func (s *Store) upsertTagged(ctx context.Context, meta *v1alpha1.ObjectMeta, spe
 		if found && existingDeletionTS.Valid {
 			return ErrTerminating
 		}
+		if err := checkResourceVersion(meta.ResourceVersion, found, existingVersion); err != nil {
+			return err
+		}
 
 		if !found {
-			var uid string
+			if opts.ImmutableTag && !opts.OverwriteImmutableTag {
+				if err := s.checkRetiredContent(ctx, tx, meta.Namespace, meta.Name, meta.Tag, incomingHash); err != nil {
+					return err
+				}
+			}
+			var (
+				uid     string
+				version int64
+			)
 			if err := tx.QueryRow(ctx,
 				fmt.Sprintf(`
 						INSERT INTO %s (namespace, name, tag, labels, annotations, spec, content_hash)
 						VALUES ($1, $2, $3, $4, $5, $6, $7)
-						RETURNING uid::text`, s.qualified),
-				meta.Namespace, meta.Name, meta.Tag, incomingLabelsJSON, incomingAnnotationsJSON, []byte(specJSON), incomingHash).Scan(&uid); err != nil {
+						RETURNING uid::text, %s`, s.qualified, s.versionColumn()),
+				meta.Namespace, meta.Name, meta.Tag, incomingLabelsJSON, incomingAnnotationsJSON, []byte(specJSON), incomingHash).Scan(&uid, &version); err != nil {
 				return fmt.Errorf("insert tag: %w", err)
 			}
-			result = UpsertResult{Tag: meta.Tag, UID: uid, Generation: 1, Outcome: UpsertCreated}
-			return nil
+			if err := s.recordRevision(ctx, tx, meta.Namespace, meta.Name, meta.Tag, incomingHash, incomingLabelsJSON, incomingAnnotationsJSON, specJSON); err != nil {
+				return err
+			}
+			result = UpsertResult{Tag: meta.Tag, UID: uid, Generation: 1, ResourceVersion: formatResourceVersion(version), Outcome: UpsertCreated}
+			event = upsertEvent(kind, meta, meta.Tag, UpsertCreated, auditDiff(nil, nil, nil, incomingLabelsJSON, incomingAnnotationsJSON, specJSON))
+			if err := s.audit(ctx, tx, event); err != nil {
+				return err
+			}
+			return s.publishApproval(ctx, tx, meta, incomingHash, opts, &result)
 		}
 
 		if incomingHash == existingHash {
-			result = UpsertResult{Tag: meta.Tag, UID: existingUID, Generation: existingGeneration, Outcome: UpsertNoOp}
-			return nil
+			result = UpsertResult{Tag: meta.Tag, UID: existingUID, Generation: existingGeneration, ResourceVersion: formatResourceVersion(existingVersion), Outcome: UpsertNoOp}
+			event = types.AuditEvent{}
+			return s.publishApproval(ctx, tx, meta, incomingHash, opts, &result)
+		}
+		if opts.ImmutableTag && !opts.OverwriteImmutableTag {
+			return fmt.Errorf("%w: %s/%s@%s already exists with different content; publish the change under a new tag",
+				ErrTagImmutable, meta.Namespace, meta.Name, meta.Tag)
+		}
+
+		// Record the outgoing content first: a tag last written before
+		// revision history was enabled has no revisions yet. Content the tag
+		// has held before keeps its revision number.
+		oldHash, err := storedContentHash(oldLabels, oldAnnotations, oldSpec)
+		if err != nil {
+			return fmt.Errorf("content hash: %w", err)
+		}
+		if err := s.recordRevision(ctx, tx, meta.Namespace, meta.Name, meta.Tag, oldHash, oldLabels, oldAnnotations, oldSpec); err != nil {
+			return err
+		}
+		if err := s.recordRevision(ctx, tx, meta.Namespace, meta.Name, meta.Tag, incomingHash, incomingLabelsJSON, incomingAnnotationsJSON, specJSON); err != nil {
+			return err
 		}
 
 		nextGeneration := existingGeneration + 1
-		var uid string
+		// Deprecation and yank markers judged the outgoing content, so
+		// new content under the tag starts unmarked.
+		clearMarkers := ""
+		if s.markers {
+			clearMarkers = ", deprecated=NULL, yanked=NULL"
+		}
+		var (
+			uid     string
+			version int64
+		)
 		if err := tx.QueryRow(ctx,
 			fmt.Sprintf(`
 						UPDATE %s
-						SET labels=$4, annotations=$5, spec=$6, content_hash=$7, generation=$8, status='{}'::jsonb, deletion_timestamp=NULL
+						SET labels=$4, annotations=$5, spec=$6, content_hash=$7, generation=$8, status='{}'::jsonb, deletion_timestamp=NULL%s
 						WHERE namespace=$1 AND name=$2 AND tag=$3
-						RETURNING uid::text`, s.qualified),
-			meta.Namespace, meta.Name, meta.Tag, incomingLabelsJSON, incomingAnnotationsJSON, []byte(specJSON), incomingHash, nextGeneration).Scan(&uid); err != nil {
+						RETURNING uid::text, %s`, s.qualified, clearMarkers, s.versionColumn()),
+			meta.Namespace, meta.Name, meta.Tag, incomingLabelsJSON, incomingAnnotationsJSON, []byte(specJSON), incomingHash, nextGeneration).Scan(&uid, &version); err != nil {
 			return fmt.Errorf("replace tag: %w", err)
 		}
-		result = UpsertResult{Tag: meta.Tag, UID: uid, Generation: nextGeneration, Outcome: UpsertReplaced}
-		return nil
+		if version, err = s.currentResourceVersion(ctx, tx, meta.Namespace, meta.Name, meta.Tag, version); err != nil {
+			return err
+		}
+		result = UpsertResult{Tag: meta.Tag, UID: uid, Generation: nextGeneration, ResourceVersion: formatResourceVersion(version), Outcome: UpsertReplaced}
+		event = upsertEvent(kind, meta, meta.Tag, UpsertReplaced, auditDiff(oldLabels, oldAnnotations, oldSpec, incomingLabelsJSON, incomingAnnotationsJSON, specJSON))
+		if opts.ImmutableTag {
+			event.Reason = "immutable tag overwritten by admin override"
+		}
+		if err := s.audit(ctx, tx, event); err != nil {
+			return err
+		}
+		return s.publishApproval(ctx, tx, meta, incomingHash, opts, &result)
 	})
 	if err != nil {
-		return UpsertResult{}, err
+		return UpsertResult{}, types.AuditEvent{}, err
 	}
-	return result, nil
+	return result, event, nil
 }
 
 // upsertMutable implements in-place semantics for mutable-object tables.
-func (s *Store) upsertMutable(ctx context.Context, meta *v1alpha1.ObjectMeta, specJSON json.RawMessage, opts UpsertOpts) (UpsertResult, error) {
+// The returned event is the audit log entry written with the change; it is
+// zero for UpsertNoOp.
+func (s *Store) upsertMutable(ctx context.Context, kind string, meta *v1alpha1.ObjectMeta, specJSON json.RawMessage, opts UpsertOpts) (UpsertResult, types.AuditEvent, error) {
 	labelsJSON, err := canonicalJSONMap(meta.Labels)
 	if err != nil {
-		return UpsertResult{}, fmt.Errorf("v1alpha1 store: marshal labels: %w", err)
+		return UpsertResult{}, types.AuditEvent{}, fmt.Errorf("v1alpha1 store: marshal labels: %w", err)
 	}
 	annotationsJSON, err := canonicalJSONMap(meta.Annotations)
 	if err != nil {
-		return UpsertResult{}, fmt.Errorf("v1alpha1 store: marshal annotations: %w", err)
+		return UpsertResult{}, types.AuditEvent{}, fmt.Errorf("v1alpha1 store: marshal annotations: %w", err)
 	}
 
-	var result UpsertResult
-	err = runInTx(ctx, s.pool, func(tx pgx.Tx) error {
+	var (
+		result                             UpsertResult
+		event                              types.AuditEvent
+		oldSpec, oldAnnotations, oldLabels []byte
+	)
+	err = runInTx(ctx, s.db, func(tx DB) error {
 		var (
-			oldSpec        []byte
-			oldGen         int64
-			oldFinalizers  []byte
-			oldAnnotations []byte
-			oldLabels      []byte
-			oldDeletion    pgtype.Timestamptz
-			oldUID         string
-			found          bool
+			oldGen        int64
+			oldFinalizers []byte
+			oldDeletion   pgtype.Timestamptz
+			oldUID        string
+			oldVersion    int64
+			found         bool
 		)
 		err := tx.QueryRow(ctx,
 			fmt.Sprintf(`
-					SELECT spec, generation, finalizers, annotations, labels, deletion_timestamp, uid::text
+					SELECT spec, generation, finalizers, annotations, labels, deletion_timestamp, uid::text, %s
 					FROM %s
 					WHERE namespace=$1 AND name=$2
-					FOR UPDATE`, s.qualified),
-			meta.Namespace, meta.Name).Scan(&oldSpec, &oldGen, &oldFinalizers, &oldAnnotations, &oldLabels, &oldDeletion, &oldUID)
+					FOR UPDATE`, s.versionColumn(), s.qualified),
+			meta.Namespace, meta.Name).Scan(&oldSpec, &oldGen, &oldFinalizers, &oldAnnotations, &oldLabels, &oldDeletion, &oldUID, &oldVersion)
 		switch {
 		case err == nil:
 			found = true
@@ -453,6 +682,9 @@ func (s *Store) upsertMutable(ctx context.Context, meta *v1alpha1.ObjectMeta, sp
 		if found && oldDeletion.Valid {
 			return ErrTerminating
 		}
+		if err := checkResourceVersion(meta.ResourceVersion, found, oldVersion); err != nil {
+			return err
+		}
 
 		var (
 			newGen  int64
@@ -487,7 +719,10 @@ func (s *Store) upsertMutable(ctx context.Context, meta *v1alpha1.ObjectMeta, sp
 			}
 		}
 
-		var uid string
+		var (
+			uid     string
+			version int64
+		)
 		err = tx.QueryRow(ctx,
 			fmt.Sprintf(`
 					INSERT INTO %s (namespace, name, generation, labels, annotations, spec, finalizers)
@@ -498,23 +733,56 @@ func (s *Store) upsertMutable(ctx context.Context, meta *v1alpha1.ObjectMeta, sp
 					    annotations = EXCLUDED.annotations,
 					    spec        = EXCLUDED.spec,
 					    finalizers  = EXCLUDED.finalizers
-					RETURNING uid::text
-				`, s.qualified),
-			meta.Namespace, meta.Name, newGen, labelsJSON, annotationsJSON, []byte(specJSON), finalizersJSON).Scan(&uid)
+					RETURNING uid::text, %s
+				`, s.qualified, s.versionColumn()),
+			meta.Namespace, meta.Name, newGen, labelsJSON, annotationsJSON, []byte(specJSON), finalizersJSON).Scan(&uid, &version)
 		if err != nil {
 			return fmt.Errorf("upsert row: %w", err)
 		}
+		if found {
+			if version, err = s.currentResourceVersion(ctx, tx, meta.Namespace, meta.Name, "", version); err != nil {
+				return err
+			}
+		}
 		if uid == "" {
 			uid = oldUID
 		}
 
-		result = UpsertResult{UID: uid, Generation: newGen, Outcome: outcome}
-		return nil
+		result = UpsertResult{UID: uid, Generation: newGen, ResourceVersion: formatResourceVersion(version), Outcome: outcome}
+		var diff json.RawMessage
+		if found {
+			diff = auditDiff(oldLabels, oldAnnotations, oldSpec, labelsJSON, annotationsJSON, specJSON)
+		} else {
+			diff = auditDiff(nil, nil, nil, labelsJSON, annotationsJSON, specJSON)
+		}
+		event = upsertEvent(kind, meta, "", outcome, diff)
+		return s.audit(ctx, tx, event)
 	})
 	if err != nil {
-		return UpsertResult{}, err
+		return UpsertResult{}, types.AuditEvent{}, err
 	}
-	return result, nil
+	return result, event, nil
+}
+
+// currentResourceVersion returns the resource_version of an updated row.
+// PostgreSQL assigns it in a BEFORE trigger, so returned is already
+// current; SQLite assigns it in an AFTER trigger that RETURNING does not
+// see, so the row is read back within tx.
+func (s *Store) currentResourceVersion(ctx context.Context, tx DB, namespace, name, tag string, returned int64) (int64, error) {
+	if !isSQLite(s.db) {
+		return returned, nil
+	}
+	query := fmt.Sprintf(`SELECT resource_version FROM %s WHERE namespace=$1 AND name=$2`, s.qualified)
+	args := []any{namespace, name}
+	if s.behavior == TaggedArtifactStore {
+		query += ` AND tag=$3`
+		args = append(args, tag)
+	}
+	var version int64
+	if err := tx.QueryRow(ctx, query, args...).Scan(&version); err != nil {
+		return 0, fmt.Errorf("read resource version: %w", err)
+	}
+	return version, nil
 }
 
 // PatchOpts bundles optional column mutations applied atomically by
@@ -545,7 +813,15 @@ func (s *Store) ApplyPatch(ctx context.Context, namespace, name, tag string, pat
 	if tag == "" && s.behavior == TaggedArtifactStore {
 		return errors.New("v1alpha1 store: tag is required")
 	}
-	return runInTx(ctx, s.pool, func(tx pgx.Tx) error {
+	// Annotation changes are audited; status, which controllers report,
+	// and finalizer bookkeeping are not.
+	auditTag := tag
+	if s.behavior == MutableObjectStore {
+		auditTag = ""
+	}
+	var event types.AuditEvent
+	err := runInTx(ctx, s.db, func(tx DB) error {
+		event = types.AuditEvent{}
 		statusJSON, annotationsJSON, finalizersJSON, err := s.loadPatchRow(ctx, tx, namespace, name, tag)
 		if err != nil {
 			return err
@@ -581,6 +857,10 @@ func (s *Store) ApplyPatch(ctx context.Context, namespace, name, tag string, pat
 			if !equalJSONMap(annotationsJSON, newJSON) {
 				args = append(args, newJSON)
 				setClauses = append(setClauses, fmt.Sprintf("annotations=$%d", len(args)))
+				event = types.AuditEvent{
+					Verb: types.AuditVerbUpdate, Kind: s.kind, Namespace: namespace, Name: name, Tag: auditTag,
+					Diff: auditDiff(nil, annotationsJSON, nil, nil, newJSON, nil),
+				}
 			}
 		}
 		if patch.Finalizers != nil {
@@ -603,15 +883,20 @@ func (s *Store) ApplyPatch(ctx context.Context, namespace, name, tag string, pat
 			args...); err != nil {
 			return fmt.Errorf("apply patch: %w", err)
 		}
-		return nil
+		return s.audit(ctx, tx, event)
 	})
+	if err != nil {
+		return err
+	}
+	s.record(ctx, event)
+	return nil
 }
 
 // loadPatchRow loads the columns ApplyPatch may mutate
 // (status, annotations, and on mutable-object stores finalizers) and returns
 // pkgdb.ErrNotFound if no row matches. The finalizers payload is empty
 // for tagged-artifact stores.
-func (s *Store) loadPatchRow(ctx context.Context, tx pgx.Tx, namespace, name, tag string) (statusJSON, annotationsJSON, finalizersJSON []byte, err error) {
+func (s *Store) loadPatchRow(ctx context.Context, tx DB, namespace, name, tag string) (statusJSON, annotationsJSON, finalizersJSON []byte, err error) {
 	if s.behavior == MutableObjectStore {
 		err = tx.QueryRow(ctx,
 			fmt.Sprintf(`
@@ -720,7 +1005,7 @@ func (s *Store) Get(ctx context.Context, namespace, name, tag string) (*v1alpha1
 		if tag == "" {
 			return nil, errors.New("v1alpha1 store: tag is required")
 		}
-		row := s.pool.QueryRow(ctx,
+		row := s.db.QueryRow(ctx,
 			fmt.Sprintf(`
 				SELECT %s
 				FROM %s
@@ -728,7 +1013,7 @@ func (s *Store) Get(ctx context.Context, namespace, name, tag string) (*v1alpha1
 			namespace, name, tag)
 		return scanRow(row, true)
 	}
-	row := s.pool.QueryRow(ctx,
+	row := s.db.QueryRow(ctx,
 		fmt.Sprintf(`
 			SELECT %s
 			FROM %s
@@ -740,7 +1025,8 @@ func (s *Store) Get(ctx context.Context, namespace, name, tag string) (*v1alpha1
 // GetByRef resolves the public reference shape shared by v1alpha1 resources.
 // Blank tag means the current live row: literal "latest" for tagged artifacts,
 // namespace/name for mutable objects. Non-empty tag selects a tagged artifact
-// row and is invalid for mutable-object stores.
+// row and is invalid for mutable-object stores; a semver range selects the
+// highest matching tag (see ResolveTag).
 func (s *Store) GetByRef(ctx context.Context, namespace, name, tag string) (*v1alpha1.RawObject, error) {
 	if tag == "" {
 		return s.GetLatest(ctx, namespace, name)
@@ -748,9 +1034,41 @@ func (s *Store) GetByRef(ctx context.Context, namespace, name, tag string) (*v1a
 	if s.behavior == MutableObjectStore {
 		return nil, errors.New("v1alpha1 store: tag pinning is not supported on mutable-object stores")
 	}
+	if v1alpha1.IsTagRange(tag) {
+		resolved, err := s.ResolveTag(ctx, namespace, name, tag)
+		if err != nil {
+			return nil, err
+		}
+		tag = resolved
+	}
 	return s.Get(ctx, namespace, name, tag)
 }
 
+// ResolveTag maps a ref tag to the concrete tag it selects. Literal tags are
+// returned unchanged; a semver range resolves to the highest live tag that
+// satisfies it and is not yanked. Returns pkgdb.ErrNotFound when no such
+// tag matches.
+func (s *Store) ResolveTag(ctx context.Context, namespace, name, tag string) (string, error) {
+	if !v1alpha1.IsTagRange(tag) {
+		return tag, nil
+	}
+	rows, err := s.ListTags(ctx, namespace, name)
+	if err != nil {
+		return "", err
+	}
+	tags := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if row.Metadata.Yanked == nil {
+			tags = append(tags, row.Metadata.Tag)
+		}
+	}
+	resolved, ok := v1alpha1.HighestMatchingTag(tag, tags)
+	if !ok {
+		return "", pkgdb.ErrNotFound
+	}
+	return resolved, nil
+}
+
 // GetLatest returns the literal "latest" live tag for (namespace, name) on
 // tagged-artifact tables, or the current live row for mutable-object stores.
 // Returns pkgdb.ErrNotFound if no live row exists.
@@ -762,7 +1080,7 @@ func (s *Store) GetLatest(ctx context.Context, namespace, name string) (*v1alpha
 			SELECT %s
 			FROM %s
 			WHERE namespace=$1 AND name=$2 AND tag=$3 AND deletion_timestamp IS NULL`, s.selectColumns(), s.qualified)
-		row := s.pool.QueryRow(ctx, query, namespace, name, DefaultTag())
+		row := s.db.QueryRow(ctx, query, namespace, name, DefaultTag())
 		return scanRow(row, true)
 	} else {
 		query = fmt.Sprintf(`
@@ -770,7 +1088,7 @@ func (s *Store) GetLatest(ctx context.Context, namespace, name string) (*v1alpha
 			FROM %s
 			WHERE namespace=$1 AND name=$2 AND deletion_timestamp IS NULL`, s.selectColumns(), s.qualified)
 	}
-	row := s.pool.QueryRow(ctx, query, namespace, name)
+	row := s.db.QueryRow(ctx, query, namespace, name)
 	return scanRow(row, false)
 }
 
@@ -787,14 +1105,14 @@ func (s *Store) GetLatestIncludingTerminating(ctx context.Context, namespace, na
 			SELECT %s
 			FROM %s
 			WHERE namespace=$1 AND name=$2 AND tag=$3`, s.selectColumns(), s.qualified)
-		row := s.pool.QueryRow(ctx, query, namespace, name, DefaultTag())
+		row := s.db.QueryRow(ctx, query, namespace, name, DefaultTag())
 		return scanRow(row, true)
 	}
 	query = fmt.Sprintf(`
 		SELECT %s
 		FROM %s
 		WHERE namespace=$1 AND name=$2`, s.selectColumns(), s.qualified)
-	row := s.pool.QueryRow(ctx, query, namespace, name)
+	row := s.db.QueryRow(ctx, query, namespace, name)
 	return scanRow(row, false)
 }
 
@@ -808,9 +1126,43 @@ func (s *Store) Delete(ctx context.Context, namespace, name, tag string) error {
 			return errors.New("v1alpha1 store: tag is required")
 		}
 		args := []any{namespace, name, tag}
-		return s.deleteTagged(ctx, args)
+		if err := s.deleteTagged(ctx, args, false); err != nil {
+			return err
+		}
+		s.record(ctx, s.deleteEvent(namespace, name, tag))
+		return nil
+	}
+	if err := s.deleteMutable(ctx, namespace, name); err != nil {
+		return err
+	}
+	s.record(ctx, s.deleteEvent(namespace, name, ""))
+	return nil
+}
+
+// DeleteRetainingHistory removes a tag row like Delete but keeps the tag's
+// revision history, current content included. Upsert with
+// UpsertOpts.ImmutableTag then refuses to re-create the tag with content
+// it never held, so deleting a protected tag does not free it for new
+// content. Tag retention prunes protected tags through it. Without
+// revision history it behaves like Delete. Tagged-artifact stores only.
+func (s *Store) DeleteRetainingHistory(ctx context.Context, namespace, name, tag string) error {
+	if s.behavior != TaggedArtifactStore {
+		return errors.New("v1alpha1 store: DeleteRetainingHistory is not supported on mutable-object stores")
+	}
+	if tag == "" {
+		return errors.New("v1alpha1 store: tag is required")
+	}
+	if err := s.deleteTagged(ctx, []any{namespace, name, tag}, true); err != nil {
+		return err
 	}
-	return s.deleteMutable(ctx, namespace, name)
+	s.record(ctx, s.deleteEvent(namespace, name, tag))
+	return nil
+}
+
+// deleteEvent returns the audit log entry of a Delete or DeleteAllTags.
+// tag is empty for mutable objects and for deletes of every tag.
+func (s *Store) deleteEvent(namespace, name, tag string) types.AuditEvent {
+	return types.AuditEvent{Verb: types.AuditVerbDelete, Kind: s.kind, Namespace: namespace, Name: name, Tag: tag}
 }
 
 // DeleteByRef applies the public reference/delete shape shared by v1alpha1
@@ -845,7 +1197,7 @@ func (s *Store) ListTags(ctx context.Context, namespace, name string) ([]*v1alph
 	if namespace == "" || name == "" {
 		return nil, errors.New("v1alpha1 store: namespace and name are required")
 	}
-	rows, err := s.pool.Query(ctx,
+	rows, err := s.db.Query(ctx,
 		fmt.Sprintf(`
 			SELECT %s
 			FROM %s
@@ -887,30 +1239,49 @@ func (s *Store) DeleteAllTags(ctx context.Context, namespace, name string) error
 	if namespace == "" || name == "" {
 		return errors.New("v1alpha1 store: namespace and name are required")
 	}
-	cmdTag, err := s.pool.Exec(ctx,
-		fmt.Sprintf(`
-			DELETE FROM %s
-			WHERE namespace=$1 AND name=$2`, s.qualified),
-		namespace, name)
+	err := runInTx(ctx, s.db, func(tx DB) error {
+		cmdTag, err := tx.Exec(ctx,
+			fmt.Sprintf(`
+				DELETE FROM %s
+				WHERE namespace=$1 AND name=$2`, s.qualified),
+			namespace, name)
+		if err != nil {
+			return fmt.Errorf("delete all tags: %w", err)
+		}
+		if cmdTag.RowsAffected() == 0 {
+			return pkgdb.ErrNotFound
+		}
+		if err := s.deleteRevisions(ctx, tx, namespace, name, ""); err != nil {
+			return err
+		}
+		if err := s.deleteSignatures(ctx, tx, namespace, name); err != nil {
+			return err
+		}
+		if err := s.deleteApprovals(ctx, tx, namespace, name); err != nil {
+			return err
+		}
+		return s.audit(ctx, tx, s.deleteEvent(namespace, name, ""))
+	})
 	if err != nil {
-		return fmt.Errorf("delete all tags: %w", err)
-	}
-	if cmdTag.RowsAffected() == 0 {
-		return pkgdb.ErrNotFound
+		return err
 	}
+	s.record(ctx, s.deleteEvent(namespace, name, ""))
 	return nil
 }
 
-func (s *Store) deleteTagged(ctx context.Context, args []any) error {
-	return runInTx(ctx, s.pool, func(tx pgx.Tx) error {
-		var deletionTS pgtype.Timestamptz
+func (s *Store) deleteTagged(ctx context.Context, args []any, retainHistory bool) error {
+	return runInTx(ctx, s.db, func(tx DB) error {
+		var (
+			deletionTS                pgtype.Timestamptz
+			labels, annotations, spec []byte
+		)
 		err := tx.QueryRow(ctx,
 			fmt.Sprintf(`
-				SELECT deletion_timestamp
+				SELECT deletion_timestamp, labels, annotations, spec
 				FROM %s
 				WHERE namespace=$1 AND name=$2 AND tag=$3
 				FOR UPDATE`, s.qualified),
-			args...).Scan(&deletionTS)
+			args...).Scan(&deletionTS, &labels, &annotations, &spec)
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
 				return pkgdb.ErrNotFound
@@ -927,12 +1298,27 @@ func (s *Store) deleteTagged(ctx context.Context, args []any) error {
 			args...); err != nil {
 			return fmt.Errorf("hard delete: %w", err)
 		}
-		return nil
+		namespace, name, tag := args[0].(string), args[1].(string), args[2].(string)
+		if retainHistory {
+			// A tag last written before revision history was enabled has
+			// no revisions yet.
+			hash, err := storedContentHash(labels, annotations, spec)
+			if err != nil {
+				return fmt.Errorf("content hash: %w", err)
+			}
+			if err := s.recordRevision(ctx, tx, namespace, name, tag, hash, labels, annotations, spec); err != nil {
+				return err
+			}
+		} else if err := s.deleteRevisions(ctx, tx, namespace, name, tag); err != nil {
+			return err
+		}
+		return s.audit(ctx, tx, s.deleteEvent(namespace, name, tag))
 	})
 }
 
 func (s *Store) deleteMutable(ctx context.Context, namespace, name string) error {
-	return runInTx(ctx, s.pool, func(tx pgx.Tx) error {
+	event := s.deleteEvent(namespace, name, "")
+	return runInTx(ctx, s.db, func(tx DB) error {
 		var (
 			finalizersRaw []byte
 			deletionTS    pgtype.Timestamptz
@@ -961,11 +1347,11 @@ func (s *Store) deleteMutable(ctx context.Context, namespace, name string) error
 				namespace, name); err != nil {
 				return fmt.Errorf("hard delete: %w", err)
 			}
-			return nil
+			return s.audit(ctx, tx, event)
 		}
 
 		if deletionTS.Valid {
-			return nil
+			return s.audit(ctx, tx, event)
 		}
 
 		if _, err := tx.Exec(ctx,
@@ -974,7 +1360,7 @@ func (s *Store) deleteMutable(ctx context.Context, namespace, name string) error
 			namespace, name); err != nil {
 			return fmt.Errorf("mark terminating: %w", err)
 		}
-		return nil
+		return s.audit(ctx, tx, event)
 	})
 }
 
@@ -1005,7 +1391,7 @@ func (s *Store) PurgeFinalized(ctx context.Context) (int64, error) {
 			WHERE deletion_timestamp IS NOT NULL
 			  AND finalizers = '[]'::jsonb`, s.qualified)
 	}
-	cmdTag, err := s.pool.Exec(ctx, query)
+	cmdTag, err := s.db.Exec(ctx, query)
 	if err != nil {
 		return 0, fmt.Errorf("purge finalized: %w", err)
 	}
@@ -1045,14 +1431,11 @@ func (s *Store) List(ctx context.Context, opts ListOpts) ([]*v1alpha1.RawObject,
 	if !opts.IncludeTerminating {
 		where = append(where, "deletion_timestamp IS NULL")
 	}
-	if len(opts.LabelSelector) > 0 {
-		labelJSON, err := json.Marshal(opts.LabelSelector)
-		if err != nil {
-			return nil, "", fmt.Errorf("marshal labels: %w", err)
-		}
-		args = append(args, labelJSON)
-		where = append(where, fmt.Sprintf("labels @> $%d", len(args)))
+	selectors, args, err := s.selectorPredicates(opts.LabelSelector, opts.FieldSelector, args)
+	if err != nil {
+		return nil, "", err
 	}
+	where = append(where, selectors...)
 	if opts.Cursor != "" {
 		cursor, err := s.decodeListCursor(opts.Cursor)
 		if err != nil {
@@ -1097,7 +1480,7 @@ func (s *Store) List(ctx context.Context, opts ListOpts) ([]*v1alpha1.RawObject,
 	args = append(args, limit+1)
 	query += fmt.Sprintf(" ORDER BY %s LIMIT $%d", s.listOrderBy(), len(args))
 
-	rows, err := s.pool.Query(ctx, query, args...)
+	rows, err := s.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, "", fmt.Errorf("list: %w", err)
 	}
@@ -1222,16 +1605,33 @@ type FindReferrersOpts struct {
 	// IncludeTerminating, when true, keeps rows whose deletion_timestamp
 	// is set. Default (false) excludes them.
 	IncludeTerminating bool
+	// Mentions, when non-empty, restricts results to rows whose spec
+	// holds this exact string value at any depth. It is a cheap prefilter
+	// for callers that decode the candidates' refs themselves.
+	Mentions string
 }
 
 // FindReferrers returns rows from this Store's table whose spec JSONB
-// matches pathJSON (via the `@>` containment operator).
+// matches pathJSON (via the `@>` containment operator). A nil pathJSON
+// matches every spec, leaving the filtering to opts.
 func (s *Store) FindReferrers(ctx context.Context, pathJSON json.RawMessage, opts FindReferrersOpts) ([]*v1alpha1.RawObject, error) {
-	args := []any{[]byte(pathJSON)}
+	var args []any
 	query := fmt.Sprintf(`
 		SELECT %s
 		FROM %s
-		WHERE spec @> $1::jsonb`, s.selectColumns(), s.qualified)
+		WHERE true`, s.selectColumns(), s.qualified)
+	if pathJSON != nil {
+		args = append(args, []byte(pathJSON))
+		query += fmt.Sprintf(" AND spec @> $%d::jsonb", len(args))
+	}
+	if opts.Mentions != "" {
+		args = append(args, opts.Mentions)
+		if isSQLite(s.db) {
+			query += fmt.Sprintf(" AND json_mentions(spec, $%d)", len(args))
+		} else {
+			query += fmt.Sprintf(" AND jsonb_path_exists(spec, '$.** ? (@ == $v)', jsonb_build_object('v', $%d::text))", len(args))
+		}
+	}
 	if !opts.IncludeTerminating {
 		query += " AND deletion_timestamp IS NULL"
 	}
@@ -1247,7 +1647,7 @@ func (s *Store) FindReferrers(ctx context.Context, pathJSON json.RawMessage, opt
 	}
 	query += " ORDER BY updated_at DESC"
 
-	rows, err := s.pool.Query(ctx, query, args...)
+	rows, err := s.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("find referrers: %w", err)
 	}
@@ -1267,14 +1667,30 @@ func (s *Store) FindReferrers(ctx context.Context, pathJSON json.RawMessage, opt
 // selectColumns returns the column list emitted by Get/List/FindReferrers
 // queries. Mutable-object tables include generation/finalizers columns;
 // tagged-artifact tables emit synthetic placeholders for them so scanRow's
-// column layout stays uniform.
+// column layout stays uniform. Tables without tag markers likewise emit
+// NULL deprecated/yanked columns, and unversioned tables a 0
+// resource_version.
 func (s *Store) selectColumns() string {
+	markers := `NULL::jsonb AS deprecated, NULL::jsonb AS yanked`
+	if s.markers {
+		markers = `deprecated, yanked`
+	}
 	if s.behavior == TaggedArtifactStore {
 		return `namespace, name, tag, uid::text, generation, labels, annotations, spec, status,
-		       deletion_timestamp, '[]'::jsonb AS finalizers, created_at, updated_at`
+		       deletion_timestamp, '[]'::jsonb AS finalizers, created_at, updated_at, ` + s.versionColumn() + `, ` + markers
 	}
 	return `namespace, name, ''::text AS tag, uid::text, generation, labels, annotations, spec, status,
-		       deletion_timestamp, finalizers, created_at, updated_at`
+		       deletion_timestamp, finalizers, created_at, updated_at, ` + s.versionColumn() + `, ` + markers
+}
+
+// versionColumn is the resource_version column, or a 0 placeholder on
+// unversioned tables. No revision is 0, so formatResourceVersion renders
+// it as no version at all.
+func (s *Store) versionColumn() string {
+	if s.unversioned {
+		return `0::bigint AS resource_version`
+	}
+	return `resource_version`
 }
 
 // canonicalJSONMap renders m to canonical JSON suitable for an
@@ -0,0 +1,147 @@
+//go:build integration
+
+package v1alpha1store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+func TestStore_UpsertRejectsStaleResourceVersion(t *testing.T) {
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
+	ctx := context.Background()
+
+	created := upsertAgent(t, store, "foo", v1alpha1.AgentSpec{Title: "alpha"}, nil)
+	require.NotEmpty(t, created.ResourceVersion)
+
+	obj, err := store.Get(ctx, testNS, "foo", DefaultTag())
+	require.NoError(t, err)
+	require.Equal(t, created.ResourceVersion, obj.Metadata.ResourceVersion)
+
+	// A write carrying the current version succeeds and bumps it.
+	updated, err := store.Upsert(ctx, &v1alpha1.Agent{
+		Metadata: v1alpha1.ObjectMeta{Namespace: testNS, Name: "foo", ResourceVersion: created.ResourceVersion},
+		Spec:     v1alpha1.AgentSpec{Title: "beta"},
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, created.ResourceVersion, updated.ResourceVersion)
+
+	// Replaying the old version is a conflict and leaves the row alone.
+	_, err = store.Upsert(ctx, &v1alpha1.Agent{
+		Metadata: v1alpha1.ObjectMeta{Namespace: testNS, Name: "foo", ResourceVersion: created.ResourceVersion},
+		Spec:     v1alpha1.AgentSpec{Title: "gamma"},
+	})
+	require.ErrorIs(t, err, ErrResourceVersionConflict)
+
+	obj, err = store.Get(ctx, testNS, "foo", DefaultTag())
+	require.NoError(t, err)
+	require.Equal(t, updated.ResourceVersion, obj.Metadata.ResourceVersion)
+
+	// Writes without a version stay unconditional.
+	_, err = store.Upsert(ctx, &v1alpha1.Agent{
+		Metadata: v1alpha1.ObjectMeta{Namespace: testNS, Name: "foo"},
+		Spec:     v1alpha1.AgentSpec{Title: "delta"},
+	})
+	require.NoError(t, err)
+}
+
+func TestStore_UpsertMutableRejectsStaleResourceVersion(t *testing.T) {
+	db := NewTestDB(t)
+	runtimes := NewMutableObjectStore(db, TestSchema(), "runtimes")
+	ctx := context.Background()
+
+	created, err := runtimes.Upsert(ctx, &v1alpha1.Runtime{
+		Metadata: v1alpha1.ObjectMeta{Namespace: testNS, Name: "custom"},
+		Spec:     v1alpha1.RuntimeSpec{Type: v1alpha1.TypeKubernetes},
+	})
+	require.NoError(t, err)
+
+	_, err = runtimes.Upsert(ctx, &v1alpha1.Runtime{
+		Metadata: v1alpha1.ObjectMeta{Namespace: testNS, Name: "custom", Labels: map[string]string{"team": "a"}},
+		Spec:     v1alpha1.RuntimeSpec{Type: v1alpha1.TypeKubernetes},
+	})
+	require.NoError(t, err)
+
+	_, err = runtimes.Upsert(ctx, &v1alpha1.Runtime{
+		Metadata: v1alpha1.ObjectMeta{Namespace: testNS, Name: "custom", Labels: map[string]string{"team": "b"}, ResourceVersion: created.ResourceVersion},
+		Spec:     v1alpha1.RuntimeSpec{Type: v1alpha1.TypeKubernetes},
+	})
+	require.ErrorIs(t, err, ErrResourceVersionConflict)
+
+	// A version for an object that does not exist is a conflict too.
+	_, err = runtimes.Upsert(ctx, &v1alpha1.Runtime{
+		Metadata: v1alpha1.ObjectMeta{Namespace: testNS, Name: "missing", ResourceVersion: created.ResourceVersion},
+		Spec:     v1alpha1.RuntimeSpec{Type: v1alpha1.TypeKubernetes},
+	})
+	require.ErrorIs(t, err, ErrResourceVersionConflict)
+}
+
+func TestStore_PatchStatusKeepsResourceVersion(t *testing.T) {
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
+	ctx := context.Background()
+
+	created := upsertAgent(t, store, "foo", v1alpha1.AgentSpec{Title: "alpha"}, nil)
+	err := store.PatchStatus(ctx, testNS, "foo", DefaultTag(), v1alpha1.StatusPatcher(func(s *v1alpha1.Status) {
+		s.SetCondition(v1alpha1.Condition{Type: "Ready", Status: v1alpha1.ConditionTrue, Reason: "Converged"})
+	}))
+	require.NoError(t, err)
+
+	obj, err := store.Get(ctx, testNS, "foo", DefaultTag())
+	require.NoError(t, err)
+	require.Equal(t, created.ResourceVersion, obj.Metadata.ResourceVersion)
+}
+
+func TestStore_ResourceVersionMatchesEventRevision(t *testing.T) {
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
+	ctx := context.Background()
+
+	res := upsertAgent(t, store, "foo", v1alpha1.AgentSpec{Title: "alpha"}, nil)
+
+	var revision string
+	require.NoError(t, db.QueryRow(ctx,
+		`SELECT max(revision)::text FROM control_plane_events WHERE kind = 'Agent' AND name = 'foo'`,
+	).Scan(&revision))
+	require.Equal(t, res.ResourceVersion, revision)
+}
+
+func TestStore_UnversionedTableServesUnconditionalWrites(t *testing.T) {
+	pool := NewTestPool(t)
+	ctx := context.Background()
+
+	// An extension table created without migration 016's column.
+	_, err := pool.Exec(ctx, `CREATE TABLE `+TestSchema().Qualify("widgets")+` (LIKE `+TestSchema().Qualify(testTable)+` INCLUDING DEFAULTS INCLUDING CONSTRAINTS)`)
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, `ALTER TABLE `+TestSchema().Qualify("widgets")+` DROP COLUMN resource_version`)
+	require.NoError(t, err)
+
+	versioned, err := HasResourceVersions(ctx, pool, TestSchema(), testTable)
+	require.NoError(t, err)
+	require.True(t, versioned)
+	versioned, err = HasResourceVersions(ctx, pool, TestSchema(), "widgets")
+	require.NoError(t, err)
+	require.False(t, versioned)
+
+	store := NewStore(pool, TestSchema(), "widgets", WithoutResourceVersions())
+	created := upsertAgent(t, store, "foo", v1alpha1.AgentSpec{Title: "alpha"}, nil)
+	require.Empty(t, created.ResourceVersion)
+
+	replaced := upsertAgent(t, store, "foo", v1alpha1.AgentSpec{Title: "beta"}, nil)
+	require.Equal(t, UpsertReplaced, replaced.Outcome)
+
+	obj, err := store.Get(ctx, testNS, "foo", DefaultTag())
+	require.NoError(t, err)
+	require.Empty(t, obj.Metadata.ResourceVersion)
+
+	_, err = store.Upsert(ctx, &v1alpha1.Agent{
+		Metadata: v1alpha1.ObjectMeta{Namespace: testNS, Name: "foo", ResourceVersion: "1"},
+		Spec:     v1alpha1.AgentSpec{Title: "gamma"},
+	})
+	require.ErrorIs(t, err, ErrResourceVersionConflict)
+}
@@ -0,0 +1,58 @@
+//go:build integration
+
+package v1alpha1store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+func TestStore_SearchRanksHighlightsAndFacets(t *testing.T) {
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
+	ctx := context.Background()
+
+	upsertAgent(t, store, "weather-bot", v1alpha1.AgentSpec{
+		Title:       "Weather",
+		Description: "Answers forecast questions.",
+	}, map[string]string{"team": "climate"})
+	upsertAgent(t, store, "travel-planner", v1alpha1.AgentSpec{
+		Description: "Plans trips and checks the weather at the destination.",
+	}, map[string]string{"team": "travel"})
+	upsertAgent(t, store, "billing", v1alpha1.AgentSpec{
+		Description: "Explains invoices.",
+	}, map[string]string{"team": "finance"})
+
+	res, err := store.Search(ctx, SearchOpts{Query: "weather", LatestOnly: true})
+	require.NoError(t, err)
+	require.Equal(t, 2, res.Total)
+	require.Len(t, res.Hits, 2)
+	require.Equal(t, "weather-bot", res.Hits[0].Object.Metadata.Name, "title and name matches outrank description matches")
+	require.Greater(t, res.Hits[0].Rank, res.Hits[1].Rank)
+	require.Contains(t, res.Hits[1].Highlight, SearchHighlightStart+"weather"+SearchHighlightStop)
+	require.Equal(t, map[string]map[string]int{"team": {"climate": 1, "travel": 1}}, res.LabelFacets)
+
+	res, err = store.Search(ctx, SearchOpts{Query: "finance"})
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Total, "label values are searchable")
+
+	res, err = store.Search(ctx, SearchOpts{Query: "weather -trips", Limit: 1})
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Total)
+
+	res, err = store.Search(ctx, SearchOpts{
+		Query:      "weather",
+		ExtraWhere: "name = $1",
+		ExtraArgs:  []any{"travel-planner"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Total)
+	require.Equal(t, "travel-planner", res.Hits[0].Object.Metadata.Name)
+
+	_, err = store.Search(ctx, SearchOpts{Query: "weather", ExtraWhere: "name = $1"})
+	require.ErrorIs(t, err, ErrInvalidExtraWhere)
+}
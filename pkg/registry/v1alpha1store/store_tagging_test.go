@@ -4,6 +4,7 @@ package v1alpha1store_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"sync"
@@ -34,8 +35,8 @@ func taggedAgentObj(name, tag, title string, labels map[string]string) *v1alpha1
 
 func setupAgentStore(t *testing.T) *v1alpha1store.Store {
 	t.Helper()
-	pool := v1alpha1store.NewTestPool(t)
-	return v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
+	db := v1alpha1store.NewTestDB(t)
+	return v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
 }
 
 func TestUpsert_NewName_DefaultsTagLatest(t *testing.T) {
@@ -188,10 +189,10 @@ func TestDelete_LatestTagDoesNotPromoteAnotherTag(t *testing.T) {
 // branch — tag stays latest, outcome is UpsertNoOp — proving same-tag dedupe
 // is durable across Store lifetimes.
 func TestUpsert_IdempotentAcrossRestarts(t *testing.T) {
-	pool := v1alpha1store.NewTestPool(t)
+	db := v1alpha1store.NewTestDB(t)
 	ctx := context.Background()
 
-	s1 := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
+	s1 := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
 	res1, err := s1.Upsert(ctx, agentObj("foo", "model-a", nil))
 	require.NoError(t, err)
 	require.Equal(t, v1alpha1store.DefaultTag(), res1.Tag)
@@ -199,7 +200,7 @@ func TestUpsert_IdempotentAcrossRestarts(t *testing.T) {
 
 	// Simulate a restart: drop s1, build a fresh Store against the same
 	// underlying database. Re-applying the same spec must dedupe to no-op.
-	s2 := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
+	s2 := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents")
 	res2, err := s2.Upsert(ctx, agentObj("foo", "model-a", nil))
 	require.NoError(t, err)
 	require.Equal(t, res1.Tag, res2.Tag)
@@ -208,8 +209,8 @@ func TestUpsert_IdempotentAcrossRestarts(t *testing.T) {
 
 func setupAgentStoreWithAuditor(t *testing.T, a types.Auditor) *v1alpha1store.Store {
 	t.Helper()
-	pool := v1alpha1store.NewTestPool(t)
-	return v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents",
+	db := v1alpha1store.NewTestDB(t)
+	return v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents",
 		v1alpha1store.WithKind(v1alpha1.KindAgent),
 		v1alpha1store.WithAuditor(a),
 	)
@@ -217,8 +218,8 @@ func setupAgentStoreWithAuditor(t *testing.T, a types.Auditor) *v1alpha1store.St
 
 func setupProviderStoreWithAuditor(t *testing.T, a types.Auditor) *v1alpha1store.Store {
 	t.Helper()
-	pool := v1alpha1store.NewTestPool(t)
-	return v1alpha1store.NewMutableObjectStore(pool, v1alpha1store.TestSchema(), "runtimes",
+	db := v1alpha1store.NewTestDB(t)
+	return v1alpha1store.NewMutableObjectStore(db, v1alpha1store.TestSchema(), "runtimes",
 		v1alpha1store.WithKind(v1alpha1.KindRuntime),
 		v1alpha1store.WithAuditor(a),
 	)
@@ -277,3 +278,89 @@ func TestUpsert_AuditorNotCalledForMutableObjectKinds(t *testing.T) {
 	require.NoError(t, err)
 	require.Empty(t, auditor.Events(), "mutable-object kinds must not emit ResourceTagCreated")
 }
+
+func TestUpsert_ImmutableTagRejectsChangedContent(t *testing.T) {
+	store := setupAgentStore(t)
+	ctx := context.Background()
+	immutable := v1alpha1store.UpsertOpts{ImmutableTag: true}
+
+	res, err := store.Upsert(ctx, taggedAgentObj("foo", "1.0.0", "model-a", nil), immutable)
+	require.NoError(t, err)
+	require.Equal(t, v1alpha1store.UpsertCreated, res.Outcome)
+
+	// Re-applying identical content stays a no-op.
+	res, err = store.Upsert(ctx, taggedAgentObj("foo", "1.0.0", "model-a", nil), immutable)
+	require.NoError(t, err)
+	require.Equal(t, v1alpha1store.UpsertNoOp, res.Outcome)
+
+	_, err = store.Upsert(ctx, taggedAgentObj("foo", "1.0.0", "model-b", nil), immutable)
+	require.ErrorIs(t, err, v1alpha1store.ErrTagImmutable)
+	require.ErrorContains(t, err, "default/foo@1.0.0")
+
+	got, err := store.Get(ctx, "default", "foo", "1.0.0")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), got.Metadata.Generation, "a rejected overwrite must leave the tag untouched")
+}
+
+func TestUpsert_ImmutableTagOverwriteIsAudited(t *testing.T) {
+	auditor := &typestest.RecordingAuditor{}
+	store := setupAgentStoreWithAuditor(t, auditor)
+	ctx := context.Background()
+
+	_, err := store.Upsert(ctx, taggedAgentObj("foo", "1.0.0", "model-a", nil), v1alpha1store.UpsertOpts{ImmutableTag: true})
+	require.NoError(t, err)
+	require.Empty(t, auditor.Overwrites())
+
+	res, err := store.Upsert(ctx, taggedAgentObj("foo", "1.0.0", "model-b", nil),
+		v1alpha1store.UpsertOpts{ImmutableTag: true, OverwriteImmutableTag: true})
+	require.NoError(t, err)
+	require.Equal(t, v1alpha1store.UpsertReplaced, res.Outcome)
+	require.Equal(t, []typestest.ResourceTagEvent{{Kind: v1alpha1.KindAgent, Namespace: "default", Name: "foo", Tag: "1.0.0"}}, auditor.Overwrites())
+
+	// Replacing a mutable tag is ordinary and not audited as an override.
+	_, err = store.Upsert(ctx, taggedAgentObj("foo", "stable", "model-a", nil))
+	require.NoError(t, err)
+	_, err = store.Upsert(ctx, taggedAgentObj("foo", "stable", "model-b", nil))
+	require.NoError(t, err)
+	require.Len(t, auditor.Overwrites(), 1)
+}
+
+// TestStore_RecordsAuditLog walks one tag through every audited write and
+// checks the verb and diff the Store reports for each.
+func TestStore_RecordsAuditLog(t *testing.T) {
+	auditor := &typestest.RecordingAuditor{}
+	store := setupAgentStoreWithAuditor(t, auditor)
+	ctx := context.Background()
+
+	_, err := store.Upsert(ctx, agentObj("foo", "model-a", nil))
+	require.NoError(t, err)
+	_, err = store.Upsert(ctx, agentObj("foo", "model-a", nil))
+	require.NoError(t, err)
+	_, err = store.Upsert(ctx, agentObj("foo", "model-b", map[string]string{"env": "prod"}))
+	require.NoError(t, err)
+	require.NoError(t, store.PatchStatus(ctx, "default", "foo", "latest", func(json.RawMessage) (json.RawMessage, error) {
+		return json.RawMessage(`{"phase":"Ready"}`), nil
+	}))
+	require.NoError(t, store.PatchAnnotations(ctx, "default", "foo", "latest", func(a map[string]string) map[string]string {
+		a["owner"] = "alice"
+		return a
+	}))
+	require.NoError(t, store.Delete(ctx, "default", "foo", "latest"))
+
+	records := auditor.Records()
+	require.Len(t, records, 4, "the no-op re-apply and the status patch are not recorded")
+	verbs := make([]string, 0, len(records))
+	for _, r := range records {
+		require.Equal(t, v1alpha1.KindAgent, r.Kind)
+		require.Equal(t, "foo", r.Name)
+		require.Equal(t, "latest", r.Tag)
+		verbs = append(verbs, r.Verb)
+	}
+	require.Equal(t, []string{
+		types.AuditVerbCreate, types.AuditVerbUpdate, types.AuditVerbUpdate, types.AuditVerbDelete,
+	}, verbs)
+	require.JSONEq(t, `{"spec":{"title":"model-a"}}`, string(records[0].Diff))
+	require.JSONEq(t, `{"metadata":{"labels":{"env":"prod"}},"spec":{"title":"model-b"}}`, string(records[1].Diff))
+	require.JSONEq(t, `{"metadata":{"annotations":{"owner":"alice"}}}`, string(records[2].Diff))
+	require.Empty(t, records[3].Diff)
+}
@@ -33,8 +33,8 @@ func upsertAgent(t *testing.T, store *Store, name string, spec v1alpha1.AgentSpe
 }
 
 func TestStore_UpsertCreatesRow(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 	ctx := context.Background()
 
 	res, err := store.Upsert(ctx, &v1alpha1.Agent{
@@ -56,8 +56,8 @@ func TestStore_UpsertCreatesRow(t *testing.T) {
 // TestStore_UpsertNoOpOnIdenticalSpec verifies the new apply-branch
 // semantics: same spec_hash + same labels/annotations is a no-op.
 func TestStore_UpsertNoOpOnIdenticalSpec(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 
 	upsertAgent(t, store, "foo", v1alpha1.AgentSpec{Title: "alpha"}, nil)
 	res := upsertAgent(t, store, "foo", v1alpha1.AgentSpec{Title: "alpha"}, nil)
@@ -68,8 +68,8 @@ func TestStore_UpsertNoOpOnIdenticalSpec(t *testing.T) {
 // TestStore_UpsertReplacesLatestOnSpecChange verifies that a changed payload
 // for the same default tag atomically replaces the previous latest row.
 func TestStore_UpsertReplacesLatestOnSpecChange(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 
 	upsertAgent(t, store, "foo", v1alpha1.AgentSpec{Title: "first"}, nil)
 	res := upsertAgent(t, store, "foo", v1alpha1.AgentSpec{Title: "second"}, nil)
@@ -84,8 +84,8 @@ func TestStore_UpsertReplacesLatestOnSpecChange(t *testing.T) {
 // TestStore_GetLatestReadsLiteralLatestTag verifies that GetLatest returns the
 // row tagged "latest", not the newest or lexicographically highest tag.
 func TestStore_GetLatestReadsLiteralLatestTag(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 
 	for _, tag := range []string{"stable", "candidate"} {
 		_, err := store.Upsert(context.Background(), &v1alpha1.Agent{
@@ -102,8 +102,8 @@ func TestStore_GetLatestReadsLiteralLatestTag(t *testing.T) {
 }
 
 func TestStore_GetByRefResolvesBlankTagToLatest(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 	ctx := context.Background()
 
 	_, err := store.Upsert(ctx, &v1alpha1.Agent{
@@ -122,9 +122,39 @@ func TestStore_GetByRefResolvesBlankTagToLatest(t *testing.T) {
 	require.Equal(t, "stable", stable.Metadata.Tag)
 }
 
+func TestStore_GetByRefResolvesSemverRange(t *testing.T) {
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
+	ctx := context.Background()
+
+	for _, tag := range []string{"1.2.0", "1.10.0", "2.0.0-rc.1", "stable"} {
+		_, err := store.Upsert(ctx, &v1alpha1.Agent{
+			Metadata: v1alpha1.ObjectMeta{Namespace: testNS, Name: "foo", Tag: tag},
+			Spec:     v1alpha1.AgentSpec{Title: tag},
+		})
+		require.NoError(t, err)
+	}
+	upsertAgent(t, store, "foo", v1alpha1.AgentSpec{Title: "current"}, nil)
+
+	obj, err := store.GetByRef(ctx, testNS, "foo", "^1.2")
+	require.NoError(t, err)
+	require.Equal(t, "1.10.0", obj.Metadata.Tag)
+
+	resolved, err := store.ResolveTag(ctx, testNS, "foo", ">=1.0 <2")
+	require.NoError(t, err)
+	require.Equal(t, "1.10.0", resolved)
+
+	resolved, err = store.ResolveTag(ctx, testNS, "foo", "stable")
+	require.NoError(t, err)
+	require.Equal(t, "stable", resolved, "literal tags resolve to themselves")
+
+	_, err = store.GetByRef(ctx, testNS, "foo", "^2")
+	require.ErrorIs(t, err, pkgdb.ErrNotFound, "prereleases do not satisfy a plain range")
+}
+
 func TestStore_GetByRefMutableRejectsTag(t *testing.T) {
-	pool := NewTestPool(t)
-	runtimes := NewMutableObjectStore(pool, TestSchema(), "runtimes")
+	db := NewTestDB(t)
+	runtimes := NewMutableObjectStore(db, TestSchema(), "runtimes")
 	ctx := context.Background()
 
 	kubernetes, err := runtimes.GetByRef(ctx, testNS, "kubernetes-default", "")
@@ -136,8 +166,8 @@ func TestStore_GetByRefMutableRejectsTag(t *testing.T) {
 }
 
 func TestStore_DeleteByRefTaggedBlankDeletesAllTags(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 	ctx := context.Background()
 
 	_, err := store.Upsert(ctx, &v1alpha1.Agent{
@@ -155,8 +185,8 @@ func TestStore_DeleteByRefTaggedBlankDeletesAllTags(t *testing.T) {
 }
 
 func TestStore_DeleteByRefMutableDeletesByName(t *testing.T) {
-	pool := NewTestPool(t)
-	runtimes := NewMutableObjectStore(pool, TestSchema(), "runtimes")
+	db := NewTestDB(t)
+	runtimes := NewMutableObjectStore(db, TestSchema(), "runtimes")
 	ctx := context.Background()
 
 	_, err := runtimes.Upsert(ctx, &v1alpha1.Runtime{
@@ -174,8 +204,8 @@ func TestStore_DeleteByRefMutableDeletesByName(t *testing.T) {
 }
 
 func TestStore_PatchStatusDisjointFromSpec(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 	ctx := context.Background()
 
 	upsertAgent(t, store, "foo", v1alpha1.AgentSpec{Title: "alpha"}, nil)
@@ -197,8 +227,8 @@ func TestStore_PatchStatusDisjointFromSpec(t *testing.T) {
 }
 
 func TestStore_PatchStatusNotFound(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 	ctx := context.Background()
 
 	err := store.PatchStatus(ctx, testNS, "nope", "1", v1alpha1.StatusPatcher(func(*v1alpha1.Status) {}))
@@ -210,8 +240,8 @@ func TestStore_PatchStatusNotFound(t *testing.T) {
 // deployment discovery) re-patch on every poll, and an unconditional UPDATE
 // would churn updated_at and WAL with no content change.
 func TestStore_ApplyPatchSkipsUnchangedWrites(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 	ctx := context.Background()
 
 	upsertAgent(t, store, "steady", v1alpha1.AgentSpec{Title: "alpha"}, nil)
@@ -223,7 +253,7 @@ func TestStore_ApplyPatchSkipsUnchangedWrites(t *testing.T) {
 
 	updatedAt := func() time.Time {
 		var ts time.Time
-		require.NoError(t, pool.QueryRow(ctx, fmt.Sprintf(
+		require.NoError(t, db.QueryRow(ctx, fmt.Sprintf(
 			`SELECT updated_at FROM %s WHERE namespace=$1 AND name=$2 AND tag=$3`, store.qualified),
 			testNS, "steady", DefaultTag()).Scan(&ts))
 		return ts
@@ -240,8 +270,8 @@ func TestStore_ApplyPatchSkipsUnchangedWrites(t *testing.T) {
 }
 
 func TestStore_GetNotFound(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 	ctx := context.Background()
 
 	_, err := store.Get(ctx, testNS, "nope", "1")
@@ -256,8 +286,8 @@ func TestStore_GetNotFound(t *testing.T) {
 // hard-deletes immediately. arctl delete + arctl apply works without any
 // background GC pass.
 func TestStore_DeleteHardDeletesTaggedRow(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 	ctx := context.Background()
 
 	_, err := store.Upsert(ctx, &v1alpha1.Agent{
@@ -292,8 +322,8 @@ func TestStore_DeleteHardDeletesTaggedRow(t *testing.T) {
 }
 
 func TestStore_List(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 	ctx := context.Background()
 
 	_, err := store.Upsert(ctx, &v1alpha1.Agent{
@@ -320,11 +350,11 @@ func TestStore_List(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, teamA, 2)
 
-	ownerX, _, err := store.List(ctx, ListOpts{LabelSelector: map[string]string{"owner": "x"}})
+	ownerX, _, err := store.List(ctx, ListOpts{LabelSelector: v1alpha1.LabelSelectorFromMap(map[string]string{"owner": "x"})})
 	require.NoError(t, err)
 	require.Len(t, ownerX, 2)
 
-	teamAOwnerX, _, err := store.List(ctx, ListOpts{Namespace: "team-a", LabelSelector: map[string]string{"owner": "x"}})
+	teamAOwnerX, _, err := store.List(ctx, ListOpts{Namespace: "team-a", LabelSelector: v1alpha1.LabelSelectorFromMap(map[string]string{"owner": "x"})})
 	require.NoError(t, err)
 	require.Len(t, teamAOwnerX, 1)
 
@@ -336,8 +366,8 @@ func TestStore_List(t *testing.T) {
 }
 
 func TestStore_ListExtraWhereRebasesPlaceholders(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 	ctx := context.Background()
 
 	for _, name := range []string{"a", "b", "c"} {
@@ -378,8 +408,8 @@ func TestStore_ListExtraWhereRebasesPlaceholders(t *testing.T) {
 // count doesn't match the arg count, rather than silently executing a
 // wrong query.
 func TestStore_ListExtraWhereRejectsMismatch(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 	ctx := context.Background()
 
 	cases := []struct {
@@ -412,8 +442,8 @@ func TestStore_ListExtraWhereRejectsMismatch(t *testing.T) {
 }
 
 func TestStore_ListCursorPagination(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 	ctx := context.Background()
 
 	for _, name := range []string{"first", "second", "third"} {
@@ -439,8 +469,8 @@ func TestStore_ListCursorPagination(t *testing.T) {
 }
 
 func TestStore_ListRejectsInvalidCursor(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 
 	_, _, err := store.List(context.Background(), ListOpts{Cursor: "not-a-valid-cursor"})
 	require.ErrorIs(t, err, ErrInvalidCursor)
@@ -451,8 +481,8 @@ func TestStore_ListRejectsInvalidCursor(t *testing.T) {
 // rather than updated_at DESC: a row whose updated_at moves under a
 // concurrent PatchStatus must not jump pages or get returned twice.
 func TestStore_ListCursorStableUnderStatusChurn(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 	ctx := context.Background()
 
 	names := []string{"alpha", "beta", "gamma", "delta"} // lexical order: alpha, beta, delta, gamma
@@ -487,8 +517,8 @@ func TestStore_ListCursorStableUnderStatusChurn(t *testing.T) {
 }
 
 func TestStore_PatchAnnotationsPreservesExistingKeys(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
 	ctx := context.Background()
 
 	_, err := store.Upsert(ctx, &v1alpha1.Agent{
@@ -512,8 +542,8 @@ func TestStore_PatchAnnotationsPreservesExistingKeys(t *testing.T) {
 }
 
 func TestStore_FindReferrers(t *testing.T) {
-	pool := NewTestPool(t)
-	agents := NewStore(pool, TestSchema(), "agents")
+	db := NewTestDB(t)
+	agents := NewStore(db, TestSchema(), "agents")
 	ctx := context.Background()
 
 	_, err := agents.Upsert(ctx, &v1alpha1.Agent{
@@ -541,12 +571,22 @@ func TestStore_FindReferrers(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, results, 1)
 	require.Equal(t, "refs-bar", results[0].Metadata.Name)
+
+	// Mentions matches the name at any depth, without a pattern.
+	results, err = agents.FindReferrers(ctx, nil, FindReferrersOpts{Mentions: "baz"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "refs-baz", results[0].Metadata.Name)
+
+	results, err = agents.FindReferrers(ctx, nil, FindReferrersOpts{Mentions: "ba"})
+	require.NoError(t, err)
+	require.Empty(t, results)
 }
 
 func TestStore_SeededRuntimes(t *testing.T) {
-	pool := NewTestPool(t)
+	db := NewTestDB(t)
 	// Runtime is a mutable object keyed by namespace/name.
-	runtimes := NewMutableObjectStore(pool, TestSchema(), "runtimes")
+	runtimes := NewMutableObjectStore(db, TestSchema(), "runtimes")
 	ctx := context.Background()
 
 	var spec v1alpha1.RuntimeSpec
@@ -603,9 +643,9 @@ func TestStore_NotifyPayloadDiscreteFields(t *testing.T) {
 }
 
 func TestStore_ControlPlaneEventsTrackSourceWritesOnly(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
-	events := NewControlPlaneEventStore(pool, TestSchema())
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
+	events := NewControlPlaneEventStore(db, TestSchema())
 	ctx := context.Background()
 
 	_, err := store.Upsert(ctx, &v1alpha1.Agent{
@@ -651,8 +691,8 @@ func TestStore_ControlPlaneEventsTrackSourceWritesOnly(t *testing.T) {
 }
 
 func TestStore_ControlPlaneEventTracksResolvedSourceStatusChanges(t *testing.T) {
-	pool := NewTestPool(t)
-	events := NewControlPlaneEventStore(pool, TestSchema())
+	db := NewTestDB(t)
+	events := NewControlPlaneEventStore(db, TestSchema())
 	ctx := context.Background()
 
 	tests := []struct {
@@ -668,7 +708,7 @@ func TestStore_ControlPlaneEventTracksResolvedSourceStatusChanges(t *testing.T)
 			name:    "plugin",
 			kind:    v1alpha1.KindPlugin,
 			rowName: "plugin",
-			store:   NewStore(pool, TestSchema(), "plugins"),
+			store:   NewStore(db, TestSchema(), "plugins"),
 			upsert: func(t *testing.T, store *Store) {
 				t.Helper()
 				_, err := store.Upsert(ctx, &v1alpha1.Plugin{
@@ -716,7 +756,7 @@ func TestStore_ControlPlaneEventTracksResolvedSourceStatusChanges(t *testing.T)
 			name:    "skill",
 			kind:    v1alpha1.KindSkill,
 			rowName: "skill",
-			store:   NewStore(pool, TestSchema(), "skills"),
+			store:   NewStore(db, TestSchema(), "skills"),
 			upsert: func(t *testing.T, store *Store) {
 				t.Helper()
 				_, err := store.Upsert(ctx, &v1alpha1.Skill{
@@ -796,15 +836,15 @@ func TestStore_ControlPlaneEventTracksResolvedSourceStatusChanges(t *testing.T)
 }
 
 func TestControlPlaneEventStore_PruneBeforeHonorsKeepAfterRevision(t *testing.T) {
-	pool := NewTestPool(t)
-	store := NewStore(pool, TestSchema(), testTable)
-	events := NewControlPlaneEventStore(pool, TestSchema())
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable)
+	events := NewControlPlaneEventStore(db, TestSchema())
 	ctx := context.Background()
 
 	// Migrations may legitimately emit control-plane invalidations. This test
 	// owns its event fixture, so discard bootstrap history before asserting
 	// exact prune counts.
-	_, err := pool.Exec(ctx, `TRUNCATE `+events.qualified+` RESTART IDENTITY`)
+	_, err := db.Exec(ctx, `DELETE FROM `+events.qualified)
 	require.NoError(t, err)
 
 	upsertAgent(t, store, "pruned", v1alpha1.AgentSpec{Title: "first"}, nil)
@@ -828,9 +868,9 @@ func TestControlPlaneEventStore_PruneBeforeHonorsKeepAfterRevision(t *testing.T)
 // TestStore_ModelTaggedArtifactCRUD covers Model's tagged storage: distinct
 // configuration tags coexist and each write records a control-plane event.
 func TestStore_ModelTaggedArtifactCRUD(t *testing.T) {
-	pool := NewTestPool(t)
-	models := NewStore(pool, TestSchema(), "models")
-	events := NewControlPlaneEventStore(pool, TestSchema())
+	db := NewTestDB(t)
+	models := NewStore(db, TestSchema(), "models")
+	events := NewControlPlaneEventStore(db, TestSchema())
 	ctx := context.Background()
 
 	_, err := models.Upsert(ctx, &v1alpha1.Model{
@@ -888,3 +928,28 @@ func TestStore_ModelTaggedArtifactCRUD(t *testing.T) {
 	require.True(t, seenTags["approved-v1"], "expected a control-plane event for approved-v1")
 	require.True(t, seenTags["approved-v2"], "expected a control-plane event for approved-v2")
 }
+
+func TestStore_UpsertRequestsApprovalInTransaction(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	store := NewStore(db, TestSchema(), testTable, WithApprovals(TestSchema()))
+	agent := func(tag string) *v1alpha1.Agent {
+		return &v1alpha1.Agent{
+			Metadata: v1alpha1.ObjectMeta{Namespace: testNS, Name: "held", Tag: tag},
+			Spec:     v1alpha1.AgentSpec{Title: tag},
+		}
+	}
+
+	res, err := store.Upsert(ctx, agent("1.0.0"), UpsertOpts{RequestApproval: true, RequestedBy: "alice"})
+	require.NoError(t, err)
+	require.Equal(t, ApprovalPending, res.ApprovalState)
+	_, err = store.GetUsableByRef(ctx, testNS, "held", "1.0.0")
+	require.ErrorIs(t, err, ErrUnapproved)
+
+	_, err = db.Exec(ctx, `DROP TABLE `+store.approvals)
+	require.NoError(t, err)
+	_, err = store.Upsert(ctx, agent("1.1.0"), UpsertOpts{RequestApproval: true, RequestedBy: "alice"})
+	require.Error(t, err)
+	_, err = store.Get(ctx, testNS, "held", "1.1.0")
+	require.ErrorIs(t, err, pkgdb.ErrNotFound, "content is never stored without its pending review")
+}
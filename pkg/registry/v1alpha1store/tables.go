@@ -3,8 +3,6 @@ package v1alpha1store
 import (
 	"strings"
 
-	"github.com/jackc/pgx/v5/pgxpool"
-
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 )
@@ -21,6 +19,7 @@ var builtInKinds = map[string]struct{}{
 	v1alpha1.KindRuntime:    {},
 	v1alpha1.KindModel:      {},
 	v1alpha1.KindDeployment: {},
+	v1alpha1.KindNamespace:  {},
 }
 
 // NewStores builds one *Store per OSS built-in v1alpha1 Kind, bound to its
@@ -30,14 +29,17 @@ var builtInKinds = map[string]struct{}{
 //
 // Kinds whose descriptors use KindStorageMutableObject are bound through
 // NewMutableObjectStore. Every other built-in kind uses NewStore
-// (tagged-artifact behavior). Extension kinds are intentionally not built here;
-// the composition root wires them from V1Alpha1StoreTables after this function
-// returns.
+// (tagged-artifact behavior), keeps revision history, signatures and
+// approvals in the OSS schema's tag_revisions, artifact_signatures and
+// artifact_approvals tables, and reads tag markers from the kind tables'
+// deprecated and yanked columns. Extension kinds are intentionally not built
+// here; the composition root wires them from V1Alpha1StoreTables after this
+// function returns.
 //
 // The variadic opts are applied to every Store produced. Downstream
 // callers pass WithAuditor(...) here to plumb a single audit sink
 // across all kinds in one call.
-func NewStores(pool *pgxpool.Pool, schemas *pkgdb.SchemaRegistry, opts ...StoreOption) map[string]*Store {
+func NewStores(db DB, schemas *pkgdb.SchemaRegistry, opts ...StoreOption) map[string]*Store {
 	// The OSS source's schema is statically known to be registered by the
 	// composition root before stores are built; a missing entry is a
 	// wiring bug, so MustGet panics rather than returning a nil schema
@@ -59,10 +61,10 @@ func NewStores(pool *pgxpool.Pool, schemas *pkgdb.SchemaRegistry, opts ...StoreO
 		// option chain).
 		kindOpts := append([]StoreOption{WithKind(kind)}, opts...)
 		if descriptor.Storage == v1alpha1.KindStorageMutableObject {
-			out[kind] = NewMutableObjectStore(pool, ossSchema, table, kindOpts...)
+			out[kind] = NewMutableObjectStore(db, ossSchema, table, kindOpts...)
 			continue
 		}
-		out[kind] = NewStore(pool, ossSchema, table, kindOpts...)
+		out[kind] = NewStore(db, ossSchema, table, append([]StoreOption{WithRevisionHistory(ossSchema), WithSignatures(ossSchema), WithApprovals(ossSchema), WithTagMarkers()}, kindOpts...)...)
 	}
 	for kind := range builtInKinds {
 		if _, ok := out[kind]; !ok {
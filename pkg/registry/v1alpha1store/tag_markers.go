@@ -0,0 +1,177 @@
+package v1alpha1store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+var (
+	// ErrTagMarkersDisabled reports a deprecation or yank write on a Store
+	// built without WithTagMarkers.
+	ErrTagMarkersDisabled = errors.New("v1alpha1 store: tag markers are not enabled")
+	// ErrYanked reports a reference that selects only yanked tags: a
+	// yanked "latest", or a semver range every matching tag of which is
+	// yanked.
+	ErrYanked = errors.New("yanked")
+)
+
+// WithTagMarkers reads and writes the deprecated and yanked columns of the
+// table (see migrations/023_tag_markers.up.sql). Ignored on mutable-object
+// stores. NewStores enables it for every built-in tagged kind; extension
+// tables opt in by adding the columns and passing it.
+func WithTagMarkers() StoreOption {
+	return func(s *Store) {
+		if s.behavior == TaggedArtifactStore {
+			s.markers = true
+		}
+	}
+}
+
+// TagMarkers reports whether the Store keeps deprecation and yank markers.
+func (s *Store) TagMarkers() bool {
+	return s != nil && s.markers
+}
+
+// SetDeprecation marks tag of (namespace, name) deprecated, replacing any
+// earlier deprecation, and returns the marked row. A nil d clears the
+// marker. DeprecatedAt is stamped by the Store. Returns pkgdb.ErrNotFound
+// when the tag does not exist.
+func (s *Store) SetDeprecation(ctx context.Context, namespace, name, tag string, d *v1alpha1.Deprecation) (*v1alpha1.RawObject, error) {
+	verb, reason := types.AuditVerbUndeprecate, ""
+	var value any
+	if d != nil {
+		marked := *d
+		marked.DeprecatedAt = time.Now().UTC()
+		raw, err := json.Marshal(marked)
+		if err != nil {
+			return nil, fmt.Errorf("v1alpha1 store: marshal deprecation: %w", err)
+		}
+		value, verb, reason = raw, types.AuditVerbDeprecate, d.Message
+	}
+	return s.setTagMarker(ctx, "deprecated", namespace, name, tag, value, verb, reason)
+}
+
+// SetYank yanks tag of (namespace, name) and returns the yanked row. A nil
+// y un-yanks it. YankedAt is stamped by the Store. Returns
+// pkgdb.ErrNotFound when the tag does not exist.
+func (s *Store) SetYank(ctx context.Context, namespace, name, tag string, y *v1alpha1.Yank) (*v1alpha1.RawObject, error) {
+	verb, reason := types.AuditVerbUnyank, ""
+	var value any
+	if y != nil {
+		marked := *y
+		marked.YankedAt = time.Now().UTC()
+		raw, err := json.Marshal(marked)
+		if err != nil {
+			return nil, fmt.Errorf("v1alpha1 store: marshal yank: %w", err)
+		}
+		value, verb, reason = raw, types.AuditVerbYank, y.Reason
+	}
+	return s.setTagMarker(ctx, "yanked", namespace, name, tag, value, verb, reason)
+}
+
+// setTagMarker writes value (JSON, or nil for NULL) to the marker column
+// of one live tag and audits the change under verb.
+func (s *Store) setTagMarker(ctx context.Context, column, namespace, name, tag string, value any, verb, reason string) (*v1alpha1.RawObject, error) {
+	if !s.TagMarkers() {
+		return nil, ErrTagMarkersDisabled
+	}
+	if namespace == "" || name == "" || tag == "" {
+		return nil, errors.New("v1alpha1 store: namespace, name and tag are required")
+	}
+	event := types.AuditEvent{
+		Verb: verb, Kind: s.kind, Namespace: namespace, Name: name, Tag: tag,
+		Reason: reason,
+	}
+	var obj *v1alpha1.RawObject
+	err := runInTx(ctx, s.db, func(tx DB) error {
+		var err error
+		obj, err = scanRow(tx.QueryRow(ctx,
+			fmt.Sprintf(`
+				UPDATE %s
+				SET %s=$4
+				WHERE namespace=$1 AND name=$2 AND tag=$3 AND deletion_timestamp IS NULL
+				RETURNING %s`, s.qualified, column, s.selectColumns()),
+			namespace, name, tag, value), true)
+		if err != nil {
+			return err
+		}
+		return s.audit(ctx, tx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.record(ctx, event)
+	return obj, nil
+}
+
+// GetUsableByRef is GetByRef for reference resolution: it ignores tags
+// that refs may not select. Tags whose content awaits review or was
+// rejected are never selected; a literal one returns an error wrapping
+// ErrUnapproved. Yanked tags are skipped by semver ranges and no longer
+// count as "latest", which returns an error wrapping ErrYanked when it is
+// yanked; any other literal tag still resolves when yanked, so existing
+// pins keep working.
+func (s *Store) GetUsableByRef(ctx context.Context, namespace, name, tag string) (*v1alpha1.RawObject, error) {
+	if !s.Approvals() && !s.TagMarkers() {
+		return s.GetByRef(ctx, namespace, name, tag)
+	}
+	if !v1alpha1.IsTagRange(tag) {
+		obj, err := s.GetByRef(ctx, namespace, name, tag)
+		if err != nil {
+			return nil, err
+		}
+		state, err := s.rowApprovalState(ctx, obj)
+		if err != nil {
+			return nil, err
+		}
+		if state != ApprovalApproved {
+			return nil, fmt.Errorf("%w: %s %s/%s:%s is %s", ErrUnapproved, s.kind, namespace, name, obj.Metadata.Tag, describeApprovalState(state))
+		}
+		if obj.Metadata.Yanked != nil && obj.Metadata.Tag == DefaultTag() {
+			return nil, fmt.Errorf("%w: %s %s/%s:%s", ErrYanked, s.kind, namespace, name, obj.Metadata.Tag)
+		}
+		return obj, nil
+	}
+
+	// ListTags returns yanked tags too: ResolveTag's filter is applied
+	// here alongside approval so the error can say which one excluded a
+	// match.
+	rows, err := s.ListTags(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	var all, approved, usable []string
+	byTag := make(map[string]*v1alpha1.RawObject, len(rows))
+	for _, row := range rows {
+		all = append(all, row.Metadata.Tag)
+		state, err := s.rowApprovalState(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if state != ApprovalApproved {
+			continue
+		}
+		approved = append(approved, row.Metadata.Tag)
+		if row.Metadata.Yanked == nil {
+			usable = append(usable, row.Metadata.Tag)
+			byTag[row.Metadata.Tag] = row
+		}
+	}
+	if resolved, ok := v1alpha1.HighestMatchingTag(tag, usable); ok {
+		return byTag[resolved], nil
+	}
+	if _, ok := v1alpha1.HighestMatchingTag(tag, approved); ok {
+		return nil, fmt.Errorf("%w: every tag of %s %s/%s matching %s", ErrYanked, s.kind, namespace, name, tag)
+	}
+	if _, ok := v1alpha1.HighestMatchingTag(tag, all); ok {
+		return nil, fmt.Errorf("%w: no approved tag of %s %s/%s matches %s", ErrUnapproved, s.kind, namespace, name, tag)
+	}
+	return nil, pkgdb.ErrNotFound
+}
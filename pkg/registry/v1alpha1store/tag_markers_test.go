@@ -0,0 +1,105 @@
+//go:build integration
+
+package v1alpha1store_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+func TestTagMarkers_SetClearResolve(t *testing.T) {
+	ctx := context.Background()
+	db := v1alpha1store.NewTestDB(t)
+	store := v1alpha1store.NewStore(db, v1alpha1store.TestSchema(), "agents", v1alpha1store.WithTagMarkers())
+	require.True(t, store.TagMarkers())
+
+	for _, tag := range []string{"1.0.0", "1.1.0", "latest"} {
+		_, err := store.Upsert(ctx, taggedAgentObj("alice", tag, "v"+tag, nil))
+		require.NoError(t, err)
+	}
+
+	deprecated, err := store.SetDeprecation(ctx, "default", "alice", "1.0.0", &v1alpha1.Deprecation{
+		Message:     "use 1.1",
+		Replacement: &v1alpha1.ResourceRef{Kind: v1alpha1.KindAgent, Namespace: "default", Name: "alice", Tag: "1.1.0"},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, deprecated.Metadata.Deprecated)
+	require.Equal(t, "use 1.1", deprecated.Metadata.Deprecated.Message)
+	require.False(t, deprecated.Metadata.Deprecated.DeprecatedAt.IsZero())
+	require.Nil(t, deprecated.Metadata.Yanked)
+
+	// Markers are read back on every path and do not bump the revision.
+	got, err := store.Get(ctx, "default", "alice", "1.0.0")
+	require.NoError(t, err)
+	require.NotNil(t, got.Metadata.Deprecated)
+	require.Equal(t, "1.1.0", got.Metadata.Deprecated.Replacement.Tag)
+	require.Equal(t, deprecated.Metadata.Generation, got.Metadata.Generation)
+
+	// A deprecated tag still resolves; a yanked one is skipped by ranges.
+	_, err = store.SetYank(ctx, "default", "alice", "1.1.0", &v1alpha1.Yank{Reason: "broken"})
+	require.NoError(t, err)
+	resolved, err := store.ResolveTag(ctx, "default", "alice", "^1.0")
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0", resolved)
+	row, err := store.GetUsableByRef(ctx, "default", "alice", "^1.0")
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0", row.Metadata.Tag)
+	row, err = store.GetUsableByRef(ctx, "default", "alice", "1.1.0")
+	require.NoError(t, err, "an exact pin to a yanked tag still resolves")
+	require.NotNil(t, row.Metadata.Yanked)
+
+	_, err = store.SetYank(ctx, "default", "alice", "1.0.0", &v1alpha1.Yank{})
+	require.NoError(t, err)
+	_, err = store.GetUsableByRef(ctx, "default", "alice", "^1.0")
+	require.ErrorIs(t, err, v1alpha1store.ErrYanked)
+
+	// A yanked latest no longer counts as latest.
+	_, err = store.SetYank(ctx, "default", "alice", "latest", &v1alpha1.Yank{Reason: "bad"})
+	require.NoError(t, err)
+	_, err = store.GetUsableByRef(ctx, "default", "alice", "")
+	require.ErrorIs(t, err, v1alpha1store.ErrYanked)
+
+	// Clearing a marker restores resolution.
+	cleared, err := store.SetYank(ctx, "default", "alice", "1.1.0", nil)
+	require.NoError(t, err)
+	require.Nil(t, cleared.Metadata.Yanked)
+	resolved, err = store.ResolveTag(ctx, "default", "alice", "^1.0")
+	require.NoError(t, err)
+	require.Equal(t, "1.1.0", resolved)
+
+	// Republishing a tag with new content clears its markers; the same
+	// content keeps them.
+	_, err = store.Upsert(ctx, taggedAgentObj("alice", "latest", "vlatest", nil))
+	require.NoError(t, err)
+	got, err = store.Get(ctx, "default", "alice", "latest")
+	require.NoError(t, err)
+	require.NotNil(t, got.Metadata.Yanked)
+	_, err = store.Upsert(ctx, taggedAgentObj("alice", "latest", "fixed", nil))
+	require.NoError(t, err)
+	got, err = store.Get(ctx, "default", "alice", "latest")
+	require.NoError(t, err)
+	require.Nil(t, got.Metadata.Yanked)
+
+	_, err = store.SetDeprecation(ctx, "default", "alice", "9.9.9", &v1alpha1.Deprecation{})
+	require.ErrorIs(t, err, pkgdb.ErrNotFound)
+}
+
+func TestTagMarkers_DisabledStore(t *testing.T) {
+	ctx := context.Background()
+	store := setupAgentStore(t)
+	require.False(t, store.TagMarkers())
+
+	_, err := store.Upsert(ctx, taggedAgentObj("alice", "1.0.0", "one", nil))
+	require.NoError(t, err)
+	_, err = store.SetYank(ctx, "default", "alice", "1.0.0", &v1alpha1.Yank{})
+	require.ErrorIs(t, err, v1alpha1store.ErrTagMarkersDisabled)
+	got, err := store.Get(ctx, "default", "alice", "1.0.0")
+	require.NoError(t, err)
+	require.Nil(t, got.Metadata.Yanked)
+}
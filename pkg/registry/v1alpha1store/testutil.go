@@ -11,6 +11,7 @@ import (
 	"log/slog"
 	"net/url"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -77,10 +78,32 @@ func NewTestPool(t *testing.T) *pgxpool.Pool {
 	return pool
 }
 
+// NewTestDB is NewTestPool for tests that only go through the stores: it
+// returns a fresh PostgreSQL database, or with
+// AGENT_REGISTRY_TEST_STORAGE=sqlite a fresh SQLite database file, so the
+// same suite covers both backends.
+func NewTestDB(t *testing.T) DB {
+	t.Helper()
+	if !testStorageSQLite() {
+		return NewTestPool(t)
+	}
+	db, err := OpenSQLite(context.Background(), filepath.Join(t.TempDir(), "registry.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func testStorageSQLite() bool {
+	return os.Getenv("AGENT_REGISTRY_TEST_STORAGE") == "sqlite"
+}
+
 // NewTestPoolWithDSN is NewTestPool with a caller-supplied admin DSN
 // (URL-form, postgres://...). Also returns the per-test database's DSN.
 func NewTestPoolWithDSN(t *testing.T, adminDSN string) (pool *pgxpool.Pool, testDSN string) {
 	t.Helper()
+	if testStorageSQLite() {
+		t.Skip("requires PostgreSQL; AGENT_REGISTRY_TEST_STORAGE=sqlite")
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
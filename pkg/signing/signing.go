@@ -0,0 +1,153 @@
+// Package signing creates and checks detached signatures over registry
+// content digests with offline key pairs.
+//
+// The signed payload is the digest string itself ("sha256:<hex>", see
+// v1alpha1store.ContentDigest). Ed25519 keys sign it directly; ECDSA keys
+// sign its SHA-256 and encode the signature as ASN.1 DER, which is what
+// `cosign sign-blob --key` produces over a file holding the digest, so
+// cosign key pairs work unchanged.
+//
+// Public keys are PEM "PUBLIC KEY" blocks (PKIX). Private keys are
+// unencrypted PEM "PRIVATE KEY" (PKCS #8) or "EC PRIVATE KEY" (SEC 1)
+// blocks; cosign's encrypted private keys are signed with cosign itself.
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidSignature reports a signature that does not verify against
+// the public key and digest it was checked with.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// ParsePublicKey decodes the first PEM "PUBLIC KEY" block of data into an
+// Ed25519 or ECDSA public key.
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	if block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("unsupported PEM block %q: want PUBLIC KEY", block.Type)
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse public key: %w", err)
+	}
+	switch pub.(type) {
+	case ed25519.PublicKey, *ecdsa.PublicKey:
+		return pub, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T: want Ed25519 or ECDSA", pub)
+	}
+}
+
+// ParsePrivateKey decodes the first PEM private key block of data into an
+// Ed25519 or ECDSA signer.
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	var (
+		key any
+		err error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		if strings.Contains(block.Type, "ENCRYPTED") {
+			return nil, fmt.Errorf("encrypted private keys (%s) are not supported; sign with cosign sign-blob and pass the signature instead", block.Type)
+		}
+		return nil, fmt.Errorf("unsupported PEM block %q: want PRIVATE KEY or EC PRIVATE KEY", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %w", err)
+	}
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		return k, nil
+	case *ecdsa.PrivateKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T: want Ed25519 or ECDSA", key)
+	}
+}
+
+// MarshalPublicKey encodes pub as a PEM "PUBLIC KEY" block.
+func MarshalPublicKey(pub crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", fmt.Errorf("marshal public key: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// KeyID identifies pub by the hex SHA-256 of its PKIX encoding, so the
+// same key has the same ID however its PEM is formatted.
+func KeyID(pub crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", fmt.Errorf("marshal public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Sign signs digest with signer and returns the base64 signature.
+func Sign(signer crypto.Signer, digest string) (string, error) {
+	var (
+		sig []byte
+		err error
+	)
+	switch signer.(type) {
+	case ed25519.PrivateKey:
+		sig, err = signer.Sign(rand.Reader, []byte(digest), crypto.Hash(0))
+	case *ecdsa.PrivateKey:
+		sum := sha256.Sum256([]byte(digest))
+		sig, err = signer.Sign(rand.Reader, sum[:], crypto.SHA256)
+	default:
+		return "", fmt.Errorf("unsupported signer type %T", signer)
+	}
+	if err != nil {
+		return "", fmt.Errorf("sign %s: %w", digest, err)
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// Verify checks the base64 signature of digest against pub. It returns an
+// error wrapping ErrInvalidSignature when the signature does not match.
+func Verify(pub crypto.PublicKey, digest, signature string) error {
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(signature))
+	if err != nil {
+		return fmt.Errorf("%w: not base64: %v", ErrInvalidSignature, err)
+	}
+	var ok bool
+	switch k := pub.(type) {
+	case ed25519.PublicKey:
+		ok = ed25519.Verify(k, []byte(digest), sig)
+	case *ecdsa.PublicKey:
+		sum := sha256.Sum256([]byte(digest))
+		ok = ecdsa.VerifyASN1(k, sum[:], sig)
+	default:
+		return fmt.Errorf("unsupported public key type %T", pub)
+	}
+	if !ok {
+		return fmt.Errorf("%w for %s", ErrInvalidSignature, digest)
+	}
+	return nil
+}
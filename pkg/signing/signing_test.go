@@ -0,0 +1,88 @@
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+)
+
+const testDigest = "sha256:3f1c6b2d4e5a69788796a5b4c3d2e1f00112233445566778899aabbccddeeff"
+
+func TestSignVerify(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edDER, err := x509.MarshalPKCS8PrivateKey(edKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, keyPEM := range map[string][]byte{
+		"ed25519": pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: edDER}),
+		"ecdsa":   pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			signer, err := ParsePrivateKey(keyPEM)
+			if err != nil {
+				t.Fatalf("ParsePrivateKey: %v", err)
+			}
+			pubPEM, err := MarshalPublicKey(signer.Public())
+			if err != nil {
+				t.Fatal(err)
+			}
+			pub, err := ParsePublicKey([]byte(pubPEM))
+			if err != nil {
+				t.Fatalf("ParsePublicKey: %v", err)
+			}
+			sig, err := Sign(signer, testDigest)
+			if err != nil {
+				t.Fatalf("Sign: %v", err)
+			}
+			if err := Verify(pub, testDigest, sig); err != nil {
+				t.Errorf("Verify: %v", err)
+			}
+			other := "sha256:" + testDigest[len(testDigest)-64:len(testDigest)-1] + "0"
+			if err := Verify(pub, other, sig); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("Verify(other digest) = %v, want ErrInvalidSignature", err)
+			}
+
+			id1, err := KeyID(pub)
+			if err != nil {
+				t.Fatal(err)
+			}
+			id2, _ := KeyID(signer.Public())
+			if id1 != id2 || len(id1) != 64 {
+				t.Errorf("KeyID = %q and %q, want the same 64-char hex ID", id1, id2)
+			}
+		})
+	}
+}
+
+func TestParsePrivateKey_RejectsEncrypted(t *testing.T) {
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED SIGSTORE PRIVATE KEY", Bytes: []byte("x")})
+	if _, err := ParsePrivateKey(keyPEM); err == nil {
+		t.Fatal("ParsePrivateKey accepted an encrypted key")
+	}
+}
+
+func TestParsePublicKey_RejectsGarbage(t *testing.T) {
+	for _, data := range []string{"", "not pem", "-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n"} {
+		if _, err := ParsePublicKey([]byte(data)); err == nil {
+			t.Errorf("ParsePublicKey(%q) succeeded", data)
+		}
+	}
+}
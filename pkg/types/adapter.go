@@ -154,10 +154,14 @@ type RemoveResult struct {
 // LogsInput selects a log stream for the deployed workload.
 type LogsInput struct {
 	Deployment *v1alpha1.Deployment
+	// Runtime is the resolved RuntimeRef, so adapters can reach the
+	// cluster/host the workload runs on.
+	Runtime *v1alpha1.Runtime
 	// Follow ⇒ stream indefinitely until ctx is cancelled. !Follow ⇒
 	// return the available backlog and close.
 	Follow bool
-	// TailLines bounds the initial backlog; 0 means unbounded.
+	// TailLines bounds the initial backlog; 0 means unbounded. Adapters
+	// that multiplex several workload streams apply it per stream.
 	TailLines int
 }
 
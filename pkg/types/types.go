@@ -4,6 +4,7 @@ package types
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"reflect"
 
@@ -26,7 +27,8 @@ type DatabaseFactory func(ctx context.Context, databaseURL string, baseStore dat
 // resource.AuthorizeInput field-for-field; declared here to keep
 // AppOptions free of internal-package imports.
 type AuthorizeInput struct {
-	// Verb is one of "get", "list", "apply", "delete".
+	// Verb is one of "get", "list", "apply", "delete", "sign", "approve",
+	// "deprecate", "yank".
 	Verb string
 	// Kind is the canonical Kind name (v1alpha1.KindAgent, etc.).
 	Kind string
@@ -71,6 +73,8 @@ const (
 	AdmissionSourceApply  = "apply"
 	AdmissionSourceDelete = "delete"
 	AdmissionSourceImport = "import"
+	// AdmissionSourceMCPSync labels servers the MCP registry mirror writes.
+	AdmissionSourceMCPSync = "mcp-sync"
 )
 
 // Admission owns the final write decision for an apply request after authz,
@@ -95,12 +99,26 @@ type AdmissionInput struct {
 	Store             any
 	PostUpsert        PostUpsert
 	InitialFinalizers func(v1alpha1.Object) []string
+	// ImmutableTag reports that the tag policy protects Tag; an existing
+	// tag may only be rewritten with identical content.
+	ImmutableTag bool
+	// OverwriteImmutableTag reports an authorized admin override of
+	// ImmutableTag.
+	OverwriteImmutableTag bool
+	// RequestApproval reports that the namespace approval policy covers the
+	// object: new content must be put up for review in the same write that
+	// stores it.
+	RequestApproval bool
 }
 
 type AdmissionResult struct {
-	Status     string
-	Tag        string
-	Generation int64
+	Status          string
+	Tag             string
+	Generation      int64
+	ResourceVersion string
+	// ApprovalState is the review state of the admitted content when
+	// RequestApproval was set.
+	ApprovalState string
 }
 
 // DeleteAdmission owns the final delete decision after authz has passed. The
@@ -122,8 +140,20 @@ type DeleteAdmissionInput struct {
 	Object     v1alpha1.Object
 	Store      any
 	PostDelete PostDelete
+	// Referrers lists the live objects that still reference the target.
+	// While it returns any, the delete is refused unless Force is set.
+	// Nil skips the check.
+	Referrers ReferrerLookup
+	// Force reports the caller's request (?force=true) to delete the
+	// target even though other objects reference it.
+	Force bool
 }
 
+// ReferrerLookup returns the live objects whose ResourceRefs select ref.
+// ref.Tag is the tag being deleted; empty means every tag of ref.Name (or
+// the single row of a mutable object).
+type ReferrerLookup func(ctx context.Context, ref v1alpha1.ResourceRef) ([]v1alpha1.ResourceRef, error)
+
 type DeleteAdmissionResult struct {
 	Status string
 	Tag    string
@@ -159,6 +189,56 @@ type Auditor interface {
 	ResourceTagCreated(ctx context.Context, kind, namespace, name, tag string)
 }
 
+// AuditRecorder is an optional interface an Auditor implements to receive
+// every entry of the audit log once it is committed: each create, update
+// and delete the v1alpha1 store makes, and each authorization denial. The
+// principal and request ID are available from ctx.
+type AuditRecorder interface {
+	Record(ctx context.Context, event AuditEvent)
+}
+
+// TagOverwriteAuditor is an optional interface an Auditor implements to
+// learn when Store.Upsert replaces the content of a tag the tag policy
+// protects, which only an explicit admin override allows. The principal is
+// available from ctx.
+type TagOverwriteAuditor interface {
+	ImmutableTagOverwritten(ctx context.Context, kind, namespace, name, tag string)
+}
+
+// Audit log verbs.
+const (
+	AuditVerbCreate      = "create"
+	AuditVerbUpdate      = "update"
+	AuditVerbDelete      = "delete"
+	AuditVerbDeny        = "deny"
+	AuditVerbSign        = "sign"
+	AuditVerbApprove     = "approve"
+	AuditVerbReject      = "reject"
+	AuditVerbDeprecate   = "deprecate"
+	AuditVerbUndeprecate = "undeprecate"
+	AuditVerbYank        = "yank"
+	AuditVerbUnyank      = "unyank"
+)
+
+// AuditEvent is one entry of the audit log.
+type AuditEvent struct {
+	// Verb is one of the AuditVerb constants.
+	Verb      string
+	Kind      string
+	Namespace string
+	Name      string
+	// Tag is set for tagged artifacts.
+	Tag string
+	// Diff is a JSON merge patch (RFC 7386) from the previous state to the
+	// new one: metadata.labels, metadata.annotations and spec for create
+	// and update. Empty for deletes and denials.
+	Diff json.RawMessage
+	// Reason explains denials and admin overrides, names the signing key
+	// of a sign event, carries the digest and comment of a review, and
+	// the message of a deprecation or yank.
+	Reason string
+}
+
 type noopAuditor struct{}
 
 func (noopAuditor) ResourceTagCreated(ctx context.Context, kind, namespace, name, tag string) {
@@ -200,6 +280,14 @@ type AppOptions struct {
 	// intent. Wins over the SKIP_MIGRATIONS env var when set true.
 	SkipMigrations bool
 
+	// Storage selects the storage backend, overriding the
+	// AGENT_REGISTRY_STORAGE env var when set: "postgres" connects to
+	// DATABASE_URL, and "sqlite:///path/to/registry.db" runs on an
+	// embedded SQLite file. SQLite storage cannot be combined with
+	// DatabaseFactory or V1Alpha1StoreTables, whose extensions migrate
+	// their own PostgreSQL tables.
+	Storage string
+
 	// RuntimeAdapters registers per-type PostUpsert/PostDelete
 	// hooks for the KindRuntime resource handler, keyed by the
 	// canonical CamelCase Runtime.Spec.Type ("BedrockAgentCore",
@@ -233,6 +321,15 @@ type AppOptions struct {
 	// the call, with API-level authn middleware still applying.
 	Authorizers map[string]Authorizer
 
+	// NamespaceAuthorizer gates every operation on every kind by the
+	// namespace it targets, ahead of the per-kind Authorizers. Use it for
+	// tenancy rules that do not depend on the kind ("members of team-a
+	// may only touch namespace team-a"). AuthorizeInput.Namespace is ""
+	// for cross-namespace lists; Namespace objects themselves arrive with
+	// Kind v1alpha1.KindNamespace and Name set to the namespace they
+	// describe. Nil admits every namespace.
+	NamespaceAuthorizer Authorizer
+
 	// ListFilters injects per-kind ExtraWhere predicates into
 	// list queries. Use this for row-level visibility (e.g. RBAC
 	// filtering: a reader without a grant for a given resource never
@@ -288,6 +385,12 @@ type AppOptions struct {
 	// another schema, qualify the value as "schema.table"; the schema
 	// segment must be a valid lowercase identifier (^[a-z_][a-z0-9_]*$)
 	// or server startup panics.
+	//
+	// Tables should carry the resource_version column and the
+	// stamp_resource_version trigger that the OSS tables gain in
+	// migration 016. A table without the column still serves reads and
+	// unconditional writes, but its objects carry no
+	// metadata.resourceVersion and conditional writes are refused.
 	V1Alpha1StoreTables map[string]string
 
 	// V1Alpha1MutableStoreKinds marks extra v1alpha1 kinds that use mutable
@@ -9,7 +9,8 @@ import (
 	"github.com/agentregistry-dev/agentregistry/pkg/types"
 )
 
-// ResourceTagEvent is one captured Auditor.ResourceTagCreated call.
+// ResourceTagEvent is one captured Auditor.ResourceTagCreated or
+// TagOverwriteAuditor.ImmutableTagOverwritten call.
 type ResourceTagEvent struct {
 	Kind      string
 	Namespace string
@@ -18,12 +19,15 @@ type ResourceTagEvent struct {
 }
 
 // RecordingAuditor is a thread-safe types.Auditor that captures every
-// ResourceTagCreated event for assertions in tests. The mutex is
+// ResourceTagCreated, ImmutableTagOverwritten and AuditRecorder.Record event for
+// assertions in tests. The mutex is
 // load-bearing because the v1alpha1store concurrency test invokes the
 // auditor from multiple goroutines.
 type RecordingAuditor struct {
-	mu     sync.Mutex
-	events []ResourceTagEvent
+	mu         sync.Mutex
+	events     []ResourceTagEvent
+	overwrites []ResourceTagEvent
+	records    []types.AuditEvent
 }
 
 // ResourceTagCreated records the event under the auditor's mutex.
@@ -38,6 +42,25 @@ func (r *RecordingAuditor) ResourceTagCreated(_ context.Context, kind, namespace
 	})
 }
 
+// ImmutableTagOverwritten records the event under the auditor's mutex.
+func (r *RecordingAuditor) ImmutableTagOverwritten(_ context.Context, kind, namespace, name, tag string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.overwrites = append(r.overwrites, ResourceTagEvent{
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      name,
+		Tag:       tag,
+	})
+}
+
+// Record records the audit log entry under the auditor's mutex.
+func (r *RecordingAuditor) Record(_ context.Context, event types.AuditEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, event)
+}
+
 // Events returns a copy of the captured events. Callers may mutate the
 // returned slice without affecting the auditor's internal state.
 func (r *RecordingAuditor) Events() []ResourceTagEvent {
@@ -48,4 +71,26 @@ func (r *RecordingAuditor) Events() []ResourceTagEvent {
 	return out
 }
 
-var _ types.Auditor = (*RecordingAuditor)(nil)
+// Overwrites returns a copy of the captured ImmutableTagOverwritten events.
+func (r *RecordingAuditor) Overwrites() []ResourceTagEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]ResourceTagEvent, len(r.overwrites))
+	copy(out, r.overwrites)
+	return out
+}
+
+// Records returns a copy of the captured audit log entries.
+func (r *RecordingAuditor) Records() []types.AuditEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]types.AuditEvent, len(r.records))
+	copy(out, r.records)
+	return out
+}
+
+var (
+	_ types.Auditor             = (*RecordingAuditor)(nil)
+	_ types.TagOverwriteAuditor = (*RecordingAuditor)(nil)
+	_ types.AuditRecorder       = (*RecordingAuditor)(nil)
+)